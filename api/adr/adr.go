@@ -0,0 +1,3 @@
+//go:generate protoc -I=. -I=../.. --go_out=paths=source_relative,plugins=grpc:. adr.proto
+
+package adr
@@ -0,0 +1,447 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: adr.proto
+
+package adr
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type HandleRequest struct {
+	// Device EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Device-profile ID.
+	DeviceProfileId []byte `protobuf:"bytes,2,opt,name=device_profile_id,json=deviceProfileId,proto3" json:"device_profile_id,omitempty"`
+	// Service-profile ID.
+	ServiceProfileId []byte `protobuf:"bytes,3,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
+	// Current data-rate.
+	Dr uint32 `protobuf:"varint,4,opt,name=dr,proto3" json:"dr,omitempty"`
+	// Current tx-power index.
+	TxPowerIndex uint32 `protobuf:"varint,5,opt,name=tx_power_index,json=txPowerIndex,proto3" json:"tx_power_index,omitempty"`
+	// Current number of transmissions (NbTrans).
+	NbTrans uint32 `protobuf:"varint,6,opt,name=nb_trans,json=nbTrans,proto3" json:"nb_trans,omitempty"`
+	// Min. data-rate (as configured in the service-profile).
+	MinDr uint32 `protobuf:"varint,7,opt,name=min_dr,json=minDr,proto3" json:"min_dr,omitempty"`
+	// Max. data-rate (as configured in the service-profile).
+	MaxDr uint32 `protobuf:"varint,8,opt,name=max_dr,json=maxDr,proto3" json:"max_dr,omitempty"`
+	// Min. tx-power index supported by the device (max. tx-power).
+	MinTxPowerIndex uint32 `protobuf:"varint,9,opt,name=min_tx_power_index,json=minTxPowerIndex,proto3" json:"min_tx_power_index,omitempty"`
+	// Max. tx-power index supported by the device (min. tx-power).
+	MaxTxPowerIndex uint32 `protobuf:"varint,10,opt,name=max_tx_power_index,json=maxTxPowerIndex,proto3" json:"max_tx_power_index,omitempty"`
+	// Target packet error rate in percent (as configured in the
+	// service-profile, 0 = not set).
+	TargetPer uint32 `protobuf:"varint,11,opt,name=target_per,json=targetPer,proto3" json:"target_per,omitempty"`
+	// Required SNR for the current data-rate.
+	RequiredSnrForDr float64 `protobuf:"fixed64,12,opt,name=required_snr_for_dr,json=requiredSnrForDr,proto3" json:"required_snr_for_dr,omitempty"`
+	// Configured installation margin.
+	InstallationMargin float64 `protobuf:"fixed64,13,opt,name=installation_margin,json=installationMargin,proto3" json:"installation_margin,omitempty"`
+	// Packet-loss percentage, based on the uplink history.
+	PacketLossPercentage float64 `protobuf:"fixed64,14,opt,name=packet_loss_percentage,json=packetLossPercentage,proto3" json:"packet_loss_percentage,omitempty"`
+	// Uplink history.
+	UplinkHistory        []*UplinkHistory `protobuf:"bytes,15,rep,name=uplink_history,json=uplinkHistory,proto3" json:"uplink_history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *HandleRequest) Reset()         { *m = HandleRequest{} }
+func (m *HandleRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleRequest) ProtoMessage()    {}
+func (*HandleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06647071f4073c32, []int{0}
+}
+
+func (m *HandleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleRequest.Unmarshal(m, b)
+}
+func (m *HandleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleRequest.Marshal(b, m, deterministic)
+}
+func (m *HandleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleRequest.Merge(m, src)
+}
+func (m *HandleRequest) XXX_Size() int {
+	return xxx_messageInfo_HandleRequest.Size(m)
+}
+func (m *HandleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleRequest proto.InternalMessageInfo
+
+func (m *HandleRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *HandleRequest) GetDeviceProfileId() []byte {
+	if m != nil {
+		return m.DeviceProfileId
+	}
+	return nil
+}
+
+func (m *HandleRequest) GetServiceProfileId() []byte {
+	if m != nil {
+		return m.ServiceProfileId
+	}
+	return nil
+}
+
+func (m *HandleRequest) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *HandleRequest) GetTxPowerIndex() uint32 {
+	if m != nil {
+		return m.TxPowerIndex
+	}
+	return 0
+}
+
+func (m *HandleRequest) GetNbTrans() uint32 {
+	if m != nil {
+		return m.NbTrans
+	}
+	return 0
+}
+
+func (m *HandleRequest) GetMinDr() uint32 {
+	if m != nil {
+		return m.MinDr
+	}
+	return 0
+}
+
+func (m *HandleRequest) GetMaxDr() uint32 {
+	if m != nil {
+		return m.MaxDr
+	}
+	return 0
+}
+
+func (m *HandleRequest) GetMinTxPowerIndex() uint32 {
+	if m != nil {
+		return m.MinTxPowerIndex
+	}
+	return 0
+}
+
+func (m *HandleRequest) GetMaxTxPowerIndex() uint32 {
+	if m != nil {
+		return m.MaxTxPowerIndex
+	}
+	return 0
+}
+
+func (m *HandleRequest) GetTargetPer() uint32 {
+	if m != nil {
+		return m.TargetPer
+	}
+	return 0
+}
+
+func (m *HandleRequest) GetRequiredSnrForDr() float64 {
+	if m != nil {
+		return m.RequiredSnrForDr
+	}
+	return 0
+}
+
+func (m *HandleRequest) GetInstallationMargin() float64 {
+	if m != nil {
+		return m.InstallationMargin
+	}
+	return 0
+}
+
+func (m *HandleRequest) GetPacketLossPercentage() float64 {
+	if m != nil {
+		return m.PacketLossPercentage
+	}
+	return 0
+}
+
+func (m *HandleRequest) GetUplinkHistory() []*UplinkHistory {
+	if m != nil {
+		return m.UplinkHistory
+	}
+	return nil
+}
+
+type UplinkHistory struct {
+	// Frame-counter.
+	FCnt uint32 `protobuf:"varint,1,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Max. SNR (of all receiving gateways).
+	MaxSnr float64 `protobuf:"fixed64,2,opt,name=max_snr,json=maxSnr,proto3" json:"max_snr,omitempty"`
+	// SNR margin.
+	// This is the max. SNR minus the required SNR for the current data-rate
+	// and the installation margin.
+	SnrMargin float64 `protobuf:"fixed64,3,opt,name=snr_margin,json=snrMargin,proto3" json:"snr_margin,omitempty"`
+	// TX-power index used for the uplink.
+	TxPowerIndex uint32 `protobuf:"varint,4,opt,name=tx_power_index,json=txPowerIndex,proto3" json:"tx_power_index,omitempty"`
+	// Number of receiving gateways.
+	GatewayCount         uint32   `protobuf:"varint,5,opt,name=gateway_count,json=gatewayCount,proto3" json:"gateway_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UplinkHistory) Reset()         { *m = UplinkHistory{} }
+func (m *UplinkHistory) String() string { return proto.CompactTextString(m) }
+func (*UplinkHistory) ProtoMessage()    {}
+func (*UplinkHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06647071f4073c32, []int{1}
+}
+
+func (m *UplinkHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UplinkHistory.Unmarshal(m, b)
+}
+func (m *UplinkHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UplinkHistory.Marshal(b, m, deterministic)
+}
+func (m *UplinkHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UplinkHistory.Merge(m, src)
+}
+func (m *UplinkHistory) XXX_Size() int {
+	return xxx_messageInfo_UplinkHistory.Size(m)
+}
+func (m *UplinkHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_UplinkHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UplinkHistory proto.InternalMessageInfo
+
+func (m *UplinkHistory) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *UplinkHistory) GetMaxSnr() float64 {
+	if m != nil {
+		return m.MaxSnr
+	}
+	return 0
+}
+
+func (m *UplinkHistory) GetSnrMargin() float64 {
+	if m != nil {
+		return m.SnrMargin
+	}
+	return 0
+}
+
+func (m *UplinkHistory) GetTxPowerIndex() uint32 {
+	if m != nil {
+		return m.TxPowerIndex
+	}
+	return 0
+}
+
+func (m *UplinkHistory) GetGatewayCount() uint32 {
+	if m != nil {
+		return m.GatewayCount
+	}
+	return 0
+}
+
+type HandleResponse struct {
+	// Data-rate.
+	Dr uint32 `protobuf:"varint,1,opt,name=dr,proto3" json:"dr,omitempty"`
+	// TX-power index.
+	TxPowerIndex uint32 `protobuf:"varint,2,opt,name=tx_power_index,json=txPowerIndex,proto3" json:"tx_power_index,omitempty"`
+	// Number of transmissions (NbTrans).
+	NbTrans              uint32   `protobuf:"varint,3,opt,name=nb_trans,json=nbTrans,proto3" json:"nb_trans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HandleResponse) Reset()         { *m = HandleResponse{} }
+func (m *HandleResponse) String() string { return proto.CompactTextString(m) }
+func (*HandleResponse) ProtoMessage()    {}
+func (*HandleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06647071f4073c32, []int{2}
+}
+
+func (m *HandleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleResponse.Unmarshal(m, b)
+}
+func (m *HandleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleResponse.Marshal(b, m, deterministic)
+}
+func (m *HandleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleResponse.Merge(m, src)
+}
+func (m *HandleResponse) XXX_Size() int {
+	return xxx_messageInfo_HandleResponse.Size(m)
+}
+func (m *HandleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleResponse proto.InternalMessageInfo
+
+func (m *HandleResponse) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *HandleResponse) GetTxPowerIndex() uint32 {
+	if m != nil {
+		return m.TxPowerIndex
+	}
+	return 0
+}
+
+func (m *HandleResponse) GetNbTrans() uint32 {
+	if m != nil {
+		return m.NbTrans
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*HandleRequest)(nil), "adr.HandleRequest")
+	proto.RegisterType((*UplinkHistory)(nil), "adr.UplinkHistory")
+	proto.RegisterType((*HandleResponse)(nil), "adr.HandleResponse")
+}
+
+func init() { proto.RegisterFile("adr.proto", fileDescriptor_06647071f4073c32) }
+
+var fileDescriptor_06647071f4073c32 = []byte{
+	// 523 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0x5d, 0x6f, 0xd3, 0x3c,
+	0x14, 0xc7, 0x9f, 0xb4, 0x5d, 0xb7, 0x9e, 0x2d, 0xdd, 0x1e, 0x97, 0x97, 0x80, 0x84, 0x54, 0x15,
+	0x2e, 0x2a, 0x5e, 0x86, 0xb4, 0x71, 0xc3, 0x25, 0x5a, 0x81, 0x4d, 0x02, 0xa9, 0xca, 0xc6, 0xb5,
+	0xe5, 0xd6, 0x6e, 0xb1, 0x96, 0x1e, 0x67, 0xc7, 0x4e, 0x97, 0x7d, 0x22, 0x3e, 0x0c, 0x5f, 0x0a,
+	0xd9, 0x49, 0xa1, 0x2d, 0x08, 0x2e, 0xfd, 0xff, 0xff, 0x22, 0xdb, 0x27, 0x3f, 0x43, 0x47, 0x48,
+	0x3a, 0xce, 0xc9, 0x38, 0xc3, 0x9a, 0x42, 0xd2, 0xe0, 0x7b, 0x0b, 0xe2, 0x73, 0x81, 0x32, 0x53,
+	0xa9, 0xba, 0x29, 0x94, 0x75, 0xec, 0x21, 0xec, 0x4a, 0xb5, 0xe4, 0xaa, 0xd0, 0x49, 0xd4, 0x8f,
+	0x86, 0x07, 0x69, 0x5b, 0xaa, 0xe5, 0xfb, 0x42, 0xb3, 0xe7, 0xf0, 0xbf, 0x54, 0x4b, 0x3d, 0x55,
+	0x3c, 0x27, 0x33, 0xd3, 0x99, 0xe2, 0x5a, 0x26, 0x8d, 0x80, 0x1c, 0x56, 0xc5, 0xb8, 0xca, 0x2f,
+	0x24, 0x7b, 0x09, 0xcc, 0x2a, 0xda, 0x86, 0x9b, 0x01, 0x3e, 0xaa, 0x9b, 0x5f, 0x74, 0x17, 0x1a,
+	0x92, 0x92, 0x56, 0x3f, 0x1a, 0xc6, 0x69, 0x43, 0x12, 0x7b, 0x06, 0x5d, 0x57, 0xf2, 0xdc, 0xdc,
+	0x2a, 0xe2, 0x1a, 0xa5, 0x2a, 0x93, 0x9d, 0xd0, 0x1d, 0xb8, 0x72, 0xec, 0xc3, 0x0b, 0x9f, 0xb1,
+	0x47, 0xb0, 0x87, 0x13, 0xee, 0x48, 0xa0, 0x4d, 0xda, 0xa1, 0xdf, 0xc5, 0xc9, 0x95, 0x5f, 0xb2,
+	0xfb, 0xd0, 0x5e, 0x68, 0xe4, 0x92, 0x92, 0xdd, 0x50, 0xec, 0x2c, 0x34, 0x8e, 0x28, 0xc4, 0xa2,
+	0xf4, 0xf1, 0x5e, 0x1d, 0x8b, 0x72, 0x44, 0xec, 0x05, 0x30, 0x4f, 0x6f, 0x6d, 0xd9, 0x09, 0xc8,
+	0xe1, 0x42, 0xe3, 0xd5, 0xfa, 0xae, 0x1e, 0x16, 0xe5, 0x36, 0x0c, 0x35, 0x2c, 0xca, 0x0d, 0xf8,
+	0x09, 0x80, 0x13, 0x34, 0x57, 0x8e, 0xe7, 0x8a, 0x92, 0xfd, 0x00, 0x75, 0xaa, 0x64, 0xac, 0x88,
+	0xbd, 0x82, 0x1e, 0xa9, 0x9b, 0x42, 0x93, 0x92, 0xdc, 0x22, 0xf1, 0x99, 0x21, 0x7f, 0xb8, 0x83,
+	0x7e, 0x34, 0x8c, 0xd2, 0xa3, 0x55, 0x75, 0x89, 0xf4, 0xc1, 0xd0, 0x88, 0xd8, 0x6b, 0xe8, 0x69,
+	0xb4, 0x4e, 0x64, 0x99, 0x70, 0xda, 0x20, 0x5f, 0x08, 0x9a, 0x6b, 0x4c, 0xe2, 0x80, 0xb3, 0xf5,
+	0xea, 0x73, 0x68, 0xd8, 0x1b, 0x78, 0x90, 0x8b, 0xe9, 0xb5, 0x72, 0x3c, 0x33, 0xd6, 0xfa, 0x33,
+	0x4c, 0x15, 0x3a, 0x31, 0x57, 0x49, 0x37, 0x7c, 0x73, 0xaf, 0x6a, 0x3f, 0x19, 0x6b, 0xc7, 0x3f,
+	0x3b, 0xf6, 0x16, 0xba, 0x45, 0x9e, 0x69, 0xbc, 0xe6, 0x5f, 0xb5, 0x75, 0x86, 0xee, 0x92, 0xc3,
+	0x7e, 0x73, 0xb8, 0x7f, 0xc2, 0x8e, 0xbd, 0x3b, 0x5f, 0x42, 0x75, 0x5e, 0x35, 0x69, 0x5c, 0xac,
+	0x2f, 0x07, 0xdf, 0x22, 0x88, 0x37, 0x00, 0xd6, 0x83, 0x9d, 0x19, 0x9f, 0xa2, 0x0b, 0x2e, 0xc5,
+	0x69, 0x6b, 0x76, 0x86, 0x41, 0x31, 0x3f, 0x43, 0x8b, 0x14, 0xfc, 0x89, 0x52, 0xff, 0x5b, 0x2e,
+	0x91, 0xfc, 0xbc, 0xfc, 0x1c, 0xea, 0x8b, 0x35, 0x43, 0xd7, 0xb1, 0x48, 0xf5, 0x7d, 0x7e, 0xf7,
+	0xa2, 0xf5, 0x07, 0x2f, 0x9e, 0x42, 0x3c, 0x17, 0x4e, 0xdd, 0x8a, 0x3b, 0x3e, 0x35, 0x05, 0xba,
+	0x95, 0x3c, 0x75, 0x78, 0xe6, 0xb3, 0x81, 0x80, 0xee, 0x4a, 0x7b, 0x9b, 0x1b, 0xb4, 0xaa, 0x96,
+	0x30, 0xfa, 0x8b, 0x84, 0x8d, 0x7f, 0x48, 0xd8, 0xdc, 0x90, 0xf0, 0xe4, 0x23, 0x1c, 0xbd, 0x1b,
+	0xa5, 0xe3, 0xac, 0x98, 0x6b, 0xbc, 0xac, 0x94, 0x67, 0xa7, 0xd0, 0xae, 0xb6, 0x65, 0xd5, 0x34,
+	0x37, 0x9e, 0xde, 0xe3, 0xde, 0x46, 0x56, 0x9d, 0x6b, 0xf0, 0xdf, 0xa4, 0x1d, 0xde, 0xeb, 0xe9,
+	0x8f, 0x01, 0x00, 0xb8, 0xa5, 0x67, 0x62, 0xbc, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ADRPluginServiceClient is the client API for ADRPluginService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ADRPluginServiceClient interface {
+	// Handle returns the data-rate, tx-power index and nb-trans to set on
+	// the device using the LinkADRReq mac-command.
+	Handle(ctx context.Context, in *HandleRequest, opts ...grpc.CallOption) (*HandleResponse, error)
+}
+
+type aDRPluginServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewADRPluginServiceClient(cc *grpc.ClientConn) ADRPluginServiceClient {
+	return &aDRPluginServiceClient{cc}
+}
+
+func (c *aDRPluginServiceClient) Handle(ctx context.Context, in *HandleRequest, opts ...grpc.CallOption) (*HandleResponse, error) {
+	out := new(HandleResponse)
+	err := c.cc.Invoke(ctx, "/adr.ADRPluginService/Handle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ADRPluginServiceServer is the server API for ADRPluginService service.
+type ADRPluginServiceServer interface {
+	// Handle returns the data-rate, tx-power index and nb-trans to set on
+	// the device using the LinkADRReq mac-command.
+	Handle(context.Context, *HandleRequest) (*HandleResponse, error)
+}
+
+func RegisterADRPluginServiceServer(s *grpc.Server, srv ADRPluginServiceServer) {
+	s.RegisterService(&_ADRPluginService_serviceDesc, srv)
+}
+
+func _ADRPluginService_Handle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ADRPluginServiceServer).Handle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adr.ADRPluginService/Handle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ADRPluginServiceServer).Handle(ctx, req.(*HandleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ADRPluginService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "adr.ADRPluginService",
+	HandlerType: (*ADRPluginServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Handle",
+			Handler:    _ADRPluginService_Handle_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "adr.proto",
+}
@@ -54,12 +54,13 @@ func (RXWindow) EnumDescriptor() ([]byte, []int) {
 type ErrorType int32
 
 const (
-	ErrorType_GENERIC                ErrorType = 0
-	ErrorType_OTAA                   ErrorType = 1
-	ErrorType_DATA_UP_FCNT           ErrorType = 2
-	ErrorType_DATA_UP_MIC            ErrorType = 3
-	ErrorType_DEVICE_QUEUE_ITEM_SIZE ErrorType = 4
-	ErrorType_DEVICE_QUEUE_ITEM_FCNT ErrorType = 5
+	ErrorType_GENERIC                  ErrorType = 0
+	ErrorType_OTAA                     ErrorType = 1
+	ErrorType_DATA_UP_FCNT             ErrorType = 2
+	ErrorType_DATA_UP_MIC              ErrorType = 3
+	ErrorType_DEVICE_QUEUE_ITEM_SIZE   ErrorType = 4
+	ErrorType_DEVICE_QUEUE_ITEM_FCNT   ErrorType = 5
+	ErrorType_DATA_UP_MIN_GW_DIVERSITY ErrorType = 6
 )
 
 var ErrorType_name = map[int32]string{
@@ -69,15 +70,17 @@ var ErrorType_name = map[int32]string{
 	3: "DATA_UP_MIC",
 	4: "DEVICE_QUEUE_ITEM_SIZE",
 	5: "DEVICE_QUEUE_ITEM_FCNT",
+	6: "DATA_UP_MIN_GW_DIVERSITY",
 }
 
 var ErrorType_value = map[string]int32{
-	"GENERIC":                0,
-	"OTAA":                   1,
-	"DATA_UP_FCNT":           2,
-	"DATA_UP_MIC":            3,
-	"DEVICE_QUEUE_ITEM_SIZE": 4,
-	"DEVICE_QUEUE_ITEM_FCNT": 5,
+	"GENERIC":                  0,
+	"OTAA":                     1,
+	"DATA_UP_FCNT":             2,
+	"DATA_UP_MIC":              3,
+	"DEVICE_QUEUE_ITEM_SIZE":   4,
+	"DEVICE_QUEUE_ITEM_FCNT":   5,
+	"DATA_UP_MIN_GW_DIVERSITY": 6,
 }
 
 func (x ErrorType) String() string {
@@ -161,9 +164,20 @@ type HandleUplinkDataRequest struct {
 	// This field is only set on the first uplink frame when the security
 	// context has changed (e.g. a new OTAA (re)activation).
 	DeviceActivationContext *DeviceActivationContext `protobuf:"bytes,10,opt,name=device_activation_context,json=deviceActivationContext,proto3" json:"device_activation_context,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{}                 `json:"-"`
-	XXX_unrecognized        []byte                   `json:"-"`
-	XXX_sizecache           int32                    `json:"-"`
+	// Uplink rate-limit exceeded.
+	//
+	// This is set when the uplink rate-limit of the service-profile has been
+	// exceeded and the uplink rate-policy is set to Mark.
+	UlRateLimitExceeded bool `protobuf:"varint,11,opt,name=ul_rate_limit_exceeded,json=ulRateLimitExceeded,proto3" json:"ul_rate_limit_exceeded,omitempty"`
+	// Min. gateway diversity not met.
+	//
+	// This is set when the uplink was received by less gateways than the
+	// min. gateway diversity of the service-profile and the min. gateway
+	// diversity policy is set to Mark.
+	MinGwDiversityNotMet bool     `protobuf:"varint,12,opt,name=min_gw_diversity_not_met,json=minGwDiversityNotMet,proto3" json:"min_gw_diversity_not_met,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *HandleUplinkDataRequest) Reset()         { *m = HandleUplinkDataRequest{} }
@@ -261,6 +275,20 @@ func (m *HandleUplinkDataRequest) GetDeviceActivationContext() *DeviceActivation
 	return nil
 }
 
+func (m *HandleUplinkDataRequest) GetUlRateLimitExceeded() bool {
+	if m != nil {
+		return m.UlRateLimitExceeded
+	}
+	return false
+}
+
+func (m *HandleUplinkDataRequest) GetMinGwDiversityNotMet() bool {
+	if m != nil {
+		return m.MinGwDiversityNotMet
+	}
+	return false
+}
+
 type HandleProprietaryUplinkRequest struct {
 	// MACPayload of the proprietary LoRaWAN frame.
 	MacPayload []byte `protobuf:"bytes,1,opt,name=mac_payload,json=macPayload,proto3" json:"mac_payload,omitempty"`
@@ -453,6 +481,74 @@ func (m *HandleDownlinkACKRequest) GetAcknowledged() bool {
 	return false
 }
 
+type HandleTxAckRequest struct {
+	// Device EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Downlink frame-counter.
+	FCnt uint32 `protobuf:"varint,2,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Gateway ID (8 bytes) of the gateway which reported the acknowledgement.
+	GatewayId []byte `protobuf:"bytes,3,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Error code reported by the gateway (e.g. TOO_LATE). This field is empty
+	// when the downlink was transmitted.
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HandleTxAckRequest) Reset()         { *m = HandleTxAckRequest{} }
+func (m *HandleTxAckRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleTxAckRequest) ProtoMessage()    {}
+func (*HandleTxAckRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_426943aecdb4a493, []int{5}
+}
+
+func (m *HandleTxAckRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleTxAckRequest.Unmarshal(m, b)
+}
+func (m *HandleTxAckRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleTxAckRequest.Marshal(b, m, deterministic)
+}
+func (m *HandleTxAckRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleTxAckRequest.Merge(m, src)
+}
+func (m *HandleTxAckRequest) XXX_Size() int {
+	return xxx_messageInfo_HandleTxAckRequest.Size(m)
+}
+func (m *HandleTxAckRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleTxAckRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleTxAckRequest proto.InternalMessageInfo
+
+func (m *HandleTxAckRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *HandleTxAckRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *HandleTxAckRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *HandleTxAckRequest) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type SetDeviceStatusRequest struct {
 	// Device EUI (8 bytes).
 	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
@@ -479,7 +575,7 @@ func (m *SetDeviceStatusRequest) Reset()         { *m = SetDeviceStatusRequest{}
 func (m *SetDeviceStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*SetDeviceStatusRequest) ProtoMessage()    {}
 func (*SetDeviceStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_426943aecdb4a493, []int{5}
+	return fileDescriptor_426943aecdb4a493, []int{6}
 }
 
 func (m *SetDeviceStatusRequest) XXX_Unmarshal(b []byte) error {
@@ -556,7 +652,7 @@ func (m *SetDeviceLocationRequest) Reset()         { *m = SetDeviceLocationReque
 func (m *SetDeviceLocationRequest) String() string { return proto.CompactTextString(m) }
 func (*SetDeviceLocationRequest) ProtoMessage()    {}
 func (*SetDeviceLocationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_426943aecdb4a493, []int{6}
+	return fileDescriptor_426943aecdb4a493, []int{7}
 }
 
 func (m *SetDeviceLocationRequest) XXX_Unmarshal(b []byte) error {
@@ -617,7 +713,7 @@ func (m *HandleGatewayStatsRequest) Reset()         { *m = HandleGatewayStatsReq
 func (m *HandleGatewayStatsRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleGatewayStatsRequest) ProtoMessage()    {}
 func (*HandleGatewayStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_426943aecdb4a493, []int{7}
+	return fileDescriptor_426943aecdb4a493, []int{8}
 }
 
 func (m *HandleGatewayStatsRequest) XXX_Unmarshal(b []byte) error {
@@ -694,6 +790,64 @@ func (m *HandleGatewayStatsRequest) GetTxPacketsEmitted() uint32 {
 	return 0
 }
 
+type HandleGatewayStateChangeRequest struct {
+	// Gateway ID (8 bytes).
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Gateway state.
+	State common.GatewayState `protobuf:"varint,2,opt,name=state,proto3,enum=common.GatewayState" json:"state,omitempty"`
+	// Timestamp of the state change.
+	ChangedAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *HandleGatewayStateChangeRequest) Reset()         { *m = HandleGatewayStateChangeRequest{} }
+func (m *HandleGatewayStateChangeRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleGatewayStateChangeRequest) ProtoMessage()    {}
+func (*HandleGatewayStateChangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_426943aecdb4a493, []int{9}
+}
+
+func (m *HandleGatewayStateChangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleGatewayStateChangeRequest.Unmarshal(m, b)
+}
+func (m *HandleGatewayStateChangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleGatewayStateChangeRequest.Marshal(b, m, deterministic)
+}
+func (m *HandleGatewayStateChangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleGatewayStateChangeRequest.Merge(m, src)
+}
+func (m *HandleGatewayStateChangeRequest) XXX_Size() int {
+	return xxx_messageInfo_HandleGatewayStateChangeRequest.Size(m)
+}
+func (m *HandleGatewayStateChangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleGatewayStateChangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleGatewayStateChangeRequest proto.InternalMessageInfo
+
+func (m *HandleGatewayStateChangeRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *HandleGatewayStateChangeRequest) GetState() common.GatewayState {
+	if m != nil {
+		return m.State
+	}
+	return common.GatewayState_NEVER_SEEN
+}
+
+func (m *HandleGatewayStateChangeRequest) GetChangedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ChangedAt
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("as.RXWindow", RXWindow_name, RXWindow_value)
 	proto.RegisterEnum("as.ErrorType", ErrorType_name, ErrorType_value)
@@ -702,80 +856,94 @@ func init() {
 	proto.RegisterType((*HandleProprietaryUplinkRequest)(nil), "as.HandleProprietaryUplinkRequest")
 	proto.RegisterType((*HandleErrorRequest)(nil), "as.HandleErrorRequest")
 	proto.RegisterType((*HandleDownlinkACKRequest)(nil), "as.HandleDownlinkACKRequest")
+	proto.RegisterType((*HandleTxAckRequest)(nil), "as.HandleTxAckRequest")
 	proto.RegisterType((*SetDeviceStatusRequest)(nil), "as.SetDeviceStatusRequest")
 	proto.RegisterType((*SetDeviceLocationRequest)(nil), "as.SetDeviceLocationRequest")
 	proto.RegisterType((*HandleGatewayStatsRequest)(nil), "as.HandleGatewayStatsRequest")
+	proto.RegisterType((*HandleGatewayStateChangeRequest)(nil), "as.HandleGatewayStateChangeRequest")
 }
 
 func init() { proto.RegisterFile("as.proto", fileDescriptor_426943aecdb4a493) }
 
 var fileDescriptor_426943aecdb4a493 = []byte{
-	// 1040 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x4d, 0x73, 0xdb, 0x36,
-	0x10, 0x8d, 0xbe, 0xe5, 0xb5, 0x1d, 0x33, 0x70, 0x23, 0xd1, 0x4a, 0xd2, 0xb8, 0xea, 0xc5, 0xcd,
-	0x64, 0xe4, 0xa9, 0x73, 0xeb, 0xa5, 0xa3, 0x91, 0x59, 0x57, 0xe3, 0x38, 0x51, 0x29, 0xb9, 0xf6,
-	0xf4, 0x82, 0x81, 0xc9, 0x95, 0x86, 0x15, 0x45, 0xb0, 0x10, 0xf4, 0x35, 0x9d, 0xfe, 0xa0, 0x1e,
-	0xfb, 0x87, 0xfa, 0x33, 0x3a, 0x3d, 0x76, 0x00, 0x42, 0x1f, 0xb6, 0x64, 0xb9, 0x17, 0x89, 0xd8,
-	0xf7, 0xb8, 0xbb, 0x78, 0x58, 0x3c, 0x42, 0x91, 0x0d, 0x6b, 0xb1, 0xe0, 0x92, 0x93, 0x34, 0x1b,
-	0x56, 0x5e, 0xf5, 0x38, 0xef, 0x85, 0x78, 0xaa, 0x23, 0x77, 0xa3, 0xee, 0x29, 0x0e, 0x62, 0x39,
-	0x4b, 0x08, 0x95, 0xb7, 0x0f, 0x41, 0x19, 0x0c, 0x70, 0x28, 0xd9, 0x20, 0x36, 0x84, 0x32, 0x8b,
-	0x83, 0x53, 0x8f, 0x0f, 0x06, 0x3c, 0x32, 0x7f, 0x06, 0x38, 0x50, 0x40, 0x6f, 0x72, 0xda, 0x9b,
-	0x24, 0x81, 0x2a, 0x42, 0xf9, 0x1c, 0xc7, 0x81, 0x87, 0x75, 0x4f, 0x06, 0x63, 0x26, 0x03, 0x1e,
-	0x35, 0x78, 0x24, 0x71, 0x2a, 0xc9, 0x11, 0x14, 0x7d, 0x1c, 0x53, 0xe6, 0xfb, 0xc2, 0x4e, 0x1d,
-	0xa7, 0x4e, 0xf6, 0xdc, 0x82, 0x8f, 0xe3, 0xba, 0xef, 0x0b, 0x72, 0x0a, 0x3b, 0x2c, 0x8e, 0xe9,
-	0x90, 0xf6, 0x71, 0x66, 0xa7, 0x8f, 0x53, 0x27, 0xbb, 0x67, 0x87, 0x35, 0x53, 0xe8, 0x12, 0x67,
-	0x4e, 0x34, 0xc6, 0x90, 0xc7, 0xe8, 0x16, 0x58, 0x1c, 0xb7, 0x2f, 0x71, 0x56, 0xfd, 0x3b, 0x0d,
-	0xe5, 0x1f, 0x59, 0xe4, 0x87, 0x78, 0x1d, 0x87, 0x41, 0xd4, 0x3f, 0x67, 0x92, 0xb9, 0xf8, 0xdb,
-	0x08, 0x87, 0x92, 0x94, 0x41, 0xe5, 0xa5, 0x38, 0x0a, 0x4c, 0x99, 0xbc, 0x8f, 0x63, 0x67, 0x14,
-	0xa8, 0x06, 0x7e, 0xe5, 0x41, 0xa4, 0x91, 0x74, 0xd2, 0x80, 0x5a, 0x2b, 0xe8, 0x10, 0x72, 0x5d,
-	0xea, 0x45, 0xd2, 0xce, 0x1c, 0xa7, 0x4e, 0xf6, 0xdd, 0x6c, 0xb7, 0x11, 0x49, 0xf2, 0x12, 0xf2,
-	0x5d, 0x1a, 0x73, 0x21, 0xed, 0xac, 0x8e, 0xe6, 0xba, 0x2d, 0x2e, 0x24, 0xb1, 0x20, 0xc3, 0x7c,
-	0x61, 0xe7, 0x8e, 0x53, 0x27, 0x45, 0x57, 0x3d, 0x92, 0xe7, 0x90, 0xf6, 0x85, 0x9d, 0xd7, 0xa4,
-	0xb4, 0x2f, 0xc8, 0x37, 0x50, 0x90, 0x53, 0x1a, 0x44, 0x5d, 0x6e, 0x17, 0xf4, 0x66, 0xac, 0x5a,
-	0x6f, 0x52, 0x4b, 0x3a, 0xed, 0xdc, 0x36, 0xa3, 0x2e, 0x77, 0xf3, 0x72, 0xaa, 0xfe, 0x15, 0x55,
-	0x18, 0x6a, 0xf1, 0x38, 0x73, 0x9f, 0xea, 0x1a, 0xaa, 0x48, 0xa8, 0x04, 0xb2, 0x3e, 0x93, 0xcc,
-	0xde, 0xd1, 0xad, 0xeb, 0x67, 0x72, 0x03, 0x47, 0xbe, 0x96, 0x9b, 0xb2, 0x85, 0xde, 0xd4, 0x4b,
-	0x04, 0xb7, 0x41, 0xd7, 0x7e, 0x55, 0x63, 0xc3, 0xda, 0x23, 0x67, 0xe2, 0x96, 0xfd, 0xcd, 0x40,
-	0xf5, 0xcf, 0x14, 0x7c, 0x99, 0x08, 0xdc, 0x12, 0x3c, 0x16, 0x01, 0x4a, 0x26, 0x66, 0xa6, 0x2d,
-	0xa3, 0xf3, 0x5b, 0xd8, 0x1d, 0x30, 0x8f, 0xc6, 0x6c, 0x16, 0x72, 0xe6, 0x1b, 0xad, 0x61, 0xc0,
-	0xbc, 0x56, 0x12, 0x51, 0x42, 0x0d, 0x02, 0xcf, 0x48, 0xad, 0x1e, 0x57, 0x85, 0xc9, 0xfc, 0x7f,
-	0x61, 0xb2, 0xdb, 0x85, 0xa9, 0xfe, 0x0e, 0x24, 0x69, 0xd5, 0x11, 0x82, 0x8b, 0x27, 0xc7, 0xe0,
-	0x2b, 0xc8, 0xca, 0x59, 0x8c, 0xba, 0x83, 0xe7, 0x67, 0xfb, 0x4a, 0x1e, 0xfd, 0x62, 0x67, 0x16,
-	0xa3, 0xab, 0x21, 0xf2, 0x05, 0xe4, 0x50, 0x85, 0xf4, 0xc1, 0xef, 0xb8, 0xc9, 0x62, 0x39, 0x24,
-	0xb9, 0xe5, 0x90, 0x54, 0x43, 0xb0, 0x93, 0xe2, 0xe7, 0x7c, 0x12, 0xa9, 0xe6, 0xea, 0x8d, 0xcb,
-	0x27, 0x5b, 0x58, 0x64, 0x4a, 0xaf, 0x8c, 0x5b, 0x15, 0xf6, 0x98, 0xd7, 0x8f, 0xf8, 0x24, 0x44,
-	0xbf, 0x87, 0xbe, 0xee, 0xaf, 0xe8, 0xde, 0x8b, 0x55, 0xff, 0x4d, 0x41, 0xa9, 0x8d, 0x32, 0x39,
-	0xce, 0xb6, 0x64, 0x72, 0x34, 0x7c, 0xb2, 0x98, 0x0d, 0x85, 0x3b, 0x26, 0x25, 0x8a, 0x99, 0x29,
-	0x37, 0x5f, 0x92, 0x12, 0xe4, 0x07, 0x4c, 0xf4, 0x82, 0x48, 0xd7, 0xca, 0xb9, 0x66, 0x45, 0xce,
-	0xe0, 0x25, 0x4e, 0x25, 0x8a, 0x88, 0x85, 0x34, 0xe6, 0x13, 0x14, 0x74, 0xc8, 0x47, 0xc2, 0x43,
-	0x2d, 0x47, 0xd1, 0x3d, 0x9c, 0x83, 0x2d, 0x85, 0xb5, 0x35, 0x44, 0xbe, 0x83, 0x23, 0x93, 0x96,
-	0x86, 0x38, 0xc6, 0x90, 0x8e, 0x22, 0x36, 0x66, 0x41, 0xc8, 0xee, 0x42, 0x34, 0x77, 0xa5, 0x6c,
-	0x08, 0x1f, 0x15, 0x7e, 0xbd, 0x84, 0xc9, 0xd7, 0xb0, 0x7f, 0xef, 0x5d, 0x7d, 0x95, 0xd2, 0xee,
-	0xde, 0x2a, 0xbf, 0xca, 0xc0, 0x5e, 0xec, 0xfc, 0x23, 0xf7, 0xf4, 0xb4, 0x3e, 0xb9, 0xf7, 0xf7,
-	0x50, 0x0c, 0x0d, 0xd7, 0xf8, 0x8a, 0x35, 0xf7, 0x95, 0x45, 0x8e, 0x05, 0xa3, 0xfa, 0x4f, 0x1a,
-	0x8e, 0x92, 0xc3, 0xbc, 0x60, 0x12, 0x27, 0x6c, 0xa6, 0x14, 0x5e, 0x08, 0xfc, 0x06, 0xa0, 0x97,
-	0x84, 0x69, 0x30, 0x1f, 0xf7, 0x1d, 0x13, 0x69, 0xfa, 0xca, 0x5d, 0x86, 0x8a, 0xae, 0x40, 0xe3,
-	0x2e, 0x7a, 0xdd, 0xf4, 0x49, 0x0d, 0xb2, 0xca, 0x51, 0xcd, 0xcc, 0x57, 0x6a, 0x89, 0xdd, 0xd6,
-	0xe6, 0x76, 0x5b, 0xeb, 0xcc, 0xed, 0xd6, 0xd5, 0xbc, 0x7b, 0x5d, 0x67, 0x9f, 0xea, 0x9a, 0xd4,
-	0xe0, 0x50, 0x4c, 0x69, 0xcc, 0xbc, 0x3e, 0xca, 0x21, 0x15, 0xe8, 0x61, 0x30, 0x46, 0xdf, 0x0c,
-	0xe9, 0x0b, 0x31, 0x6d, 0x25, 0x88, 0x6b, 0x00, 0xf2, 0x01, 0x4a, 0x1b, 0xf8, 0x94, 0xf7, 0x8d,
-	0x83, 0x1d, 0xae, 0xbd, 0xf2, 0xb9, 0xaf, 0x8a, 0xc8, 0x0d, 0x45, 0x0a, 0x49, 0x11, 0xb9, 0x56,
-	0xe4, 0x3d, 0x90, 0x15, 0x3e, 0x0e, 0x02, 0x29, 0xd1, 0xb7, 0x8b, 0x9a, 0x6e, 0x2d, 0xe8, 0x4e,
-	0x12, 0x7f, 0xf7, 0x1a, 0x8a, 0xee, 0xed, 0x4d, 0x10, 0xf9, 0x7c, 0x42, 0x0a, 0x90, 0x71, 0x6f,
-	0xbf, 0xb5, 0x9e, 0x25, 0x0f, 0x67, 0x56, 0xea, 0xdd, 0x1f, 0xb0, 0xb3, 0xb8, 0xa0, 0x64, 0x17,
-	0x0a, 0x17, 0xce, 0x27, 0xc7, 0x6d, 0x36, 0xac, 0x67, 0xa4, 0x08, 0xd9, 0xcf, 0x9d, 0x7a, 0xdd,
-	0x4a, 0x11, 0x0b, 0xf6, 0xce, 0xeb, 0x9d, 0x3a, 0xbd, 0x6e, 0xd1, 0x1f, 0x1a, 0x9f, 0x3a, 0x56,
-	0x9a, 0x1c, 0xc0, 0xee, 0x3c, 0x72, 0xd5, 0x6c, 0x58, 0x19, 0x52, 0x81, 0xd2, 0xb9, 0xf3, 0x73,
-	0xb3, 0xe1, 0xd0, 0x9f, 0xae, 0x9d, 0x6b, 0x87, 0x36, 0x3b, 0xce, 0x15, 0x6d, 0x37, 0x7f, 0x71,
-	0xac, 0xec, 0x66, 0x4c, 0x27, 0xca, 0x9d, 0xfd, 0x95, 0x05, 0xbb, 0x1e, 0xc7, 0x61, 0x90, 0xe8,
-	0xdd, 0x46, 0x31, 0x46, 0xa1, 0x7e, 0x03, 0x0f, 0x49, 0x13, 0xac, 0x87, 0xdf, 0x21, 0xa2, 0x1d,
-	0xf7, 0x91, 0xaf, 0x53, 0xa5, 0xb4, 0x76, 0xfa, 0x8e, 0xfa, 0x12, 0x57, 0x9f, 0x91, 0x9b, 0xf9,
-	0x27, 0x6d, 0xcd, 0x71, 0x49, 0x75, 0x99, 0xf1, 0x31, 0x3b, 0xde, 0x92, 0xf8, 0x7b, 0xd8, 0x5d,
-	0xf1, 0x47, 0x52, 0x5a, 0x26, 0x5b, 0x35, 0xcc, 0x2d, 0x09, 0x2e, 0xe1, 0xc5, 0x9a, 0xc7, 0x91,
-	0xd7, 0xcb, 0x34, 0xeb, 0xd6, 0xb7, 0x25, 0xd9, 0xd5, 0xdc, 0xad, 0x57, 0xef, 0x18, 0x79, 0xb3,
-	0xcc, 0xb6, 0xe1, 0xee, 0x6d, 0x49, 0x77, 0x01, 0x07, 0x0f, 0x0c, 0x91, 0x54, 0x54, 0xae, 0xcd,
-	0x2e, 0xb9, 0x7d, 0x93, 0x6b, 0xfe, 0x92, 0x6c, 0xf2, 0x31, 0xdb, 0x79, 0x3c, 0xd9, 0x5d, 0x5e,
-	0x47, 0x3e, 0xfc, 0x17, 0x00, 0x00, 0xff, 0xff, 0x8b, 0x2c, 0x5f, 0xde, 0x85, 0x09, 0x00, 0x00,
+	// 1227 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xdb, 0x6e, 0xdb, 0x46,
+	0x13, 0x8e, 0x64, 0x9d, 0x3c, 0x76, 0x12, 0x66, 0x9d, 0x58, 0xb4, 0x93, 0xfc, 0xf1, 0xcf, 0xdc,
+	0xb8, 0x41, 0x20, 0xa3, 0x0e, 0x50, 0xa0, 0xbd, 0x29, 0x04, 0x89, 0x75, 0x05, 0xc7, 0x8e, 0x4b,
+	0xc9, 0x71, 0xd2, 0x9b, 0xc5, 0x9a, 0x1c, 0xa9, 0xac, 0x78, 0xea, 0x6a, 0x75, 0x42, 0x1f, 0xa5,
+	0x0f, 0x50, 0xf4, 0x59, 0x8a, 0xbe, 0x4e, 0xd1, 0xcb, 0x62, 0x97, 0xab, 0x83, 0xad, 0x83, 0x8d,
+	0xde, 0x48, 0xe4, 0xcc, 0xb7, 0xdf, 0xcc, 0xce, 0xb7, 0x3b, 0x43, 0x28, 0xb1, 0x5e, 0x25, 0xe1,
+	0xb1, 0x88, 0x49, 0x96, 0xf5, 0xf6, 0x9f, 0x77, 0xe2, 0xb8, 0x13, 0xe0, 0x91, 0xb2, 0x5c, 0xf7,
+	0xdb, 0x47, 0x18, 0x26, 0x62, 0x9c, 0x02, 0xf6, 0x5f, 0xdd, 0x76, 0x0a, 0x3f, 0xc4, 0x9e, 0x60,
+	0x61, 0xa2, 0x01, 0x65, 0x96, 0xf8, 0x47, 0x6e, 0x1c, 0x86, 0x71, 0xa4, 0xff, 0xb4, 0xe3, 0xb1,
+	0x74, 0x74, 0x86, 0x47, 0x9d, 0x61, 0x6a, 0xb0, 0x10, 0xca, 0x75, 0x1c, 0xf8, 0x2e, 0x56, 0x5d,
+	0xe1, 0x0f, 0x98, 0xf0, 0xe3, 0xa8, 0x16, 0x47, 0x02, 0x47, 0x82, 0xec, 0x41, 0xc9, 0xc3, 0x01,
+	0x65, 0x9e, 0xc7, 0xcd, 0xcc, 0x41, 0xe6, 0x70, 0xdb, 0x29, 0x7a, 0x38, 0xa8, 0x7a, 0x1e, 0x27,
+	0x47, 0xb0, 0xc9, 0x92, 0x84, 0xf6, 0x68, 0x17, 0xc7, 0x66, 0xf6, 0x20, 0x73, 0xb8, 0x75, 0xbc,
+	0x53, 0xd1, 0x81, 0x4e, 0x71, 0x6c, 0x47, 0x03, 0x0c, 0xe2, 0x04, 0x9d, 0x22, 0x4b, 0x92, 0xe6,
+	0x29, 0x8e, 0xad, 0x3f, 0x37, 0xa0, 0xfc, 0x3d, 0x8b, 0xbc, 0x00, 0x2f, 0x93, 0xc0, 0x8f, 0xba,
+	0x75, 0x26, 0x98, 0x83, 0xbf, 0xf4, 0xb1, 0x27, 0x48, 0x19, 0x24, 0x2f, 0xc5, 0xbe, 0xaf, 0xc3,
+	0x14, 0x3c, 0x1c, 0xd8, 0x7d, 0x5f, 0x26, 0xf0, 0x73, 0xec, 0x47, 0xca, 0x93, 0x4d, 0x13, 0x90,
+	0xef, 0xd2, 0xb5, 0x03, 0xf9, 0x36, 0x75, 0x23, 0x61, 0x6e, 0x1c, 0x64, 0x0e, 0x1f, 0x3a, 0xb9,
+	0x76, 0x2d, 0x12, 0xe4, 0x19, 0x14, 0xda, 0x34, 0x89, 0xb9, 0x30, 0x73, 0xca, 0x9a, 0x6f, 0x5f,
+	0xc4, 0x5c, 0x10, 0x03, 0x36, 0x98, 0xc7, 0xcd, 0xfc, 0x41, 0xe6, 0xb0, 0xe4, 0xc8, 0x47, 0xf2,
+	0x08, 0xb2, 0x1e, 0x37, 0x0b, 0x0a, 0x94, 0xf5, 0x38, 0xf9, 0x02, 0x8a, 0x62, 0x44, 0xfd, 0xa8,
+	0x1d, 0x9b, 0x45, 0xb5, 0x19, 0xa3, 0xd2, 0x19, 0x56, 0xd2, 0x4c, 0x5b, 0x9f, 0x1a, 0x51, 0x3b,
+	0x76, 0x0a, 0x62, 0x24, 0xff, 0x25, 0x94, 0x6b, 0x68, 0xe9, 0x60, 0xe3, 0x26, 0xd4, 0xd1, 0x50,
+	0x9e, 0x42, 0x09, 0xe4, 0x3c, 0x26, 0x98, 0xb9, 0xa9, 0x52, 0x57, 0xcf, 0xe4, 0x0a, 0xf6, 0x3c,
+	0x55, 0x6e, 0xca, 0xa6, 0xf5, 0xa6, 0x6e, 0x5a, 0x70, 0x13, 0x54, 0xec, 0xe7, 0x15, 0xd6, 0xab,
+	0xac, 0xd0, 0xc4, 0x29, 0x7b, 0x2b, 0xc4, 0x7a, 0x07, 0xbb, 0xfd, 0x80, 0x72, 0x26, 0x90, 0x06,
+	0x7e, 0xe8, 0x0b, 0x8a, 0x23, 0x17, 0xd1, 0x43, 0xcf, 0xdc, 0x52, 0xfb, 0xde, 0xe9, 0x07, 0x0e,
+	0x13, 0xf8, 0x5e, 0xfa, 0x6c, 0xed, 0x22, 0x5f, 0x81, 0x19, 0xfa, 0x11, 0xed, 0x0c, 0xa9, 0xe7,
+	0x0f, 0x90, 0xf7, 0x7c, 0x31, 0xa6, 0x51, 0x2c, 0x68, 0x88, 0xc2, 0xdc, 0x56, 0xcb, 0x9e, 0x86,
+	0x7e, 0x74, 0x32, 0xac, 0x4f, 0xbc, 0xe7, 0xb1, 0x38, 0x43, 0x61, 0xfd, 0x91, 0x81, 0xff, 0xa5,
+	0x6a, 0x5e, 0xf0, 0x38, 0xe1, 0x3e, 0x0a, 0xc6, 0xc7, 0xba, 0x06, 0x5a, 0xd4, 0x57, 0xb0, 0x15,
+	0x32, 0x97, 0x26, 0x6c, 0x1c, 0xc4, 0xcc, 0xd3, 0xc2, 0x42, 0xc8, 0xdc, 0x8b, 0xd4, 0x22, 0x55,
+	0x09, 0x7d, 0x57, 0xeb, 0x2a, 0x1f, 0xe7, 0x55, 0xd8, 0xb8, 0xbf, 0x0a, 0xb9, 0xf5, 0x2a, 0x58,
+	0xbf, 0x02, 0x49, 0x53, 0xb5, 0x39, 0x8f, 0xf9, 0x9d, 0x67, 0xee, 0xff, 0x90, 0x13, 0xe3, 0x04,
+	0x55, 0x06, 0x8f, 0x8e, 0x1f, 0x4a, 0x2d, 0xd4, 0xc2, 0xd6, 0x38, 0x41, 0x47, 0xb9, 0xc8, 0x53,
+	0xc8, 0xa3, 0x34, 0xa9, 0x53, 0xb6, 0xe9, 0xa4, 0x2f, 0xb3, 0x13, 0x99, 0x9f, 0x9d, 0x48, 0x2b,
+	0x00, 0x33, 0x0d, 0x5e, 0x8f, 0x87, 0x91, 0x4c, 0xae, 0x5a, 0x3b, 0xbd, 0x33, 0x85, 0x29, 0x53,
+	0x76, 0xee, 0x6c, 0x5b, 0xb0, 0xcd, 0xdc, 0x6e, 0x14, 0x0f, 0x03, 0xf4, 0x3a, 0xe8, 0xa9, 0xfc,
+	0x4a, 0xce, 0x0d, 0x9b, 0x35, 0x9c, 0x6c, 0xb5, 0x35, 0xaa, 0xba, 0xdd, 0xff, 0x16, 0xe7, 0x25,
+	0x40, 0x87, 0x09, 0x1c, 0xb2, 0x31, 0xf5, 0xd3, 0x28, 0xdb, 0xce, 0xa6, 0xb6, 0x34, 0xbc, 0xe5,
+	0x7b, 0xb7, 0xfe, 0xc9, 0xc0, 0x6e, 0x13, 0x45, 0x7a, 0x68, 0x9b, 0x82, 0x89, 0x7e, 0xef, 0xce,
+	0xe8, 0x26, 0x14, 0xaf, 0x99, 0x10, 0xc8, 0xc7, 0x3a, 0xfe, 0xe4, 0x95, 0xec, 0x42, 0x21, 0x64,
+	0xbc, 0xe3, 0x47, 0x2a, 0x7c, 0xde, 0xd1, 0x6f, 0xe4, 0x18, 0x9e, 0xe1, 0x48, 0x20, 0x8f, 0x58,
+	0x40, 0x93, 0x78, 0x88, 0x9c, 0xf6, 0xe2, 0x3e, 0x77, 0x51, 0xe5, 0x52, 0x72, 0x76, 0x26, 0xce,
+	0x0b, 0xe9, 0x6b, 0x2a, 0x17, 0xf9, 0x06, 0xf6, 0x34, 0x2d, 0x0d, 0x70, 0x80, 0x01, 0xed, 0x47,
+	0x6c, 0xc0, 0xfc, 0x80, 0x5d, 0x07, 0xa8, 0x3b, 0x42, 0x59, 0x03, 0xde, 0x4b, 0xff, 0xe5, 0xcc,
+	0x4d, 0x5e, 0xc3, 0xc3, 0x1b, 0x6b, 0x55, 0xc3, 0xc8, 0x3a, 0xdb, 0xf3, 0x78, 0x8b, 0x81, 0x39,
+	0xdd, 0xf9, 0xfb, 0xd8, 0x55, 0x77, 0xf2, 0xce, 0xbd, 0xbf, 0x85, 0x52, 0xa0, 0xb1, 0xba, 0x7b,
+	0x1a, 0x93, 0xee, 0x39, 0xe5, 0x98, 0x22, 0xac, 0xbf, 0xb3, 0xb0, 0x97, 0xea, 0x7a, 0x92, 0xea,
+	0x20, 0x2b, 0x3c, 0x2d, 0xf0, 0x4d, 0xc1, 0x32, 0xb7, 0x05, 0xdb, 0x83, 0x52, 0x4f, 0xc2, 0xa5,
+	0x53, 0xf7, 0x50, 0xf5, 0xde, 0xf0, 0x48, 0x05, 0x72, 0x72, 0x6e, 0xe8, 0xcb, 0xb6, 0x5f, 0x49,
+	0x87, 0x4a, 0x65, 0x32, 0x54, 0x2a, 0xad, 0xc9, 0x50, 0x71, 0x14, 0xee, 0x46, 0xd6, 0xb9, 0xbb,
+	0xb2, 0x26, 0x15, 0xd8, 0xe1, 0x23, 0x9a, 0x30, 0xb7, 0x8b, 0xa2, 0x47, 0x39, 0xba, 0xe8, 0x0f,
+	0xd0, 0xd3, 0xb7, 0xe3, 0x09, 0x1f, 0x5d, 0xa4, 0x1e, 0x47, 0x3b, 0x64, 0x03, 0x5b, 0x82, 0xa7,
+	0x71, 0x57, 0xf7, 0xe9, 0x9d, 0x85, 0x25, 0x1f, 0xba, 0x32, 0x88, 0x58, 0x12, 0xa4, 0x98, 0x06,
+	0x11, 0x0b, 0x41, 0xde, 0x02, 0x99, 0xc3, 0x63, 0xe8, 0x0b, 0x81, 0x9e, 0x59, 0x52, 0x70, 0x63,
+	0x0a, 0xb7, 0x53, 0xbb, 0xf5, 0x7b, 0x06, 0x5e, 0x2d, 0x14, 0x1e, 0x6b, 0x3f, 0xb1, 0xa8, 0x83,
+	0xf7, 0x2c, 0xff, 0x1b, 0xc8, 0xcb, 0x72, 0xa3, 0xaa, 0xfd, 0xa3, 0xe3, 0xa7, 0x93, 0x82, 0xcd,
+	0x13, 0x3a, 0x29, 0x84, 0x7c, 0x0d, 0xe0, 0x2a, 0x6e, 0x8f, 0x32, 0x71, 0x0f, 0x55, 0x36, 0x35,
+	0xba, 0x2a, 0xde, 0xbc, 0x80, 0x92, 0xf3, 0xe9, 0xca, 0x8f, 0xbc, 0x78, 0x48, 0x8a, 0xb0, 0xe1,
+	0x7c, 0xfa, 0xd2, 0x78, 0x90, 0x3e, 0x1c, 0x1b, 0x99, 0x37, 0xbf, 0x65, 0x60, 0x73, 0xda, 0xc4,
+	0xc8, 0x16, 0x14, 0x4f, 0xec, 0x73, 0xdb, 0x69, 0xd4, 0x8c, 0x07, 0xa4, 0x04, 0xb9, 0x0f, 0xad,
+	0x6a, 0xd5, 0xc8, 0x10, 0x03, 0xb6, 0xeb, 0xd5, 0x56, 0x95, 0x5e, 0x5e, 0xd0, 0xef, 0x6a, 0xe7,
+	0x2d, 0x23, 0x4b, 0x1e, 0xc3, 0xd6, 0xc4, 0x72, 0xd6, 0xa8, 0x19, 0x1b, 0x64, 0x1f, 0x76, 0xeb,
+	0xf6, 0xc7, 0x46, 0xcd, 0xa6, 0x3f, 0x5c, 0xda, 0x97, 0x36, 0x6d, 0xb4, 0xec, 0x33, 0xda, 0x6c,
+	0xfc, 0x68, 0x1b, 0xb9, 0xe5, 0x3e, 0x45, 0x94, 0x27, 0x2f, 0xc0, 0x9c, 0x11, 0x9d, 0xd3, 0x93,
+	0x2b, 0x5a, 0x6f, 0x7c, 0xb4, 0x9d, 0x66, 0xa3, 0xf5, 0xd9, 0x28, 0x1c, 0xff, 0x95, 0x07, 0xb3,
+	0x9a, 0x24, 0x81, 0x9f, 0x1e, 0x9c, 0x26, 0xf2, 0x01, 0x72, 0xf9, 0xeb, 0xbb, 0x48, 0x1a, 0x60,
+	0xdc, 0xfe, 0x6c, 0x20, 0x6a, 0x40, 0xae, 0xf8, 0x98, 0xd8, 0xdf, 0x5d, 0x28, 0x98, 0x2d, 0x3f,
+	0x9c, 0xac, 0x07, 0xe4, 0x0a, 0xca, 0x2b, 0x66, 0x16, 0xb1, 0x66, 0x8c, 0xab, 0x06, 0xda, 0x1a,
+	0xe2, 0x6f, 0x61, 0x6b, 0x6e, 0xc2, 0x90, 0xdd, 0x19, 0xd9, 0xfc, 0xc8, 0x59, 0x43, 0x70, 0x0a,
+	0x4f, 0x16, 0xa6, 0x04, 0x79, 0x31, 0xa3, 0x59, 0x1c, 0x1e, 0xf7, 0xc9, 0x46, 0x0d, 0x81, 0xf9,
+	0x6c, 0xe6, 0xa7, 0xc2, 0x1a, 0x82, 0xb3, 0xc9, 0x14, 0x99, 0xef, 0x36, 0xe4, 0xe5, 0x8c, 0x67,
+	0x49, 0x17, 0x5a, 0x43, 0xf7, 0x19, 0xcc, 0x85, 0x65, 0xfa, 0x0e, 0x91, 0xd7, 0x4b, 0x49, 0x6f,
+	0xde, 0xb0, 0x35, 0xd4, 0x27, 0xf0, 0xf8, 0xd6, 0xd4, 0x21, 0xfb, 0x92, 0x71, 0xf9, 0x28, 0x5a,
+	0x2f, 0xc0, 0x42, 0x13, 0x4f, 0x05, 0x58, 0xd5, 0xdb, 0x57, 0x93, 0x5d, 0x17, 0x94, 0xe5, 0xdd,
+	0xbf, 0x03, 0x00, 0xee, 0x2d, 0x5e, 0x71, 0xd0, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -798,8 +966,15 @@ type ApplicationServerServiceClient interface {
 	HandleError(ctx context.Context, in *HandleErrorRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// HandleDownlinkACK handles a downlink ACK or nACK response.
 	HandleDownlinkACK(ctx context.Context, in *HandleDownlinkACKRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// HandleTxAck handles the transmission acknowledgement of a downlink
+	// (as reported by the gateway). In case the downlink could not be
+	// transmitted, the error field contains the error code.
+	HandleTxAck(ctx context.Context, in *HandleTxAckRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// HandleGatewayStats handles the given gateway stats.
 	HandleGatewayStats(ctx context.Context, in *HandleGatewayStatsRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// HandleGatewayStateChange handles a gateway connection-state change
+	// (e.g. the gateway went offline).
+	HandleGatewayStateChange(ctx context.Context, in *HandleGatewayStateChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// SetDeviceStatus updates the device-status for a device.
 	SetDeviceStatus(ctx context.Context, in *SetDeviceStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// SetDeviceLocation updates the device-location for a device.
@@ -850,6 +1025,15 @@ func (c *applicationServerServiceClient) HandleDownlinkACK(ctx context.Context,
 	return out, nil
 }
 
+func (c *applicationServerServiceClient) HandleTxAck(ctx context.Context, in *HandleTxAckRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/as.ApplicationServerService/HandleTxAck", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *applicationServerServiceClient) HandleGatewayStats(ctx context.Context, in *HandleGatewayStatsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/as.ApplicationServerService/HandleGatewayStats", in, out, opts...)
@@ -859,6 +1043,15 @@ func (c *applicationServerServiceClient) HandleGatewayStats(ctx context.Context,
 	return out, nil
 }
 
+func (c *applicationServerServiceClient) HandleGatewayStateChange(ctx context.Context, in *HandleGatewayStateChangeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/as.ApplicationServerService/HandleGatewayStateChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *applicationServerServiceClient) SetDeviceStatus(ctx context.Context, in *SetDeviceStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/as.ApplicationServerService/SetDeviceStatus", in, out, opts...)
@@ -887,8 +1080,15 @@ type ApplicationServerServiceServer interface {
 	HandleError(context.Context, *HandleErrorRequest) (*empty.Empty, error)
 	// HandleDownlinkACK handles a downlink ACK or nACK response.
 	HandleDownlinkACK(context.Context, *HandleDownlinkACKRequest) (*empty.Empty, error)
+	// HandleTxAck handles the transmission acknowledgement of a downlink
+	// (as reported by the gateway). In case the downlink could not be
+	// transmitted, the error field contains the error code.
+	HandleTxAck(context.Context, *HandleTxAckRequest) (*empty.Empty, error)
 	// HandleGatewayStats handles the given gateway stats.
 	HandleGatewayStats(context.Context, *HandleGatewayStatsRequest) (*empty.Empty, error)
+	// HandleGatewayStateChange handles a gateway connection-state change
+	// (e.g. the gateway went offline).
+	HandleGatewayStateChange(context.Context, *HandleGatewayStateChangeRequest) (*empty.Empty, error)
 	// SetDeviceStatus updates the device-status for a device.
 	SetDeviceStatus(context.Context, *SetDeviceStatusRequest) (*empty.Empty, error)
 	// SetDeviceLocation updates the device-location for a device.
@@ -971,6 +1171,24 @@ func _ApplicationServerService_HandleDownlinkACK_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationServerService_HandleTxAck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleTxAckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServerServiceServer).HandleTxAck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/as.ApplicationServerService/HandleTxAck",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServerServiceServer).HandleTxAck(ctx, req.(*HandleTxAckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApplicationServerService_HandleGatewayStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HandleGatewayStatsRequest)
 	if err := dec(in); err != nil {
@@ -989,6 +1207,24 @@ func _ApplicationServerService_HandleGatewayStats_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationServerService_HandleGatewayStateChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleGatewayStateChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServerServiceServer).HandleGatewayStateChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/as.ApplicationServerService/HandleGatewayStateChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServerServiceServer).HandleGatewayStateChange(ctx, req.(*HandleGatewayStateChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApplicationServerService_SetDeviceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetDeviceStatusRequest)
 	if err := dec(in); err != nil {
@@ -1045,10 +1281,18 @@ var _ApplicationServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "HandleDownlinkACK",
 			Handler:    _ApplicationServerService_HandleDownlinkACK_Handler,
 		},
+		{
+			MethodName: "HandleTxAck",
+			Handler:    _ApplicationServerService_HandleTxAck_Handler,
+		},
 		{
 			MethodName: "HandleGatewayStats",
 			Handler:    _ApplicationServerService_HandleGatewayStats_Handler,
 		},
+		{
+			MethodName: "HandleGatewayStateChange",
+			Handler:    _ApplicationServerService_HandleGatewayStateChange_Handler,
+		},
 		{
 			MethodName: "SetDeviceStatus",
 			Handler:    _ApplicationServerService_SetDeviceStatus_Handler,
@@ -106,6 +106,37 @@ func (Region) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_555bd8c177793206, []int{1}
 }
 
+type GatewayState int32
+
+const (
+	// The gateway has never been seen.
+	GatewayState_NEVER_SEEN GatewayState = 0
+	// The gateway is online.
+	GatewayState_ONLINE GatewayState = 1
+	// The gateway is offline.
+	GatewayState_OFFLINE GatewayState = 2
+)
+
+var GatewayState_name = map[int32]string{
+	0: "NEVER_SEEN",
+	1: "ONLINE",
+	2: "OFFLINE",
+}
+
+var GatewayState_value = map[string]int32{
+	"NEVER_SEEN": 0,
+	"ONLINE":     1,
+	"OFFLINE":    2,
+}
+
+func (x GatewayState) String() string {
+	return proto.EnumName(GatewayState_name, int32(x))
+}
+
+func (GatewayState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{2}
+}
+
 type LocationSource int32
 
 const (
@@ -138,7 +169,7 @@ func (x LocationSource) String() string {
 }
 
 func (LocationSource) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{2}
+	return fileDescriptor_555bd8c177793206, []int{3}
 }
 
 type KeyEnvelope struct {
@@ -312,6 +343,7 @@ func (m *ContextDetails) GetContextId() []byte {
 func init() {
 	proto.RegisterEnum("common.Modulation", Modulation_name, Modulation_value)
 	proto.RegisterEnum("common.Region", Region_name, Region_value)
+	proto.RegisterEnum("common.GatewayState", GatewayState_name, GatewayState_value)
 	proto.RegisterEnum("common.LocationSource", LocationSource_name, LocationSource_value)
 	proto.RegisterType((*KeyEnvelope)(nil), "common.KeyEnvelope")
 	proto.RegisterType((*Location)(nil), "common.Location")
@@ -321,33 +353,35 @@ func init() {
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 440 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x52, 0x4b, 0x6f, 0xda, 0x40,
-	0x10, 0x8e, 0x79, 0x18, 0x7b, 0x42, 0xd1, 0x6a, 0x0f, 0x2d, 0xea, 0x43, 0x45, 0x9c, 0x10, 0x52,
-	0x70, 0x8a, 0x79, 0x1e, 0x53, 0xe2, 0x20, 0x64, 0x6a, 0x57, 0x6b, 0xb9, 0x95, 0x7a, 0x41, 0x8b,
-	0xd9, 0x52, 0xcb, 0x8e, 0x17, 0xf9, 0x41, 0xeb, 0xff, 0xd4, 0x1f, 0x59, 0xad, 0x97, 0xa4, 0xca,
-	0xed, 0x7b, 0xcd, 0xec, 0xcc, 0x68, 0xa1, 0x1d, 0xf0, 0xc7, 0x47, 0x9e, 0x8c, 0x4e, 0x29, 0xcf,
-	0x39, 0x56, 0x25, 0xeb, 0xaf, 0xe0, 0xda, 0x66, 0xa5, 0x95, 0x9c, 0x59, 0xcc, 0x4f, 0x0c, 0xbf,
-	0x03, 0x3d, 0x62, 0xd1, 0x2e, 0xa6, 0x7b, 0x16, 0x77, 0x95, 0x9e, 0x32, 0xd0, 0x89, 0x16, 0xb1,
-	0x68, 0x2b, 0x38, 0x7e, 0x03, 0x2d, 0xca, 0xb2, 0x5d, 0xc4, 0xca, 0x6e, 0xad, 0xa7, 0x0c, 0xda,
-	0x44, 0xa5, 0x2c, 0xb3, 0x59, 0xd9, 0xff, 0xab, 0x80, 0xb6, 0xe5, 0x01, 0xcd, 0x43, 0x9e, 0xe0,
-	0xb7, 0xa0, 0xc5, 0x34, 0x0f, 0xf3, 0xe2, 0xc0, 0xaa, 0x0e, 0x0a, 0x79, 0xe6, 0xf8, 0x3d, 0xe8,
-	0x31, 0x4f, 0x8e, 0xd2, 0xac, 0x55, 0xe6, 0x7f, 0x41, 0x54, 0xd2, 0xf8, 0x52, 0x59, 0x97, 0x95,
-	0x4f, 0x1c, 0x8f, 0x40, 0xcd, 0x78, 0x91, 0x06, 0xac, 0xdb, 0xe8, 0x29, 0x83, 0xce, 0xf8, 0xf5,
-	0xe8, 0xb2, 0xce, 0xd3, 0xbb, 0x5e, 0xe5, 0x92, 0x4b, 0xaa, 0xea, 0x15, 0x04, 0x45, 0x4a, 0x83,
-	0xb2, 0xdb, 0xec, 0x29, 0x83, 0x57, 0xe4, 0x99, 0xf7, 0x0d, 0xe8, 0xac, 0x78, 0x92, 0xb3, 0x3f,
-	0xf9, 0x3d, 0xcb, 0x69, 0x18, 0x67, 0xf8, 0x03, 0x40, 0x20, 0x95, 0x5d, 0x78, 0xa8, 0xa6, 0x6e,
-	0x13, 0xfd, 0xa2, 0x6c, 0x0e, 0xc3, 0x8f, 0x00, 0x5f, 0xf8, 0xa1, 0x88, 0xe5, 0x82, 0x1a, 0x34,
-	0xb6, 0x2e, 0xb9, 0x43, 0x57, 0xb8, 0x05, 0xf5, 0x07, 0xcf, 0x46, 0xca, 0xf0, 0x0c, 0x2a, 0x61,
-	0x47, 0x61, 0xea, 0xd0, 0xb4, 0xfc, 0xc5, 0x6c, 0x81, 0xae, 0x04, 0xf4, 0xbd, 0xe5, 0xa7, 0x29,
-	0xaa, 0x09, 0xb8, 0x72, 0xe6, 0xf3, 0x25, 0xaa, 0xcb, 0xc0, 0xc4, 0x34, 0x51, 0x43, 0xc0, 0x3b,
-	0x5f, 0x04, 0x9a, 0x32, 0x30, 0x99, 0xdf, 0x22, 0xb5, 0x52, 0xbd, 0xe5, 0xd8, 0x44, 0x2d, 0x01,
-	0x6d, 0xb2, 0x1c, 0xdf, 0x22, 0x4d, 0xc0, 0x8d, 0xb3, 0x98, 0x4d, 0x91, 0x2e, 0x20, 0xf1, 0x17,
-	0xb3, 0x09, 0x82, 0xe1, 0x3d, 0x74, 0x5e, 0xee, 0x8f, 0xaf, 0xa1, 0xe5, 0x3b, 0xb6, 0xe3, 0x7e,
-	0x77, 0xe4, 0x7c, 0xeb, 0xaf, 0x1e, 0x52, 0x30, 0x80, 0xba, 0x72, 0x9d, 0x87, 0xcd, 0x1a, 0xd5,
-	0x30, 0x82, 0xf6, 0xda, 0x72, 0x77, 0xc4, 0xf2, 0xdc, 0xed, 0x37, 0x8b, 0xa0, 0xfa, 0xe7, 0xe9,
-	0x0f, 0xf3, 0x18, 0xe6, 0xbf, 0x8a, 0xbd, 0xb8, 0xa9, 0xb1, 0x4f, 0x79, 0x40, 0x69, 0x6a, 0xc4,
-	0x3c, 0x0d, 0x7f, 0x96, 0x37, 0x09, 0xcb, 0x7f, 0xf3, 0x34, 0xba, 0xc9, 0x58, 0x7a, 0x66, 0xa9,
-	0x41, 0x4f, 0xa1, 0x21, 0x2f, 0xbf, 0x57, 0xab, 0x9f, 0x64, 0xfe, 0x0b, 0x00, 0x00, 0xff, 0xff,
-	0x3c, 0xc5, 0x7d, 0x3d, 0x59, 0x02, 0x00, 0x00,
+	// 477 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x52, 0x5b, 0x6f, 0xda, 0x30,
+	0x14, 0x26, 0x5c, 0x02, 0x39, 0x65, 0xc8, 0xf2, 0xc3, 0x86, 0x76, 0xd1, 0x10, 0x4f, 0x08, 0xa9,
+	0xd0, 0x01, 0xe5, 0xf2, 0xd8, 0xd1, 0x80, 0x10, 0x2c, 0x99, 0x1c, 0xd1, 0x49, 0x7b, 0x41, 0xc6,
+	0x1c, 0xd1, 0x28, 0x69, 0x8c, 0x12, 0x43, 0xc7, 0x7f, 0xda, 0x8f, 0x9c, 0x9c, 0xd0, 0x4e, 0x7d,
+	0xfb, 0x6e, 0xe7, 0xd8, 0xc7, 0xc7, 0x50, 0x15, 0xf2, 0xe9, 0x49, 0x46, 0x9d, 0x43, 0x2c, 0x95,
+	0xa4, 0x66, 0xc6, 0x9a, 0x53, 0xb8, 0x5a, 0xe2, 0xd9, 0x8e, 0x4e, 0x18, 0xca, 0x03, 0xd2, 0x4f,
+	0x60, 0x05, 0x18, 0x6c, 0x42, 0xbe, 0xc5, 0xb0, 0x6e, 0x34, 0x8c, 0x96, 0xc5, 0x2a, 0x01, 0x06,
+	0x2b, 0xcd, 0xe9, 0x07, 0x28, 0x73, 0x4c, 0x36, 0x01, 0x9e, 0xeb, 0xf9, 0x86, 0xd1, 0xaa, 0x32,
+	0x93, 0x63, 0xb2, 0xc4, 0x73, 0xf3, 0xaf, 0x01, 0x95, 0x95, 0x14, 0x5c, 0xf9, 0x32, 0xa2, 0x1f,
+	0xa1, 0x12, 0x72, 0xe5, 0xab, 0xe3, 0x0e, 0xd3, 0x0e, 0x06, 0x7b, 0xe5, 0xf4, 0x33, 0x58, 0xa1,
+	0x8c, 0xf6, 0x99, 0x99, 0x4f, 0xcd, 0xff, 0x82, 0xae, 0xe4, 0xe1, 0xa5, 0xb2, 0x90, 0x55, 0xbe,
+	0x70, 0xda, 0x01, 0x33, 0x91, 0xc7, 0x58, 0x60, 0xbd, 0xd8, 0x30, 0x5a, 0xb5, 0xde, 0xfb, 0xce,
+	0x65, 0x9c, 0x97, 0x73, 0xbd, 0xd4, 0x65, 0x97, 0x54, 0xda, 0x4b, 0x88, 0x63, 0xcc, 0xc5, 0xb9,
+	0x5e, 0x6a, 0x18, 0xad, 0x77, 0xec, 0x95, 0x37, 0xbb, 0x50, 0x9b, 0xca, 0x48, 0xe1, 0x1f, 0x75,
+	0x8f, 0x8a, 0xfb, 0x61, 0x42, 0xbf, 0x00, 0x88, 0x4c, 0xd9, 0xf8, 0xbb, 0xf4, 0xd6, 0x55, 0x66,
+	0x5d, 0x94, 0xc5, 0xae, 0xfd, 0x15, 0xe0, 0x87, 0xdc, 0x1d, 0xc3, 0x6c, 0xc0, 0x0a, 0x14, 0x57,
+	0x2e, 0xbb, 0x23, 0x39, 0x5a, 0x86, 0xc2, 0xcc, 0x5b, 0x12, 0xa3, 0x7d, 0x02, 0x93, 0xe1, 0x5e,
+	0x9b, 0x16, 0x94, 0xec, 0xf5, 0x78, 0x38, 0x26, 0x39, 0x0d, 0xd7, 0xde, 0xe4, 0xdb, 0x2d, 0xc9,
+	0x6b, 0x38, 0x75, 0x46, 0xa3, 0x09, 0x29, 0x64, 0x81, 0x41, 0xbf, 0x4f, 0x8a, 0x1a, 0xde, 0xad,
+	0x75, 0xa0, 0x94, 0x05, 0x06, 0xa3, 0x1b, 0x62, 0xa6, 0xaa, 0x37, 0xe9, 0xf5, 0x49, 0x59, 0xc3,
+	0x25, 0x9b, 0xf4, 0x6e, 0x48, 0x45, 0xc3, 0x85, 0x33, 0x1e, 0xde, 0x12, 0x4b, 0x43, 0xb6, 0x1e,
+	0x0f, 0x07, 0x04, 0xda, 0x23, 0xa8, 0xce, 0xb9, 0xc2, 0x67, 0x7e, 0xf6, 0x14, 0x57, 0x48, 0x6b,
+	0x00, 0x8e, 0xfd, 0x60, 0xb3, 0x8d, 0x67, 0xdb, 0x0e, 0xc9, 0x51, 0x00, 0xd3, 0x75, 0x56, 0x0b,
+	0xc7, 0x26, 0x06, 0xbd, 0x82, 0xb2, 0x3b, 0x9b, 0xa5, 0x24, 0xdf, 0xbe, 0x87, 0xda, 0xdb, 0x87,
+	0xd3, 0xf6, 0xda, 0x59, 0x3a, 0xee, 0x2f, 0x27, 0x1b, 0x6c, 0xfe, 0xd3, 0x23, 0x86, 0x6e, 0x30,
+	0x75, 0x9d, 0xd9, 0x62, 0x4e, 0xf2, 0x94, 0x40, 0x75, 0x6e, 0xbb, 0x1b, 0x66, 0x7b, 0xee, 0xea,
+	0xc1, 0x66, 0xa4, 0xf0, 0x7d, 0xf2, 0x7b, 0xb4, 0xf7, 0xd5, 0xe3, 0x71, 0xab, 0x97, 0xd1, 0xdd,
+	0xc6, 0x52, 0x70, 0x1e, 0x77, 0xc5, 0xa3, 0x1f, 0x1f, 0x12, 0xc5, 0x45, 0x70, 0x1d, 0xa1, 0x7a,
+	0x96, 0x71, 0x70, 0x9d, 0x60, 0x7c, 0xc2, 0xb8, 0xcb, 0x0f, 0x7e, 0x37, 0x5b, 0xdb, 0xd6, 0x4c,
+	0xbf, 0x61, 0xff, 0xdf, 0x00, 0xc6, 0x89, 0xc0, 0x26, 0x96, 0x02, 0x00, 0x00,
 }
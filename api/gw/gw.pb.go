@@ -85,6 +85,31 @@ func (FineTimestampType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_9ee4117efac0d846, []int{1}
 }
 
+type ConnState_State int32
+
+const (
+	ConnState_OFFLINE ConnState_State = 0
+	ConnState_ONLINE  ConnState_State = 1
+)
+
+var ConnState_State_name = map[int32]string{
+	0: "OFFLINE",
+	1: "ONLINE",
+}
+
+var ConnState_State_value = map[string]int32{
+	"OFFLINE": 0,
+	"ONLINE":  1,
+}
+
+func (x ConnState_State) String() string {
+	return proto.EnumName(ConnState_State_name, int32(x))
+}
+
+func (ConnState_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9ee4117efac0d846, []int{21, 0}
+}
+
 type UplinkTXInfo struct {
 	// Frequency (Hz).
 	Frequency uint32 `protobuf:"varint,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
@@ -1731,9 +1756,59 @@ func (m *GatewayCommandExecResponse) GetError() string {
 	return ""
 }
 
+type ConnState struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayID,proto3" json:"gateway_id,omitempty"`
+	// Connection state.
+	State                ConnState_State `protobuf:"varint,2,opt,name=state,proto3,enum=gw.ConnState_State" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ConnState) Reset()         { *m = ConnState{} }
+func (m *ConnState) String() string { return proto.CompactTextString(m) }
+func (*ConnState) ProtoMessage()    {}
+func (*ConnState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ee4117efac0d846, []int{21}
+}
+
+func (m *ConnState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnState.Unmarshal(m, b)
+}
+func (m *ConnState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnState.Marshal(b, m, deterministic)
+}
+func (m *ConnState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnState.Merge(m, src)
+}
+func (m *ConnState) XXX_Size() int {
+	return xxx_messageInfo_ConnState.Size(m)
+}
+func (m *ConnState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnState proto.InternalMessageInfo
+
+func (m *ConnState) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *ConnState) GetState() ConnState_State {
+	if m != nil {
+		return m.State
+	}
+	return ConnState_OFFLINE
+}
+
 func init() {
 	proto.RegisterEnum("gw.DownlinkTiming", DownlinkTiming_name, DownlinkTiming_value)
 	proto.RegisterEnum("gw.FineTimestampType", FineTimestampType_name, FineTimestampType_value)
+	proto.RegisterEnum("gw.ConnState_State", ConnState_State_name, ConnState_State_value)
 	proto.RegisterType((*UplinkTXInfo)(nil), "gw.UplinkTXInfo")
 	proto.RegisterType((*LoRaModulationInfo)(nil), "gw.LoRaModulationInfo")
 	proto.RegisterType((*FSKModulationInfo)(nil), "gw.FSKModulationInfo")
@@ -1757,116 +1832,120 @@ func init() {
 	proto.RegisterType((*GatewayCommandExecRequest)(nil), "gw.GatewayCommandExecRequest")
 	proto.RegisterMapType((map[string]string)(nil), "gw.GatewayCommandExecRequest.EnvironmentEntry")
 	proto.RegisterType((*GatewayCommandExecResponse)(nil), "gw.GatewayCommandExecResponse")
+	proto.RegisterType((*ConnState)(nil), "gw.ConnState")
 }
 
 func init() { proto.RegisterFile("gw.proto", fileDescriptor_9ee4117efac0d846) }
 
 var fileDescriptor_9ee4117efac0d846 = []byte{
-	// 1690 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x5f, 0x6f, 0x22, 0xc9,
-	0x11, 0x37, 0xd8, 0x18, 0x28, 0x8c, 0x0d, 0x6d, 0x6c, 0xcf, 0x3a, 0xc9, 0x9d, 0x33, 0x52, 0xa4,
-	0xdd, 0xbd, 0x3b, 0x50, 0x7c, 0x89, 0x12, 0x65, 0xa5, 0x48, 0xb6, 0x61, 0x77, 0x59, 0xff, 0x59,
-	0xd4, 0x38, 0xa7, 0xdb, 0xbc, 0x4c, 0xda, 0x33, 0x0d, 0x1e, 0x01, 0x3d, 0x93, 0x9e, 0xb6, 0x81,
-	0xe4, 0x35, 0x51, 0xf2, 0x01, 0xee, 0xe9, 0x5e, 0xf2, 0x9c, 0xc7, 0x7c, 0x99, 0x7c, 0x9e, 0xa8,
-	0xff, 0x0c, 0xcc, 0x00, 0x77, 0xac, 0x93, 0xdc, 0x13, 0x54, 0x75, 0x75, 0x55, 0x75, 0xd7, 0xaf,
-	0x7f, 0x55, 0x03, 0x85, 0xfe, 0xb8, 0x1e, 0xf2, 0x40, 0x04, 0x28, 0xdb, 0x1f, 0x1f, 0x1f, 0x91,
-	0xd0, 0x6f, 0xb8, 0xc1, 0x68, 0x14, 0x30, 0xf3, 0xa3, 0x17, 0x8f, 0x3f, 0xed, 0x07, 0x41, 0x7f,
-	0x48, 0x1b, 0x4a, 0xba, 0x7b, 0xe8, 0x35, 0x84, 0x3f, 0xa2, 0x91, 0x20, 0xa3, 0xd0, 0x18, 0x7c,
-	0xb2, 0x68, 0xe0, 0x3d, 0x70, 0x22, 0xfc, 0xd8, 0x81, 0xfd, 0xf7, 0x2c, 0xec, 0xfc, 0x2e, 0x1c,
-	0xfa, 0x6c, 0x70, 0xfb, 0x75, 0x9b, 0xf5, 0x02, 0xf4, 0x63, 0x28, 0xf6, 0x38, 0xfd, 0xe3, 0x03,
-	0x65, 0xee, 0xd4, 0xca, 0x9c, 0x64, 0x9e, 0x97, 0xf1, 0x5c, 0x81, 0x4e, 0x01, 0x46, 0x81, 0xf7,
-	0x30, 0x54, 0x2e, 0xac, 0xec, 0x49, 0xe6, 0xf9, 0xee, 0x29, 0xaa, 0x9b, 0x94, 0xae, 0x67, 0x2b,
-	0x38, 0x61, 0x85, 0xde, 0x41, 0x6d, 0x18, 0x70, 0xe2, 0xcc, 0x55, 0x8e, 0xcf, 0x7a, 0x81, 0xb5,
-	0x79, 0x92, 0x79, 0x5e, 0x3a, 0x3d, 0xac, 0xf7, 0xc7, 0xf5, 0xab, 0x00, 0x93, 0xf9, 0x6e, 0x99,
-	0xc7, 0xdb, 0x0d, 0x8c, 0x86, 0x4b, 0x5a, 0xf4, 0x06, 0xf6, 0x7b, 0xd1, 0x60, 0xc9, 0xd5, 0x96,
-	0x72, 0x75, 0x20, 0x5d, 0xbd, 0xee, 0x5e, 0x2e, 0x79, 0xaa, 0xf6, 0xa2, 0x41, 0x5a, 0x79, 0x5e,
-	0x85, 0xbd, 0x05, 0x27, 0xf6, 0xbf, 0x32, 0x80, 0x96, 0x13, 0x91, 0x17, 0x72, 0x47, 0x98, 0x37,
-	0xf6, 0x3d, 0x71, 0x1f, 0x5f, 0xc8, 0x4c, 0x81, 0x5e, 0x40, 0x25, 0x0a, 0x39, 0x25, 0x9e, 0xcf,
-	0xfa, 0x4e, 0x8f, 0xb8, 0x22, 0xe0, 0xea, 0x5a, 0xca, 0x78, 0x6f, 0xa6, 0x7f, 0xad, 0xd4, 0xe8,
-	0x47, 0x50, 0x74, 0x03, 0x8f, 0x3a, 0x9c, 0x08, 0xaa, 0x0e, 0x5f, 0xc4, 0x05, 0xa9, 0xc0, 0x44,
-	0x50, 0xf4, 0x4b, 0x38, 0x0c, 0x83, 0x21, 0xe1, 0xfe, 0x9f, 0xe2, 0x8c, 0x1e, 0x29, 0x8f, 0xe4,
-	0x25, 0xcb, 0xb3, 0x15, 0xf0, 0x41, 0x72, 0xb5, 0x1d, 0x2f, 0xda, 0x97, 0x50, 0x5d, 0x3a, 0xf0,
-	0x9a, 0x8c, 0x2d, 0xc8, 0xdf, 0xf9, 0x42, 0x25, 0xa1, 0x13, 0x8d, 0x45, 0x7b, 0x02, 0x87, 0x2d,
-	0xe6, 0xf2, 0x69, 0x28, 0xa8, 0xf7, 0xda, 0x67, 0xf4, 0x36, 0xc6, 0x12, 0xb2, 0xa1, 0x4c, 0x68,
-	0xe4, 0x0c, 0xe8, 0xd4, 0xf1, 0x99, 0x47, 0x27, 0xc6, 0x6b, 0x89, 0xd0, 0xe8, 0x92, 0x4e, 0xdb,
-	0x52, 0x85, 0x7e, 0x0a, 0x3b, 0x34, 0xde, 0xed, 0xb0, 0x48, 0x39, 0xdf, 0xc1, 0xa5, 0x99, 0xee,
-	0xa6, 0x8b, 0x8e, 0x20, 0xdf, 0x0b, 0xfb, 0xc4, 0xf1, 0x3d, 0x75, 0xfe, 0x1d, 0xbc, 0x2d, 0xc5,
-	0x76, 0xd3, 0x6e, 0x02, 0xea, 0x0c, 0x89, 0xcf, 0xd2, 0x51, 0xeb, 0xb0, 0x25, 0xe1, 0xac, 0x82,
-	0x95, 0x4e, 0x8f, 0xeb, 0x1a, 0xca, 0xf5, 0x18, 0xca, 0xf5, 0x99, 0x25, 0x56, 0x76, 0xf6, 0xb7,
-	0x5b, 0xb0, 0xf3, 0x86, 0x08, 0x3a, 0x26, 0xd3, 0xae, 0x20, 0x22, 0x42, 0x3f, 0x01, 0xe8, 0x6b,
-	0x59, 0x86, 0xcc, 0xa8, 0x90, 0x45, 0xa3, 0x69, 0x37, 0xd1, 0x2e, 0x64, 0xfd, 0xd0, 0x2a, 0xaa,
-	0x4a, 0x64, 0xfd, 0x79, 0xbc, 0xec, 0xc7, 0xc5, 0x43, 0x9f, 0x43, 0x61, 0x18, 0xb8, 0xfa, 0x29,
-	0x68, 0x30, 0x57, 0xe2, 0xa7, 0x70, 0x65, 0xf4, 0x78, 0x66, 0x81, 0x7e, 0x06, 0xbb, 0x6e, 0xc0,
-	0x7a, 0x7e, 0xdf, 0x49, 0x56, 0xb6, 0x88, 0xcb, 0x5a, 0xfb, 0x95, 0x56, 0xa2, 0x3a, 0xec, 0xf3,
-	0x89, 0x13, 0x12, 0x77, 0x40, 0x45, 0xe4, 0x70, 0xea, 0x52, 0xff, 0x91, 0x7a, 0x56, 0x4e, 0x5d,
-	0x78, 0x95, 0x4f, 0x3a, 0x7a, 0x05, 0x9b, 0x05, 0xf4, 0x25, 0x1c, 0xae, 0xb0, 0x77, 0x82, 0x81,
-	0xb5, 0xad, 0xb6, 0xec, 0x2f, 0x6d, 0x79, 0x7f, 0x29, 0x83, 0x88, 0x15, 0x41, 0xf2, 0x3a, 0x88,
-	0x58, 0x0a, 0xf2, 0x39, 0xa0, 0x84, 0x3d, 0x1d, 0xf9, 0x42, 0x50, 0xcf, 0x2a, 0x28, 0xf3, 0xca,
-	0xcc, 0xbc, 0xa5, 0xf5, 0xe8, 0x15, 0x14, 0x47, 0x54, 0x10, 0xc7, 0x23, 0x82, 0x58, 0x70, 0xb2,
-	0xf9, 0xbc, 0x74, 0xfa, 0x89, 0x7c, 0x9a, 0xc9, 0xda, 0xd4, 0xaf, 0xa9, 0x20, 0x4d, 0x22, 0x48,
-	0x8b, 0x09, 0x3e, 0xc5, 0x85, 0x91, 0x11, 0xd1, 0x33, 0x28, 0x44, 0xd2, 0x40, 0x56, 0xac, 0xa4,
-	0x2a, 0x96, 0x57, 0x72, 0xbb, 0x79, 0xfc, 0x0a, 0xca, 0xa9, 0x5d, 0xa8, 0x02, 0x9b, 0x03, 0xaa,
-	0x59, 0xaa, 0x88, 0xe5, 0x5f, 0x54, 0x83, 0xdc, 0x23, 0x19, 0x3e, 0xe8, 0x1a, 0x16, 0xb1, 0x16,
-	0x7e, 0x93, 0xfd, 0x75, 0xc6, 0xfe, 0x47, 0x2e, 0x26, 0x3a, 0xac, 0x89, 0x6e, 0x0d, 0x38, 0x9e,
-	0x0a, 0x86, 0x77, 0x50, 0x93, 0xbf, 0x4e, 0xe4, 0x33, 0x97, 0x3a, 0xfd, 0x30, 0x72, 0x68, 0x18,
-	0xb8, 0xf7, 0x06, 0x18, 0xcf, 0x96, 0xf6, 0x37, 0x0d, 0x0f, 0xe3, 0xaa, 0xdc, 0xd6, 0x95, 0xbb,
-	0xde, 0x74, 0xba, 0x2d, 0xb9, 0x07, 0x21, 0xd8, 0xe2, 0x51, 0xe4, 0xab, 0xa2, 0xe7, 0xb0, 0xfa,
-	0x2f, 0xef, 0x45, 0xb1, 0x68, 0xc4, 0xb8, 0xaa, 0x6c, 0x06, 0xe7, 0x25, 0x3f, 0x76, 0x6f, 0xb0,
-	0x7c, 0xd1, 0xee, 0x3d, 0x61, 0x8c, 0x0e, 0x4d, 0x05, 0x63, 0x51, 0x6e, 0xe2, 0x3d, 0xc7, 0xbd,
-	0x27, 0x3e, 0x33, 0xd5, 0xca, 0xf3, 0xde, 0x85, 0x14, 0xe5, 0x4d, 0xdd, 0x05, 0x84, 0x7b, 0x0a,
-	0xff, 0x65, 0xac, 0x05, 0xe9, 0x8a, 0x30, 0x41, 0x19, 0x93, 0x85, 0x53, 0xf6, 0x46, 0x4c, 0x81,
-	0xbd, 0xb4, 0x16, 0xec, 0x2d, 0xd8, 0xef, 0xf9, 0x8c, 0x3a, 0xb3, 0x76, 0xe4, 0x88, 0x69, 0x48,
-	0xad, 0x1d, 0xd5, 0x30, 0x34, 0x4f, 0x27, 0x9f, 0xfa, 0xed, 0x34, 0xa4, 0xb8, 0xda, 0x5b, 0x54,
-	0xa1, 0xaf, 0xc0, 0x9a, 0x73, 0x4a, 0xda, 0xa1, 0x55, 0x8e, 0x0b, 0x33, 0xae, 0xaf, 0x66, 0xad,
-	0xb7, 0x1b, 0xf8, 0x90, 0xae, 0xe6, 0xb3, 0x77, 0x50, 0x0b, 0x25, 0xdf, 0x2c, 0xfa, 0xdc, 0x9d,
-	0xb7, 0xa4, 0x65, 0x3e, 0x92, 0x2d, 0x29, 0x5c, 0x66, 0x29, 0x79, 0xfb, 0x01, 0x13, 0x74, 0x22,
-	0xac, 0x3d, 0x8d, 0x57, 0x23, 0x4a, 0xc2, 0x7f, 0x50, 0x88, 0x93, 0x00, 0xab, 0xa8, 0xb5, 0x82,
-	0x56, 0xb4, 0x9b, 0xe7, 0x15, 0xd8, 0x4d, 0x07, 0xb7, 0xff, 0x99, 0x83, 0xdd, 0x66, 0x30, 0x66,
-	0x89, 0x66, 0xbc, 0x06, 0xa3, 0xa9, 0x5e, 0x9d, 0x5b, 0xec, 0xd5, 0x35, 0xc8, 0x85, 0xc1, 0x98,
-	0x6a, 0xb8, 0xe4, 0xb0, 0x16, 0x16, 0x3a, 0x78, 0xfe, 0x7f, 0xea, 0xe0, 0x85, 0xff, 0x5f, 0x07,
-	0x2f, 0x3e, 0xb5, 0x83, 0xcf, 0x01, 0x0c, 0xdf, 0x01, 0xe0, 0x52, 0x1a, 0xc0, 0x2f, 0x61, 0x5b,
-	0xf8, 0x23, 0x9f, 0xf5, 0x0d, 0x0a, 0x91, 0x8c, 0x35, 0xbb, 0x6f, 0xb5, 0x82, 0x8d, 0x05, 0xea,
-	0xc2, 0x91, 0x3f, 0x1a, 0x51, 0xcf, 0x27, 0x82, 0x0e, 0xa7, 0x8e, 0xd6, 0xea, 0x44, 0xcb, 0xf1,
-	0x7b, 0x1e, 0xd7, 0xdb, 0x73, 0x13, 0xbd, 0x5f, 0x25, 0x9b, 0xc1, 0x07, 0xfe, 0xaa, 0x05, 0x74,
-	0x06, 0x55, 0x8f, 0x0e, 0x49, 0xda, 0x9d, 0x46, 0xdc, 0xbe, 0xca, 0x45, 0x2e, 0xa6, 0x1c, 0xed,
-	0x79, 0x69, 0x15, 0xba, 0x84, 0x83, 0x19, 0xb3, 0xa4, 0xdc, 0xec, 0xcd, 0x2b, 0x11, 0xb3, 0x48,
-	0xca, 0x13, 0xea, 0x87, 0xd1, 0x82, 0x36, 0x09, 0xdc, 0x4a, 0x0a, 0xb8, 0x2b, 0x86, 0xa3, 0xf3,
-	0x32, 0x94, 0x12, 0xf1, 0xec, 0x23, 0x38, 0x58, 0x79, 0x7a, 0xfb, 0x1c, 0xf6, 0x16, 0xce, 0x81,
-	0x1a, 0x90, 0x53, 0xe7, 0x30, 0x7d, 0xfc, 0x7b, 0xa8, 0x50, 0xdb, 0xd9, 0x7f, 0x00, 0xb4, 0x7c,
-	0x88, 0xef, 0x24, 0xd8, 0xcc, 0xd3, 0x09, 0xd6, 0xfe, 0x4b, 0x06, 0x4a, 0xba, 0x19, 0xbc, 0xe6,
-	0x64, 0x44, 0xd1, 0xa7, 0x50, 0x0a, 0xef, 0xa7, 0x4e, 0x48, 0xa6, 0xc3, 0x80, 0xc4, 0x0f, 0x0d,
-	0xc2, 0xfb, 0x69, 0x47, 0x6b, 0xd0, 0x0b, 0xc8, 0x8b, 0x89, 0xbe, 0xea, 0xac, 0x21, 0xbf, 0xfe,
-	0xb8, 0x9e, 0x1c, 0x9c, 0xf1, 0xb6, 0x98, 0xa8, 0x3c, 0x5f, 0x40, 0x9e, 0x4f, 0x92, 0x13, 0x6e,
-	0xc2, 0x14, 0x1b, 0x53, 0xae, 0x4c, 0xed, 0xbf, 0x65, 0x60, 0x37, 0x91, 0x46, 0x97, 0x8a, 0x1f,
-	0x2e, 0x93, 0xcd, 0xef, 0xcd, 0xe4, 0x9b, 0x0c, 0x94, 0xe3, 0xb7, 0xf0, 0x91, 0x57, 0xf2, 0xd9,
-	0x62, 0x22, 0xe9, 0x07, 0x95, 0x4e, 0xa5, 0x06, 0x39, 0x11, 0x0c, 0xa8, 0x9e, 0x93, 0xca, 0x58,
-	0x0b, 0x32, 0x86, 0x67, 0xec, 0x25, 0xbf, 0x6d, 0xe9, 0x18, 0xb1, 0xaa, 0xdd, 0xb4, 0xff, 0x3c,
-	0xcf, 0xea, 0xf6, 0xeb, 0x33, 0x77, 0xb0, 0x8e, 0x10, 0x67, 0x61, 0xb2, 0xc9, 0x30, 0x35, 0xc8,
-	0x51, 0xce, 0x03, 0x6e, 0x86, 0x6e, 0x2d, 0xac, 0x0f, 0xfe, 0xd7, 0x0c, 0xd4, 0xcc, 0xc8, 0x72,
-	0xa1, 0x46, 0x34, 0x03, 0xa8, 0x75, 0x49, 0x58, 0x90, 0x8f, 0x27, 0x3c, 0x3d, 0x85, 0xc4, 0x22,
-	0xfa, 0x05, 0x14, 0x4c, 0x67, 0x8e, 0x4c, 0x45, 0x2c, 0x79, 0x67, 0x17, 0x5a, 0x97, 0x0a, 0x82,
-	0x67, 0x96, 0xf6, 0xbf, 0xb3, 0x50, 0x5b, 0x65, 0xf2, 0x03, 0x7c, 0xaa, 0x75, 0xe0, 0x70, 0x91,
-	0xe8, 0xf5, 0x74, 0x6a, 0xa0, 0x6c, 0x2d, 0x53, 0xbd, 0x4e, 0xe9, 0xed, 0x06, 0xae, 0x0d, 0x57,
-	0xe8, 0xd1, 0x35, 0x1c, 0x2c, 0xd0, 0xbd, 0x71, 0xa8, 0x3f, 0xd9, 0x8e, 0x96, 0x08, 0x7f, 0xe6,
-	0x6f, 0x3f, 0x45, 0xf9, 0xc6, 0xdd, 0x8c, 0xf4, 0x73, 0x49, 0xd2, 0x3f, 0x81, 0x92, 0x47, 0x4d,
-	0x88, 0x80, 0x9b, 0xc1, 0x37, 0xa9, 0x3a, 0xdf, 0x87, 0xea, 0x52, 0x0a, 0x36, 0x81, 0xda, 0xaa,
-	0xb3, 0xac, 0xf9, 0x7e, 0xfa, 0x0c, 0xaa, 0x8b, 0x5f, 0x7c, 0xf2, 0x63, 0x67, 0x53, 0x8e, 0xc2,
-	0x0b, 0x9f, 0x7c, 0x91, 0x7d, 0x0d, 0xfb, 0x2b, 0x4e, 0xf7, 0x5f, 0x7f, 0xa1, 0x7d, 0x93, 0x85,
-	0x67, 0x33, 0x48, 0x8e, 0x46, 0x84, 0x79, 0xad, 0x09, 0x75, 0xb1, 0x2c, 0x79, 0x24, 0x3e, 0x02,
-	0x97, 0xae, 0xde, 0x14, 0xe3, 0xd2, 0x88, 0xe8, 0x10, 0xb6, 0xa5, 0x9f, 0xf6, 0xec, 0xb3, 0x8c,
-	0x4a, 0x49, 0x3d, 0xa7, 0x48, 0x78, 0x3e, 0x33, 0x8f, 0x43, 0x0b, 0xa8, 0x03, 0x25, 0xca, 0x1e,
-	0x7d, 0x1e, 0xb0, 0x11, 0x65, 0xc2, 0xca, 0x29, 0x20, 0xd7, 0x13, 0x03, 0xfe, 0x72, 0x6a, 0xf5,
-	0xd6, 0x7c, 0x83, 0x1e, 0xf8, 0x93, 0x2e, 0x8e, 0x7f, 0x0b, 0x95, 0x45, 0x83, 0x27, 0xcd, 0xf6,
-	0xdf, 0x66, 0xe0, 0x78, 0x55, 0xec, 0x28, 0x0c, 0x58, 0x44, 0xd7, 0xdd, 0xcb, 0x11, 0xe4, 0xe5,
-	0x79, 0xe5, 0x5a, 0x36, 0x75, 0xfc, 0x43, 0xd8, 0x8e, 0x84, 0x17, 0x3c, 0x88, 0xf8, 0x5a, 0xb4,
-	0x64, 0xf4, 0x94, 0x73, 0x73, 0x2f, 0x46, 0x9a, 0xf3, 0x4c, 0x2e, 0xc1, 0x33, 0x2f, 0x5f, 0x25,
-	0xa6, 0x3a, 0x3d, 0x5d, 0xec, 0x41, 0xa9, 0x7d, 0x7d, 0xdd, 0x6a, 0xb6, 0xcf, 0x6e, 0x5b, 0x57,
-	0x1f, 0x2a, 0x1b, 0xa8, 0x08, 0xb9, 0x66, 0xeb, 0xea, 0xec, 0x43, 0x25, 0x83, 0xca, 0x50, 0x7c,
-	0xd3, 0xe9, 0x3a, 0xad, 0xce, 0xfb, 0x8b, 0xb7, 0x95, 0xec, 0xcb, 0x5f, 0x41, 0x75, 0x69, 0x50,
-	0x46, 0x05, 0xd8, 0xba, 0x79, 0x7f, 0xd3, 0xaa, 0x6c, 0x48, 0xeb, 0xd6, 0xcd, 0x05, 0xfe, 0xd0,
-	0xb9, 0x6d, 0x35, 0x2b, 0x19, 0xe9, 0xa7, 0x73, 0x75, 0xd6, 0xbe, 0xa9, 0x64, 0xcf, 0x7f, 0xfe,
-	0xfb, 0x46, 0xdf, 0x17, 0xf7, 0x0f, 0x77, 0xf2, 0xc5, 0x37, 0xee, 0x78, 0xe0, 0x12, 0xc2, 0x1b,
-	0xc3, 0x80, 0xfb, 0xbd, 0xe9, 0x17, 0x8c, 0x8a, 0x71, 0xc0, 0x07, 0x5f, 0x44, 0x94, 0x3f, 0x52,
-	0xde, 0x20, 0xa1, 0xdf, 0xe8, 0x8f, 0xef, 0xb6, 0x55, 0xeb, 0xfc, 0xf2, 0x3f, 0x01, 0x00, 0x00,
-	0xff, 0xff, 0xfb, 0x8d, 0xc8, 0xa0, 0x7b, 0x12, 0x00, 0x00,
+	// 1736 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5f, 0x6f, 0xe3, 0xb8,
+	0x11, 0x8f, 0x9c, 0x38, 0xb6, 0xc7, 0x71, 0x62, 0x33, 0x4e, 0xa2, 0x4d, 0xdb, 0xbb, 0x54, 0x40,
+	0x81, 0xdd, 0xbd, 0x3b, 0x07, 0xc8, 0xf6, 0xd0, 0xa2, 0x0b, 0x14, 0x48, 0x62, 0x67, 0xd7, 0x97,
+	0x7f, 0x06, 0x9d, 0x1e, 0x6e, 0xfb, 0xa2, 0x32, 0x12, 0xed, 0x08, 0xb6, 0x29, 0x95, 0x62, 0x62,
+	0xbb, 0x7d, 0x6d, 0xd1, 0x7e, 0x80, 0x7b, 0xba, 0x97, 0x3e, 0xf7, 0xb1, 0x5f, 0xa6, 0x9f, 0xa7,
+	0x20, 0x29, 0xc9, 0x92, 0xed, 0xab, 0x37, 0xed, 0xdd, 0x4b, 0xac, 0x19, 0x0e, 0x67, 0x86, 0x33,
+	0xc3, 0xdf, 0x0c, 0x03, 0xc5, 0xfe, 0xb8, 0x11, 0x70, 0x5f, 0xf8, 0x28, 0xd7, 0x1f, 0x1f, 0x1e,
+	0x90, 0xc0, 0x3b, 0x76, 0xfc, 0xd1, 0xc8, 0x67, 0xd1, 0x8f, 0x5e, 0x3c, 0xfc, 0xb4, 0xef, 0xfb,
+	0xfd, 0x21, 0x3d, 0x56, 0xd4, 0xfd, 0x63, 0xef, 0x58, 0x78, 0x23, 0x1a, 0x0a, 0x32, 0x0a, 0x22,
+	0x81, 0x4f, 0xe6, 0x05, 0xdc, 0x47, 0x4e, 0x84, 0x17, 0x2b, 0xb0, 0xfe, 0x9e, 0x83, 0xad, 0xdf,
+	0x05, 0x43, 0x8f, 0x0d, 0xee, 0xbe, 0x69, 0xb3, 0x9e, 0x8f, 0x7e, 0x0a, 0xa5, 0x1e, 0xa7, 0x7f,
+	0x7c, 0xa4, 0xcc, 0x99, 0x9a, 0xc6, 0x91, 0xf1, 0xb2, 0x82, 0x67, 0x0c, 0x74, 0x02, 0x30, 0xf2,
+	0xdd, 0xc7, 0xa1, 0x52, 0x61, 0xe6, 0x8e, 0x8c, 0x97, 0xdb, 0x27, 0xa8, 0x11, 0xb9, 0x74, 0x9d,
+	0xac, 0xe0, 0x94, 0x14, 0xfa, 0x0a, 0xea, 0x43, 0x9f, 0x13, 0x7b, 0xc6, 0xb2, 0x3d, 0xd6, 0xf3,
+	0xcd, 0xf5, 0x23, 0xe3, 0x65, 0xf9, 0x64, 0xbf, 0xd1, 0x1f, 0x37, 0xae, 0x7c, 0x4c, 0x66, 0xbb,
+	0xa5, 0x1f, 0xef, 0xd7, 0x30, 0x1a, 0x2e, 0x70, 0xd1, 0x3b, 0xd8, 0xed, 0x85, 0x83, 0x05, 0x55,
+	0x1b, 0x4a, 0xd5, 0x9e, 0x54, 0x75, 0xd1, 0xbd, 0x5c, 0xd0, 0x54, 0xeb, 0x85, 0x83, 0x2c, 0xf3,
+	0xac, 0x06, 0x3b, 0x73, 0x4a, 0xac, 0x7f, 0x19, 0x80, 0x16, 0x1d, 0x91, 0x01, 0xb9, 0x27, 0xcc,
+	0x1d, 0x7b, 0xae, 0x78, 0x88, 0x03, 0x92, 0x30, 0xd0, 0x2b, 0xa8, 0x86, 0x01, 0xa7, 0xc4, 0xf5,
+	0x58, 0xdf, 0xee, 0x11, 0x47, 0xf8, 0x5c, 0x85, 0xa5, 0x82, 0x77, 0x12, 0xfe, 0x85, 0x62, 0xa3,
+	0x9f, 0x40, 0xc9, 0xf1, 0x5d, 0x6a, 0x73, 0x22, 0xa8, 0x3a, 0x7c, 0x09, 0x17, 0x25, 0x03, 0x13,
+	0x41, 0xd1, 0x97, 0xb0, 0x1f, 0xf8, 0x43, 0xc2, 0xbd, 0x3f, 0xc5, 0x1e, 0x3d, 0x51, 0x1e, 0xca,
+	0x20, 0xcb, 0xb3, 0x15, 0xf1, 0x5e, 0x7a, 0xb5, 0x1d, 0x2f, 0x5a, 0x97, 0x50, 0x5b, 0x38, 0xf0,
+	0x0a, 0x8f, 0x4d, 0x28, 0xdc, 0x7b, 0x42, 0x39, 0xa1, 0x1d, 0x8d, 0x49, 0x6b, 0x02, 0xfb, 0x2d,
+	0xe6, 0xf0, 0x69, 0x20, 0xa8, 0x7b, 0xe1, 0x31, 0x7a, 0x17, 0xd7, 0x12, 0xb2, 0xa0, 0x42, 0x68,
+	0x68, 0x0f, 0xe8, 0xd4, 0xf6, 0x98, 0x4b, 0x27, 0x91, 0xd6, 0x32, 0xa1, 0xe1, 0x25, 0x9d, 0xb6,
+	0x25, 0x0b, 0xfd, 0x1c, 0xb6, 0x68, 0xbc, 0xdb, 0x66, 0xa1, 0x52, 0xbe, 0x85, 0xcb, 0x09, 0xef,
+	0xa6, 0x8b, 0x0e, 0xa0, 0xd0, 0x0b, 0xfa, 0xc4, 0xf6, 0x5c, 0x75, 0xfe, 0x2d, 0xbc, 0x29, 0xc9,
+	0x76, 0xd3, 0x6a, 0x02, 0xea, 0x0c, 0x89, 0xc7, 0xb2, 0x56, 0x1b, 0xb0, 0x21, 0xcb, 0x59, 0x19,
+	0x2b, 0x9f, 0x1c, 0x36, 0x74, 0x29, 0x37, 0xe2, 0x52, 0x6e, 0x24, 0x92, 0x58, 0xc9, 0x59, 0xdf,
+	0x6d, 0xc0, 0xd6, 0x3b, 0x22, 0xe8, 0x98, 0x4c, 0xbb, 0x82, 0x88, 0x10, 0xfd, 0x0c, 0xa0, 0xaf,
+	0x69, 0x69, 0xd2, 0x50, 0x26, 0x4b, 0x11, 0xa7, 0xdd, 0x44, 0xdb, 0x90, 0xf3, 0x02, 0xb3, 0xa4,
+	0x32, 0x91, 0xf3, 0x66, 0xf6, 0x72, 0x1f, 0x67, 0x0f, 0x7d, 0x0e, 0xc5, 0xa1, 0xef, 0xe8, 0xab,
+	0xa0, 0x8b, 0xb9, 0x1a, 0x5f, 0x85, 0xab, 0x88, 0x8f, 0x13, 0x09, 0xf4, 0x0b, 0xd8, 0x76, 0x7c,
+	0xd6, 0xf3, 0xfa, 0x76, 0x3a, 0xb3, 0x25, 0x5c, 0xd1, 0xdc, 0xaf, 0x35, 0x13, 0x35, 0x60, 0x97,
+	0x4f, 0xec, 0x80, 0x38, 0x03, 0x2a, 0x42, 0x9b, 0x53, 0x87, 0x7a, 0x4f, 0xd4, 0x35, 0xf3, 0x2a,
+	0xe0, 0x35, 0x3e, 0xe9, 0xe8, 0x15, 0x1c, 0x2d, 0xa0, 0x37, 0xb0, 0xbf, 0x44, 0xde, 0xf6, 0x07,
+	0xe6, 0xa6, 0xda, 0xb2, 0xbb, 0xb0, 0xe5, 0xf6, 0x52, 0x1a, 0x11, 0x4b, 0x8c, 0x14, 0xb4, 0x11,
+	0xb1, 0x60, 0xe4, 0x73, 0x40, 0x29, 0x79, 0x3a, 0xf2, 0x84, 0xa0, 0xae, 0x59, 0x54, 0xe2, 0xd5,
+	0x44, 0xbc, 0xa5, 0xf9, 0xe8, 0x2d, 0x94, 0x46, 0x54, 0x10, 0xdb, 0x25, 0x82, 0x98, 0x70, 0xb4,
+	0xfe, 0xb2, 0x7c, 0xf2, 0x89, 0xbc, 0x9a, 0xe9, 0xdc, 0x34, 0xae, 0xa9, 0x20, 0x4d, 0x22, 0x48,
+	0x8b, 0x09, 0x3e, 0xc5, 0xc5, 0x51, 0x44, 0xa2, 0x17, 0x50, 0x0c, 0xa5, 0x80, 0xcc, 0x58, 0x59,
+	0x65, 0xac, 0xa0, 0xe8, 0x76, 0xf3, 0xf0, 0x2d, 0x54, 0x32, 0xbb, 0x50, 0x15, 0xd6, 0x07, 0x54,
+	0xa3, 0x54, 0x09, 0xcb, 0x4f, 0x54, 0x87, 0xfc, 0x13, 0x19, 0x3e, 0xea, 0x1c, 0x96, 0xb0, 0x26,
+	0x7e, 0x93, 0xfb, 0xb5, 0x61, 0xfd, 0x23, 0x1f, 0x03, 0x1d, 0xd6, 0x40, 0xb7, 0xa2, 0x38, 0x9e,
+	0x5b, 0x0c, 0x5f, 0x41, 0x5d, 0xfe, 0xda, 0xa1, 0xc7, 0x1c, 0x6a, 0xf7, 0x83, 0xd0, 0xa6, 0x81,
+	0xef, 0x3c, 0x44, 0x85, 0xf1, 0x62, 0x61, 0x7f, 0x33, 0xc2, 0x61, 0x5c, 0x93, 0xdb, 0xba, 0x72,
+	0xd7, 0xbb, 0x4e, 0xb7, 0x25, 0xf7, 0x20, 0x04, 0x1b, 0x3c, 0x0c, 0x3d, 0x95, 0xf4, 0x3c, 0x56,
+	0xdf, 0x32, 0x2e, 0x0a, 0x45, 0x43, 0xc6, 0x55, 0x66, 0x0d, 0x5c, 0x90, 0xf8, 0xd8, 0xbd, 0xc1,
+	0xf2, 0x46, 0x3b, 0x0f, 0x84, 0x31, 0x3a, 0x8c, 0x32, 0x18, 0x93, 0x72, 0x13, 0xef, 0xd9, 0xce,
+	0x03, 0xf1, 0x58, 0x94, 0xad, 0x02, 0xef, 0x9d, 0x4b, 0x52, 0x46, 0xea, 0xde, 0x27, 0xdc, 0x55,
+	0xf5, 0x5f, 0xc1, 0x9a, 0x90, 0xaa, 0x08, 0x13, 0x94, 0x31, 0x99, 0x38, 0x25, 0x1f, 0x91, 0x99,
+	0x62, 0x2f, 0xaf, 0x2c, 0xf6, 0x16, 0xec, 0xf6, 0x3c, 0x46, 0xed, 0xa4, 0x1d, 0xd9, 0x62, 0x1a,
+	0x50, 0x73, 0x4b, 0x35, 0x0c, 0x8d, 0xd3, 0xe9, 0xab, 0x7e, 0x37, 0x0d, 0x28, 0xae, 0xf5, 0xe6,
+	0x59, 0xe8, 0x6b, 0x30, 0x67, 0x98, 0x92, 0x55, 0x68, 0x56, 0xe2, 0xc4, 0x8c, 0x1b, 0xcb, 0x51,
+	0xeb, 0xfd, 0x1a, 0xde, 0xa7, 0x4b, 0x57, 0x64, 0xb2, 0x02, 0x89, 0x37, 0xf3, 0x3a, 0xb7, 0x67,
+	0x2d, 0x69, 0x11, 0x8f, 0x64, 0x4b, 0x0a, 0x16, 0xb8, 0x2a, 0xfa, 0x3e, 0x13, 0x74, 0x22, 0xcc,
+	0x1d, 0x5d, 0xaf, 0x11, 0x29, 0x01, 0xff, 0x51, 0x55, 0x9c, 0x2c, 0xb0, 0xaa, 0x5a, 0x2b, 0x6a,
+	0x46, 0xbb, 0x79, 0x56, 0x85, 0xed, 0xac, 0x71, 0xeb, 0x9f, 0x79, 0xd8, 0x6e, 0xfa, 0x63, 0x96,
+	0x6a, 0xc6, 0x2b, 0x6a, 0x34, 0xd3, 0xab, 0xf3, 0xf3, 0xbd, 0xba, 0x0e, 0xf9, 0xc0, 0x1f, 0x53,
+	0x5d, 0x2e, 0x79, 0xac, 0x89, 0xb9, 0x0e, 0x5e, 0xf8, 0xbf, 0x3a, 0x78, 0xf1, 0x87, 0xeb, 0xe0,
+	0xa5, 0xe7, 0x76, 0xf0, 0x59, 0x01, 0xc3, 0xf7, 0x14, 0x70, 0x39, 0x5b, 0xc0, 0xaf, 0x61, 0x53,
+	0x78, 0x23, 0x8f, 0xf5, 0xa3, 0x2a, 0x44, 0xd2, 0x56, 0x12, 0x6f, 0xb5, 0x82, 0x23, 0x09, 0xd4,
+	0x85, 0x03, 0x6f, 0x34, 0xa2, 0xae, 0x47, 0x04, 0x1d, 0x4e, 0x6d, 0xcd, 0xd5, 0x8e, 0x56, 0xe2,
+	0xfb, 0x3c, 0x6e, 0xb4, 0x67, 0x22, 0x7a, 0xbf, 0x72, 0xd6, 0xc0, 0x7b, 0xde, 0xb2, 0x05, 0x74,
+	0x0a, 0x35, 0x97, 0x0e, 0x49, 0x56, 0x9d, 0xae, 0xb8, 0x5d, 0xe5, 0x8b, 0x5c, 0xcc, 0x28, 0xda,
+	0x71, 0xb3, 0x2c, 0x74, 0x09, 0x7b, 0x09, 0xb2, 0x64, 0xd4, 0xec, 0xcc, 0x32, 0x11, 0xa3, 0x48,
+	0x46, 0x13, 0xea, 0x07, 0xe1, 0x1c, 0x37, 0x5d, 0xb8, 0xd5, 0x4c, 0xe1, 0x2e, 0x19, 0x8e, 0xce,
+	0x2a, 0x50, 0x4e, 0xd9, 0xb3, 0x0e, 0x60, 0x6f, 0xe9, 0xe9, 0xad, 0x33, 0xd8, 0x99, 0x3b, 0x07,
+	0x3a, 0x86, 0xbc, 0x3a, 0x87, 0x69, 0xac, 0x82, 0x42, 0x2d, 0x67, 0xfd, 0x01, 0xd0, 0xe2, 0x21,
+	0xbe, 0x17, 0x60, 0x8d, 0xe7, 0x03, 0xac, 0xf5, 0x17, 0x03, 0xca, 0xba, 0x19, 0x5c, 0x70, 0x32,
+	0xa2, 0xe8, 0x53, 0x28, 0x07, 0x0f, 0x53, 0x3b, 0x20, 0xd3, 0xa1, 0x4f, 0xe2, 0x8b, 0x06, 0xc1,
+	0xc3, 0xb4, 0xa3, 0x39, 0xe8, 0x15, 0x14, 0xc4, 0x44, 0x87, 0x3a, 0x17, 0x81, 0x5f, 0x7f, 0xdc,
+	0x48, 0x0f, 0xce, 0x78, 0x53, 0x4c, 0x94, 0x9f, 0xaf, 0xa0, 0xc0, 0x27, 0xe9, 0x09, 0x37, 0x25,
+	0x8a, 0x23, 0x51, 0xae, 0x44, 0xad, 0xbf, 0x19, 0xb0, 0x9d, 0x72, 0xa3, 0x4b, 0xc5, 0x8f, 0xe7,
+	0xc9, 0xfa, 0x7f, 0xf5, 0xe4, 0x5b, 0x03, 0x2a, 0xf1, 0x5d, 0xf8, 0xc8, 0x90, 0x7c, 0x36, 0xef,
+	0x48, 0xf6, 0x42, 0x65, 0x5d, 0xa9, 0x43, 0x5e, 0xf8, 0x03, 0xaa, 0xe7, 0xa4, 0x0a, 0xd6, 0x84,
+	0xb4, 0xe1, 0x46, 0xf2, 0x12, 0xdf, 0x36, 0xb4, 0x8d, 0x98, 0xd5, 0x6e, 0x5a, 0x7f, 0x9e, 0x79,
+	0x75, 0xf7, 0xcd, 0xa9, 0x33, 0x58, 0x05, 0x88, 0x89, 0x99, 0x5c, 0xda, 0x4c, 0x1d, 0xf2, 0x94,
+	0x73, 0x9f, 0x47, 0x43, 0xb7, 0x26, 0x56, 0x1b, 0xff, 0xab, 0x01, 0xf5, 0x68, 0x64, 0x39, 0x57,
+	0x23, 0x5a, 0x54, 0x50, 0xab, 0x9c, 0x30, 0xa1, 0x10, 0x4f, 0x78, 0x7a, 0x0a, 0x89, 0x49, 0xf4,
+	0x4b, 0x28, 0x46, 0x9d, 0x39, 0x8c, 0x32, 0x62, 0xca, 0x98, 0x9d, 0x6b, 0x5e, 0xc6, 0x08, 0x4e,
+	0x24, 0xad, 0x7f, 0xe7, 0xa0, 0xbe, 0x4c, 0xe4, 0x47, 0x78, 0xaa, 0x75, 0x60, 0x7f, 0x1e, 0xe8,
+	0xf5, 0x74, 0x1a, 0x95, 0xb2, 0xb9, 0x08, 0xf5, 0xda, 0xa5, 0xf7, 0x6b, 0xb8, 0x3e, 0x5c, 0xc2,
+	0x47, 0xd7, 0xb0, 0x37, 0x07, 0xf7, 0x91, 0x42, 0xfd, 0x64, 0x3b, 0x58, 0x00, 0xfc, 0x44, 0xdf,
+	0x6e, 0x06, 0xf2, 0x23, 0x75, 0x09, 0xe8, 0xe7, 0xd3, 0xa0, 0x7f, 0x04, 0x65, 0x97, 0x46, 0x26,
+	0x7c, 0x1e, 0x0d, 0xbe, 0x69, 0xd6, 0xd9, 0x2e, 0xd4, 0x16, 0x5c, 0xb0, 0x08, 0xd4, 0x97, 0x9d,
+	0x65, 0xc5, 0xfb, 0xe9, 0x33, 0xa8, 0xcd, 0xbf, 0xf8, 0xe4, 0x63, 0x67, 0x5d, 0x8e, 0xc2, 0x73,
+	0x4f, 0xbe, 0xd0, 0xba, 0x86, 0xdd, 0x25, 0xa7, 0xfb, 0x9f, 0x5f, 0x68, 0xdf, 0xe6, 0xe0, 0x45,
+	0x52, 0x92, 0xa3, 0x11, 0x61, 0x6e, 0x6b, 0x42, 0x1d, 0x2c, 0x53, 0x1e, 0x8a, 0x8f, 0xa8, 0x4b,
+	0x47, 0x6f, 0x8a, 0xeb, 0x32, 0x22, 0xd1, 0x3e, 0x6c, 0x4a, 0x3d, 0xed, 0xe4, 0x59, 0x46, 0x25,
+	0xa5, 0xae, 0x53, 0x28, 0x5c, 0x8f, 0x45, 0x97, 0x43, 0x13, 0xa8, 0x03, 0x65, 0xca, 0x9e, 0x3c,
+	0xee, 0xb3, 0x11, 0x65, 0xc2, 0xcc, 0xab, 0x42, 0x6e, 0xa4, 0x06, 0xfc, 0x45, 0xd7, 0x1a, 0xad,
+	0xd9, 0x06, 0x3d, 0xf0, 0xa7, 0x55, 0x1c, 0xfe, 0x16, 0xaa, 0xf3, 0x02, 0xcf, 0x9a, 0xed, 0xbf,
+	0x33, 0xe0, 0x70, 0x99, 0xed, 0x30, 0xf0, 0x59, 0x48, 0x57, 0xc5, 0xe5, 0x00, 0x0a, 0xf2, 0xbc,
+	0x72, 0x2d, 0x97, 0x39, 0xfe, 0x3e, 0x6c, 0x86, 0xc2, 0xf5, 0x1f, 0x45, 0x1c, 0x16, 0x4d, 0x45,
+	0x7c, 0xca, 0x79, 0x14, 0x97, 0x88, 0x9a, 0xe1, 0x4c, 0x3e, 0x85, 0x33, 0xd6, 0x18, 0x4a, 0xe7,
+	0x3e, 0x63, 0xf2, 0xd5, 0xb3, 0xd2, 0x95, 0x57, 0x32, 0xe0, 0x71, 0xde, 0xb7, 0xf5, 0x58, 0x90,
+	0x6c, 0x6e, 0xa8, 0xbf, 0x58, 0x4b, 0x58, 0x47, 0x90, 0xd7, 0x2a, 0xcb, 0x50, 0xb8, 0xbd, 0xb8,
+	0xb8, 0x6a, 0xdf, 0xb4, 0xaa, 0x6b, 0x08, 0x60, 0xf3, 0xf6, 0x46, 0x7d, 0x1b, 0xaf, 0xdf, 0xa6,
+	0xc6, 0x49, 0x3d, 0xd6, 0xec, 0x40, 0xb9, 0x7d, 0x7d, 0xdd, 0x6a, 0xb6, 0x4f, 0xef, 0x5a, 0x57,
+	0x1f, 0xaa, 0x6b, 0xa8, 0x04, 0xf9, 0x66, 0xeb, 0xea, 0xf4, 0x43, 0xd5, 0x40, 0x15, 0x28, 0xbd,
+	0xeb, 0x74, 0xed, 0x56, 0xe7, 0xf6, 0xfc, 0x7d, 0x35, 0xf7, 0xfa, 0x57, 0x50, 0x5b, 0x98, 0xd0,
+	0x51, 0x11, 0x36, 0x6e, 0x6e, 0x95, 0x9d, 0x0a, 0x94, 0x5a, 0x37, 0xe7, 0xf8, 0x43, 0xe7, 0xae,
+	0xd5, 0xac, 0x1a, 0x52, 0x4f, 0xe7, 0xea, 0xb4, 0x7d, 0x53, 0xcd, 0x9d, 0x7d, 0xf9, 0xfb, 0x37,
+	0x7d, 0x4f, 0x3c, 0x3c, 0xde, 0x4b, 0xa8, 0x39, 0xbe, 0xe7, 0xbe, 0x43, 0x08, 0x3f, 0x76, 0x1e,
+	0x3c, 0x1e, 0x84, 0x82, 0x38, 0x83, 0x2f, 0x18, 0x15, 0x63, 0x9f, 0x0f, 0xbe, 0x08, 0x29, 0x7f,
+	0xa2, 0xfc, 0x58, 0xfe, 0x67, 0xab, 0x3f, 0xbe, 0xdf, 0x54, 0x7d, 0xfb, 0xcd, 0x7f, 0x06, 0x00,
+	0x46, 0x8a, 0x93, 0x53, 0xf8, 0x12, 0x00, 0x00,
 }
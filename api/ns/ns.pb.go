@@ -9,6 +9,7 @@ import (
 	common "github.com/brocaar/chirpstack-network-server/api/common"
 	gw "github.com/brocaar/chirpstack-network-server/api/gw"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
@@ -53,6 +54,33 @@ func (RXWindow) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_3b280de855f92a4a, []int{0}
 }
 
+type DeviceStatusType int32
+
+const (
+	// DevStatusAns mac-command.
+	DeviceStatusType_DEV_STATUS DeviceStatusType = 0
+	// LinkCheckReq mac-command.
+	DeviceStatusType_LINK_CHECK DeviceStatusType = 1
+)
+
+var DeviceStatusType_name = map[int32]string{
+	0: "DEV_STATUS",
+	1: "LINK_CHECK",
+}
+
+var DeviceStatusType_value = map[string]int32{
+	"DEV_STATUS": 0,
+	"LINK_CHECK": 1,
+}
+
+func (x DeviceStatusType) String() string {
+	return proto.EnumName(DeviceStatusType_name, int32(x))
+}
+
+func (DeviceStatusType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{1}
+}
+
 type AggregationInterval int32
 
 const (
@@ -93,7 +121,7 @@ func (x AggregationInterval) String() string {
 }
 
 func (AggregationInterval) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{1}
+	return fileDescriptor_3b280de855f92a4a, []int{2}
 }
 
 type MulticastGroupType int32
@@ -120,7 +148,113 @@ func (x MulticastGroupType) String() string {
 }
 
 func (MulticastGroupType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{2}
+	return fileDescriptor_3b280de855f92a4a, []int{3}
+}
+
+type MulticastDeliveryStatus int32
+
+const (
+	// The frame has been sent to the gateway, the tx acknowledgement is
+	// pending.
+	MulticastDeliveryStatus_PENDING MulticastDeliveryStatus = 0
+	// The gateway acknowledged the transmission.
+	MulticastDeliveryStatus_SUCCESS MulticastDeliveryStatus = 1
+	// The frame was not transmitted by the gateway.
+	MulticastDeliveryStatus_FAILED MulticastDeliveryStatus = 2
+)
+
+var MulticastDeliveryStatus_name = map[int32]string{
+	0: "PENDING",
+	1: "SUCCESS",
+	2: "FAILED",
+}
+
+var MulticastDeliveryStatus_value = map[string]int32{
+	"PENDING": 0,
+	"SUCCESS": 1,
+	"FAILED":  2,
+}
+
+func (x MulticastDeliveryStatus) String() string {
+	return proto.EnumName(MulticastDeliveryStatus_name, int32(x))
+}
+
+func (MulticastDeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{4}
+}
+
+type MulticastSetupState int32
+
+const (
+	// The setup commands have been enqueued.
+	MulticastSetupState_SETUP_PENDING MulticastSetupState = 0
+	// The device acknowledged the McGroupSetupReq.
+	MulticastSetupState_SETUP_GROUP_CREATED MulticastSetupState = 1
+	// The device acknowledged the McClassBSessionReq or McClassCSessionReq.
+	MulticastSetupState_SETUP_COMPLETED MulticastSetupState = 2
+	// The device rejected one of the setup commands.
+	MulticastSetupState_SETUP_FAILED MulticastSetupState = 3
+)
+
+var MulticastSetupState_name = map[int32]string{
+	0: "SETUP_PENDING",
+	1: "SETUP_GROUP_CREATED",
+	2: "SETUP_COMPLETED",
+	3: "SETUP_FAILED",
+}
+
+var MulticastSetupState_value = map[string]int32{
+	"SETUP_PENDING":       0,
+	"SETUP_GROUP_CREATED": 1,
+	"SETUP_COMPLETED":     2,
+	"SETUP_FAILED":        3,
+}
+
+func (x MulticastSetupState) String() string {
+	return proto.EnumName(MulticastSetupState_name, int32(x))
+}
+
+func (MulticastSetupState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{5}
+}
+
+type MulticastFragSessionState int32
+
+const (
+	// The fragments have been enqueued.
+	MulticastFragSessionState_FRAG_SESSION_PENDING MulticastFragSessionState = 0
+	// The device acknowledged the FragSessionSetupReq.
+	MulticastFragSessionState_FRAG_SESSION_CREATED MulticastFragSessionState = 1
+	// The device rejected the FragSessionSetupReq.
+	MulticastFragSessionState_FRAG_SESSION_FAILED MulticastFragSessionState = 2
+	// The device reported missing fragments.
+	MulticastFragSessionState_FRAG_SESSION_INCOMPLETE MulticastFragSessionState = 3
+	// The device reported that the data block has been reconstructed.
+	MulticastFragSessionState_FRAG_SESSION_COMPLETED MulticastFragSessionState = 4
+)
+
+var MulticastFragSessionState_name = map[int32]string{
+	0: "FRAG_SESSION_PENDING",
+	1: "FRAG_SESSION_CREATED",
+	2: "FRAG_SESSION_FAILED",
+	3: "FRAG_SESSION_INCOMPLETE",
+	4: "FRAG_SESSION_COMPLETED",
+}
+
+var MulticastFragSessionState_value = map[string]int32{
+	"FRAG_SESSION_PENDING":    0,
+	"FRAG_SESSION_CREATED":    1,
+	"FRAG_SESSION_FAILED":     2,
+	"FRAG_SESSION_INCOMPLETE": 3,
+	"FRAG_SESSION_COMPLETED":  4,
+}
+
+func (x MulticastFragSessionState) String() string {
+	return proto.EnumName(MulticastFragSessionState_name, int32(x))
+}
+
+func (MulticastFragSessionState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{6}
 }
 
 type CreateServiceProfileRequest struct {
@@ -1476,6 +1610,182 @@ func (m *GetDeviceActivationResponse) GetDeviceActivation() *DeviceActivation {
 	return nil
 }
 
+type DeviceStatusHistoryItem struct {
+	// Timestamp at which the status was received.
+	Time *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Status type.
+	Type DeviceStatusType `protobuf:"varint,2,opt,name=type,proto3,enum=ns.DeviceStatusType" json:"type,omitempty"`
+	// Battery as reported by the device (DEV_STATUS only).
+	Battery uint32 `protobuf:"varint,3,opt,name=battery,proto3" json:"battery,omitempty"`
+	// Margin (dB).
+	// For DEV_STATUS, this is the demodulation margin reported by the device.
+	// For LINK_CHECK, this is the link margin sent to the device.
+	Margin int32 `protobuf:"varint,4,opt,name=margin,proto3" json:"margin,omitempty"`
+	// Number of gateways that received the LinkCheckReq (LINK_CHECK only).
+	GatewayCount         uint32   `protobuf:"varint,5,opt,name=gateway_count,json=gatewayCount,proto3" json:"gateway_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceStatusHistoryItem) Reset()         { *m = DeviceStatusHistoryItem{} }
+func (m *DeviceStatusHistoryItem) String() string { return proto.CompactTextString(m) }
+func (*DeviceStatusHistoryItem) ProtoMessage()    {}
+func (*DeviceStatusHistoryItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{29}
+}
+
+func (m *DeviceStatusHistoryItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceStatusHistoryItem.Unmarshal(m, b)
+}
+func (m *DeviceStatusHistoryItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceStatusHistoryItem.Marshal(b, m, deterministic)
+}
+func (m *DeviceStatusHistoryItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceStatusHistoryItem.Merge(m, src)
+}
+func (m *DeviceStatusHistoryItem) XXX_Size() int {
+	return xxx_messageInfo_DeviceStatusHistoryItem.Size(m)
+}
+func (m *DeviceStatusHistoryItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceStatusHistoryItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceStatusHistoryItem proto.InternalMessageInfo
+
+func (m *DeviceStatusHistoryItem) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *DeviceStatusHistoryItem) GetType() DeviceStatusType {
+	if m != nil {
+		return m.Type
+	}
+	return DeviceStatusType_DEV_STATUS
+}
+
+func (m *DeviceStatusHistoryItem) GetBattery() uint32 {
+	if m != nil {
+		return m.Battery
+	}
+	return 0
+}
+
+func (m *DeviceStatusHistoryItem) GetMargin() int32 {
+	if m != nil {
+		return m.Margin
+	}
+	return 0
+}
+
+func (m *DeviceStatusHistoryItem) GetGatewayCount() uint32 {
+	if m != nil {
+		return m.GatewayCount
+	}
+	return 0
+}
+
+type GetDeviceStatusHistoryRequest struct {
+	// Device EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Timestamp to start from.
+	StartTimestamp *timestamp.Timestamp `protobuf:"bytes,2,opt,name=start_timestamp,json=startTimestamp,proto3" json:"start_timestamp,omitempty"`
+	// Timestamp until to get from.
+	EndTimestamp         *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end_timestamp,json=endTimestamp,proto3" json:"end_timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetDeviceStatusHistoryRequest) Reset()         { *m = GetDeviceStatusHistoryRequest{} }
+func (m *GetDeviceStatusHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceStatusHistoryRequest) ProtoMessage()    {}
+func (*GetDeviceStatusHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{30}
+}
+
+func (m *GetDeviceStatusHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceStatusHistoryRequest.Unmarshal(m, b)
+}
+func (m *GetDeviceStatusHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceStatusHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceStatusHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceStatusHistoryRequest.Merge(m, src)
+}
+func (m *GetDeviceStatusHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceStatusHistoryRequest.Size(m)
+}
+func (m *GetDeviceStatusHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceStatusHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceStatusHistoryRequest proto.InternalMessageInfo
+
+func (m *GetDeviceStatusHistoryRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *GetDeviceStatusHistoryRequest) GetStartTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return nil
+}
+
+func (m *GetDeviceStatusHistoryRequest) GetEndTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTimestamp
+	}
+	return nil
+}
+
+type GetDeviceStatusHistoryResponse struct {
+	// Device-status history items, sorted by time.
+	Result               []*DeviceStatusHistoryItem `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *GetDeviceStatusHistoryResponse) Reset()         { *m = GetDeviceStatusHistoryResponse{} }
+func (m *GetDeviceStatusHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceStatusHistoryResponse) ProtoMessage()    {}
+func (*GetDeviceStatusHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{31}
+}
+
+func (m *GetDeviceStatusHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceStatusHistoryResponse.Unmarshal(m, b)
+}
+func (m *GetDeviceStatusHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceStatusHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDeviceStatusHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceStatusHistoryResponse.Merge(m, src)
+}
+func (m *GetDeviceStatusHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceStatusHistoryResponse.Size(m)
+}
+func (m *GetDeviceStatusHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceStatusHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDeviceStatusHistoryResponse proto.InternalMessageInfo
+
+func (m *GetDeviceStatusHistoryResponse) GetResult() []*DeviceStatusHistoryItem {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
 type GetRandomDevAddrResponse struct {
 	// Random device address (DevAddr).
 	// Note that this includes the NetID prefix of the network-server.
@@ -1489,7 +1799,7 @@ func (m *GetRandomDevAddrResponse) Reset()         { *m = GetRandomDevAddrRespon
 func (m *GetRandomDevAddrResponse) String() string { return proto.CompactTextString(m) }
 func (*GetRandomDevAddrResponse) ProtoMessage()    {}
 func (*GetRandomDevAddrResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{29}
+	return fileDescriptor_3b280de855f92a4a, []int{32}
 }
 
 func (m *GetRandomDevAddrResponse) XXX_Unmarshal(b []byte) error {
@@ -1534,7 +1844,7 @@ func (m *CreateMACCommandQueueItemRequest) Reset()         { *m = CreateMACComma
 func (m *CreateMACCommandQueueItemRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateMACCommandQueueItemRequest) ProtoMessage()    {}
 func (*CreateMACCommandQueueItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{30}
+	return fileDescriptor_3b280de855f92a4a, []int{33}
 }
 
 func (m *CreateMACCommandQueueItemRequest) XXX_Unmarshal(b []byte) error {
@@ -1599,7 +1909,7 @@ func (m *SendProprietaryPayloadRequest) Reset()         { *m = SendProprietaryPa
 func (m *SendProprietaryPayloadRequest) String() string { return proto.CompactTextString(m) }
 func (*SendProprietaryPayloadRequest) ProtoMessage()    {}
 func (*SendProprietaryPayloadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{31}
+	return fileDescriptor_3b280de855f92a4a, []int{34}
 }
 
 func (m *SendProprietaryPayloadRequest) XXX_Unmarshal(b []byte) error {
@@ -1677,7 +1987,13 @@ type Gateway struct {
 	// Routing Profile ID.
 	// The routing-profile ID defines to which application-server statistical
 	// data for this gateway is forwarded.
-	RoutingProfileId     []byte   `protobuf:"bytes,5,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	RoutingProfileId []byte `protobuf:"bytes,5,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	// Gateway backend (optional).
+	// When ChirpStack Network Server is configured with multiple gateway
+	// backends (multiplexer), this pins the gateway to the given backend
+	// (e.g. mqtt). When left blank, the backend on which the gateway was
+	// last seen is used.
+	Backend              string   `protobuf:"bytes,6,opt,name=backend,proto3" json:"backend,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1687,7 +2003,7 @@ func (m *Gateway) Reset()         { *m = Gateway{} }
 func (m *Gateway) String() string { return proto.CompactTextString(m) }
 func (*Gateway) ProtoMessage()    {}
 func (*Gateway) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{32}
+	return fileDescriptor_3b280de855f92a4a, []int{35}
 }
 
 func (m *Gateway) XXX_Unmarshal(b []byte) error {
@@ -1743,6 +2059,13 @@ func (m *Gateway) GetRoutingProfileId() []byte {
 	return nil
 }
 
+func (m *Gateway) GetBackend() string {
+	if m != nil {
+		return m.Backend
+	}
+	return ""
+}
+
 type GatewayBoard struct {
 	// FPGA ID of the gateway (8 bytes) (optional).
 	FpgaId []byte `protobuf:"bytes,1,opt,name=fpga_id,json=fpgaId,proto3" json:"fpga_id,omitempty"`
@@ -1757,7 +2080,7 @@ func (m *GatewayBoard) Reset()         { *m = GatewayBoard{} }
 func (m *GatewayBoard) String() string { return proto.CompactTextString(m) }
 func (*GatewayBoard) ProtoMessage()    {}
 func (*GatewayBoard) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{33}
+	return fileDescriptor_3b280de855f92a4a, []int{36}
 }
 
 func (m *GatewayBoard) XXX_Unmarshal(b []byte) error {
@@ -1804,7 +2127,7 @@ func (m *CreateGatewayRequest) Reset()         { *m = CreateGatewayRequest{} }
 func (m *CreateGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateGatewayRequest) ProtoMessage()    {}
 func (*CreateGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{34}
+	return fileDescriptor_3b280de855f92a4a, []int{37}
 }
 
 func (m *CreateGatewayRequest) XXX_Unmarshal(b []byte) error {
@@ -1844,7 +2167,7 @@ func (m *GetGatewayRequest) Reset()         { *m = GetGatewayRequest{} }
 func (m *GetGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayRequest) ProtoMessage()    {}
 func (*GetGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{35}
+	return fileDescriptor_3b280de855f92a4a, []int{38}
 }
 
 func (m *GetGatewayRequest) XXX_Unmarshal(b []byte) error {
@@ -1882,7 +2205,11 @@ type GetGatewayResponse struct {
 	// First seen timestamp.
 	FirstSeenAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=first_seen_at,json=firstSeenAt,proto3" json:"first_seen_at,omitempty"`
 	// Last seen timestamp.
-	LastSeenAt           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	LastSeenAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	// Gateway state.
+	State common.GatewayState `protobuf:"varint,6,opt,name=state,proto3,enum=common.GatewayState" json:"state,omitempty"`
+	// Timestamp of the last state change.
+	StateChangedAt       *timestamp.Timestamp `protobuf:"bytes,7,opt,name=state_changed_at,json=stateChangedAt,proto3" json:"state_changed_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -1892,7 +2219,7 @@ func (m *GetGatewayResponse) Reset()         { *m = GetGatewayResponse{} }
 func (m *GetGatewayResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayResponse) ProtoMessage()    {}
 func (*GetGatewayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{36}
+	return fileDescriptor_3b280de855f92a4a, []int{39}
 }
 
 func (m *GetGatewayResponse) XXX_Unmarshal(b []byte) error {
@@ -1948,6 +2275,20 @@ func (m *GetGatewayResponse) GetLastSeenAt() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *GetGatewayResponse) GetState() common.GatewayState {
+	if m != nil {
+		return m.State
+	}
+	return common.GatewayState_NEVER_SEEN
+}
+
+func (m *GetGatewayResponse) GetStateChangedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StateChangedAt
+	}
+	return nil
+}
+
 type UpdateGatewayRequest struct {
 	// Gateway object to update.
 	Gateway              *Gateway `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
@@ -1960,7 +2301,7 @@ func (m *UpdateGatewayRequest) Reset()         { *m = UpdateGatewayRequest{} }
 func (m *UpdateGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateGatewayRequest) ProtoMessage()    {}
 func (*UpdateGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{37}
+	return fileDescriptor_3b280de855f92a4a, []int{40}
 }
 
 func (m *UpdateGatewayRequest) XXX_Unmarshal(b []byte) error {
@@ -2000,7 +2341,7 @@ func (m *DeleteGatewayRequest) Reset()         { *m = DeleteGatewayRequest{} }
 func (m *DeleteGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteGatewayRequest) ProtoMessage()    {}
 func (*DeleteGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{38}
+	return fileDescriptor_3b280de855f92a4a, []int{41}
 }
 
 func (m *DeleteGatewayRequest) XXX_Unmarshal(b []byte) error {
@@ -2048,7 +2389,7 @@ func (m *GatewayStats) Reset()         { *m = GatewayStats{} }
 func (m *GatewayStats) String() string { return proto.CompactTextString(m) }
 func (*GatewayStats) ProtoMessage()    {}
 func (*GatewayStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{39}
+	return fileDescriptor_3b280de855f92a4a, []int{42}
 }
 
 func (m *GatewayStats) XXX_Unmarshal(b []byte) error {
@@ -2122,7 +2463,7 @@ func (m *GetGatewayStatsRequest) Reset()         { *m = GetGatewayStatsRequest{}
 func (m *GetGatewayStatsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayStatsRequest) ProtoMessage()    {}
 func (*GetGatewayStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{40}
+	return fileDescriptor_3b280de855f92a4a, []int{43}
 }
 
 func (m *GetGatewayStatsRequest) XXX_Unmarshal(b []byte) error {
@@ -2182,7 +2523,7 @@ func (m *GetGatewayStatsResponse) Reset()         { *m = GetGatewayStatsResponse
 func (m *GetGatewayStatsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayStatsResponse) ProtoMessage()    {}
 func (*GetGatewayStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{41}
+	return fileDescriptor_3b280de855f92a4a, []int{44}
 }
 
 func (m *GetGatewayStatsResponse) XXX_Unmarshal(b []byte) error {
@@ -2238,7 +2579,7 @@ func (m *DeviceQueueItem) Reset()         { *m = DeviceQueueItem{} }
 func (m *DeviceQueueItem) String() string { return proto.CompactTextString(m) }
 func (*DeviceQueueItem) ProtoMessage()    {}
 func (*DeviceQueueItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{42}
+	return fileDescriptor_3b280de855f92a4a, []int{45}
 }
 
 func (m *DeviceQueueItem) XXX_Unmarshal(b []byte) error {
@@ -2312,7 +2653,7 @@ func (m *CreateDeviceQueueItemRequest) Reset()         { *m = CreateDeviceQueueI
 func (m *CreateDeviceQueueItemRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceQueueItemRequest) ProtoMessage()    {}
 func (*CreateDeviceQueueItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{43}
+	return fileDescriptor_3b280de855f92a4a, []int{46}
 }
 
 func (m *CreateDeviceQueueItemRequest) XXX_Unmarshal(b []byte) error {
@@ -2352,7 +2693,7 @@ func (m *FlushDeviceQueueForDevEUIRequest) Reset()         { *m = FlushDeviceQue
 func (m *FlushDeviceQueueForDevEUIRequest) String() string { return proto.CompactTextString(m) }
 func (*FlushDeviceQueueForDevEUIRequest) ProtoMessage()    {}
 func (*FlushDeviceQueueForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{44}
+	return fileDescriptor_3b280de855f92a4a, []int{47}
 }
 
 func (m *FlushDeviceQueueForDevEUIRequest) XXX_Unmarshal(b []byte) error {
@@ -2392,7 +2733,7 @@ func (m *GetDeviceQueueItemsForDevEUIRequest) Reset()         { *m = GetDeviceQu
 func (m *GetDeviceQueueItemsForDevEUIRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceQueueItemsForDevEUIRequest) ProtoMessage()    {}
 func (*GetDeviceQueueItemsForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{45}
+	return fileDescriptor_3b280de855f92a4a, []int{48}
 }
 
 func (m *GetDeviceQueueItemsForDevEUIRequest) XXX_Unmarshal(b []byte) error {
@@ -2431,7 +2772,7 @@ func (m *GetDeviceQueueItemsForDevEUIResponse) Reset()         { *m = GetDeviceQ
 func (m *GetDeviceQueueItemsForDevEUIResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceQueueItemsForDevEUIResponse) ProtoMessage()    {}
 func (*GetDeviceQueueItemsForDevEUIResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{46}
+	return fileDescriptor_3b280de855f92a4a, []int{49}
 }
 
 func (m *GetDeviceQueueItemsForDevEUIResponse) XXX_Unmarshal(b []byte) error {
@@ -2471,7 +2812,7 @@ func (m *GetNextDownlinkFCntForDevEUIRequest) Reset()         { *m = GetNextDown
 func (m *GetNextDownlinkFCntForDevEUIRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNextDownlinkFCntForDevEUIRequest) ProtoMessage()    {}
 func (*GetNextDownlinkFCntForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{47}
+	return fileDescriptor_3b280de855f92a4a, []int{50}
 }
 
 func (m *GetNextDownlinkFCntForDevEUIRequest) XXX_Unmarshal(b []byte) error {
@@ -2511,7 +2852,7 @@ func (m *GetNextDownlinkFCntForDevEUIResponse) Reset()         { *m = GetNextDow
 func (m *GetNextDownlinkFCntForDevEUIResponse) String() string { return proto.CompactTextString(m) }
 func (*GetNextDownlinkFCntForDevEUIResponse) ProtoMessage()    {}
 func (*GetNextDownlinkFCntForDevEUIResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{48}
+	return fileDescriptor_3b280de855f92a4a, []int{51}
 }
 
 func (m *GetNextDownlinkFCntForDevEUIResponse) XXX_Unmarshal(b []byte) error {
@@ -2539,67 +2880,201 @@ func (m *GetNextDownlinkFCntForDevEUIResponse) GetFCnt() uint32 {
 	return 0
 }
 
-type StreamFrameLogsForGatewayRequest struct {
-	// MAC address of the gateway.
-	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type ExecGatewayCommandRequest struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Command to execute.
+	Command string `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	// Standard input.
+	Stdin []byte `protobuf:"bytes,3,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// Environment variables.
+	Environment          map[string]string `protobuf:"bytes,4,rep,name=environment,proto3" json:"environment,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *StreamFrameLogsForGatewayRequest) Reset()         { *m = StreamFrameLogsForGatewayRequest{} }
-func (m *StreamFrameLogsForGatewayRequest) String() string { return proto.CompactTextString(m) }
-func (*StreamFrameLogsForGatewayRequest) ProtoMessage()    {}
-func (*StreamFrameLogsForGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{49}
+func (m *ExecGatewayCommandRequest) Reset()         { *m = ExecGatewayCommandRequest{} }
+func (m *ExecGatewayCommandRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecGatewayCommandRequest) ProtoMessage()    {}
+func (*ExecGatewayCommandRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{52}
 }
 
-func (m *StreamFrameLogsForGatewayRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StreamFrameLogsForGatewayRequest.Unmarshal(m, b)
+func (m *ExecGatewayCommandRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecGatewayCommandRequest.Unmarshal(m, b)
 }
-func (m *StreamFrameLogsForGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StreamFrameLogsForGatewayRequest.Marshal(b, m, deterministic)
+func (m *ExecGatewayCommandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecGatewayCommandRequest.Marshal(b, m, deterministic)
 }
-func (m *StreamFrameLogsForGatewayRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StreamFrameLogsForGatewayRequest.Merge(m, src)
+func (m *ExecGatewayCommandRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecGatewayCommandRequest.Merge(m, src)
 }
-func (m *StreamFrameLogsForGatewayRequest) XXX_Size() int {
-	return xxx_messageInfo_StreamFrameLogsForGatewayRequest.Size(m)
+func (m *ExecGatewayCommandRequest) XXX_Size() int {
+	return xxx_messageInfo_ExecGatewayCommandRequest.Size(m)
 }
-func (m *StreamFrameLogsForGatewayRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StreamFrameLogsForGatewayRequest.DiscardUnknown(m)
+func (m *ExecGatewayCommandRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecGatewayCommandRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StreamFrameLogsForGatewayRequest proto.InternalMessageInfo
+var xxx_messageInfo_ExecGatewayCommandRequest proto.InternalMessageInfo
 
-func (m *StreamFrameLogsForGatewayRequest) GetGatewayId() []byte {
+func (m *ExecGatewayCommandRequest) GetGatewayId() []byte {
 	if m != nil {
 		return m.GatewayId
 	}
 	return nil
 }
 
-type StreamFrameLogsForGatewayResponse struct {
-	// Types that are valid to be assigned to Frame:
-	//	*StreamFrameLogsForGatewayResponse_UplinkFrameSet
-	//	*StreamFrameLogsForGatewayResponse_DownlinkFrame
-	Frame                isStreamFrameLogsForGatewayResponse_Frame `protobuf_oneof:"frame"`
-	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
-	XXX_unrecognized     []byte                                    `json:"-"`
-	XXX_sizecache        int32                                     `json:"-"`
+func (m *ExecGatewayCommandRequest) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
 }
 
-func (m *StreamFrameLogsForGatewayResponse) Reset()         { *m = StreamFrameLogsForGatewayResponse{} }
-func (m *StreamFrameLogsForGatewayResponse) String() string { return proto.CompactTextString(m) }
-func (*StreamFrameLogsForGatewayResponse) ProtoMessage()    {}
-func (*StreamFrameLogsForGatewayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{50}
+func (m *ExecGatewayCommandRequest) GetStdin() []byte {
+	if m != nil {
+		return m.Stdin
+	}
+	return nil
 }
 
-func (m *StreamFrameLogsForGatewayResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StreamFrameLogsForGatewayResponse.Unmarshal(m, b)
-}
-func (m *StreamFrameLogsForGatewayResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ExecGatewayCommandRequest) GetEnvironment() map[string]string {
+	if m != nil {
+		return m.Environment
+	}
+	return nil
+}
+
+type ExecGatewayCommandResponse struct {
+	// Execution request ID (UUID).
+	ExecId []byte `protobuf:"bytes,1,opt,name=exec_id,json=execId,proto3" json:"exec_id,omitempty"`
+	// Standard output.
+	Stdout []byte `protobuf:"bytes,2,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	// Standard error.
+	Stderr []byte `protobuf:"bytes,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	// Error message (returned by the gateway).
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecGatewayCommandResponse) Reset()         { *m = ExecGatewayCommandResponse{} }
+func (m *ExecGatewayCommandResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecGatewayCommandResponse) ProtoMessage()    {}
+func (*ExecGatewayCommandResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{53}
+}
+
+func (m *ExecGatewayCommandResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecGatewayCommandResponse.Unmarshal(m, b)
+}
+func (m *ExecGatewayCommandResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecGatewayCommandResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecGatewayCommandResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecGatewayCommandResponse.Merge(m, src)
+}
+func (m *ExecGatewayCommandResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecGatewayCommandResponse.Size(m)
+}
+func (m *ExecGatewayCommandResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecGatewayCommandResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecGatewayCommandResponse proto.InternalMessageInfo
+
+func (m *ExecGatewayCommandResponse) GetExecId() []byte {
+	if m != nil {
+		return m.ExecId
+	}
+	return nil
+}
+
+func (m *ExecGatewayCommandResponse) GetStdout() []byte {
+	if m != nil {
+		return m.Stdout
+	}
+	return nil
+}
+
+func (m *ExecGatewayCommandResponse) GetStderr() []byte {
+	if m != nil {
+		return m.Stderr
+	}
+	return nil
+}
+
+func (m *ExecGatewayCommandResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type StreamFrameLogsForGatewayRequest struct {
+	// MAC address of the gateway.
+	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamFrameLogsForGatewayRequest) Reset()         { *m = StreamFrameLogsForGatewayRequest{} }
+func (m *StreamFrameLogsForGatewayRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamFrameLogsForGatewayRequest) ProtoMessage()    {}
+func (*StreamFrameLogsForGatewayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{54}
+}
+
+func (m *StreamFrameLogsForGatewayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamFrameLogsForGatewayRequest.Unmarshal(m, b)
+}
+func (m *StreamFrameLogsForGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamFrameLogsForGatewayRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamFrameLogsForGatewayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamFrameLogsForGatewayRequest.Merge(m, src)
+}
+func (m *StreamFrameLogsForGatewayRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamFrameLogsForGatewayRequest.Size(m)
+}
+func (m *StreamFrameLogsForGatewayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamFrameLogsForGatewayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamFrameLogsForGatewayRequest proto.InternalMessageInfo
+
+func (m *StreamFrameLogsForGatewayRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+type StreamFrameLogsForGatewayResponse struct {
+	// Types that are valid to be assigned to Frame:
+	//	*StreamFrameLogsForGatewayResponse_UplinkFrameSet
+	//	*StreamFrameLogsForGatewayResponse_DownlinkFrame
+	Frame                isStreamFrameLogsForGatewayResponse_Frame `protobuf_oneof:"frame"`
+	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
+	XXX_unrecognized     []byte                                    `json:"-"`
+	XXX_sizecache        int32                                     `json:"-"`
+}
+
+func (m *StreamFrameLogsForGatewayResponse) Reset()         { *m = StreamFrameLogsForGatewayResponse{} }
+func (m *StreamFrameLogsForGatewayResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamFrameLogsForGatewayResponse) ProtoMessage()    {}
+func (*StreamFrameLogsForGatewayResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{55}
+}
+
+func (m *StreamFrameLogsForGatewayResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamFrameLogsForGatewayResponse.Unmarshal(m, b)
+}
+func (m *StreamFrameLogsForGatewayResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_StreamFrameLogsForGatewayResponse.Marshal(b, m, deterministic)
 }
 func (m *StreamFrameLogsForGatewayResponse) XXX_Merge(src proto.Message) {
@@ -2671,7 +3146,7 @@ func (m *StreamFrameLogsForDeviceRequest) Reset()         { *m = StreamFrameLogs
 func (m *StreamFrameLogsForDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForDeviceRequest) ProtoMessage()    {}
 func (*StreamFrameLogsForDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{51}
+	return fileDescriptor_3b280de855f92a4a, []int{56}
 }
 
 func (m *StreamFrameLogsForDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -2713,7 +3188,7 @@ func (m *StreamFrameLogsForDeviceResponse) Reset()         { *m = StreamFrameLog
 func (m *StreamFrameLogsForDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForDeviceResponse) ProtoMessage()    {}
 func (*StreamFrameLogsForDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{52}
+	return fileDescriptor_3b280de855f92a4a, []int{57}
 }
 
 func (m *StreamFrameLogsForDeviceResponse) XXX_Unmarshal(b []byte) error {
@@ -2793,7 +3268,7 @@ func (m *GetVersionResponse) Reset()         { *m = GetVersionResponse{} }
 func (m *GetVersionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetVersionResponse) ProtoMessage()    {}
 func (*GetVersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{53}
+	return fileDescriptor_3b280de855f92a4a, []int{58}
 }
 
 func (m *GetVersionResponse) XXX_Unmarshal(b []byte) error {
@@ -2828,6 +3303,95 @@ func (m *GetVersionResponse) GetRegion() common.Region {
 	return common.Region_EU868
 }
 
+type ADRAlgorithm struct {
+	// ADR algorithm ID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ADR algorithm name.
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ADRAlgorithm) Reset()         { *m = ADRAlgorithm{} }
+func (m *ADRAlgorithm) String() string { return proto.CompactTextString(m) }
+func (*ADRAlgorithm) ProtoMessage()    {}
+func (*ADRAlgorithm) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{59}
+}
+
+func (m *ADRAlgorithm) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ADRAlgorithm.Unmarshal(m, b)
+}
+func (m *ADRAlgorithm) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ADRAlgorithm.Marshal(b, m, deterministic)
+}
+func (m *ADRAlgorithm) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ADRAlgorithm.Merge(m, src)
+}
+func (m *ADRAlgorithm) XXX_Size() int {
+	return xxx_messageInfo_ADRAlgorithm.Size(m)
+}
+func (m *ADRAlgorithm) XXX_DiscardUnknown() {
+	xxx_messageInfo_ADRAlgorithm.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ADRAlgorithm proto.InternalMessageInfo
+
+func (m *ADRAlgorithm) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ADRAlgorithm) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type GetADRAlgorithmsResponse struct {
+	// Available ADR algorithms.
+	AdrAlgorithms        []*ADRAlgorithm `protobuf:"bytes,1,rep,name=adr_algorithms,json=adrAlgorithms,proto3" json:"adr_algorithms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetADRAlgorithmsResponse) Reset()         { *m = GetADRAlgorithmsResponse{} }
+func (m *GetADRAlgorithmsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetADRAlgorithmsResponse) ProtoMessage()    {}
+func (*GetADRAlgorithmsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{60}
+}
+
+func (m *GetADRAlgorithmsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetADRAlgorithmsResponse.Unmarshal(m, b)
+}
+func (m *GetADRAlgorithmsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetADRAlgorithmsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetADRAlgorithmsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetADRAlgorithmsResponse.Merge(m, src)
+}
+func (m *GetADRAlgorithmsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetADRAlgorithmsResponse.Size(m)
+}
+func (m *GetADRAlgorithmsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetADRAlgorithmsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetADRAlgorithmsResponse proto.InternalMessageInfo
+
+func (m *GetADRAlgorithmsResponse) GetAdrAlgorithms() []*ADRAlgorithm {
+	if m != nil {
+		return m.AdrAlgorithms
+	}
+	return nil
+}
+
 type GatewayProfile struct {
 	// ID of the gateway-profile.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -2836,17 +3400,28 @@ type GatewayProfile struct {
 	Channels []uint32 `protobuf:"varint,2,rep,packed,name=channels,proto3" json:"channels,omitempty"`
 	// Extra channels added to the channel-configuration (in case the LoRaWAN
 	// region supports adding custom channels).
-	ExtraChannels        []*GatewayProfileExtraChannel `protobuf:"bytes,3,rep,name=extra_channels,json=extraChannels,proto3" json:"extra_channels,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
+	ExtraChannels []*GatewayProfileExtraChannel `protobuf:"bytes,3,rep,name=extra_channels,json=extraChannels,proto3" json:"extra_channels,omitempty"`
+	// Stats interval.
+	// This must match the stats interval configured in the gateway (e.g.
+	// ChirpStack Gateway Bridge) and is used to detect offline gateways.
+	// When not set, the default stats interval from the configuration is
+	// used.
+	StatsInterval *duration.Duration `protobuf:"bytes,4,opt,name=stats_interval,json=statsInterval,proto3" json:"stats_interval,omitempty"`
+	// Class-B beacon transmission.
+	// When enabled, ChirpStack Network Server will schedule the Class-B
+	// beacon transmissions for the gateways using this profile. This
+	// requires the gateway to be GPS equipped.
+	BeaconEnabled        bool     `protobuf:"varint,5,opt,name=beacon_enabled,json=beaconEnabled,proto3" json:"beacon_enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GatewayProfile) Reset()         { *m = GatewayProfile{} }
 func (m *GatewayProfile) String() string { return proto.CompactTextString(m) }
 func (*GatewayProfile) ProtoMessage()    {}
 func (*GatewayProfile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{54}
+	return fileDescriptor_3b280de855f92a4a, []int{61}
 }
 
 func (m *GatewayProfile) XXX_Unmarshal(b []byte) error {
@@ -2888,6 +3463,20 @@ func (m *GatewayProfile) GetExtraChannels() []*GatewayProfileExtraChannel {
 	return nil
 }
 
+func (m *GatewayProfile) GetStatsInterval() *duration.Duration {
+	if m != nil {
+		return m.StatsInterval
+	}
+	return nil
+}
+
+func (m *GatewayProfile) GetBeaconEnabled() bool {
+	if m != nil {
+		return m.BeaconEnabled
+	}
+	return false
+}
+
 type GatewayProfileExtraChannel struct {
 	// Modulation.
 	Modulation common.Modulation `protobuf:"varint,1,opt,name=modulation,proto3,enum=common.Modulation" json:"modulation,omitempty"`
@@ -2908,7 +3497,7 @@ func (m *GatewayProfileExtraChannel) Reset()         { *m = GatewayProfileExtraC
 func (m *GatewayProfileExtraChannel) String() string { return proto.CompactTextString(m) }
 func (*GatewayProfileExtraChannel) ProtoMessage()    {}
 func (*GatewayProfileExtraChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{55}
+	return fileDescriptor_3b280de855f92a4a, []int{62}
 }
 
 func (m *GatewayProfileExtraChannel) XXX_Unmarshal(b []byte) error {
@@ -2976,7 +3565,7 @@ func (m *CreateGatewayProfileRequest) Reset()         { *m = CreateGatewayProfil
 func (m *CreateGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateGatewayProfileRequest) ProtoMessage()    {}
 func (*CreateGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{56}
+	return fileDescriptor_3b280de855f92a4a, []int{63}
 }
 
 func (m *CreateGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -3016,7 +3605,7 @@ func (m *CreateGatewayProfileResponse) Reset()         { *m = CreateGatewayProfi
 func (m *CreateGatewayProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateGatewayProfileResponse) ProtoMessage()    {}
 func (*CreateGatewayProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{57}
+	return fileDescriptor_3b280de855f92a4a, []int{64}
 }
 
 func (m *CreateGatewayProfileResponse) XXX_Unmarshal(b []byte) error {
@@ -3056,7 +3645,7 @@ func (m *GetGatewayProfileRequest) Reset()         { *m = GetGatewayProfileReque
 func (m *GetGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayProfileRequest) ProtoMessage()    {}
 func (*GetGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{58}
+	return fileDescriptor_3b280de855f92a4a, []int{65}
 }
 
 func (m *GetGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -3100,7 +3689,7 @@ func (m *GetGatewayProfileResponse) Reset()         { *m = GetGatewayProfileResp
 func (m *GetGatewayProfileResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayProfileResponse) ProtoMessage()    {}
 func (*GetGatewayProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{59}
+	return fileDescriptor_3b280de855f92a4a, []int{66}
 }
 
 func (m *GetGatewayProfileResponse) XXX_Unmarshal(b []byte) error {
@@ -3154,7 +3743,7 @@ func (m *UpdateGatewayProfileRequest) Reset()         { *m = UpdateGatewayProfil
 func (m *UpdateGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateGatewayProfileRequest) ProtoMessage()    {}
 func (*UpdateGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{60}
+	return fileDescriptor_3b280de855f92a4a, []int{67}
 }
 
 func (m *UpdateGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -3194,7 +3783,7 @@ func (m *DeleteGatewayProfileRequest) Reset()         { *m = DeleteGatewayProfil
 func (m *DeleteGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteGatewayProfileRequest) ProtoMessage()    {}
 func (*DeleteGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{61}
+	return fileDescriptor_3b280de855f92a4a, []int{68}
 }
 
 func (m *DeleteGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
@@ -3255,7 +3844,7 @@ func (m *MulticastGroup) Reset()         { *m = MulticastGroup{} }
 func (m *MulticastGroup) String() string { return proto.CompactTextString(m) }
 func (*MulticastGroup) ProtoMessage()    {}
 func (*MulticastGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{62}
+	return fileDescriptor_3b280de855f92a4a, []int{69}
 }
 
 func (m *MulticastGroup) XXX_Unmarshal(b []byte) error {
@@ -3358,7 +3947,7 @@ func (m *CreateMulticastGroupRequest) Reset()         { *m = CreateMulticastGrou
 func (m *CreateMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateMulticastGroupRequest) ProtoMessage()    {}
 func (*CreateMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{63}
+	return fileDescriptor_3b280de855f92a4a, []int{70}
 }
 
 func (m *CreateMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3398,7 +3987,7 @@ func (m *CreateMulticastGroupResponse) Reset()         { *m = CreateMulticastGro
 func (m *CreateMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateMulticastGroupResponse) ProtoMessage()    {}
 func (*CreateMulticastGroupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{64}
+	return fileDescriptor_3b280de855f92a4a, []int{71}
 }
 
 func (m *CreateMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
@@ -3438,7 +4027,7 @@ func (m *GetMulticastGroupRequest) Reset()         { *m = GetMulticastGroupReque
 func (m *GetMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*GetMulticastGroupRequest) ProtoMessage()    {}
 func (*GetMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{65}
+	return fileDescriptor_3b280de855f92a4a, []int{72}
 }
 
 func (m *GetMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3482,7 +4071,7 @@ func (m *GetMulticastGroupResponse) Reset()         { *m = GetMulticastGroupResp
 func (m *GetMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
 func (*GetMulticastGroupResponse) ProtoMessage()    {}
 func (*GetMulticastGroupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{66}
+	return fileDescriptor_3b280de855f92a4a, []int{73}
 }
 
 func (m *GetMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
@@ -3536,7 +4125,7 @@ func (m *UpdateMulticastGroupRequest) Reset()         { *m = UpdateMulticastGrou
 func (m *UpdateMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateMulticastGroupRequest) ProtoMessage()    {}
 func (*UpdateMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{67}
+	return fileDescriptor_3b280de855f92a4a, []int{74}
 }
 
 func (m *UpdateMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3576,7 +4165,7 @@ func (m *DeleteMulticastGroupRequest) Reset()         { *m = DeleteMulticastGrou
 func (m *DeleteMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteMulticastGroupRequest) ProtoMessage()    {}
 func (*DeleteMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{68}
+	return fileDescriptor_3b280de855f92a4a, []int{75}
 }
 
 func (m *DeleteMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3618,7 +4207,7 @@ func (m *AddDeviceToMulticastGroupRequest) Reset()         { *m = AddDeviceToMul
 func (m *AddDeviceToMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*AddDeviceToMulticastGroupRequest) ProtoMessage()    {}
 func (*AddDeviceToMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{69}
+	return fileDescriptor_3b280de855f92a4a, []int{76}
 }
 
 func (m *AddDeviceToMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3667,7 +4256,7 @@ func (m *RemoveDeviceFromMulticastGroupRequest) Reset()         { *m = RemoveDev
 func (m *RemoveDeviceFromMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveDeviceFromMulticastGroupRequest) ProtoMessage()    {}
 func (*RemoveDeviceFromMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{70}
+	return fileDescriptor_3b280de855f92a4a, []int{77}
 }
 
 func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3720,7 +4309,7 @@ func (m *MulticastQueueItem) Reset()         { *m = MulticastQueueItem{} }
 func (m *MulticastQueueItem) String() string { return proto.CompactTextString(m) }
 func (*MulticastQueueItem) ProtoMessage()    {}
 func (*MulticastQueueItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{71}
+	return fileDescriptor_3b280de855f92a4a, []int{78}
 }
 
 func (m *MulticastQueueItem) XXX_Unmarshal(b []byte) error {
@@ -3780,7 +4369,7 @@ func (m *EnqueueMulticastQueueItemRequest) Reset()         { *m = EnqueueMultica
 func (m *EnqueueMulticastQueueItemRequest) String() string { return proto.CompactTextString(m) }
 func (*EnqueueMulticastQueueItemRequest) ProtoMessage()    {}
 func (*EnqueueMulticastQueueItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{72}
+	return fileDescriptor_3b280de855f92a4a, []int{79}
 }
 
 func (m *EnqueueMulticastQueueItemRequest) XXX_Unmarshal(b []byte) error {
@@ -3824,7 +4413,7 @@ func (m *FlushMulticastQueueForMulticastGroupRequest) String() string {
 }
 func (*FlushMulticastQueueForMulticastGroupRequest) ProtoMessage() {}
 func (*FlushMulticastQueueForMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{73}
+	return fileDescriptor_3b280de855f92a4a, []int{80}
 }
 
 func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3868,7 +4457,7 @@ func (m *GetMulticastQueueItemsForMulticastGroupRequest) String() string {
 }
 func (*GetMulticastQueueItemsForMulticastGroupRequest) ProtoMessage() {}
 func (*GetMulticastQueueItemsForMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{74}
+	return fileDescriptor_3b280de855f92a4a, []int{81}
 }
 
 func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
@@ -3909,40 +4498,1184 @@ func (m *GetMulticastQueueItemsForMulticastGroupResponse) Reset() {
 func (m *GetMulticastQueueItemsForMulticastGroupResponse) String() string {
 	return proto.CompactTextString(m)
 }
-func (*GetMulticastQueueItemsForMulticastGroupResponse) ProtoMessage() {}
-func (*GetMulticastQueueItemsForMulticastGroupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{75}
+func (*GetMulticastQueueItemsForMulticastGroupResponse) ProtoMessage() {}
+func (*GetMulticastQueueItemsForMulticastGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{82}
+}
+
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Merge(m, src)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Size(m)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse proto.InternalMessageInfo
+
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) GetMulticastQueueItems() []*MulticastQueueItem {
+	if m != nil {
+		return m.MulticastQueueItems
+	}
+	return nil
+}
+
+type MulticastQueueItemDelivery struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Delivery status.
+	Status MulticastDeliveryStatus `protobuf:"varint,2,opt,name=status,proto3,enum=ns.MulticastDeliveryStatus" json:"status,omitempty"`
+	// Error code.
+	// This contains the tx acknowledgement error reported by the gateway,
+	// PAYLOAD_SIZE in case the frame was discarded or DUTY_CYCLE or
+	// TX_SCHEDULE in case the frame has been rescheduled.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *MulticastQueueItemDelivery) Reset()         { *m = MulticastQueueItemDelivery{} }
+func (m *MulticastQueueItemDelivery) String() string { return proto.CompactTextString(m) }
+func (*MulticastQueueItemDelivery) ProtoMessage()    {}
+func (*MulticastQueueItemDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{83}
+}
+
+func (m *MulticastQueueItemDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastQueueItemDelivery.Unmarshal(m, b)
+}
+func (m *MulticastQueueItemDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastQueueItemDelivery.Marshal(b, m, deterministic)
+}
+func (m *MulticastQueueItemDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastQueueItemDelivery.Merge(m, src)
+}
+func (m *MulticastQueueItemDelivery) XXX_Size() int {
+	return xxx_messageInfo_MulticastQueueItemDelivery.Size(m)
+}
+func (m *MulticastQueueItemDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastQueueItemDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastQueueItemDelivery proto.InternalMessageInfo
+
+func (m *MulticastQueueItemDelivery) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *MulticastQueueItemDelivery) GetStatus() MulticastDeliveryStatus {
+	if m != nil {
+		return m.Status
+	}
+	return MulticastDeliveryStatus_PENDING
+}
+
+func (m *MulticastQueueItemDelivery) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *MulticastQueueItemDelivery) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *MulticastQueueItemDelivery) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type GetMulticastQueueItemDeliveryReportRequest struct {
+	// Multicast-group id.
+	MulticastGroupId []byte `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	// Frame-counter of the queue-item.
+	FCnt                 uint32   `protobuf:"varint,2,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastQueueItemDeliveryReportRequest) Reset() {
+	*m = GetMulticastQueueItemDeliveryReportRequest{}
+}
+func (m *GetMulticastQueueItemDeliveryReportRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*GetMulticastQueueItemDeliveryReportRequest) ProtoMessage() {}
+func (*GetMulticastQueueItemDeliveryReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{84}
+}
+
+func (m *GetMulticastQueueItemDeliveryReportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastQueueItemDeliveryReportRequest.Unmarshal(m, b)
+}
+func (m *GetMulticastQueueItemDeliveryReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastQueueItemDeliveryReportRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastQueueItemDeliveryReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastQueueItemDeliveryReportRequest.Merge(m, src)
+}
+func (m *GetMulticastQueueItemDeliveryReportRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastQueueItemDeliveryReportRequest.Size(m)
+}
+func (m *GetMulticastQueueItemDeliveryReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastQueueItemDeliveryReportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastQueueItemDeliveryReportRequest proto.InternalMessageInfo
+
+func (m *GetMulticastQueueItemDeliveryReportRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+func (m *GetMulticastQueueItemDeliveryReportRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+type GetMulticastQueueItemDeliveryReportResponse struct {
+	// Number of gateways that were used for the delivery.
+	GatewaysAttempted uint32 `protobuf:"varint,1,opt,name=gateways_attempted,json=gatewaysAttempted,proto3" json:"gateways_attempted,omitempty"`
+	// Number of gateways that acknowledged the transmission.
+	GatewaysSucceeded uint32 `protobuf:"varint,2,opt,name=gateways_succeeded,json=gatewaysSucceeded,proto3" json:"gateways_succeeded,omitempty"`
+	// Number of gateways that failed the transmission.
+	GatewaysFailed uint32 `protobuf:"varint,3,opt,name=gateways_failed,json=gatewaysFailed,proto3" json:"gateways_failed,omitempty"`
+	// Delivery per gateway.
+	Deliveries           []*MulticastQueueItemDelivery `protobuf:"bytes,4,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *GetMulticastQueueItemDeliveryReportResponse) Reset() {
+	*m = GetMulticastQueueItemDeliveryReportResponse{}
+}
+func (m *GetMulticastQueueItemDeliveryReportResponse) String() string {
+	return proto.CompactTextString(m)
+}
+func (*GetMulticastQueueItemDeliveryReportResponse) ProtoMessage() {}
+func (*GetMulticastQueueItemDeliveryReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{85}
+}
+
+func (m *GetMulticastQueueItemDeliveryReportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastQueueItemDeliveryReportResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastQueueItemDeliveryReportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastQueueItemDeliveryReportResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastQueueItemDeliveryReportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastQueueItemDeliveryReportResponse.Merge(m, src)
+}
+func (m *GetMulticastQueueItemDeliveryReportResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastQueueItemDeliveryReportResponse.Size(m)
+}
+func (m *GetMulticastQueueItemDeliveryReportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastQueueItemDeliveryReportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastQueueItemDeliveryReportResponse proto.InternalMessageInfo
+
+func (m *GetMulticastQueueItemDeliveryReportResponse) GetGatewaysAttempted() uint32 {
+	if m != nil {
+		return m.GatewaysAttempted
+	}
+	return 0
+}
+
+func (m *GetMulticastQueueItemDeliveryReportResponse) GetGatewaysSucceeded() uint32 {
+	if m != nil {
+		return m.GatewaysSucceeded
+	}
+	return 0
+}
+
+func (m *GetMulticastQueueItemDeliveryReportResponse) GetGatewaysFailed() uint32 {
+	if m != nil {
+		return m.GatewaysFailed
+	}
+	return 0
+}
+
+func (m *GetMulticastQueueItemDeliveryReportResponse) GetDeliveries() []*MulticastQueueItemDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+type GetMulticastGroupSetupCommandsRequest struct {
+	// Multicast-group id.
+	MulticastGroupId []byte `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	// Multicast-group id on the device (0 - 3).
+	McGroupId uint32 `protobuf:"varint,2,opt,name=mc_group_id,json=mcGroupId,proto3" json:"mc_group_id,omitempty"`
+	// McKey, encrypted using the McKEKey of the device.
+	McKeyEncrypted []byte `protobuf:"bytes,3,opt,name=mc_key_encrypted,json=mcKeyEncrypted,proto3" json:"mc_key_encrypted,omitempty"`
+	// Max. multicast frame-counter.
+	// When set to 0, the session is valid until the frame-counter rolls over.
+	MaxMcFCnt uint32 `protobuf:"varint,4,opt,name=max_mc_f_cnt,json=maxMcFCnt,proto3" json:"max_mc_f_cnt,omitempty"`
+	// Session time-out (2^session_time_out seconds, max. 15).
+	SessionTimeOut uint32 `protobuf:"varint,5,opt,name=session_time_out,json=sessionTimeOut,proto3" json:"session_time_out,omitempty"`
+	// The session will not start before this timestamp.
+	// When not set, the session starts as soon as possible.
+	SessionStartAfter    *timestamp.Timestamp `protobuf:"bytes,6,opt,name=session_start_after,json=sessionStartAfter,proto3" json:"session_start_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetMulticastGroupSetupCommandsRequest) Reset()         { *m = GetMulticastGroupSetupCommandsRequest{} }
+func (m *GetMulticastGroupSetupCommandsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastGroupSetupCommandsRequest) ProtoMessage()    {}
+func (*GetMulticastGroupSetupCommandsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{86}
+}
+
+func (m *GetMulticastGroupSetupCommandsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastGroupSetupCommandsRequest.Unmarshal(m, b)
+}
+func (m *GetMulticastGroupSetupCommandsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastGroupSetupCommandsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastGroupSetupCommandsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastGroupSetupCommandsRequest.Merge(m, src)
+}
+func (m *GetMulticastGroupSetupCommandsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastGroupSetupCommandsRequest.Size(m)
+}
+func (m *GetMulticastGroupSetupCommandsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastGroupSetupCommandsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastGroupSetupCommandsRequest proto.InternalMessageInfo
+
+func (m *GetMulticastGroupSetupCommandsRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+func (m *GetMulticastGroupSetupCommandsRequest) GetMcGroupId() uint32 {
+	if m != nil {
+		return m.McGroupId
+	}
+	return 0
+}
+
+func (m *GetMulticastGroupSetupCommandsRequest) GetMcKeyEncrypted() []byte {
+	if m != nil {
+		return m.McKeyEncrypted
+	}
+	return nil
+}
+
+func (m *GetMulticastGroupSetupCommandsRequest) GetMaxMcFCnt() uint32 {
+	if m != nil {
+		return m.MaxMcFCnt
+	}
+	return 0
+}
+
+func (m *GetMulticastGroupSetupCommandsRequest) GetSessionTimeOut() uint32 {
+	if m != nil {
+		return m.SessionTimeOut
+	}
+	return 0
+}
+
+func (m *GetMulticastGroupSetupCommandsRequest) GetSessionStartAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.SessionStartAfter
+	}
+	return nil
+}
+
+type GetMulticastGroupSetupCommandsResponse struct {
+	// FPort of the Remote Multicast Setup package.
+	FPort uint32 `protobuf:"varint,1,opt,name=f_port,json=fPort,proto3" json:"f_port,omitempty"`
+	// McGroupSetupReq command.
+	McGroupSetupReq []byte `protobuf:"bytes,2,opt,name=mc_group_setup_req,json=mcGroupSetupReq,proto3" json:"mc_group_setup_req,omitempty"`
+	// McClassBSessionReq or McClassCSessionReq command (depending on the
+	// multicast-group type).
+	McSessionReq []byte `protobuf:"bytes,3,opt,name=mc_session_req,json=mcSessionReq,proto3" json:"mc_session_req,omitempty"`
+	// Session start time (time since GPS epoch).
+	SessionTime          *duration.Duration `protobuf:"bytes,4,opt,name=session_time,json=sessionTime,proto3" json:"session_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetMulticastGroupSetupCommandsResponse) Reset() {
+	*m = GetMulticastGroupSetupCommandsResponse{}
+}
+func (m *GetMulticastGroupSetupCommandsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastGroupSetupCommandsResponse) ProtoMessage()    {}
+func (*GetMulticastGroupSetupCommandsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{87}
+}
+
+func (m *GetMulticastGroupSetupCommandsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastGroupSetupCommandsResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastGroupSetupCommandsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastGroupSetupCommandsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastGroupSetupCommandsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastGroupSetupCommandsResponse.Merge(m, src)
+}
+func (m *GetMulticastGroupSetupCommandsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastGroupSetupCommandsResponse.Size(m)
+}
+func (m *GetMulticastGroupSetupCommandsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastGroupSetupCommandsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastGroupSetupCommandsResponse proto.InternalMessageInfo
+
+func (m *GetMulticastGroupSetupCommandsResponse) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *GetMulticastGroupSetupCommandsResponse) GetMcGroupSetupReq() []byte {
+	if m != nil {
+		return m.McGroupSetupReq
+	}
+	return nil
+}
+
+func (m *GetMulticastGroupSetupCommandsResponse) GetMcSessionReq() []byte {
+	if m != nil {
+		return m.McSessionReq
+	}
+	return nil
+}
+
+func (m *GetMulticastGroupSetupCommandsResponse) GetSessionTime() *duration.Duration {
+	if m != nil {
+		return m.SessionTime
+	}
+	return nil
+}
+
+type EnqueueMulticastGroupSetupRequest struct {
+	// Multicast-group id.
+	MulticastGroupId []byte `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	// Multicast-group id on the device (0 - 3).
+	McGroupId uint32 `protobuf:"varint,2,opt,name=mc_group_id,json=mcGroupId,proto3" json:"mc_group_id,omitempty"`
+	// Session start time (time since GPS epoch), as returned by
+	// GetMulticastGroupSetupCommands.
+	SessionTime *duration.Duration `protobuf:"bytes,3,opt,name=session_time,json=sessionTime,proto3" json:"session_time,omitempty"`
+	// Device-queue items containing the McGroupSetupReq and the
+	// McClassBSessionReq or McClassCSessionReq command, encrypted using the
+	// AppSKey of the device. The items must use FPort 200 and the devices
+	// must be member of the multicast-group.
+	Items                []*DeviceQueueItem `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *EnqueueMulticastGroupSetupRequest) Reset()         { *m = EnqueueMulticastGroupSetupRequest{} }
+func (m *EnqueueMulticastGroupSetupRequest) String() string { return proto.CompactTextString(m) }
+func (*EnqueueMulticastGroupSetupRequest) ProtoMessage()    {}
+func (*EnqueueMulticastGroupSetupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{88}
+}
+
+func (m *EnqueueMulticastGroupSetupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnqueueMulticastGroupSetupRequest.Unmarshal(m, b)
+}
+func (m *EnqueueMulticastGroupSetupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnqueueMulticastGroupSetupRequest.Marshal(b, m, deterministic)
+}
+func (m *EnqueueMulticastGroupSetupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnqueueMulticastGroupSetupRequest.Merge(m, src)
+}
+func (m *EnqueueMulticastGroupSetupRequest) XXX_Size() int {
+	return xxx_messageInfo_EnqueueMulticastGroupSetupRequest.Size(m)
+}
+func (m *EnqueueMulticastGroupSetupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnqueueMulticastGroupSetupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnqueueMulticastGroupSetupRequest proto.InternalMessageInfo
+
+func (m *EnqueueMulticastGroupSetupRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+func (m *EnqueueMulticastGroupSetupRequest) GetMcGroupId() uint32 {
+	if m != nil {
+		return m.McGroupId
+	}
+	return 0
+}
+
+func (m *EnqueueMulticastGroupSetupRequest) GetSessionTime() *duration.Duration {
+	if m != nil {
+		return m.SessionTime
+	}
+	return nil
+}
+
+func (m *EnqueueMulticastGroupSetupRequest) GetItems() []*DeviceQueueItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type DeviceMulticastGroupSetup struct {
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Multicast-group id on the device.
+	McGroupId uint32 `protobuf:"varint,2,opt,name=mc_group_id,json=mcGroupId,proto3" json:"mc_group_id,omitempty"`
+	// Setup state.
+	State MulticastSetupState `protobuf:"varint,3,opt,name=state,proto3,enum=ns.MulticastSetupState" json:"state,omitempty"`
+	// Error code (in case of SETUP_FAILED).
+	// ID_ERROR, MC_GROUP_UNDEFINED, FREQ_ERROR or DR_ERROR.
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Session start time (time since GPS epoch).
+	SessionTime *duration.Duration `protobuf:"bytes,5,opt,name=session_time,json=sessionTime,proto3" json:"session_time,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DeviceMulticastGroupSetup) Reset()         { *m = DeviceMulticastGroupSetup{} }
+func (m *DeviceMulticastGroupSetup) String() string { return proto.CompactTextString(m) }
+func (*DeviceMulticastGroupSetup) ProtoMessage()    {}
+func (*DeviceMulticastGroupSetup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{89}
+}
+
+func (m *DeviceMulticastGroupSetup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceMulticastGroupSetup.Unmarshal(m, b)
+}
+func (m *DeviceMulticastGroupSetup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceMulticastGroupSetup.Marshal(b, m, deterministic)
+}
+func (m *DeviceMulticastGroupSetup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceMulticastGroupSetup.Merge(m, src)
+}
+func (m *DeviceMulticastGroupSetup) XXX_Size() int {
+	return xxx_messageInfo_DeviceMulticastGroupSetup.Size(m)
+}
+func (m *DeviceMulticastGroupSetup) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceMulticastGroupSetup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceMulticastGroupSetup proto.InternalMessageInfo
+
+func (m *DeviceMulticastGroupSetup) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *DeviceMulticastGroupSetup) GetMcGroupId() uint32 {
+	if m != nil {
+		return m.McGroupId
+	}
+	return 0
+}
+
+func (m *DeviceMulticastGroupSetup) GetState() MulticastSetupState {
+	if m != nil {
+		return m.State
+	}
+	return MulticastSetupState_SETUP_PENDING
+}
+
+func (m *DeviceMulticastGroupSetup) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *DeviceMulticastGroupSetup) GetSessionTime() *duration.Duration {
+	if m != nil {
+		return m.SessionTime
+	}
+	return nil
+}
+
+func (m *DeviceMulticastGroupSetup) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *DeviceMulticastGroupSetup) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type GetMulticastGroupSetupStatusRequest struct {
+	// Multicast-group id.
+	MulticastGroupId     []byte   `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastGroupSetupStatusRequest) Reset()         { *m = GetMulticastGroupSetupStatusRequest{} }
+func (m *GetMulticastGroupSetupStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastGroupSetupStatusRequest) ProtoMessage()    {}
+func (*GetMulticastGroupSetupStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{90}
+}
+
+func (m *GetMulticastGroupSetupStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastGroupSetupStatusRequest.Unmarshal(m, b)
+}
+func (m *GetMulticastGroupSetupStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastGroupSetupStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastGroupSetupStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastGroupSetupStatusRequest.Merge(m, src)
+}
+func (m *GetMulticastGroupSetupStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastGroupSetupStatusRequest.Size(m)
+}
+func (m *GetMulticastGroupSetupStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastGroupSetupStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastGroupSetupStatusRequest proto.InternalMessageInfo
+
+func (m *GetMulticastGroupSetupStatusRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+type GetMulticastGroupSetupStatusResponse struct {
+	// Setup state per device.
+	Devices              []*DeviceMulticastGroupSetup `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *GetMulticastGroupSetupStatusResponse) Reset()         { *m = GetMulticastGroupSetupStatusResponse{} }
+func (m *GetMulticastGroupSetupStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastGroupSetupStatusResponse) ProtoMessage()    {}
+func (*GetMulticastGroupSetupStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{91}
+}
+
+func (m *GetMulticastGroupSetupStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastGroupSetupStatusResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastGroupSetupStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastGroupSetupStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastGroupSetupStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastGroupSetupStatusResponse.Merge(m, src)
+}
+func (m *GetMulticastGroupSetupStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastGroupSetupStatusResponse.Size(m)
+}
+func (m *GetMulticastGroupSetupStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastGroupSetupStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastGroupSetupStatusResponse proto.InternalMessageInfo
+
+func (m *GetMulticastGroupSetupStatusResponse) GetDevices() []*DeviceMulticastGroupSetup {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type HandleApplicationLayerUplinkRequest struct {
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// FPort of the application layer package (200 = Remote Multicast Setup,
+	// 201 = Fragmented Data Block Transport).
+	FPort uint32 `protobuf:"varint,2,opt,name=f_port,json=fPort,proto3" json:"f_port,omitempty"`
+	// FRMPayload, decrypted using the AppSKey of the device.
+	FrmPayload           []byte   `protobuf:"bytes,3,opt,name=frm_payload,json=frmPayload,proto3" json:"frm_payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HandleApplicationLayerUplinkRequest) Reset()         { *m = HandleApplicationLayerUplinkRequest{} }
+func (m *HandleApplicationLayerUplinkRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleApplicationLayerUplinkRequest) ProtoMessage()    {}
+func (*HandleApplicationLayerUplinkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{92}
+}
+
+func (m *HandleApplicationLayerUplinkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleApplicationLayerUplinkRequest.Unmarshal(m, b)
+}
+func (m *HandleApplicationLayerUplinkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleApplicationLayerUplinkRequest.Marshal(b, m, deterministic)
+}
+func (m *HandleApplicationLayerUplinkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleApplicationLayerUplinkRequest.Merge(m, src)
+}
+func (m *HandleApplicationLayerUplinkRequest) XXX_Size() int {
+	return xxx_messageInfo_HandleApplicationLayerUplinkRequest.Size(m)
+}
+func (m *HandleApplicationLayerUplinkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleApplicationLayerUplinkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleApplicationLayerUplinkRequest proto.InternalMessageInfo
+
+func (m *HandleApplicationLayerUplinkRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *HandleApplicationLayerUplinkRequest) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *HandleApplicationLayerUplinkRequest) GetFrmPayload() []byte {
+	if m != nil {
+		return m.FrmPayload
+	}
+	return nil
+}
+
+type GetMulticastDataFragmentsRequest struct {
+	// Multicast-group id.
+	MulticastGroupId []byte `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	// Multicast-group id on the device (0 - 3).
+	McGroupId uint32 `protobuf:"varint,2,opt,name=mc_group_id,json=mcGroupId,proto3" json:"mc_group_id,omitempty"`
+	// Fragmentation session index (0 - 3).
+	FragIndex uint32 `protobuf:"varint,3,opt,name=frag_index,json=fragIndex,proto3" json:"frag_index,omitempty"`
+	// Fragment size (bytes).
+	FragSize uint32 `protobuf:"varint,4,opt,name=frag_size,json=fragSize,proto3" json:"frag_size,omitempty"`
+	// Number of redundancy fragments.
+	Redundancy uint32 `protobuf:"varint,5,opt,name=redundancy,proto3" json:"redundancy,omitempty"`
+	// Block ack delay (used in the FragSessionSetupReq).
+	BlockAckDelay uint32 `protobuf:"varint,6,opt,name=block_ack_delay,json=blockAckDelay,proto3" json:"block_ack_delay,omitempty"`
+	// Fragmentation session descriptor (4 bytes, used in the FragSessionSetupReq).
+	FragSessionDescriptor []byte `protobuf:"bytes,7,opt,name=frag_session_descriptor,json=fragSessionDescriptor,proto3" json:"frag_session_descriptor,omitempty"`
+	// Data block.
+	Data                 []byte   `protobuf:"bytes,8,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastDataFragmentsRequest) Reset()         { *m = GetMulticastDataFragmentsRequest{} }
+func (m *GetMulticastDataFragmentsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastDataFragmentsRequest) ProtoMessage()    {}
+func (*GetMulticastDataFragmentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{93}
+}
+
+func (m *GetMulticastDataFragmentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastDataFragmentsRequest.Unmarshal(m, b)
+}
+func (m *GetMulticastDataFragmentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastDataFragmentsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastDataFragmentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastDataFragmentsRequest.Merge(m, src)
+}
+func (m *GetMulticastDataFragmentsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastDataFragmentsRequest.Size(m)
+}
+func (m *GetMulticastDataFragmentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastDataFragmentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastDataFragmentsRequest proto.InternalMessageInfo
+
+func (m *GetMulticastDataFragmentsRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+func (m *GetMulticastDataFragmentsRequest) GetMcGroupId() uint32 {
+	if m != nil {
+		return m.McGroupId
+	}
+	return 0
+}
+
+func (m *GetMulticastDataFragmentsRequest) GetFragIndex() uint32 {
+	if m != nil {
+		return m.FragIndex
+	}
+	return 0
+}
+
+func (m *GetMulticastDataFragmentsRequest) GetFragSize() uint32 {
+	if m != nil {
+		return m.FragSize
+	}
+	return 0
+}
+
+func (m *GetMulticastDataFragmentsRequest) GetRedundancy() uint32 {
+	if m != nil {
+		return m.Redundancy
+	}
+	return 0
+}
+
+func (m *GetMulticastDataFragmentsRequest) GetBlockAckDelay() uint32 {
+	if m != nil {
+		return m.BlockAckDelay
+	}
+	return 0
+}
+
+func (m *GetMulticastDataFragmentsRequest) GetFragSessionDescriptor() []byte {
+	if m != nil {
+		return m.FragSessionDescriptor
+	}
+	return nil
+}
+
+func (m *GetMulticastDataFragmentsRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type GetMulticastDataFragmentsResponse struct {
+	// FPort of the Fragmented Data Block Transport package.
+	FPort uint32 `protobuf:"varint,1,opt,name=f_port,json=fPort,proto3" json:"f_port,omitempty"`
+	// Next frame-counter of the multicast-group.
+	FCnt uint32 `protobuf:"varint,2,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Number of (uncoded) fragments.
+	NbFrag uint32 `protobuf:"varint,3,opt,name=nb_frag,json=nbFrag,proto3" json:"nb_frag,omitempty"`
+	// Number of fragments including the redundancy fragments.
+	NbFragTotal uint32 `protobuf:"varint,4,opt,name=nb_frag_total,json=nbFragTotal,proto3" json:"nb_frag_total,omitempty"`
+	// Number of padding bytes added to the last fragment.
+	Padding uint32 `protobuf:"varint,5,opt,name=padding,proto3" json:"padding,omitempty"`
+	// FragSessionSetupReq command (unencrypted).
+	FragSessionSetupReq []byte `protobuf:"bytes,6,opt,name=frag_session_setup_req,json=fragSessionSetupReq,proto3" json:"frag_session_setup_req,omitempty"`
+	// DataFragment commands (unencrypted), including the redundancy fragments.
+	DataFragments        [][]byte `protobuf:"bytes,7,rep,name=data_fragments,json=dataFragments,proto3" json:"data_fragments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastDataFragmentsResponse) Reset()         { *m = GetMulticastDataFragmentsResponse{} }
+func (m *GetMulticastDataFragmentsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastDataFragmentsResponse) ProtoMessage()    {}
+func (*GetMulticastDataFragmentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{94}
+}
+
+func (m *GetMulticastDataFragmentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastDataFragmentsResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastDataFragmentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastDataFragmentsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastDataFragmentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastDataFragmentsResponse.Merge(m, src)
+}
+func (m *GetMulticastDataFragmentsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastDataFragmentsResponse.Size(m)
+}
+func (m *GetMulticastDataFragmentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastDataFragmentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastDataFragmentsResponse proto.InternalMessageInfo
+
+func (m *GetMulticastDataFragmentsResponse) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *GetMulticastDataFragmentsResponse) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *GetMulticastDataFragmentsResponse) GetNbFrag() uint32 {
+	if m != nil {
+		return m.NbFrag
+	}
+	return 0
+}
+
+func (m *GetMulticastDataFragmentsResponse) GetNbFragTotal() uint32 {
+	if m != nil {
+		return m.NbFragTotal
+	}
+	return 0
+}
+
+func (m *GetMulticastDataFragmentsResponse) GetPadding() uint32 {
+	if m != nil {
+		return m.Padding
+	}
+	return 0
+}
+
+func (m *GetMulticastDataFragmentsResponse) GetFragSessionSetupReq() []byte {
+	if m != nil {
+		return m.FragSessionSetupReq
+	}
+	return nil
+}
+
+func (m *GetMulticastDataFragmentsResponse) GetDataFragments() [][]byte {
+	if m != nil {
+		return m.DataFragments
+	}
+	return nil
+}
+
+type EnqueueMulticastFragmentedDataBlockRequest struct {
+	// Multicast-group id.
+	MulticastGroupId []byte `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	// Fragmentation session index (0 - 3).
+	FragIndex uint32 `protobuf:"varint,2,opt,name=frag_index,json=fragIndex,proto3" json:"frag_index,omitempty"`
+	// Number of (uncoded) fragments.
+	NbFrag uint32 `protobuf:"varint,3,opt,name=nb_frag,json=nbFrag,proto3" json:"nb_frag,omitempty"`
+	// Frame-counter of the first fragment.
+	FCnt uint32 `protobuf:"varint,4,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// DataFragment commands, encrypted using the McAppSKey of the
+	// multicast-group. Each fragment is enqueued using the next
+	// frame-counter.
+	FrmPayloads          [][]byte `protobuf:"bytes,5,rep,name=frm_payloads,json=frmPayloads,proto3" json:"frm_payloads,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnqueueMulticastFragmentedDataBlockRequest) Reset() {
+	*m = EnqueueMulticastFragmentedDataBlockRequest{}
+}
+func (m *EnqueueMulticastFragmentedDataBlockRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*EnqueueMulticastFragmentedDataBlockRequest) ProtoMessage() {}
+func (*EnqueueMulticastFragmentedDataBlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{95}
+}
+
+func (m *EnqueueMulticastFragmentedDataBlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnqueueMulticastFragmentedDataBlockRequest.Unmarshal(m, b)
+}
+func (m *EnqueueMulticastFragmentedDataBlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnqueueMulticastFragmentedDataBlockRequest.Marshal(b, m, deterministic)
+}
+func (m *EnqueueMulticastFragmentedDataBlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnqueueMulticastFragmentedDataBlockRequest.Merge(m, src)
+}
+func (m *EnqueueMulticastFragmentedDataBlockRequest) XXX_Size() int {
+	return xxx_messageInfo_EnqueueMulticastFragmentedDataBlockRequest.Size(m)
+}
+func (m *EnqueueMulticastFragmentedDataBlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnqueueMulticastFragmentedDataBlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnqueueMulticastFragmentedDataBlockRequest proto.InternalMessageInfo
+
+func (m *EnqueueMulticastFragmentedDataBlockRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+func (m *EnqueueMulticastFragmentedDataBlockRequest) GetFragIndex() uint32 {
+	if m != nil {
+		return m.FragIndex
+	}
+	return 0
+}
+
+func (m *EnqueueMulticastFragmentedDataBlockRequest) GetNbFrag() uint32 {
+	if m != nil {
+		return m.NbFrag
+	}
+	return 0
+}
+
+func (m *EnqueueMulticastFragmentedDataBlockRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *EnqueueMulticastFragmentedDataBlockRequest) GetFrmPayloads() [][]byte {
+	if m != nil {
+		return m.FrmPayloads
+	}
+	return nil
+}
+
+type DeviceMulticastFragSession struct {
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Fragmentation session index.
+	FragIndex uint32 `protobuf:"varint,2,opt,name=frag_index,json=fragIndex,proto3" json:"frag_index,omitempty"`
+	// Number of (uncoded) fragments.
+	NbFrag uint32 `protobuf:"varint,3,opt,name=nb_frag,json=nbFrag,proto3" json:"nb_frag,omitempty"`
+	// Fragmentation session state.
+	State MulticastFragSessionState `protobuf:"varint,4,opt,name=state,proto3,enum=ns.MulticastFragSessionState" json:"state,omitempty"`
+	// Error code (in case of FRAG_SESSION_FAILED or FRAG_SESSION_INCOMPLETE).
+	// WRONG_DESCRIPTOR, FRAG_SESSION_INDEX_NOT_SUPPORTED, NOT_ENOUGH_MEMORY,
+	// ENCODING_UNSUPPORTED or NOT_ENOUGH_MATRIX_MEMORY.
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// Number of fragments received by the device (as reported by the
+	// FragSessionStatusAns).
+	NbFragReceived uint32 `protobuf:"varint,6,opt,name=nb_frag_received,json=nbFragReceived,proto3" json:"nb_frag_received,omitempty"`
+	// Number of fragments missing for reconstructing the data block (as
+	// reported by the FragSessionStatusAns).
+	MissingFrag uint32 `protobuf:"varint,7,opt,name=missing_frag,json=missingFrag,proto3" json:"missing_frag,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DeviceMulticastFragSession) Reset()         { *m = DeviceMulticastFragSession{} }
+func (m *DeviceMulticastFragSession) String() string { return proto.CompactTextString(m) }
+func (*DeviceMulticastFragSession) ProtoMessage()    {}
+func (*DeviceMulticastFragSession) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{96}
+}
+
+func (m *DeviceMulticastFragSession) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceMulticastFragSession.Unmarshal(m, b)
+}
+func (m *DeviceMulticastFragSession) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceMulticastFragSession.Marshal(b, m, deterministic)
+}
+func (m *DeviceMulticastFragSession) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceMulticastFragSession.Merge(m, src)
+}
+func (m *DeviceMulticastFragSession) XXX_Size() int {
+	return xxx_messageInfo_DeviceMulticastFragSession.Size(m)
+}
+func (m *DeviceMulticastFragSession) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceMulticastFragSession.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceMulticastFragSession proto.InternalMessageInfo
+
+func (m *DeviceMulticastFragSession) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *DeviceMulticastFragSession) GetFragIndex() uint32 {
+	if m != nil {
+		return m.FragIndex
+	}
+	return 0
+}
+
+func (m *DeviceMulticastFragSession) GetNbFrag() uint32 {
+	if m != nil {
+		return m.NbFrag
+	}
+	return 0
+}
+
+func (m *DeviceMulticastFragSession) GetState() MulticastFragSessionState {
+	if m != nil {
+		return m.State
+	}
+	return MulticastFragSessionState_FRAG_SESSION_PENDING
+}
+
+func (m *DeviceMulticastFragSession) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *DeviceMulticastFragSession) GetNbFragReceived() uint32 {
+	if m != nil {
+		return m.NbFragReceived
+	}
+	return 0
+}
+
+func (m *DeviceMulticastFragSession) GetMissingFrag() uint32 {
+	if m != nil {
+		return m.MissingFrag
+	}
+	return 0
+}
+
+func (m *DeviceMulticastFragSession) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *DeviceMulticastFragSession) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type GetMulticastFragSessionStatusRequest struct {
+	// Multicast-group id.
+	MulticastGroupId     []byte   `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastFragSessionStatusRequest) Reset()         { *m = GetMulticastFragSessionStatusRequest{} }
+func (m *GetMulticastFragSessionStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastFragSessionStatusRequest) ProtoMessage()    {}
+func (*GetMulticastFragSessionStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{97}
+}
+
+func (m *GetMulticastFragSessionStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastFragSessionStatusRequest.Unmarshal(m, b)
+}
+func (m *GetMulticastFragSessionStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastFragSessionStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastFragSessionStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastFragSessionStatusRequest.Merge(m, src)
+}
+func (m *GetMulticastFragSessionStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastFragSessionStatusRequest.Size(m)
+}
+func (m *GetMulticastFragSessionStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastFragSessionStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastFragSessionStatusRequest proto.InternalMessageInfo
+
+func (m *GetMulticastFragSessionStatusRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+type GetMulticastFragSessionStatusResponse struct {
+	// Fragmentation session status per device and fragmentation session index.
+	Devices              []*DeviceMulticastFragSession `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *GetMulticastFragSessionStatusResponse) Reset()         { *m = GetMulticastFragSessionStatusResponse{} }
+func (m *GetMulticastFragSessionStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastFragSessionStatusResponse) ProtoMessage()    {}
+func (*GetMulticastFragSessionStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{98}
 }
 
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Unmarshal(m, b)
+func (m *GetMulticastFragSessionStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastFragSessionStatusResponse.Unmarshal(m, b)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Marshal(b, m, deterministic)
+func (m *GetMulticastFragSessionStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastFragSessionStatusResponse.Marshal(b, m, deterministic)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Merge(m, src)
+func (m *GetMulticastFragSessionStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastFragSessionStatusResponse.Merge(m, src)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Size() int {
-	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Size(m)
+func (m *GetMulticastFragSessionStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastFragSessionStatusResponse.Size(m)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.DiscardUnknown(m)
+func (m *GetMulticastFragSessionStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastFragSessionStatusResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetMulticastFragSessionStatusResponse proto.InternalMessageInfo
 
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) GetMulticastQueueItems() []*MulticastQueueItem {
+func (m *GetMulticastFragSessionStatusResponse) GetDevices() []*DeviceMulticastFragSession {
 	if m != nil {
-		return m.MulticastQueueItems
+		return m.Devices
 	}
 	return nil
 }
 
 func init() {
 	proto.RegisterEnum("ns.RXWindow", RXWindow_name, RXWindow_value)
+	proto.RegisterEnum("ns.DeviceStatusType", DeviceStatusType_name, DeviceStatusType_value)
 	proto.RegisterEnum("ns.AggregationInterval", AggregationInterval_name, AggregationInterval_value)
 	proto.RegisterEnum("ns.MulticastGroupType", MulticastGroupType_name, MulticastGroupType_value)
+	proto.RegisterEnum("ns.MulticastDeliveryStatus", MulticastDeliveryStatus_name, MulticastDeliveryStatus_value)
+	proto.RegisterEnum("ns.MulticastSetupState", MulticastSetupState_name, MulticastSetupState_value)
+	proto.RegisterEnum("ns.MulticastFragSessionState", MulticastFragSessionState_name, MulticastFragSessionState_value)
 	proto.RegisterType((*CreateServiceProfileRequest)(nil), "ns.CreateServiceProfileRequest")
 	proto.RegisterType((*CreateServiceProfileResponse)(nil), "ns.CreateServiceProfileResponse")
 	proto.RegisterType((*GetServiceProfileRequest)(nil), "ns.GetServiceProfileRequest")
@@ -3972,6 +5705,9 @@ func init() {
 	proto.RegisterType((*DeactivateDeviceRequest)(nil), "ns.DeactivateDeviceRequest")
 	proto.RegisterType((*GetDeviceActivationRequest)(nil), "ns.GetDeviceActivationRequest")
 	proto.RegisterType((*GetDeviceActivationResponse)(nil), "ns.GetDeviceActivationResponse")
+	proto.RegisterType((*DeviceStatusHistoryItem)(nil), "ns.DeviceStatusHistoryItem")
+	proto.RegisterType((*GetDeviceStatusHistoryRequest)(nil), "ns.GetDeviceStatusHistoryRequest")
+	proto.RegisterType((*GetDeviceStatusHistoryResponse)(nil), "ns.GetDeviceStatusHistoryResponse")
 	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "ns.GetRandomDevAddrResponse")
 	proto.RegisterType((*CreateMACCommandQueueItemRequest)(nil), "ns.CreateMACCommandQueueItemRequest")
 	proto.RegisterType((*SendProprietaryPayloadRequest)(nil), "ns.SendProprietaryPayloadRequest")
@@ -3992,11 +5728,16 @@ func init() {
 	proto.RegisterType((*GetDeviceQueueItemsForDevEUIResponse)(nil), "ns.GetDeviceQueueItemsForDevEUIResponse")
 	proto.RegisterType((*GetNextDownlinkFCntForDevEUIRequest)(nil), "ns.GetNextDownlinkFCntForDevEUIRequest")
 	proto.RegisterType((*GetNextDownlinkFCntForDevEUIResponse)(nil), "ns.GetNextDownlinkFCntForDevEUIResponse")
+	proto.RegisterType((*ExecGatewayCommandRequest)(nil), "ns.ExecGatewayCommandRequest")
+	proto.RegisterMapType((map[string]string)(nil), "ns.ExecGatewayCommandRequest.EnvironmentEntry")
+	proto.RegisterType((*ExecGatewayCommandResponse)(nil), "ns.ExecGatewayCommandResponse")
 	proto.RegisterType((*StreamFrameLogsForGatewayRequest)(nil), "ns.StreamFrameLogsForGatewayRequest")
 	proto.RegisterType((*StreamFrameLogsForGatewayResponse)(nil), "ns.StreamFrameLogsForGatewayResponse")
 	proto.RegisterType((*StreamFrameLogsForDeviceRequest)(nil), "ns.StreamFrameLogsForDeviceRequest")
 	proto.RegisterType((*StreamFrameLogsForDeviceResponse)(nil), "ns.StreamFrameLogsForDeviceResponse")
 	proto.RegisterType((*GetVersionResponse)(nil), "ns.GetVersionResponse")
+	proto.RegisterType((*ADRAlgorithm)(nil), "ns.ADRAlgorithm")
+	proto.RegisterType((*GetADRAlgorithmsResponse)(nil), "ns.GetADRAlgorithmsResponse")
 	proto.RegisterType((*GatewayProfile)(nil), "ns.GatewayProfile")
 	proto.RegisterType((*GatewayProfileExtraChannel)(nil), "ns.GatewayProfileExtraChannel")
 	proto.RegisterType((*CreateGatewayProfileRequest)(nil), "ns.CreateGatewayProfileRequest")
@@ -4019,200 +5760,318 @@ func init() {
 	proto.RegisterType((*FlushMulticastQueueForMulticastGroupRequest)(nil), "ns.FlushMulticastQueueForMulticastGroupRequest")
 	proto.RegisterType((*GetMulticastQueueItemsForMulticastGroupRequest)(nil), "ns.GetMulticastQueueItemsForMulticastGroupRequest")
 	proto.RegisterType((*GetMulticastQueueItemsForMulticastGroupResponse)(nil), "ns.GetMulticastQueueItemsForMulticastGroupResponse")
+	proto.RegisterType((*MulticastQueueItemDelivery)(nil), "ns.MulticastQueueItemDelivery")
+	proto.RegisterType((*GetMulticastQueueItemDeliveryReportRequest)(nil), "ns.GetMulticastQueueItemDeliveryReportRequest")
+	proto.RegisterType((*GetMulticastQueueItemDeliveryReportResponse)(nil), "ns.GetMulticastQueueItemDeliveryReportResponse")
+	proto.RegisterType((*GetMulticastGroupSetupCommandsRequest)(nil), "ns.GetMulticastGroupSetupCommandsRequest")
+	proto.RegisterType((*GetMulticastGroupSetupCommandsResponse)(nil), "ns.GetMulticastGroupSetupCommandsResponse")
+	proto.RegisterType((*EnqueueMulticastGroupSetupRequest)(nil), "ns.EnqueueMulticastGroupSetupRequest")
+	proto.RegisterType((*DeviceMulticastGroupSetup)(nil), "ns.DeviceMulticastGroupSetup")
+	proto.RegisterType((*GetMulticastGroupSetupStatusRequest)(nil), "ns.GetMulticastGroupSetupStatusRequest")
+	proto.RegisterType((*GetMulticastGroupSetupStatusResponse)(nil), "ns.GetMulticastGroupSetupStatusResponse")
+	proto.RegisterType((*HandleApplicationLayerUplinkRequest)(nil), "ns.HandleApplicationLayerUplinkRequest")
+	proto.RegisterType((*GetMulticastDataFragmentsRequest)(nil), "ns.GetMulticastDataFragmentsRequest")
+	proto.RegisterType((*GetMulticastDataFragmentsResponse)(nil), "ns.GetMulticastDataFragmentsResponse")
+	proto.RegisterType((*EnqueueMulticastFragmentedDataBlockRequest)(nil), "ns.EnqueueMulticastFragmentedDataBlockRequest")
+	proto.RegisterType((*DeviceMulticastFragSession)(nil), "ns.DeviceMulticastFragSession")
+	proto.RegisterType((*GetMulticastFragSessionStatusRequest)(nil), "ns.GetMulticastFragSessionStatusRequest")
+	proto.RegisterType((*GetMulticastFragSessionStatusResponse)(nil), "ns.GetMulticastFragSessionStatusResponse")
 }
 
 func init() { proto.RegisterFile("ns.proto", fileDescriptor_3b280de855f92a4a) }
 
 var fileDescriptor_3b280de855f92a4a = []byte{
-	// 3004 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0xcd, 0x72, 0xdb, 0xc8,
-	0xf1, 0x17, 0x29, 0x91, 0x92, 0x5a, 0x22, 0x45, 0x8d, 0x6c, 0x8b, 0xa6, 0xe5, 0x95, 0x8c, 0xf5,
-	0xae, 0xb5, 0x5e, 0x2f, 0xfd, 0xff, 0x6b, 0xcb, 0x55, 0xfb, 0x91, 0x75, 0x8a, 0x4b, 0x51, 0xb6,
-	0xd6, 0xb6, 0x6c, 0x83, 0x96, 0xf7, 0xab, 0x2a, 0x08, 0x0c, 0x0c, 0x69, 0x94, 0x08, 0x80, 0x0b,
-	0x0c, 0x45, 0x2b, 0x55, 0x39, 0xa4, 0x72, 0xcc, 0x21, 0x97, 0xbc, 0x43, 0x72, 0x49, 0x25, 0xe7,
-	0x3c, 0x42, 0xaa, 0x92, 0x4b, 0x6e, 0xfb, 0x0c, 0x39, 0xe5, 0x09, 0x52, 0x83, 0x19, 0x0c, 0x3e,
-	0x38, 0x00, 0xe9, 0xb5, 0x5d, 0xce, 0x49, 0xc2, 0x74, 0xcf, 0x6f, 0xba, 0x7b, 0x7a, 0xa6, 0x7b,
-	0xba, 0x09, 0x4b, 0x8e, 0xdf, 0x1c, 0x7a, 0x2e, 0x71, 0x51, 0xd1, 0xf1, 0x1b, 0xdb, 0x7d, 0xd7,
-	0xed, 0x0f, 0xf0, 0xcd, 0x60, 0xe4, 0xd9, 0xa8, 0x77, 0x93, 0x58, 0x36, 0xf6, 0x89, 0x6e, 0x0f,
-	0x19, 0x53, 0xe3, 0x52, 0x9a, 0x01, 0xdb, 0x43, 0x72, 0xc6, 0x89, 0x9b, 0xfa, 0xd0, 0xba, 0x69,
-	0xb8, 0xb6, 0xed, 0x3a, 0xfc, 0x0f, 0x27, 0xac, 0x51, 0x42, 0x7f, 0x7c, 0xb3, 0x3f, 0xe6, 0x03,
-	0xd5, 0xa1, 0xe7, 0xf6, 0xac, 0x01, 0xe6, 0x6b, 0x2b, 0xdf, 0xc1, 0xa5, 0xb6, 0x87, 0x75, 0x82,
-	0xbb, 0xd8, 0x3b, 0xb5, 0x0c, 0xfc, 0x88, 0x91, 0x55, 0xfc, 0xc3, 0x08, 0xfb, 0x04, 0x7d, 0x0e,
-	0x6b, 0x3e, 0x23, 0x68, 0x7c, 0x62, 0xbd, 0xb0, 0x53, 0xd8, 0x5d, 0xd9, 0x43, 0x4d, 0xc7, 0x6f,
-	0xa6, 0xe6, 0x54, 0xfd, 0xc4, 0xb7, 0xd2, 0x84, 0x2d, 0x39, 0xb6, 0x3f, 0x74, 0x1d, 0x1f, 0xa3,
-	0x2a, 0x14, 0x2d, 0x33, 0xc0, 0x5b, 0x55, 0x8b, 0x96, 0xa9, 0x5c, 0x87, 0xfa, 0x1d, 0x4c, 0xe4,
-	0x82, 0xa4, 0x79, 0xff, 0x59, 0x80, 0x8b, 0x12, 0x66, 0x8e, 0xfc, 0x2a, 0x62, 0xa3, 0x4f, 0x01,
-	0x8c, 0x40, 0x6c, 0x53, 0xd3, 0x49, 0xbd, 0x18, 0xcc, 0x6b, 0x34, 0x99, 0xf9, 0x9b, 0xa1, 0xf9,
-	0x9b, 0x4f, 0xc2, 0xfd, 0x51, 0x97, 0x39, 0x77, 0x8b, 0xd0, 0xa9, 0xa3, 0xa1, 0x19, 0x4e, 0x9d,
-	0x9f, 0x3e, 0x95, 0x73, 0xb7, 0x08, 0xdd, 0x88, 0xe3, 0xe0, 0xe3, 0x0d, 0x6c, 0xc4, 0x47, 0x70,
-	0x69, 0x1f, 0x0f, 0x70, 0x16, 0x76, 0xda, 0xb6, 0xc2, 0x27, 0x54, 0x77, 0x44, 0x2c, 0xa7, 0x3f,
-	0x29, 0x8a, 0xc7, 0x08, 0x32, 0x51, 0x52, 0x73, 0xaa, 0x5e, 0xe2, 0x3b, 0xf2, 0x89, 0x34, 0x76,
-	0xae, 0x4f, 0xc8, 0x05, 0xc9, 0xf0, 0x89, 0x0c, 0xe4, 0x57, 0x11, 0xfb, 0x6d, 0xfb, 0xc4, 0x1b,
-	0xd8, 0x08, 0xe1, 0x13, 0xb3, 0xd9, 0xf6, 0x29, 0x34, 0xd8, 0xbe, 0xed, 0x63, 0x89, 0x07, 0x7d,
-	0x02, 0x55, 0x13, 0x4b, 0x9c, 0x73, 0x9d, 0x0a, 0x92, 0x9c, 0x51, 0x31, 0x71, 0xca, 0x35, 0xa5,
-	0xb8, 0x19, 0xee, 0xf0, 0x01, 0x6c, 0xde, 0xc1, 0x44, 0x2a, 0x43, 0x9a, 0xf5, 0xef, 0x85, 0xc0,
-	0x75, 0xe4, 0xb8, 0x3f, 0x59, 0xe0, 0xb7, 0xe4, 0x09, 0x4f, 0xa1, 0xc1, 0x3c, 0xe1, 0x35, 0x9b,
-	0xff, 0x06, 0x34, 0x98, 0x17, 0xcc, 0x64, 0xd2, 0xdf, 0x14, 0xa1, 0xcc, 0x18, 0xd1, 0x26, 0x2c,
-	0x9a, 0xf8, 0x54, 0xc3, 0x23, 0x8b, 0xd3, 0xcb, 0x26, 0x3e, 0xed, 0x8c, 0x2c, 0x74, 0x1d, 0xd6,
-	0x93, 0xb2, 0x68, 0x96, 0x19, 0x98, 0x69, 0x55, 0x5d, 0x4b, 0xac, 0x7d, 0x68, 0xa2, 0x1b, 0x80,
-	0x52, 0x97, 0x1a, 0x65, 0x9e, 0x0f, 0x98, 0x6b, 0xc9, 0x3b, 0x8c, 0x71, 0xa7, 0xdc, 0x9d, 0x72,
-	0x2f, 0x30, 0xee, 0xa4, 0x77, 0x1f, 0x9a, 0xe8, 0x1a, 0xd4, 0xfc, 0x13, 0x6b, 0xa8, 0xf5, 0x34,
-	0xc3, 0x21, 0x9a, 0xf1, 0x1c, 0x1b, 0x27, 0xf5, 0xd2, 0x4e, 0x61, 0x77, 0x49, 0xad, 0xd0, 0xf1,
-	0x83, 0xb6, 0x43, 0xda, 0x74, 0x10, 0x7d, 0x04, 0xc8, 0xc3, 0x3d, 0xec, 0x61, 0xc7, 0xc0, 0x9a,
-	0x3e, 0x20, 0x16, 0x19, 0x99, 0xb8, 0x5e, 0xde, 0x29, 0xec, 0x16, 0xd4, 0x75, 0x41, 0x69, 0x71,
-	0x82, 0xf2, 0x29, 0x6c, 0xc4, 0x1d, 0x36, 0x34, 0x95, 0x02, 0x65, 0xa6, 0x1d, 0x37, 0x3d, 0x44,
-	0xa6, 0x57, 0x39, 0x45, 0xf9, 0x10, 0x6a, 0xc2, 0x21, 0xc3, 0x79, 0x59, 0x76, 0x54, 0xfe, 0x5c,
-	0x80, 0xf5, 0x18, 0x37, 0xf7, 0xdb, 0x19, 0x96, 0x79, 0x4b, 0x1e, 0xfa, 0x29, 0x6c, 0xc4, 0x3d,
-	0xf4, 0x65, 0xec, 0xd2, 0x84, 0x8d, 0xb8, 0x13, 0x4e, 0x35, 0xcd, 0xdf, 0x8a, 0x50, 0x63, 0xac,
-	0x2d, 0x83, 0x58, 0xa7, 0x3a, 0xb1, 0x5c, 0x27, 0xdb, 0x21, 0x2f, 0xc2, 0x12, 0x25, 0xe8, 0xa6,
-	0xe9, 0x71, 0x3f, 0xa4, 0x8c, 0x2d, 0xd3, 0xf4, 0xd0, 0x55, 0x58, 0xf3, 0x35, 0x67, 0x7c, 0xa2,
-	0xf9, 0x9a, 0xe5, 0x10, 0xed, 0x04, 0x9f, 0x71, 0xe7, 0x5b, 0xf1, 0x8f, 0xc6, 0x27, 0xdd, 0x43,
-	0x87, 0xdc, 0xc3, 0x67, 0x94, 0xab, 0x97, 0xe2, 0x62, 0x4e, 0xb7, 0xd2, 0x8b, 0x71, 0x5d, 0x81,
-	0x0a, 0xe3, 0xc1, 0x8e, 0x11, 0xf0, 0x94, 0x02, 0x1e, 0x70, 0xc6, 0x27, 0xdd, 0x8e, 0x63, 0x50,
-	0x96, 0x3a, 0x2c, 0x31, 0x6f, 0x1c, 0x0d, 0x03, 0xff, 0xaa, 0xa8, 0xe5, 0x5e, 0xdb, 0x21, 0xc7,
-	0x43, 0xb4, 0x0d, 0xab, 0x0e, 0xf7, 0x54, 0xd3, 0x1d, 0x3b, 0xf5, 0xc5, 0x80, 0xba, 0xec, 0x50,
-	0x2f, 0xdd, 0x77, 0xc7, 0x0e, 0x65, 0xd0, 0xe3, 0x0c, 0x4b, 0x8c, 0x41, 0x17, 0x0c, 0x32, 0x77,
-	0x5f, 0x96, 0xb8, 0xbb, 0xf2, 0x1d, 0x9c, 0xe7, 0x56, 0x4b, 0x99, 0xbb, 0x25, 0x0e, 0xae, 0x2e,
-	0xac, 0xca, 0x37, 0xed, 0x5c, 0xb4, 0x69, 0x91, 0xc5, 0xd5, 0x9a, 0x99, 0x1a, 0x51, 0xf6, 0x60,
-	0x73, 0x1f, 0xeb, 0x52, 0xf4, 0xcc, 0xcd, 0xbc, 0x05, 0x0d, 0xe1, 0xe6, 0x31, 0xf0, 0x69, 0xd3,
-	0x7e, 0x09, 0x97, 0xa4, 0xd3, 0xf8, 0x39, 0x79, 0x0d, 0xca, 0xdc, 0x62, 0x99, 0x87, 0xee, 0x98,
-	0xae, 0xbd, 0xcf, 0x1c, 0x46, 0xc0, 0xc7, 0x7d, 0xaa, 0x90, 0xf0, 0x29, 0xc5, 0x82, 0x1d, 0x76,
-	0x3f, 0x3c, 0x68, 0xb5, 0xdb, 0xae, 0x6d, 0xeb, 0x8e, 0xf9, 0x78, 0x84, 0x47, 0xf8, 0x90, 0x60,
-	0x7b, 0x9a, 0x56, 0xa8, 0x06, 0xf3, 0x06, 0xbf, 0xd3, 0x2a, 0x2a, 0xfd, 0x17, 0x35, 0x60, 0xc9,
-	0x60, 0x28, 0x7e, 0xbd, 0xb4, 0x33, 0xbf, 0xbb, 0xaa, 0x8a, 0x6f, 0xe5, 0xc7, 0x02, 0x5c, 0xee,
-	0x62, 0xc7, 0x7c, 0xe4, 0xb9, 0x43, 0xcf, 0xc2, 0x44, 0xf7, 0xce, 0x1e, 0xe9, 0x67, 0x03, 0x57,
-	0x37, 0xc3, 0x85, 0xb6, 0x61, 0xc5, 0xd6, 0x0d, 0x6d, 0xc8, 0x46, 0xf9, 0x62, 0x60, 0xeb, 0x06,
-	0xe7, 0xa3, 0x0b, 0xda, 0x96, 0xc1, 0xcf, 0x05, 0xfd, 0x17, 0x5d, 0x81, 0xd5, 0xbe, 0x4e, 0xf0,
-	0x58, 0x3f, 0xd3, 0x6c, 0xdd, 0xf0, 0xeb, 0xf3, 0xc1, 0xa2, 0x2b, 0x7c, 0xec, 0x81, 0x6e, 0xf8,
-	0xe8, 0x16, 0x5c, 0x18, 0xba, 0x03, 0xdd, 0xb3, 0x7e, 0x15, 0x58, 0x4a, 0xb3, 0x9c, 0x53, 0xec,
-	0xf9, 0xd4, 0xc2, 0x0b, 0x81, 0xc7, 0x9d, 0x8f, 0x53, 0x0f, 0x43, 0x22, 0xda, 0x82, 0xe5, 0x9e,
-	0x47, 0x05, 0x73, 0x0c, 0x76, 0x3a, 0x2a, 0x6a, 0x34, 0x40, 0x63, 0x8d, 0xe9, 0xf1, 0x63, 0x51,
-	0x34, 0x3d, 0xe5, 0x1f, 0x05, 0x58, 0xbc, 0xc3, 0x16, 0x4d, 0xc7, 0x21, 0x74, 0x03, 0x96, 0x06,
-	0xae, 0xc1, 0x36, 0x95, 0xdd, 0x6f, 0xb5, 0x26, 0x7f, 0xf6, 0xdc, 0xe7, 0xe3, 0xaa, 0xe0, 0xa0,
-	0x71, 0x23, 0xd4, 0x68, 0x32, 0xca, 0x70, 0x4a, 0x14, 0x37, 0x76, 0xa1, 0xfc, 0xcc, 0xd5, 0x3d,
-	0xd3, 0xaf, 0x2f, 0xec, 0xcc, 0x07, 0xc8, 0x8e, 0xdf, 0xe4, 0x82, 0x7c, 0x49, 0x09, 0x2a, 0xa7,
-	0x67, 0xc4, 0xa3, 0x92, 0x3c, 0x1e, 0x29, 0xc7, 0xb0, 0x1a, 0x47, 0xa1, 0x3e, 0xd0, 0x1b, 0xf6,
-	0x75, 0x4d, 0x28, 0x56, 0xa6, 0x9f, 0x2c, 0xcc, 0xf5, 0x2c, 0x07, 0x6b, 0xe2, 0x01, 0x18, 0xdc,
-	0x26, 0x6c, 0x87, 0x6a, 0x94, 0x22, 0xae, 0xdf, 0x7b, 0xf8, 0x4c, 0xf9, 0x02, 0xce, 0x31, 0x77,
-	0xe3, 0xe0, 0xe1, 0xce, 0xbf, 0x07, 0x8b, 0x5c, 0x35, 0xee, 0xf6, 0x2b, 0x31, 0x3d, 0xd4, 0x90,
-	0xa6, 0xbc, 0x1b, 0x04, 0x99, 0xd4, 0xdc, 0x74, 0xd8, 0xff, 0x4b, 0x11, 0x50, 0x9c, 0x8b, 0x1f,
-	0x82, 0xd9, 0x96, 0x78, 0x3b, 0xe1, 0x08, 0xdd, 0x86, 0x4a, 0xcf, 0xf2, 0x7c, 0xa2, 0xf9, 0x18,
-	0x3b, 0x74, 0xf6, 0xc2, 0xd4, 0xd9, 0x2b, 0xc1, 0x84, 0x2e, 0xc6, 0x4e, 0x8b, 0xa0, 0x9f, 0xc1,
-	0xea, 0x40, 0x8f, 0x4d, 0x2f, 0x4d, 0x9d, 0x0e, 0x94, 0x9f, 0xcd, 0xa6, 0xbb, 0xc2, 0x82, 0xe1,
-	0x4f, 0xdb, 0x95, 0xf7, 0xe1, 0x1c, 0x0b, 0x88, 0x53, 0x36, 0xe6, 0x77, 0x45, 0xe1, 0x54, 0x5d,
-	0xa2, 0x13, 0x1f, 0x7d, 0x02, 0xcb, 0xc2, 0x6d, 0xf8, 0x0a, 0xb9, 0xf6, 0x12, 0xcc, 0xa8, 0x09,
-	0x1b, 0xde, 0x0b, 0x6d, 0xa8, 0x1b, 0x27, 0x98, 0xf8, 0x9a, 0x87, 0x0d, 0x6c, 0x9d, 0x62, 0x96,
-	0xb8, 0x95, 0xd4, 0x75, 0xef, 0xc5, 0x23, 0x46, 0x51, 0x39, 0x01, 0x7d, 0x0c, 0x17, 0x24, 0xfc,
-	0x9a, 0x7b, 0x12, 0x6c, 0x53, 0x49, 0xdd, 0x98, 0x98, 0xf2, 0xf0, 0x84, 0x2e, 0x42, 0x24, 0x8b,
-	0x2c, 0xb0, 0x45, 0xc8, 0xc4, 0x22, 0x37, 0x00, 0xc5, 0xf8, 0xb1, 0x6d, 0x11, 0x82, 0xd9, 0x09,
-	0x2b, 0xa9, 0x35, 0xc1, 0xde, 0x61, 0xe3, 0xca, 0x7f, 0x0a, 0x70, 0x21, 0x72, 0xd3, 0xc0, 0x20,
-	0xa1, 0xe1, 0x2e, 0x03, 0x84, 0x57, 0x80, 0x30, 0xe0, 0x32, 0x1f, 0x39, 0xa4, 0xca, 0x2c, 0x59,
-	0x0e, 0xc1, 0xde, 0xa9, 0x3e, 0x08, 0x34, 0xae, 0xee, 0x6d, 0xd2, 0x7d, 0x69, 0xf5, 0xfb, 0x1e,
-	0xee, 0xf3, 0x5b, 0x8c, 0x91, 0x55, 0xc1, 0x88, 0xda, 0xb0, 0xe6, 0x13, 0xdd, 0x23, 0xd1, 0x41,
-	0x9d, 0xc1, 0x43, 0xab, 0xc1, 0x14, 0xf1, 0x8d, 0x7e, 0x0e, 0x15, 0xec, 0x98, 0x31, 0x88, 0xe9,
-	0x6e, 0xba, 0x8a, 0x1d, 0x53, 0x7c, 0x29, 0xed, 0xe0, 0x41, 0x94, 0xd4, 0x99, 0x9f, 0xcf, 0x5d,
-	0x28, 0x7b, 0xd8, 0x1f, 0x0d, 0x48, 0xbd, 0x30, 0x71, 0x93, 0x31, 0x4e, 0x4e, 0x57, 0xfe, 0x5a,
-	0x80, 0x35, 0x16, 0x11, 0x45, 0xa8, 0xca, 0x8e, 0x51, 0xdb, 0xb0, 0xd2, 0xf3, 0x6c, 0x11, 0x53,
-	0xd8, 0xc5, 0x04, 0x3d, 0xcf, 0x0e, 0x63, 0xca, 0x06, 0x94, 0x82, 0x2c, 0x24, 0x30, 0x47, 0x45,
-	0x5d, 0xa0, 0x39, 0x0e, 0x3a, 0x0f, 0xe5, 0x9e, 0x36, 0x74, 0x3d, 0xc2, 0x83, 0x5b, 0xa9, 0xf7,
-	0xc8, 0xf5, 0x08, 0x8d, 0x09, 0x86, 0xeb, 0xf4, 0x2c, 0xcf, 0xe6, 0x1b, 0xbb, 0xa4, 0x46, 0x03,
-	0x89, 0x30, 0x5b, 0x4e, 0x86, 0xd9, 0x3b, 0x61, 0x1d, 0x21, 0x25, 0x77, 0xb8, 0xe3, 0xd7, 0x60,
-	0xc1, 0x22, 0xd8, 0xe6, 0x87, 0x60, 0x23, 0x8a, 0xf9, 0x11, 0x67, 0xc0, 0xa0, 0x7c, 0x0e, 0x3b,
-	0x07, 0x83, 0x91, 0xff, 0x3c, 0x46, 0x3d, 0x70, 0xbd, 0x7d, 0x7c, 0xda, 0x39, 0x3e, 0x9c, 0x9a,
-	0x85, 0xdc, 0x86, 0x77, 0x45, 0x16, 0x22, 0x80, 0xfd, 0xd9, 0xe7, 0x3f, 0x86, 0xab, 0xf9, 0xf3,
-	0xf9, 0x56, 0x7e, 0x00, 0x25, 0x2a, 0xac, 0xcf, 0x77, 0x52, 0xaa, 0x0e, 0xe3, 0xe0, 0x22, 0x1d,
-	0xe1, 0x17, 0x41, 0x5e, 0x38, 0xb0, 0x9c, 0x13, 0x9a, 0xfb, 0xcd, 0x2e, 0xd2, 0xe7, 0x81, 0x48,
-	0x39, 0xf3, 0xb9, 0x48, 0x62, 0x97, 0x0b, 0xd1, 0x2e, 0x2b, 0x2d, 0xd8, 0xe9, 0x12, 0x0f, 0xeb,
-	0xf6, 0x81, 0xa7, 0xdb, 0xf8, 0xbe, 0xdb, 0xa7, 0xba, 0xa4, 0x2e, 0xb1, 0xfc, 0xb3, 0xa8, 0xfc,
-	0xa9, 0x00, 0x57, 0x72, 0x30, 0xf8, 0xea, 0xb7, 0xa1, 0x36, 0x1a, 0x52, 0xe1, 0xb4, 0x1e, 0xe5,
-	0xd2, 0x7c, 0x4c, 0x44, 0xed, 0xa3, 0x3f, 0x6e, 0x1e, 0x07, 0xb4, 0x00, 0xa0, 0x8b, 0xc9, 0xdd,
-	0x39, 0xb5, 0x3a, 0x4a, 0x8c, 0xa0, 0xcf, 0xa0, 0x6a, 0x72, 0xf5, 0x18, 0x02, 0x0f, 0x4c, 0xeb,
-	0x74, 0xb6, 0x50, 0x9c, 0x12, 0xee, 0xce, 0xa9, 0x15, 0x33, 0x3e, 0xf0, 0xe5, 0x22, 0x94, 0x82,
-	0x29, 0xca, 0x67, 0xb0, 0x3d, 0x29, 0xe9, 0x8c, 0x69, 0xef, 0x1f, 0x0b, 0x32, 0x53, 0xa5, 0x5e,
-	0x7b, 0xff, 0x13, 0x5a, 0x3e, 0x0d, 0x82, 0xff, 0x53, 0x96, 0xc4, 0x09, 0xd1, 0xea, 0xb0, 0x18,
-	0x26, 0x7d, 0x54, 0xa2, 0x65, 0x35, 0xfc, 0x44, 0xef, 0xd3, 0x6b, 0xa7, 0x1f, 0xa6, 0x66, 0xd5,
-	0xbd, 0x6a, 0x98, 0x9a, 0xa9, 0xc1, 0xa8, 0xca, 0xa9, 0xca, 0x6f, 0x0b, 0x50, 0xbd, 0x93, 0xc8,
-	0xbe, 0x26, 0xf2, 0x3c, 0x9a, 0xfc, 0x3e, 0xd7, 0x1d, 0x07, 0x0f, 0xfc, 0x7a, 0x71, 0x67, 0x7e,
-	0xb7, 0xa2, 0x8a, 0x6f, 0xd4, 0x81, 0x2a, 0x7e, 0x41, 0x3c, 0x5d, 0x13, 0x1c, 0xf3, 0xc1, 0xd9,
-	0x78, 0x27, 0x76, 0xcb, 0x71, 0xdc, 0x0e, 0xe5, 0x6b, 0x33, 0x36, 0xb5, 0x82, 0x63, 0x5f, 0xbe,
-	0xf2, 0xaf, 0x02, 0x34, 0xb2, 0xb9, 0xd1, 0x1e, 0x80, 0xed, 0x9a, 0xa3, 0x41, 0xf4, 0x80, 0xa8,
-	0xee, 0xa1, 0x50, 0xa1, 0x07, 0x82, 0xa2, 0xc6, 0xb8, 0x92, 0x79, 0x6e, 0x31, 0x9d, 0xe7, 0x6e,
-	0xc1, 0xf2, 0x33, 0xdd, 0x31, 0xc7, 0x96, 0x49, 0x9e, 0xf3, 0x1b, 0x32, 0x1a, 0xa0, 0x66, 0x7d,
-	0x66, 0x11, 0x4f, 0x27, 0x98, 0xdf, 0x93, 0xe1, 0x27, 0xfa, 0x10, 0xd6, 0xfd, 0xa1, 0x87, 0x75,
-	0x93, 0xe6, 0x9b, 0x3d, 0xdd, 0x20, 0xae, 0xc7, 0x5e, 0x04, 0x15, 0xb5, 0x26, 0x08, 0x07, 0x6c,
-	0x3c, 0xaa, 0xe0, 0x26, 0x55, 0x8b, 0x15, 0x0e, 0x53, 0x19, 0x71, 0xbc, 0x70, 0x98, 0x9a, 0x53,
-	0x4d, 0xa6, 0xc8, 0x51, 0x05, 0x37, 0x8d, 0x9d, 0x5b, 0xc1, 0x95, 0x0b, 0x92, 0x51, 0xc1, 0xcd,
-	0x40, 0x7e, 0x15, 0xb1, 0xdf, 0x76, 0x05, 0xf7, 0x0d, 0x6c, 0x84, 0xa8, 0xe0, 0xce, 0x66, 0xdb,
-	0x1f, 0x8b, 0x50, 0x7d, 0x30, 0x1a, 0x10, 0xcb, 0xd0, 0x7d, 0x72, 0xc7, 0x73, 0x47, 0xc3, 0x89,
-	0xf3, 0xb6, 0x09, 0x8b, 0xb6, 0x11, 0xaf, 0x94, 0x94, 0x6d, 0x23, 0x28, 0x94, 0x6c, 0xc3, 0xaa,
-	0x6d, 0xf0, 0x1a, 0x48, 0x54, 0x25, 0x59, 0xb6, 0x8d, 0xa3, 0xf1, 0x49, 0xf7, 0x1e, 0x3e, 0x8b,
-	0xa2, 0xc1, 0x42, 0x2c, 0xe6, 0xdf, 0x02, 0xe8, 0xd3, 0x75, 0x34, 0x72, 0x36, 0xc4, 0x41, 0x74,
-	0xaf, 0xee, 0x5d, 0xa0, 0x8a, 0x25, 0xc5, 0x78, 0x72, 0x36, 0xc4, 0xea, 0x72, 0x3f, 0xfc, 0x37,
-	0xfd, 0x12, 0x4c, 0x9e, 0xa7, 0xc5, 0xf4, 0x79, 0xda, 0x85, 0xda, 0x90, 0x1e, 0x09, 0x7f, 0xe0,
-	0x12, 0x6d, 0x88, 0x3d, 0xcb, 0x35, 0x79, 0x75, 0xa4, 0x4a, 0xc7, 0xbb, 0x03, 0x97, 0x3c, 0x0a,
-	0x46, 0x33, 0xaa, 0x8d, 0xcb, 0x2f, 0x55, 0x6d, 0x84, 0x8c, 0xd7, 0x9d, 0x38, 0x70, 0x49, 0xd5,
-	0x62, 0xfb, 0x6c, 0x87, 0x04, 0x2d, 0xd0, 0x34, 0xbe, 0xcf, 0xa9, 0x39, 0x55, 0x3b, 0xf1, 0x1d,
-	0x1d, 0xb8, 0x34, 0x76, 0xee, 0x81, 0x93, 0x0b, 0x92, 0x71, 0xe0, 0x32, 0x90, 0x5f, 0x45, 0xec,
-	0xb7, 0x7d, 0xe0, 0xde, 0xc0, 0x46, 0x88, 0x03, 0x37, 0x9b, 0x6d, 0x2d, 0xd8, 0x69, 0x99, 0x26,
-	0x0b, 0xe9, 0x4f, 0x5c, 0xf9, 0x9c, 0xcc, 0x2c, 0xfb, 0x06, 0xa0, 0x94, 0xa0, 0x51, 0x1d, 0xbd,
-	0x96, 0x94, 0xeb, 0xd0, 0x54, 0x1c, 0x78, 0x4f, 0xc5, 0xb6, 0x7b, 0xca, 0xb3, 0xe1, 0x03, 0xcf,
-	0xb5, 0xdf, 0xe8, 0x7a, 0xbf, 0x2f, 0x00, 0x12, 0x0b, 0x44, 0x6f, 0x06, 0x39, 0x48, 0x41, 0x0e,
-	0x12, 0xdd, 0x19, 0x45, 0xe9, 0x3b, 0x61, 0x3e, 0xfe, 0x4e, 0x48, 0x3d, 0x3a, 0x16, 0xd2, 0x8f,
-	0x0e, 0x65, 0x00, 0x3b, 0x1d, 0xe7, 0x07, 0x2a, 0xc9, 0xa4, 0x5c, 0xa1, 0xf2, 0x77, 0xe1, 0x5c,
-	0x24, 0x5e, 0xc0, 0xab, 0xc5, 0xde, 0x08, 0xc9, 0x9b, 0x29, 0x9a, 0x1c, 0xa9, 0x24, 0xc6, 0x94,
-	0xef, 0xe1, 0xc3, 0xe0, 0xd1, 0x90, 0x64, 0x3f, 0x70, 0x3d, 0xb9, 0xd5, 0x5f, 0xca, 0x2e, 0xca,
-	0x2f, 0xa0, 0x19, 0x3f, 0x92, 0x89, 0x77, 0xc1, 0xeb, 0xc0, 0xff, 0x35, 0xdc, 0x9c, 0x19, 0x9f,
-	0x5f, 0x04, 0x5f, 0xc1, 0x79, 0x99, 0xe5, 0xc2, 0xf7, 0x48, 0x96, 0xe9, 0x36, 0x26, 0x4d, 0xe7,
-	0x5f, 0xdf, 0x82, 0x25, 0xf5, 0x9b, 0xaf, 0x2d, 0xc7, 0x74, 0xc7, 0x68, 0x11, 0xe6, 0xd5, 0x6f,
-	0xfe, 0xbf, 0x36, 0xc7, 0xfe, 0xd9, 0xab, 0x15, 0xae, 0x0f, 0x60, 0x43, 0xf2, 0xec, 0x46, 0x00,
-	0xe5, 0x6e, 0xa7, 0xfd, 0xf0, 0x68, 0xbf, 0x36, 0x47, 0xff, 0x7f, 0x70, 0x78, 0x74, 0xfc, 0xa4,
-	0x53, 0x2b, 0xa0, 0x25, 0x58, 0xb8, 0xfb, 0xf0, 0x58, 0xad, 0x15, 0x29, 0xc2, 0x7e, 0xeb, 0xdb,
-	0xda, 0x3c, 0x1d, 0xfa, 0xba, 0xd3, 0xb9, 0x57, 0x5b, 0x40, 0xcb, 0x50, 0x7a, 0xf0, 0xf0, 0xe8,
-	0xc9, 0xdd, 0x5a, 0x09, 0xad, 0xc0, 0xe2, 0xe3, 0xe3, 0x96, 0xfa, 0xa4, 0xa3, 0xd6, 0xca, 0x94,
-	0xe3, 0xdb, 0x4e, 0x4b, 0xad, 0x2d, 0x5e, 0x6f, 0xc6, 0xdc, 0x58, 0xc4, 0x22, 0xca, 0xdc, 0xbe,
-	0xdf, 0xea, 0x76, 0xb5, 0x76, 0x6d, 0x2e, 0xfa, 0xf8, 0xb2, 0x56, 0xd8, 0xfb, 0xf7, 0x36, 0x9c,
-	0x3b, 0xc2, 0x64, 0xec, 0x7a, 0x27, 0x5d, 0xec, 0x9d, 0x62, 0x8f, 0x37, 0xd4, 0xd1, 0xf7, 0x61,
-	0x19, 0x2e, 0xd9, 0x61, 0x47, 0xdb, 0xd4, 0x32, 0x39, 0x3f, 0xb0, 0x68, 0xec, 0x64, 0x33, 0x30,
-	0xdb, 0x2b, 0x73, 0x48, 0x0d, 0x8a, 0x74, 0x29, 0xe4, 0xad, 0x20, 0x43, 0xc8, 0xf8, 0xb9, 0x44,
-	0xe3, 0x72, 0x06, 0x55, 0x60, 0x3e, 0x0e, 0x2b, 0x54, 0x32, 0x81, 0x73, 0x7e, 0x88, 0xd0, 0xb8,
-	0x30, 0x71, 0x0f, 0x77, 0xec, 0x21, 0x39, 0x63, 0x90, 0xb2, 0x5f, 0x19, 0x30, 0xc8, 0x9c, 0xdf,
-	0x1f, 0xe4, 0x40, 0x0a, 0xb3, 0x26, 0x9b, 0xd4, 0x71, 0xb3, 0x4a, 0xdb, 0xd7, 0x71, 0xb3, 0xca,
-	0x7f, 0x0e, 0x20, 0xcc, 0x9a, 0x42, 0x0e, 0xcd, 0x2a, 0x87, 0xbd, 0x9c, 0x41, 0x9d, 0x34, 0xab,
-	0x4c, 0xe0, 0x9c, 0x5e, 0xfe, 0x2c, 0x66, 0x95, 0x41, 0xe6, 0xb4, 0xf0, 0x73, 0x20, 0xbf, 0x49,
-	0xf6, 0x30, 0x43, 0xc4, 0x77, 0x22, 0xa3, 0xc9, 0xda, 0xc1, 0x8d, 0xed, 0x4c, 0xba, 0xd0, 0xff,
-	0x61, 0xac, 0xc5, 0x19, 0xc2, 0x5e, 0xe2, 0x46, 0x93, 0x62, 0x6e, 0xc9, 0x89, 0x31, 0xc0, 0x0d,
-	0x49, 0xe3, 0x9b, 0x89, 0x9a, 0xdd, 0x11, 0xcf, 0xd1, 0xfd, 0x61, 0xb2, 0xd9, 0x98, 0x00, 0xcc,
-	0x6e, 0x85, 0xe7, 0x00, 0xb6, 0x60, 0x35, 0x6e, 0x13, 0xb4, 0x99, 0xb6, 0xd2, 0x74, 0x88, 0xcf,
-	0x60, 0x59, 0x98, 0x00, 0x9d, 0x4b, 0x58, 0x24, 0x9c, 0x7c, 0x3e, 0x35, 0x2a, 0x0c, 0xd4, 0x82,
-	0xd5, 0xb8, 0x1d, 0xd8, 0xf2, 0x92, 0x4e, 0x6c, 0xbe, 0x06, 0x71, 0xcd, 0x19, 0x84, 0xa4, 0x23,
-	0x9b, 0x03, 0xd1, 0x81, 0x6a, 0xb2, 0xab, 0x88, 0x2e, 0x06, 0x15, 0x54, 0x59, 0x2f, 0x30, 0x07,
-	0xe6, 0x10, 0x6a, 0xe9, 0x06, 0x22, 0x73, 0x9f, 0x8c, 0xb6, 0x62, 0xbe, 0x8f, 0x4b, 0x1a, 0x84,
-	0x6c, 0x9f, 0xb3, 0x1b, 0x8e, 0xcc, 0xc7, 0x73, 0x3a, 0x8b, 0xca, 0x1c, 0xea, 0xc2, 0x79, 0x69,
-	0xe9, 0x11, 0xed, 0xa4, 0x77, 0x3e, 0x9d, 0x81, 0xe4, 0xde, 0x74, 0x17, 0x33, 0xcb, 0x90, 0xe8,
-	0x2a, 0x05, 0x9e, 0x56, 0xa5, 0xcc, 0x01, 0xf7, 0x61, 0x2b, 0xaf, 0xcc, 0x88, 0xae, 0x25, 0x94,
-	0xce, 0x2e, 0x64, 0x36, 0x76, 0xa7, 0x33, 0x0a, 0x33, 0xb1, 0x45, 0x33, 0x0b, 0x89, 0x62, 0xd1,
-	0x69, 0xa5, 0x4a, 0xb1, 0xe8, 0xd4, 0x9a, 0xa4, 0x32, 0x87, 0xbe, 0x0a, 0xee, 0x9f, 0x44, 0xd3,
-	0x16, 0x65, 0xd8, 0x45, 0x5c, 0x3d, 0xd2, 0x16, 0x2f, 0xdb, 0x92, 0xcc, 0x4e, 0x2e, 0xdb, 0x92,
-	0x69, 0x8d, 0xde, 0x9c, 0x2d, 0x39, 0x86, 0x0b, 0xf2, 0xd6, 0x2d, 0xba, 0xc2, 0x7e, 0xd0, 0x97,
-	0xd3, 0xd6, 0xcd, 0x81, 0x6d, 0x43, 0x25, 0x51, 0x9c, 0x41, 0xf5, 0x48, 0xce, 0x64, 0x1d, 0x36,
-	0x07, 0xe4, 0x0b, 0x80, 0xa8, 0x08, 0x83, 0xc2, 0x9b, 0x67, 0x62, 0x7a, 0x6a, 0x58, 0xd8, 0xad,
-	0x0d, 0x95, 0x44, 0xcd, 0x83, 0xc9, 0x20, 0xeb, 0x87, 0xe5, 0x2b, 0x92, 0x28, 0x6e, 0x30, 0x10,
-	0x59, 0x57, 0x6c, 0x96, 0xf4, 0x21, 0x55, 0x67, 0xdc, 0x9e, 0x30, 0x4a, 0x76, 0xfa, 0x20, 0xaf,
-	0x45, 0x89, 0xf4, 0x21, 0x85, 0xbc, 0x95, 0xb4, 0x4a, 0x46, 0xfa, 0x90, 0x89, 0xf9, 0x38, 0xd5,
-	0x37, 0x94, 0xa4, 0x0f, 0x72, 0xe4, 0x19, 0xd2, 0x07, 0x19, 0x64, 0x4e, 0xfd, 0x28, 0x07, 0xf2,
-	0x3e, 0xac, 0xa5, 0x7a, 0x4e, 0xa8, 0x91, 0xd4, 0x2c, 0xde, 0x7c, 0x6b, 0x5c, 0x92, 0xd2, 0x84,
-	0xce, 0x03, 0xb8, 0x98, 0x59, 0xef, 0x67, 0xc7, 0x6c, 0x5a, 0x4b, 0xa1, 0xf1, 0xde, 0x14, 0xae,
-	0x70, 0xad, 0xff, 0x2b, 0x20, 0x0b, 0xea, 0x59, 0x65, 0x77, 0xf4, 0xae, 0x1c, 0x26, 0x19, 0x71,
-	0xae, 0xe6, 0x33, 0xc5, 0x96, 0x12, 0xde, 0x97, 0xaa, 0xba, 0xc5, 0xbc, 0x4f, 0xfa, 0x9c, 0x8b,
-	0x7b, 0x9f, 0xfc, 0x3d, 0x26, 0xbc, 0x2f, 0x85, 0x1c, 0x7a, 0x9f, 0x1c, 0xf6, 0x72, 0x06, 0x75,
-	0xd2, 0xfb, 0x64, 0x02, 0xe7, 0x54, 0x55, 0x66, 0xf1, 0x3e, 0x19, 0x64, 0x4e, 0x31, 0x25, 0x3f,
-	0x52, 0x66, 0x96, 0x55, 0x98, 0xbf, 0x4c, 0xab, 0xba, 0xe4, 0x80, 0x63, 0x78, 0x27, 0xbf, 0x90,
-	0x82, 0x3e, 0x08, 0x7e, 0x5b, 0x3b, 0x4b, 0xb1, 0x25, 0x5f, 0x87, 0xcc, 0x6a, 0x05, 0xd3, 0x61,
-	0x5a, 0x31, 0x23, 0x07, 0xfc, 0x07, 0xb8, 0x3a, 0x4b, 0x71, 0x02, 0xdd, 0x14, 0x59, 0xc5, 0x6c,
-	0x65, 0x8c, 0x9c, 0x25, 0xff, 0x50, 0x80, 0x6b, 0x33, 0xd6, 0x14, 0xd0, 0x5e, 0xda, 0x0d, 0xa7,
-	0x17, 0x38, 0x1a, 0x1f, 0xbf, 0xd4, 0x1c, 0xe1, 0xd0, 0xb7, 0x83, 0x40, 0xc6, 0x5b, 0x57, 0x99,
-	0x79, 0x40, 0x18, 0xc9, 0x52, 0x2d, 0x2e, 0x65, 0xee, 0x59, 0x39, 0xe0, 0xfc, 0xf8, 0xbf, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0xfb, 0x21, 0xf1, 0x75, 0xab, 0x31, 0x00, 0x00,
+	// 4627 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x3b, 0x4d, 0x73, 0xdb, 0x48,
+	0x76, 0x06, 0x25, 0xea, 0xe3, 0x89, 0xa4, 0xa9, 0x96, 0x6d, 0xd1, 0x94, 0xf5, 0x61, 0xd8, 0x9e,
+	0xd1, 0xc8, 0x1e, 0x39, 0x91, 0x6b, 0x92, 0x9d, 0x99, 0x5d, 0x6f, 0x38, 0x14, 0x25, 0x6b, 0x6c,
+	0x4b, 0x32, 0x28, 0x79, 0x67, 0x77, 0x2b, 0x41, 0x20, 0xa0, 0x49, 0x23, 0x22, 0x00, 0x1a, 0x68,
+	0x4a, 0xd6, 0xa4, 0x72, 0x48, 0x2a, 0x87, 0x54, 0xe5, 0x90, 0x4b, 0xfe, 0xc0, 0x9e, 0x92, 0x4b,
+	0xaa, 0x72, 0xca, 0x21, 0x87, 0x54, 0xce, 0x5b, 0x95, 0x54, 0xaa, 0x72, 0xdb, 0x3f, 0x90, 0x4b,
+	0x4e, 0xa9, 0xdc, 0x92, 0x43, 0xaa, 0x3f, 0xf0, 0x49, 0x00, 0xa4, 0xe4, 0x71, 0x39, 0x27, 0xb2,
+	0xfb, 0x7d, 0xf4, 0xeb, 0xd7, 0xaf, 0x5f, 0xbf, 0xee, 0xf7, 0x00, 0x33, 0xb6, 0xb7, 0xd9, 0x77,
+	0x1d, 0xe2, 0xa0, 0x82, 0xed, 0xd5, 0x57, 0xbb, 0x8e, 0xd3, 0xed, 0xe1, 0xc7, 0xac, 0xe7, 0x64,
+	0xd0, 0x79, 0x4c, 0x4c, 0x0b, 0x7b, 0x44, 0xb3, 0xfa, 0x1c, 0xa9, 0xbe, 0x92, 0x44, 0x30, 0x06,
+	0xae, 0x46, 0x4c, 0xc7, 0x16, 0xf0, 0xa5, 0x24, 0x1c, 0x5b, 0x7d, 0x72, 0x21, 0x80, 0x8b, 0x5a,
+	0xdf, 0x7c, 0xac, 0x3b, 0x96, 0xe5, 0xd8, 0xe2, 0x47, 0x00, 0xae, 0x53, 0x40, 0xf7, 0xfc, 0x71,
+	0xf7, 0x5c, 0x74, 0x54, 0xfa, 0xae, 0xd3, 0x31, 0x7b, 0x58, 0xc8, 0x26, 0xff, 0x02, 0x96, 0x9a,
+	0x2e, 0xd6, 0x08, 0x6e, 0x63, 0xf7, 0xcc, 0xd4, 0xf1, 0x21, 0x07, 0x2b, 0xf8, 0xed, 0x00, 0x7b,
+	0x04, 0x7d, 0x0d, 0xd7, 0x3d, 0x0e, 0x50, 0x05, 0x61, 0x4d, 0x5a, 0x93, 0xd6, 0xe7, 0xb6, 0xd0,
+	0xa6, 0xed, 0x6d, 0x26, 0x68, 0x2a, 0x5e, 0xac, 0x2d, 0x6f, 0xc2, 0x9d, 0x74, 0xde, 0x5e, 0xdf,
+	0xb1, 0x3d, 0x8c, 0x2a, 0x50, 0x30, 0x0d, 0xc6, 0xaf, 0xa4, 0x14, 0x4c, 0x43, 0xde, 0x80, 0xda,
+	0x2e, 0x26, 0xe9, 0x82, 0x24, 0x71, 0xff, 0x55, 0x82, 0xdb, 0x29, 0xc8, 0x82, 0xf3, 0xfb, 0x88,
+	0x8d, 0xbe, 0x04, 0xd0, 0x99, 0xd8, 0x86, 0xaa, 0x91, 0x5a, 0x81, 0xd1, 0xd5, 0x37, 0xb9, 0xfa,
+	0x37, 0x7d, 0xf5, 0x6f, 0x1e, 0xf9, 0xeb, 0xa7, 0xcc, 0x0a, 0xec, 0x06, 0xa1, 0xa4, 0x83, 0xbe,
+	0xe1, 0x93, 0x4e, 0x8c, 0x26, 0x15, 0xd8, 0x0d, 0x42, 0x17, 0xe2, 0x98, 0x35, 0x3e, 0xc0, 0x42,
+	0x7c, 0x0e, 0x4b, 0xdb, 0xb8, 0x87, 0x09, 0x1e, 0x4f, 0xb7, 0x81, 0x4d, 0x28, 0xce, 0x80, 0x98,
+	0x76, 0x77, 0x58, 0x14, 0x97, 0x03, 0xd2, 0x44, 0x49, 0xd0, 0x54, 0xdc, 0x58, 0x3b, 0xb4, 0x89,
+	0x24, 0xef, 0x5c, 0x9b, 0x48, 0x17, 0x24, 0xc3, 0x26, 0x32, 0x38, 0xbf, 0x8f, 0xd8, 0x1f, 0xdb,
+	0x26, 0x3e, 0xc0, 0x42, 0x04, 0x36, 0x31, 0x9e, 0x6e, 0x5f, 0x43, 0x9d, 0xaf, 0xdb, 0x36, 0x4e,
+	0xb1, 0xa0, 0x1f, 0x41, 0xc5, 0xc0, 0x29, 0xc6, 0x39, 0x4f, 0x05, 0x89, 0x53, 0x94, 0x0d, 0x9c,
+	0x30, 0xcd, 0x54, 0xbe, 0x19, 0xe6, 0xf0, 0x19, 0x2c, 0xee, 0x62, 0x92, 0x2a, 0x43, 0x12, 0xf5,
+	0xd7, 0x12, 0xd4, 0x86, 0x71, 0x05, 0xdf, 0x2b, 0x0b, 0xfc, 0x91, 0x2c, 0xe1, 0x35, 0xd4, 0xb9,
+	0x25, 0xfc, 0xc0, 0xea, 0x7f, 0x04, 0x75, 0x6e, 0x05, 0x63, 0xa9, 0xf4, 0x4f, 0x0b, 0x30, 0xc5,
+	0x11, 0xd1, 0x22, 0x4c, 0x1b, 0xf8, 0x4c, 0xc5, 0x03, 0x53, 0xc0, 0xa7, 0x0c, 0x7c, 0xd6, 0x1a,
+	0x98, 0x68, 0x03, 0xe6, 0xe3, 0xb2, 0xa8, 0xa6, 0xc1, 0xd4, 0x54, 0x52, 0xae, 0xc7, 0xc6, 0xde,
+	0x33, 0xd0, 0x23, 0x40, 0x09, 0xa7, 0x46, 0x91, 0x27, 0x18, 0x72, 0x35, 0xee, 0xc3, 0x38, 0x76,
+	0xc2, 0xdc, 0x29, 0xf6, 0x24, 0xc7, 0x8e, 0x5b, 0xf7, 0x9e, 0x81, 0x3e, 0x85, 0xaa, 0x77, 0x6a,
+	0xf6, 0xd5, 0x8e, 0xaa, 0xdb, 0x44, 0xd5, 0xdf, 0x60, 0xfd, 0xb4, 0x56, 0x5c, 0x93, 0xd6, 0x67,
+	0x94, 0x32, 0xed, 0xdf, 0x69, 0xda, 0xa4, 0x49, 0x3b, 0xd1, 0xe7, 0x80, 0x5c, 0xdc, 0xc1, 0x2e,
+	0xb6, 0x75, 0xac, 0x6a, 0x3d, 0x62, 0x92, 0x81, 0x81, 0x6b, 0x53, 0x6b, 0xd2, 0xba, 0xa4, 0xcc,
+	0x07, 0x90, 0x86, 0x00, 0xc8, 0x5f, 0xc2, 0x42, 0xd4, 0x60, 0x7d, 0x55, 0xc9, 0x30, 0xc5, 0x67,
+	0x27, 0x54, 0x0f, 0xa1, 0xea, 0x15, 0x01, 0x91, 0x1f, 0x42, 0x35, 0x30, 0x48, 0x9f, 0x2e, 0x4b,
+	0x8f, 0xf2, 0xdf, 0x49, 0x30, 0x1f, 0xc1, 0x16, 0x76, 0x3b, 0xc6, 0x30, 0x1f, 0xc9, 0x42, 0xbf,
+	0x84, 0x85, 0xa8, 0x85, 0x5e, 0x46, 0x2f, 0x9b, 0xb0, 0x10, 0x35, 0xc2, 0x91, 0xaa, 0xf9, 0xc7,
+	0x02, 0x54, 0x39, 0x6a, 0x43, 0x27, 0xe6, 0x19, 0x8b, 0x92, 0xb2, 0x0d, 0xf2, 0x36, 0xcc, 0x50,
+	0x80, 0x66, 0x18, 0xae, 0xb0, 0x43, 0x8a, 0xd8, 0x30, 0x0c, 0x17, 0xdd, 0x87, 0xeb, 0x9e, 0x6a,
+	0x9f, 0x9f, 0xaa, 0x9e, 0x6a, 0xda, 0x44, 0x3d, 0xc5, 0x17, 0xc2, 0xf8, 0xe6, 0xbc, 0xfd, 0xf3,
+	0xd3, 0xf6, 0x9e, 0x4d, 0x9e, 0xe3, 0x0b, 0x8a, 0xd5, 0x49, 0x60, 0x71, 0xa3, 0x9b, 0xeb, 0x44,
+	0xb0, 0xee, 0x42, 0x99, 0xe3, 0x60, 0x5b, 0x67, 0x38, 0x45, 0x86, 0x03, 0xf6, 0xf9, 0x69, 0xbb,
+	0x65, 0xeb, 0x14, 0xa5, 0x06, 0x33, 0xdc, 0x1a, 0x07, 0x7d, 0x66, 0x5f, 0x65, 0x65, 0xaa, 0xd3,
+	0xb4, 0xc9, 0x71, 0x1f, 0xad, 0x42, 0xc9, 0x16, 0x96, 0x6a, 0x38, 0xe7, 0x76, 0x6d, 0x9a, 0x41,
+	0x67, 0x6d, 0x6a, 0xa5, 0xdb, 0xce, 0xb9, 0x4d, 0x11, 0xb4, 0x28, 0xc2, 0x0c, 0x47, 0xd0, 0x02,
+	0x84, 0x34, 0x73, 0x9f, 0x4d, 0x31, 0x77, 0xf9, 0x17, 0x70, 0x53, 0x68, 0x2d, 0xa1, 0xee, 0x46,
+	0xb0, 0x71, 0xb5, 0x40, 0xab, 0x62, 0xd1, 0x6e, 0x84, 0x8b, 0x16, 0x6a, 0x5c, 0xa9, 0x1a, 0x89,
+	0x1e, 0x79, 0x0b, 0x16, 0xb7, 0xb1, 0x96, 0xca, 0x3d, 0x73, 0x31, 0xbf, 0x80, 0x7a, 0x60, 0xe6,
+	0x11, 0xe6, 0xa3, 0xc8, 0xfe, 0x10, 0x96, 0x52, 0xc9, 0xc4, 0x3e, 0xf9, 0x01, 0x26, 0xf3, 0x6b,
+	0x89, 0xce, 0x86, 0x76, 0xb6, 0x89, 0x46, 0x06, 0xde, 0x33, 0xd3, 0x23, 0x8e, 0x7b, 0xb1, 0x47,
+	0xb0, 0x85, 0x36, 0x61, 0x92, 0xc6, 0xef, 0x35, 0x69, 0xe4, 0x0e, 0x61, 0x78, 0x68, 0x1d, 0x26,
+	0xc9, 0x45, 0x1f, 0x33, 0xfb, 0xab, 0x44, 0x25, 0xe0, 0xac, 0x8f, 0x2e, 0xfa, 0x58, 0x61, 0x18,
+	0xa8, 0x06, 0xd3, 0x27, 0x1a, 0x21, 0xd8, 0xe5, 0xa6, 0x58, 0x56, 0xfc, 0x26, 0xba, 0x05, 0x53,
+	0x96, 0xe6, 0x76, 0x4d, 0x9b, 0x59, 0x5f, 0x51, 0x11, 0x2d, 0x74, 0x0f, 0xca, 0x5d, 0x8d, 0xe0,
+	0x73, 0xed, 0x42, 0xd5, 0x9d, 0x81, 0x4d, 0x98, 0xe1, 0x95, 0x95, 0x92, 0xe8, 0x6c, 0xd2, 0x3e,
+	0xf9, 0x9f, 0x24, 0x58, 0x0e, 0xf4, 0x15, 0x9b, 0xcf, 0x28, 0x4d, 0xa3, 0x26, 0x5c, 0xf7, 0x88,
+	0xe6, 0x12, 0x35, 0xb8, 0xb1, 0x8c, 0xe1, 0x53, 0x2a, 0x8c, 0x24, 0x68, 0xa3, 0x9f, 0x42, 0x19,
+	0xdb, 0x46, 0x84, 0xc5, 0x68, 0xdf, 0x52, 0xc2, 0xb6, 0x11, 0xb4, 0xe4, 0x63, 0x58, 0xc9, 0x92,
+	0x5f, 0x2c, 0xf9, 0x13, 0x98, 0x72, 0xb1, 0x37, 0xe8, 0x91, 0x9a, 0xb4, 0x36, 0xb1, 0x3e, 0xb7,
+	0xb5, 0x94, 0xd4, 0x72, 0x64, 0x01, 0x15, 0x81, 0x2a, 0x7f, 0xc1, 0xc3, 0x4b, 0xcd, 0x36, 0x1c,
+	0x6b, 0x9b, 0x7b, 0x85, 0x80, 0x61, 0xd4, 0x71, 0x48, 0x31, 0xc7, 0x21, 0x9b, 0xb0, 0xc6, 0x0f,
+	0x81, 0x97, 0x8d, 0x66, 0xd3, 0xb1, 0x2c, 0xcd, 0x36, 0x5e, 0x0d, 0xf0, 0x00, 0x33, 0xde, 0xa3,
+	0x14, 0x5a, 0x85, 0x09, 0x5d, 0x1c, 0x5c, 0x65, 0x85, 0xfe, 0x45, 0x75, 0x98, 0xd1, 0x39, 0x17,
+	0xaf, 0x56, 0x5c, 0x9b, 0x58, 0x2f, 0x29, 0x41, 0x5b, 0xfe, 0x8d, 0x04, 0xcb, 0x6d, 0x6c, 0x1b,
+	0x87, 0xae, 0xd3, 0x77, 0x4d, 0x4c, 0x34, 0xf7, 0xe2, 0x50, 0xbb, 0xe8, 0x39, 0x9a, 0xe1, 0x0f,
+	0xb4, 0x0a, 0x73, 0x96, 0xa6, 0xab, 0x7d, 0xde, 0x2b, 0x06, 0x03, 0x4b, 0xd3, 0x05, 0x1e, 0x1d,
+	0xd0, 0x32, 0x75, 0xe1, 0xfc, 0xe8, 0x5f, 0x74, 0x17, 0x7c, 0xf3, 0x50, 0x2d, 0x4d, 0xf7, 0x6a,
+	0x13, 0x6c, 0xd0, 0x39, 0xd1, 0xf7, 0x52, 0xd3, 0x3d, 0xf4, 0x05, 0xdc, 0xea, 0x3b, 0x3d, 0xcd,
+	0x35, 0xbf, 0x67, 0xdb, 0x41, 0x35, 0xed, 0x33, 0xec, 0x7a, 0x74, 0x1b, 0x4d, 0x32, 0xb7, 0x72,
+	0x33, 0x0a, 0xdd, 0xf3, 0x81, 0xe8, 0x0e, 0xcc, 0x76, 0x5c, 0x2a, 0x98, 0xad, 0x5f, 0x08, 0x4b,
+	0x0c, 0x3b, 0x68, 0x40, 0x61, 0xb8, 0xc2, 0xf7, 0x15, 0x0c, 0x57, 0xfe, 0x0f, 0x09, 0xa6, 0x77,
+	0xf9, 0xa0, 0xc9, 0x60, 0x03, 0x3d, 0x82, 0x99, 0x9e, 0xa3, 0xf3, 0x9d, 0xcb, 0x0d, 0xae, 0xba,
+	0x29, 0xee, 0xb6, 0x2f, 0x44, 0xbf, 0x12, 0x60, 0xd0, 0xe0, 0xc0, 0x9f, 0xd1, 0x70, 0x28, 0x21,
+	0x20, 0x61, 0x70, 0xb0, 0x0e, 0x53, 0x27, 0x8e, 0xe6, 0x1a, 0x5e, 0x6d, 0x92, 0xd9, 0x4a, 0x95,
+	0xda, 0x8a, 0x10, 0xe4, 0x1b, 0x0a, 0x50, 0x04, 0x3c, 0x23, 0xe8, 0x28, 0x66, 0x04, 0x1d, 0x6c,
+	0xf7, 0xea, 0xa7, 0xd8, 0x36, 0xd8, 0x24, 0x67, 0x15, 0xbf, 0x29, 0x1f, 0x43, 0x29, 0xca, 0x9f,
+	0x5a, 0x47, 0xa7, 0xdf, 0xd5, 0xd4, 0x60, 0xca, 0x53, 0xb4, 0xc9, 0xa3, 0x9c, 0x8e, 0x69, 0xe3,
+	0x70, 0xab, 0xb0, 0xc3, 0x84, 0xaf, 0x5d, 0x95, 0x42, 0x82, 0x3d, 0xf1, 0x1c, 0x5f, 0xc8, 0x3f,
+	0x81, 0x1b, 0xdc, 0x10, 0x05, 0x73, 0xdf, 0x26, 0x1e, 0xc0, 0xb4, 0x98, 0xb4, 0xf0, 0x51, 0x73,
+	0x91, 0x19, 0x2a, 0x3e, 0x4c, 0xbe, 0xc7, 0x62, 0x8c, 0x04, 0x6d, 0x32, 0xea, 0xfb, 0xd5, 0x04,
+	0xa0, 0x28, 0x96, 0xd8, 0x1e, 0xe3, 0x0d, 0xf1, 0x71, 0xa2, 0x11, 0xf4, 0x14, 0xca, 0x1d, 0xd3,
+	0xf5, 0x88, 0xea, 0x61, 0x6c, 0x53, 0xea, 0xc9, 0x91, 0xd4, 0x73, 0x8c, 0xa0, 0x8d, 0xb1, 0xdd,
+	0x20, 0xe8, 0xc7, 0x50, 0xea, 0x69, 0x11, 0xf2, 0xe2, 0x48, 0x72, 0xe8, 0x69, 0x01, 0xf5, 0x06,
+	0x14, 0x3d, 0xa2, 0x11, 0x1e, 0x45, 0x52, 0x7f, 0x2f, 0xec, 0x56, 0x28, 0x87, 0xba, 0x23, 0xac,
+	0x70, 0x14, 0xb4, 0x0d, 0x55, 0xf6, 0x47, 0xd5, 0xdf, 0x68, 0x76, 0x97, 0x4f, 0x75, 0x7a, 0x2c,
+	0xff, 0x4a, 0x70, 0x93, 0x93, 0x34, 0x08, 0xb5, 0x03, 0x1e, 0x7d, 0x5d, 0xcd, 0x0e, 0x3e, 0x81,
+	0x1b, 0x3c, 0x02, 0x1b, 0x61, 0x0a, 0x7f, 0x59, 0x80, 0x52, 0x64, 0x12, 0x1e, 0xfa, 0x11, 0xcc,
+	0x86, 0x3e, 0x7d, 0xf4, 0x69, 0x18, 0x22, 0xa3, 0x4d, 0x58, 0x70, 0xdf, 0xa9, 0x7d, 0xba, 0x3d,
+	0x88, 0xa7, 0xba, 0x58, 0xc7, 0xe6, 0x19, 0xe6, 0x37, 0x85, 0xa2, 0x32, 0xef, 0xbe, 0x3b, 0xe4,
+	0x10, 0x45, 0x00, 0xd0, 0x13, 0xb8, 0x95, 0x82, 0xaf, 0x3a, 0xa7, 0xcc, 0x30, 0x8a, 0xca, 0xc2,
+	0x10, 0xc9, 0xc1, 0x29, 0x1d, 0x84, 0xa4, 0x0c, 0xc2, 0x0f, 0xd0, 0x79, 0x32, 0x34, 0xc8, 0x23,
+	0x40, 0x11, 0x7c, 0x6c, 0x99, 0x84, 0x60, 0xbe, 0xdb, 0x8b, 0x4a, 0x35, 0x40, 0x6f, 0xf1, 0x7e,
+	0xf9, 0xbf, 0x24, 0xb8, 0x15, 0x6e, 0x0c, 0xa6, 0x10, 0x5f, 0x71, 0xcb, 0x00, 0xbe, 0x3b, 0x0a,
+	0x14, 0x38, 0x2b, 0x7a, 0xf6, 0xe8, 0x64, 0x66, 0x4c, 0x9b, 0x60, 0xf7, 0x4c, 0xeb, 0x89, 0x98,
+	0x60, 0x91, 0xae, 0x4b, 0xa3, 0xdb, 0x75, 0x71, 0x57, 0x78, 0x54, 0x0e, 0x56, 0x02, 0xc4, 0xb4,
+	0x83, 0x78, 0xe2, 0xfd, 0x0f, 0xe2, 0xc9, 0x4b, 0x1e, 0xc4, 0x4d, 0x58, 0x1c, 0x9a, 0xb3, 0xf0,
+	0x08, 0xeb, 0x89, 0x13, 0x38, 0xea, 0x55, 0x39, 0xa6, 0x7f, 0xec, 0xfe, 0xbd, 0x04, 0xd7, 0xf9,
+	0xd1, 0x1c, 0x1c, 0x9b, 0xd9, 0xe7, 0xe5, 0x2a, 0xcc, 0x75, 0x5c, 0x2b, 0x38, 0xdf, 0xb8, 0x2b,
+	0x84, 0x8e, 0x6b, 0xf9, 0xe7, 0xdb, 0x02, 0x14, 0x59, 0xd8, 0x2b, 0x22, 0xa6, 0x49, 0x1a, 0x54,
+	0xa3, 0x9b, 0x30, 0xd5, 0x51, 0xfb, 0x8e, 0x4b, 0xc4, 0x41, 0x5b, 0xec, 0x1c, 0x3a, 0x2e, 0xa1,
+	0xe7, 0x93, 0xee, 0xd8, 0x1d, 0xd3, 0xb5, 0xc4, 0xc2, 0xce, 0x28, 0x61, 0x47, 0xec, 0xc8, 0x9f,
+	0x8a, 0x1f, 0xf9, 0xbb, 0xfe, 0xc3, 0x55, 0x42, 0x6e, 0x7f, 0xc5, 0x3f, 0x85, 0x49, 0x93, 0x60,
+	0x4b, 0x6c, 0x82, 0x85, 0x30, 0xf8, 0x08, 0x31, 0x19, 0x82, 0xfc, 0x35, 0xac, 0xed, 0xf4, 0x06,
+	0xde, 0x9b, 0x08, 0x74, 0xc7, 0x71, 0xb7, 0xf1, 0x59, 0xeb, 0x78, 0x6f, 0x64, 0xd8, 0xfb, 0x14,
+	0xee, 0x05, 0x61, 0x50, 0xc0, 0xd8, 0x1b, 0x9f, 0xfe, 0x15, 0xdc, 0xcf, 0xa7, 0x17, 0x4b, 0xf9,
+	0x19, 0x14, 0xa9, 0xb0, 0x9e, 0x58, 0xc9, 0xd4, 0xe9, 0x70, 0x0c, 0x21, 0xd2, 0x3e, 0x7e, 0xc7,
+	0x2e, 0x22, 0x3d, 0xd3, 0x3e, 0xa5, 0x97, 0x8d, 0xf1, 0x45, 0xfa, 0x1a, 0xee, 0xe7, 0xd3, 0x0b,
+	0x91, 0x82, 0x55, 0x96, 0xc2, 0x55, 0x96, 0xff, 0x57, 0x82, 0xdb, 0xad, 0x77, 0x58, 0xdf, 0xf5,
+	0x83, 0x5d, 0x16, 0x35, 0x8d, 0xb9, 0x0b, 0x6b, 0x30, 0x2d, 0xc2, 0x2c, 0x66, 0x54, 0xb3, 0x8a,
+	0xdf, 0x44, 0x37, 0xa8, 0x03, 0x37, 0x4c, 0x5b, 0x04, 0x10, 0xbc, 0x81, 0x0e, 0x61, 0x0e, 0xdb,
+	0x67, 0xa6, 0xeb, 0xd8, 0x16, 0xb6, 0x89, 0x08, 0x1d, 0x36, 0xa9, 0x6a, 0x32, 0x45, 0xd8, 0x6c,
+	0x85, 0x04, 0x2d, 0x9b, 0xb8, 0x17, 0x4a, 0x94, 0x45, 0xfd, 0x29, 0x54, 0x93, 0x08, 0x34, 0x5a,
+	0xa3, 0x27, 0xbe, 0xc4, 0x24, 0xa2, 0x7f, 0xa9, 0x34, 0x67, 0x5a, 0x6f, 0x80, 0x85, 0x94, 0xbc,
+	0xf1, 0x55, 0xe1, 0x47, 0x92, 0xfc, 0xc7, 0x50, 0x4f, 0x1b, 0x5a, 0x68, 0x6c, 0x11, 0xa6, 0xf1,
+	0x3b, 0xac, 0x47, 0x62, 0x0c, 0xda, 0xdc, 0x33, 0xe8, 0x55, 0xc2, 0x23, 0x86, 0x33, 0x20, 0x62,
+	0x33, 0x89, 0x96, 0xe8, 0xc7, 0xae, 0x2b, 0xe6, 0x2d, 0x5a, 0x54, 0x00, 0xec, 0xba, 0x8e, 0xcb,
+	0xb6, 0xd2, 0xac, 0xc2, 0x1b, 0x72, 0x03, 0xd6, 0xda, 0xc4, 0xc5, 0x9a, 0xb5, 0xe3, 0x6a, 0x16,
+	0x7e, 0xe1, 0x74, 0xa9, 0x1d, 0x25, 0x0e, 0x90, 0xfc, 0x15, 0x90, 0xff, 0x56, 0x82, 0xbb, 0x39,
+	0x3c, 0xc4, 0x3c, 0x9e, 0x42, 0x75, 0xd0, 0xa7, 0x86, 0xa1, 0x76, 0x28, 0x96, 0xea, 0x61, 0x12,
+	0x3c, 0x74, 0x76, 0xcf, 0x37, 0x8f, 0x19, 0x8c, 0x31, 0x68, 0x63, 0xf2, 0xec, 0x9a, 0x52, 0x19,
+	0xc4, 0x7a, 0xd0, 0x57, 0x50, 0x31, 0x84, 0x69, 0x71, 0x0e, 0x22, 0x0c, 0x99, 0xa7, 0xd4, 0x81,
+	0xd1, 0x51, 0xc0, 0xb3, 0x6b, 0x4a, 0xd9, 0x88, 0x76, 0x7c, 0x33, 0x0d, 0x45, 0x46, 0x22, 0x7f,
+	0x05, 0xab, 0xc3, 0x92, 0x8e, 0x79, 0xc7, 0xfd, 0x1b, 0x09, 0xd6, 0xb2, 0x89, 0xff, 0x3f, 0xcd,
+	0xf2, 0x35, 0x0b, 0xf5, 0x5e, 0xf3, 0x60, 0x3e, 0x10, 0xad, 0x06, 0xd3, 0x7e, 0xf0, 0xcf, 0xcd,
+	0xd2, 0x6f, 0xa2, 0x4f, 0xa8, 0xcb, 0xef, 0xfa, 0x21, 0x7a, 0x65, 0xab, 0xe2, 0x87, 0x3a, 0x0a,
+	0xeb, 0x55, 0x04, 0x54, 0xde, 0x82, 0x52, 0x63, 0x5b, 0x69, 0xf4, 0xba, 0x8e, 0x6b, 0x92, 0x37,
+	0x56, 0x24, 0xb0, 0x98, 0x65, 0xc1, 0x3e, 0x82, 0x49, 0xdb, 0x17, 0x79, 0x56, 0x61, 0xff, 0xe5,
+	0x36, 0xbb, 0x9b, 0x45, 0xc9, 0xc2, 0xa3, 0xe6, 0x77, 0xa1, 0xa2, 0x19, 0xae, 0xaa, 0x05, 0x90,
+	0xe8, 0x91, 0x13, 0x25, 0x51, 0xca, 0x9a, 0xe1, 0x86, 0x0c, 0xe4, 0xff, 0x94, 0xa0, 0xb2, 0x1b,
+	0xbb, 0x0e, 0x0c, 0x5d, 0x3c, 0xe8, 0x6d, 0xec, 0x8d, 0x66, 0xdb, 0xb8, 0xe7, 0xd5, 0x0a, 0x6b,
+	0x13, 0xeb, 0x65, 0x25, 0x68, 0xa3, 0x16, 0x54, 0xf0, 0x3b, 0xe2, 0x6a, 0x6a, 0x80, 0x31, 0xc1,
+	0xc6, 0x5d, 0x89, 0x1c, 0x75, 0x82, 0x6f, 0x8b, 0xe2, 0x35, 0x39, 0x9a, 0x52, 0xc6, 0x91, 0x96,
+	0x87, 0x7e, 0x0f, 0x58, 0x00, 0xe7, 0xa9, 0xfe, 0xe1, 0x2e, 0x8e, 0xe1, 0xdb, 0x43, 0xc7, 0xf0,
+	0xb6, 0xc8, 0x02, 0x2a, 0x65, 0x46, 0xe0, 0x87, 0x05, 0xe8, 0x01, 0x54, 0x4e, 0xb0, 0xa6, 0x3b,
+	0xb6, 0x8a, 0x6d, 0xed, 0xa4, 0x17, 0x1c, 0x66, 0x65, 0xde, 0xdb, 0xe2, 0x9d, 0xf2, 0xbf, 0x4b,
+	0x50, 0xcf, 0x16, 0x0b, 0x6d, 0x01, 0x58, 0x8e, 0x31, 0xe8, 0x85, 0xef, 0x23, 0x95, 0x2d, 0xe4,
+	0x2f, 0xe1, 0xcb, 0x00, 0xa2, 0x44, 0xb0, 0xe2, 0x37, 0xbc, 0x42, 0xf2, 0x86, 0x77, 0x07, 0x66,
+	0x4f, 0x34, 0xdb, 0x38, 0x37, 0x0d, 0xf2, 0x46, 0x9c, 0xc7, 0x61, 0x07, 0xbb, 0x1f, 0x99, 0xc4,
+	0xa5, 0xa1, 0xf1, 0xa4, 0x78, 0xdd, 0xe0, 0x4d, 0xf4, 0x10, 0xe6, 0xbd, 0xbe, 0x8b, 0x35, 0x83,
+	0xde, 0xb4, 0x3a, 0x9a, 0x4e, 0x1c, 0x97, 0xdf, 0x85, 0xcb, 0x4a, 0x35, 0x00, 0xec, 0xf0, 0xfe,
+	0x30, 0x41, 0x15, 0x9f, 0x5a, 0x24, 0x2f, 0x92, 0xb8, 0x0b, 0x46, 0xf3, 0x22, 0x09, 0x9a, 0x4a,
+	0xfc, 0x72, 0x18, 0x26, 0xa8, 0x92, 0xbc, 0x73, 0x13, 0x54, 0xe9, 0x82, 0x64, 0x24, 0xa8, 0x32,
+	0x38, 0xbf, 0x8f, 0xd8, 0x1f, 0x3b, 0x41, 0xf5, 0x01, 0x16, 0x22, 0x48, 0x50, 0x8d, 0xa7, 0xdb,
+	0xdf, 0x14, 0xa0, 0xf2, 0x72, 0xd0, 0x23, 0xa6, 0xae, 0x79, 0x64, 0xd7, 0x75, 0x06, 0xfd, 0xa1,
+	0x8d, 0xbd, 0x08, 0xd3, 0x96, 0x1e, 0x7d, 0x08, 0x9e, 0xb2, 0x74, 0xf6, 0x0e, 0xbc, 0x0a, 0x25,
+	0x4b, 0x17, 0x4f, 0xbc, 0xe1, 0x23, 0xf0, 0xac, 0xa5, 0xd3, 0xf7, 0x5d, 0xfa, 0x72, 0x1b, 0xc4,
+	0x1e, 0x93, 0x91, 0x08, 0xf3, 0x0b, 0x80, 0x2e, 0x1d, 0x47, 0x65, 0x4f, 0x7b, 0x45, 0xb6, 0x79,
+	0x6e, 0xd1, 0x89, 0xc5, 0xc5, 0x60, 0x8f, 0x7b, 0xb3, 0x5d, 0xff, 0x6f, 0xf2, 0x0d, 0x24, 0xbe,
+	0x9f, 0xa6, 0x93, 0xfb, 0x69, 0x1d, 0xaa, 0x7d, 0xba, 0x25, 0xbc, 0x9e, 0x43, 0xd4, 0x3e, 0x76,
+	0x4d, 0xc7, 0x10, 0x8f, 0xbf, 0x15, 0xda, 0xdf, 0xee, 0x39, 0xe4, 0x90, 0xf5, 0x66, 0x24, 0x53,
+	0x66, 0x2f, 0x95, 0x4c, 0x81, 0xf4, 0x77, 0x8d, 0x70, 0xc3, 0xc5, 0xa7, 0x16, 0x59, 0x67, 0xcb,
+	0x07, 0xa8, 0x6c, 0xa6, 0xd1, 0x75, 0x4e, 0xd0, 0x54, 0xac, 0x58, 0x3b, 0xdc, 0x70, 0x49, 0xde,
+	0xb9, 0x1b, 0x2e, 0x5d, 0x90, 0x8c, 0x0d, 0x97, 0xc1, 0xf9, 0x7d, 0xc4, 0xfe, 0xd8, 0x1b, 0xee,
+	0x03, 0x2c, 0x44, 0xb0, 0xe1, 0xc6, 0xd3, 0xad, 0x09, 0x6b, 0x0d, 0xc3, 0xe0, 0x41, 0xcc, 0x91,
+	0x93, 0x4e, 0x93, 0x79, 0xa7, 0x7b, 0x04, 0x28, 0x21, 0x68, 0x98, 0x26, 0xac, 0xc6, 0xe5, 0xda,
+	0x33, 0x64, 0x1b, 0x1e, 0x28, 0xd8, 0x72, 0xce, 0xc4, 0xdd, 0x6b, 0xc7, 0x75, 0xac, 0x0f, 0x3a,
+	0xde, 0x5f, 0x49, 0x80, 0x82, 0x01, 0xc2, 0x1b, 0x6a, 0x3a, 0x13, 0x29, 0x9d, 0x49, 0xe8, 0x33,
+	0x0a, 0xa9, 0xb7, 0xd2, 0x89, 0xe8, 0xad, 0x34, 0x71, 0xc5, 0x9d, 0x4c, 0x5e, 0x71, 0xe5, 0x1e,
+	0xac, 0xb5, 0xec, 0xb7, 0x54, 0x92, 0x61, 0xb9, 0xfc, 0xc9, 0x3f, 0x83, 0x1b, 0xa1, 0x78, 0x0c,
+	0x57, 0x8d, 0xdc, 0x48, 0xe3, 0x9e, 0x29, 0x24, 0x46, 0xd6, 0x50, 0x9f, 0xfc, 0x4b, 0x78, 0xc8,
+	0xae, 0xa8, 0x71, 0xf4, 0x1d, 0xc7, 0x4d, 0xd7, 0xfa, 0xa5, 0xf4, 0x22, 0xff, 0x01, 0x6c, 0x46,
+	0xb7, 0x64, 0xec, 0x16, 0xfa, 0x43, 0xf0, 0xff, 0x13, 0x78, 0x3c, 0x36, 0x7f, 0xe1, 0x08, 0xbe,
+	0x85, 0x9b, 0x69, 0x9a, 0xf3, 0x83, 0xca, 0x2c, 0xd5, 0x2d, 0x0c, 0xab, 0xce, 0xa3, 0x37, 0xd2,
+	0xfa, 0x30, 0xee, 0x36, 0xee, 0x99, 0x67, 0x34, 0x8b, 0x33, 0xf2, 0x61, 0x68, 0xca, 0x63, 0xc9,
+	0x0a, 0x11, 0x4f, 0x2f, 0xc5, 0x86, 0xf6, 0xb9, 0xf0, 0x7c, 0x86, 0x22, 0x50, 0xc3, 0xeb, 0xd9,
+	0x44, 0xe4, 0x7a, 0x96, 0x70, 0x50, 0x93, 0x57, 0x77, 0x50, 0xc5, 0xcb, 0x38, 0x28, 0x07, 0x36,
+	0x52, 0xb5, 0xef, 0x8b, 0xae, 0x60, 0x6a, 0xfd, 0x57, 0x5a, 0xd9, 0xd4, 0x1d, 0x25, 0xff, 0xb7,
+	0x04, 0x0f, 0xc7, 0x1a, 0x51, 0xac, 0xf5, 0xe7, 0x41, 0xa2, 0xc0, 0x53, 0x35, 0x42, 0xb0, 0xd5,
+	0x27, 0x98, 0x0f, 0x59, 0x56, 0xe6, 0x7d, 0x48, 0xc3, 0x07, 0xc4, 0xd0, 0xbd, 0x81, 0xae, 0x63,
+	0x6c, 0x88, 0x57, 0xca, 0x08, 0x7a, 0xdb, 0x07, 0xa0, 0x4f, 0x83, 0x88, 0xc7, 0x53, 0x3b, 0x9a,
+	0x49, 0xe3, 0x72, 0xbe, 0xd1, 0xfd, 0xe8, 0xc6, 0xdb, 0x61, 0xbd, 0xe8, 0x29, 0x80, 0xc1, 0x05,
+	0x34, 0xb1, 0x9f, 0x85, 0x58, 0x49, 0xb7, 0xb3, 0x60, 0x22, 0x11, 0x0a, 0xf9, 0x1f, 0x0a, 0xf0,
+	0x60, 0xe8, 0x64, 0x6b, 0x63, 0x32, 0xe8, 0x8b, 0x57, 0x00, 0xef, 0x6a, 0x3a, 0x5e, 0x81, 0x39,
+	0x4b, 0x8f, 0x7b, 0xc8, 0x32, 0x8d, 0x84, 0x7c, 0xf8, 0x3a, 0x54, 0x2d, 0x96, 0xdf, 0xa6, 0x79,
+	0x6e, 0xf7, 0xa2, 0x4f, 0xc4, 0x0c, 0x4b, 0x4a, 0xc5, 0xa2, 0x49, 0xee, 0x96, 0xdf, 0xcb, 0x82,
+	0x2a, 0xed, 0x9d, 0x6a, 0xe9, 0x6a, 0x34, 0x74, 0x9a, 0xb5, 0xb4, 0x77, 0x2f, 0x75, 0xfa, 0xc2,
+	0x43, 0x59, 0x79, 0xd8, 0xa3, 0xd7, 0x48, 0xf6, 0x1c, 0xa9, 0x3a, 0x03, 0x6e, 0x6b, 0x65, 0xa5,
+	0x22, 0xfa, 0xa9, 0x85, 0x1d, 0x0c, 0x08, 0xfa, 0x16, 0x16, 0x7c, 0x4c, 0xfe, 0x02, 0xaa, 0x75,
+	0x08, 0xe6, 0x31, 0x54, 0xbe, 0x61, 0xce, 0x0b, 0xb2, 0x36, 0xa5, 0x6a, 0x50, 0x22, 0xf9, 0xdf,
+	0x24, 0xf8, 0x64, 0x94, 0xe2, 0x84, 0xa9, 0x84, 0xce, 0x5a, 0x8a, 0x3a, 0xeb, 0x87, 0x80, 0x02,
+	0x15, 0x79, 0x94, 0x50, 0x75, 0xf1, 0x5b, 0xbf, 0xc4, 0xc5, 0xd2, 0x43, 0x86, 0x0a, 0x7e, 0x8b,
+	0xee, 0x43, 0xc5, 0xd2, 0x55, 0x5f, 0x7a, 0x8a, 0xc8, 0xb5, 0x55, 0xb2, 0xf4, 0x36, 0xef, 0xa4,
+	0x58, 0x3f, 0x86, 0x52, 0x54, 0x15, 0xa3, 0x6f, 0x83, 0x73, 0x11, 0x0d, 0xd1, 0x14, 0xe1, 0xdd,
+	0xe4, 0xe9, 0x10, 0x93, 0xe2, 0x03, 0xd8, 0x41, 0x52, 0xe2, 0x89, 0xcb, 0x48, 0x1c, 0x3e, 0x2f,
+	0x4e, 0x8e, 0x7c, 0x5e, 0xfc, 0x97, 0x02, 0xdc, 0xe6, 0xa0, 0x94, 0xb9, 0x65, 0x1f, 0xf8, 0xa3,
+	0xe4, 0xff, 0xdc, 0x4f, 0xd1, 0x4c, 0x84, 0xcf, 0xef, 0xc1, 0x00, 0x8c, 0x77, 0x2c, 0x4b, 0x93,
+	0xfa, 0x02, 0x36, 0xa4, 0x84, 0xe2, 0xa5, 0x94, 0x10, 0x77, 0xd0, 0x53, 0x57, 0x77, 0xd0, 0xd3,
+	0x97, 0x71, 0xd0, 0x6d, 0xf6, 0x5c, 0x9b, 0xa2, 0x4b, 0x71, 0xa8, 0x5c, 0xe9, 0xcc, 0x55, 0xe1,
+	0x7e, 0x3e, 0xd3, 0xe0, 0xd9, 0x66, 0x9a, 0xd7, 0x59, 0xf8, 0x47, 0xeb, 0x72, 0xb8, 0xf2, 0x69,
+	0xa6, 0xeb, 0x63, 0xcb, 0x67, 0x70, 0xef, 0x99, 0x66, 0x1b, 0x3d, 0xdc, 0xe8, 0xf7, 0x7b, 0x26,
+	0xcf, 0xf9, 0xbe, 0xd0, 0x2e, 0xb0, 0xcb, 0x9f, 0xc5, 0x46, 0xc6, 0x7f, 0xe1, 0x56, 0x2e, 0xe4,
+	0xc4, 0x5d, 0x13, 0x43, 0x71, 0xd7, 0x3f, 0x17, 0x60, 0x2d, 0x3a, 0xb3, 0x6d, 0x8d, 0x68, 0x3b,
+	0xae, 0xd6, 0xb5, 0xb0, 0x4d, 0x3e, 0x90, 0x87, 0x5d, 0x06, 0xe8, 0xb8, 0x5a, 0x57, 0x35, 0x6d,
+	0x03, 0xbf, 0xf3, 0x9f, 0x50, 0x68, 0xcf, 0x1e, 0xed, 0x40, 0x4b, 0xc0, 0x1a, 0xaa, 0x67, 0x7e,
+	0xef, 0x3f, 0xa2, 0xcc, 0xd0, 0x8e, 0xb6, 0xf9, 0x3d, 0x46, 0x2b, 0x00, 0x2e, 0x36, 0x06, 0xb6,
+	0xa1, 0x85, 0xe9, 0xf7, 0x48, 0x0f, 0xfa, 0x04, 0xae, 0x9f, 0xf4, 0x1c, 0xfd, 0x54, 0xd5, 0xf4,
+	0x53, 0xd5, 0xc0, 0x3d, 0xed, 0x42, 0x5c, 0x44, 0xcb, 0xac, 0xbb, 0xa1, 0x9f, 0x6e, 0xd3, 0x4e,
+	0xf4, 0x3b, 0xb0, 0xc8, 0x07, 0x11, 0xd6, 0x6d, 0x60, 0x4f, 0x77, 0xcd, 0x3e, 0x71, 0x5c, 0x66,
+	0x6c, 0x25, 0xe5, 0x26, 0x1b, 0x92, 0x43, 0xb7, 0x03, 0x20, 0x7d, 0xc6, 0x33, 0x34, 0xa2, 0xb1,
+	0x1b, 0x6a, 0x49, 0x61, 0xff, 0xe5, 0x3f, 0x2b, 0xc0, 0xdd, 0x1c, 0x15, 0xe6, 0xfb, 0xda, 0xd4,
+	0x20, 0x7a, 0x11, 0xa6, 0xed, 0x13, 0xfa, 0xc6, 0xd9, 0x15, 0xea, 0x99, 0xb2, 0x4f, 0x28, 0x47,
+	0x24, 0x43, 0x59, 0x00, 0x54, 0xe2, 0x10, 0xf1, 0xaa, 0x56, 0x56, 0xe6, 0x38, 0xf8, 0x88, 0x76,
+	0xd1, 0x27, 0xa8, 0xbe, 0x66, 0xd0, 0xd7, 0x24, 0xa1, 0x1f, 0xbf, 0x49, 0x33, 0x8c, 0xb1, 0x49,
+	0x87, 0xbe, 0x9d, 0xa7, 0x82, 0x16, 0x22, 0x73, 0x0e, 0xfc, 0xfb, 0x03, 0xa8, 0xd0, 0x59, 0xaa,
+	0x1d, 0x7f, 0x46, 0xb5, 0x69, 0x56, 0x4b, 0x51, 0x36, 0xa2, 0xd3, 0xa4, 0xc5, 0x44, 0x1b, 0x49,
+	0x17, 0xed, 0x43, 0xb1, 0x41, 0x55, 0xf2, 0x0d, 0x5d, 0x80, 0xab, 0x59, 0x54, 0xdc, 0x62, 0x0a,
+	0x49, 0x8b, 0xc9, 0x54, 0x57, 0xea, 0xab, 0xc6, 0x5d, 0x28, 0x45, 0xb6, 0x84, 0x5f, 0x8f, 0x32,
+	0x17, 0xee, 0x09, 0x4f, 0xfe, 0x9f, 0x02, 0xad, 0x1a, 0x8d, 0xed, 0xd9, 0x9d, 0x50, 0x35, 0xd9,
+	0x9b, 0xf0, 0xaa, 0x72, 0x3e, 0xf1, 0x7d, 0xf5, 0x24, 0xf3, 0xd5, 0xcb, 0x31, 0x5f, 0x1d, 0x19,
+	0x39, 0xdd, 0x63, 0x17, 0xa3, 0x1e, 0x7b, 0x1d, 0xaa, 0xbe, 0x85, 0x04, 0xd9, 0x60, 0xbe, 0x03,
+	0x2a, 0x7c, 0xb0, 0x20, 0x15, 0x7c, 0x17, 0x4a, 0x96, 0xe9, 0x79, 0xec, 0x39, 0x92, 0x8a, 0xc4,
+	0x5f, 0x66, 0xe6, 0x44, 0x1f, 0x93, 0x2b, 0xee, 0xc0, 0x67, 0xae, 0xee, 0xc0, 0x67, 0x2f, 0xe3,
+	0xc0, 0x8f, 0xe2, 0xbe, 0x36, 0x31, 0xff, 0xab, 0x7a, 0x70, 0x0d, 0x1e, 0x8c, 0xe0, 0x1a, 0x54,
+	0x4e, 0x27, 0x5c, 0xf8, 0x4a, 0x8a, 0x0b, 0x8f, 0x90, 0x07, 0x3e, 0x7c, 0xe3, 0x0e, 0xcc, 0x28,
+	0xdf, 0xfd, 0xcc, 0xb4, 0x0d, 0xe7, 0x1c, 0x4d, 0xc3, 0x84, 0xf2, 0xdd, 0x6f, 0x57, 0xaf, 0xf1,
+	0x3f, 0x5b, 0x55, 0x69, 0x63, 0xcb, 0xaf, 0xe9, 0x0c, 0x4b, 0xe2, 0x50, 0x05, 0x60, 0xbb, 0xf5,
+	0x5a, 0x6d, 0x1f, 0x35, 0x8e, 0x8e, 0xdb, 0xd5, 0x6b, 0xb4, 0xfd, 0x62, 0x6f, 0xff, 0xb9, 0xda,
+	0x7c, 0xd6, 0x6a, 0x3e, 0xaf, 0x4a, 0x1b, 0x3d, 0x58, 0x48, 0x49, 0x99, 0x23, 0x80, 0xa9, 0x76,
+	0xab, 0x79, 0xb0, 0xbf, 0x5d, 0xbd, 0x46, 0xff, 0xbf, 0xdc, 0xdb, 0x3f, 0x3e, 0x6a, 0x55, 0x25,
+	0x34, 0x03, 0x93, 0xcf, 0x0e, 0x8e, 0x95, 0x6a, 0x81, 0x8e, 0xba, 0xdd, 0xf8, 0x79, 0x75, 0x82,
+	0x76, 0xfd, 0xac, 0xd5, 0x7a, 0x5e, 0x9d, 0x44, 0xb3, 0x50, 0x7c, 0x79, 0xb0, 0x7f, 0xf4, 0xac,
+	0x5a, 0x44, 0x73, 0x30, 0xfd, 0xea, 0xb8, 0xa1, 0x1c, 0xb5, 0x94, 0xea, 0x14, 0xc5, 0xf8, 0x79,
+	0xab, 0xa1, 0x54, 0xa7, 0x37, 0x36, 0x23, 0x8f, 0x02, 0xc1, 0xcb, 0x1e, 0x45, 0x6e, 0xbe, 0x68,
+	0xb4, 0xdb, 0x6a, 0xb3, 0x7a, 0x2d, 0x6c, 0x7c, 0x53, 0x95, 0x36, 0x7e, 0x0a, 0x8b, 0x19, 0x37,
+	0x37, 0x8a, 0x77, 0xd8, 0xda, 0xdf, 0xde, 0xdb, 0xdf, 0xe5, 0x44, 0xed, 0xe3, 0x66, 0xb3, 0xd5,
+	0x6e, 0x57, 0x25, 0x2a, 0xef, 0x4e, 0x63, 0xef, 0x45, 0x6b, 0xbb, 0x5a, 0xd8, 0x78, 0x03, 0x0b,
+	0x29, 0x21, 0x09, 0x9a, 0x87, 0x72, 0xbb, 0x75, 0x74, 0x7c, 0xa8, 0x86, 0x2c, 0x16, 0x61, 0x81,
+	0x77, 0xed, 0x2a, 0x07, 0xc7, 0x87, 0x6a, 0x53, 0x69, 0x35, 0x8e, 0x5a, 0xdb, 0x55, 0x09, 0x2d,
+	0xc0, 0x75, 0x0e, 0x68, 0x1e, 0xbc, 0x3c, 0x7c, 0xd1, 0xa2, 0x9d, 0x05, 0x54, 0x85, 0x12, 0xef,
+	0x14, 0x23, 0x4d, 0x6c, 0xfc, 0x4a, 0x82, 0xdb, 0x99, 0x3b, 0x0a, 0xd5, 0xe0, 0xc6, 0x8e, 0xd2,
+	0xd8, 0x55, 0xdb, 0xad, 0x76, 0x7b, 0xef, 0x60, 0x3f, 0x32, 0x6e, 0x12, 0x12, 0x0e, 0xbc, 0x08,
+	0x0b, 0x31, 0x88, 0x3f, 0x29, 0xb4, 0x04, 0x8b, 0x31, 0xc0, 0xde, 0xbe, 0x2f, 0x5a, 0x75, 0x02,
+	0xd5, 0xe1, 0x56, 0x9c, 0x5f, 0x20, 0xf5, 0xe4, 0xd6, 0x9f, 0x7f, 0x06, 0x37, 0xf6, 0x31, 0x39,
+	0x77, 0xdc, 0x53, 0xfa, 0x19, 0x0b, 0x76, 0xc5, 0xc7, 0x2c, 0xe8, 0x97, 0x7e, 0x0d, 0x54, 0xfc,
+	0xeb, 0x16, 0xb4, 0x4a, 0x0d, 0x33, 0xe7, 0xe3, 0xa6, 0xfa, 0x5a, 0x36, 0x02, 0x37, 0x76, 0xf9,
+	0x1a, 0x52, 0x58, 0x85, 0x54, 0x82, 0xf3, 0x1d, 0x4a, 0x98, 0xf5, 0xa9, 0x52, 0x7d, 0x39, 0x03,
+	0x1a, 0xf0, 0x7c, 0xe5, 0x17, 0xeb, 0xa4, 0x09, 0x9c, 0xf3, 0x11, 0x50, 0xfd, 0xd6, 0x90, 0x87,
+	0x68, 0xd1, 0x8f, 0xc0, 0x38, 0xcb, 0xb4, 0x2f, 0x7c, 0x38, 0xcb, 0x9c, 0x6f, 0x7f, 0x72, 0x58,
+	0x06, 0x6a, 0x8d, 0x7f, 0x20, 0x12, 0x55, 0x6b, 0xea, 0xa7, 0x23, 0xf5, 0xb5, 0x6c, 0x84, 0x84,
+	0x5a, 0x13, 0x9c, 0x7d, 0xb5, 0xa6, 0xb3, 0x5d, 0xce, 0x80, 0x0e, 0xab, 0x35, 0x4d, 0xe0, 0x9c,
+	0xef, 0x68, 0xc6, 0x51, 0x6b, 0x1a, 0xcb, 0x9c, 0xcf, 0x67, 0x72, 0x58, 0x7e, 0x17, 0xff, 0x7e,
+	0xc0, 0xe7, 0xb8, 0x12, 0x2a, 0x2d, 0xed, 0x53, 0x8c, 0xfa, 0x6a, 0x26, 0x3c, 0x98, 0xff, 0x41,
+	0xe4, 0xf3, 0x02, 0x9f, 0xed, 0x92, 0x50, 0x5a, 0x2a, 0xcf, 0x3b, 0xe9, 0xc0, 0x08, 0xc3, 0x85,
+	0x94, 0x8f, 0x4e, 0xb8, 0xa8, 0xd9, 0x5f, 0xa3, 0xe4, 0xcc, 0xfd, 0x20, 0x5e, 0xe8, 0x1f, 0x63,
+	0x98, 0xfd, 0x19, 0x4a, 0x0e, 0xc3, 0x06, 0x94, 0xa2, 0x3a, 0x41, 0x8b, 0x49, 0x2d, 0x8d, 0x66,
+	0xf1, 0x15, 0xcc, 0x06, 0x2a, 0x40, 0x37, 0x62, 0x1a, 0xf1, 0x89, 0x6f, 0x26, 0x7a, 0x03, 0x05,
+	0x35, 0xa0, 0x14, 0xd5, 0x03, 0x1f, 0x3e, 0xe5, 0x2b, 0x88, 0xfc, 0x19, 0x44, 0x67, 0xce, 0x59,
+	0xa4, 0x7c, 0x0d, 0x91, 0xc3, 0xa2, 0x05, 0x95, 0x78, 0x45, 0x3f, 0xba, 0xcd, 0xb2, 0xe0, 0x69,
+	0x75, 0xf8, 0x39, 0x6c, 0xf6, 0xe8, 0x01, 0x1c, 0x2f, 0xde, 0x47, 0xa2, 0x86, 0x5a, 0xbb, 0x24,
+	0xab, 0xef, 0x60, 0x21, 0xa5, 0x38, 0x9f, 0xaf, 0x73, 0x76, 0xb1, 0x7f, 0x7d, 0x35, 0x13, 0x1e,
+	0x68, 0x5c, 0x83, 0x5b, 0x01, 0x42, 0xac, 0xaa, 0x1b, 0xdd, 0x8d, 0x11, 0xa7, 0x95, 0xb8, 0xd7,
+	0xe5, 0x3c, 0x94, 0x60, 0x88, 0x36, 0xdc, 0x4c, 0x2d, 0xf4, 0x42, 0x6b, 0x49, 0xe3, 0x4a, 0xbe,
+	0xc0, 0xe7, 0x3a, 0xd3, 0xdb, 0x99, 0x45, 0x5f, 0xe8, 0x3e, 0x65, 0x3c, 0xaa, 0x26, 0x2c, 0x87,
+	0xb9, 0x07, 0x77, 0xf2, 0x8a, 0xba, 0xd0, 0xa7, 0xb1, 0x79, 0x67, 0x97, 0x8d, 0xd5, 0xd7, 0x47,
+	0x23, 0x06, 0x6a, 0xe2, 0x83, 0x66, 0x96, 0x6d, 0x05, 0x83, 0x8e, 0x2a, 0x0c, 0xab, 0xaf, 0x8f,
+	0x46, 0x0c, 0x06, 0xfd, 0x16, 0xaa, 0xc9, 0x72, 0x7d, 0x94, 0xa1, 0x97, 0xc0, 0xbb, 0xa5, 0x16,
+	0xf7, 0xf3, 0x25, 0xc9, 0xac, 0xe1, 0xe7, 0x4b, 0x32, 0xaa, 0xc4, 0x3f, 0x67, 0x49, 0x8e, 0xe1,
+	0x56, 0x7a, 0xd1, 0x3e, 0xb7, 0xd3, 0xdc, 0x82, 0xfe, 0x1c, 0xb6, 0x4d, 0x28, 0xc7, 0x8a, 0x13,
+	0x50, 0x2d, 0x94, 0x33, 0x5e, 0x79, 0x95, 0xc3, 0xe4, 0x27, 0x00, 0x61, 0x11, 0x02, 0xf2, 0x9d,
+	0xdb, 0x10, 0x79, 0xa2, 0x3b, 0xd0, 0x5b, 0x13, 0xca, 0xb1, 0x9c, 0x3f, 0x97, 0x21, 0xad, 0xfa,
+	0x38, 0x7f, 0x22, 0xb1, 0xe4, 0x3e, 0x67, 0x92, 0x56, 0x83, 0x3c, 0x4e, 0x84, 0x92, 0x28, 0xe8,
+	0x59, 0x1d, 0x52, 0x4a, 0x76, 0x84, 0x92, 0x5e, 0x8b, 0x11, 0x44, 0x28, 0x09, 0xce, 0x77, 0xe2,
+	0x5a, 0xc9, 0x88, 0x50, 0x32, 0x79, 0xbe, 0x4a, 0x54, 0x69, 0xa7, 0x44, 0x28, 0xe9, 0x9c, 0xc7,
+	0x88, 0x50, 0xd2, 0x58, 0xe6, 0xd4, 0x4f, 0xe4, 0xb0, 0x7c, 0x01, 0xd7, 0x13, 0x15, 0xbe, 0xa8,
+	0x1e, 0x9f, 0x59, 0xb4, 0xd4, 0xb9, 0xbe, 0x94, 0x0a, 0x0b, 0xe6, 0x7c, 0x0c, 0x68, 0xb8, 0x44,
+	0x11, 0x2d, 0xe7, 0x56, 0x4d, 0xd6, 0x57, 0xb2, 0xc0, 0x01, 0xdb, 0x1e, 0xdc, 0xce, 0x2c, 0x1c,
+	0xe4, 0xbb, 0x77, 0x54, 0x6d, 0x62, 0xfd, 0xc1, 0x08, 0x2c, 0x7f, 0xac, 0xdf, 0x92, 0x90, 0x09,
+	0xb5, 0xac, 0xfa, 0x3d, 0x74, 0x2f, 0x9d, 0x4d, 0xfc, 0xac, 0xbc, 0x9f, 0x8f, 0x14, 0x19, 0x2a,
+	0x30, 0xea, 0x44, 0x31, 0x4b, 0xc4, 0xa8, 0x53, 0xb3, 0xa4, 0xf5, 0xb5, 0x6c, 0x84, 0x84, 0x51,
+	0x27, 0x38, 0xfb, 0x46, 0x9d, 0xce, 0x76, 0x39, 0x03, 0x3a, 0x6c, 0xd4, 0x69, 0x02, 0xe7, 0x14,
+	0x2b, 0x8c, 0x63, 0xd4, 0x69, 0x2c, 0x73, 0x6a, 0x14, 0xf2, 0x0f, 0xe0, 0xcc, 0x6a, 0x05, 0x6e,
+	0x2f, 0xa3, 0x8a, 0x19, 0x72, 0x98, 0x63, 0x58, 0xc9, 0xaf, 0x4f, 0x40, 0x9f, 0xd1, 0x11, 0xc6,
+	0xaa, 0x61, 0xc8, 0x9f, 0x43, 0x66, 0x11, 0x00, 0x9f, 0xc3, 0xa8, 0x1a, 0x81, 0x1c, 0xe6, 0x6f,
+	0xe1, 0xfe, 0x38, 0x39, 0x7f, 0xf4, 0x38, 0x08, 0x56, 0xc6, 0xab, 0x0e, 0xc8, 0x19, 0xf2, 0xaf,
+	0x25, 0xf8, 0x74, 0xcc, 0x54, 0x3d, 0xda, 0x4a, 0x9a, 0xe1, 0xe8, 0xba, 0x81, 0xfa, 0x93, 0x4b,
+	0xd1, 0x04, 0x06, 0xfd, 0x17, 0x12, 0xdc, 0x4b, 0xa5, 0x8a, 0x67, 0x94, 0xd1, 0x66, 0x26, 0xfb,
+	0xd4, 0x64, 0x77, 0xfd, 0xf1, 0xd8, 0xf8, 0x81, 0x28, 0x17, 0xb0, 0x12, 0x25, 0x18, 0xce, 0x55,
+	0x72, 0xc3, 0x1a, 0x2b, 0x11, 0x5c, 0xdf, 0x18, 0x07, 0x35, 0x18, 0xfa, 0xf7, 0xa1, 0x9e, 0x9d,
+	0x53, 0x44, 0x0f, 0xd2, 0xac, 0x6d, 0x28, 0xe7, 0x38, 0x32, 0x66, 0xcd, 0xcc, 0x18, 0x05, 0xe1,
+	0xe3, 0xa8, 0x44, 0x55, 0x7d, 0x7d, 0x34, 0x62, 0xe4, 0xf6, 0x70, 0x27, 0x2f, 0x8b, 0xc4, 0x07,
+	0x1d, 0x23, 0xcf, 0x94, 0x33, 0xaf, 0x3f, 0x8a, 0x17, 0x9c, 0xc5, 0x92, 0x1d, 0x7c, 0x8f, 0x8e,
+	0x4a, 0x27, 0xd5, 0x1f, 0x8c, 0xc0, 0x0a, 0xa6, 0xe3, 0xc0, 0xbd, 0x31, 0x72, 0x0a, 0xdc, 0x4e,
+	0xc7, 0x4f, 0x3e, 0xe4, 0x4c, 0xee, 0x8c, 0x7d, 0x44, 0x9c, 0xfd, 0x48, 0x8c, 0x86, 0x16, 0x23,
+	0xeb, 0x75, 0xba, 0xfe, 0xd9, 0x18, 0x98, 0xc1, 0x44, 0x9f, 0x02, 0x84, 0x55, 0xe9, 0x99, 0x01,
+	0xbf, 0x1f, 0xb2, 0x26, 0xaa, 0xd7, 0x83, 0x6b, 0x43, 0xac, 0x92, 0x7c, 0xe4, 0xb5, 0x21, 0xb5,
+	0xee, 0x5c, 0xbe, 0x76, 0x32, 0xc5, 0xf0, 0x9f, 0xfc, 0xdf, 0x00, 0x32, 0xef, 0xdd, 0x5c, 0xdf,
+	0x49, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4265,6 +6124,8 @@ type NetworkServerServiceClient interface {
 	DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetDeviceActivation returns the device activation details.
 	GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error)
+	// GetDeviceStatusHistory returns the device-status (DevStatusAns and LinkCheckReq) history for the given DevEUI.
+	GetDeviceStatusHistory(ctx context.Context, in *GetDeviceStatusHistoryRequest, opts ...grpc.CallOption) (*GetDeviceStatusHistoryResponse, error)
 	// CreateDeviceQueueItem creates the given device-queue item.
 	CreateDeviceQueueItem(ctx context.Context, in *CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
@@ -4299,6 +6160,10 @@ type NetworkServerServiceClient interface {
 	// GetGatewayStats returns stats of an existing gateway.
 	// Deprecated (stats are forwarded to Application Server API).
 	GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error)
+	// ExecGatewayCommand executes the given command on the gateway and
+	// returns the command output. The command must be pre-configured in the
+	// gateway (e.g. ChirpStack Gateway Bridge) configuration.
+	ExecGatewayCommand(ctx context.Context, in *ExecGatewayCommandRequest, opts ...grpc.CallOption) (*ExecGatewayCommandResponse, error)
 	// StreamFrameLogsForGateway returns a stream of frames seen by the given gateway.
 	StreamFrameLogsForGateway(ctx context.Context, in *StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForGatewayClient, error)
 	// StreamFrameLogsForDevice returns a stream of frames seen by the given device.
@@ -4322,8 +6187,26 @@ type NetworkServerServiceClient interface {
 	FlushMulticastQueueForMulticastGroup(ctx context.Context, in *FlushMulticastQueueForMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetMulticastQueueItemsForMulticastGroup returns the queue-items given a multicast-group id.
 	GetMulticastQueueItemsForMulticastGroup(ctx context.Context, in *GetMulticastQueueItemsForMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastQueueItemsForMulticastGroupResponse, error)
+	// GetMulticastQueueItemDeliveryReport returns the delivery report (per gateway) of the multicast queue-item given a multicast-group id and frame-counter.
+	GetMulticastQueueItemDeliveryReport(ctx context.Context, in *GetMulticastQueueItemDeliveryReportRequest, opts ...grpc.CallOption) (*GetMulticastQueueItemDeliveryReportResponse, error)
+	// GetMulticastGroupSetupCommands returns the (unencrypted) Remote Multicast Setup commands for setting up the given multicast-group on a device.
+	GetMulticastGroupSetupCommands(ctx context.Context, in *GetMulticastGroupSetupCommandsRequest, opts ...grpc.CallOption) (*GetMulticastGroupSetupCommandsResponse, error)
+	// EnqueueMulticastGroupSetup enqueues the (encrypted) Remote Multicast Setup commands for setting up the given multicast-group on the member devices and tracks the setup state of each device.
+	EnqueueMulticastGroupSetup(ctx context.Context, in *EnqueueMulticastGroupSetupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetMulticastGroupSetupStatus returns the Remote Multicast Setup state of each device of the given multicast-group.
+	GetMulticastGroupSetupStatus(ctx context.Context, in *GetMulticastGroupSetupStatusRequest, opts ...grpc.CallOption) (*GetMulticastGroupSetupStatusResponse, error)
+	// HandleApplicationLayerUplink handles the (decrypted) uplink payload of an application layer package (e.g. the Remote Multicast Setup answers).
+	HandleApplicationLayerUplink(ctx context.Context, in *HandleApplicationLayerUplinkRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetMulticastDataFragments fragments the given data block (e.g. a firmware image), adds the forward error correction fragments and returns the (unencrypted) Fragmented Data Block Transport commands.
+	GetMulticastDataFragments(ctx context.Context, in *GetMulticastDataFragmentsRequest, opts ...grpc.CallOption) (*GetMulticastDataFragmentsResponse, error)
+	// EnqueueMulticastFragmentedDataBlock enqueues the (encrypted) fragments for the given multicast-group and tracks the fragmentation session status of each device.
+	EnqueueMulticastFragmentedDataBlock(ctx context.Context, in *EnqueueMulticastFragmentedDataBlockRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetMulticastFragSessionStatus returns the fragmentation session status of each device of the given multicast-group.
+	GetMulticastFragSessionStatus(ctx context.Context, in *GetMulticastFragSessionStatusRequest, opts ...grpc.CallOption) (*GetMulticastFragSessionStatusResponse, error)
 	// GetVersion returns the ChirpStack Network Server version.
 	GetVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	// GetADRAlgorithms returns the available ADR algorithms.
+	GetADRAlgorithms(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetADRAlgorithmsResponse, error)
 }
 
 type networkServerServiceClient struct {
@@ -4505,6 +6388,15 @@ func (c *networkServerServiceClient) GetDeviceActivation(ctx context.Context, in
 	return out, nil
 }
 
+func (c *networkServerServiceClient) GetDeviceStatusHistory(ctx context.Context, in *GetDeviceStatusHistoryRequest, opts ...grpc.CallOption) (*GetDeviceStatusHistoryResponse, error) {
+	out := new(GetDeviceStatusHistoryResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceStatusHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkServerServiceClient) CreateDeviceQueueItem(ctx context.Context, in *CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateDeviceQueueItem", in, out, opts...)
@@ -4649,6 +6541,15 @@ func (c *networkServerServiceClient) GetGatewayStats(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *networkServerServiceClient) ExecGatewayCommand(ctx context.Context, in *ExecGatewayCommandRequest, opts ...grpc.CallOption) (*ExecGatewayCommandResponse, error) {
+	out := new(ExecGatewayCommandResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ExecGatewayCommand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkServerServiceClient) StreamFrameLogsForGateway(ctx context.Context, in *StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForGatewayClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_NetworkServerService_serviceDesc.Streams[0], "/ns.NetworkServerService/StreamFrameLogsForGateway", opts...)
 	if err != nil {
@@ -4794,6 +6695,78 @@ func (c *networkServerServiceClient) GetMulticastQueueItemsForMulticastGroup(ctx
 	return out, nil
 }
 
+func (c *networkServerServiceClient) GetMulticastQueueItemDeliveryReport(ctx context.Context, in *GetMulticastQueueItemDeliveryReportRequest, opts ...grpc.CallOption) (*GetMulticastQueueItemDeliveryReportResponse, error) {
+	out := new(GetMulticastQueueItemDeliveryReportResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastQueueItemDeliveryReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetMulticastGroupSetupCommands(ctx context.Context, in *GetMulticastGroupSetupCommandsRequest, opts ...grpc.CallOption) (*GetMulticastGroupSetupCommandsResponse, error) {
+	out := new(GetMulticastGroupSetupCommandsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastGroupSetupCommands", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) EnqueueMulticastGroupSetup(ctx context.Context, in *EnqueueMulticastGroupSetupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/EnqueueMulticastGroupSetup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetMulticastGroupSetupStatus(ctx context.Context, in *GetMulticastGroupSetupStatusRequest, opts ...grpc.CallOption) (*GetMulticastGroupSetupStatusResponse, error) {
+	out := new(GetMulticastGroupSetupStatusResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastGroupSetupStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) HandleApplicationLayerUplink(ctx context.Context, in *HandleApplicationLayerUplinkRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/HandleApplicationLayerUplink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetMulticastDataFragments(ctx context.Context, in *GetMulticastDataFragmentsRequest, opts ...grpc.CallOption) (*GetMulticastDataFragmentsResponse, error) {
+	out := new(GetMulticastDataFragmentsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastDataFragments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) EnqueueMulticastFragmentedDataBlock(ctx context.Context, in *EnqueueMulticastFragmentedDataBlockRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/EnqueueMulticastFragmentedDataBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetMulticastFragSessionStatus(ctx context.Context, in *GetMulticastFragSessionStatusRequest, opts ...grpc.CallOption) (*GetMulticastFragSessionStatusResponse, error) {
+	out := new(GetMulticastFragSessionStatusResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastFragSessionStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *networkServerServiceClient) GetVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetVersionResponse, error) {
 	out := new(GetVersionResponse)
 	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetVersion", in, out, opts...)
@@ -4803,6 +6776,15 @@ func (c *networkServerServiceClient) GetVersion(ctx context.Context, in *empty.E
 	return out, nil
 }
 
+func (c *networkServerServiceClient) GetADRAlgorithms(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetADRAlgorithmsResponse, error) {
+	out := new(GetADRAlgorithmsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetADRAlgorithms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkServerServiceServer is the server API for NetworkServerService service.
 type NetworkServerServiceServer interface {
 	// CreateServiceProfile creates the given service-profile.
@@ -4843,6 +6825,8 @@ type NetworkServerServiceServer interface {
 	DeactivateDevice(context.Context, *DeactivateDeviceRequest) (*empty.Empty, error)
 	// GetDeviceActivation returns the device activation details.
 	GetDeviceActivation(context.Context, *GetDeviceActivationRequest) (*GetDeviceActivationResponse, error)
+	// GetDeviceStatusHistory returns the device-status (DevStatusAns and LinkCheckReq) history for the given DevEUI.
+	GetDeviceStatusHistory(context.Context, *GetDeviceStatusHistoryRequest) (*GetDeviceStatusHistoryResponse, error)
 	// CreateDeviceQueueItem creates the given device-queue item.
 	CreateDeviceQueueItem(context.Context, *CreateDeviceQueueItemRequest) (*empty.Empty, error)
 	// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
@@ -4877,6 +6861,10 @@ type NetworkServerServiceServer interface {
 	// GetGatewayStats returns stats of an existing gateway.
 	// Deprecated (stats are forwarded to Application Server API).
 	GetGatewayStats(context.Context, *GetGatewayStatsRequest) (*GetGatewayStatsResponse, error)
+	// ExecGatewayCommand executes the given command on the gateway and
+	// returns the command output. The command must be pre-configured in the
+	// gateway (e.g. ChirpStack Gateway Bridge) configuration.
+	ExecGatewayCommand(context.Context, *ExecGatewayCommandRequest) (*ExecGatewayCommandResponse, error)
 	// StreamFrameLogsForGateway returns a stream of frames seen by the given gateway.
 	StreamFrameLogsForGateway(*StreamFrameLogsForGatewayRequest, NetworkServerService_StreamFrameLogsForGatewayServer) error
 	// StreamFrameLogsForDevice returns a stream of frames seen by the given device.
@@ -4900,8 +6888,26 @@ type NetworkServerServiceServer interface {
 	FlushMulticastQueueForMulticastGroup(context.Context, *FlushMulticastQueueForMulticastGroupRequest) (*empty.Empty, error)
 	// GetMulticastQueueItemsForMulticastGroup returns the queue-items given a multicast-group id.
 	GetMulticastQueueItemsForMulticastGroup(context.Context, *GetMulticastQueueItemsForMulticastGroupRequest) (*GetMulticastQueueItemsForMulticastGroupResponse, error)
+	// GetMulticastQueueItemDeliveryReport returns the delivery report (per gateway) of the multicast queue-item given a multicast-group id and frame-counter.
+	GetMulticastQueueItemDeliveryReport(context.Context, *GetMulticastQueueItemDeliveryReportRequest) (*GetMulticastQueueItemDeliveryReportResponse, error)
+	// GetMulticastGroupSetupCommands returns the (unencrypted) Remote Multicast Setup commands for setting up the given multicast-group on a device.
+	GetMulticastGroupSetupCommands(context.Context, *GetMulticastGroupSetupCommandsRequest) (*GetMulticastGroupSetupCommandsResponse, error)
+	// EnqueueMulticastGroupSetup enqueues the (encrypted) Remote Multicast Setup commands for setting up the given multicast-group on the member devices and tracks the setup state of each device.
+	EnqueueMulticastGroupSetup(context.Context, *EnqueueMulticastGroupSetupRequest) (*empty.Empty, error)
+	// GetMulticastGroupSetupStatus returns the Remote Multicast Setup state of each device of the given multicast-group.
+	GetMulticastGroupSetupStatus(context.Context, *GetMulticastGroupSetupStatusRequest) (*GetMulticastGroupSetupStatusResponse, error)
+	// HandleApplicationLayerUplink handles the (decrypted) uplink payload of an application layer package (e.g. the Remote Multicast Setup answers).
+	HandleApplicationLayerUplink(context.Context, *HandleApplicationLayerUplinkRequest) (*empty.Empty, error)
+	// GetMulticastDataFragments fragments the given data block (e.g. a firmware image), adds the forward error correction fragments and returns the (unencrypted) Fragmented Data Block Transport commands.
+	GetMulticastDataFragments(context.Context, *GetMulticastDataFragmentsRequest) (*GetMulticastDataFragmentsResponse, error)
+	// EnqueueMulticastFragmentedDataBlock enqueues the (encrypted) fragments for the given multicast-group and tracks the fragmentation session status of each device.
+	EnqueueMulticastFragmentedDataBlock(context.Context, *EnqueueMulticastFragmentedDataBlockRequest) (*empty.Empty, error)
+	// GetMulticastFragSessionStatus returns the fragmentation session status of each device of the given multicast-group.
+	GetMulticastFragSessionStatus(context.Context, *GetMulticastFragSessionStatusRequest) (*GetMulticastFragSessionStatusResponse, error)
 	// GetVersion returns the ChirpStack Network Server version.
 	GetVersion(context.Context, *empty.Empty) (*GetVersionResponse, error)
+	// GetADRAlgorithms returns the available ADR algorithms.
+	GetADRAlgorithms(context.Context, *empty.Empty) (*GetADRAlgorithmsResponse, error)
 }
 
 func RegisterNetworkServerServiceServer(s *grpc.Server, srv NetworkServerServiceServer) {
@@ -5250,6 +7256,24 @@ func _NetworkServerService_GetDeviceActivation_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_GetDeviceStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceStatusHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetDeviceStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetDeviceStatusHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetDeviceStatusHistory(ctx, req.(*GetDeviceStatusHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServerService_CreateDeviceQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateDeviceQueueItemRequest)
 	if err := dec(in); err != nil {
@@ -5538,6 +7562,24 @@ func _NetworkServerService_GetGatewayStats_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_ExecGatewayCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecGatewayCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).ExecGatewayCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/ExecGatewayCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).ExecGatewayCommand(ctx, req.(*ExecGatewayCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServerService_StreamFrameLogsForGateway_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(StreamFrameLogsForGatewayRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -5742,6 +7784,150 @@ func _NetworkServerService_GetMulticastQueueItemsForMulticastGroup_Handler(srv i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_GetMulticastQueueItemDeliveryReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastQueueItemDeliveryReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetMulticastQueueItemDeliveryReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetMulticastQueueItemDeliveryReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetMulticastQueueItemDeliveryReport(ctx, req.(*GetMulticastQueueItemDeliveryReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetMulticastGroupSetupCommands_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastGroupSetupCommandsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetMulticastGroupSetupCommands(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetMulticastGroupSetupCommands",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetMulticastGroupSetupCommands(ctx, req.(*GetMulticastGroupSetupCommandsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_EnqueueMulticastGroupSetup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueMulticastGroupSetupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).EnqueueMulticastGroupSetup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/EnqueueMulticastGroupSetup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).EnqueueMulticastGroupSetup(ctx, req.(*EnqueueMulticastGroupSetupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetMulticastGroupSetupStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastGroupSetupStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetMulticastGroupSetupStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetMulticastGroupSetupStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetMulticastGroupSetupStatus(ctx, req.(*GetMulticastGroupSetupStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_HandleApplicationLayerUplink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleApplicationLayerUplinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).HandleApplicationLayerUplink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/HandleApplicationLayerUplink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).HandleApplicationLayerUplink(ctx, req.(*HandleApplicationLayerUplinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetMulticastDataFragments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastDataFragmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetMulticastDataFragments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetMulticastDataFragments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetMulticastDataFragments(ctx, req.(*GetMulticastDataFragmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_EnqueueMulticastFragmentedDataBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueMulticastFragmentedDataBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).EnqueueMulticastFragmentedDataBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/EnqueueMulticastFragmentedDataBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).EnqueueMulticastFragmentedDataBlock(ctx, req.(*EnqueueMulticastFragmentedDataBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetMulticastFragSessionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastFragSessionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetMulticastFragSessionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetMulticastFragSessionStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetMulticastFragSessionStatus(ctx, req.(*GetMulticastFragSessionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NetworkServerService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -5760,6 +7946,24 @@ func _NetworkServerService_GetVersion_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerService_GetADRAlgorithms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetADRAlgorithms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetADRAlgorithms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetADRAlgorithms(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "ns.NetworkServerService",
 	HandlerType: (*NetworkServerServiceServer)(nil),
@@ -5840,6 +8044,10 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDeviceActivation",
 			Handler:    _NetworkServerService_GetDeviceActivation_Handler,
 		},
+		{
+			MethodName: "GetDeviceStatusHistory",
+			Handler:    _NetworkServerService_GetDeviceStatusHistory_Handler,
+		},
 		{
 			MethodName: "CreateDeviceQueueItem",
 			Handler:    _NetworkServerService_CreateDeviceQueueItem_Handler,
@@ -5904,6 +8112,10 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetGatewayStats",
 			Handler:    _NetworkServerService_GetGatewayStats_Handler,
 		},
+		{
+			MethodName: "ExecGatewayCommand",
+			Handler:    _NetworkServerService_ExecGatewayCommand_Handler,
+		},
 		{
 			MethodName: "CreateMulticastGroup",
 			Handler:    _NetworkServerService_CreateMulticastGroup_Handler,
@@ -5940,10 +8152,46 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetMulticastQueueItemsForMulticastGroup",
 			Handler:    _NetworkServerService_GetMulticastQueueItemsForMulticastGroup_Handler,
 		},
+		{
+			MethodName: "GetMulticastQueueItemDeliveryReport",
+			Handler:    _NetworkServerService_GetMulticastQueueItemDeliveryReport_Handler,
+		},
+		{
+			MethodName: "GetMulticastGroupSetupCommands",
+			Handler:    _NetworkServerService_GetMulticastGroupSetupCommands_Handler,
+		},
+		{
+			MethodName: "EnqueueMulticastGroupSetup",
+			Handler:    _NetworkServerService_EnqueueMulticastGroupSetup_Handler,
+		},
+		{
+			MethodName: "GetMulticastGroupSetupStatus",
+			Handler:    _NetworkServerService_GetMulticastGroupSetupStatus_Handler,
+		},
+		{
+			MethodName: "HandleApplicationLayerUplink",
+			Handler:    _NetworkServerService_HandleApplicationLayerUplink_Handler,
+		},
+		{
+			MethodName: "GetMulticastDataFragments",
+			Handler:    _NetworkServerService_GetMulticastDataFragments_Handler,
+		},
+		{
+			MethodName: "EnqueueMulticastFragmentedDataBlock",
+			Handler:    _NetworkServerService_EnqueueMulticastFragmentedDataBlock_Handler,
+		},
+		{
+			MethodName: "GetMulticastFragSessionStatus",
+			Handler:    _NetworkServerService_GetMulticastFragSessionStatus_Handler,
+		},
 		{
 			MethodName: "GetVersion",
 			Handler:    _NetworkServerService_GetVersion_Handler,
 		},
+		{
+			MethodName: "GetADRAlgorithms",
+			Handler:    _NetworkServerService_GetADRAlgorithms_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -87,7 +87,19 @@ type ServiceProfile struct {
 	// Target Packet Error Rate.
 	TargetPer uint32 `protobuf:"varint,19,opt,name=target_per,json=targetPer,proto3" json:"target_per,omitempty"`
 	// Minimum number of receiving GWs (informative).
-	MinGwDiversity       uint32   `protobuf:"varint,20,opt,name=min_gw_diversity,json=minGwDiversity,proto3" json:"min_gw_diversity,omitempty"`
+	MinGwDiversity uint32 `protobuf:"varint,20,opt,name=min_gw_diversity,json=minGwDiversity,proto3" json:"min_gw_diversity,omitempty"`
+	// Drop or mark when the number of receiving GWs is below MinGWDiversity.
+	MinGwDiversityPolicy RatePolicy `protobuf:"varint,21,opt,name=min_gw_diversity_policy,json=minGwDiversityPolicy,proto3,enum=ns.RatePolicy" json:"min_gw_diversity_policy,omitempty"`
+	// Token bucket filling rate for all devices of the service-profile,
+	// including ACKs (packet/h). ULRatePolicy applies when exceeded.
+	SpUlRate uint32 `protobuf:"varint,22,opt,name=sp_ul_rate,json=spUlRate,proto3" json:"sp_ul_rate,omitempty"`
+	// Token bucket burst size for all devices of the service-profile.
+	SpUlBucketSize uint32 `protobuf:"varint,23,opt,name=sp_ul_bucket_size,json=spUlBucketSize,proto3" json:"sp_ul_bucket_size,omitempty"`
+	// Token bucket filling rate for all devices of the service-profile,
+	// including ACKs (packet/h). DLRatePolicy applies when exceeded.
+	SpDlRate uint32 `protobuf:"varint,24,opt,name=sp_dl_rate,json=spDlRate,proto3" json:"sp_dl_rate,omitempty"`
+	// Token bucket burst size for all devices of the service-profile.
+	SpDlBucketSize       uint32   `protobuf:"varint,25,opt,name=sp_dl_bucket_size,json=spDlBucketSize,proto3" json:"sp_dl_bucket_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -258,6 +270,41 @@ func (m *ServiceProfile) GetMinGwDiversity() uint32 {
 	return 0
 }
 
+func (m *ServiceProfile) GetMinGwDiversityPolicy() RatePolicy {
+	if m != nil {
+		return m.MinGwDiversityPolicy
+	}
+	return RatePolicy_DROP
+}
+
+func (m *ServiceProfile) GetSpUlRate() uint32 {
+	if m != nil {
+		return m.SpUlRate
+	}
+	return 0
+}
+
+func (m *ServiceProfile) GetSpUlBucketSize() uint32 {
+	if m != nil {
+		return m.SpUlBucketSize
+	}
+	return 0
+}
+
+func (m *ServiceProfile) GetSpDlRate() uint32 {
+	if m != nil {
+		return m.SpDlRate
+	}
+	return 0
+}
+
+func (m *ServiceProfile) GetSpDlBucketSize() uint32 {
+	if m != nil {
+		return m.SpDlBucketSize
+	}
+	return 0
+}
+
 type DeviceProfile struct {
 	// Device-profile ID.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -306,7 +353,11 @@ type DeviceProfile struct {
 	// Geolocation minimum buffer size.
 	// When > 0, geolocation will only be performed when the buffer has
 	// at least the given size.
-	GeolocMinBufferSize  uint32   `protobuf:"varint,22,opt,name=geoloc_min_buffer_size,json=geolocMinBufferSize,proto3" json:"geoloc_min_buffer_size,omitempty"`
+	GeolocMinBufferSize uint32 `protobuf:"varint,22,opt,name=geoloc_min_buffer_size,json=geolocMinBufferSize,proto3" json:"geoloc_min_buffer_size,omitempty"`
+	// ADR algorithm ID.
+	// The ID of the ADR algorithm used for the devices using this
+	// device-profile. When left blank, the default ADR algorithm is used.
+	AdrAlgorithmId       string   `protobuf:"bytes,23,opt,name=adr_algorithm_id,json=adrAlgorithmId,proto3" json:"adr_algorithm_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -491,6 +542,13 @@ func (m *DeviceProfile) GetGeolocMinBufferSize() uint32 {
 	return 0
 }
 
+func (m *DeviceProfile) GetAdrAlgorithmId() string {
+	if m != nil {
+		return m.AdrAlgorithmId
+	}
+	return ""
+}
+
 type RoutingProfile struct {
 	// ID of the routing profile.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -580,66 +638,71 @@ func init() {
 func init() { proto.RegisterFile("profiles.proto", fileDescriptor_9610db3cccb08234) }
 
 var fileDescriptor_9610db3cccb08234 = []byte{
-	// 964 bytes of a gzipped FileDescriptorProto
+	// 1044 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0x5d, 0x6f, 0xdb, 0x36,
-	0x14, 0x9d, 0xd3, 0xc4, 0xb1, 0x19, 0x4b, 0x71, 0xe8, 0x24, 0x55, 0xf7, 0xe9, 0xa5, 0xc3, 0x60,
-	0x14, 0x58, 0xb6, 0x38, 0x03, 0x86, 0x3d, 0x36, 0xf6, 0x1a, 0x6c, 0x9d, 0x51, 0x83, 0x29, 0xf6,
-	0x4a, 0xd0, 0x22, 0xed, 0x70, 0x96, 0x44, 0xe5, 0x92, 0x8a, 0xed, 0x3e, 0xee, 0x17, 0xee, 0x87,
-	0xec, 0x47, 0x0c, 0xbc, 0x92, 0xed, 0x34, 0xdd, 0xfa, 0x66, 0x9f, 0x73, 0xae, 0x0e, 0xef, 0xe5,
-	0xb9, 0x12, 0x09, 0x73, 0x30, 0x53, 0x9d, 0x28, 0x7b, 0x9e, 0x83, 0x71, 0x86, 0xee, 0x64, 0xf6,
-	0xec, 0x9f, 0x3d, 0x12, 0xde, 0x28, 0xb8, 0xd7, 0xb1, 0x1a, 0x97, 0x2c, 0x0d, 0xc9, 0x8e, 0x96,
-	0x51, 0xad, 0x5b, 0xeb, 0xb5, 0xd8, 0x8e, 0x96, 0xf4, 0x29, 0xd9, 0x2f, 0x12, 0x0e, 0xc2, 0xa9,
-	0x68, 0xa7, 0x5b, 0xeb, 0x05, 0xac, 0x5e, 0x24, 0x4c, 0x38, 0x45, 0xbf, 0x21, 0x61, 0x91, 0xf0,
-	0x49, 0x11, 0xcf, 0x95, 0xe3, 0x56, 0xbf, 0x53, 0xd1, 0x13, 0xe4, 0x5b, 0x45, 0x72, 0x85, 0xe0,
-	0x8d, 0x7e, 0xa7, 0xe8, 0x8f, 0xa8, 0xf2, 0xe5, 0x3c, 0x37, 0x89, 0x8e, 0x57, 0xd1, 0x6e, 0xb7,
-	0xd6, 0x0b, 0xfb, 0xe1, 0x79, 0x66, 0xcf, 0xfd, 0x73, 0xc6, 0x88, 0xfa, 0xaa, 0xed, 0x3f, 0x6f,
-	0x2a, 0x2b, 0xd3, 0xbd, 0xd2, 0x54, 0x6e, 0x4c, 0xe5, 0xfb, 0xa6, 0xf5, 0xd2, 0x54, 0x3e, 0x32,
-	0x95, 0xef, 0x9b, 0xee, 0xff, 0xb7, 0xa9, 0x7c, 0x68, 0xfa, 0x2d, 0x39, 0x14, 0x52, 0xf2, 0xd9,
-	0x82, 0xa7, 0xca, 0x09, 0x29, 0x9c, 0x88, 0x1a, 0xdd, 0x5a, 0xaf, 0xc1, 0x02, 0x21, 0xe5, 0xf5,
-	0x62, 0x54, 0x81, 0xf4, 0x3b, 0xd2, 0x91, 0xea, 0x9e, 0x5b, 0x27, 0x5c, 0x61, 0x39, 0xa8, 0x3b,
-	0x3e, 0x05, 0x75, 0x17, 0x35, 0xf1, 0x20, 0x6d, 0xa9, 0xee, 0x6f, 0x90, 0x61, 0xea, 0xee, 0x15,
-	0xa8, 0x3b, 0xfa, 0x33, 0x79, 0x06, 0x2a, 0x37, 0xe0, 0xf8, 0x83, 0xaa, 0x89, 0x70, 0x4e, 0xc1,
-	0x2a, 0x22, 0x68, 0x70, 0x5a, 0x0a, 0x86, 0xeb, 0xd2, 0xab, 0x92, 0xa5, 0x3f, 0x91, 0xe8, 0xc3,
-	0xd2, 0x54, 0xc0, 0x4c, 0x67, 0xd1, 0x01, 0x56, 0x9e, 0x3c, 0xaa, 0x1c, 0x21, 0x49, 0x4f, 0x48,
-	0x5d, 0x02, 0x4f, 0x75, 0x16, 0xb5, 0xf0, 0x54, 0x7b, 0x12, 0x46, 0x5b, 0x58, 0x2c, 0xa3, 0x60,
-	0x03, 0x8b, 0x25, 0xfd, 0x9a, 0xb4, 0xe2, 0x5b, 0x91, 0x65, 0x2a, 0xe1, 0xa9, 0xb0, 0xf3, 0x28,
-	0xc4, 0xcb, 0x3f, 0xa8, 0xb0, 0x91, 0xb0, 0x73, 0xfa, 0x05, 0x21, 0x39, 0x70, 0x91, 0x24, 0x66,
-	0xa1, 0x64, 0x74, 0x88, 0xde, 0xcd, 0x1c, 0x5e, 0x96, 0x80, 0xa7, 0x6f, 0xb7, 0x74, 0xbb, 0xa4,
-	0x6f, 0x1f, 0xd2, 0x20, 0x36, 0xf4, 0x51, 0x49, 0x83, 0x58, 0xd3, 0x5f, 0x92, 0x83, 0x6c, 0x31,
-	0xe7, 0x33, 0x65, 0x78, 0x62, 0xe2, 0x88, 0x96, 0x7c, 0xb6, 0x98, 0x5f, 0x2b, 0xf3, 0xbb, 0x89,
-	0x7d, 0xb9, 0x13, 0x30, 0x53, 0x8e, 0xe7, 0x0a, 0xa2, 0x0e, 0x1e, 0xbd, 0x59, 0x22, 0x63, 0x05,
-	0xb4, 0x47, 0xda, 0xa9, 0xce, 0xfc, 0xbd, 0x49, 0x7d, 0xaf, 0xc0, 0x6a, 0xb7, 0x8a, 0x8e, 0x51,
-	0x14, 0xa6, 0x3a, 0xbb, 0x5e, 0x0c, 0xd7, 0xe8, 0xd9, 0xdf, 0x75, 0x12, 0x0c, 0xd5, 0xc7, 0xd2,
-	0xde, 0x23, 0x6d, 0x5b, 0xe4, 0x7e, 0xa4, 0x96, 0xc7, 0x89, 0xb0, 0x96, 0x4f, 0x30, 0xf6, 0x0d,
-	0x16, 0xae, 0xf1, 0x81, 0x87, 0xaf, 0x7c, 0x5a, 0x2a, 0x01, 0x77, 0x3a, 0x55, 0xa6, 0x70, 0x55,
-	0xfe, 0x03, 0x84, 0xaf, 0xde, 0x96, 0xa0, 0x7f, 0x62, 0xae, 0xb3, 0x19, 0xb7, 0x89, 0xc1, 0xf3,
-	0x6b, 0x23, 0x71, 0x05, 0x02, 0x16, 0x7a, 0xfc, 0x26, 0x31, 0xbe, 0x09, 0x6d, 0x24, 0xed, 0x92,
-	0xd6, 0x56, 0x29, 0xa1, 0x4a, 0x3e, 0x59, 0xab, 0x86, 0xe0, 0xd3, 0xbf, 0x55, 0x60, 0xe8, 0xaa,
-	0xf4, 0xaf, 0x35, 0x18, 0xb8, 0x0f, 0x7b, 0x88, 0x31, 0xff, 0x8f, 0x7b, 0x18, 0x6c, 0x7b, 0x88,
-	0x37, 0x3d, 0x34, 0x1e, 0xf4, 0x30, 0x58, 0xf7, 0xf0, 0x15, 0x39, 0x48, 0x45, 0xcc, 0x71, 0x8c,
-	0x26, 0xc3, 0xa4, 0x37, 0x19, 0x49, 0x45, 0xfc, 0x47, 0x89, 0xd0, 0x73, 0xd2, 0x01, 0x35, 0xe3,
-	0xb9, 0x00, 0x91, 0xfa, 0x95, 0xb8, 0xd7, 0x28, 0x24, 0x28, 0x3c, 0x02, 0x35, 0x1b, 0x23, 0xc3,
-	0x2a, 0x82, 0x7e, 0x4e, 0x08, 0x2c, 0xb9, 0x54, 0x89, 0x58, 0xf1, 0x0b, 0x8c, 0x72, 0xc0, 0x1a,
-	0xb0, 0x1c, 0x7a, 0xe0, 0x82, 0x3e, 0x27, 0xa1, 0x67, 0x81, 0x9b, 0xe9, 0xd4, 0x2a, 0xc7, 0x2f,
-	0xaa, 0x14, 0x1f, 0xc0, 0x72, 0x08, 0x6f, 0x10, 0xbb, 0xa0, 0x67, 0x24, 0xf0, 0x22, 0xe1, 0x04,
-	0xee, 0x79, 0xbf, 0x8a, 0xb4, 0xd7, 0x54, 0x58, 0x9f, 0x7e, 0x4a, 0x9a, 0xb0, 0xc4, 0x41, 0xf1,
-	0x3e, 0xa6, 0x3a, 0x60, 0xfb, 0xb0, 0xf4, 0x43, 0xea, 0xd3, 0x1f, 0xc8, 0xf1, 0x54, 0xc4, 0xce,
-	0xc0, 0x8a, 0xe7, 0xa0, 0xbc, 0x8d, 0xd7, 0xd9, 0xe8, 0xb0, 0xfb, 0xa4, 0x17, 0x30, 0x5a, 0x71,
-	0x63, 0xa4, 0x7c, 0x85, 0xa5, 0xcf, 0x48, 0x23, 0x15, 0x4b, 0xae, 0x34, 0xe4, 0x18, 0xf1, 0x80,
-	0xed, 0xa7, 0x62, 0xf9, 0x8b, 0x86, 0xdc, 0x5f, 0x8c, 0xa7, 0x64, 0xe1, 0x56, 0x3c, 0x5e, 0xc5,
-	0x89, 0xc2, 0x90, 0x07, 0xac, 0x95, 0x8a, 0xe5, 0xb0, 0x70, 0xab, 0x81, 0xc7, 0xe8, 0x73, 0x12,
-	0x6c, 0x2e, 0xe6, 0x4f, 0xa3, 0xb3, 0x2a, 0xe9, 0xad, 0x35, 0xf8, 0x9b, 0xd1, 0x19, 0xfd, 0x8c,
-	0x34, 0x61, 0xca, 0x41, 0xcd, 0xfc, 0x00, 0x3b, 0x38, 0xc0, 0x06, 0x4c, 0x19, 0xfe, 0xa7, 0xdf,
-	0x93, 0xe3, 0xcd, 0x13, 0x2e, 0xfb, 0x13, 0xed, 0xf8, 0x94, 0xc7, 0x99, 0xc3, 0xb8, 0x37, 0xd8,
-	0xd1, 0x9a, 0x43, 0xea, 0xd5, 0x20, 0x73, 0xf4, 0x05, 0x39, 0x9a, 0x29, 0x93, 0x98, 0x98, 0x4f,
-	0x8a, 0xe9, 0x54, 0x01, 0x77, 0x2e, 0x89, 0x4e, 0xf0, 0x6c, 0x87, 0x25, 0x71, 0x85, 0xf8, 0x5b,
-	0x97, 0xd0, 0x4b, 0x72, 0x5a, 0x69, 0xfd, 0x3a, 0x55, 0x7a, 0x7c, 0xc7, 0x9e, 0x62, 0x41, 0xa7,
-	0x64, 0x47, 0x3a, 0x2b, 0x6b, 0xfc, 0xab, 0xf6, 0xec, 0xaf, 0x1a, 0x09, 0x99, 0x29, 0x9c, 0xce,
-	0x66, 0xff, 0xb7, 0x53, 0x1d, 0xb2, 0x27, 0x2c, 0xd7, 0x12, 0x17, 0xa9, 0xc9, 0x76, 0x85, 0xfd,
-	0x15, 0x3f, 0x2b, 0xb1, 0xe0, 0xb1, 0x82, 0x72, 0x6d, 0x9a, 0xac, 0x1e, 0x8b, 0x81, 0x02, 0xe7,
-	0xa7, 0xec, 0x12, 0x5b, 0x32, 0xbb, 0xc8, 0xec, 0xbb, 0xc4, 0x22, 0xf5, 0x94, 0xf8, 0x9f, 0x7c,
-	0xae, 0x56, 0xb8, 0x1b, 0x4d, 0x56, 0x77, 0x89, 0x7d, 0xad, 0x56, 0x2f, 0xba, 0x84, 0x3c, 0x78,
-	0x8f, 0x37, 0xc8, 0xee, 0x90, 0xbd, 0x19, 0xb7, 0x3f, 0xf1, 0xbf, 0x46, 0x2f, 0xd9, 0xeb, 0x76,
-	0x6d, 0x52, 0xc7, 0x6f, 0xde, 0xe5, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xfa, 0x88, 0x3f, 0x1d,
-	0x05, 0x07, 0x00, 0x00,
+	0x14, 0x9d, 0xd3, 0xd4, 0x1f, 0x37, 0x96, 0xe2, 0xd0, 0x69, 0xa3, 0xee, 0xd3, 0x4b, 0x87, 0xc1,
+	0x2b, 0xb0, 0x6c, 0x71, 0x07, 0x0c, 0x7b, 0x6c, 0xec, 0xb6, 0xe8, 0x3a, 0xa3, 0x86, 0xd2, 0xed,
+	0x95, 0xa0, 0x45, 0xda, 0xe1, 0x2c, 0x89, 0x0a, 0x49, 0xc5, 0x76, 0x1f, 0xf7, 0xdf, 0xf6, 0xa7,
+	0xf6, 0x34, 0xf0, 0x4a, 0xb2, 0x9d, 0x34, 0xdb, 0x9b, 0x7d, 0xce, 0xb9, 0xf7, 0x88, 0xbc, 0xf7,
+	0x48, 0xe0, 0x67, 0x5a, 0xcd, 0x64, 0x2c, 0xcc, 0x59, 0xa6, 0x95, 0x55, 0x64, 0x2f, 0x35, 0xa7,
+	0x7f, 0x37, 0xc0, 0xbf, 0x14, 0xfa, 0x46, 0x46, 0x62, 0x52, 0xb0, 0xc4, 0x87, 0x3d, 0xc9, 0x83,
+	0x5a, 0xaf, 0xd6, 0x6f, 0x87, 0x7b, 0x92, 0x93, 0x13, 0x68, 0xe4, 0x31, 0xd5, 0xcc, 0x8a, 0x60,
+	0xaf, 0x57, 0xeb, 0x7b, 0x61, 0x3d, 0x8f, 0x43, 0x66, 0x05, 0xf9, 0x06, 0xfc, 0x3c, 0xa6, 0xd3,
+	0x3c, 0x5a, 0x08, 0x4b, 0x8d, 0xfc, 0x20, 0x82, 0x07, 0xc8, 0xb7, 0xf3, 0xf8, 0x02, 0xc1, 0x4b,
+	0xf9, 0x41, 0x90, 0x9f, 0xc0, 0x2f, 0xcb, 0x69, 0xa6, 0x62, 0x19, 0xad, 0x83, 0xfd, 0x5e, 0xad,
+	0xef, 0x0f, 0xfc, 0xb3, 0xd4, 0x9c, 0xb9, 0x3e, 0x13, 0x44, 0x5d, 0xd5, 0xf6, 0x9f, 0x33, 0xe5,
+	0xa5, 0xe9, 0xc3, 0xc2, 0x94, 0x6f, 0x4c, 0xf9, 0x6d, 0xd3, 0x7a, 0x61, 0xca, 0xef, 0x98, 0xf2,
+	0xdb, 0xa6, 0x8d, 0xfb, 0x4d, 0xf9, 0xae, 0xe9, 0xb7, 0x70, 0xc8, 0x38, 0xa7, 0xf3, 0x25, 0x4d,
+	0x84, 0x65, 0x9c, 0x59, 0x16, 0x34, 0x7b, 0xb5, 0x7e, 0x33, 0xf4, 0x18, 0xe7, 0xaf, 0x97, 0xe3,
+	0x12, 0x24, 0xdf, 0x43, 0x97, 0x8b, 0x1b, 0x6a, 0x2c, 0xb3, 0xb9, 0xa1, 0x5a, 0x5c, 0xd3, 0x99,
+	0x16, 0xd7, 0x41, 0x0b, 0x1f, 0xa4, 0xc3, 0xc5, 0xcd, 0x25, 0x32, 0xa1, 0xb8, 0x7e, 0xa5, 0xc5,
+	0x35, 0xf9, 0x05, 0x9e, 0x68, 0x91, 0x29, 0x6d, 0xe9, 0x4e, 0xd5, 0x94, 0x59, 0x2b, 0xf4, 0x3a,
+	0x00, 0x34, 0x78, 0x5c, 0x08, 0x46, 0x55, 0xe9, 0x45, 0xc1, 0x92, 0x9f, 0x21, 0xf8, 0xb8, 0x34,
+	0x61, 0x7a, 0x2e, 0xd3, 0xe0, 0x00, 0x2b, 0x1f, 0xdd, 0xa9, 0x1c, 0x23, 0x49, 0x1e, 0x41, 0x9d,
+	0x6b, 0x9a, 0xc8, 0x34, 0x68, 0xe3, 0x53, 0x3d, 0xe4, 0x7a, 0xbc, 0x85, 0xd9, 0x2a, 0xf0, 0x36,
+	0x30, 0x5b, 0x91, 0xaf, 0xa1, 0x1d, 0x5d, 0xb1, 0x34, 0x15, 0x31, 0x4d, 0x98, 0x59, 0x04, 0x3e,
+	0x0e, 0xff, 0xa0, 0xc4, 0xc6, 0xcc, 0x2c, 0xc8, 0x17, 0x00, 0x99, 0xa6, 0x2c, 0x8e, 0xd5, 0x52,
+	0xf0, 0xe0, 0x10, 0xbd, 0x5b, 0x99, 0x7e, 0x51, 0x00, 0x8e, 0xbe, 0xda, 0xd2, 0x9d, 0x82, 0xbe,
+	0xda, 0xa5, 0x35, 0xdb, 0xd0, 0x47, 0x05, 0xad, 0x59, 0x45, 0x7f, 0x09, 0x07, 0xe9, 0x72, 0x41,
+	0xe7, 0x42, 0xd1, 0x58, 0x45, 0x01, 0x29, 0xf8, 0x74, 0xb9, 0x78, 0x2d, 0xd4, 0x6f, 0x2a, 0x72,
+	0xe5, 0x96, 0xe9, 0xb9, 0xb0, 0x34, 0x13, 0x3a, 0xe8, 0xe2, 0xa3, 0xb7, 0x0a, 0x64, 0x22, 0x34,
+	0xe9, 0x43, 0x27, 0x91, 0xa9, 0x9b, 0x1b, 0x97, 0x37, 0x42, 0x1b, 0x69, 0xd7, 0xc1, 0x31, 0x8a,
+	0xfc, 0x44, 0xa6, 0xaf, 0x97, 0xa3, 0x0a, 0x25, 0x2f, 0xe1, 0xe4, 0xae, 0xb2, 0x5a, 0x90, 0x47,
+	0xf7, 0x2e, 0xc8, 0xf1, 0xed, 0x06, 0x05, 0x4a, 0x3e, 0x07, 0x30, 0x19, 0xad, 0x52, 0xf1, 0x18,
+	0xad, 0x9a, 0x26, 0xfb, 0xbd, 0x58, 0xd1, 0xef, 0xe0, 0xa8, 0x60, 0x77, 0xb7, 0xf4, 0xa4, 0x78,
+	0x1e, 0x27, 0xda, 0xd9, 0xd3, 0xa2, 0x51, 0xb5, 0xe9, 0x41, 0xd5, 0x68, 0xb4, 0xdb, 0xe8, 0xce,
+	0xba, 0x3f, 0xa9, 0x1a, 0x8d, 0x76, 0x1a, 0x9d, 0xfe, 0x53, 0x07, 0x6f, 0x24, 0xfe, 0x2f, 0xc6,
+	0x7d, 0xe8, 0x98, 0x3c, 0x73, 0xbb, 0x62, 0x68, 0x14, 0x33, 0x63, 0xe8, 0x14, 0xf3, 0xdc, 0x0c,
+	0xfd, 0x0a, 0x1f, 0x3a, 0xf8, 0xc2, 0xc5, 0xa0, 0x14, 0x50, 0x2b, 0x13, 0xa1, 0x72, 0x5b, 0x06,
+	0xdb, 0x43, 0xf8, 0xe2, 0x7d, 0x01, 0xba, 0x8e, 0x99, 0x4c, 0xe7, 0xd4, 0xc4, 0x0a, 0x07, 0x23,
+	0x15, 0xc7, 0x6c, 0x7b, 0xa1, 0xef, 0xf0, 0xcb, 0x58, 0xb9, 0xe9, 0x48, 0xc5, 0x49, 0x0f, 0xda,
+	0x5b, 0x25, 0xd7, 0x65, 0xa4, 0xa1, 0x52, 0x8d, 0xb4, 0x8b, 0xf5, 0x56, 0x81, 0x69, 0x2a, 0x63,
+	0x5d, 0x69, 0x30, 0x49, 0x1f, 0x9f, 0x21, 0x0a, 0x1a, 0xf7, 0x9c, 0x61, 0xb8, 0x3d, 0x43, 0xb4,
+	0x39, 0x43, 0x73, 0xe7, 0x0c, 0xc3, 0xea, 0x0c, 0x5f, 0xc1, 0x41, 0xc2, 0x22, 0x8a, 0xe3, 0x55,
+	0x29, 0x46, 0xb8, 0x15, 0x42, 0xc2, 0xa2, 0x3f, 0x0a, 0x84, 0x9c, 0x41, 0x57, 0x8b, 0x39, 0xcd,
+	0x98, 0x66, 0x89, 0xcb, 0xfa, 0x8d, 0x44, 0x21, 0xa0, 0xf0, 0x48, 0x8b, 0xf9, 0x04, 0x99, 0xb0,
+	0x24, 0xdc, 0x44, 0xf5, 0x8a, 0x72, 0x11, 0xb3, 0x35, 0x3d, 0xc7, 0x8c, 0x7a, 0x61, 0x53, 0xaf,
+	0x46, 0x0e, 0x38, 0x27, 0x4f, 0xc1, 0x77, 0xac, 0xa6, 0x6a, 0x36, 0x33, 0xc2, 0xd2, 0xf3, 0x32,
+	0x9e, 0x07, 0x7a, 0x35, 0xd2, 0xef, 0x10, 0x3b, 0x27, 0xa7, 0xe0, 0x39, 0x11, 0xb3, 0x0c, 0x5f,
+	0x60, 0x83, 0xc0, 0xdb, 0x68, 0x4a, 0x6c, 0x40, 0x3e, 0x85, 0x96, 0x5e, 0xe1, 0x45, 0xd1, 0x01,
+	0xc6, 0xd5, 0x0b, 0x1b, 0x7a, 0xe5, 0x2e, 0x69, 0x40, 0x7e, 0x84, 0xe3, 0x19, 0x8b, 0xac, 0xd2,
+	0x6b, 0x9a, 0x69, 0xe1, 0x6c, 0x9c, 0xce, 0x04, 0x87, 0xbd, 0x07, 0x7d, 0x2f, 0x24, 0x25, 0x37,
+	0x41, 0xca, 0x55, 0x18, 0xf2, 0x04, 0x9a, 0x09, 0x5b, 0x51, 0x21, 0x75, 0x86, 0xd9, 0xf5, 0xc2,
+	0x46, 0xc2, 0x56, 0x2f, 0xa5, 0xce, 0xdc, 0x60, 0x1c, 0xc5, 0x73, 0xbb, 0xa6, 0xd1, 0x3a, 0x8a,
+	0x05, 0xa6, 0xd7, 0x0b, 0xdb, 0x09, 0x5b, 0x8d, 0x72, 0xbb, 0x1e, 0x3a, 0x8c, 0x3c, 0x05, 0x6f,
+	0x33, 0x98, 0x3f, 0x95, 0x4c, 0xcb, 0x08, 0xb7, 0x2b, 0xf0, 0x57, 0x25, 0x53, 0xf2, 0x19, 0xb4,
+	0xf4, 0x8c, 0x6a, 0x31, 0x77, 0x17, 0xd8, 0xc5, 0x0b, 0x6c, 0xea, 0x59, 0x88, 0xff, 0xc9, 0x0f,
+	0x70, 0xbc, 0xe9, 0xf0, 0x7c, 0x30, 0x95, 0x96, 0xce, 0x68, 0x94, 0x5a, 0xcc, 0x71, 0x33, 0x3c,
+	0xaa, 0x38, 0xa4, 0x5e, 0x0d, 0x53, 0x4b, 0x9e, 0xc1, 0xd1, 0x5c, 0xa8, 0x58, 0x45, 0x74, 0x9a,
+	0xcf, 0x66, 0x42, 0x53, 0x6b, 0x63, 0x0c, 0xb1, 0x17, 0x1e, 0x16, 0xc4, 0x05, 0xe2, 0xef, 0x6d,
+	0x4c, 0x9e, 0xc3, 0xe3, 0x52, 0xeb, 0xd2, 0x5f, 0xea, 0x31, 0x4d, 0x45, 0x76, 0xbb, 0x05, 0x3b,
+	0x96, 0x69, 0x51, 0x83, 0xd9, 0xec, 0x43, 0x87, 0x71, 0xf7, 0x4e, 0x9b, 0x2b, 0x2d, 0xed, 0x55,
+	0x42, 0x25, 0xc7, 0x14, 0xb7, 0x42, 0x9f, 0x71, 0xfd, 0xa2, 0x82, 0xdf, 0xf0, 0xd3, 0xbf, 0x6a,
+	0xe0, 0x87, 0x2a, 0xb7, 0x32, 0x9d, 0xff, 0x57, 0xfa, 0xba, 0xf0, 0x90, 0x19, 0xd7, 0x61, 0x0f,
+	0x3b, 0xec, 0x33, 0xf3, 0x06, 0xbf, 0xac, 0x11, 0xa3, 0x91, 0xd0, 0x45, 0xc0, 0x5a, 0x61, 0x3d,
+	0x62, 0x43, 0xa1, 0xad, 0x9b, 0x87, 0x8d, 0x4d, 0xc1, 0xec, 0x23, 0xd3, 0xb0, 0xb1, 0x41, 0xea,
+	0x04, 0xdc, 0x4f, 0xba, 0x10, 0x6b, 0x4c, 0x51, 0x2b, 0xac, 0xdb, 0xd8, 0xbc, 0x15, 0xeb, 0x67,
+	0x3d, 0x80, 0x9d, 0x4f, 0x59, 0x13, 0xf6, 0x47, 0xe1, 0xbb, 0x49, 0xe7, 0x13, 0xf7, 0x6b, 0xfc,
+	0x22, 0x7c, 0xdb, 0xa9, 0x4d, 0xeb, 0xf8, 0xd9, 0x7f, 0xfe, 0xef, 0x00, 0x22, 0xdb, 0x02, 0xcb,
+	0x08, 0x08, 0x00, 0x00,
 }
@@ -119,6 +119,20 @@ deduplication_delay="{{ .NetworkServer.DeduplicationDelay }}"
 # values can be combined, e.g. '24h30m15s'.
 device_session_ttl="{{ .NetworkServer.DeviceSessionTTL }}"
 
+# Device-status history expiration.
+#
+# The DevStatusAns (battery and margin) and LinkCheckReq (margin and gateway
+# count) history of each device is kept for this duration. Valid units are
+# 'ms', 's', 'm', 'h'. Set this to 0 to disable the device-status history.
+device_status_history_ttl="{{ .NetworkServer.DeviceStatusHistoryTTL }}"
+
+# Multicast delivery report expiration.
+#
+# The delivery report of each multicast downlink payload is kept for this
+# duration after its last update. Valid units are 'ms', 's', 'm', 'h'. Set
+# this to 0 to keep the delivery reports forever.
+multicast_delivery_ttl="{{ .NetworkServer.MulticastDeliveryTTL }}"
+
 # Get downlink data delay.
 #
 # This is the time that ChirpStack Network Server waits between forwarding data to the
@@ -340,6 +354,83 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # 15 = about 1 year
   max_time_n={{ .NetworkServer.NetworkSettings.RejoinRequest.MaxTimeN }}
 
+  # Duty-cycle settings
+  #
+  # When enabled, ChirpStack Network Server keeps track of the airtime used
+  # by each gateway per sub-band and will not schedule downlink transmissions
+  # that would exceed the regulatory duty-cycle limitations. In this case it
+  # will try to use another gateway or RX window. Currently this implements
+  # the ETSI EN 300 220 limitations for the EU868 band.
+  [network_server.network_settings.duty_cycle]
+  # Enable duty-cycle accounting.
+  enabled={{ .NetworkServer.NetworkSettings.DutyCycle.Enabled }}
+
+  # Sliding window over which the duty-cycle is calculated.
+  window="{{ .NetworkServer.NetworkSettings.DutyCycle.Window }}"
+
+  # Gateway TX schedule settings
+  #
+  # When enabled, ChirpStack Network Server keeps track of the downlink
+  # emissions scheduled for each gateway and will not schedule downlink
+  # transmissions that would overlap with an already scheduled emission
+  # of the same gateway. In this case it will try to use another gateway
+  # or RX window.
+  [network_server.network_settings.tx_schedule]
+  # Enable the gateway TX schedule.
+  enabled={{ .NetworkServer.NetworkSettings.TXSchedule.Enabled }}
+
+  # Margin between two emissions of the same gateway.
+  #
+  # Note that emissions scheduled relative to the uplink timestamp (e.g.
+  # Class-A) are converted to the network-server time using an estimation,
+  # in which case the margin should also account for the network latency.
+  margin="{{ .NetworkServer.NetworkSettings.TXSchedule.Margin }}"
+
+  # ADR plugins
+  #
+  # ADR plugins are external services implementing the ADRPluginService gRPC
+  # interface (see api/adr/adr.proto). Each plugin can be selected by its ID
+  # in the device-profile, like the built-in ADR algorithms.
+  #
+  # Example:
+  # [[network_server.network_settings.adr_plugins]]
+  # # ID of the ADR algorithm.
+  # #
+  # # This ID must be unique and can not be equal to one of the built-in
+  # # ADR algorithm IDs.
+  # id="my-adr"
+
+  # # Name of the ADR algorithm.
+  # name="My ADR algorithm"
+
+  # # hostname:port of the ADR plugin.
+  # server="localhost:8010"
+
+  # # CA certificate used by the ADR plugin client (optional).
+  # ca_cert=""
+
+  # # TLS certificate used by the ADR plugin client (optional).
+  # tls_cert=""
+
+  # # TLS key used by the ADR plugin client (optional).
+  # tls_key=""
+
+  # # Request timeout (optional, default 200ms).
+  # #
+  # # As ADR is handled before sending the downlink, this must be well below
+  # # the RX1 delay.
+  # timeout="200ms"
+{{ range $index, $element := .NetworkServer.NetworkSettings.ADRPlugins }}
+  [[network_server.network_settings.adr_plugins]]
+  id="{{ $element.ID }}"
+  name="{{ $element.Name }}"
+  server="{{ $element.Server }}"
+  ca_cert="{{ $element.CACert }}"
+  tls_cert="{{ $element.TLSCert }}"
+  tls_key="{{ $element.TLSKey }}"
+  timeout="{{ $element.Timeout }}"
+{{ end }}
+
 
   # Scheduler settings
   #
@@ -379,6 +470,39 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   tls_key="{{ .NetworkServer.API.TLSKey }}"
 
 
+  # Gateway settings.
+  [network_server.gateway]
+  # Gateway command execution timeout.
+  #
+  # This defines how long the ExecGatewayCommand API method waits for the
+  # response of the gateway before returning a timeout error.
+  command_exec_timeout="{{ .NetworkServer.Gateway.CommandExecTimeout }}"
+
+
+  # Gateway connection-state monitor.
+  #
+  # The monitor marks a gateway as offline when no stats have been received
+  # within the given number of stats intervals. The state changes are
+  # reported to ChirpStack Application Server.
+  [network_server.gateway.monitor]
+  # Interval in which the monitor checks for offline gateways.
+  interval="{{ .NetworkServer.Gateway.Monitor.Interval }}"
+
+  # Default stats interval.
+  #
+  # This is the stats interval which is used when the gateway does not have
+  # a gateway-profile or when the stats interval of the gateway-profile is
+  # not set. This must match the stats interval of the gateway (e.g. the
+  # ChirpStack Gateway Bridge).
+  default_stats_interval="{{ .NetworkServer.Gateway.Monitor.DefaultStatsInterval }}"
+
+  # Missed stats threshold.
+  #
+  # The number of stats intervals without stats after which the gateway is
+  # marked as offline.
+  missed_stats_threshold={{ .NetworkServer.Gateway.Monitor.MissedStatsThreshold }}
+
+
   # Backend defines the gateway backend settings.
   #
   # The gateway backend handles the communication with the gateway(s) part of
@@ -392,6 +516,11 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
     #  * mqtt
     #  * gcp_pub_sub
     #  * azure_iot_hub
+    #  * basic_station
+    #  * semtech_udp
+    #  * amqp
+    #  * kafka
+    #  * multiplexer (see multiplexer section below)
     type="{{ .NetworkServer.Gateway.Backend.Type }}"
 
 
@@ -510,6 +639,188 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
     commands_connection_string="{{ .NetworkServer.Gateway.Backend.AzureIoTHub.CommandsConnectionString }}"
 
 
+    # Basics Station backend.
+    #
+    # Use this backend when the gateways are running the LoRa Basics Station
+    # software and connect directly to ChirpStack Network Server using the LNS
+    # protocol (WebSocket). No ChirpStack Gateway Bridge is needed in this case.
+    [network_server.gateway.backend.basic_station]
+    # ip:port to bind the WebSocket listener to.
+    #
+    # The gateways must be configured with ws://IP:PORT (or wss:// when TLS is
+    # configured) as the router-info (TC / LNS) URI.
+    bind="{{ .NetworkServer.Gateway.Backend.BasicStation.Bind }}"
+
+    # TLS certificate and key files.
+    #
+    # When set, the WebSocket listener will use TLS.
+    tls_cert="{{ .NetworkServer.Gateway.Backend.BasicStation.TLSCert }}"
+    tls_key="{{ .NetworkServer.Gateway.Backend.BasicStation.TLSKey }}"
+
+    # TLS CA certificate.
+    #
+    # When configured, ChirpStack Network Server will validate that the client-certificate
+    # of the gateway has been signed by this CA certificate.
+    ca_cert="{{ .NetworkServer.Gateway.Backend.BasicStation.CACert }}"
+
+    # Stats interval.
+    #
+    # This defines the interval in which the Basics Station backend generates
+    # the gateway stats for each connected gateway.
+    stats_interval="{{ .NetworkServer.Gateway.Backend.BasicStation.StatsInterval }}"
+
+    # Ping interval.
+    ping_interval="{{ .NetworkServer.Gateway.Backend.BasicStation.PingInterval }}"
+
+    # Read timeout.
+    #
+    # This interval must be greater than the configured ping interval.
+    read_timeout="{{ .NetworkServer.Gateway.Backend.BasicStation.ReadTimeout }}"
+
+    # Write timeout.
+    write_timeout="{{ .NetworkServer.Gateway.Backend.BasicStation.WriteTimeout }}"
+
+    # Region.
+    #
+    # The Basics Station region name (e.g. EU863, US902, ...). When left blank,
+    # this will be derived from the configured band.
+    region="{{ .NetworkServer.Gateway.Backend.BasicStation.Region }}"
+
+    # Minimum and maximum frequency (Hz).
+    #
+    # This defines the frequency range in which the gateways are allowed to
+    # transmit. When left blank (0), this will be derived from the configured
+    # band.
+    frequency_min={{ .NetworkServer.Gateway.Backend.BasicStation.FrequencyMin }}
+    frequency_max={{ .NetworkServer.Gateway.Backend.BasicStation.FrequencyMax }}
+
+
+    # Semtech UDP packet-forwarder backend.
+    #
+    # Use this backend when the gateways are running the Semtech UDP
+    # packet-forwarder and send their data directly to ChirpStack Network Server
+    # (without ChirpStack Gateway Bridge and MQTT broker).
+    #
+    # Note: the packet-forwarder does not support the remote (re)configuration
+    # of its channel-plan. Gateway Profile configuration will not be applied.
+    [network_server.gateway.backend.semtech_udp]
+    # ip:port to bind the UDP listener to.
+    #
+    # Example: 0.0.0.0:1700 to listen on port 1700 for all network interfaces.
+    # This is the listener to which the packet-forwarder forwards its data
+    # so make sure the 'serv_port_up' and 'serv_port_down' from your
+    # packet-forwarder matches this port.
+    udp_bind="{{ .NetworkServer.Gateway.Backend.SemtechUDP.UDPBind }}"
+
+    # Skip the CRC status-check of received packets.
+    #
+    # This only has effect when the packet-forwarder is configured to forward
+    # LoRa frames with CRC errors.
+    skip_crc_check={{ .NetworkServer.Gateway.Backend.SemtechUDP.SkipCRCCheck }}
+
+
+    # AMQP / RabbitMQ gateway backend settings.
+    #
+    # This is the backend communicating with the LoRa gateways over an AMQP
+    # 0-9-1 broker (e.g. RabbitMQ). Gateway events are consumed from a durable
+    # queue which is bound to the amq.topic exchange. When multiple
+    # ChirpStack Network Server instances consume from the same queue, the
+    # events are distributed over these instances.
+    [network_server.gateway.backend.amqp]
+    # Server URL.
+    #
+    # See for a specification of all the possible options:
+    # https://www.rabbitmq.com/uri-spec.html
+    url="{{ .NetworkServer.Gateway.Backend.AMQP.URL }}"
+
+    # Event queue name.
+    #
+    # This queue will be created when it does not yet exist and is used to
+    # queue the events received from the gateway.
+    event_queue_name="{{ .NetworkServer.Gateway.Backend.AMQP.EventQueueName }}"
+
+    # Event routing key.
+    #
+    # This is the routing-key used for creating the queue binding.
+    event_routing_key="{{ .NetworkServer.Gateway.Backend.AMQP.EventRoutingKey }}"
+
+    # Command routing-key template.
+    #
+    # This is the command routing-key template used when publishing gateway
+    # commands.
+    #
+    # Use:
+    #   * "{{ "{{ .GatewayID }}" }}" as an substitution for the LoRa gateway ID
+    #   * "{{ "{{ .CommandType }}" }}" as an substitution for the command type
+    command_routing_key_template="{{ .NetworkServer.Gateway.Backend.AMQP.CommandRoutingKeyTemplate }}"
+
+
+    # Kafka gateway backend settings.
+    #
+    # This is the backend communicating with the LoRa gateways over Kafka.
+    # The uplink, stats and ack events are consumed using a consumer group,
+    # so that multiple ChirpStack Network Server instances share the load.
+    # Events and commands are keyed by gateway ID, so that all messages of
+    # a single gateway end up in the same partition. Consumer offsets are
+    # committed once the event has been handed over for processing, which
+    # is before the event has been handled (e.g. the uplink de-duplication
+    # and processing). Events which are being handled when ChirpStack Network
+    # Server stops or crashes are not re-delivered (at-most-once delivery).
+    #
+    # Note: the events must be published using the HEX encoded gateway ID as
+    # message key.
+    [network_server.gateway.backend.kafka]
+    # Brokers.
+    brokers=[{{ if .NetworkServer.Gateway.Backend.Kafka.Brokers|len }}"{{ end }}{{ range $index, $elm := .NetworkServer.Gateway.Backend.Kafka.Brokers }}{{ if $index }}", "{{ end }}{{ $elm }}{{ end }}{{ if .NetworkServer.Gateway.Backend.Kafka.Brokers|len }}"{{ end }}]
+
+    # TLS.
+    #
+    # Set this to true when the Kafka client must connect using TLS to the Broker.
+    tls={{ .NetworkServer.Gateway.Backend.Kafka.TLS }}
+
+    # SASL PLAIN username (optional).
+    username="{{ .NetworkServer.Gateway.Backend.Kafka.Username }}"
+
+    # SASL PLAIN password (optional).
+    password="{{ .NetworkServer.Gateway.Backend.Kafka.Password }}"
+
+    # Consumer group ID.
+    #
+    # All ChirpStack Network Server instances sharing the same group ID
+    # will split the partitions of the event topics.
+    group_id="{{ .NetworkServer.Gateway.Backend.Kafka.GroupID }}"
+
+    # Uplink event topic.
+    uplink_topic="{{ .NetworkServer.Gateway.Backend.Kafka.UplinkTopic }}"
+
+    # Stats event topic.
+    stats_topic="{{ .NetworkServer.Gateway.Backend.Kafka.StatsTopic }}"
+
+    # Ack event topic.
+    ack_topic="{{ .NetworkServer.Gateway.Backend.Kafka.AckTopic }}"
+
+    # Command topic.
+    #
+    # Both the downlink and gateway configuration commands are published to
+    # this topic. The command type is set as the 'command' message header
+    # ('down' or 'config').
+    command_topic="{{ .NetworkServer.Gateway.Backend.Kafka.CommandTopic }}"
+
+
+    # Multiplexer gateway backend settings.
+    #
+    # When the backend type is set to multiplexer, ChirpStack Network Server
+    # will use all the gateway backends listed below at the same time (each
+    # configured by its own section). Commands for a gateway are sent to the
+    # backend the gateway is pinned to (see the gateway backend setting of
+    # the gateway), or else to the backend on which the gateway was last seen.
+    [network_server.gateway.backend.multiplexer]
+    # Gateway backends.
+    #
+    # Example: ["mqtt", "azure_iot_hub"]
+    backends=[{{ if .NetworkServer.Gateway.Backend.Multiplexer.Backends|len }}"{{ end }}{{ range $index, $elm := .NetworkServer.Gateway.Backend.Multiplexer.Backends }}{{ if $index }}", "{{ end }}{{ $elm }}{{ end }}{{ if .NetworkServer.Gateway.Backend.Multiplexer.Backends|len }}"{{ end }}]
+
+
   # Geolocation settings.
   #
   # When set, ChirpStack Network Server will use the configured geolocation server to
@@ -664,6 +975,109 @@ resolve_domain_suffix="{{ .JoinServer.ResolveDomainSuffix }}"
   kek="{{ $element.KEK }}"
   {{ end }}
 
+
+# Roaming settings (experimental).
+#
+# Passive-roaming as specified by the LoRaWAN Backend Interfaces 1.0.
+# Uplinks of devices with a DevAddr matching the NetID of a roaming
+# agreement are forwarded to the network-server of the roaming partner
+# (fNS). Passive-roaming requests of roaming partners are handled by
+# the roaming API (sNS).
+[roaming]
+# Request timeout.
+#
+# This defines the timeout of the requests to the network-server of a
+# roaming partner. It also bounds the forwarding of an uplink to all
+# matching roaming partners (fNS), as the sNS must be able to respond
+# with a downlink within the RX window of the device.
+request_timeout="{{ .Roaming.RequestTimeout }}"
+
+  # Roaming API.
+  #
+  # The roaming API handles the requests of roaming partners. When the
+  # bind is left blank, the roaming API is disabled.
+  [roaming.api]
+  # ip:port to bind the roaming API server to.
+  bind="{{ .Roaming.API.Bind }}"
+
+  # CA certificate (optional).
+  #
+  # When set, the roaming API requires client-certificate authentication.
+  ca_cert="{{ .Roaming.API.CACert }}"
+
+  # TLS certificate (optional).
+  tls_cert="{{ .Roaming.API.TLSCert }}"
+
+  # TLS key (optional).
+  tls_key="{{ .Roaming.API.TLSKey }}"
+
+
+  # Roaming agreements.
+  #
+  # Example (the [[roaming.servers]] can be repeated):
+  # [[roaming.servers]]
+  # # NetID of the roaming partner.
+  # net_id="010203"
+
+  # # Passive-roaming is allowed.
+  # passive_roaming=true
+
+  # # Passive-roaming session lifetime.
+  # #
+  # # When set to 0, the passive-roaming session is stateless and each
+  # # uplink results in a PRStartReq to the roaming partner.
+  # passive_roaming_lifetime="24h0m0s"
+
+  # # Passive-roaming KEK label (optional).
+  # #
+  # # The KEK label used to encrypt the session-key send to the roaming
+  # # partner (when this network-server is the sNS).
+  # passive_roaming_kek_label=""
+
+  # # Server of the roaming partner.
+  # server="https://example.com:8005"
+
+  # # CA certificate (optional).
+  # #
+  # # Set this to validate the server certificate of the roaming partner.
+  # ca_cert="/path/to/ca.pem"
+
+  # # TLS client-certificate (optional).
+  # tls_cert="/path/to/tls_cert.pem"
+
+  # # TLS client-certificate key (optional).
+  # tls_key="/path/to/tls_key.pem"
+  {{ range $index, $element := .Roaming.Servers }}
+  [[roaming.servers]]
+  net_id="{{ $element.NetID }}"
+  passive_roaming={{ $element.PassiveRoaming }}
+  passive_roaming_lifetime="{{ $element.PassiveRoamingLifetime }}"
+  passive_roaming_kek_label="{{ $element.PassiveRoamingKEKLabel }}"
+  server="{{ $element.Server }}"
+  ca_cert="{{ $element.CACert }}"
+  tls_cert="{{ $element.TLSCert }}"
+  tls_key="{{ $element.TLSKey }}"
+  {{ end }}
+
+
+  # Roaming KEK set.
+  #
+  # These KEKs (Key Encryption Keys) are used to encrypt and decrypt the
+  # session-keys exchanged with the roaming partners.
+  #
+  # Example (the [[roaming.kek.set]] can be repeated):
+  # [[roaming.kek.set]]
+  # # KEK label.
+  # label="000000"
+
+  # # Key Encryption Key.
+  # kek="01020304050607080102030405060708"
+  {{ range $index, $element := .Roaming.KEK.Set }}
+  [[roaming.kek.set]]
+  label="{{ $element.Label }}"
+  kek="{{ $element.KEK }}"
+  {{ end }}
+
   # Network-controller configuration.
   [network_controller]
   # hostname:port of the network-controller api server (optional)
@@ -11,7 +11,9 @@ import (
 	"syscall"
 
 	"github.com/brocaar/chirpstack-network-server/internal/adr"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/amqp"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/azureiothub"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/basicstation"
 	"github.com/brocaar/chirpstack-network-server/internal/metrics"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
@@ -23,18 +25,25 @@ import (
 	"github.com/brocaar/chirpstack-network-server/api/geo"
 	"github.com/brocaar/chirpstack-network-server/api/nc"
 	"github.com/brocaar/chirpstack-network-server/internal/api"
+	roamingapi "github.com/brocaar/chirpstack-network-server/internal/api/roaming"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/applicationserver"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/controller"
 	gwbackend "github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/gcppubsub"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/kafka"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/mqtt"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/multiplexer"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/semtechudp"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/geolocationserver"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/joinserver"
 	"github.com/brocaar/chirpstack-network-server/internal/band"
 	"github.com/brocaar/chirpstack-network-server/internal/config"
 	"github.com/brocaar/chirpstack-network-server/internal/downlink"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/beacon"
 	"github.com/brocaar/chirpstack-network-server/internal/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/gateway/monitor"
 	"github.com/brocaar/chirpstack-network-server/internal/migrations/code"
+	"github.com/brocaar/chirpstack-network-server/internal/roaming"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 	"github.com/brocaar/chirpstack-network-server/internal/uplink"
 )
@@ -56,6 +65,7 @@ func run(cmd *cobra.Command, args []string) error {
 		setupADR,
 		setupGeolocationServer,
 		setupJoinServer,
+		setupRoaming,
 		setupNetworkController,
 		setupUplink,
 		setupDownlink,
@@ -63,9 +73,11 @@ func run(cmd *cobra.Command, args []string) error {
 		migrateGatewayStats,
 		flushGatewayCache,
 		setupAPI,
+		setupRoamingAPI,
 		startLoRaServer(server),
 		startStatsServer(gwStats),
 		startQueueScheduler,
+		startGatewayMonitor,
 	}
 
 	for _, t := range tasks {
@@ -211,18 +223,18 @@ func setGatewayBackend() error {
 	var err error
 	var gw gwbackend.Gateway
 
-	switch config.C.NetworkServer.Gateway.Backend.Type {
-	case "mqtt":
-		gw, err = mqtt.NewBackend(
-			storage.RedisPool(),
-			config.C,
-		)
-	case "gcp_pub_sub":
-		gw, err = gcppubsub.NewBackend(config.C)
-	case "azure_iot_hub":
-		gw, err = azureiothub.NewBackend(config.C)
-	default:
-		return fmt.Errorf("unexpected gateway backend type: %s", config.C.NetworkServer.Gateway.Backend.Type)
+	if config.C.NetworkServer.Gateway.Backend.Type == "multiplexer" {
+		backends := make(map[string]gwbackend.Gateway)
+		for _, t := range config.C.NetworkServer.Gateway.Backend.Multiplexer.Backends {
+			backends[t], err = newGatewayBackend(t)
+			if err != nil {
+				return errors.Wrapf(err, "%s gateway-backend setup failed", t)
+			}
+		}
+
+		gw, err = multiplexer.NewBackend(storage.RedisPool(), storage.DB(), backends)
+	} else {
+		gw, err = newGatewayBackend(config.C.NetworkServer.Gateway.Backend.Type)
 	}
 
 	if err != nil {
@@ -233,6 +245,30 @@ func setGatewayBackend() error {
 	return nil
 }
 
+func newGatewayBackend(t string) (gwbackend.Gateway, error) {
+	switch t {
+	case "mqtt":
+		return mqtt.NewBackend(
+			storage.RedisPool(),
+			config.C,
+		)
+	case "gcp_pub_sub":
+		return gcppubsub.NewBackend(config.C)
+	case "azure_iot_hub":
+		return azureiothub.NewBackend(config.C)
+	case "basic_station":
+		return basicstation.NewBackend(config.C)
+	case "semtech_udp":
+		return semtechudp.NewBackend(config.C)
+	case "amqp":
+		return amqp.NewBackend(config.C)
+	case "kafka":
+		return kafka.NewBackend(config.C)
+	default:
+		return nil, fmt.Errorf("unexpected gateway backend type: %s", t)
+	}
+}
+
 func setupApplicationServer() error {
 	if err := applicationserver.Setup(); err != nil {
 		return errors.Wrap(err, "application-server setup error")
@@ -280,6 +316,13 @@ func setupJoinServer() error {
 	return nil
 }
 
+func setupRoaming() error {
+	if err := roaming.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup roaming error")
+	}
+	return nil
+}
+
 func setupNetworkController() error {
 	// TODO: move this logic to controller.Setup function
 	if config.C.NetworkController.Server != "" {
@@ -331,6 +374,14 @@ func setupAPI() error {
 	return nil
 }
 
+func setupRoamingAPI() error {
+	if err := roamingapi.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup roaming api error")
+	}
+
+	return nil
+}
+
 func startLoRaServer(server *uplink.Server) func() error {
 	return func() error {
 		*server = *uplink.NewServer()
@@ -355,6 +406,20 @@ func startQueueScheduler() error {
 	log.Info("starting multicast scheduler")
 	go downlink.MulticastQueueSchedulerLoop()
 
+	log.Info("starting class-b beacon scheduler")
+	go beacon.BeaconLoop()
+
+	return nil
+}
+
+func startGatewayMonitor() error {
+	if err := monitor.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup gateway monitor error")
+	}
+
+	log.Info("starting gateway connection-state monitor")
+	go monitor.MonitorLoop()
+
 	return nil
 }
 
@@ -3,17 +3,22 @@ package adr
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/channels"
 	"github.com/brocaar/chirpstack-network-server/internal/config"
 	"github.com/brocaar/chirpstack-network-server/internal/logging"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 	"github.com/brocaar/lorawan"
 )
 
+// maxNbRep defines the max. NbRep (NbTrans) set by the ADR engine.
+const maxNbRep = 3
+
 var pktLossRateTable = [][3]uint8{
 	{1, 1, 2},
 	{1, 2, 3},
@@ -27,36 +32,104 @@ var disableADR bool
 // installationMargin defines the ADR installation-margin.
 var installationMargin float64
 
+// handlers contains the available ADR algorithms, by ID.
+var handlers map[string]Handler
+
+// handlerIDs contains the IDs of the available ADR algorithms, in the
+// order they were registered.
+var handlerIDs []string
+
 // Setup configures the adr engine.
 func Setup(c config.Config) error {
 	disableADR = c.NetworkServer.NetworkSettings.DisableADR
 	installationMargin = c.NetworkServer.NetworkSettings.InstallationMargin
 
+	handlers = make(map[string]Handler)
+	handlerIDs = nil
+
+	for _, h := range []Handler{
+		&defaultHandler{},
+		&conservativeHandler{},
+	} {
+		if err := registerHandler(h); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range c.NetworkServer.NetworkSettings.ADRPlugins {
+		h, err := newPluginHandler(p.ID, p.Name, p.Server, p.CACert, p.TLSCert, p.TLSKey, p.Timeout)
+		if err != nil {
+			return errors.Wrapf(err, "setup adr plugin error (id: %s)", p.ID)
+		}
+
+		if err := registerHandler(h); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetHandlers returns the available ADR algorithms.
+func GetHandlers() []Handler {
+	var out []Handler
+	for _, id := range handlerIDs {
+		out = append(out, handlers[id])
+	}
+	return out
+}
+
+// HandlerExists returns true when an ADR algorithm with the given ID is
+// available. An empty ID refers to the default ADR algorithm.
+func HandlerExists(id string) bool {
+	if id == "" {
+		return true
+	}
+
+	_, ok := handlers[id]
+	return ok
+}
+
+func registerHandler(h Handler) error {
+	if _, ok := handlers[h.ID()]; ok {
+		return fmt.Errorf("adr algorithm with id %s is already registered", h.ID())
+	}
+
+	handlers[h.ID()] = h
+	handlerIDs = append(handlerIDs, h.ID())
+
 	return nil
 }
 
+// getHandler returns the ADR algorithm for the given ID. In case the ID is
+// empty or unknown, the default ADR algorithm is returned.
+func getHandler(ctx context.Context, id string) Handler {
+	if id == "" {
+		return handlers[DefaultHandlerID]
+	}
+
+	h, ok := handlers[id]
+	if !ok {
+		log.WithFields(log.Fields{
+			"adr_algorithm_id": id,
+			"ctx_id":           ctx.Value(logging.ContextIDKey),
+		}).Warning("unknown adr algorithm, falling back to default")
+		return handlers[DefaultHandlerID]
+	}
+
+	return h
+}
+
 // HandleADR handles ADR in case requested by the node and configured
-// in the device-session.
-func HandleADR(ctx context.Context, sp storage.ServiceProfile, ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
+// in the device-session. The ADR algorithm is selected by the
+// ADRAlgorithmID of the device-profile.
+func HandleADR(ctx context.Context, dp storage.DeviceProfile, sp storage.ServiceProfile, ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
 
 	// if the node has ADR disabled or it's disabled gloablly
 	if !ds.ADR || disableADR {
 		return nil, nil
 	}
 
-	// get the max SNR from the UplinkHistory
-	var snrM float64 = -999
-	var historyCount int
-	for _, uh := range ds.UplinkHistory {
-		if uh.TXPowerIndex == ds.TXPowerIndex {
-			historyCount++
-
-			if uh.MaxSNR > snrM {
-				snrM = uh.MaxSNR
-			}
-		}
-	}
-
 	dr, err := band.Band().GetDataRate(ds.DR)
 	if err != nil {
 		return nil, errors.Wrap(err, "get data-rate error")
@@ -67,68 +140,53 @@ func HandleADR(ctx context.Context, sp storage.ServiceProfile, ds storage.Device
 		return nil, err
 	}
 
-	snrMargin := snrM - requiredSNR - installationMargin
-	nStep := int(snrMargin / 3)
-
-	// In case of negative steps the ADR algorithm will increase the TXPower
-	// if possible. To avoid up / down / up / down TXPower changes, wait until
-	// we have a full history table before making adjustments.
-	if nStep < 0 && historyCount != storage.UplinkHistorySize {
-		return nil, nil
+	handler := getHandler(ctx, dp.ADRAlgorithmID)
+	req := HandleRequest{
+		DeviceProfile:      dp,
+		ServiceProfile:     sp,
+		DeviceSession:      ds,
+		MinDR:              sp.DRMin,
+		MaxDR:              sp.DRMax,
+		MaxTXPowerIndex:    getMaxSupportedTXPowerOffsetIndexForDevice(ds),
+		RequiredSNRForDR:   requiredSNR,
+		InstallationMargin: installationMargin,
+	}
+	resp, err := handler.Handle(ctx, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "handle adr error (adr_algorithm_id: %s)", handler.ID())
 	}
 
-	maxSupportedDR := sp.DRMax
-	maxSupportedTXPowerOffsetIndex := getMaxSupportedTXPowerOffsetIndexForDevice(ds)
-
-	var idealTXPowerIndex, idealDR int
+	// the limits apply to every algorithm, including external plugins
+	resp = applyLimits(req, resp)
 
-	if ds.DR > maxSupportedDR {
-		idealDR = maxSupportedDR
-		idealTXPowerIndex = ds.TXPowerIndex
-	} else {
-		idealTXPowerIndex, idealDR = getIdealTXPowerOffsetAndDR(nStep, ds.TXPowerIndex, ds.DR, ds.MinSupportedTXPowerIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR)
-	}
+	idealDR := resp.DR
+	idealTXPowerIndex := resp.TXPowerIndex
+	idealNbRep := uint8(resp.NbTrans)
 
-	idealNbRep := getNbRep(ds.NbTrans, ds.GetPacketLossPercentage())
+	enabledChannels := getEnabledUplinkChannels(ctx, sp, ds)
 
 	// there is nothing to adjust
-	if ds.TXPowerIndex == idealTXPowerIndex && ds.DR == idealDR && ds.NbTrans == idealNbRep {
+	if ds.TXPowerIndex == idealTXPowerIndex && ds.DR == idealDR && ds.NbTrans == idealNbRep && len(enabledChannels) == len(ds.EnabledUplinkChannels) {
 		return nil, nil
 	}
 
 	if linkADRReqBlock == nil || len(linkADRReqBlock.MACCommands) == 0 {
 		// nothing is pending
-		var chMask lorawan.ChMask
-		chMaskCntl := -1
-		for _, c := range ds.EnabledUplinkChannels {
-			if chMaskCntl != c/16 {
-				if chMaskCntl == -1 {
-					// set the chMaskCntl
-					chMaskCntl = c / 16
-				} else {
-					// break the loop as we only need to send one block of channels
-					break
-				}
-			}
-			chMask[c%16] = true
-		}
+		payloads := channels.GetLinkADRReqPayloads(ds.EnabledUplinkChannels, enabledChannels)
+
+		// the node will use the dr, tx power and nb-rep from the last command
+		payloads[len(payloads)-1].DataRate = uint8(idealDR)
+		payloads[len(payloads)-1].TXPower = uint8(idealTXPowerIndex)
+		payloads[len(payloads)-1].Redundancy.NbRep = uint8(idealNbRep)
 
 		linkADRReqBlock = &storage.MACCommandBlock{
 			CID: lorawan.LinkADRReq,
-			MACCommands: []lorawan.MACCommand{
-				{
-					CID: lorawan.LinkADRReq,
-					Payload: &lorawan.LinkADRReqPayload{
-						DataRate: uint8(idealDR),
-						TXPower:  uint8(idealTXPowerIndex),
-						ChMask:   chMask,
-						Redundancy: lorawan.Redundancy{
-							ChMaskCntl: uint8(chMaskCntl),
-							NbRep:      uint8(idealNbRep),
-						},
-					},
-				},
-			},
+		}
+		for i := range payloads {
+			linkADRReqBlock.MACCommands = append(linkADRReqBlock.MACCommands, lorawan.MACCommand{
+				CID:     lorawan.LinkADRReq,
+				Payload: &payloads[i],
+			})
 		}
 	} else {
 		// there is a pending block of commands in the queue, add the adr parameters
@@ -153,12 +211,42 @@ func HandleADR(ctx context.Context, sp storage.ServiceProfile, ds storage.Device
 		"req_tx_power_idx": idealTXPowerIndex,
 		"nb_trans":         ds.NbTrans,
 		"req_nb_trans":     idealNbRep,
+		"adr_algorithm_id": handler.ID(),
 		"ctx_id":           ctx.Value(logging.ContextIDKey),
 	}).Info("adr request added to mac-command queue")
 
 	return []storage.MACCommandBlock{*linkADRReqBlock}, nil
 }
 
+// getEnabledUplinkChannels returns the uplink channels enabled on the device
+// which are allowed by the service-profile channel-mask. In case the
+// service-profile does not define a channel-mask, or when none of the
+// channels would remain enabled, the enabled channels of the device are
+// returned.
+func getEnabledUplinkChannels(ctx context.Context, sp storage.ServiceProfile, ds storage.DeviceSession) []int {
+	if len(sp.ChannelMask) == 0 {
+		return ds.EnabledUplinkChannels
+	}
+
+	var out []int
+	for _, c := range ds.EnabledUplinkChannels {
+		if sp.IsChannelEnabled(c) {
+			out = append(out, c)
+		}
+	}
+
+	if len(out) == 0 {
+		log.WithFields(log.Fields{
+			"dev_eui":            ds.DevEUI,
+			"service_profile_id": sp.ID,
+			"ctx_id":             ctx.Value(logging.ContextIDKey),
+		}).Warning("service-profile channel-mask does not contain any of the enabled device channels, ignoring channel-mask")
+		return ds.EnabledUplinkChannels
+	}
+
+	return out
+}
+
 func getNbRep(currentNbRep uint8, pktLossRate float64) uint8 {
 	if currentNbRep < 1 {
 		currentNbRep = 1
@@ -177,6 +265,30 @@ func getNbRep(currentNbRep uint8, pktLossRate float64) uint8 {
 	return pktLossRateTable[3][currentNbRep-1]
 }
 
+// getNbRepForTargetPER returns the lowest NbRep for which the expected
+// packet error rate does not exceed the target PER (both in percent).
+// As the packet-loss rate is measured with the current NbRep, it first
+// derives the loss rate of a single transmission from it. In case the target
+// can not be met, maxNbRep is returned.
+func getNbRepForTargetPER(currentNbRep uint8, pktLossRate float64, targetPER int) uint8 {
+	if currentNbRep < 1 {
+		currentNbRep = 1
+	}
+	if currentNbRep > maxNbRep {
+		currentNbRep = maxNbRep
+	}
+
+	txLossRate := math.Pow(pktLossRate/100, 1/float64(currentNbRep))
+
+	for nbRep := uint8(1); nbRep < maxNbRep; nbRep++ {
+		if math.Pow(txLossRate, float64(nbRep))*100 <= float64(targetPER) {
+			return nbRep
+		}
+	}
+
+	return maxNbRep
+}
+
 func getMaxTXPowerOffsetIndex() int {
 	var idx int
 	for i := 0; ; i++ {
@@ -244,3 +356,24 @@ func getRequiredSNRForSF(sf int) (float64, error) {
 	}
 	return snr, nil
 }
+
+// applyLimits clamps the data-rate of the given response to the data-rate
+// range of the request and the tx-power index to the tx-power range
+// supported by the device.
+func applyLimits(req HandleRequest, resp HandleResponse) HandleResponse {
+	if resp.DR > req.MaxDR {
+		resp.DR = req.MaxDR
+	}
+	if resp.DR < req.MinDR {
+		resp.DR = req.MinDR
+	}
+
+	if resp.TXPowerIndex > req.MaxTXPowerIndex {
+		resp.TXPowerIndex = req.MaxTXPowerIndex
+	}
+	if resp.TXPowerIndex < req.DeviceSession.MinSupportedTXPowerIndex {
+		resp.TXPowerIndex = req.DeviceSession.MinSupportedTXPowerIndex
+	}
+
+	return resp
+}
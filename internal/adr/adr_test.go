@@ -40,6 +40,30 @@ func TestADR(t *testing.T) {
 			}
 		})
 
+		Convey("Given a testtable for getNbRepForTargetPER", func() {
+			testTable := []struct {
+				CurrentNbRep  uint8
+				PktLossRate   float64
+				TargetPER     int
+				ExpectedNbRep uint8
+			}{
+				{1, 0, 1, 1},
+				{1, 20, 5, 2},
+				{1, 50, 1, 3},
+				{2, 4, 10, 2},
+				{2, 1, 15, 1},
+				{3, 0.8, 5, 2},
+			}
+
+			for i, tst := range testTable {
+				Convey(fmt.Sprintf("Given PktLossRate: %f, Current NbRep: %d, TargetPER: %d [%d]", tst.PktLossRate, tst.CurrentNbRep, tst.TargetPER, i), func() {
+					Convey(fmt.Sprintf("Then NbRep equals: %d", tst.ExpectedNbRep), func() {
+						So(getNbRepForTargetPER(tst.CurrentNbRep, tst.PktLossRate, tst.TargetPER), ShouldEqual, tst.ExpectedNbRep)
+					})
+				})
+			}
+		})
+
 		Convey("getMaxTXPowerOffsetIndex returns 7", func() {
 			So(getMaxTXPowerOffsetIndex(), ShouldEqual, 7)
 		})
@@ -238,6 +262,7 @@ func TestADR(t *testing.T) {
 
 				testTable := []struct {
 					Name            string
+					DeviceProfile   storage.DeviceProfile
 					ServiceProfile  storage.ServiceProfile
 					DeviceSession   storage.DeviceSession
 					LinkADRReqBlock *storage.MACCommandBlock
@@ -263,6 +288,29 @@ func TestADR(t *testing.T) {
 						Expected:      []storage.MACCommandBlock{macBlock},
 						ExpectedError: nil,
 					},
+					{
+						Name: "ADR using the conservative algorithm, not increasing data-rate (incomplete history)",
+						DeviceProfile: storage.DeviceProfile{
+							ADRAlgorithmID: ConservativeHandlerID,
+						},
+						ServiceProfile: storage.ServiceProfile{
+							DRMin: 0,
+							DRMax: 5,
+						},
+						DeviceSession: storage.DeviceSession{
+							DevAddr:               [4]byte{1, 2, 3, 4},
+							DevEUI:                [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+							EnabledUplinkChannels: []int{0, 1, 2},
+							DR:                    2,
+							NbTrans:               1,
+							ADR:                   true,
+							UplinkHistory: []storage.UplinkHistory{
+								{MaxSNR: -7},
+							},
+						},
+						Expected:      nil,
+						ExpectedError: nil,
+					},
 					{
 						Name: "ADR decreasing data-rate by one step as a lower value has been specified in the service-profile",
 						ServiceProfile: storage.ServiceProfile{
@@ -300,6 +348,64 @@ func TestADR(t *testing.T) {
 						},
 						ExpectedError: nil,
 					},
+					{
+						Name: "ADR increasing data-rate to the min. data-rate of the service-profile",
+						ServiceProfile: storage.ServiceProfile{
+							DRMin: 3,
+							DRMax: 5,
+						},
+						DeviceSession: storage.DeviceSession{
+							DevAddr:               [4]byte{1, 2, 3, 4},
+							DevEUI:                [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+							EnabledUplinkChannels: []int{0, 1, 2},
+							DR:                    1,
+							ADR:                   true,
+							UplinkHistory: []storage.UplinkHistory{
+								{MaxSNR: -10},
+							},
+						},
+						Expected:      []storage.MACCommandBlock{macBlock},
+						ExpectedError: nil,
+					},
+					{
+						Name: "ADR applying the service-profile channel-mask",
+						ServiceProfile: storage.ServiceProfile{
+							DRMin:       0,
+							DRMax:       5,
+							ChannelMask: []byte{0x03},
+						},
+						DeviceSession: storage.DeviceSession{
+							DevAddr:               [4]byte{1, 2, 3, 4},
+							DevEUI:                [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+							EnabledUplinkChannels: []int{0, 1, 2},
+							DR:                    3,
+							NbTrans:               1,
+							ADR:                   true,
+							UplinkHistory: []storage.UplinkHistory{
+								{MaxSNR: -10},
+							},
+						},
+						Expected: []storage.MACCommandBlock{
+							{
+								CID: lorawan.LinkADRReq,
+								MACCommands: []lorawan.MACCommand{
+									{
+										CID: lorawan.LinkADRReq,
+										Payload: &lorawan.LinkADRReqPayload{
+											DataRate: 3,
+											TXPower:  0,
+											ChMask:   lorawan.ChMask{true, true},
+											Redundancy: lorawan.Redundancy{
+												ChMaskCntl: 0,
+												NbRep:      1,
+											},
+										},
+									},
+								},
+							},
+						},
+						ExpectedError: nil,
+					},
 					{
 						Name: "ADR increasing tx-power by one step (no CFlist)",
 						ServiceProfile: storage.ServiceProfile{
@@ -337,6 +443,65 @@ func TestADR(t *testing.T) {
 							},
 						},
 					},
+					{
+						// the packet-loss rate is 10%, a target PER of 2% requires
+						// two transmissions
+						Name: "ADR not increasing data-rate as the target PER requires re-transmissions",
+						ServiceProfile: storage.ServiceProfile{
+							DRMin:     0,
+							DRMax:     5,
+							TargetPER: 2,
+						},
+						DeviceSession: storage.DeviceSession{
+							DevAddr:               [4]byte{1, 2, 3, 4},
+							DevEUI:                [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+							EnabledUplinkChannels: []int{0, 1, 2},
+							DR:                    2,
+							NbTrans:               1,
+							ADR:                   true,
+							UplinkHistory: []storage.UplinkHistory{
+								{FCnt: 0, MaxSNR: -7},
+								{FCnt: 1, MaxSNR: -7},
+								{FCnt: 2, MaxSNR: -7},
+								{FCnt: 3, MaxSNR: -7},
+								{FCnt: 4, MaxSNR: -7},
+								{FCnt: 5, MaxSNR: -7},
+								{FCnt: 6, MaxSNR: -7},
+								{FCnt: 7, MaxSNR: -7},
+								{FCnt: 8, MaxSNR: -7},
+								{FCnt: 9, MaxSNR: -7},
+								{FCnt: 10, MaxSNR: -7},
+								{FCnt: 11, MaxSNR: -7},
+								{FCnt: 12, MaxSNR: -7},
+								{FCnt: 13, MaxSNR: -7},
+								{FCnt: 14, MaxSNR: -7},
+								{FCnt: 15, MaxSNR: -7},
+								{FCnt: 16, MaxSNR: -7},
+								{FCnt: 17, MaxSNR: -7},
+								{FCnt: 19, MaxSNR: -7},
+								{FCnt: 21, MaxSNR: -7},
+							},
+						},
+						Expected: []storage.MACCommandBlock{
+							{
+								CID: lorawan.LinkADRReq,
+								MACCommands: []lorawan.MACCommand{
+									{
+										CID: lorawan.LinkADRReq,
+										Payload: &lorawan.LinkADRReqPayload{
+											DataRate: 2,
+											TXPower:  0,
+											ChMask:   lorawan.ChMask{true, true, true},
+											Redundancy: lorawan.Redundancy{
+												ChMaskCntl: 0,
+												NbRep:      2,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
 					{
 						// this is because we don't have enough uplink history
 						// and the packetloss function returns therefore 0%.
@@ -604,7 +769,7 @@ func TestADR(t *testing.T) {
 
 				for i, tst := range testTable {
 					Convey(fmt.Sprintf("Test: %s [%d]", tst.Name, i), func() {
-						blocks, err := HandleADR(context.Background(), tst.ServiceProfile, tst.DeviceSession, tst.LinkADRReqBlock)
+						blocks, err := HandleADR(context.Background(), tst.DeviceProfile, tst.ServiceProfile, tst.DeviceSession, tst.LinkADRReqBlock)
 						if tst.ExpectedError != nil {
 							So(err, ShouldNotBeNil)
 							So(err, ShouldResemble, tst.ExpectedError)
@@ -651,7 +816,7 @@ func TestADR(t *testing.T) {
 					},
 				}
 
-				blocks, err := HandleADR(context.Background(), sp, ds, larb)
+				blocks, err := HandleADR(context.Background(), storage.DeviceProfile{}, sp, ds, larb)
 
 				So(err, ShouldBeNil)
 				So(blocks, ShouldBeNil)
@@ -0,0 +1,79 @@
+package adr
+
+import (
+	"context"
+
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+)
+
+// ConservativeHandlerID contains the ID of the conservative ADR algorithm.
+const ConservativeHandlerID = "conservative"
+
+// conservativeHandler implements an ADR algorithm for devices with varying
+// radio conditions, e.g. mobile devices. Unlike the default algorithm, it
+// uses the min. SNR of the uplink history, it only adjusts the data-rate
+// when the uplink history is complete and by at most one step at a time and
+// it decreases the data-rate when the link margin is negative. The device
+// always uses its max. tx-power.
+type conservativeHandler struct{}
+
+func (h *conservativeHandler) ID() string {
+	return ConservativeHandlerID
+}
+
+func (h *conservativeHandler) Name() string {
+	return "Conservative ADR algorithm (LoRa only, for mobile devices)"
+}
+
+func (h *conservativeHandler) Handle(ctx context.Context, req HandleRequest) (HandleResponse, error) {
+	ds := req.DeviceSession
+	resp := HandleResponse{
+		DR:           ds.DR,
+		TXPowerIndex: ds.MinSupportedTXPowerIndex,
+		NbTrans:      int(ds.NbTrans),
+	}
+
+	if req.ServiceProfile.TargetPER > 0 {
+		resp.NbTrans = int(getNbRepForTargetPER(ds.NbTrans, ds.GetPacketLossPercentage(), req.ServiceProfile.TargetPER))
+	} else {
+		resp.NbTrans = int(getNbRep(ds.NbTrans, ds.GetPacketLossPercentage()))
+	}
+
+	if ds.DR > req.MaxDR {
+		resp.DR = req.MaxDR
+		return resp, nil
+	}
+	if ds.DR < req.MinDR {
+		resp.DR = req.MinDR
+		return resp, nil
+	}
+
+	// get the min SNR from the UplinkHistory
+	var snrM float64 = 999
+	var historyCount int
+	for _, uh := range ds.UplinkHistory {
+		if uh.TXPowerIndex == ds.TXPowerIndex {
+			historyCount++
+
+			if uh.MaxSNR < snrM {
+				snrM = uh.MaxSNR
+			}
+		}
+	}
+
+	if historyCount != storage.UplinkHistorySize {
+		return resp, nil
+	}
+
+	snrMargin := snrM - req.RequiredSNRForDR - req.InstallationMargin
+
+	// In case re-transmissions are required, do not increase the data-rate
+	// as this would further increase the packet error rate.
+	if snrMargin >= 3 && resp.NbTrans <= 1 && ds.DR < req.MaxDR {
+		resp.DR = ds.DR + 1
+	} else if snrMargin < 0 && ds.DR > req.MinDR {
+		resp.DR = ds.DR - 1
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,133 @@
+package adr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+)
+
+func TestConservativeHandler(t *testing.T) {
+	fullHistory := func(snr float64, txPowerIndex int) []storage.UplinkHistory {
+		var out []storage.UplinkHistory
+		for i := 0; i < storage.UplinkHistorySize; i++ {
+			out = append(out, storage.UplinkHistory{FCnt: uint32(i), MaxSNR: snr, TXPowerIndex: txPowerIndex})
+		}
+		return out
+	}
+
+	tests := []struct {
+		Name             string
+		Request          HandleRequest
+		ExpectedResponse HandleResponse
+	}{
+		{
+			Name: "incomplete history",
+			Request: HandleRequest{
+				DeviceSession: storage.DeviceSession{
+					DR:            2,
+					NbTrans:       1,
+					UplinkHistory: []storage.UplinkHistory{{MaxSNR: 10}},
+				},
+				MaxDR:            5,
+				RequiredSNRForDR: -15,
+			},
+			ExpectedResponse: HandleResponse{DR: 2, TXPowerIndex: 0, NbTrans: 1},
+		},
+		{
+			Name: "margin allows one step, data-rate is increased by one",
+			Request: HandleRequest{
+				DeviceSession: storage.DeviceSession{
+					DR:            2,
+					NbTrans:       1,
+					UplinkHistory: fullHistory(0, 0),
+				},
+				MaxDR:            5,
+				RequiredSNRForDR: -15,
+			},
+			ExpectedResponse: HandleResponse{DR: 3, TXPowerIndex: 0, NbTrans: 1},
+		},
+		{
+			Name: "min snr is used",
+			Request: HandleRequest{
+				DeviceSession: storage.DeviceSession{
+					DR:      2,
+					NbTrans: 1,
+					UplinkHistory: append(fullHistory(0, 0)[1:], storage.UplinkHistory{
+						FCnt:   20,
+						MaxSNR: -14,
+					}),
+				},
+				MaxDR:            5,
+				RequiredSNRForDR: -15,
+			},
+			ExpectedResponse: HandleResponse{DR: 2, TXPowerIndex: 0, NbTrans: 1},
+		},
+		{
+			Name: "negative margin, data-rate is decreased by one",
+			Request: HandleRequest{
+				DeviceSession: storage.DeviceSession{
+					DR:            3,
+					NbTrans:       1,
+					UplinkHistory: fullHistory(-13, 0),
+				},
+				MaxDR:            5,
+				RequiredSNRForDR: -12.5,
+			},
+			ExpectedResponse: HandleResponse{DR: 2, TXPowerIndex: 0, NbTrans: 1},
+		},
+		{
+			Name: "negative margin, data-rate is not decreased below min dr",
+			Request: HandleRequest{
+				DeviceSession: storage.DeviceSession{
+					DR:            2,
+					NbTrans:       1,
+					UplinkHistory: fullHistory(-20, 0),
+				},
+				MinDR:            2,
+				MaxDR:            5,
+				RequiredSNRForDR: -15,
+			},
+			ExpectedResponse: HandleResponse{DR: 2, TXPowerIndex: 0, NbTrans: 1},
+		},
+		{
+			Name: "tx-power is reset to max. tx-power",
+			Request: HandleRequest{
+				DeviceSession: storage.DeviceSession{
+					DR:            5,
+					TXPowerIndex:  3,
+					NbTrans:       1,
+					UplinkHistory: fullHistory(0, 3),
+				},
+				MaxDR:            5,
+				RequiredSNRForDR: -7.5,
+			},
+			ExpectedResponse: HandleResponse{DR: 5, TXPowerIndex: 0, NbTrans: 1},
+		},
+		{
+			Name: "data-rate exceeds max dr",
+			Request: HandleRequest{
+				DeviceSession: storage.DeviceSession{
+					DR:      5,
+					NbTrans: 1,
+				},
+				MaxDR:            3,
+				RequiredSNRForDR: -7.5,
+			},
+			ExpectedResponse: HandleResponse{DR: 3, TXPowerIndex: 0, NbTrans: 1},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var h conservativeHandler
+			resp, err := h.Handle(context.Background(), tst.Request)
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedResponse, resp)
+		})
+	}
+}
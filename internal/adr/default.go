@@ -0,0 +1,80 @@
+package adr
+
+import (
+	"context"
+
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+)
+
+// DefaultHandlerID contains the ID of the default ADR algorithm.
+const DefaultHandlerID = "default"
+
+// defaultHandler implements the default ADR algorithm. It uses the max.
+// SNR of the uplink history to increase the data-rate and to decrease the
+// tx-power. It will never decrease the data-rate.
+type defaultHandler struct{}
+
+func (h *defaultHandler) ID() string {
+	return DefaultHandlerID
+}
+
+func (h *defaultHandler) Name() string {
+	return "Default ADR algorithm (LoRa only)"
+}
+
+func (h *defaultHandler) Handle(ctx context.Context, req HandleRequest) (HandleResponse, error) {
+	ds := req.DeviceSession
+	resp := HandleResponse{
+		DR:           ds.DR,
+		TXPowerIndex: ds.TXPowerIndex,
+		NbTrans:      int(ds.NbTrans),
+	}
+
+	// get the max SNR from the UplinkHistory
+	var snrM float64 = -999
+	var historyCount int
+	for _, uh := range ds.UplinkHistory {
+		if uh.TXPowerIndex == ds.TXPowerIndex {
+			historyCount++
+
+			if uh.MaxSNR > snrM {
+				snrM = uh.MaxSNR
+			}
+		}
+	}
+
+	snrMargin := snrM - req.RequiredSNRForDR - req.InstallationMargin
+	nStep := int(snrMargin / 3)
+
+	// In case of negative steps the ADR algorithm will increase the TXPower
+	// if possible. To avoid up / down / up / down TXPower changes, wait until
+	// we have a full history table before making adjustments.
+	if nStep < 0 && historyCount != storage.UplinkHistorySize {
+		return resp, nil
+	}
+
+	var idealNbRep uint8
+	if req.ServiceProfile.TargetPER > 0 {
+		idealNbRep = getNbRepForTargetPER(ds.NbTrans, ds.GetPacketLossPercentage(), req.ServiceProfile.TargetPER)
+
+		// In case the target PER can only be met by re-transmissions, do not
+		// increase the data-rate or decrease the tx-power as this would
+		// further increase the packet error rate.
+		if idealNbRep > 1 && nStep > 0 {
+			nStep = 0
+		}
+	} else {
+		idealNbRep = getNbRep(ds.NbTrans, ds.GetPacketLossPercentage())
+	}
+	resp.NbTrans = int(idealNbRep)
+
+	if ds.DR > req.MaxDR {
+		resp.DR = req.MaxDR
+	} else if ds.DR < req.MinDR {
+		resp.DR = req.MinDR
+	} else {
+		resp.TXPowerIndex, resp.DR = getIdealTXPowerOffsetAndDR(nStep, ds.TXPowerIndex, ds.DR, ds.MinSupportedTXPowerIndex, req.MaxTXPowerIndex, req.MaxDR)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,53 @@
+package adr
+
+import (
+	"context"
+
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+)
+
+// Handler defines the interface of an ADR algorithm.
+type Handler interface {
+	// ID returns the unique identifier of the ADR algorithm. This is the
+	// value that is stored in the device-profile.
+	ID() string
+
+	// Name returns the human-readable name of the ADR algorithm.
+	Name() string
+
+	// Handle returns the desired data-rate, tx-power index and nb-trans
+	// for the given request.
+	Handle(ctx context.Context, req HandleRequest) (HandleResponse, error)
+}
+
+// HandleRequest contains the input of an ADR algorithm.
+type HandleRequest struct {
+	DeviceProfile  storage.DeviceProfile
+	ServiceProfile storage.ServiceProfile
+
+	// DeviceSession contains the uplink history and the current data-rate,
+	// tx-power index and nb-trans of the device.
+	DeviceSession storage.DeviceSession
+
+	// MinDR and MaxDR contain the data-rate range allowed by the
+	// service-profile.
+	MinDR int
+	MaxDR int
+
+	// MaxTXPowerIndex contains the max. tx-power index supported by the
+	// device.
+	MaxTXPowerIndex int
+
+	// RequiredSNRForDR contains the required SNR for the current data-rate.
+	RequiredSNRForDR float64
+
+	// InstallationMargin contains the configured installation-margin.
+	InstallationMargin float64
+}
+
+// HandleResponse contains the output of an ADR algorithm.
+type HandleResponse struct {
+	DR           int
+	TXPowerIndex int
+	NbTrans      int
+}
@@ -0,0 +1,140 @@
+package adr
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	adrapi "github.com/brocaar/chirpstack-network-server/api/adr"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/tls"
+)
+
+// defaultPluginTimeout defines the ADR plugin request timeout, in case it
+// has not been configured.
+const defaultPluginTimeout = 200 * time.Millisecond
+
+// maxNbTrans defines the max. NbTrans value that can be set using the
+// LinkADRReq mac-command.
+const maxNbTrans = 15
+
+// pluginHandler implements an ADR algorithm by delegating the ADR decision
+// to an external ADR plugin (gRPC service).
+type pluginHandler struct {
+	id      string
+	name    string
+	timeout time.Duration
+	client  adrapi.ADRPluginServiceClient
+}
+
+// newPluginHandler creates a new ADR plugin handler, connecting to the given
+// server.
+func newPluginHandler(id, name, server, caCert, tlsCert, tlsKey string, timeout time.Duration) (*pluginHandler, error) {
+	if id == "" {
+		return nil, errors.New("adr plugin id must not be empty")
+	}
+
+	log.WithFields(log.Fields{
+		"id":       id,
+		"server":   server,
+		"ca_cert":  caCert,
+		"tls_cert": tlsCert,
+		"tls_key":  tlsKey,
+	}).Info("adr: connecting to adr plugin")
+
+	var dialOptions []grpc.DialOption
+	if tlsCert != "" && tlsKey != "" {
+		creds, err := tls.GetTransportCredentials(caCert, tlsCert, tlsKey, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "get transport credentials error")
+		}
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(creds))
+	} else {
+		dialOptions = append(dialOptions, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(server, dialOptions...)
+	if err != nil {
+		return nil, errors.Wrap(err, "adr plugin dial error")
+	}
+
+	if timeout == 0 {
+		timeout = defaultPluginTimeout
+	}
+
+	return &pluginHandler{
+		id:      id,
+		name:    name,
+		timeout: timeout,
+		client:  adrapi.NewADRPluginServiceClient(conn),
+	}, nil
+}
+
+func (h *pluginHandler) ID() string {
+	return h.id
+}
+
+func (h *pluginHandler) Name() string {
+	return h.name
+}
+
+func (h *pluginHandler) Handle(ctx context.Context, req HandleRequest) (HandleResponse, error) {
+	ds := req.DeviceSession
+
+	pluginReq := adrapi.HandleRequest{
+		DevEui:               ds.DevEUI[:],
+		DeviceProfileId:      req.DeviceProfile.ID.Bytes(),
+		ServiceProfileId:     req.ServiceProfile.ID.Bytes(),
+		Dr:                   uint32(ds.DR),
+		TxPowerIndex:         uint32(ds.TXPowerIndex),
+		NbTrans:              uint32(ds.NbTrans),
+		MinDr:                uint32(req.MinDR),
+		MaxDr:                uint32(req.MaxDR),
+		MinTxPowerIndex:      uint32(ds.MinSupportedTXPowerIndex),
+		MaxTxPowerIndex:      uint32(req.MaxTXPowerIndex),
+		TargetPer:            uint32(req.ServiceProfile.TargetPER),
+		RequiredSnrForDr:     req.RequiredSNRForDR,
+		InstallationMargin:   req.InstallationMargin,
+		PacketLossPercentage: ds.GetPacketLossPercentage(),
+	}
+
+	for _, uh := range ds.UplinkHistory {
+		pluginReq.UplinkHistory = append(pluginReq.UplinkHistory, &adrapi.UplinkHistory{
+			FCnt:         uh.FCnt,
+			MaxSnr:       uh.MaxSNR,
+			SnrMargin:    uh.MaxSNR - req.RequiredSNRForDR - req.InstallationMargin,
+			TxPowerIndex: uint32(uh.TXPowerIndex),
+			GatewayCount: uint32(uh.GatewayCount),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	resp, err := h.client.Handle(ctx, &pluginReq)
+	if err != nil {
+		return HandleResponse{}, errors.Wrap(err, "adr plugin request error")
+	}
+
+	if _, err := band.Band().GetDataRate(int(resp.Dr)); err != nil {
+		return HandleResponse{}, errors.Wrapf(err, "adr plugin returned invalid data-rate %d", resp.Dr)
+	}
+
+	if _, err := band.Band().GetTXPowerOffset(int(resp.TxPowerIndex)); err != nil {
+		return HandleResponse{}, errors.Wrapf(err, "adr plugin returned invalid tx-power index %d", resp.TxPowerIndex)
+	}
+
+	if resp.NbTrans < 1 || resp.NbTrans > maxNbTrans {
+		return HandleResponse{}, fmt.Errorf("adr plugin returned invalid nb-trans %d", resp.NbTrans)
+	}
+
+	return HandleResponse{
+		DR:           int(resp.Dr),
+		TXPowerIndex: int(resp.TxPowerIndex),
+		NbTrans:      int(resp.NbTrans),
+	}, nil
+}
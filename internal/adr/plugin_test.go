@@ -0,0 +1,145 @@
+package adr
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	adrapi "github.com/brocaar/chirpstack-network-server/api/adr"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+)
+
+type testPluginServer struct {
+	request  adrapi.HandleRequest
+	response adrapi.HandleResponse
+}
+
+func (s *testPluginServer) Handle(ctx context.Context, req *adrapi.HandleRequest) (*adrapi.HandleResponse, error) {
+	s.request = *req
+	return &s.response, nil
+}
+
+func TestPluginHandler(t *testing.T) {
+	assert := require.New(t)
+
+	assert.NoError(Setup(test.GetConfig()))
+
+	srv := testPluginServer{}
+	gs := grpc.NewServer()
+	adrapi.RegisterADRPluginServiceServer(gs, &srv)
+	ln, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(err)
+	go gs.Serve(ln)
+	defer gs.Stop()
+
+	h, err := newPluginHandler("test", "Test plugin", ln.Addr().String(), "", "", "", 0)
+	assert.NoError(err)
+	assert.Equal("test", h.ID())
+	assert.Equal("Test plugin", h.Name())
+
+	req := HandleRequest{
+		DeviceSession: storage.DeviceSession{
+			DevEUI:       [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			DR:           2,
+			TXPowerIndex: 1,
+			NbTrans:      1,
+			UplinkHistory: []storage.UplinkHistory{
+				{FCnt: 10, MaxSNR: -5, TXPowerIndex: 1, GatewayCount: 2},
+			},
+		},
+		MinDR:              1,
+		MaxDR:              5,
+		MaxTXPowerIndex:    7,
+		RequiredSNRForDR:   -15,
+		InstallationMargin: 5,
+	}
+
+	t.Run("valid response", func(t *testing.T) {
+		assert := require.New(t)
+		srv.response = adrapi.HandleResponse{Dr: 3, TxPowerIndex: 2, NbTrans: 2}
+
+		resp, err := h.Handle(context.Background(), req)
+		assert.NoError(err)
+		assert.Equal(HandleResponse{DR: 3, TXPowerIndex: 2, NbTrans: 2}, resp)
+
+		assert.Equal([]byte{1, 2, 3, 4, 5, 6, 7, 8}, srv.request.DevEui)
+		assert.EqualValues(2, srv.request.Dr)
+		assert.EqualValues(1, srv.request.TxPowerIndex)
+		assert.EqualValues(1, srv.request.MinDr)
+		assert.EqualValues(5, srv.request.MaxDr)
+		assert.EqualValues(7, srv.request.MaxTxPowerIndex)
+		assert.Equal([]*adrapi.UplinkHistory{
+			{FCnt: 10, MaxSnr: -5, SnrMargin: 5, TxPowerIndex: 1, GatewayCount: 2},
+		}, srv.request.UplinkHistory)
+	})
+
+	t.Run("invalid data-rate", func(t *testing.T) {
+		assert := require.New(t)
+		srv.response = adrapi.HandleResponse{Dr: 20, NbTrans: 1}
+
+		_, err := h.Handle(context.Background(), req)
+		assert.Error(err)
+	})
+
+	t.Run("invalid nb-trans", func(t *testing.T) {
+		assert := require.New(t)
+		srv.response = adrapi.HandleResponse{Dr: 3}
+
+		_, err := h.Handle(context.Background(), req)
+		assert.Error(err)
+	})
+}
+
+func TestApplyLimits(t *testing.T) {
+	req := HandleRequest{
+		DeviceSession: storage.DeviceSession{
+			MinSupportedTXPowerIndex: 1,
+		},
+		MinDR:           1,
+		MaxDR:           4,
+		MaxTXPowerIndex: 5,
+	}
+
+	tests := []struct {
+		Name     string
+		Response HandleResponse
+		Expected HandleResponse
+	}{
+		{
+			Name:     "within limits",
+			Response: HandleResponse{DR: 3, TXPowerIndex: 2, NbTrans: 1},
+			Expected: HandleResponse{DR: 3, TXPowerIndex: 2, NbTrans: 1},
+		},
+		{
+			Name:     "dr above max dr",
+			Response: HandleResponse{DR: 5, TXPowerIndex: 2, NbTrans: 1},
+			Expected: HandleResponse{DR: 4, TXPowerIndex: 2, NbTrans: 1},
+		},
+		{
+			Name:     "dr below min dr",
+			Response: HandleResponse{DR: 0, TXPowerIndex: 2, NbTrans: 1},
+			Expected: HandleResponse{DR: 1, TXPowerIndex: 2, NbTrans: 1},
+		},
+		{
+			Name:     "tx-power index above max",
+			Response: HandleResponse{DR: 3, TXPowerIndex: 7, NbTrans: 1},
+			Expected: HandleResponse{DR: 3, TXPowerIndex: 5, NbTrans: 1},
+		},
+		{
+			Name:     "tx-power index below min supported",
+			Response: HandleResponse{DR: 3, TXPowerIndex: 0, NbTrans: 1},
+			Expected: HandleResponse{DR: 3, TXPowerIndex: 1, NbTrans: 1},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Expected, applyLimits(req, tst.Response))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/brocaar/chirpstack-network-server/internal/downlink/data"
 	"github.com/brocaar/chirpstack-network-server/internal/downlink/multicast"
 	"github.com/brocaar/chirpstack-network-server/internal/downlink/proprietary"
+	"github.com/brocaar/chirpstack-network-server/internal/gateway"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 )
 
@@ -20,7 +21,10 @@ var errToCode = map[error]codes.Code{
 
 	proprietary.ErrInvalidDataRate: codes.Internal,
 
-	multicast.ErrInvalidFCnt: codes.InvalidArgument,
+	multicast.ErrInvalidFCnt:  codes.InvalidArgument,
+	multicast.ErrFragmentSize: codes.InvalidArgument,
+
+	gateway.ErrCommandExecTimeout: codes.DeadlineExceeded,
 
 	storage.ErrAlreadyExists:                  codes.AlreadyExists,
 	storage.ErrDoesNotExistOrFCntOrMICInvalid: codes.NotFound,
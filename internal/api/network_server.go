@@ -13,13 +13,17 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/brocaar/chirpstack-network-server/api/common"
+	"github.com/brocaar/chirpstack-network-server/api/gw"
 	"github.com/brocaar/chirpstack-network-server/api/ns"
+	"github.com/brocaar/chirpstack-network-server/internal/adr"
 	"github.com/brocaar/chirpstack-network-server/internal/band"
 	"github.com/brocaar/chirpstack-network-server/internal/config"
 	"github.com/brocaar/chirpstack-network-server/internal/downlink/data/classb"
 	"github.com/brocaar/chirpstack-network-server/internal/downlink/multicast"
 	proprietarydown "github.com/brocaar/chirpstack-network-server/internal/downlink/proprietary"
 	"github.com/brocaar/chirpstack-network-server/internal/framelog"
+	"github.com/brocaar/chirpstack-network-server/internal/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/gateway/monitor"
 	"github.com/brocaar/chirpstack-network-server/internal/gps"
 	"github.com/brocaar/chirpstack-network-server/internal/helpers"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
@@ -70,6 +74,10 @@ func (n *NetworkServerAPI) CreateServiceProfile(ctx context.Context, req *ns.Cre
 		NwkGeoLoc:              req.ServiceProfile.NwkGeoLoc,
 		TargetPER:              int(req.ServiceProfile.TargetPer),
 		MinGWDiversity:         int(req.ServiceProfile.MinGwDiversity),
+		SPULRate:               int(req.ServiceProfile.SpUlRate),
+		SPULBucketSize:         int(req.ServiceProfile.SpUlBucketSize),
+		SPDLRate:               int(req.ServiceProfile.SpDlRate),
+		SPDLBucketSize:         int(req.ServiceProfile.SpDlBucketSize),
 	}
 
 	switch req.ServiceProfile.UlRatePolicy {
@@ -86,6 +94,13 @@ func (n *NetworkServerAPI) CreateServiceProfile(ctx context.Context, req *ns.Cre
 		sp.DLRatePolicy = storage.Drop
 	}
 
+	switch req.ServiceProfile.MinGwDiversityPolicy {
+	case ns.RatePolicy_MARK:
+		sp.MinGWDiversityPolicy = storage.Mark
+	case ns.RatePolicy_DROP:
+		sp.MinGWDiversityPolicy = storage.Drop
+	}
+
 	if err := storage.CreateServiceProfile(ctx, storage.DB(), &sp); err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -125,6 +140,10 @@ func (n *NetworkServerAPI) GetServiceProfile(ctx context.Context, req *ns.GetSer
 			NwkGeoLoc:              sp.NwkGeoLoc,
 			TargetPer:              uint32(sp.TargetPER),
 			MinGwDiversity:         uint32(sp.MinGWDiversity),
+			SpUlRate:               uint32(sp.SPULRate),
+			SpUlBucketSize:         uint32(sp.SPULBucketSize),
+			SpDlRate:               uint32(sp.SPDLRate),
+			SpDlBucketSize:         uint32(sp.SPDLBucketSize),
 		},
 	}
 
@@ -152,6 +171,13 @@ func (n *NetworkServerAPI) GetServiceProfile(ctx context.Context, req *ns.GetSer
 		resp.ServiceProfile.DlRatePolicy = ns.RatePolicy_DROP
 	}
 
+	switch sp.MinGWDiversityPolicy {
+	case storage.Mark:
+		resp.ServiceProfile.MinGwDiversityPolicy = ns.RatePolicy_MARK
+	case storage.Drop:
+		resp.ServiceProfile.MinGwDiversityPolicy = ns.RatePolicy_DROP
+	}
+
 	return &resp, nil
 }
 
@@ -186,6 +212,10 @@ func (n *NetworkServerAPI) UpdateServiceProfile(ctx context.Context, req *ns.Upd
 	sp.NwkGeoLoc = req.ServiceProfile.NwkGeoLoc
 	sp.TargetPER = int(req.ServiceProfile.TargetPer)
 	sp.MinGWDiversity = int(req.ServiceProfile.MinGwDiversity)
+	sp.SPULRate = int(req.ServiceProfile.SpUlRate)
+	sp.SPULBucketSize = int(req.ServiceProfile.SpUlBucketSize)
+	sp.SPDLRate = int(req.ServiceProfile.SpDlRate)
+	sp.SPDLBucketSize = int(req.ServiceProfile.SpDlBucketSize)
 
 	switch req.ServiceProfile.UlRatePolicy {
 	case ns.RatePolicy_MARK:
@@ -201,6 +231,13 @@ func (n *NetworkServerAPI) UpdateServiceProfile(ctx context.Context, req *ns.Upd
 		sp.DLRatePolicy = storage.Drop
 	}
 
+	switch req.ServiceProfile.MinGwDiversityPolicy {
+	case ns.RatePolicy_MARK:
+		sp.MinGWDiversityPolicy = storage.Mark
+	case ns.RatePolicy_DROP:
+		sp.MinGWDiversityPolicy = storage.Drop
+	}
+
 	if err := storage.FlushServiceProfileCache(ctx, storage.RedisPool(), sp.ID); err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -337,6 +374,10 @@ func (n *NetworkServerAPI) CreateDeviceProfile(ctx context.Context, req *ns.Crea
 		return nil, grpc.Errorf(codes.InvalidArgument, "device_profile must not be nil")
 	}
 
+	if !adr.HandlerExists(req.DeviceProfile.AdrAlgorithmId) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "unknown adr_algorithm_id: %s", req.DeviceProfile.AdrAlgorithmId)
+	}
+
 	var dpID uuid.UUID
 	copy(dpID[:], req.DeviceProfile.Id)
 
@@ -368,6 +409,7 @@ func (n *NetworkServerAPI) CreateDeviceProfile(ctx context.Context, req *ns.Crea
 		RFRegion:            band.Band().Name(),
 		GeolocBufferTTL:     int(req.DeviceProfile.GeolocBufferTtl),
 		GeolocMinBufferSize: int(req.DeviceProfile.GeolocMinBufferSize),
+		ADRAlgorithmID:      req.DeviceProfile.AdrAlgorithmId,
 	}
 
 	if err := storage.CreateDeviceProfile(ctx, storage.DB(), &dp); err != nil {
@@ -418,6 +460,7 @@ func (n *NetworkServerAPI) GetDeviceProfile(ctx context.Context, req *ns.GetDevi
 			Supports_32BitFCnt:  dp.Supports32bitFCnt,
 			GeolocBufferTtl:     uint32(dp.GeolocBufferTTL),
 			GeolocMinBufferSize: uint32(dp.GeolocMinBufferSize),
+			AdrAlgorithmId:      dp.ADRAlgorithmID,
 		},
 	}
 
@@ -441,6 +484,10 @@ func (n *NetworkServerAPI) UpdateDeviceProfile(ctx context.Context, req *ns.Upda
 		return nil, grpc.Errorf(codes.InvalidArgument, "device_profile must not be nil")
 	}
 
+	if !adr.HandlerExists(req.DeviceProfile.AdrAlgorithmId) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "unknown adr_algorithm_id: %s", req.DeviceProfile.AdrAlgorithmId)
+	}
+
 	var dpID uuid.UUID
 	copy(dpID[:], req.DeviceProfile.Id)
 
@@ -475,6 +522,7 @@ func (n *NetworkServerAPI) UpdateDeviceProfile(ctx context.Context, req *ns.Upda
 	dp.RFRegion = band.Band().Name()
 	dp.GeolocBufferTTL = int(req.DeviceProfile.GeolocBufferTtl)
 	dp.GeolocMinBufferSize = int(req.DeviceProfile.GeolocMinBufferSize)
+	dp.ADRAlgorithmID = req.DeviceProfile.AdrAlgorithmId
 
 	if err := storage.FlushDeviceProfileCache(ctx, storage.RedisPool(), dp.ID); err != nil {
 		return nil, errToRPCError(err)
@@ -737,6 +785,53 @@ func (n *NetworkServerAPI) GetDeviceActivation(ctx context.Context, req *ns.GetD
 	}, nil
 }
 
+// GetDeviceStatusHistory returns the device-status (DevStatusAns and
+// LinkCheckReq) history for the given DevEUI.
+func (n *NetworkServerAPI) GetDeviceStatusHistory(ctx context.Context, req *ns.GetDeviceStatusHistoryRequest) (*ns.GetDeviceStatusHistoryResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	start, err := ptypes.Timestamp(req.StartTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	end, err := ptypes.Timestamp(req.EndTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	items, err := storage.GetDeviceStatusHistory(ctx, storage.RedisPool(), devEUI, start, end)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.GetDeviceStatusHistoryResponse
+
+	for _, item := range items {
+		row := ns.DeviceStatusHistoryItem{
+			Battery:      uint32(item.Battery),
+			Margin:       int32(item.Margin),
+			GatewayCount: uint32(item.GatewayCount),
+		}
+
+		if item.Type == storage.DeviceStatusLinkCheck {
+			row.Type = ns.DeviceStatusType_LINK_CHECK
+		} else {
+			row.Type = ns.DeviceStatusType_DEV_STATUS
+		}
+
+		row.Time, err = ptypes.TimestampProto(item.Time)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		resp.Result = append(resp.Result, &row)
+	}
+
+	return &resp, nil
+}
+
 // GetRandomDevAddr returns a random DevAddr.
 func (n *NetworkServerAPI) GetRandomDevAddr(ctx context.Context, req *empty.Empty) (*ns.GetRandomDevAddrResponse, error) {
 	devAddr, err := storage.GetRandomDevAddr(config.C.NetworkServer.NetID)
@@ -814,6 +909,7 @@ func (n *NetworkServerAPI) CreateGateway(ctx context.Context, req *ns.CreateGate
 			Longitude: req.Gateway.Location.Longitude,
 		},
 		Altitude: req.Gateway.Location.Altitude,
+		Backend:  req.Gateway.Backend,
 	}
 
 	// Gateway ID
@@ -879,6 +975,7 @@ func (n *NetworkServerAPI) GetGateway(ctx context.Context, req *ns.GetGatewayReq
 				Longitude: gw.Location.Longitude,
 				Altitude:  gw.Altitude,
 			},
+			Backend: gw.Backend,
 		},
 	}
 
@@ -897,6 +994,11 @@ func (n *NetworkServerAPI) GetGateway(ctx context.Context, req *ns.GetGatewayReq
 		resp.LastSeenAt, _ = ptypes.TimestampProto(*gw.LastSeenAt)
 	}
 
+	resp.State = monitor.GatewayStateToPB(gw.State)
+	if gw.StateChangedAt != nil {
+		resp.StateChangedAt, _ = ptypes.TimestampProto(*gw.StateChangedAt)
+	}
+
 	for i := range gw.Boards {
 		var gwBoard ns.GatewayBoard
 		if gw.Boards[i].FPGAID != nil {
@@ -948,6 +1050,7 @@ func (n *NetworkServerAPI) UpdateGateway(ctx context.Context, req *ns.UpdateGate
 		Longitude: req.Gateway.Location.Longitude,
 	}
 	gw.Altitude = req.Gateway.Location.Altitude
+	gw.Backend = req.Gateway.Backend
 
 	gw.Boards = nil
 	for _, board := range req.Gateway.Boards {
@@ -1041,6 +1144,40 @@ func (n *NetworkServerAPI) GetGatewayStats(ctx context.Context, req *ns.GetGatew
 	return &resp, nil
 }
 
+// ExecGatewayCommand executes the given command on the gateway and returns
+// the command output.
+func (n *NetworkServerAPI) ExecGatewayCommand(ctx context.Context, req *ns.ExecGatewayCommandRequest) (*ns.ExecGatewayCommandResponse, error) {
+	if req.Command == "" {
+		return nil, grpc.Errorf(codes.InvalidArgument, "command must not be empty")
+	}
+
+	execID, err := uuid.NewV4()
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.C.NetworkServer.Gateway.CommandExecTimeout)
+	defer cancel()
+
+	resp, err := gateway.ExecCommand(ctx, storage.RedisPool(), gw.GatewayCommandExecRequest{
+		GatewayId:   req.GatewayId,
+		Command:     req.Command,
+		ExecId:      execID.Bytes(),
+		Stdin:       req.Stdin,
+		Environment: req.Environment,
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.ExecGatewayCommandResponse{
+		ExecId: resp.ExecId,
+		Stdout: resp.Stdout,
+		Stderr: resp.Stderr,
+		Error:  resp.Error,
+	}, nil
+}
+
 // StreamFrameLogsForGateway returns a stream of frames seen by the given gateway.
 func (n *NetworkServerAPI) StreamFrameLogsForGateway(req *ns.StreamFrameLogsForGatewayRequest, srv ns.NetworkServerService_StreamFrameLogsForGatewayServer) error {
 	frameLogChan := make(chan framelog.FrameLog)
@@ -1125,7 +1262,16 @@ func (n *NetworkServerAPI) CreateGatewayProfile(ctx context.Context, req *ns.Cre
 	copy(gpID[:], req.GatewayProfile.Id)
 
 	gc := storage.GatewayProfile{
-		ID: gpID,
+		ID:            gpID,
+		BeaconEnabled: req.GatewayProfile.BeaconEnabled,
+	}
+
+	if req.GatewayProfile.StatsInterval != nil {
+		var err error
+		gc.StatsInterval, err = ptypes.Duration(req.GatewayProfile.StatsInterval)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
 	}
 
 	for _, c := range req.GatewayProfile.Channels {
@@ -1175,10 +1321,15 @@ func (n *NetworkServerAPI) GetGatewayProfile(ctx context.Context, req *ns.GetGat
 
 	out := ns.GetGatewayProfileResponse{
 		GatewayProfile: &ns.GatewayProfile{
-			Id: gc.ID.Bytes(),
+			Id:            gc.ID.Bytes(),
+			BeaconEnabled: gc.BeaconEnabled,
 		},
 	}
 
+	if gc.StatsInterval != 0 {
+		out.GatewayProfile.StatsInterval = ptypes.DurationProto(gc.StatsInterval)
+	}
+
 	out.CreatedAt, err = ptypes.TimestampProto(gc.CreatedAt)
 	if err != nil {
 		return nil, errToRPCError(err)
@@ -1231,6 +1382,15 @@ func (n *NetworkServerAPI) UpdateGatewayProfile(ctx context.Context, req *ns.Upd
 		return nil, errToRPCError(err)
 	}
 
+	gc.BeaconEnabled = req.GatewayProfile.BeaconEnabled
+	gc.StatsInterval = 0
+	if req.GatewayProfile.StatsInterval != nil {
+		gc.StatsInterval, err = ptypes.Duration(req.GatewayProfile.StatsInterval)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+
 	gc.Channels = []int64{}
 	for _, c := range req.GatewayProfile.Channels {
 		gc.Channels = append(gc.Channels, int64(c))
@@ -1286,8 +1446,32 @@ func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.Cr
 		return nil, grpc.Errorf(codes.InvalidArgument, "item must not be nil")
 	}
 
+	if _, err := createDeviceQueueItem(ctx, storage.DB(), req.Item); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// downlinkRateLimitToken holds a downlink rate-limit token which was taken
+// on enqueue, so that it can be returned when the enqueue is rolled back.
+type downlinkRateLimitToken struct {
+	sp     storage.ServiceProfile
+	devEUI lorawan.EUI64
+}
+
+// giveBack returns the token to the downlink rate-limit bucket.
+func (t downlinkRateLimitToken) giveBack(ctx context.Context) {
+	if err := storage.ReturnDownlinkRateLimitToken(ctx, storage.RedisPool(), t.sp, t.devEUI); err != nil {
+		log.WithError(err).WithField("dev_eui", t.devEUI).Error("return downlink rate-limit token error")
+	}
+}
+
+// createDeviceQueueItem adds the given item to the device-queue. It returns
+// the taken downlink rate-limit token (if any) and an RPC error.
+func createDeviceQueueItem(ctx context.Context, db sqlx.Queryer, item *ns.DeviceQueueItem) (*downlinkRateLimitToken, error) {
 	var devEUI lorawan.EUI64
-	copy(devEUI[:], req.Item.DevEui)
+	copy(devEUI[:], item.DevEui)
 
 	d, err := storage.GetDevice(ctx, storage.DB(), devEUI)
 	if err != nil {
@@ -1305,19 +1489,27 @@ func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.Cr
 	}
 
 	var devAddr lorawan.DevAddr
-	copy(devAddr[:], req.Item.DevAddr)
+	copy(devAddr[:], item.DevAddr)
 
 	if (devAddr != lorawan.DevAddr{0, 0, 0, 0} && ds.DevAddr != devAddr) {
 		return nil, grpc.Errorf(codes.InvalidArgument, "device security-context out of sync")
 	}
 
+	// With the Drop rate-policy, the downlink rate-limit is enforced on
+	// enqueue. With the Mark rate-policy, the item is held back by the
+	// downlink scheduling until the rate-limit allows it to be sent.
+	sp, err := storage.GetAndCacheServiceProfile(ctx, storage.DB(), storage.RedisPool(), d.ServiceProfileID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
 	qi := storage.DeviceQueueItem{
 		DevAddr:    devAddr,
 		DevEUI:     d.DevEUI,
-		FRMPayload: req.Item.FrmPayload,
-		FCnt:       req.Item.FCnt,
-		FPort:      uint8(req.Item.FPort),
-		Confirmed:  req.Item.Confirmed,
+		FRMPayload: item.FrmPayload,
+		FCnt:       item.FCnt,
+		FPort:      uint8(item.FPort),
+		Confirmed:  item.Confirmed,
 	}
 
 	// When the device is operating in Class-B and has a beacon lock, calculate
@@ -1325,7 +1517,7 @@ func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.Cr
 	if dp.SupportsClassB {
 		// check if device is currently active and is operating in Class-B mode
 		if err == nil && ds.BeaconLocked {
-			scheduleAfterGPSEpochTS, err := storage.GetMaxEmitAtTimeSinceGPSEpochForDevEUI(ctx, storage.DB(), d.DevEUI)
+			scheduleAfterGPSEpochTS, err := storage.GetMaxEmitAtTimeSinceGPSEpochForDevEUI(ctx, db, d.DevEUI)
 			if err != nil {
 				return nil, errToRPCError(err)
 			}
@@ -1348,12 +1540,30 @@ func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.Cr
 		}
 	}
 
-	err = storage.CreateDeviceQueueItem(ctx, storage.DB(), &qi)
+	// the token is taken after all other checks, so that it is not taken
+	// for an item that is rejected
+	var token *downlinkRateLimitToken
+	if sp.DLRatePolicy != storage.Mark {
+		ok, err := storage.TakeDownlinkRateLimitToken(ctx, storage.RedisPool(), sp, d.DevEUI)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		if !ok {
+			return nil, grpc.Errorf(codes.ResourceExhausted, "downlink rate-limit exceeded")
+		}
+		token = &downlinkRateLimitToken{sp: sp, devEUI: d.DevEUI}
+	}
+
+	err = storage.CreateDeviceQueueItem(ctx, db, &qi)
 	if err != nil {
+		// the item was not enqueued, return the rate-limit token
+		if token != nil {
+			token.giveBack(ctx)
+		}
 		return nil, errToRPCError(err)
 	}
 
-	return &empty.Empty{}, nil
+	return token, nil
 }
 
 // FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
@@ -1666,6 +1876,405 @@ func (n *NetworkServerAPI) GetMulticastQueueItemsForMulticastGroup(ctx context.C
 	return &out, nil
 }
 
+// GetMulticastQueueItemDeliveryReport returns the delivery report (per gateway) of the multicast queue-item given a multicast-group id and frame-counter.
+func (n *NetworkServerAPI) GetMulticastQueueItemDeliveryReport(ctx context.Context, req *ns.GetMulticastQueueItemDeliveryReportRequest) (*ns.GetMulticastQueueItemDeliveryReportResponse, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	deliveries, err := storage.GetMulticastQueueItemDeliveries(ctx, storage.DB(), mgID, req.FCnt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out ns.GetMulticastQueueItemDeliveryReportResponse
+	for _, d := range deliveries {
+		delivery := ns.MulticastQueueItemDelivery{
+			GatewayId: d.GatewayID[:],
+			Error:     d.Error,
+		}
+
+		switch d.Status {
+		case storage.MulticastDeliverySuccess:
+			delivery.Status = ns.MulticastDeliveryStatus_SUCCESS
+			out.GatewaysSucceeded++
+		case storage.MulticastDeliveryFailed:
+			delivery.Status = ns.MulticastDeliveryStatus_FAILED
+			out.GatewaysFailed++
+		default:
+			delivery.Status = ns.MulticastDeliveryStatus_PENDING
+		}
+
+		delivery.CreatedAt, err = ptypes.TimestampProto(d.CreatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		delivery.UpdatedAt, err = ptypes.TimestampProto(d.UpdatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		out.GatewaysAttempted++
+		out.Deliveries = append(out.Deliveries, &delivery)
+	}
+
+	return &out, nil
+}
+
+// GetMulticastGroupSetupCommands returns the (unencrypted) Remote Multicast
+// Setup commands for setting up the given multicast-group on a device.
+func (n *NetworkServerAPI) GetMulticastGroupSetupCommands(ctx context.Context, req *ns.GetMulticastGroupSetupCommandsRequest) (*ns.GetMulticastGroupSetupCommandsResponse, error) {
+	if req.McGroupId > 3 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "mc_group_id must be <= 3")
+	}
+	if req.SessionTimeOut > 15 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "session_time_out must be <= 15")
+	}
+	if len(req.McKeyEncrypted) != len(lorawan.AES128Key{}) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "mc_key_encrypted must be exactly 16 bytes")
+	}
+
+	var mgID uuid.UUID
+	var mcKeyEncrypted lorawan.AES128Key
+	copy(mgID[:], req.MulticastGroupId)
+	copy(mcKeyEncrypted[:], req.McKeyEncrypted)
+
+	mg, err := storage.GetMulticastGroup(ctx, storage.DB(), mgID, false)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	startAfter := time.Now()
+	if req.SessionStartAfter != nil {
+		ts, err := ptypes.Timestamp(req.SessionStartAfter)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+		if ts.After(startAfter) {
+			startAfter = ts
+		}
+	}
+	sessionTime := multicast.GetSessionTime(mg, startAfter)
+
+	groupSetupReq, err := multicast.GetMcGroupSetupReq(mg, uint8(req.McGroupId), mcKeyEncrypted, req.MaxMcFCnt).MarshalBinary()
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	sessionReqCmd, err := multicast.GetMcSessionReq(mg, uint8(req.McGroupId), sessionTime, uint8(req.SessionTimeOut))
+	if err != nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, err.Error())
+	}
+	sessionReq, err := sessionReqCmd.MarshalBinary()
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.GetMulticastGroupSetupCommandsResponse{
+		FPort:           multicast.SetupFPort,
+		McGroupSetupReq: groupSetupReq,
+		McSessionReq:    sessionReq,
+		SessionTime:     ptypes.DurationProto(sessionTime),
+	}, nil
+}
+
+// EnqueueMulticastGroupSetup enqueues the (encrypted) Remote Multicast Setup
+// commands for setting up the given multicast-group on the member devices
+// and tracks the setup state of each device.
+func (n *NetworkServerAPI) EnqueueMulticastGroupSetup(ctx context.Context, req *ns.EnqueueMulticastGroupSetupRequest) (*empty.Empty, error) {
+	if req.McGroupId > 3 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "mc_group_id must be <= 3")
+	}
+	if len(req.Items) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "items must not be empty")
+	}
+
+	sessionTime, err := ptypes.Duration(req.SessionTime)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	if _, err := storage.GetMulticastGroup(ctx, storage.DB(), mgID, false); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	devEUIs, err := storage.GetDevEUIsForMulticastGroup(ctx, storage.DB(), mgID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	members := make(map[lorawan.EUI64]struct{})
+	for _, devEUI := range devEUIs {
+		members[devEUI] = struct{}{}
+	}
+
+	// validate all items before enqueueing any of them
+	var setupDevEUIs []lorawan.EUI64
+	setupSeen := make(map[lorawan.EUI64]struct{})
+	for _, item := range req.Items {
+		if item == nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "item must not be nil")
+		}
+		if item.FPort != multicast.SetupFPort {
+			return nil, grpc.Errorf(codes.InvalidArgument, "f_port must be %d", multicast.SetupFPort)
+		}
+
+		var devEUI lorawan.EUI64
+		copy(devEUI[:], item.DevEui)
+
+		if _, ok := members[devEUI]; !ok {
+			return nil, grpc.Errorf(codes.FailedPrecondition, "device %s is not a member of the multicast-group", devEUI)
+		}
+
+		if _, ok := setupSeen[devEUI]; !ok {
+			setupSeen[devEUI] = struct{}{}
+			setupDevEUIs = append(setupDevEUIs, devEUI)
+		}
+	}
+
+	// the items are enqueued within a single transaction, so that either
+	// all or none of the devices receive the setup commands
+	var tokens []downlinkRateLimitToken
+	err = storage.Transaction(func(tx sqlx.Ext) error {
+		for _, item := range req.Items {
+			token, err := createDeviceQueueItem(ctx, tx, item)
+			if err != nil {
+				return err
+			}
+			if token != nil {
+				tokens = append(tokens, *token)
+			}
+		}
+
+		for _, devEUI := range setupDevEUIs {
+			if err := storage.SaveDeviceMulticastGroupSetup(ctx, tx, &storage.DeviceMulticastGroupSetup{
+				DevEUI:           devEUI,
+				MulticastGroupID: mgID,
+				McGroupID:        int(req.McGroupId),
+				SessionTime:      sessionTime,
+				State:            storage.MulticastSetupPending,
+			}); err != nil {
+				return errToRPCError(err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		// none of the items was enqueued, return the rate-limit tokens
+		for _, token := range tokens {
+			token.giveBack(ctx)
+		}
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetMulticastGroupSetupStatus returns the Remote Multicast Setup state of
+// each device of the given multicast-group.
+func (n *NetworkServerAPI) GetMulticastGroupSetupStatus(ctx context.Context, req *ns.GetMulticastGroupSetupStatusRequest) (*ns.GetMulticastGroupSetupStatusResponse, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	setups, err := storage.GetDeviceMulticastGroupSetupsForMulticastGroup(ctx, storage.DB(), mgID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out ns.GetMulticastGroupSetupStatusResponse
+	for _, s := range setups {
+		setup := ns.DeviceMulticastGroupSetup{
+			DevEui:      s.DevEUI[:],
+			McGroupId:   uint32(s.McGroupID),
+			Error:       s.Error,
+			SessionTime: ptypes.DurationProto(s.SessionTime),
+		}
+
+		switch s.State {
+		case storage.MulticastSetupGroupCreated:
+			setup.State = ns.MulticastSetupState_SETUP_GROUP_CREATED
+		case storage.MulticastSetupCompleted:
+			setup.State = ns.MulticastSetupState_SETUP_COMPLETED
+		case storage.MulticastSetupFailed:
+			setup.State = ns.MulticastSetupState_SETUP_FAILED
+		default:
+			setup.State = ns.MulticastSetupState_SETUP_PENDING
+		}
+
+		setup.CreatedAt, err = ptypes.TimestampProto(s.CreatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		setup.UpdatedAt, err = ptypes.TimestampProto(s.UpdatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		out.Devices = append(out.Devices, &setup)
+	}
+
+	return &out, nil
+}
+
+// HandleApplicationLayerUplink handles the (decrypted) uplink payload of an
+// application layer package.
+func (n *NetworkServerAPI) HandleApplicationLayerUplink(ctx context.Context, req *ns.HandleApplicationLayerUplinkRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	switch req.FPort {
+	case multicast.SetupFPort:
+		if err := multicast.HandleSetupUplink(ctx, storage.DB(), devEUI, req.FrmPayload); err != nil {
+			return nil, errToRPCError(err)
+		}
+	case uint32(multicast.FragmentationFPort):
+		if err := multicast.HandleFragmentationUplink(ctx, storage.DB(), devEUI, req.FrmPayload); err != nil {
+			return nil, errToRPCError(err)
+		}
+	default:
+		return nil, grpc.Errorf(codes.InvalidArgument, "unsupported f_port: %d", req.FPort)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetMulticastDataFragments fragments the given data block, adds the forward
+// error correction fragments and returns the (unencrypted) DataFragment and
+// FragSessionSetupReq commands.
+func (n *NetworkServerAPI) GetMulticastDataFragments(ctx context.Context, req *ns.GetMulticastDataFragmentsRequest) (*ns.GetMulticastDataFragmentsResponse, error) {
+	if req.McGroupId > 3 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "mc_group_id must be <= 3")
+	}
+	if req.BlockAckDelay > 7 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "block_ack_delay must be <= 7")
+	}
+	if len(req.FragSessionDescriptor) != 0 && len(req.FragSessionDescriptor) != 4 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "frag_session_descriptor must be exactly 4 bytes")
+	}
+
+	var mgID uuid.UUID
+	var descriptor [4]byte
+	copy(mgID[:], req.MulticastGroupId)
+	copy(descriptor[:], req.FragSessionDescriptor)
+
+	mg, err := storage.GetMulticastGroup(ctx, storage.DB(), mgID, false)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	block, err := multicast.GetDataFragments(req.Data, uint8(req.FragIndex), int(req.FragSize), int(req.Redundancy))
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if err := multicast.ValidateDataFragmentSize(mg, block.DataFragments); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	setupCmd, err := multicast.GetFragSessionSetupReq(uint8(req.FragIndex), uint8(req.McGroupId), int(req.FragSize), block, uint8(req.BlockAckDelay), descriptor)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	setupReq, err := setupCmd.MarshalBinary()
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.GetMulticastDataFragmentsResponse{
+		FPort:               uint32(multicast.FragmentationFPort),
+		FCnt:                mg.FCnt,
+		NbFrag:              uint32(block.NbFrag),
+		NbFragTotal:         uint32(len(block.DataFragments)),
+		Padding:             uint32(block.Padding),
+		FragSessionSetupReq: setupReq,
+		DataFragments:       block.DataFragments,
+	}, nil
+}
+
+// EnqueueMulticastFragmentedDataBlock enqueues the (encrypted) fragments for
+// the given multicast-group, using consecutive frame-counters and tracks the
+// fragmentation session status of each device.
+func (n *NetworkServerAPI) EnqueueMulticastFragmentedDataBlock(ctx context.Context, req *ns.EnqueueMulticastFragmentedDataBlockRequest) (*empty.Empty, error) {
+	if req.FragIndex > 3 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "frag_index must be <= 3")
+	}
+	if len(req.FrmPayloads) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "frm_payloads must not be empty")
+	}
+	if req.NbFrag == 0 || int(req.NbFrag) > len(req.FrmPayloads) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "nb_frag must be between 1 and the number of frm_payloads")
+	}
+
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	err := storage.Transaction(func(tx sqlx.Ext) error {
+		return multicast.EnqueueDataFragments(ctx, storage.RedisPool(), tx, mgID, int(req.FragIndex), int(req.NbFrag), req.FCnt, req.FrmPayloads)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetMulticastFragSessionStatus returns the fragmentation session status of
+// each device of the given multicast-group.
+func (n *NetworkServerAPI) GetMulticastFragSessionStatus(ctx context.Context, req *ns.GetMulticastFragSessionStatusRequest) (*ns.GetMulticastFragSessionStatusResponse, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	sessions, err := storage.GetDeviceMulticastFragSessionsForMulticastGroup(ctx, storage.DB(), mgID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out ns.GetMulticastFragSessionStatusResponse
+	for _, s := range sessions {
+		session := ns.DeviceMulticastFragSession{
+			DevEui:         s.DevEUI[:],
+			FragIndex:      uint32(s.FragIndex),
+			NbFrag:         uint32(s.NbFrag),
+			Error:          s.Error,
+			NbFragReceived: uint32(s.NbFragReceived),
+			MissingFrag:    uint32(s.MissingFrag),
+		}
+
+		switch s.State {
+		case storage.MulticastFragSessionCreated:
+			session.State = ns.MulticastFragSessionState_FRAG_SESSION_CREATED
+		case storage.MulticastFragSessionFailed:
+			session.State = ns.MulticastFragSessionState_FRAG_SESSION_FAILED
+		case storage.MulticastFragSessionIncomplete:
+			session.State = ns.MulticastFragSessionState_FRAG_SESSION_INCOMPLETE
+		case storage.MulticastFragSessionCompleted:
+			session.State = ns.MulticastFragSessionState_FRAG_SESSION_COMPLETED
+		default:
+			session.State = ns.MulticastFragSessionState_FRAG_SESSION_PENDING
+		}
+
+		session.CreatedAt, err = ptypes.TimestampProto(s.CreatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		session.UpdatedAt, err = ptypes.TimestampProto(s.UpdatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		out.Devices = append(out.Devices, &session)
+	}
+
+	return &out, nil
+}
+
 // GetVersion returns the ChirpStack Network Server version.
 func (n *NetworkServerAPI) GetVersion(ctx context.Context, req *empty.Empty) (*ns.GetVersionResponse, error) {
 	region, ok := map[string]common.Region{
@@ -1692,3 +2301,17 @@ func (n *NetworkServerAPI) GetVersion(ctx context.Context, req *empty.Empty) (*n
 		Version: config.Version,
 	}, nil
 }
+
+// GetADRAlgorithms returns the available ADR algorithms.
+func (n *NetworkServerAPI) GetADRAlgorithms(ctx context.Context, req *empty.Empty) (*ns.GetADRAlgorithmsResponse, error) {
+	var resp ns.GetADRAlgorithmsResponse
+
+	for _, h := range adr.GetHandlers() {
+		resp.AdrAlgorithms = append(resp.AdrAlgorithms, &ns.ADRAlgorithm{
+			Id:   h.ID(),
+			Name: h.Name(),
+		})
+	}
+
+	return &resp, nil
+}
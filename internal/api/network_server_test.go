@@ -11,18 +11,28 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
 	"github.com/brocaar/chirpstack-network-server/api/common"
 	"github.com/brocaar/chirpstack-network-server/api/gw"
 	"github.com/brocaar/chirpstack-network-server/api/ns"
+	gwbackend "github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
 	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/data/classb"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/multicast"
 	"github.com/brocaar/chirpstack-network-server/internal/framelog"
+	"github.com/brocaar/chirpstack-network-server/internal/gateway"
 	"github.com/brocaar/chirpstack-network-server/internal/gps"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 	"github.com/brocaar/chirpstack-network-server/internal/test"
 	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/applayer/fragmentation"
+	"github.com/brocaar/lorawan/applayer/multicastsetup"
+	loraband "github.com/brocaar/lorawan/band"
 )
 
 func TestNetworkServerAPI(t *testing.T) {
@@ -118,6 +128,65 @@ func TestNetworkServerAPI(t *testing.T) {
 			})
 		})
 
+		Convey("When calling ExecGatewayCommand", func() {
+			gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+			gwBackend := test.NewGatewayBackend()
+			gwbackend.SetBackend(gwBackend)
+			config.C.NetworkServer.Gateway.CommandExecTimeout = time.Second
+
+			type result struct {
+				resp *ns.ExecGatewayCommandResponse
+				err  error
+			}
+			resultChan := make(chan result, 1)
+
+			go func() {
+				resp, err := api.ExecGatewayCommand(ctx, &ns.ExecGatewayCommandRequest{
+					GatewayId: gatewayID[:],
+					Command:   "reboot",
+					Stdin:     []byte("stdin"),
+					Environment: map[string]string{
+						"FOO": "bar",
+					},
+				})
+				resultChan <- result{resp, err}
+			}()
+
+			Convey("Then the command execution request was sent to the gateway", func() {
+				req := <-gwBackend.GatewayCommandExecRequestChan
+				So(req.GatewayId, ShouldResemble, gatewayID[:])
+				So(req.Command, ShouldEqual, "reboot")
+				So(req.Stdin, ShouldResemble, []byte("stdin"))
+				So(req.Environment, ShouldResemble, map[string]string{"FOO": "bar"})
+				So(req.ExecId, ShouldHaveLength, 16)
+
+				Convey("When the gateway responds, the response is returned", func() {
+					So(gateway.HandleGatewayCommandExecResponse(ctx, storage.RedisPool(), gw.GatewayCommandExecResponse{
+						GatewayId: gatewayID[:],
+						ExecId:    req.ExecId,
+						Stdout:    []byte("stdout"),
+						Stderr:    []byte("stderr"),
+						Error:     "error",
+					}), ShouldBeNil)
+
+					res := <-resultChan
+					So(res.err, ShouldBeNil)
+					So(res.resp, ShouldResemble, &ns.ExecGatewayCommandResponse{
+						ExecId: req.ExecId,
+						Stdout: []byte("stdout"),
+						Stderr: []byte("stderr"),
+						Error:  "error",
+					})
+				})
+
+				Convey("When the gateway does not respond, a timeout error is returned", func() {
+					res := <-resultChan
+					So(res.err, ShouldNotBeNil)
+					So(grpc.Code(res.err), ShouldEqual, codes.DeadlineExceeded)
+				})
+			})
+		})
+
 		Convey("When calling StreamFrameLogsForDevice", func() {
 			respChan := make(chan *ns.StreamFrameLogsForDeviceResponse)
 
@@ -182,6 +251,10 @@ func TestNetworkServerAPI(t *testing.T) {
 					NwkGeoLoc:              true,
 					TargetPer:              1,
 					MinGwDiversity:         7,
+					SpUlRate:               9,
+					SpUlBucketSize:         10,
+					SpDlRate:               11,
+					SpDlBucketSize:         12,
 				},
 			})
 			So(err, ShouldBeNil)
@@ -214,6 +287,10 @@ func TestNetworkServerAPI(t *testing.T) {
 					NwkGeoLoc:              true,
 					TargetPer:              1,
 					MinGwDiversity:         7,
+					SpUlRate:               9,
+					SpUlBucketSize:         10,
+					SpDlRate:               11,
+					SpDlBucketSize:         12,
 				})
 			})
 
@@ -240,6 +317,10 @@ func TestNetworkServerAPI(t *testing.T) {
 						NwkGeoLoc:              false,
 						TargetPer:              2,
 						MinGwDiversity:         8,
+						SpUlRate:               10,
+						SpUlBucketSize:         11,
+						SpDlRate:               12,
+						SpDlBucketSize:         13,
 					},
 				})
 				So(err, ShouldBeNil)
@@ -269,6 +350,10 @@ func TestNetworkServerAPI(t *testing.T) {
 					NwkGeoLoc:              false,
 					TargetPer:              2,
 					MinGwDiversity:         8,
+					SpUlRate:               10,
+					SpUlBucketSize:         11,
+					SpDlRate:               12,
+					SpDlBucketSize:         13,
 				})
 			})
 
@@ -411,6 +496,20 @@ func TestNetworkServerAPI(t *testing.T) {
 			})
 		})
 
+		Convey("When calling CreateDeviceProfile with an unknown adr_algorithm_id", func() {
+			_, err := api.CreateDeviceProfile(ctx, &ns.CreateDeviceProfileRequest{
+				DeviceProfile: &ns.DeviceProfile{
+					MacVersion:     "1.0.2",
+					AdrAlgorithmId: "unknown",
+				},
+			})
+
+			Convey("Then an InvalidArgument error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+			})
+		})
+
 		Convey("Given a ServiceProfile, RoutingProfile, DeviceProfile and Device", func() {
 			sp := storage.ServiceProfile{
 				DRMin: 3,
@@ -690,6 +789,8 @@ func TestNetworkServerAPI(t *testing.T) {
 				So(resp.CreatedAt.String(), ShouldNotEqual, "")
 				So(resp.UpdatedAt.String(), ShouldNotEqual, "")
 				So(resp.LastSeenAt, ShouldBeNil)
+				So(resp.State, ShouldEqual, common.GatewayState_NEVER_SEEN)
+				So(resp.StateChangedAt, ShouldBeNil)
 			})
 
 			Convey("Then UpdateGateway updates the gateway", func() {
@@ -702,6 +803,7 @@ func TestNetworkServerAPI(t *testing.T) {
 							Longitude: 1.1236,
 							Altitude:  15.7,
 						},
+						Backend: "mqtt",
 						Boards: []*ns.GatewayBoard{
 							{
 								FineTimestampKey: []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
@@ -774,7 +876,9 @@ func TestNetworkServerAPI(t *testing.T) {
 			Convey("When creating a gateway-profile object", func() {
 				req := ns.CreateGatewayProfileRequest{
 					GatewayProfile: &ns.GatewayProfile{
-						Channels: []uint32{0, 1, 2},
+						Channels:      []uint32{0, 1, 2},
+						StatsInterval: ptypes.DurationProto(30 * time.Second),
+						BeaconEnabled: true,
 						ExtraChannels: []*ns.GatewayProfileExtraChannel{
 							{
 								Modulation:       common.Modulation_LORA,
@@ -804,8 +908,10 @@ func TestNetworkServerAPI(t *testing.T) {
 					})
 					So(err, ShouldBeNil)
 					So(getResp.GatewayProfile, ShouldResemble, &ns.GatewayProfile{
-						Id:       createResp.Id,
-						Channels: []uint32{0, 1, 2},
+						Id:            createResp.Id,
+						Channels:      []uint32{0, 1, 2},
+						StatsInterval: ptypes.DurationProto(30 * time.Second),
+						BeaconEnabled: true,
 						ExtraChannels: []*ns.GatewayProfileExtraChannel{
 							{
 								Modulation:       common.Modulation_LORA,
@@ -826,8 +932,9 @@ func TestNetworkServerAPI(t *testing.T) {
 				Convey("Then it can be updated", func() {
 					updateReq := ns.UpdateGatewayProfileRequest{
 						GatewayProfile: &ns.GatewayProfile{
-							Id:       createResp.Id,
-							Channels: []uint32{0, 1},
+							Id:            createResp.Id,
+							Channels:      []uint32{0, 1},
+							StatsInterval: ptypes.DurationProto(time.Minute),
 							ExtraChannels: []*ns.GatewayProfileExtraChannel{
 								{
 									Modulation: common.Modulation_FSK,
@@ -852,8 +959,9 @@ func TestNetworkServerAPI(t *testing.T) {
 					})
 					So(err, ShouldBeNil)
 					So(resp.GatewayProfile, ShouldResemble, &ns.GatewayProfile{
-						Id:       createResp.Id,
-						Channels: []uint32{0, 1},
+						Id:            createResp.Id,
+						Channels:      []uint32{0, 1},
+						StatsInterval: ptypes.DurationProto(time.Minute),
 						ExtraChannels: []*ns.GatewayProfileExtraChannel{
 							{
 								Modulation: common.Modulation_FSK,
@@ -898,3 +1006,1314 @@ func TestNetworkServerAPI(t *testing.T) {
 		})
 	})
 }
+
+type NetworkServerAPITestSuite struct {
+	suite.Suite
+	api ns.NetworkServerServiceServer
+}
+
+func (ts *NetworkServerAPITestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustResetDB(storage.DB().DB)
+	ts.api = NewNetworkServerAPI()
+}
+
+func (ts *NetworkServerAPITestSuite) SetupTest() {
+	test.MustFlushRedis(storage.RedisPool())
+}
+
+func (ts *NetworkServerAPITestSuite) TestMulticastGroup() {
+	assert := require.New(ts.T())
+
+	var rp storage.RoutingProfile
+	var sp storage.ServiceProfile
+
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+
+	mg := ns.MulticastGroup{
+		McAddr:           []byte{1, 2, 3, 4},
+		McNwkSKey:        []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		FCnt:             10,
+		GroupType:        ns.MulticastGroupType_CLASS_B,
+		Dr:               5,
+		Frequency:        868300000,
+		PingSlotPeriod:   16,
+		RoutingProfileId: rp.ID[:],
+		ServiceProfileId: sp.ID[:],
+	}
+
+	ts.T().Run("Create", func(t *testing.T) {
+		assert := require.New(t)
+		createResp, err := ts.api.CreateMulticastGroup(context.Background(), &ns.CreateMulticastGroupRequest{
+			MulticastGroup: &mg,
+		})
+		assert.Nil(err)
+		assert.Len(createResp.Id, 16)
+		assert.NotEqual(uuid.Nil.Bytes(), createResp.Id)
+		mg.Id = createResp.Id
+
+		t.Run("Get", func(t *testing.T) {
+			assert := require.New(t)
+			getResp, err := ts.api.GetMulticastGroup(context.Background(), &ns.GetMulticastGroupRequest{
+				Id: createResp.Id,
+			})
+			assert.Nil(err)
+			assert.NotNil(getResp.MulticastGroup)
+			assert.NotNil(getResp.CreatedAt)
+			assert.NotNil(getResp.UpdatedAt)
+			assert.Equal(&mg, getResp.MulticastGroup)
+		})
+
+		t.Run("Update", func(t *testing.T) {
+			assert := require.New(t)
+
+			mgUpdated := ns.MulticastGroup{
+				Id:               createResp.Id,
+				McAddr:           []byte{4, 3, 2, 1},
+				McNwkSKey:        []byte{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+				FCnt:             20,
+				GroupType:        ns.MulticastGroupType_CLASS_C,
+				Dr:               3,
+				Frequency:        868100000,
+				PingSlotPeriod:   32,
+				RoutingProfileId: rp.ID[:],
+				ServiceProfileId: sp.ID[:],
+			}
+
+			_, err := ts.api.UpdateMulticastGroup(context.Background(), &ns.UpdateMulticastGroupRequest{
+				MulticastGroup: &mgUpdated,
+			})
+			assert.Nil(err)
+
+			getResp, err := ts.api.GetMulticastGroup(context.Background(), &ns.GetMulticastGroupRequest{
+				Id: createResp.Id,
+			})
+			assert.Nil(err)
+			assert.Equal(&mgUpdated, getResp.MulticastGroup)
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			assert := require.New(t)
+
+			_, err := ts.api.DeleteMulticastGroup(context.Background(), &ns.DeleteMulticastGroupRequest{
+				Id: createResp.Id,
+			})
+			assert.Nil(err)
+
+			_, err = ts.api.DeleteMulticastGroup(context.Background(), &ns.DeleteMulticastGroupRequest{
+				Id: createResp.Id,
+			})
+			assert.NotNil(err)
+			assert.Equal(codes.NotFound, grpc.Code(err))
+
+			_, err = ts.api.GetMulticastGroup(context.Background(), &ns.GetMulticastGroupRequest{
+				Id: createResp.Id,
+			})
+			assert.NotNil(err)
+			assert.Equal(codes.NotFound, grpc.Code(err))
+		})
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestMulticastQueue() {
+	assert := require.New(ts.T())
+
+	rp := storage.RoutingProfile{
+		ASID: "localhost:1234",
+	}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	gateways := []storage.Gateway{
+		{
+			GatewayID:        lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			RoutingProfileID: rp.ID,
+		},
+		{
+			GatewayID:        lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 2},
+			RoutingProfileID: rp.ID,
+		},
+	}
+	for i := range gateways {
+		assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &gateways[i]))
+	}
+
+	var sp storage.ServiceProfile
+	var dp storage.DeviceProfile
+
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+	assert.NoError(storage.CreateDeviceProfile(context.Background(), storage.DB(), &dp))
+
+	devices := []storage.Device{
+		{
+			DevEUI:           lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 1},
+			RoutingProfileID: rp.ID,
+			ServiceProfileID: sp.ID,
+			DeviceProfileID:  dp.ID,
+		},
+		{
+			DevEUI:           lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+			RoutingProfileID: rp.ID,
+			ServiceProfileID: sp.ID,
+			DeviceProfileID:  dp.ID,
+		},
+	}
+	for i := range devices {
+		assert.NoError(storage.CreateDevice(context.Background(), storage.DB(), &devices[i]))
+		assert.NoError(storage.SaveDeviceGatewayRXInfoSet(context.Background(), storage.RedisPool(), storage.DeviceGatewayRXInfoSet{
+			DevEUI: devices[i].DevEUI,
+			DR:     3,
+			Items: []storage.DeviceGatewayRXInfo{
+				{
+					GatewayID: gateways[i].GatewayID,
+					RSSI:      50,
+					LoRaSNR:   5,
+				},
+			},
+		}))
+	}
+
+	ts.T().Run("Class-B", func(t *testing.T) {
+		assert := require.New(t)
+
+		mg := storage.MulticastGroup{
+			GroupType:        storage.MulticastGroupB,
+			MCAddr:           lorawan.DevAddr{1, 2, 3, 4},
+			PingSlotPeriod:   32 * 128, // every 128 seconds
+			ServiceProfileID: sp.ID,
+			RoutingProfileID: rp.ID,
+		}
+		assert.NoError(storage.CreateMulticastGroup(context.Background(), storage.DB(), &mg))
+
+		for _, d := range devices {
+			assert.NoError(storage.AddDeviceToMulticastGroup(context.Background(), storage.DB(), d.DevEUI, mg.ID))
+		}
+
+		ts.T().Run("Create", func(t *testing.T) {
+			assert := require.New(t)
+
+			qi1 := ns.MulticastQueueItem{
+				MulticastGroupId: mg.ID.Bytes(),
+				FCnt:             10,
+				FPort:            20,
+				FrmPayload:       []byte{1, 2, 3, 4},
+			}
+			qi2 := ns.MulticastQueueItem{
+				MulticastGroupId: mg.ID.Bytes(),
+				FCnt:             11,
+				FPort:            20,
+				FrmPayload:       []byte{1, 2, 3, 4},
+			}
+
+			_, err := ts.api.EnqueueMulticastQueueItem(context.Background(), &ns.EnqueueMulticastQueueItemRequest{
+				MulticastQueueItem: &qi1,
+			})
+			assert.NoError(err)
+			_, err = ts.api.EnqueueMulticastQueueItem(context.Background(), &ns.EnqueueMulticastQueueItemRequest{
+				MulticastQueueItem: &qi2,
+			})
+			assert.NoError(err)
+
+			t.Run("List", func(t *testing.T) {
+				assert := require.New(t)
+
+				listResp, err := ts.api.GetMulticastQueueItemsForMulticastGroup(context.Background(), &ns.GetMulticastQueueItemsForMulticastGroupRequest{
+					MulticastGroupId: mg.ID.Bytes(),
+				})
+				assert.NoError(err)
+				assert.Len(listResp.MulticastQueueItems, 2)
+
+				for i, exp := range []struct {
+					FCnt uint32
+				}{
+					{10}, {11},
+				} {
+					assert.Equal(exp.FCnt, listResp.MulticastQueueItems[i].FCnt)
+				}
+			})
+
+			t.Run("Test emit and schedule at", func(t *testing.T) {
+				assert := require.New(t)
+
+				items, err := storage.GetMulticastQueueItemsForMulticastGroup(context.Background(), storage.DB(), mg.ID)
+				assert.NoError(err)
+				assert.Len(items, 4)
+
+				for _, item := range items {
+					assert.NotNil(item.EmitAtTimeSinceGPSEpoch)
+				}
+
+				emitAt := *items[0].EmitAtTimeSinceGPSEpoch
+
+				// iterate over the enqueued items and based on the first item
+				// calculate the next ping-slot and validate if this is used
+				// for the next queue-item.
+				for i := range items {
+					if i == 0 {
+						continue
+					}
+					var err error
+					emitAt, err = classb.GetNextPingSlotAfter(emitAt, mg.MCAddr, (1<<12)/mg.PingSlotPeriod)
+					assert.NoError(err)
+					assert.Equal(emitAt, *items[i].EmitAtTimeSinceGPSEpoch, "queue item %d", i)
+
+					scheduleAt := time.Time(gps.NewFromTimeSinceGPSEpoch(emitAt)).Add(-2 * config.C.NetworkServer.Scheduler.SchedulerInterval)
+					assert.EqualValues(scheduleAt.UTC(), items[i].ScheduleAt.UTC())
+				}
+			})
+		})
+	})
+
+	ts.T().Run("Class-C", func(t *testing.T) {
+		assert := require.New(t)
+
+		mg := storage.MulticastGroup{
+			GroupType:        storage.MulticastGroupC,
+			ServiceProfileID: sp.ID,
+			RoutingProfileID: rp.ID,
+		}
+		assert.NoError(storage.CreateMulticastGroup(context.Background(), storage.DB(), &mg))
+
+		for _, d := range devices {
+			assert.NoError(storage.AddDeviceToMulticastGroup(context.Background(), storage.DB(), d.DevEUI, mg.ID))
+		}
+
+		ts.T().Run("Create", func(t *testing.T) {
+			assert := require.New(t)
+
+			qi1 := ns.MulticastQueueItem{
+				MulticastGroupId: mg.ID.Bytes(),
+				FCnt:             10,
+				FPort:            20,
+				FrmPayload:       []byte{1, 2, 3, 4},
+			}
+			qi2 := ns.MulticastQueueItem{
+				MulticastGroupId: mg.ID.Bytes(),
+				FCnt:             11,
+				FPort:            20,
+				FrmPayload:       []byte{1, 2, 3, 4},
+			}
+
+			_, err := ts.api.EnqueueMulticastQueueItem(context.Background(), &ns.EnqueueMulticastQueueItemRequest{
+				MulticastQueueItem: &qi1,
+			})
+			assert.NoError(err)
+			_, err = ts.api.EnqueueMulticastQueueItem(context.Background(), &ns.EnqueueMulticastQueueItemRequest{
+				MulticastQueueItem: &qi2,
+			})
+			assert.NoError(err)
+
+			t.Run("List", func(t *testing.T) {
+				assert := require.New(t)
+
+				listResp, err := ts.api.GetMulticastQueueItemsForMulticastGroup(context.Background(), &ns.GetMulticastQueueItemsForMulticastGroupRequest{
+					MulticastGroupId: mg.ID.Bytes(),
+				})
+				assert.NoError(err)
+				assert.Len(listResp.MulticastQueueItems, 2)
+
+				for i, exp := range []struct {
+					FCnt uint32
+				}{
+					{10}, {11},
+				} {
+					assert.Equal(exp.FCnt, listResp.MulticastQueueItems[i].FCnt)
+				}
+			})
+
+			t.Run("Test emit and schedule at", func(t *testing.T) {
+				assert := require.New(t)
+
+				items, err := storage.GetMulticastQueueItemsForMulticastGroup(context.Background(), storage.DB(), mg.ID)
+				assert.NoError(err)
+				assert.Len(items, 4)
+
+				for _, item := range items {
+					assert.Nil(item.EmitAtTimeSinceGPSEpoch)
+				}
+
+				scheduleAt := items[0].ScheduleAt
+
+				for i := range items {
+					if i == 0 {
+						continue
+					}
+					lockDuration := config.C.NetworkServer.Scheduler.ClassC.DownlinkLockDuration
+					assert.Equal(scheduleAt, items[i].ScheduleAt.Add(-lockDuration))
+					scheduleAt = items[i].ScheduleAt
+				}
+			})
+		})
+	})
+
+}
+
+func (ts *NetworkServerAPITestSuite) TestMulticastQueueItemDeliveryReport() {
+	assert := require.New(ts.T())
+
+	var rp storage.RoutingProfile
+	var sp storage.ServiceProfile
+
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+
+	mg := storage.MulticastGroup{
+		GroupType:        storage.MulticastGroupC,
+		MCAddr:           lorawan.DevAddr{1, 2, 3, 4},
+		ServiceProfileID: sp.ID,
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateMulticastGroup(context.Background(), storage.DB(), &mg))
+
+	deliveries := []storage.MulticastQueueItemDelivery{
+		{
+			MulticastGroupID: mg.ID,
+			FCnt:             10,
+			GatewayID:        lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 1},
+			Status:           storage.MulticastDeliverySuccess,
+		},
+		{
+			MulticastGroupID: mg.ID,
+			FCnt:             10,
+			GatewayID:        lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 2},
+			Status:           storage.MulticastDeliveryFailed,
+			Error:            "TOO_LATE",
+		},
+		{
+			MulticastGroupID: mg.ID,
+			FCnt:             10,
+			GatewayID:        lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3},
+			Status:           storage.MulticastDeliveryPending,
+		},
+	}
+	for i := range deliveries {
+		assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &storage.Gateway{
+			GatewayID:        deliveries[i].GatewayID,
+			RoutingProfileID: rp.ID,
+		}))
+		assert.NoError(storage.SaveMulticastQueueItemDelivery(context.Background(), storage.DB(), &deliveries[i]))
+	}
+
+	ts.T().Run("Get", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetMulticastQueueItemDeliveryReport(context.Background(), &ns.GetMulticastQueueItemDeliveryReportRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+			FCnt:             10,
+		})
+		assert.NoError(err)
+		assert.EqualValues(3, resp.GatewaysAttempted)
+		assert.EqualValues(1, resp.GatewaysSucceeded)
+		assert.EqualValues(1, resp.GatewaysFailed)
+		assert.Len(resp.Deliveries, 3)
+
+		for i, exp := range []struct {
+			GatewayID lorawan.EUI64
+			Status    ns.MulticastDeliveryStatus
+			Error     string
+		}{
+			{lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 1}, ns.MulticastDeliveryStatus_SUCCESS, ""},
+			{lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 2}, ns.MulticastDeliveryStatus_FAILED, "TOO_LATE"},
+			{lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}, ns.MulticastDeliveryStatus_PENDING, ""},
+		} {
+			assert.Equal(exp.GatewayID[:], resp.Deliveries[i].GatewayId)
+			assert.Equal(exp.Status, resp.Deliveries[i].Status)
+			assert.Equal(exp.Error, resp.Deliveries[i].Error)
+			assert.NotNil(resp.Deliveries[i].CreatedAt)
+			assert.NotNil(resp.Deliveries[i].UpdatedAt)
+		}
+	})
+
+	ts.T().Run("Unknown frame-counter", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetMulticastQueueItemDeliveryReport(context.Background(), &ns.GetMulticastQueueItemDeliveryReportRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+			FCnt:             11,
+		})
+		assert.NoError(err)
+		assert.EqualValues(0, resp.GatewaysAttempted)
+		assert.Len(resp.Deliveries, 0)
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestGetMulticastGroupSetupCommands() {
+	assert := require.New(ts.T())
+
+	var rp storage.RoutingProfile
+	var sp storage.ServiceProfile
+
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+
+	mg := storage.MulticastGroup{
+		GroupType:        storage.MulticastGroupC,
+		MCAddr:           lorawan.DevAddr{1, 2, 3, 4},
+		FCnt:             10,
+		DR:               3,
+		Frequency:        869525000,
+		ServiceProfileID: sp.ID,
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateMulticastGroup(context.Background(), storage.DB(), &mg))
+
+	mcKeyEncrypted := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+
+	ts.T().Run("Get", func(t *testing.T) {
+		assert := require.New(t)
+
+		startAfter := time.Now().Add(time.Hour)
+		startAfterPB, _ := ptypes.TimestampProto(startAfter)
+
+		resp, err := ts.api.GetMulticastGroupSetupCommands(context.Background(), &ns.GetMulticastGroupSetupCommandsRequest{
+			MulticastGroupId:  mg.ID.Bytes(),
+			McGroupId:         1,
+			McKeyEncrypted:    mcKeyEncrypted[:],
+			SessionTimeOut:    8,
+			SessionStartAfter: startAfterPB,
+		})
+		assert.NoError(err)
+
+		sessionTime, err := ptypes.Duration(resp.SessionTime)
+		assert.NoError(err)
+		assert.Equal(multicast.GetSessionTime(mg, startAfter), sessionTime)
+		assert.EqualValues(multicast.SetupFPort, resp.FPort)
+
+		groupSetupReq, err := multicast.GetMcGroupSetupReq(mg, 1, mcKeyEncrypted, 0).MarshalBinary()
+		assert.NoError(err)
+		assert.Equal(groupSetupReq, resp.McGroupSetupReq)
+
+		sessionReqCmd, err := multicast.GetMcSessionReq(mg, 1, sessionTime, 8)
+		assert.NoError(err)
+		sessionReq, err := sessionReqCmd.MarshalBinary()
+		assert.NoError(err)
+		assert.Equal(sessionReq, resp.McSessionReq)
+	})
+
+	ts.T().Run("Invalid mc_group_id", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.GetMulticastGroupSetupCommands(context.Background(), &ns.GetMulticastGroupSetupCommandsRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+			McGroupId:        4,
+			McKeyEncrypted:   mcKeyEncrypted[:],
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Unknown multicast-group", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.GetMulticastGroupSetupCommands(context.Background(), &ns.GetMulticastGroupSetupCommandsRequest{
+			MulticastGroupId: uuid.Must(uuid.NewV4()).Bytes(),
+			McKeyEncrypted:   mcKeyEncrypted[:],
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestMulticastGroupSetup() {
+	assert := require.New(ts.T())
+
+	var rp storage.RoutingProfile
+	var sp storage.ServiceProfile
+	var dp storage.DeviceProfile
+
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+	assert.NoError(storage.CreateDeviceProfile(context.Background(), storage.DB(), &dp))
+
+	mg := storage.MulticastGroup{
+		GroupType:        storage.MulticastGroupC,
+		MCAddr:           lorawan.DevAddr{1, 2, 3, 4},
+		DR:               3,
+		Frequency:        869525000,
+		ServiceProfileID: sp.ID,
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateMulticastGroup(context.Background(), storage.DB(), &mg))
+
+	devices := []storage.Device{
+		{DevEUI: lorawan.EUI64{2, 1, 1, 1, 1, 1, 1, 1}},
+		{DevEUI: lorawan.EUI64{2, 1, 1, 1, 1, 1, 1, 2}},
+	}
+	for i := range devices {
+		devices[i].ServiceProfileID = sp.ID
+		devices[i].DeviceProfileID = dp.ID
+		devices[i].RoutingProfileID = rp.ID
+		assert.NoError(storage.CreateDevice(context.Background(), storage.DB(), &devices[i]))
+		assert.NoError(storage.SaveDeviceSession(context.Background(), storage.RedisPool(), storage.DeviceSession{
+			DevEUI:  devices[i].DevEUI,
+			DevAddr: lorawan.DevAddr{2, 1, 1, byte(i)},
+		}))
+	}
+	assert.NoError(storage.AddDeviceToMulticastGroup(context.Background(), storage.DB(), devices[0].DevEUI, mg.ID))
+
+	sessionTime := ptypes.DurationProto(100 * time.Second)
+
+	ts.T().Run("Device not in multicast-group", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.EnqueueMulticastGroupSetup(context.Background(), &ns.EnqueueMulticastGroupSetupRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+			McGroupId:        1,
+			SessionTime:      sessionTime,
+			Items: []*ns.DeviceQueueItem{
+				{DevEui: devices[1].DevEUI[:], FrmPayload: []byte{1, 2, 3}, FCnt: 1, FPort: multicast.SetupFPort},
+			},
+		})
+		assert.Equal(codes.FailedPrecondition, grpc.Code(err))
+	})
+
+	ts.T().Run("Invalid f_port", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.EnqueueMulticastGroupSetup(context.Background(), &ns.EnqueueMulticastGroupSetupRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+			McGroupId:        1,
+			SessionTime:      sessionTime,
+			Items: []*ns.DeviceQueueItem{
+				{DevEui: devices[0].DevEUI[:], FrmPayload: []byte{1, 2, 3}, FCnt: 1, FPort: 10},
+			},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Item can not be enqueued", func(t *testing.T) {
+		assert := require.New(t)
+
+		// member without device-session
+		d := storage.Device{
+			DevEUI:           lorawan.EUI64{2, 1, 1, 1, 1, 1, 1, 3},
+			ServiceProfileID: sp.ID,
+			DeviceProfileID:  dp.ID,
+			RoutingProfileID: rp.ID,
+		}
+		assert.NoError(storage.CreateDevice(context.Background(), storage.DB(), &d))
+		assert.NoError(storage.AddDeviceToMulticastGroup(context.Background(), storage.DB(), d.DevEUI, mg.ID))
+		defer func() {
+			assert.NoError(storage.RemoveDeviceFromMulticastGroup(context.Background(), storage.DB(), d.DevEUI, mg.ID))
+		}()
+
+		_, err := ts.api.EnqueueMulticastGroupSetup(context.Background(), &ns.EnqueueMulticastGroupSetupRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+			McGroupId:        1,
+			SessionTime:      sessionTime,
+			Items: []*ns.DeviceQueueItem{
+				{DevEui: devices[0].DevEUI[:], FrmPayload: []byte{1, 2, 3}, FCnt: 1, FPort: multicast.SetupFPort},
+				{DevEui: d.DevEUI[:], FrmPayload: []byte{1, 2, 3}, FCnt: 1, FPort: multicast.SetupFPort},
+			},
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+
+		// none of the items has been enqueued
+		items, err := storage.GetDeviceQueueItemsForDevEUI(context.Background(), storage.DB(), devices[0].DevEUI)
+		assert.NoError(err)
+		assert.Len(items, 0)
+
+		resp, err := ts.api.GetMulticastGroupSetupStatus(context.Background(), &ns.GetMulticastGroupSetupStatusRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+		})
+		assert.NoError(err)
+		assert.Len(resp.Devices, 0)
+	})
+
+	ts.T().Run("Enqueue", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.EnqueueMulticastGroupSetup(context.Background(), &ns.EnqueueMulticastGroupSetupRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+			McGroupId:        1,
+			SessionTime:      sessionTime,
+			Items: []*ns.DeviceQueueItem{
+				{DevEui: devices[0].DevEUI[:], FrmPayload: []byte{1, 2, 3}, FCnt: 1, FPort: multicast.SetupFPort},
+				{DevEui: devices[0].DevEUI[:], FrmPayload: []byte{4, 5, 6}, FCnt: 2, FPort: multicast.SetupFPort},
+			},
+		})
+		assert.NoError(err)
+
+		items, err := storage.GetDeviceQueueItemsForDevEUI(context.Background(), storage.DB(), devices[0].DevEUI)
+		assert.NoError(err)
+		assert.Len(items, 2)
+
+		resp, err := ts.api.GetMulticastGroupSetupStatus(context.Background(), &ns.GetMulticastGroupSetupStatusRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+		})
+		assert.NoError(err)
+		assert.Len(resp.Devices, 1)
+		assert.Equal(devices[0].DevEUI[:], resp.Devices[0].DevEui)
+		assert.EqualValues(1, resp.Devices[0].McGroupId)
+		assert.Equal(ns.MulticastSetupState_SETUP_PENDING, resp.Devices[0].State)
+		assert.Equal(sessionTime, resp.Devices[0].SessionTime)
+
+		t.Run("Handle answers", func(t *testing.T) {
+			assert := require.New(t)
+
+			cmds := multicastsetup.Commands{
+				{
+					CID: multicastsetup.McGroupSetupAns,
+					Payload: &multicastsetup.McGroupSetupAnsPayload{
+						McGroupIDHeader: multicastsetup.McGroupSetupAnsPayloadMcGroupIDHeader{
+							McGroupID: 1,
+						},
+					},
+				},
+				{
+					CID: multicastsetup.McClassCSessionAns,
+					Payload: &multicastsetup.McClassCSessionAnsPayload{
+						StatusAndMcGroupID: multicastsetup.McClassCSessionAnsPayloadStatusAndMcGroupID{
+							McGroupID: 1,
+						},
+						TimeToStart: func() *uint32 { v := uint32(10); return &v }(),
+					},
+				},
+			}
+			b, err := cmds.MarshalBinary()
+			assert.NoError(err)
+
+			_, err = ts.api.HandleApplicationLayerUplink(context.Background(), &ns.HandleApplicationLayerUplinkRequest{
+				DevEui:     devices[0].DevEUI[:],
+				FPort:      multicast.SetupFPort,
+				FrmPayload: b,
+			})
+			assert.NoError(err)
+
+			resp, err := ts.api.GetMulticastGroupSetupStatus(context.Background(), &ns.GetMulticastGroupSetupStatusRequest{
+				MulticastGroupId: mg.ID.Bytes(),
+			})
+			assert.NoError(err)
+			assert.Len(resp.Devices, 1)
+			assert.Equal(ns.MulticastSetupState_SETUP_COMPLETED, resp.Devices[0].State)
+		})
+
+		t.Run("Unsupported f_port", func(t *testing.T) {
+			assert := require.New(t)
+
+			_, err := ts.api.HandleApplicationLayerUplink(context.Background(), &ns.HandleApplicationLayerUplinkRequest{
+				DevEui:     devices[0].DevEUI[:],
+				FPort:      10,
+				FrmPayload: []byte{1, 2, 3},
+			})
+			assert.Equal(codes.InvalidArgument, grpc.Code(err))
+		})
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestMulticastFragmentedDataBlock() {
+	assert := require.New(ts.T())
+
+	var rp storage.RoutingProfile
+	var sp storage.ServiceProfile
+	var dp storage.DeviceProfile
+
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+	assert.NoError(storage.CreateDeviceProfile(context.Background(), storage.DB(), &dp))
+
+	mg := storage.MulticastGroup{
+		GroupType:        storage.MulticastGroupC,
+		MCAddr:           lorawan.DevAddr{1, 2, 3, 4},
+		FCnt:             10,
+		DR:               3,
+		Frequency:        869525000,
+		ServiceProfileID: sp.ID,
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateMulticastGroup(context.Background(), storage.DB(), &mg))
+
+	d := storage.Device{
+		DevEUI:           lorawan.EUI64{3, 1, 1, 1, 1, 1, 1, 1},
+		ServiceProfileID: sp.ID,
+		DeviceProfileID:  dp.ID,
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateDevice(context.Background(), storage.DB(), &d))
+	assert.NoError(storage.AddDeviceToMulticastGroup(context.Background(), storage.DB(), d.DevEUI, mg.ID))
+
+	ts.T().Run("Get data fragments", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetMulticastDataFragments(context.Background(), &ns.GetMulticastDataFragmentsRequest{
+			MulticastGroupId:      mg.ID.Bytes(),
+			McGroupId:             1,
+			FragIndex:             2,
+			FragSize:              10,
+			Redundancy:            2,
+			FragSessionDescriptor: []byte{1, 2, 3, 4},
+			Data:                  make([]byte, 25),
+		})
+		assert.NoError(err)
+		assert.EqualValues(multicast.FragmentationFPort, resp.FPort)
+		assert.EqualValues(10, resp.FCnt)
+		assert.EqualValues(3, resp.NbFrag)
+		assert.EqualValues(5, resp.NbFragTotal)
+		assert.EqualValues(5, resp.Padding)
+
+		block, err := multicast.GetDataFragments(make([]byte, 25), 2, 10, 2)
+		assert.NoError(err)
+		assert.Equal(block.DataFragments, resp.DataFragments)
+
+		setupCmd, err := multicast.GetFragSessionSetupReq(2, 1, 10, block, 0, [4]byte{1, 2, 3, 4})
+		assert.NoError(err)
+		setupReq, err := setupCmd.MarshalBinary()
+		assert.NoError(err)
+		assert.Equal(setupReq, resp.FragSessionSetupReq)
+	})
+
+	ts.T().Run("Get data fragments exceeding max payload-size", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.GetMulticastDataFragments(context.Background(), &ns.GetMulticastDataFragmentsRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+			FragSize:         200,
+			Data:             make([]byte, 200),
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Enqueue", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.EnqueueMulticastFragmentedDataBlock(context.Background(), &ns.EnqueueMulticastFragmentedDataBlockRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+			FragIndex:        2,
+			NbFrag:           3,
+			FCnt:             10,
+			FrmPayloads:      [][]byte{{1}, {2}, {3}, {4}, {5}},
+		})
+		assert.NoError(err)
+
+		mgGet, err := storage.GetMulticastGroup(context.Background(), storage.DB(), mg.ID, false)
+		assert.NoError(err)
+		assert.EqualValues(15, mgGet.FCnt)
+
+		resp, err := ts.api.GetMulticastFragSessionStatus(context.Background(), &ns.GetMulticastFragSessionStatusRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+		})
+		assert.NoError(err)
+		assert.Len(resp.Devices, 1)
+		assert.Equal(d.DevEUI[:], resp.Devices[0].DevEui)
+		assert.EqualValues(2, resp.Devices[0].FragIndex)
+		assert.EqualValues(3, resp.Devices[0].NbFrag)
+		assert.Equal(ns.MulticastFragSessionState_FRAG_SESSION_PENDING, resp.Devices[0].State)
+
+		t.Run("Handle status answer", func(t *testing.T) {
+			assert := require.New(t)
+
+			cmds := fragmentation.Commands{
+				{
+					CID: fragmentation.FragSessionStatusAns,
+					Payload: &fragmentation.FragSessionStatusAnsPayload{
+						ReceivedAndIndex: fragmentation.FragSessionStatusAnsPayloadReceivedAndIndex{
+							FragIndex:      2,
+							NbFragReceived: 4,
+						},
+					},
+				},
+			}
+			b, err := cmds.MarshalBinary()
+			assert.NoError(err)
+
+			_, err = ts.api.HandleApplicationLayerUplink(context.Background(), &ns.HandleApplicationLayerUplinkRequest{
+				DevEui:     d.DevEUI[:],
+				FPort:      uint32(multicast.FragmentationFPort),
+				FrmPayload: b,
+			})
+			assert.NoError(err)
+
+			resp, err := ts.api.GetMulticastFragSessionStatus(context.Background(), &ns.GetMulticastFragSessionStatusRequest{
+				MulticastGroupId: mg.ID.Bytes(),
+			})
+			assert.NoError(err)
+			assert.Len(resp.Devices, 1)
+			assert.Equal(ns.MulticastFragSessionState_FRAG_SESSION_COMPLETED, resp.Devices[0].State)
+			assert.EqualValues(4, resp.Devices[0].NbFragReceived)
+		})
+	})
+
+	ts.T().Run("Enqueue with invalid frame-counter", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.EnqueueMulticastFragmentedDataBlock(context.Background(), &ns.EnqueueMulticastFragmentedDataBlockRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+			NbFrag:           1,
+			FCnt:             10,
+			FrmPayloads:      [][]byte{{1}},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Enqueue with invalid nb_frag", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.EnqueueMulticastFragmentedDataBlock(context.Background(), &ns.EnqueueMulticastFragmentedDataBlockRequest{
+			MulticastGroupId: mg.ID.Bytes(),
+			NbFrag:           2,
+			FCnt:             20,
+			FrmPayloads:      [][]byte{{1}},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestGetDeviceStatusHistory() {
+	assert := require.New(ts.T())
+
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 9}
+	now := time.Now()
+
+	assert.NoError(storage.SaveDeviceStatusHistoryItem(context.Background(), storage.RedisPool(), devEUI, storage.DeviceStatusHistoryItem{
+		Time:    now.Add(-2 * time.Second),
+		Type:    storage.DeviceStatusDevStatus,
+		Battery: 254,
+		Margin:  -5,
+	}))
+	assert.NoError(storage.SaveDeviceStatusHistoryItem(context.Background(), storage.RedisPool(), devEUI, storage.DeviceStatusHistoryItem{
+		Time:         now.Add(-time.Second),
+		Type:         storage.DeviceStatusLinkCheck,
+		Margin:       20,
+		GatewayCount: 3,
+	}))
+
+	start, _ := ptypes.TimestampProto(now.Add(-time.Minute))
+	end, _ := ptypes.TimestampProto(now)
+
+	resp, err := ts.api.GetDeviceStatusHistory(context.Background(), &ns.GetDeviceStatusHistoryRequest{
+		DevEui:         devEUI[:],
+		StartTimestamp: start,
+		EndTimestamp:   end,
+	})
+	assert.NoError(err)
+	assert.Len(resp.Result, 2)
+
+	assert.Equal(ns.DeviceStatusType_DEV_STATUS, resp.Result[0].Type)
+	assert.EqualValues(254, resp.Result[0].Battery)
+	assert.EqualValues(-5, resp.Result[0].Margin)
+	assert.NotNil(resp.Result[0].Time)
+
+	assert.Equal(ns.DeviceStatusType_LINK_CHECK, resp.Result[1].Type)
+	assert.EqualValues(20, resp.Result[1].Margin)
+	assert.EqualValues(3, resp.Result[1].GatewayCount)
+	assert.NotNil(resp.Result[1].Time)
+}
+
+func (ts *NetworkServerAPITestSuite) TestDevice() {
+	assert := require.New(ts.T())
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	sp := storage.ServiceProfile{
+		DRMin: 3,
+		DRMax: 6,
+	}
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+
+	dp := storage.DeviceProfile{
+		FactoryPresetFreqs: []int{
+			868100000,
+			868300000,
+			868500000,
+		},
+		RXDelay1:       3,
+		RXDROffset1:    2,
+		RXDataRate2:    5,
+		RXFreq2:        868900000,
+		PingSlotPeriod: 32,
+		PingSlotFreq:   868100000,
+		PingSlotDR:     5,
+		MACVersion:     "1.0.2",
+	}
+	assert.NoError(storage.CreateDeviceProfile(context.Background(), storage.DB(), &dp))
+
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	ts.T().Run("Create", func(t *testing.T) {
+		assert := require.New(t)
+
+		d := &ns.Device{
+			DevEui:            devEUI[:],
+			DeviceProfileId:   dp.ID.Bytes(),
+			ServiceProfileId:  sp.ID.Bytes(),
+			RoutingProfileId:  rp.ID.Bytes(),
+			SkipFCntCheck:     true,
+			ReferenceAltitude: 5.6,
+		}
+
+		_, err := ts.api.CreateDevice(context.Background(), &ns.CreateDeviceRequest{
+			Device: d,
+		})
+		assert.NoError(err)
+
+		t.Run("Get", func(t *testing.T) {
+			assert := require.New(t)
+
+			getResp, err := ts.api.GetDevice(context.Background(), &ns.GetDeviceRequest{
+				DevEui: devEUI[:],
+			})
+			assert.NoError(err)
+			assert.Equal(d, getResp.Device)
+		})
+
+		t.Run("Multicast-groups", func(t *testing.T) {
+			assert := require.New(t)
+
+			mg1 := storage.MulticastGroup{
+				RoutingProfileID: rp.ID,
+				ServiceProfileID: sp.ID,
+			}
+			assert.NoError(storage.CreateMulticastGroup(context.Background(), storage.DB(), &mg1))
+
+			t.Run("Add", func(t *testing.T) {
+				_, err := ts.api.AddDeviceToMulticastGroup(context.Background(), &ns.AddDeviceToMulticastGroupRequest{
+					DevEui:           devEUI[:],
+					MulticastGroupId: mg1.ID.Bytes(),
+				})
+				assert.NoError(err)
+
+				t.Run("Remove", func(t *testing.T) {
+					assert := require.New(t)
+
+					_, err := ts.api.RemoveDeviceFromMulticastGroup(context.Background(), &ns.RemoveDeviceFromMulticastGroupRequest{
+						DevEui:           devEUI[:],
+						MulticastGroupId: mg1.ID.Bytes(),
+					})
+					assert.NoError(err)
+
+					_, err = ts.api.RemoveDeviceFromMulticastGroup(context.Background(), &ns.RemoveDeviceFromMulticastGroupRequest{
+						DevEui:           devEUI[:],
+						MulticastGroupId: mg1.ID.Bytes(),
+					})
+					assert.Error(err)
+					assert.Equal(codes.NotFound, grpc.Code(err))
+				})
+			})
+		})
+
+		t.Run("Activate", func(t *testing.T) {
+			assert := require.New(t)
+
+			devEUI := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+			devAddr := [4]byte{6, 2, 3, 4}
+			sNwkSIntKey := [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+			fNwkSIntKey := [16]byte{2, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+			nwkSEncKey := [16]byte{3, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+			_, err = ts.api.ActivateDevice(context.Background(), &ns.ActivateDeviceRequest{
+				DeviceActivation: &ns.DeviceActivation{
+					DevEui:        devEUI[:],
+					DevAddr:       devAddr[:],
+					SNwkSIntKey:   sNwkSIntKey[:],
+					FNwkSIntKey:   fNwkSIntKey[:],
+					NwkSEncKey:    nwkSEncKey[:],
+					FCntUp:        10,
+					NFCntDown:     11,
+					AFCntDown:     12,
+					SkipFCntCheck: false,
+				},
+			})
+			assert.NoError(err)
+
+			t.Run("Enqueue with different security-context", func(t *testing.T) {
+				assert := require.New(t)
+
+				// create item in the queue (device is not activated yet)
+				_, err := ts.api.CreateDeviceQueueItem(context.Background(), &ns.CreateDeviceQueueItemRequest{
+					Item: &ns.DeviceQueueItem{
+						DevAddr:    []byte{1, 2, 3, 4},
+						DevEui:     devEUI[:],
+						FrmPayload: []byte{1, 2, 3, 4},
+						FCnt:       10,
+						FPort:      20,
+					},
+				})
+				assert.NotNil(err)
+				assert.Equal(codes.InvalidArgument, grpc.Code(err))
+			})
+
+			t.Run("Enqueue with valid security-context", func(t *testing.T) {
+				assert := require.New(t)
+
+				// create item in the queue (device is not activated yet)
+				_, err := ts.api.CreateDeviceQueueItem(context.Background(), &ns.CreateDeviceQueueItemRequest{
+					Item: &ns.DeviceQueueItem{
+						DevAddr:    []byte{6, 2, 3, 4},
+						DevEui:     devEUI[:],
+						FrmPayload: []byte{1, 2, 3, 4},
+						FCnt:       10,
+						FPort:      20,
+					},
+				})
+				assert.Nil(err)
+			})
+
+			_, err = ts.api.ActivateDevice(context.Background(), &ns.ActivateDeviceRequest{
+				DeviceActivation: &ns.DeviceActivation{
+					DevEui:        devEUI[:],
+					DevAddr:       devAddr[:],
+					SNwkSIntKey:   sNwkSIntKey[:],
+					FNwkSIntKey:   fNwkSIntKey[:],
+					NwkSEncKey:    nwkSEncKey[:],
+					FCntUp:        10,
+					NFCntDown:     11,
+					AFCntDown:     12,
+					SkipFCntCheck: false,
+				},
+			})
+			assert.NoError(err)
+
+			t.Run("Device-queue is flushed", func(t *testing.T) {
+				assert := require.New(t)
+				items, err := storage.GetDeviceQueueItemsForDevEUI(context.Background(), storage.DB(), devEUI)
+				assert.NoError(err)
+				assert.Len(items, 0)
+			})
+
+			t.Run("Device-session is created", func(t *testing.T) {
+				ds, err := storage.GetDeviceSession(context.Background(), storage.RedisPool(), devEUI)
+				assert.NoError(err)
+				assert.Equal(storage.DeviceSession{
+					DeviceProfileID:  dp.ID,
+					ServiceProfileID: sp.ID,
+					RoutingProfileID: rp.ID,
+
+					DevAddr:               devAddr,
+					DevEUI:                devEUI,
+					SNwkSIntKey:           sNwkSIntKey,
+					FNwkSIntKey:           fNwkSIntKey,
+					NwkSEncKey:            nwkSEncKey,
+					FCntUp:                10,
+					NFCntDown:             11,
+					AFCntDown:             12,
+					SkipFCntValidation:    true,
+					EnabledUplinkChannels: band.Band().GetEnabledUplinkChannelIndices(),
+					ChannelFrequencies:    []int{868100000, 868300000, 868500000},
+					ExtraUplinkChannels:   map[int]loraband.Channel{},
+					RXDelay:               3,
+					RX1DROffset:           2,
+					RX2DR:                 5,
+					RX2Frequency:          868900000,
+					PingSlotNb:            128,
+					PingSlotDR:            5,
+					PingSlotFrequency:     868100000,
+					NbTrans:               1,
+					MACVersion:            "1.0.2",
+				}, ds)
+			})
+
+			t.Run("GetDeviceActivation", func(t *testing.T) {
+				assert := require.New(t)
+
+				resp, err := ts.api.GetDeviceActivation(context.Background(), &ns.GetDeviceActivationRequest{DevEui: devEUI[:]})
+				assert.NoError(err)
+				assert.Equal(&ns.DeviceActivation{
+					DevEui:        devEUI[:],
+					DevAddr:       devAddr[:],
+					SNwkSIntKey:   sNwkSIntKey[:],
+					FNwkSIntKey:   fNwkSIntKey[:],
+					NwkSEncKey:    nwkSEncKey[:],
+					FCntUp:        10,
+					NFCntDown:     11,
+					AFCntDown:     12,
+					SkipFCntCheck: true,
+				}, resp.DeviceActivation)
+			})
+
+			t.Run("GetNextDownlinkFCntForDevEUI", func(t *testing.T) {
+				t.Run("LoRaWAN 1.0", func(t *testing.T) {
+					assert := require.New(t)
+					resp, err := ts.api.GetNextDownlinkFCntForDevEUI(context.Background(), &ns.GetNextDownlinkFCntForDevEUIRequest{DevEui: devEUI[:]})
+					assert.NoError(err)
+					assert.EqualValues(11, resp.FCnt)
+				})
+
+				t.Run("LoRaWAN 1.1", func(t *testing.T) {
+					assert := require.New(t)
+					ds, err := storage.GetDeviceSession(context.Background(), storage.RedisPool(), devEUI)
+					assert.NoError(err)
+					ds.MACVersion = "1.1.0"
+					assert.NoError(storage.SaveDeviceSession(context.Background(), storage.RedisPool(), ds))
+
+					resp, err := ts.api.GetNextDownlinkFCntForDevEUI(context.Background(), &ns.GetNextDownlinkFCntForDevEUIRequest{DevEui: devEUI[:]})
+					assert.NoError(err)
+					assert.EqualValues(12, resp.FCnt)
+				})
+
+				t.Run("With item in device-queue", func(t *testing.T) {
+					assert := require.New(t)
+					_, err := ts.api.CreateDeviceQueueItem(context.Background(), &ns.CreateDeviceQueueItemRequest{
+						Item: &ns.DeviceQueueItem{
+							DevEui:     devEUI[:],
+							FrmPayload: []byte{1, 2, 3, 4},
+							FCnt:       13,
+							FPort:      20,
+						},
+					})
+					assert.NoError(err)
+
+					resp, err := ts.api.GetNextDownlinkFCntForDevEUI(context.Background(), &ns.GetNextDownlinkFCntForDevEUIRequest{DevEui: devEUI[:]})
+					assert.NoError(err)
+					assert.EqualValues(14, resp.FCnt)
+				})
+			})
+
+			t.Run("DeactivateDevice", func(t *testing.T) {
+				assert := require.New(t)
+
+				items, err := storage.GetDeviceQueueItemsForDevEUI(context.Background(), storage.DB(), devEUI)
+				assert.NoError(err)
+				assert.Len(items, 1)
+
+				_, err = ts.api.DeactivateDevice(context.Background(), &ns.DeactivateDeviceRequest{
+					DevEui: devEUI[:],
+				})
+				assert.NoError(err)
+
+				_, err = ts.api.GetDeviceActivation(context.Background(), &ns.GetDeviceActivationRequest{DevEui: devEUI[:]})
+				assert.Equal(codes.NotFound, grpc.Code(err))
+
+				items, err = storage.GetDeviceQueueItemsForDevEUI(context.Background(), storage.DB(), devEUI)
+				assert.NoError(err)
+				assert.Len(items, 0)
+			})
+
+			t.Run("Activate with Device.SkipFCntCheck set to true", func(t *testing.T) {
+				d.SkipFCntCheck = true
+				_, err := ts.api.UpdateDevice(context.Background(), &ns.UpdateDeviceRequest{
+					Device: d,
+				})
+				assert.NoError(err)
+
+				_, err = ts.api.ActivateDevice(context.Background(), &ns.ActivateDeviceRequest{
+					DeviceActivation: &ns.DeviceActivation{
+						DevEui:        devEUI[:],
+						DevAddr:       devAddr[:],
+						SNwkSIntKey:   sNwkSIntKey[:],
+						FNwkSIntKey:   fNwkSIntKey[:],
+						NwkSEncKey:    nwkSEncKey[:],
+						FCntUp:        10,
+						NFCntDown:     11,
+						AFCntDown:     12,
+						SkipFCntCheck: false,
+					},
+				})
+				assert.NoError(err)
+
+				ds, err := storage.GetDeviceSession(context.Background(), storage.RedisPool(), devEUI)
+				assert.NoError(err)
+				assert.True(ds.SkipFCntValidation)
+			})
+
+			t.Run("Device mode", func(t *testing.T) {
+				tests := []struct {
+					Name            string
+					ClassBSupported bool
+					ClassCSupported bool
+					MACVersion      string
+					ExpectedMode    storage.DeviceMode
+				}{
+					{
+						Name:         "LoRaWAN 1.0 Class A supported",
+						MACVersion:   "1.0.3",
+						ExpectedMode: storage.DeviceModeA,
+					},
+					{
+						Name:            "LoRaWAN 1.0 Class B supported",
+						MACVersion:      "1.0.3",
+						ClassBSupported: true,
+						ExpectedMode:    storage.DeviceModeA,
+					},
+					{
+						Name:            "LoRaWAN 1.0 Class C supported",
+						MACVersion:      "1.0.3",
+						ClassCSupported: true,
+						ExpectedMode:    storage.DeviceModeC,
+					},
+					{
+						Name:         "LoRaWAN 1.1 Class A supported",
+						MACVersion:   "1.1.0",
+						ExpectedMode: storage.DeviceModeA,
+					},
+					{
+						Name:            "LoRaWAN 1.1 Class B supported",
+						MACVersion:      "1.1.0",
+						ClassBSupported: true,
+						ExpectedMode:    storage.DeviceModeA,
+					},
+					{
+						Name:            "LoRaWAN 1.1 Class C supported",
+						MACVersion:      "1.1.0",
+						ClassCSupported: true,
+						ExpectedMode:    storage.DeviceModeC,
+					},
+				}
+
+				for _, tst := range tests {
+					t.Run(tst.Name, func(t *testing.T) {
+						assert := require.New(t)
+						dp.SupportsClassB = tst.ClassBSupported
+						dp.SupportsClassC = tst.ClassCSupported
+						dp.MACVersion = tst.MACVersion
+
+						assert.NoError(storage.UpdateDeviceProfile(context.Background(), storage.DB(), &dp))
+
+						_, err = ts.api.ActivateDevice(context.Background(), &ns.ActivateDeviceRequest{
+							DeviceActivation: &ns.DeviceActivation{
+								DevEui:      devEUI[:],
+								DevAddr:     devAddr[:],
+								SNwkSIntKey: sNwkSIntKey[:],
+								FNwkSIntKey: fNwkSIntKey[:],
+								NwkSEncKey:  nwkSEncKey[:],
+							},
+						})
+						assert.NoError(err)
+
+						d, err := storage.GetDevice(context.Background(), storage.DB(), devEUI)
+						assert.NoError(err)
+						assert.Equal(tst.ExpectedMode, d.Mode)
+					})
+				}
+			})
+		})
+
+		t.Run("Update", func(t *testing.T) {
+			assert := require.New(t)
+
+			rp2 := storage.RoutingProfile{}
+			assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp2))
+
+			d.RoutingProfileId = rp2.ID.Bytes()
+			_, err := ts.api.UpdateDevice(context.Background(), &ns.UpdateDeviceRequest{
+				Device: d,
+			})
+			assert.NoError(err)
+
+			getResp, err := ts.api.GetDevice(context.Background(), &ns.GetDeviceRequest{
+				DevEui: devEUI[:],
+			})
+			assert.NoError(err)
+			assert.Equal(d, getResp.Device)
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			assert := require.New(t)
+
+			_, err := ts.api.DeleteDevice(context.Background(), &ns.DeleteDeviceRequest{
+				DevEui: devEUI[:],
+			})
+			assert.NoError(err)
+
+			_, err = ts.api.DeleteDevice(context.Background(), &ns.DeleteDeviceRequest{
+				DevEui: devEUI[:],
+			})
+			assert.Error(err)
+			assert.Equal(codes.NotFound, grpc.Code(err))
+		})
+	})
+}
+
+func TestNetworkServerAPISuite(t *testing.T) {
+	suite.Run(t, new(NetworkServerAPITestSuite))
+}
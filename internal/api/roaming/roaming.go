@@ -0,0 +1,493 @@
+// Package roaming implements the LoRaWAN Backend Interfaces roaming API,
+// used by roaming partners to send passive-roaming requests.
+package roaming
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/dutycycle"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/txschedule"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/chirpstack-network-server/internal/roaming"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/chirpstack-network-server/internal/uplink/data"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// Setup configures the roaming API.
+func Setup(c config.Config) error {
+	conf := c.Roaming.API
+	if conf.Bind == "" {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"bind":     conf.Bind,
+		"ca_cert":  conf.CACert,
+		"tls_cert": conf.TLSCert,
+		"tls_key":  conf.TLSKey,
+	}).Info("api/roaming: starting roaming api")
+
+	server := http.Server{
+		Handler:   &API{},
+		Addr:      conf.Bind,
+		TLSConfig: &tls.Config{},
+	}
+
+	if conf.CACert == "" && conf.TLSCert == "" && conf.TLSKey == "" {
+		go func() {
+			err := server.ListenAndServe()
+			log.WithError(err).Fatal("api/roaming: roaming api error")
+		}()
+		return nil
+	}
+
+	if conf.CACert != "" {
+		caCert, err := ioutil.ReadFile(conf.CACert)
+		if err != nil {
+			return errors.Wrap(err, "read ca certificate error")
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return errors.New("append ca certificate error")
+		}
+
+		server.TLSConfig.ClientCAs = caCertPool
+		server.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	go func() {
+		err := server.ListenAndServeTLS(conf.TLSCert, conf.TLSKey)
+		log.WithError(err).Fatal("api/roaming: roaming api error")
+	}()
+
+	return nil
+}
+
+// API implements the roaming API.
+type API struct{}
+
+// ServeHTTP handles a roaming request.
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctxID, err := uuid.NewV4()
+	if err != nil {
+		log.WithError(err).Error("api/roaming: get new uuid error")
+	}
+	ctx := context.WithValue(r.Context(), logging.ContextIDKey, ctxID)
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.WithError(err).Error("api/roaming: read request body error")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var basePL backend.BasePayload
+	if err := json.Unmarshal(b, &basePL); err != nil {
+		a.writeResponse(ctx, w, backend.BasePayload{}, backend.Result{
+			ResultCode:  backend.MalformedRequest,
+			Description: err.Error(),
+		})
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"message_type":   basePL.MessageType,
+		"sender_id":      basePL.SenderID,
+		"receiver_id":    basePL.ReceiverID,
+		"transaction_id": basePL.TransactionID,
+		"ctx_id":         ctxID,
+	}).Info("api/roaming: request received")
+
+	var senderID lorawan.NetID
+	if err := senderID.UnmarshalText([]byte(basePL.SenderID)); err != nil {
+		a.writeResponse(ctx, w, basePL, backend.Result{
+			ResultCode:  backend.UnknownSender,
+			Description: err.Error(),
+		})
+		return
+	}
+
+	// When a client-certificate is presented, its CommonName must match
+	// the SenderID, so that a roaming partner can not act as another
+	// roaming partner.
+	if r.TLS != nil && len(r.TLS.PeerCertificates) != 0 {
+		var certNetID lorawan.NetID
+		if err := certNetID.UnmarshalText([]byte(r.TLS.PeerCertificates[0].Subject.CommonName)); err != nil || certNetID != senderID {
+			a.writeResponse(ctx, w, basePL, backend.Result{
+				ResultCode:  backend.UnknownSender,
+				Description: "client-certificate does not match SenderID",
+			})
+			return
+		}
+	}
+
+	if !roaming.IsPassiveRoamingAllowed(senderID) {
+		a.writeResponse(ctx, w, basePL, backend.Result{
+			ResultCode:  backend.NoRoamingAgreement,
+			Description: "no passive-roaming agreement for sender",
+		})
+		return
+	}
+
+	switch basePL.MessageType {
+	case backend.PRStartReq:
+		a.handlePRStartReq(ctx, w, senderID, basePL, b)
+	case backend.XmitDataReq:
+		a.handleXmitDataReq(ctx, w, senderID, basePL, b)
+	default:
+		a.writeResponse(ctx, w, basePL, backend.Result{
+			ResultCode:  backend.MalformedRequest,
+			Description: "unexpected message-type: " + string(basePL.MessageType),
+		})
+	}
+}
+
+func (a *API) handlePRStartReq(ctx context.Context, w http.ResponseWriter, senderID lorawan.NetID, basePL backend.BasePayload, b []byte) {
+	var pl backend.PRStartReqPayload
+	if err := json.Unmarshal(b, &pl); err != nil {
+		a.writeResponse(ctx, w, basePL, backend.Result{
+			ResultCode:  backend.MalformedRequest,
+			Description: err.Error(),
+		})
+		return
+	}
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(pl.PHYPayload[:]); err != nil {
+		a.writeResponse(ctx, w, basePL, backend.Result{
+			ResultCode:  backend.MalformedRequest,
+			Description: err.Error(),
+		})
+		return
+	}
+
+	// Roaming activation (join through a fNS) is not supported.
+	if phy.MHDR.MType != lorawan.UnconfirmedDataUp && phy.MHDR.MType != lorawan.ConfirmedDataUp {
+		a.writeResponse(ctx, w, basePL, backend.Result{
+			ResultCode:  backend.RoamingActDisallowed,
+			Description: "only uplink data frames are supported",
+		})
+		return
+	}
+
+	ds, result := a.handleUplink(ctx, senderID, phy, pl.ULMetaData)
+	if result.ResultCode != backend.Success {
+		a.writeResponse(ctx, w, basePL, result)
+		return
+	}
+
+	ans := backend.PRStartAnsPayload{
+		BasePayload: a.getBasePayload(basePL, backend.PRStartAns),
+		Result:      result,
+		DevEUI:      &ds.DevEUI,
+	}
+
+	// the device-session contains the next expected frame-counter
+	fCntUp := ds.FCntUp - 1
+	ans.FCntUp = &fCntUp
+
+	lifetime := int(roaming.GetPassiveRoamingLifetime(senderID) / time.Second)
+	ans.Lifetime = &lifetime
+
+	// In case of a stateful passive-roaming session, the fNS needs the
+	// session-key to validate the MIC of the uplinks.
+	if lifetime > 0 {
+		kekLabel := roaming.GetPassiveRoamingKEKLabel(senderID)
+		ke, err := roaming.WrapKeyEnvelope(kekLabel, ds.FNwkSIntKey)
+		if err != nil {
+			a.writeResponse(ctx, w, basePL, backend.Result{
+				ResultCode:  backend.Other,
+				Description: err.Error(),
+			})
+			return
+		}
+
+		if ds.GetMACVersion() == lorawan.LoRaWAN1_0 {
+			ans.NwkSKey = ke
+		} else {
+			ans.FNwkSIntKey = ke
+		}
+	}
+
+	a.writeJSON(ctx, w, ans)
+}
+
+func (a *API) handleXmitDataReq(ctx context.Context, w http.ResponseWriter, senderID lorawan.NetID, basePL backend.BasePayload, b []byte) {
+	var pl backend.XmitDataReqPayload
+	if err := json.Unmarshal(b, &pl); err != nil {
+		a.writeResponse(ctx, w, basePL, backend.Result{
+			ResultCode:  backend.MalformedRequest,
+			Description: err.Error(),
+		})
+		return
+	}
+
+	switch {
+	case pl.ULMetaData != nil:
+		var phy lorawan.PHYPayload
+		if err := phy.UnmarshalBinary(pl.PHYPayload[:]); err != nil {
+			a.writeResponse(ctx, w, basePL, backend.Result{
+				ResultCode:  backend.MalformedRequest,
+				Description: err.Error(),
+			})
+			return
+		}
+
+		_, result := a.handleUplink(ctx, senderID, phy, *pl.ULMetaData)
+		a.writeResponse(ctx, w, basePL, result)
+	case pl.DLMetaData != nil:
+		if err := a.sendDownlink(ctx, pl.PHYPayload, *pl.DLMetaData); err != nil {
+			a.writeResponse(ctx, w, basePL, backend.Result{
+				ResultCode:  backend.XmitFailed,
+				Description: err.Error(),
+			})
+			return
+		}
+		a.writeResponse(ctx, w, basePL, backend.Result{ResultCode: backend.Success})
+	default:
+		a.writeResponse(ctx, w, basePL, backend.Result{
+			ResultCode:  backend.MalformedRequest,
+			Description: "ULMetaData or DLMetaData must be set",
+		})
+	}
+}
+
+// handleUplink handles the uplink as sNS.
+func (a *API) handleUplink(ctx context.Context, senderID lorawan.NetID, phy lorawan.PHYPayload, md backend.ULMetaData) (storage.DeviceSession, backend.Result) {
+	rxPacket, err := roaming.ULMetaDataToRXPacket(phy, md)
+	if err != nil {
+		return storage.DeviceSession{}, backend.Result{
+			ResultCode:  backend.MalformedRequest,
+			Description: err.Error(),
+		}
+	}
+
+	ds, err := data.HandleRoamingSNS(ctx, senderID, rxPacket, md)
+	if err != nil {
+		switch errors.Cause(err) {
+		case storage.ErrDoesNotExistOrFCntOrMICInvalid:
+			return ds, backend.Result{ResultCode: backend.MICFailed, Description: err.Error()}
+		case data.ErrRoamingNotAllowed:
+			return ds, backend.Result{ResultCode: backend.DevRoamingDisallowed, Description: err.Error()}
+		case data.ErrDropped:
+			// the uplink was dropped on purpose (e.g. rate-limit)
+			return ds, backend.Result{ResultCode: backend.Deferred, Description: err.Error()}
+		default:
+			log.WithFields(log.Fields{
+				"ctx_id": ctx.Value(logging.ContextIDKey),
+			}).WithError(err).Error("api/roaming: handle uplink error")
+
+			return ds, backend.Result{ResultCode: backend.Other, Description: err.Error()}
+		}
+	}
+
+	return ds, backend.Result{ResultCode: backend.Success}
+}
+
+// sendDownlink sends the downlink received from the sNS to the gateway
+// (as fNS). The ULToken refers to the rx-info of the gateway that received
+// the uplink. The first gateway that is able to transmit within the
+// duty-cycle limitations and tx schedule is used.
+func (a *API) sendDownlink(ctx context.Context, phyB backend.HEXBytes, md backend.DLMetaData) error {
+	delay := time.Second
+	if md.RXDelay1 != nil && *md.RXDelay1 > 0 {
+		delay = time.Duration(*md.RXDelay1) * time.Second
+	}
+
+	var freq float64
+	var dr int
+	switch {
+	case md.DLFreq1 != nil && md.DataRate1 != nil:
+		freq = *md.DLFreq1
+		dr = *md.DataRate1
+	case md.DLFreq2 != nil && md.DataRate2 != nil:
+		freq = *md.DLFreq2
+		dr = *md.DataRate2
+		delay = delay + time.Second
+	default:
+		return errors.New("DLFreq1 and DataRate1 or DLFreq2 and DataRate2 must be set")
+	}
+
+	txInfo := gw.DownlinkTXInfo{
+		Frequency: uint32(math.Round(freq * 1000000)),
+		Timing:    gw.DownlinkTiming_DELAY,
+		TimingInfo: &gw.DownlinkTXInfo_DelayTimingInfo{
+			DelayTimingInfo: &gw.DelayTimingInfo{
+				Delay: ptypes.DurationProto(delay),
+			},
+		},
+	}
+	txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+
+	if err := helpers.SetDownlinkTXInfoDataRate(&txInfo, dr, band.Band()); err != nil {
+		return errors.Wrap(err, "set downlink tx-info data-rate error")
+	}
+
+	ok, err := a.setAvailableGateway(ctx, &txInfo, md.GWInfo, len(phyB))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("no gateway available within duty-cycle limits and tx schedule")
+	}
+
+	var downlinkID uuid.UUID
+	if ctxID := ctx.Value(logging.ContextIDKey); ctxID != nil {
+		if id, ok := ctxID.(uuid.UUID); ok {
+			downlinkID = id
+		}
+	}
+
+	df := gw.DownlinkFrame{
+		Token:      uint32(binary.BigEndian.Uint16(downlinkID[0:2])),
+		DownlinkId: downlinkID[:],
+		TxInfo:     &txInfo,
+		PhyPayload: phyB[:],
+	}
+
+	if err := gateway.Backend().SendTXPacket(df); err != nil {
+		return errors.Wrap(err, "send downlink-frame to gateway error")
+	}
+
+	// save the used airtime for duty-cycle accounting
+	if err := dutycycle.SaveAirtime(ctx, df); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"ctx_id": ctx.Value(logging.ContextIDKey),
+		}).Error("api/roaming: save airtime error")
+	}
+
+	// save the emission in the gateway tx schedule
+	if err := txschedule.SaveScheduleItem(ctx, df); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"ctx_id": ctx.Value(logging.ContextIDKey),
+		}).Error("api/roaming: save tx schedule item error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id":  helpers.GetGatewayID(&txInfo),
+		"downlink_id": downlinkID,
+		"ctx_id":      ctx.Value(logging.ContextIDKey),
+	}).Info("api/roaming: roaming downlink sent to gateway")
+
+	return nil
+}
+
+// setAvailableGateway sets the first gateway (ordered by the GWInfo
+// elements) to the tx-info that is able to transmit within the duty-cycle
+// limitations and tx schedule. ULTokens which are unknown or expired are
+// skipped. It returns false when no gateway could be found.
+func (a *API) setAvailableGateway(ctx context.Context, txInfo *gw.DownlinkTXInfo, gwInfoSet []backend.GWInfoElement, size int) (bool, error) {
+	for _, gwInfo := range gwInfoSet {
+		ulToken, err := uuid.FromBytes(gwInfo.ULToken[:])
+		if err != nil {
+			continue
+		}
+
+		rxInfo, err := storage.GetPassiveRoamingULToken(ctx, storage.RedisPool(), ulToken)
+		if err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				log.WithFields(log.Fields{
+					"ul_token": ulToken,
+					"ctx_id":   ctx.Value(logging.ContextIDKey),
+				}).Warning("api/roaming: unknown or expired ul token")
+				continue
+			}
+			return false, errors.Wrap(err, "get ul token error")
+		}
+
+		txInfo.GatewayId = rxInfo.GatewayId
+		txInfo.Board = rxInfo.Board
+		txInfo.Antenna = rxInfo.Antenna
+		txInfo.Context = rxInfo.Context
+
+		ok, err := dutycycle.CanTransmit(ctx, txInfo, size)
+		if err != nil {
+			return false, errors.Wrap(err, "check duty-cycle error")
+		}
+
+		if !ok {
+			continue
+		}
+
+		ok, err = txschedule.CanSchedule(ctx, txInfo, size)
+		if err != nil {
+			return false, errors.Wrap(err, "check tx schedule error")
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (a *API) getBasePayload(req backend.BasePayload, mt backend.MessageType) backend.BasePayload {
+	return backend.BasePayload{
+		ProtocolVersion: backend.ProtocolVersion1_0,
+		SenderID:        roaming.NetID().String(),
+		ReceiverID:      req.SenderID,
+		TransactionID:   req.TransactionID,
+		MessageType:     mt,
+		ReceiverToken:   req.SenderToken,
+	}
+}
+
+// writeResponse writes the answer message, containing only the Result
+// object, for the given request.
+func (a *API) writeResponse(ctx context.Context, w http.ResponseWriter, req backend.BasePayload, result backend.Result) {
+	var mt backend.MessageType
+	switch req.MessageType {
+	case backend.PRStartReq:
+		mt = backend.PRStartAns
+	case backend.XmitDataReq:
+		mt = backend.XmitDataAns
+	}
+
+	a.writeJSON(ctx, w, struct {
+		backend.BasePayload
+		Result backend.Result `json:"Result"`
+	}{
+		BasePayload: a.getBasePayload(req, mt),
+		Result:      result,
+	})
+}
+
+func (a *API) writeJSON(ctx context.Context, w http.ResponseWriter, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"ctx_id": ctx.Value(logging.ContextIDKey),
+		}).WithError(err).Error("api/roaming: marshal response error")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(b); err != nil {
+		log.WithFields(log.Fields{
+			"ctx_id": ctx.Value(logging.ContextIDKey),
+		}).WithError(err).Error("api/roaming: write response error")
+	}
+}
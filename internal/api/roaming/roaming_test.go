@@ -0,0 +1,542 @@
+package roaming
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/applicationserver"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/controller"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink"
+	"github.com/brocaar/chirpstack-network-server/internal/roaming"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/chirpstack-network-server/internal/uplink"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+type roamingClient struct {
+	xmitDataReqChan chan backend.XmitDataReqPayload
+}
+
+func (c *roamingClient) PRStartReq(ctx context.Context, pl backend.PRStartReqPayload) (backend.PRStartAnsPayload, error) {
+	return backend.PRStartAnsPayload{}, nil
+}
+
+func (c *roamingClient) XmitDataReq(ctx context.Context, pl backend.XmitDataReqPayload) (backend.XmitDataAnsPayload, error) {
+	c.xmitDataReqChan <- pl
+	return backend.XmitDataAnsPayload{}, nil
+}
+
+type RoamingAPITestSuite struct {
+	suite.Suite
+
+	api      *API
+	netID    lorawan.NetID
+	client   *roamingClient
+	asClient *test.ApplicationClient
+
+	serviceProfile storage.ServiceProfile
+	deviceSession  storage.DeviceSession
+}
+
+func (ts *RoamingAPITestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustResetDB(storage.DB().DB)
+
+	band.Setup(conf)
+	uplink.Setup(conf)
+	downlink.Setup(conf)
+
+	ts.api = &API{}
+	ts.netID = lorawan.NetID{1, 2, 3}
+}
+
+func (ts *RoamingAPITestSuite) SetupTest() {
+	assert := require.New(ts.T())
+	test.MustFlushRedis(storage.RedisPool())
+
+	ts.asClient = test.NewApplicationClient()
+	applicationserver.SetPool(test.NewApplicationServerPool(ts.asClient))
+	controller.SetClient(test.NewNetworkControllerClient())
+	gateway.SetBackend(test.NewGatewayBackend())
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	ts.serviceProfile = storage.ServiceProfile{
+		PRAllowed: true,
+	}
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &ts.serviceProfile))
+
+	dp := storage.DeviceProfile{}
+	assert.NoError(storage.CreateDeviceProfile(context.Background(), storage.DB(), &dp))
+
+	d := storage.Device{
+		DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		RoutingProfileID: rp.ID,
+		ServiceProfileID: ts.serviceProfile.ID,
+		DeviceProfileID:  dp.ID,
+	}
+	assert.NoError(storage.CreateDevice(context.Background(), storage.DB(), &d))
+
+	ts.deviceSession = storage.DeviceSession{
+		MACVersion:            "1.0.2",
+		DevEUI:                d.DevEUI,
+		DevAddr:               lorawan.DevAddr{1, 2, 3, 4},
+		RoutingProfileID:      rp.ID,
+		ServiceProfileID:      ts.serviceProfile.ID,
+		DeviceProfileID:       dp.ID,
+		FNwkSIntKey:           lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		SNwkSIntKey:           lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		NwkSEncKey:            lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		FCntUp:                8,
+		NFCntDown:             5,
+		EnabledUplinkChannels: []int{0, 1, 2},
+		RX2Frequency:          869525000,
+	}
+	assert.NoError(storage.SaveDeviceSession(context.Background(), storage.RedisPool(), ts.deviceSession))
+
+	ts.setupRoaming(0)
+}
+
+// setupRoaming configures the passive-roaming agreement with the sender
+// using the given lifetime.
+func (ts *RoamingAPITestSuite) setupRoaming(lifetime time.Duration) {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+	conf.Roaming.Servers = []struct {
+		NetID                  string        `mapstructure:"net_id"`
+		PassiveRoaming         bool          `mapstructure:"passive_roaming"`
+		PassiveRoamingLifetime time.Duration `mapstructure:"passive_roaming_lifetime"`
+		PassiveRoamingKEKLabel string        `mapstructure:"passive_roaming_kek_label"`
+		Server                 string        `mapstructure:"server"`
+		CACert                 string        `mapstructure:"ca_cert"`
+		TLSCert                string        `mapstructure:"tls_cert"`
+		TLSKey                 string        `mapstructure:"tls_key"`
+	}{
+		{
+			NetID:                  ts.netID.String(),
+			PassiveRoaming:         true,
+			PassiveRoamingLifetime: lifetime,
+			Server:                 "http://localhost:1234",
+		},
+	}
+	assert.NoError(roaming.Setup(conf))
+
+	ts.client = &roamingClient{
+		xmitDataReqChan: make(chan backend.XmitDataReqPayload, 100),
+	}
+	assert.NoError(roaming.SetClientForNetID(ts.netID, ts.client))
+}
+
+// getPHYPayload returns the uplink PHYPayload for the device-session.
+func (ts *RoamingAPITestSuite) getPHYPayload(mType lorawan.MType) backend.HEXBytes {
+	assert := require.New(ts.T())
+	fPort := uint8(1)
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: mType,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: ts.deviceSession.DevAddr,
+				FCnt:    ts.deviceSession.FCntUp,
+			},
+			FPort: &fPort,
+			FRMPayload: []lorawan.Payload{
+				&lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}},
+			},
+		},
+	}
+	assert.NoError(phy.SetUplinkDataMIC(lorawan.LoRaWAN1_0, 0, 5, 0, ts.deviceSession.FNwkSIntKey, ts.deviceSession.SNwkSIntKey))
+
+	b, err := phy.MarshalBinary()
+	assert.NoError(err)
+	return backend.HEXBytes(b)
+}
+
+// getULMetaData returns the ULMetaData as sent by the fNS.
+func (ts *RoamingAPITestSuite) getULMetaData() backend.ULMetaData {
+	assert := require.New(ts.T())
+
+	ulToken, err := storage.SavePassiveRoamingULToken(context.Background(), storage.RedisPool(), &gw.UplinkRXInfo{
+		GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+		Context:   []byte{1, 2, 3, 4},
+	}, time.Minute)
+	assert.NoError(err)
+
+	dr := 5
+	freq := 868.1
+	rssi := -60
+	snr := 5.5
+	gwCnt := 1
+
+	return backend.ULMetaData{
+		DataRate: &dr,
+		ULFreq:   &freq,
+		RecvTime: backend.ISO8601Time(time.Now().UTC()),
+		RFRegion: backend.EU868,
+		GWCnt:    &gwCnt,
+		GWInfo: []backend.GWInfoElement{
+			{
+				ID:        backend.HEXBytes{8, 7, 6, 5, 4, 3, 2, 1},
+				RFRegion:  backend.EU868,
+				RSSI:      &rssi,
+				SNR:       &snr,
+				ULToken:   backend.HEXBytes(ulToken[:]),
+				DLAllowed: true,
+			},
+		},
+	}
+}
+
+func (ts *RoamingAPITestSuite) request(pl interface{}, ans interface{}) {
+	assert := require.New(ts.T())
+
+	b, err := json.Marshal(pl)
+	assert.NoError(err)
+
+	r := httptest.NewRequest("POST", "/", bytes.NewReader(b))
+	w := httptest.NewRecorder()
+	ts.api.ServeHTTP(w, r)
+	assert.Equal(http.StatusOK, w.Code)
+
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), ans))
+}
+
+func (ts *RoamingAPITestSuite) getBasePayload(mt backend.MessageType) backend.BasePayload {
+	return backend.BasePayload{
+		ProtocolVersion: backend.ProtocolVersion1_0,
+		SenderID:        ts.netID.String(),
+		ReceiverID:      roaming.NetID().String(),
+		TransactionID:   1234,
+		MessageType:     mt,
+	}
+}
+
+func (ts *RoamingAPITestSuite) TestStateless() {
+	assert := require.New(ts.T())
+
+	ulMetaData := ts.getULMetaData()
+
+	var ans backend.PRStartAnsPayload
+	ts.request(backend.PRStartReqPayload{
+		BasePayload: ts.getBasePayload(backend.PRStartReq),
+		PHYPayload:  ts.getPHYPayload(lorawan.ConfirmedDataUp),
+		ULMetaData:  ulMetaData,
+	}, &ans)
+
+	assert.Equal(backend.Success, ans.Result.ResultCode)
+	assert.Equal(backend.PRStartAns, ans.MessageType)
+	assert.EqualValues(1234, ans.TransactionID)
+	assert.Equal(ts.deviceSession.DevEUI, *ans.DevEUI)
+	assert.Equal(ts.deviceSession.FCntUp, *ans.FCntUp)
+	assert.Equal(0, *ans.Lifetime)
+	assert.Nil(ans.NwkSKey)
+	assert.Nil(ans.FNwkSIntKey)
+
+	ds, err := storage.GetDeviceSession(context.Background(), storage.RedisPool(), ts.deviceSession.DevEUI)
+	assert.NoError(err)
+	assert.Equal(ts.deviceSession.FCntUp+1, ds.FCntUp)
+
+	ts.T().Run("Downlink is sent to fNS", func(t *testing.T) {
+		assert := require.New(t)
+
+		var req backend.XmitDataReqPayload
+		select {
+		case req = <-ts.client.xmitDataReqChan:
+		case <-time.After(time.Second):
+			t.Fatal("no XmitDataReq received")
+		}
+
+		assert.NotNil(req.DLMetaData)
+		assert.Nil(req.ULMetaData)
+		assert.Equal(ts.deviceSession.DevEUI, *req.DLMetaData.DevEUI)
+		assert.Equal(ts.deviceSession.NFCntDown, *req.DLMetaData.FCntDown)
+		assert.Equal("A", *req.DLMetaData.ClassMode)
+		assert.Equal(1, *req.DLMetaData.RXDelay1)
+		assert.Equal(868.1, *req.DLMetaData.DLFreq1)
+		assert.Equal(5, *req.DLMetaData.DataRate1)
+		assert.Equal(869.525, *req.DLMetaData.DLFreq2)
+		assert.Equal(0, *req.DLMetaData.DataRate2)
+		assert.Len(req.DLMetaData.GWInfo, 1)
+		assert.Equal(ulMetaData.GWInfo[0].ULToken, req.DLMetaData.GWInfo[0].ULToken)
+
+		var phy lorawan.PHYPayload
+		assert.NoError(phy.UnmarshalBinary(req.PHYPayload[:]))
+		assert.Equal(lorawan.UnconfirmedDataDown, phy.MHDR.MType)
+
+		macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+		assert.True(ok)
+		assert.True(macPL.FHDR.FCtrl.ACK)
+		assert.Equal(ts.deviceSession.DevAddr, macPL.FHDR.DevAddr)
+	})
+}
+
+func (ts *RoamingAPITestSuite) TestStateful() {
+	assert := require.New(ts.T())
+	ts.setupRoaming(time.Hour)
+
+	var ans backend.PRStartAnsPayload
+	ts.request(backend.PRStartReqPayload{
+		BasePayload: ts.getBasePayload(backend.PRStartReq),
+		PHYPayload:  ts.getPHYPayload(lorawan.UnconfirmedDataUp),
+		ULMetaData:  ts.getULMetaData(),
+	}, &ans)
+
+	assert.Equal(backend.Success, ans.Result.ResultCode)
+	assert.Equal(3600, *ans.Lifetime)
+	assert.Nil(ans.FNwkSIntKey)
+	assert.NotNil(ans.NwkSKey)
+
+	key, err := roaming.UnwrapKeyEnvelope(ans.NwkSKey)
+	assert.NoError(err)
+	assert.Equal(ts.deviceSession.FNwkSIntKey, key)
+
+	ts.T().Run("XmitDataReq uplink", func(t *testing.T) {
+		assert := require.New(t)
+		ts.deviceSession.FCntUp++
+
+		var ans backend.XmitDataAnsPayload
+		ulMetaData := ts.getULMetaData()
+		ts.request(backend.XmitDataReqPayload{
+			BasePayload: ts.getBasePayload(backend.XmitDataReq),
+			PHYPayload:  ts.getPHYPayload(lorawan.UnconfirmedDataUp),
+			ULMetaData:  &ulMetaData,
+		}, &ans)
+
+		assert.Equal(backend.Success, ans.Result.ResultCode)
+		assert.Equal(backend.XmitDataAns, ans.MessageType)
+
+		ds, err := storage.GetDeviceSession(context.Background(), storage.RedisPool(), ts.deviceSession.DevEUI)
+		assert.NoError(err)
+		assert.Equal(ts.deviceSession.FCntUp+1, ds.FCntUp)
+	})
+}
+
+func (ts *RoamingAPITestSuite) TestRejected() {
+	ts.T().Run("Passive-roaming not allowed by service-profile", func(t *testing.T) {
+		assert := require.New(t)
+
+		ts.serviceProfile.PRAllowed = false
+		assert.NoError(storage.UpdateServiceProfile(context.Background(), storage.DB(), &ts.serviceProfile))
+		assert.NoError(storage.FlushServiceProfileCache(context.Background(), storage.RedisPool(), ts.serviceProfile.ID))
+
+		var ans backend.PRStartAnsPayload
+		ts.request(backend.PRStartReqPayload{
+			BasePayload: ts.getBasePayload(backend.PRStartReq),
+			PHYPayload:  ts.getPHYPayload(lorawan.UnconfirmedDataUp),
+			ULMetaData:  ts.getULMetaData(),
+		}, &ans)
+
+		assert.Equal(backend.DevRoamingDisallowed, ans.Result.ResultCode)
+		assert.Nil(ans.DevEUI)
+		assert.Nil(ans.Lifetime)
+	})
+
+	ts.T().Run("No roaming agreement", func(t *testing.T) {
+		assert := require.New(t)
+
+		basePL := ts.getBasePayload(backend.PRStartReq)
+		basePL.SenderID = lorawan.NetID{7, 8, 9}.String()
+
+		var ans backend.PRStartAnsPayload
+		ts.request(backend.PRStartReqPayload{
+			BasePayload: basePL,
+			PHYPayload:  ts.getPHYPayload(lorawan.UnconfirmedDataUp),
+			ULMetaData:  ts.getULMetaData(),
+		}, &ans)
+
+		assert.Equal(backend.NoRoamingAgreement, ans.Result.ResultCode)
+	})
+
+	ts.T().Run("Join-request", func(t *testing.T) {
+		assert := require.New(t)
+
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.JoinRequest,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.JoinRequestPayload{},
+		}
+		b, err := phy.MarshalBinary()
+		assert.NoError(err)
+
+		var ans backend.PRStartAnsPayload
+		ts.request(backend.PRStartReqPayload{
+			BasePayload: ts.getBasePayload(backend.PRStartReq),
+			PHYPayload:  backend.HEXBytes(b),
+			ULMetaData:  ts.getULMetaData(),
+		}, &ans)
+
+		assert.Equal(backend.RoamingActDisallowed, ans.Result.ResultCode)
+	})
+}
+
+func (ts *RoamingAPITestSuite) TestDropped() {
+	assert := require.New(ts.T())
+
+	ts.serviceProfile.ULRate = 1
+	ts.serviceProfile.ULBucketSize = 1
+	ts.serviceProfile.ULRatePolicy = storage.Drop
+	assert.NoError(storage.UpdateServiceProfile(context.Background(), storage.DB(), &ts.serviceProfile))
+	assert.NoError(storage.FlushServiceProfileCache(context.Background(), storage.RedisPool(), ts.serviceProfile.ID))
+
+	// empty the bucket of the device
+	_, err := storage.TakeUplinkRateLimitToken(context.Background(), storage.RedisPool(), ts.serviceProfile, ts.deviceSession.DevEUI)
+	assert.NoError(err)
+
+	var ans backend.PRStartAnsPayload
+	ts.request(backend.PRStartReqPayload{
+		BasePayload: ts.getBasePayload(backend.PRStartReq),
+		PHYPayload:  ts.getPHYPayload(lorawan.UnconfirmedDataUp),
+		ULMetaData:  ts.getULMetaData(),
+	}, &ans)
+
+	assert.Equal(backend.Deferred, ans.Result.ResultCode)
+	assert.Nil(ans.DevEUI)
+
+	// the frame-counter of the dropped uplink is stored
+	ds, err := storage.GetDeviceSession(context.Background(), storage.RedisPool(), ts.deviceSession.DevEUI)
+	assert.NoError(err)
+	assert.Equal(ts.deviceSession.FCntUp+1, ds.FCntUp)
+}
+
+func (ts *RoamingAPITestSuite) TestDownlink() {
+	dr := 5
+	freq := 868.1
+	rxDelay := 1
+	phy := backend.HEXBytes{1, 2, 3, 4}
+
+	ts.T().Run("Downlink is sent to the gateway", func(t *testing.T) {
+		assert := require.New(t)
+		gwBackend := gateway.Backend().(*test.GatewayBackend)
+
+		var ans backend.XmitDataAnsPayload
+		ts.request(backend.XmitDataReqPayload{
+			BasePayload: ts.getBasePayload(backend.XmitDataReq),
+			PHYPayload:  phy,
+			DLMetaData: &backend.DLMetaData{
+				DLFreq1:   &freq,
+				DataRate1: &dr,
+				RXDelay1:  &rxDelay,
+				GWInfo:    ts.getULMetaData().GWInfo,
+			},
+		}, &ans)
+
+		assert.Equal(backend.Success, ans.Result.ResultCode)
+
+		var df gw.DownlinkFrame
+		select {
+		case df = <-gwBackend.TXPacketChan:
+		case <-time.After(time.Second):
+			t.Fatal("no downlink frame received")
+		}
+
+		assert.Equal([]byte{8, 7, 6, 5, 4, 3, 2, 1}, df.TxInfo.GatewayId)
+		assert.Equal([]byte{1, 2, 3, 4}, df.TxInfo.Context)
+		assert.EqualValues(868100000, df.TxInfo.Frequency)
+		assert.Equal([]byte(phy), df.PhyPayload)
+	})
+
+	ts.T().Run("Unknown ULToken", func(t *testing.T) {
+		assert := require.New(t)
+
+		ulToken, err := proto.Marshal(&gw.UplinkRXInfo{
+			GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+		})
+		assert.NoError(err)
+
+		var ans backend.XmitDataAnsPayload
+		ts.request(backend.XmitDataReqPayload{
+			BasePayload: ts.getBasePayload(backend.XmitDataReq),
+			PHYPayload:  phy,
+			DLMetaData: &backend.DLMetaData{
+				DLFreq1:   &freq,
+				DataRate1: &dr,
+				RXDelay1:  &rxDelay,
+				GWInfo: []backend.GWInfoElement{
+					{
+						ID:      backend.HEXBytes{8, 7, 6, 5, 4, 3, 2, 1},
+						ULToken: backend.HEXBytes(ulToken),
+					},
+				},
+			},
+		}, &ans)
+
+		assert.Equal(backend.XmitFailed, ans.Result.ResultCode)
+	})
+}
+
+func (ts *RoamingAPITestSuite) TestClientCertificate() {
+	tests := []struct {
+		Name               string
+		CommonName         string
+		ExpectedResultCode backend.ResultCode
+	}{
+		{
+			Name:               "CommonName matches SenderID",
+			CommonName:         ts.netID.String(),
+			ExpectedResultCode: backend.Success,
+		},
+		{
+			Name:               "CommonName does not match SenderID",
+			CommonName:         lorawan.NetID{7, 8, 9}.String(),
+			ExpectedResultCode: backend.UnknownSender,
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			ts.deviceSession.FCntUp++
+
+			b, err := json.Marshal(backend.PRStartReqPayload{
+				BasePayload: ts.getBasePayload(backend.PRStartReq),
+				PHYPayload:  ts.getPHYPayload(lorawan.UnconfirmedDataUp),
+				ULMetaData:  ts.getULMetaData(),
+			})
+			assert.NoError(err)
+
+			r := httptest.NewRequest("POST", "/", bytes.NewReader(b))
+			r.TLS = &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{
+					{Subject: pkix.Name{CommonName: tst.CommonName}},
+				},
+			}
+			w := httptest.NewRecorder()
+			ts.api.ServeHTTP(w, r)
+			assert.Equal(http.StatusOK, w.Code)
+
+			var ans backend.PRStartAnsPayload
+			assert.NoError(json.Unmarshal(w.Body.Bytes(), &ans))
+			assert.Equal(tst.ExpectedResultCode, ans.Result.ResultCode)
+		})
+	}
+}
+
+func TestRoamingAPI(t *testing.T) {
+	suite.Run(t, new(RoamingAPITestSuite))
+}
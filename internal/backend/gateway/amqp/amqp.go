@@ -0,0 +1,509 @@
+// Package amqp implements an AMQP 0-9-1 (e.g. RabbitMQ) gateway backend.
+package amqp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/marshaler"
+	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/lorawan"
+)
+
+// exchange defines the exchange to which the gateway events are published
+// and to which the gateway commands are published.
+const exchange = "amq.topic"
+
+// consumerTag defines the tag used for consuming the event queue.
+const consumerTag = "chirpstack-network-server"
+
+// Backend implements an AMQP backend.
+type Backend struct {
+	sync.RWMutex
+
+	wg     sync.WaitGroup
+	closed bool
+
+	url                       string
+	eventQueueName            string
+	eventRoutingKey           string
+	commandRoutingKeyTemplate *template.Template
+
+	conn  *amqp.Connection
+	pubCh *amqp.Channel
+	subCh *amqp.Channel
+
+	uplinkFrameChan         chan gw.UplinkFrame
+	gatewayStatsChan        chan gw.GatewayStats
+	downlinkTXAckChan       chan gw.DownlinkTXAck
+	commandExecResponseChan chan gw.GatewayCommandExecResponse
+	connStateChan           chan gw.ConnState
+	gatewayMarshaler        map[lorawan.EUI64]marshaler.Type
+}
+
+// NewBackend creates a new Backend.
+func NewBackend(c config.Config) (gateway.Gateway, error) {
+	conf := c.NetworkServer.Gateway.Backend.AMQP
+	var err error
+
+	b := Backend{
+		url:                     conf.URL,
+		eventQueueName:          conf.EventQueueName,
+		eventRoutingKey:         conf.EventRoutingKey,
+		uplinkFrameChan:         make(chan gw.UplinkFrame),
+		gatewayStatsChan:        make(chan gw.GatewayStats),
+		downlinkTXAckChan:       make(chan gw.DownlinkTXAck),
+		commandExecResponseChan: make(chan gw.GatewayCommandExecResponse),
+		connStateChan:           make(chan gw.ConnState),
+		gatewayMarshaler:        make(map[lorawan.EUI64]marshaler.Type),
+	}
+
+	b.commandRoutingKeyTemplate, err = template.New("command").Parse(conf.CommandRoutingKeyTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "gateway/amqp: parse command routing-key template error")
+	}
+
+	for {
+		if err := b.connect(); err != nil {
+			log.WithError(err).Error("gateway/amqp: connecting to amqp broker failed, will retry in 2s")
+			time.Sleep(2 * time.Second)
+			continue
+		}
+		break
+	}
+
+	return &b, nil
+}
+
+// SendTXPacket sends the given downlink frame to the gateway.
+func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if pl.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(pl.TxInfo)
+	downID := helpers.GetDownlinkID(&pl)
+
+	return b.publishCommand(log.Fields{
+		"downlink_id": downID,
+	}, gatewayID, "down", &pl)
+}
+
+// SendGatewayConfigPacket sends the given gateway configuration to the gateway.
+func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+
+	return b.publishCommand(log.Fields{}, gatewayID, "config", &pl)
+}
+
+// SendGatewayCommandExecRequest sends the given command execution request
+// to the gateway.
+func (b *Backend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+
+	return b.publishCommand(log.Fields{
+		"exec_id": helpers.GetExecID(&pl),
+	}, gatewayID, "exec", &pl)
+}
+
+// RXPacketChan returns the channel to which uplink frames are published.
+func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+// StatsPacketChan returns the channel to which gateway stats are published.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+// DownlinkTXAckChan returns the downlink tx ack channel.
+func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+// GatewayCommandExecResponseChan returns the gateway command execution
+// response channel.
+func (b *Backend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.commandExecResponseChan
+}
+
+// ConnStateChan returns the gateway connection-state channel.
+// Note that this backend does not publish gateway connection-states.
+func (b *Backend) ConnStateChan() chan gw.ConnState {
+	return b.connStateChan
+}
+
+// Close closes the backend.
+// Note that this closes the backend one-way (gateway to backend).
+// This makes it possible to perform a graceful shutdown (e.g. when there are
+// still packets to send back to the gateway).
+func (b *Backend) Close() error {
+	log.Info("gateway/amqp: closing backend")
+
+	b.Lock()
+	b.closed = true
+	subCh := b.subCh
+	b.Unlock()
+
+	log.WithField("queue", b.eventQueueName).Info("gateway/amqp: cancel event queue consumer")
+	if err := subCh.Cancel(consumerTag, false); err != nil {
+		return errors.Wrap(err, "gateway/amqp: cancel consumer error")
+	}
+
+	log.Info("gateway/amqp: handling last messages")
+	b.wg.Wait()
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+	close(b.commandExecResponseChan)
+	close(b.connStateChan)
+	return nil
+}
+
+func (b *Backend) isClosed() bool {
+	b.RLock()
+	defer b.RUnlock()
+	return b.closed
+}
+
+// connect connects to the AMQP broker, declares and binds the (durable)
+// event queue and starts consuming it.
+func (b *Backend) connect() error {
+	log.Info("gateway/amqp: connecting to amqp broker")
+
+	conn, err := amqp.Dial(b.url)
+	if err != nil {
+		return errors.Wrap(err, "dial amqp url error")
+	}
+
+	pubCh, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "open publish channel error")
+	}
+
+	subCh, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "open subscribe channel error")
+	}
+
+	log.WithField("queue", b.eventQueueName).Info("gateway/amqp: declaring event queue")
+	_, err = subCh.QueueDeclare(
+		b.eventQueueName,
+		true,  // durable
+		false, // auto-delete
+		false, // exclusive
+		false, // no-wait
+		nil,
+	)
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "declare queue error")
+	}
+
+	log.WithFields(log.Fields{
+		"queue":       b.eventQueueName,
+		"exchange":    exchange,
+		"routing_key": b.eventRoutingKey,
+	}).Info("gateway/amqp: binding event queue")
+	if err := subCh.QueueBind(b.eventQueueName, b.eventRoutingKey, exchange, false, nil); err != nil {
+		conn.Close()
+		return errors.Wrap(err, "bind queue error")
+	}
+
+	msgs, err := subCh.Consume(
+		b.eventQueueName,
+		consumerTag,
+		false, // auto-ack
+		false, // exclusive
+		false, // no-local
+		false, // no-wait
+		nil,
+	)
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "consume queue error")
+	}
+
+	b.Lock()
+	b.conn = conn
+	b.pubCh = pubCh
+	b.subCh = subCh
+	b.Unlock()
+
+	amqpConnectCounter().Inc()
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.eventLoop(msgs)
+	}()
+
+	go b.reconnectLoop(conn.NotifyClose(make(chan *amqp.Error, 1)))
+
+	return nil
+}
+
+// reconnectLoop re-connects to the AMQP broker when the connection has been
+// closed unexpectedly.
+func (b *Backend) reconnectLoop(closeChan chan *amqp.Error) {
+	err, ok := <-closeChan
+	if !ok || err == nil || b.isClosed() {
+		return
+	}
+
+	log.WithError(err).Error("gateway/amqp: amqp connection error")
+	amqpDisconnectCounter().Inc()
+
+	for !b.isClosed() {
+		if err := b.connect(); err != nil {
+			log.WithError(err).Error("gateway/amqp: re-connecting to amqp broker failed, will retry in 2s")
+			time.Sleep(2 * time.Second)
+			continue
+		}
+		return
+	}
+}
+
+func (b *Backend) eventLoop(msgs <-chan amqp.Delivery) {
+	for msg := range msgs {
+		if err := b.handleEvent(msg); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"routing_key": msg.RoutingKey,
+				"data_base64": base64.StdEncoding.EncodeToString(msg.Body),
+			}).Error("gateway/amqp: handle event error")
+		}
+
+		if err := msg.Ack(false); err != nil {
+			log.WithError(err).Error("gateway/amqp: ack message error")
+		}
+	}
+}
+
+func (b *Backend) handleEvent(msg amqp.Delivery) error {
+	// The routing-key is expected to be in the format:
+	// gateway.[GatewayID].event.[EventType]
+	parts := strings.Split(msg.RoutingKey, ".")
+	if len(parts) != 4 {
+		return errors.New("unexpected routing-key format")
+	}
+
+	var gatewayID lorawan.EUI64
+	if err := gatewayID.UnmarshalText([]byte(parts[1])); err != nil {
+		return errors.Wrap(err, "unmarshal gateway id error")
+	}
+
+	typ := parts[3]
+	amqpEventCounter(typ).Inc()
+
+	switch typ {
+	case "up":
+		return b.handleUplinkFrame(gatewayID, msg.Body)
+	case "stats":
+		return b.handleGatewayStats(gatewayID, msg.Body)
+	case "ack":
+		return b.handleDownlinkTXAck(gatewayID, msg.Body)
+	case "exec":
+		return b.handleGatewayCommandExecResponse(gatewayID, msg.Body)
+	default:
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"type":       typ,
+		}).Warning("gateway/amqp: unexpected event type")
+	}
+
+	return nil
+}
+
+func (b *Backend) handleUplinkFrame(gatewayID lorawan.EUI64, data []byte) error {
+	var uplinkFrame gw.UplinkFrame
+	t, err := marshaler.UnmarshalUplinkFrame(data, &uplinkFrame)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	if uplinkFrame.RxInfo == nil {
+		return errors.New("rx_info must not be nil")
+	}
+
+	if uplinkFrame.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	// make sure that the gateway is not using a different gateway_id than
+	// the ID used in the routing-key.
+	if !bytes.Equal(uplinkFrame.RxInfo.GatewayId, gatewayID[:]) {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	upID := helpers.GetUplinkID(uplinkFrame.RxInfo)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"uplink_id":  upID,
+	}).Info("gateway/amqp: uplink event received")
+
+	b.uplinkFrameChan <- uplinkFrame
+
+	return nil
+}
+
+func (b *Backend) handleGatewayStats(gatewayID lorawan.EUI64, data []byte) error {
+	var gatewayStats gw.GatewayStats
+	t, err := marshaler.UnmarshalGatewayStats(data, &gatewayStats)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	// make sure that the gateway is not using a different gateway_id than
+	// the ID used in the routing-key.
+	if !bytes.Equal(gatewayStats.GatewayId, gatewayID[:]) {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	statsID := helpers.GetStatsID(&gatewayStats)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"stats_id":   statsID,
+	}).Info("gateway/amqp: stats event received")
+
+	b.gatewayStatsChan <- gatewayStats
+
+	return nil
+}
+
+func (b *Backend) handleDownlinkTXAck(gatewayID lorawan.EUI64, data []byte) error {
+	var ack gw.DownlinkTXAck
+	t, err := marshaler.UnmarshalDownlinkTXAck(data, &ack)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	// make sure that the gateway is not using a different gateway_id than
+	// the ID used in the routing-key.
+	if !bytes.Equal(ack.GatewayId, gatewayID[:]) {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	downID := helpers.GetDownlinkID(&ack)
+
+	log.WithFields(log.Fields{
+		"gateway_id":  gatewayID,
+		"downlink_id": downID,
+	}).Info("gateway/amqp: ack event received")
+
+	b.downlinkTXAckChan <- ack
+
+	return nil
+}
+
+func (b *Backend) handleGatewayCommandExecResponse(gatewayID lorawan.EUI64, data []byte) error {
+	var resp gw.GatewayCommandExecResponse
+	t, err := marshaler.UnmarshalGatewayCommandExecResponse(data, &resp)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	// make sure that the gateway is not using a different gateway_id than
+	// the ID used in the routing-key.
+	if !bytes.Equal(resp.GatewayId, gatewayID[:]) {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	execID := helpers.GetExecID(&resp)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"exec_id":    execID,
+	}).Info("gateway/amqp: exec event received")
+
+	b.commandExecResponseChan <- resp
+
+	return nil
+}
+
+func (b *Backend) publishCommand(fields log.Fields, gatewayID lorawan.EUI64, command string, msg proto.Message) error {
+	t := b.getGatewayMarshaler(gatewayID)
+	bb, err := marshaler.MarshalCommand(t, msg)
+	if err != nil {
+		return errors.Wrap(err, "gateway/amqp: marshal gateway command error")
+	}
+
+	templateCtx := struct {
+		GatewayID   lorawan.EUI64
+		CommandType string
+	}{gatewayID, command}
+	routingKey := bytes.NewBuffer(nil)
+	if err := b.commandRoutingKeyTemplate.Execute(routingKey, templateCtx); err != nil {
+		return errors.Wrap(err, "execute command routing-key template error")
+	}
+
+	contentType := "application/json"
+	if t == marshaler.Protobuf {
+		contentType = "application/octet-stream"
+	}
+
+	fields["gateway_id"] = gatewayID
+	fields["command"] = command
+	fields["routing_key"] = routingKey.String()
+
+	log.WithFields(fields).Info("gateway/amqp: publishing gateway command")
+
+	amqpCommandCounter(command).Inc()
+
+	b.RLock()
+	pubCh := b.pubCh
+	b.RUnlock()
+
+	err = pubCh.Publish(
+		exchange,
+		routingKey.String(),
+		false, // mandatory
+		false, // immediate
+		amqp.Publishing{
+			ContentType: contentType,
+			Body:        bb,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "gateway/amqp: publish gateway command error")
+	}
+
+	return nil
+}
+
+func (b *Backend) setGatewayMarshaler(gatewayID lorawan.EUI64, t marshaler.Type) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.gatewayMarshaler[gatewayID] = t
+}
+
+func (b *Backend) getGatewayMarshaler(gatewayID lorawan.EUI64) marshaler.Type {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.gatewayMarshaler[gatewayID]
+}
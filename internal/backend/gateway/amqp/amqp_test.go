@@ -0,0 +1,152 @@
+package amqp
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/marshaler"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+type BackendTestSuite struct {
+	suite.Suite
+
+	backend   gateway.Gateway
+	amqpConn  *amqp.Connection
+	amqpChan  *amqp.Channel
+	commandCh <-chan amqp.Delivery
+}
+
+func (ts *BackendTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+
+	var err error
+	ts.amqpConn, err = amqp.Dial(conf.NetworkServer.Gateway.Backend.AMQP.URL)
+	assert.NoError(err)
+
+	ts.amqpChan, err = ts.amqpConn.Channel()
+	assert.NoError(err)
+
+	q, err := ts.amqpChan.QueueDeclare("", false, true, true, false, nil)
+	assert.NoError(err)
+	assert.NoError(ts.amqpChan.QueueBind(q.Name, "gateway.*.command.*", "amq.topic", false, nil))
+
+	ts.commandCh, err = ts.amqpChan.Consume(q.Name, "", true, false, false, false, nil)
+	assert.NoError(err)
+
+	ts.backend, err = NewBackend(conf)
+	assert.NoError(err)
+
+	ts.backend.(*Backend).setGatewayMarshaler(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, marshaler.Protobuf)
+}
+
+func (ts *BackendTestSuite) TearDownSuite() {
+	assert := require.New(ts.T())
+
+	assert.NoError(ts.backend.Close())
+	assert.NoError(ts.amqpConn.Close())
+}
+
+func (ts *BackendTestSuite) publishEvent(routingKey string, msg proto.Message) {
+	assert := require.New(ts.T())
+
+	b, err := proto.Marshal(msg)
+	assert.NoError(err)
+
+	assert.NoError(ts.amqpChan.Publish("amq.topic", routingKey, false, false, amqp.Publishing{
+		ContentType: "application/octet-stream",
+		Body:        b,
+	}))
+}
+
+func (ts *BackendTestSuite) TestUplinkFrame() {
+	assert := require.New(ts.T())
+
+	uplinkFrame := gw.UplinkFrame{
+		PhyPayload: []byte{1, 2, 3, 4},
+		TxInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+	}
+	ts.publishEvent("gateway.0102030405060708.event.up", &uplinkFrame)
+
+	receivedUplink := <-ts.backend.RXPacketChan()
+	assert.True(proto.Equal(&uplinkFrame, &receivedUplink))
+}
+
+func (ts *BackendTestSuite) TestGatewayStats() {
+	assert := require.New(ts.T())
+
+	gatewayStats := gw.GatewayStats{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	ts.publishEvent("gateway.0102030405060708.event.stats", &gatewayStats)
+
+	receivedStats := <-ts.backend.StatsPacketChan()
+	assert.True(proto.Equal(&gatewayStats, &receivedStats))
+}
+
+func (ts *BackendTestSuite) TestDownlinkTXAck() {
+	assert := require.New(ts.T())
+
+	downlinkTXAck := gw.DownlinkTXAck{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Token:     1234,
+	}
+	ts.publishEvent("gateway.0102030405060708.event.ack", &downlinkTXAck)
+
+	receivedAck := <-ts.backend.DownlinkTXAckChan()
+	assert.True(proto.Equal(&downlinkTXAck, &receivedAck))
+}
+
+func (ts *BackendTestSuite) TestSendDownlinkFrame() {
+	assert := require.New(ts.T())
+
+	downlinkFrame := gw.DownlinkFrame{
+		PhyPayload: []byte{1, 2, 3, 4},
+		TxInfo: &gw.DownlinkTXInfo{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+	}
+	assert.NoError(ts.backend.SendTXPacket(downlinkFrame))
+
+	msg := <-ts.commandCh
+	assert.Equal("gateway.0102030405060708.command.down", msg.RoutingKey)
+
+	var downlinkReceived gw.DownlinkFrame
+	assert.NoError(proto.Unmarshal(msg.Body, &downlinkReceived))
+	assert.True(proto.Equal(&downlinkFrame, &downlinkReceived))
+}
+
+func (ts *BackendTestSuite) TestSendGatewayConfiguration() {
+	assert := require.New(ts.T())
+
+	gatewayConfig := gw.GatewayConfiguration{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Version:   "1.2.3",
+	}
+	assert.NoError(ts.backend.SendGatewayConfigPacket(gatewayConfig))
+
+	msg := <-ts.commandCh
+	assert.Equal("gateway.0102030405060708.command.config", msg.RoutingKey)
+
+	var configReceived gw.GatewayConfiguration
+	assert.NoError(proto.Unmarshal(msg.Body, &configReceived))
+	assert.True(proto.Equal(&gatewayConfig, &configReceived))
+}
+
+func TestBackend(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
@@ -0,0 +1,44 @@
+package amqp
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_amqp_event_count",
+		Help: "The number of received events by the AMQP backend (per event type).",
+	}, []string{"event"})
+
+	cc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_amqp_command_count",
+		Help: "The number of published commands by the AMQP backend (per command).",
+	}, []string{"command"})
+
+	amqpc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backend_amqp_connect_count",
+		Help: "The number of times the AMQP backend connected to the AMQP broker.",
+	})
+
+	amqpd = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backend_amqp_disconnect_count",
+		Help: "The number of times the AMQP backend disconnected from the AMQP broker.",
+	})
+)
+
+func amqpEventCounter(e string) prometheus.Counter {
+	return ec.With(prometheus.Labels{"event": e})
+}
+
+func amqpCommandCounter(c string) prometheus.Counter {
+	return cc.With(prometheus.Labels{"command": c})
+}
+
+func amqpConnectCounter() prometheus.Counter {
+	return amqpc
+}
+
+func amqpDisconnectCounter() prometheus.Counter {
+	return amqpd
+}
@@ -33,10 +33,12 @@ type Backend struct {
 	cancel context.CancelFunc
 	closed bool
 
-	uplinkFrameChan   chan gw.UplinkFrame
-	gatewayStatsChan  chan gw.GatewayStats
-	downlinkTxAckChan chan gw.DownlinkTXAck
-	gatewayMarshaler  map[lorawan.EUI64]marshaler.Type
+	uplinkFrameChan         chan gw.UplinkFrame
+	gatewayStatsChan        chan gw.GatewayStats
+	downlinkTxAckChan       chan gw.DownlinkTXAck
+	commandExecResponseChan chan gw.GatewayCommandExecResponse
+	connStateChan           chan gw.ConnState
+	gatewayMarshaler        map[lorawan.EUI64]marshaler.Type
 
 	queueName string
 	ns        *servicebus.Namespace
@@ -58,10 +60,12 @@ func NewBackend(c config.Config) (gateway.Gateway, error) {
 	conf := c.NetworkServer.Gateway.Backend.AzureIoTHub
 
 	b := Backend{
-		uplinkFrameChan:   make(chan gw.UplinkFrame),
-		gatewayStatsChan:  make(chan gw.GatewayStats),
-		downlinkTxAckChan: make(chan gw.DownlinkTXAck),
-		gatewayMarshaler:  make(map[lorawan.EUI64]marshaler.Type),
+		uplinkFrameChan:         make(chan gw.UplinkFrame),
+		gatewayStatsChan:        make(chan gw.GatewayStats),
+		downlinkTxAckChan:       make(chan gw.DownlinkTXAck),
+		commandExecResponseChan: make(chan gw.GatewayCommandExecResponse),
+		connStateChan:           make(chan gw.ConnState),
+		gatewayMarshaler:        make(map[lorawan.EUI64]marshaler.Type),
 
 		ctx: context.Background(),
 
@@ -180,6 +184,20 @@ func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
 	return b.publishCommand(log.Fields{}, gatewayID, "config", bb)
 }
 
+func (b *Backend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+	t := b.getGatewayMarshaler(gatewayID)
+
+	bb, err := marshaler.MarshalCommand(t, &pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal gateway command execution request error")
+	}
+
+	return b.publishCommand(log.Fields{
+		"exec_id": helpers.GetExecID(&pl),
+	}, gatewayID, "exec", bb)
+}
+
 func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
 	return b.uplinkFrameChan
 }
@@ -192,12 +210,24 @@ func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
 	return b.downlinkTxAckChan
 }
 
+func (b *Backend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.commandExecResponseChan
+}
+
+// ConnStateChan returns the gateway connection-state channel.
+// Note that this backend does not publish gateway connection-states.
+func (b *Backend) ConnStateChan() chan gw.ConnState {
+	return b.connStateChan
+}
+
 func (b *Backend) Close() error {
 	log.Info("gateway/azure_iot_hub: closing backend")
 	b.cancel()
 	close(b.uplinkFrameChan)
 	close(b.gatewayStatsChan)
 	close(b.downlinkTxAckChan)
+	close(b.commandExecResponseChan)
+	close(b.connStateChan)
 	b.queue.Close(context.Background())
 	return nil
 }
@@ -255,6 +285,9 @@ func (b *Backend) handleEventMessage(msg *servicebus.Message) error {
 	if _, ok := msg.UserProperties["stats"]; ok {
 		event = "stats"
 	}
+	if _, ok := msg.UserProperties["exec"]; ok {
+		event = "exec"
+	}
 
 	azureEventCounter(event).Inc()
 
@@ -265,6 +298,8 @@ func (b *Backend) handleEventMessage(msg *servicebus.Message) error {
 		err = b.handleGatewayStats(gatewayID, msg.Data)
 	case "ack":
 		err = b.handleDownlinkTXAck(gatewayID, msg.Data)
+	case "exec":
+		err = b.handleGatewayCommandExecResponse(gatewayID, msg.Data)
 	default:
 		log.WithFields(log.Fields{
 			"gateway_id": gatewayID,
@@ -372,6 +407,33 @@ func (b *Backend) handleDownlinkTXAck(gatewayID lorawan.EUI64, data []byte) erro
 	return nil
 }
 
+func (b *Backend) handleGatewayCommandExecResponse(gatewayID lorawan.EUI64, data []byte) error {
+	var resp gw.GatewayCommandExecResponse
+	t, err := marshaler.UnmarshalGatewayCommandExecResponse(data, &resp)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	// make sure that the registered gateway is not using a different gateway_id
+	// than the ID used during the registration in Cloud IoT Core.
+	if !bytes.Equal(resp.GatewayId, gatewayID[:]) {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	execID := helpers.GetExecID(&resp)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"exec_id":    execID,
+	}).Info("gateway/azure_iot_hub: exec response received from gateway")
+
+	b.commandExecResponseChan <- resp
+
+	return nil
+}
+
 func (b *Backend) publishCommand(fields log.Fields, gatewayID lorawan.EUI64, command string, data []byte) error {
 	msgID, err := uuid.NewV4()
 	if err != nil {
@@ -0,0 +1,757 @@
+// Package basicstation implements a gateway backend for the LoRa Basics
+// Station LNS protocol.
+package basicstation
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/gps"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+// ErrGatewayNotConnected is returned when the gateway is not connected
+// to the backend.
+var ErrGatewayNotConnected = errors.New("gateway is not connected")
+
+// connection holds the state of a connected gateway.
+type connection struct {
+	sync.Mutex
+
+	conn          *websocket.Conn
+	configVersion string
+	metaData      map[string]string
+	stats         gw.GatewayStats
+}
+
+// Backend implements a Basics Station backend.
+type Backend struct {
+	sync.RWMutex
+
+	ln       net.Listener
+	server   *http.Server
+	scheme   string
+	upgrader websocket.Upgrader
+	closed   bool
+	wg       sync.WaitGroup
+
+	connections    map[lorawan.EUI64]*connection
+	configurations map[lorawan.EUI64]gw.GatewayConfiguration
+
+	uplinkFrameChan         chan gw.UplinkFrame
+	gatewayStatsChan        chan gw.GatewayStats
+	downlinkTXAckChan       chan gw.DownlinkTXAck
+	commandExecResponseChan chan gw.GatewayCommandExecResponse
+	connStateChan           chan gw.ConnState
+
+	statsInterval time.Duration
+	pingInterval  time.Duration
+	readTimeout   time.Duration
+	writeTimeout  time.Duration
+
+	region       string
+	frequencyMin uint32
+	frequencyMax uint32
+}
+
+// NewBackend creates a new Backend.
+func NewBackend(c config.Config) (gateway.Gateway, error) {
+	conf := c.NetworkServer.Gateway.Backend.BasicStation
+
+	b := Backend{
+		scheme:         "ws",
+		connections:    make(map[lorawan.EUI64]*connection),
+		configurations: make(map[lorawan.EUI64]gw.GatewayConfiguration),
+
+		uplinkFrameChan:         make(chan gw.UplinkFrame),
+		gatewayStatsChan:        make(chan gw.GatewayStats),
+		downlinkTXAckChan:       make(chan gw.DownlinkTXAck),
+		commandExecResponseChan: make(chan gw.GatewayCommandExecResponse),
+		connStateChan:           make(chan gw.ConnState),
+
+		statsInterval: conf.StatsInterval,
+		pingInterval:  conf.PingInterval,
+		readTimeout:   conf.ReadTimeout,
+		writeTimeout:  conf.WriteTimeout,
+
+		region:       conf.Region,
+		frequencyMin: conf.FrequencyMin,
+		frequencyMax: conf.FrequencyMax,
+	}
+
+	defaults := regionDefaults[loraband.Name(c.NetworkServer.Band.Name)]
+	if b.region == "" {
+		b.region = defaults.Region
+	}
+	if b.frequencyMin == 0 {
+		b.frequencyMin = defaults.FrequencyMin
+	}
+	if b.frequencyMax == 0 {
+		b.frequencyMax = defaults.FrequencyMax
+	}
+	if b.region == "" || b.frequencyMin == 0 || b.frequencyMax == 0 {
+		return nil, fmt.Errorf("region and frequency range must be configured for band %s", c.NetworkServer.Band.Name)
+	}
+
+	var err error
+	b.ln, err = net.Listen("tcp", conf.Bind)
+	if err != nil {
+		return nil, errors.Wrap(err, "create listener error")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/router-info", b.handleRouterInfo)
+	mux.HandleFunc("/gateway/", b.handleGateway)
+
+	server := &http.Server{
+		Handler:   mux,
+		TLSConfig: &tls.Config{},
+	}
+	b.server = server
+
+	if conf.CACert != "" {
+		caCert, err := ioutil.ReadFile(conf.CACert)
+		if err != nil {
+			return nil, errors.Wrap(err, "read ca certificate error")
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("append ca certificate error")
+		}
+
+		server.TLSConfig.ClientCAs = caCertPool
+		server.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	log.WithFields(log.Fields{
+		"bind":     conf.Bind,
+		"ca_cert":  conf.CACert,
+		"tls_cert": conf.TLSCert,
+		"tls_key":  conf.TLSKey,
+	}).Info("gateway/basic_station: starting websocket listener")
+
+	if conf.TLSCert == "" && conf.TLSKey == "" {
+		go func() {
+			if err := server.Serve(b.ln); err != nil && !b.isClosed() {
+				log.WithError(err).Fatal("gateway/basic_station: server error")
+			}
+		}()
+	} else {
+		b.scheme = "wss"
+		go func() {
+			if err := server.ServeTLS(b.ln, conf.TLSCert, conf.TLSKey); err != nil && !b.isClosed() {
+				log.WithError(err).Fatal("gateway/basic_station: server error")
+			}
+		}()
+	}
+
+	return &b, nil
+}
+
+// SendTXPacket sends the given downlink frame to the gateway.
+func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if pl.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(pl.TxInfo)
+	downID := helpers.GetDownlinkID(&pl)
+
+	msg, err := DownlinkFrameToDownlinkMessage(pl)
+	if err != nil {
+		return errors.Wrap(err, "downlink frame to downlink message error")
+	}
+
+	if err := b.sendToGateway(gatewayID, msg); err != nil {
+		return errors.Wrap(err, "send to gateway error")
+	}
+
+	if conn, err := b.getConnection(gatewayID); err == nil {
+		conn.Lock()
+		conn.stats.TxPacketsReceived++
+		conn.Unlock()
+	}
+
+	basicStationCommandCounter("dnmsg").Inc()
+
+	log.WithFields(log.Fields{
+		"gateway_id":  gatewayID,
+		"downlink_id": downID,
+	}).Info("gateway/basic_station: downlink frame sent to gateway")
+
+	return nil
+}
+
+// SendGatewayConfigPacket stores the given gateway configuration and sends
+// the router-config to the gateway when it is connected. Otherwise it will
+// be sent once the gateway connects.
+func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+
+	b.Lock()
+	b.configurations[gatewayID] = pl
+	b.Unlock()
+
+	conn, err := b.getConnection(gatewayID)
+	if err != nil {
+		if err == ErrGatewayNotConnected {
+			return nil
+		}
+		return err
+	}
+
+	return b.sendRouterConfig(gatewayID, conn)
+}
+
+// SendGatewayCommandExecRequest is not supported by the Basic Station
+// protocol.
+func (b *Backend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	return errors.New("gateway command execution is not supported by the basic station backend")
+}
+
+// RXPacketChan returns the channel containing the received uplink frames.
+func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+// StatsPacketChan returns the channel containing the gateway stats.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+// DownlinkTXAckChan returns the channel containing the downlink tx acks.
+func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+// GatewayCommandExecResponseChan returns the channel containing the gateway
+// command execution responses. Note that this channel will never receive
+// any responses.
+func (b *Backend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.commandExecResponseChan
+}
+
+// ConnStateChan returns the gateway connection-state channel. A gateway is
+// ONLINE when its websocket connection has been opened and OFFLINE when
+// this connection has been closed.
+func (b *Backend) ConnStateChan() chan gw.ConnState {
+	return b.connStateChan
+}
+
+// Close closes the backend. It stops the server and waits until the
+// websocket handlers have returned before closing the channels.
+func (b *Backend) Close() error {
+	log.Info("gateway/basic_station: closing backend")
+
+	b.Lock()
+	b.closed = true
+
+	// this closes the listener, but not the hijacked websocket connections
+	if err := b.server.Close(); err != nil {
+		b.Unlock()
+		return errors.Wrap(err, "close server error")
+	}
+
+	for _, conn := range b.connections {
+		conn.conn.Close()
+	}
+	b.Unlock()
+
+	log.Info("gateway/basic_station: waiting for websocket handlers")
+	b.wg.Wait()
+
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+	close(b.commandExecResponseChan)
+	close(b.connStateChan)
+
+	return nil
+}
+
+func (b *Backend) isClosed() bool {
+	b.RLock()
+	defer b.RUnlock()
+	return b.closed
+}
+
+// addHandler registers a running handler, so that Close waits for it to
+// return. It returns false when the backend has been closed.
+func (b *Backend) addHandler() bool {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.closed {
+		return false
+	}
+
+	b.wg.Add(1)
+	return true
+}
+
+func (b *Backend) getConnection(gatewayID lorawan.EUI64) (*connection, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	conn, ok := b.connections[gatewayID]
+	if !ok {
+		return nil, ErrGatewayNotConnected
+	}
+
+	return conn, nil
+}
+
+func (b *Backend) setConnection(gatewayID lorawan.EUI64, conn *connection) {
+	b.Lock()
+	defer b.Unlock()
+
+	if c, ok := b.connections[gatewayID]; ok {
+		log.WithField("gateway_id", gatewayID).Warning("gateway/basic_station: gateway was already connected, closing previous connection")
+		c.conn.Close()
+	}
+
+	b.connections[gatewayID] = conn
+}
+
+// removeConnection removes the given connection. It returns false when the
+// gateway has a more recent connection, in which case nothing is removed.
+func (b *Backend) removeConnection(gatewayID lorawan.EUI64, conn *connection) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	// make sure we don't remove a more recent connection of the same gateway
+	if c, ok := b.connections[gatewayID]; ok && c == conn {
+		delete(b.connections, gatewayID)
+		return true
+	}
+
+	return false
+}
+
+func (b *Backend) getConfiguration(gatewayID lorawan.EUI64) (gw.GatewayConfiguration, error) {
+	b.RLock()
+	conf, ok := b.configurations[gatewayID]
+	b.RUnlock()
+
+	if ok {
+		return conf, nil
+	}
+
+	return getDefaultGatewayConfiguration(gatewayID)
+}
+
+func (b *Backend) handleRouterInfo(w http.ResponseWriter, r *http.Request) {
+	c, err := b.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.WithError(err).Error("gateway/basic_station: websocket upgrade error")
+		return
+	}
+	defer c.Close()
+
+	c.SetReadDeadline(time.Now().Add(b.readTimeout))
+
+	var req RouterInfoRequest
+	if err := c.ReadJSON(&req); err != nil {
+		log.WithError(err).Error("gateway/basic_station: read router-info request error")
+		return
+	}
+
+	basicStationEventCounter("router_info").Inc()
+
+	resp := RouterInfoResponse{
+		Router: req.Router,
+		Muxs:   req.Router,
+		URI:    fmt.Sprintf("%s://%s/gateway/%s", b.scheme, r.Host, lorawan.EUI64(req.Router)),
+	}
+
+	c.SetWriteDeadline(time.Now().Add(b.writeTimeout))
+	if err := c.WriteJSON(resp); err != nil {
+		log.WithError(err).Error("gateway/basic_station: write router-info response error")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": lorawan.EUI64(req.Router),
+		"uri":        resp.URI,
+	}).Info("gateway/basic_station: router-info request received")
+}
+
+func (b *Backend) handleGateway(w http.ResponseWriter, r *http.Request) {
+	if !b.addHandler() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	defer b.wg.Done()
+
+	var gatewayID EUI64
+	if err := gatewayID.UnmarshalText([]byte(strings.TrimPrefix(r.URL.Path, "/gateway/"))); err != nil {
+		log.WithError(err).WithField("path", r.URL.Path).Error("gateway/basic_station: parse gateway id error")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	gwID := lorawan.EUI64(gatewayID)
+
+	c, err := b.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.WithError(err).WithField("gateway_id", gwID).Error("gateway/basic_station: websocket upgrade error")
+		return
+	}
+	defer c.Close()
+
+	conn := connection{
+		conn: c,
+		stats: gw.GatewayStats{
+			GatewayId: gwID[:],
+		},
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		conn.stats.Ip = host
+	}
+
+	b.setConnection(gwID, &conn)
+	b.sendConnState(gwID, gw.ConnState_ONLINE)
+	defer func() {
+		// the gateway is still online when it has a more recent connection
+		if b.removeConnection(gwID, &conn) {
+			b.sendConnState(gwID, gw.ConnState_OFFLINE)
+		}
+	}()
+
+	basicStationWebsocketCounter("connect").Inc()
+	log.WithFields(log.Fields{
+		"gateway_id":  gwID,
+		"remote_addr": r.RemoteAddr,
+	}).Info("gateway/basic_station: gateway connected")
+
+	done := make(chan struct{})
+	defer close(done)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.handlePingAndStats(gwID, &conn, done)
+	}()
+
+	c.SetReadDeadline(time.Now().Add(b.readTimeout))
+	c.SetPongHandler(func(string) error {
+		c.SetReadDeadline(time.Now().Add(b.readTimeout))
+		return nil
+	})
+
+	for {
+		mt, msg, err := c.ReadMessage()
+		if err != nil {
+			if !b.isClosed() && !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.WithError(err).WithField("gateway_id", gwID).Error("gateway/basic_station: read message error")
+			}
+			break
+		}
+
+		// the read deadline is also extended on every received message
+		c.SetReadDeadline(time.Now().Add(b.readTimeout))
+
+		if mt != websocket.TextMessage {
+			continue
+		}
+
+		if err := b.handleMessage(gwID, &conn, msg); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"gateway_id": gwID,
+				"message":    string(msg),
+			}).Error("gateway/basic_station: handle message error")
+		}
+	}
+
+	basicStationWebsocketCounter("disconnect").Inc()
+	log.WithField("gateway_id", gwID).Info("gateway/basic_station: gateway disconnected")
+}
+
+// handlePingAndStats periodically sends a ping to the gateway and generates
+// the gateway stats until done is closed.
+func (b *Backend) handlePingAndStats(gatewayID lorawan.EUI64, conn *connection, done chan struct{}) {
+	pingTicker := time.NewTicker(b.pingInterval)
+	defer pingTicker.Stop()
+
+	statsTicker := time.NewTicker(b.statsInterval)
+	defer statsTicker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-pingTicker.C:
+			conn.Lock()
+			err := conn.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(b.writeTimeout))
+			conn.Unlock()
+			if err != nil {
+				log.WithError(err).WithField("gateway_id", gatewayID).Error("gateway/basic_station: send ping error")
+			}
+		case <-statsTicker.C:
+			if err := b.sendGatewayStats(gatewayID, conn); err != nil {
+				log.WithError(err).WithField("gateway_id", gatewayID).Error("gateway/basic_station: send gateway stats error")
+			}
+		}
+	}
+}
+
+func (b *Backend) handleMessage(gatewayID lorawan.EUI64, conn *connection, msg []byte) error {
+	var base BaseMessage
+	if err := json.Unmarshal(msg, &base); err != nil {
+		return errors.Wrap(err, "unmarshal message error")
+	}
+
+	basicStationEventCounter(string(base.MessageType)).Inc()
+
+	switch base.MessageType {
+	case VersionMessage:
+		return b.handleVersion(gatewayID, conn, msg)
+	case UplinkDataFrameMessage:
+		var pl UplinkDataFrame
+		if err := json.Unmarshal(msg, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal updf error")
+		}
+		uf, err := UplinkDataFrameToUplinkFrame(gatewayID, pl)
+		if err != nil {
+			return errors.Wrap(err, "updf to uplink frame error")
+		}
+		return b.handleUplinkFrame(conn, uf)
+	case JoinRequestMessage:
+		var pl JoinRequest
+		if err := json.Unmarshal(msg, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal jreq error")
+		}
+		uf, err := JoinRequestToUplinkFrame(gatewayID, pl)
+		if err != nil {
+			return errors.Wrap(err, "jreq to uplink frame error")
+		}
+		return b.handleUplinkFrame(conn, uf)
+	case ProprietaryDataFrameMessage:
+		var pl UplinkProprietaryFrame
+		if err := json.Unmarshal(msg, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal propdf error")
+		}
+		uf, err := UplinkProprietaryFrameToUplinkFrame(gatewayID, pl)
+		if err != nil {
+			return errors.Wrap(err, "propdf to uplink frame error")
+		}
+		return b.handleUplinkFrame(conn, uf)
+	case DownlinkTransmittedMessage:
+		var pl DownlinkTransmitted
+		if err := json.Unmarshal(msg, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal dntxed error")
+		}
+		return b.handleDownlinkTransmitted(gatewayID, conn, pl)
+	case TimeSyncMessage:
+		var pl TimeSync
+		if err := json.Unmarshal(msg, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal timesync error")
+		}
+		return b.handleTimeSync(gatewayID, pl)
+	default:
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"msgtype":    base.MessageType,
+		}).Warning("gateway/basic_station: unexpected message-type received")
+	}
+
+	return nil
+}
+
+func (b *Backend) handleVersion(gatewayID lorawan.EUI64, conn *connection, msg []byte) error {
+	var pl Version
+	if err := json.Unmarshal(msg, &pl); err != nil {
+		return errors.Wrap(err, "unmarshal version error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"station":    pl.Station,
+		"firmware":   pl.Firmware,
+		"package":    pl.Package,
+		"model":      pl.Model,
+		"protocol":   pl.Protocol,
+		"features":   pl.Features,
+	}).Info("gateway/basic_station: version received from gateway")
+
+	conn.Lock()
+	conn.metaData = map[string]string{
+		"station":  pl.Station,
+		"firmware": pl.Firmware,
+		"package":  pl.Package,
+		"model":    pl.Model,
+		"protocol": fmt.Sprintf("%d", pl.Protocol),
+		"features": pl.Features,
+	}
+	conn.Unlock()
+
+	if err := b.sendRouterConfig(gatewayID, conn); err != nil {
+		return errors.Wrap(err, "send router-config error")
+	}
+
+	// Sending the stats directly after the connect will update the
+	// last-seen timestamp and will trigger the gateway-profile configuration
+	// when the config version does not match.
+	if err := b.sendGatewayStats(gatewayID, conn); err != nil {
+		return errors.Wrap(err, "send gateway stats error")
+	}
+
+	return nil
+}
+
+func (b *Backend) handleUplinkFrame(conn *connection, uf gw.UplinkFrame) error {
+	conn.Lock()
+	conn.stats.RxPacketsReceived++
+	conn.stats.RxPacketsReceivedOk++
+	conn.Unlock()
+
+	log.WithFields(log.Fields{
+		"gateway_id": helpers.GetGatewayID(uf.RxInfo),
+		"uplink_id":  helpers.GetUplinkID(uf.RxInfo),
+	}).Info("gateway/basic_station: uplink received from gateway")
+
+	b.uplinkFrameChan <- uf
+
+	return nil
+}
+
+func (b *Backend) handleDownlinkTransmitted(gatewayID lorawan.EUI64, conn *connection, pl DownlinkTransmitted) error {
+	conn.Lock()
+	conn.stats.TxPacketsEmitted++
+	conn.Unlock()
+
+	ack := DownlinkTransmittedToDownlinkTXAck(gatewayID, pl)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"token":      ack.Token,
+	}).Info("gateway/basic_station: ack received from gateway")
+
+	b.downlinkTXAckChan <- ack
+
+	return nil
+}
+
+func (b *Backend) handleTimeSync(gatewayID lorawan.EUI64, pl TimeSync) error {
+	resp := TimeSync{
+		MessageType: TimeSyncMessage,
+		TxTime:      pl.TxTime,
+		GPSTime:     int64(gps.Time(time.Now()).TimeSinceGPSEpoch() / time.Microsecond),
+	}
+
+	return b.sendToGateway(gatewayID, resp)
+}
+
+func (b *Backend) sendRouterConfig(gatewayID lorawan.EUI64, conn *connection) error {
+	conf, err := b.getConfiguration(gatewayID)
+	if err != nil {
+		return errors.Wrap(err, "get gateway configuration error")
+	}
+
+	rc, err := getRouterConfig(b.region, b.frequencyMin, b.frequencyMax, conf)
+	if err != nil {
+		return errors.Wrap(err, "get router-config error")
+	}
+
+	if err := b.sendToGateway(gatewayID, rc); err != nil {
+		return err
+	}
+
+	conn.Lock()
+	conn.configVersion = conf.Version
+	conn.Unlock()
+
+	basicStationCommandCounter("router_config").Inc()
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"version":    conf.Version,
+	}).Info("gateway/basic_station: router-config sent to gateway")
+
+	return nil
+}
+
+func (b *Backend) sendGatewayStats(gatewayID lorawan.EUI64, conn *connection) error {
+	statsID, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "new uuid error")
+	}
+
+	conn.Lock()
+	stats := conn.stats
+	stats.ConfigVersion = conn.configVersion
+	stats.MetaData = conn.metaData
+	stats.StatsId = statsID[:]
+
+	// reset the counters
+	conn.stats.RxPacketsReceived = 0
+	conn.stats.RxPacketsReceivedOk = 0
+	conn.stats.TxPacketsReceived = 0
+	conn.stats.TxPacketsEmitted = 0
+	conn.Unlock()
+
+	stats.Time, err = ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return errors.Wrap(err, "timestamp proto error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"stats_id":   statsID,
+	}).Info("gateway/basic_station: stats generated for gateway")
+
+	b.gatewayStatsChan <- stats
+
+	return nil
+}
+
+func (b *Backend) sendConnState(gatewayID lorawan.EUI64, state gw.ConnState_State) {
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"state":      state,
+	}).Info("gateway/basic_station: gateway connection-state changed")
+
+	b.connStateChan <- gw.ConnState{
+		GatewayId: gatewayID[:],
+		State:     state,
+	}
+}
+
+func (b *Backend) sendToGateway(gatewayID lorawan.EUI64, v interface{}) error {
+	conn, err := b.getConnection(gatewayID)
+	if err != nil {
+		return err
+	}
+
+	conn.Lock()
+	defer conn.Unlock()
+
+	if err := conn.conn.SetWriteDeadline(time.Now().Add(b.writeTimeout)); err != nil {
+		return errors.Wrap(err, "set write deadline error")
+	}
+
+	if err := conn.conn.WriteJSON(v); err != nil {
+		return errors.Wrap(err, "write json error")
+	}
+
+	return nil
+}
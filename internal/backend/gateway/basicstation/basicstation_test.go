@@ -0,0 +1,558 @@
+package basicstation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/chirpstack-network-server/api/common"
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+type BackendTestSuite struct {
+	suite.Suite
+
+	backend   *Backend
+	conn      *websocket.Conn
+	gatewayID lorawan.EUI64
+}
+
+func (ts *BackendTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+	conf.NetworkServer.Gateway.Backend.BasicStation.Bind = "127.0.0.1:0"
+	conf.NetworkServer.Gateway.Backend.BasicStation.StatsInterval = time.Hour
+	conf.NetworkServer.Gateway.Backend.BasicStation.PingInterval = time.Minute
+	conf.NetworkServer.Gateway.Backend.BasicStation.ReadTimeout = 2 * time.Minute
+	conf.NetworkServer.Gateway.Backend.BasicStation.WriteTimeout = time.Second
+
+	ts.gatewayID = lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	backend, err := NewBackend(conf)
+	assert.NoError(err)
+	ts.backend = backend.(*Backend)
+
+	ts.conn, _, err = websocket.DefaultDialer.Dial(fmt.Sprintf("ws://%s/gateway/0102030405060708", ts.backend.ln.Addr()), nil)
+	assert.NoError(err)
+
+	// the connection-state is published once the connection has been
+	// registered
+	connState := <-ts.backend.ConnStateChan()
+	assert.Equal(gw.ConnState{
+		GatewayId: ts.gatewayID[:],
+		State:     gw.ConnState_ONLINE,
+	}, connState)
+}
+
+func (ts *BackendTestSuite) TearDownSuite() {
+	assert := require.New(ts.T())
+	assert.NoError(ts.conn.Close())
+
+	connState := <-ts.backend.ConnStateChan()
+	assert.Equal(gw.ConnState{
+		GatewayId: ts.gatewayID[:],
+		State:     gw.ConnState_OFFLINE,
+	}, connState)
+
+	assert.NoError(ts.backend.Close())
+}
+
+func (ts *BackendTestSuite) TestRouterInfo() {
+	assert := require.New(ts.T())
+
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://%s/router-info", ts.backend.ln.Addr()), nil)
+	assert.NoError(err)
+	defer conn.Close()
+
+	assert.NoError(conn.WriteMessage(websocket.TextMessage, []byte(`{"router": "0102:0304:0506:0708"}`)))
+
+	var resp RouterInfoResponse
+	assert.NoError(conn.ReadJSON(&resp))
+	assert.Equal(RouterInfoResponse{
+		Router: EUI64(ts.gatewayID),
+		Muxs:   EUI64(ts.gatewayID),
+		URI:    fmt.Sprintf("ws://%s/gateway/0102030405060708", ts.backend.ln.Addr()),
+	}, resp)
+}
+
+func (ts *BackendTestSuite) TestVersion() {
+	assert := require.New(ts.T())
+
+	assert.NoError(ts.conn.WriteJSON(Version{
+		MessageType: VersionMessage,
+		Station:     "2.0.3",
+		Protocol:    2,
+	}))
+
+	var rc RouterConfig
+	assert.NoError(ts.conn.ReadJSON(&rc))
+	assert.Equal(RouterConfigMessage, rc.MessageType)
+	assert.Equal("EU863", rc.Region)
+	assert.Equal([]uint32{863000000, 870000000}, rc.FrequencyRange)
+	assert.Equal([3]int{12, 125, 0}, rc.DataRates[0])
+	assert.Equal([3]int{-1, 0, 0}, rc.DataRates[15])
+	assert.Len(rc.SX1301Conf, 1)
+	assert.Equal(SX1301ConfRadio{Enable: true, Freq: 868300000}, rc.SX1301Conf[0].Radio0)
+	assert.Equal(SX1301ConfChannel{Enable: true, Radio: 0, IF: -200000}, rc.SX1301Conf[0].ChanMultiSF0)
+	assert.Equal(SX1301ConfChannel{Enable: true, Radio: 0, IF: 200000}, rc.SX1301Conf[0].ChanMultiSF2)
+	assert.False(rc.SX1301Conf[0].ChanMultiSF3.Enable)
+
+	stats := <-ts.backend.StatsPacketChan()
+	assert.Equal(ts.gatewayID[:], stats.GatewayId)
+	assert.Equal("", stats.ConfigVersion)
+	assert.Equal("2.0.3", stats.MetaData["station"])
+
+	ts.T().Run("SendGatewayConfigPacket", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(ts.backend.SendGatewayConfigPacket(gw.GatewayConfiguration{
+			GatewayId: ts.gatewayID[:],
+			Version:   "1.2.3",
+			Channels: []*gw.ChannelConfiguration{
+				{
+					Frequency:  867100000,
+					Modulation: common.Modulation_LORA,
+					ModulationConfig: &gw.ChannelConfiguration_LoraModulationConfig{
+						LoraModulationConfig: &gw.LoRaModulationConfig{
+							Bandwidth:        125,
+							SpreadingFactors: []uint32{7, 8, 9, 10, 11, 12},
+						},
+					},
+				},
+				{
+					Frequency:  868100000,
+					Modulation: common.Modulation_LORA,
+					ModulationConfig: &gw.ChannelConfiguration_LoraModulationConfig{
+						LoraModulationConfig: &gw.LoRaModulationConfig{
+							Bandwidth:        125,
+							SpreadingFactors: []uint32{7, 8, 9, 10, 11, 12},
+						},
+					},
+				},
+				{
+					Frequency:  868300000,
+					Modulation: common.Modulation_LORA,
+					ModulationConfig: &gw.ChannelConfiguration_LoraModulationConfig{
+						LoraModulationConfig: &gw.LoRaModulationConfig{
+							Bandwidth:        250,
+							SpreadingFactors: []uint32{7},
+						},
+					},
+				},
+				{
+					Frequency:  868800000,
+					Modulation: common.Modulation_FSK,
+					ModulationConfig: &gw.ChannelConfiguration_FskModulationConfig{
+						FskModulationConfig: &gw.FSKModulationConfig{
+							Bandwidth: 125,
+							Bitrate:   50000,
+						},
+					},
+				},
+			},
+		}))
+
+		var rc RouterConfig
+		assert.NoError(ts.conn.ReadJSON(&rc))
+		assert.Equal(SX1301ConfRadio{Enable: true, Freq: 867100000}, rc.SX1301Conf[0].Radio0)
+		assert.Equal(SX1301ConfRadio{Enable: true, Freq: 868450000}, rc.SX1301Conf[0].Radio1)
+		assert.Equal(SX1301ConfChannel{Enable: true, Radio: 0, IF: 0}, rc.SX1301Conf[0].ChanMultiSF0)
+		assert.Equal(SX1301ConfChannel{Enable: true, Radio: 1, IF: -350000}, rc.SX1301Conf[0].ChanMultiSF1)
+		assert.Equal(SX1301ConfChannel{Enable: true, Radio: 1, IF: -150000, Bandwidth: 250000, SpreadFactor: 7}, rc.SX1301Conf[0].ChanLoRaStd)
+		assert.Equal(SX1301ConfChannel{Enable: true, Radio: 1, IF: 350000, DataRate: 50000}, rc.SX1301Conf[0].ChanFSK)
+
+		conn, err := ts.backend.getConnection(ts.gatewayID)
+		assert.NoError(err)
+		assert.Equal("1.2.3", conn.configVersion)
+	})
+}
+
+func (ts *BackendTestSuite) TestUplinkDataFrame() {
+	assert := require.New(ts.T())
+
+	assert.NoError(ts.conn.WriteMessage(websocket.TextMessage, []byte(`{
+		"msgtype": "updf",
+		"MHdr": 64,
+		"DevAddr": 16909060,
+		"FCtrl": 128,
+		"FCnt": 10,
+		"FOpts": "0203",
+		"FPort": 1,
+		"FRMPayload": "0405",
+		"MIC": 84281096,
+		"DR": 5,
+		"Freq": 868100000,
+		"upinfo": {
+			"rctx": 1,
+			"xtime": 2,
+			"gpstime": 1000000,
+			"rssi": -60,
+			"snr": 5.5,
+			"rxtime": 1557834000.5
+		}
+	}`)))
+
+	uf := <-ts.backend.RXPacketChan()
+	assert.Equal([]byte{0x40, 0x04, 0x03, 0x02, 0x01, 0x80, 0x0a, 0x00, 0x02, 0x03, 0x01, 0x04, 0x05, 0x08, 0x07, 0x06, 0x05}, uf.PhyPayload)
+	assert.Equal(uint32(868100000), uf.TxInfo.Frequency)
+	assert.Equal(uint32(7), uf.TxInfo.GetLoraModulationInfo().SpreadingFactor)
+	assert.Equal(ts.gatewayID[:], uf.RxInfo.GatewayId)
+	assert.Equal(int32(-60), uf.RxInfo.Rssi)
+	assert.Equal(5.5, uf.RxInfo.LoraSnr)
+	assert.Equal(getContext(2, 1), uf.RxInfo.Context)
+	assert.Len(uf.RxInfo.UplinkId, 16)
+
+	rxTime, err := ptypes.Timestamp(uf.RxInfo.Time)
+	assert.NoError(err)
+	assert.True(rxTime.Equal(time.Unix(1557834000, 500000000)))
+
+	gpsTime, err := ptypes.Duration(uf.RxInfo.TimeSinceGpsEpoch)
+	assert.NoError(err)
+	assert.Equal(time.Second, gpsTime)
+}
+
+func (ts *BackendTestSuite) TestJoinRequest() {
+	assert := require.New(ts.T())
+
+	assert.NoError(ts.conn.WriteMessage(websocket.TextMessage, []byte(`{
+		"msgtype": "jreq",
+		"MHdr": 0,
+		"JoinEui": "01-02-03-04-05-06-07-08",
+		"DevEui": "08-07-06-05-04-03-02-01",
+		"DevNonce": 258,
+		"MIC": 16909060,
+		"DR": 0,
+		"Freq": 868100000,
+		"upinfo": {
+			"rctx": 0,
+			"xtime": 1,
+			"rssi": -100,
+			"snr": -2
+		}
+	}`)))
+
+	uf := <-ts.backend.RXPacketChan()
+	assert.Equal([]byte{0x00, 0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x02, 0x01, 0x04, 0x03, 0x02, 0x01}, uf.PhyPayload)
+	assert.Equal(uint32(12), uf.TxInfo.GetLoraModulationInfo().SpreadingFactor)
+	assert.Nil(uf.RxInfo.Time)
+	assert.Nil(uf.RxInfo.TimeSinceGpsEpoch)
+
+	var phy lorawan.PHYPayload
+	assert.NoError(phy.UnmarshalBinary(uf.PhyPayload))
+	jrPL, ok := phy.MACPayload.(*lorawan.JoinRequestPayload)
+	assert.True(ok)
+	assert.Equal(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, jrPL.JoinEUI)
+	assert.Equal(lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, jrPL.DevEUI)
+	assert.Equal(lorawan.DevNonce(258), jrPL.DevNonce)
+}
+
+func (ts *BackendTestSuite) TestProprietaryDataFrame() {
+	assert := require.New(ts.T())
+
+	assert.NoError(ts.conn.WriteMessage(websocket.TextMessage, []byte(`{
+		"msgtype": "propdf",
+		"FRMPayload": "e00102",
+		"DR": 5,
+		"Freq": 868300000,
+		"upinfo": {}
+	}`)))
+
+	uf := <-ts.backend.RXPacketChan()
+	assert.Equal([]byte{0xe0, 0x01, 0x02}, uf.PhyPayload)
+	assert.Equal(uint32(868300000), uf.TxInfo.Frequency)
+}
+
+func (ts *BackendTestSuite) TestSendTXPacket() {
+	tests := []struct {
+		Name            string
+		TXInfo          gw.DownlinkTXInfo
+		ExpectedMessage DownlinkMessage
+		ExpectedError   string
+	}{
+		{
+			Name: "class-a",
+			TXInfo: gw.DownlinkTXInfo{
+				GatewayId: ts.gatewayID[:],
+				Frequency: 868100000,
+				Context:   getContext(2, 1),
+				Timing:    gw.DownlinkTiming_DELAY,
+				TimingInfo: &gw.DownlinkTXInfo_DelayTimingInfo{
+					DelayTimingInfo: &gw.DelayTimingInfo{
+						Delay: ptypes.DurationProto(time.Second),
+					},
+				},
+			},
+			ExpectedMessage: DownlinkMessage{
+				MessageType: DownlinkMessageMessage,
+				DeviceClass: DeviceClassA,
+				DIID:        1234,
+				PDU:         HEXBytes{1, 2, 3},
+				RxDelay:     intPtr(1),
+				RX1DR:       intPtr(5),
+				RX1Freq:     uint32Ptr(868100000),
+				XTime:       uint64Ptr(2),
+				RCtx:        uint64Ptr(1),
+			},
+		},
+		{
+			Name: "class-a without context",
+			TXInfo: gw.DownlinkTXInfo{
+				GatewayId: ts.gatewayID[:],
+				Frequency: 868100000,
+				Timing:    gw.DownlinkTiming_DELAY,
+				TimingInfo: &gw.DownlinkTXInfo_DelayTimingInfo{
+					DelayTimingInfo: &gw.DelayTimingInfo{
+						Delay: ptypes.DurationProto(time.Second),
+					},
+				},
+			},
+			ExpectedError: "downlink frame to downlink message error: context must be set for delay timing",
+		},
+		{
+			Name: "class-b",
+			TXInfo: gw.DownlinkTXInfo{
+				GatewayId: ts.gatewayID[:],
+				Frequency: 869525000,
+				Timing:    gw.DownlinkTiming_GPS_EPOCH,
+				TimingInfo: &gw.DownlinkTXInfo_GpsEpochTimingInfo{
+					GpsEpochTimingInfo: &gw.GPSEpochTimingInfo{
+						TimeSinceGpsEpoch: ptypes.DurationProto(5 * time.Second),
+					},
+				},
+			},
+			ExpectedMessage: DownlinkMessage{
+				MessageType: DownlinkMessageMessage,
+				DeviceClass: DeviceClassB,
+				DIID:        1234,
+				PDU:         HEXBytes{1, 2, 3},
+				RX2DR:       intPtr(5),
+				RX2Freq:     uint32Ptr(869525000),
+				GPSTime:     int64Ptr(5000000),
+			},
+		},
+		{
+			Name: "class-c",
+			TXInfo: gw.DownlinkTXInfo{
+				GatewayId: ts.gatewayID[:],
+				Frequency: 869525000,
+				Timing:    gw.DownlinkTiming_IMMEDIATELY,
+				TimingInfo: &gw.DownlinkTXInfo_ImmediatelyTimingInfo{
+					ImmediatelyTimingInfo: &gw.ImmediatelyTimingInfo{},
+				},
+			},
+			ExpectedMessage: DownlinkMessage{
+				MessageType: DownlinkMessageMessage,
+				DeviceClass: DeviceClassC,
+				DIID:        1234,
+				PDU:         HEXBytes{1, 2, 3},
+				RX2DR:       intPtr(5),
+				RX2Freq:     uint32Ptr(869525000),
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			txInfo := tst.TXInfo
+			txInfo.Modulation = common.Modulation_LORA
+			txInfo.ModulationInfo = &gw.DownlinkTXInfo_LoraModulationInfo{
+				LoraModulationInfo: &gw.LoRaModulationInfo{
+					SpreadingFactor: 7,
+					Bandwidth:       125,
+				},
+			}
+
+			err := ts.backend.SendTXPacket(gw.DownlinkFrame{
+				PhyPayload: []byte{1, 2, 3},
+				Token:      1234,
+				TxInfo:     &txInfo,
+			})
+			if tst.ExpectedError != "" {
+				assert.EqualError(err, tst.ExpectedError)
+				return
+			}
+			assert.NoError(err)
+
+			var msg DownlinkMessage
+			assert.NoError(ts.conn.ReadJSON(&msg))
+			assert.Equal(tst.ExpectedMessage, msg)
+		})
+	}
+
+	ts.T().Run("gateway not connected", func(t *testing.T) {
+		assert := require.New(t)
+
+		err := ts.backend.SendTXPacket(gw.DownlinkFrame{
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId:  []byte{8, 7, 6, 5, 4, 3, 2, 1},
+				Modulation: common.Modulation_LORA,
+				ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+					LoraModulationInfo: &gw.LoRaModulationInfo{
+						SpreadingFactor: 7,
+						Bandwidth:       125,
+					},
+				},
+				Timing: gw.DownlinkTiming_IMMEDIATELY,
+			},
+		})
+		assert.EqualError(err, "send to gateway error: gateway is not connected")
+	})
+}
+
+func (ts *BackendTestSuite) TestDownlinkTransmitted() {
+	assert := require.New(ts.T())
+
+	assert.NoError(ts.conn.WriteMessage(websocket.TextMessage, []byte(`{
+		"msgtype": "dntxed",
+		"diid": 1234,
+		"DevEui": "00-00-00-00-00-00-00-00",
+		"rctx": 1,
+		"xtime": 2
+	}`)))
+
+	ack := <-ts.backend.DownlinkTXAckChan()
+	assert.Equal(gw.DownlinkTXAck{
+		GatewayId: ts.gatewayID[:],
+		Token:     1234,
+	}, ack)
+}
+
+func (ts *BackendTestSuite) TestTimeSync() {
+	assert := require.New(ts.T())
+
+	assert.NoError(ts.conn.WriteMessage(websocket.TextMessage, []byte(`{
+		"msgtype": "timesync",
+		"txtime": 12345.5
+	}`)))
+
+	var resp TimeSync
+	assert.NoError(ts.conn.ReadJSON(&resp))
+	assert.Equal(TimeSyncMessage, resp.MessageType)
+	assert.Equal(12345.5, resp.TxTime)
+	assert.NotEqual(0, resp.GPSTime)
+}
+
+func TestBackend(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
+
+func TestBackendClose(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	conf.NetworkServer.Gateway.Backend.BasicStation.Bind = "127.0.0.1:0"
+	conf.NetworkServer.Gateway.Backend.BasicStation.StatsInterval = time.Hour
+	conf.NetworkServer.Gateway.Backend.BasicStation.PingInterval = time.Minute
+	conf.NetworkServer.Gateway.Backend.BasicStation.ReadTimeout = 2 * time.Minute
+	conf.NetworkServer.Gateway.Backend.BasicStation.WriteTimeout = time.Second
+
+	backend, err := NewBackend(conf)
+	assert.NoError(err)
+	b := backend.(*Backend)
+
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://%s/gateway/0102030405060708", b.ln.Addr()), nil)
+	assert.NoError(err)
+	defer conn.Close()
+
+	connState := <-b.ConnStateChan()
+	assert.Equal(gw.ConnState_ONLINE, connState.State)
+
+	// the handler blocks on sending the uplink until it is consumed
+	assert.NoError(conn.WriteMessage(websocket.TextMessage, []byte(`{
+		"msgtype": "updf",
+		"MHdr": 64,
+		"DevAddr": 16909060,
+		"FCtrl": 128,
+		"FCnt": 10,
+		"FPort": 1,
+		"FRMPayload": "0405",
+		"MIC": 84281096,
+		"DR": 5,
+		"Freq": 868100000,
+		"upinfo": {
+			"rctx": 1,
+			"xtime": 2,
+			"rssi": -60,
+			"snr": 5.5
+		}
+	}`)))
+
+	// wait until the uplink is pending
+	time.Sleep(50 * time.Millisecond)
+
+	closed := make(chan error)
+	go func() {
+		closed <- b.Close()
+	}()
+
+	// the pending uplink must be received before the channel is closed
+	_, ok := <-b.RXPacketChan()
+	assert.True(ok)
+
+	connState = <-b.ConnStateChan()
+	assert.Equal(gw.ConnState_OFFLINE, connState.State)
+	assert.NoError(<-closed)
+
+	_, ok = <-b.RXPacketChan()
+	assert.False(ok)
+}
+
+func TestEUI64(t *testing.T) {
+	tests := []struct {
+		Name          string
+		JSON          string
+		Expected      EUI64
+		ExpectedError string
+	}{
+		{"eui", `"01-02-03-04-05-06-07-08"`, EUI64{1, 2, 3, 4, 5, 6, 7, 8}, ""},
+		{"hex", `"0102030405060708"`, EUI64{1, 2, 3, 4, 5, 6, 7, 8}, ""},
+		{"integer", `72623859790382856`, EUI64{1, 2, 3, 4, 5, 6, 7, 8}, ""},
+		{"id6", `"102:304:506:708"`, EUI64{1, 2, 3, 4, 5, 6, 7, 8}, ""},
+		{"id6 leading zeros", `"::1"`, EUI64{0, 0, 0, 0, 0, 0, 0, 1}, ""},
+		{"id6 trailing zeros", `"1::"`, EUI64{0, 1, 0, 0, 0, 0, 0, 0}, ""},
+		{"id6 middle zeros", `"1::2"`, EUI64{0, 1, 0, 0, 0, 0, 0, 2}, ""},
+		{"invalid id6", `"1:2:3"`, EUI64{}, "basicstation: invalid id6 '1:2:3'"},
+		{"invalid length", `"010203"`, EUI64{}, "basicstation: exactly 8 bytes are expected"},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var eui EUI64
+			err := eui.UnmarshalJSON([]byte(tst.JSON))
+			if tst.ExpectedError != "" {
+				assert.EqualError(err, tst.ExpectedError)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.Expected, eui)
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func uint32Ptr(i uint32) *uint32 {
+	return &i
+}
+
+func uint64Ptr(i uint64) *uint64 {
+	return &i
+}
@@ -0,0 +1,465 @@
+package basicstation
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+// maxIF defines the max. IF offset (Hz) of a channel relative to the center
+// frequency of the radio.
+const maxIF = 400000
+
+// regionDefaults contains the Basics Station region name and frequency range
+// per band.
+var regionDefaults = map[loraband.Name]struct {
+	Region       string
+	FrequencyMin uint32
+	FrequencyMax uint32
+}{
+	loraband.EU_863_870: {"EU863", 863000000, 870000000},
+	loraband.EU868:      {"EU863", 863000000, 870000000},
+	loraband.US_902_928: {"US902", 902000000, 928000000},
+	loraband.US915:      {"US902", 902000000, 928000000},
+	loraband.AU_915_928: {"AU915", 915000000, 928000000},
+	loraband.AU915:      {"AU915", 915000000, 928000000},
+	loraband.AS_923:     {"AS923", 915000000, 928000000},
+	loraband.AS923:      {"AS923", 915000000, 928000000},
+	loraband.KR_920_923: {"KR920", 920900000, 923300000},
+	loraband.KR920:      {"KR920", 920900000, 923300000},
+	loraband.IN_865_867: {"IN865", 865000000, 867000000},
+	loraband.IN865:      {"IN865", 865000000, 867000000},
+	loraband.CN_470_510: {"CN470", 470000000, 510000000},
+	loraband.CN470:      {"CN470", 470000000, 510000000},
+	loraband.EU_433:     {"EU433", 433175000, 434665000},
+	loraband.EU433:      {"EU433", 433175000, 434665000},
+}
+
+// getDefaultGatewayConfiguration returns the gateway configuration based on
+// the enabled uplink channels of the configured band. This is used until the
+// gateway-profile configuration has been received.
+func getDefaultGatewayConfiguration(gatewayID lorawan.EUI64) (gw.GatewayConfiguration, error) {
+	conf := gw.GatewayConfiguration{
+		GatewayId: gatewayID[:],
+	}
+
+	for _, i := range band.Band().GetEnabledUplinkChannelIndices() {
+		c, err := band.Band().GetUplinkChannel(i)
+		if err != nil {
+			return conf, errors.Wrap(err, "get uplink channel error")
+		}
+
+		dr, err := band.Band().GetDataRate(c.MaxDR)
+		if err != nil {
+			return conf, errors.Wrap(err, "get data-rate error")
+		}
+
+		var txInfo gw.UplinkTXInfo
+		if err := helpers.SetUplinkTXInfoDataRate(&txInfo, c.MaxDR, band.Band()); err != nil {
+			return conf, errors.Wrap(err, "set data-rate error")
+		}
+
+		chanConf := gw.ChannelConfiguration{
+			Frequency:  uint32(c.Frequency),
+			Modulation: txInfo.Modulation,
+		}
+
+		switch dr.Modulation {
+		case loraband.LoRaModulation:
+			modConf := gw.LoRaModulationConfig{
+				Bandwidth: uint32(dr.Bandwidth),
+			}
+			for j := c.MinDR; j <= c.MaxDR; j++ {
+				dr, err := band.Band().GetDataRate(j)
+				if err != nil {
+					return conf, errors.Wrap(err, "get data-rate error")
+				}
+				modConf.SpreadingFactors = append(modConf.SpreadingFactors, uint32(dr.SpreadFactor))
+			}
+			chanConf.ModulationConfig = &gw.ChannelConfiguration_LoraModulationConfig{
+				LoraModulationConfig: &modConf,
+			}
+		case loraband.FSKModulation:
+			chanConf.ModulationConfig = &gw.ChannelConfiguration_FskModulationConfig{
+				FskModulationConfig: &gw.FSKModulationConfig{
+					Bandwidth: uint32(dr.Bandwidth),
+					Bitrate:   uint32(dr.BitRate),
+				},
+			}
+		}
+
+		conf.Channels = append(conf.Channels, &chanConf)
+	}
+
+	return conf, nil
+}
+
+// getRouterConfig returns the router-config message for the given gateway
+// configuration.
+func getRouterConfig(region string, freqMin, freqMax uint32, conf gw.GatewayConfiguration) (RouterConfig, error) {
+	rc := RouterConfig{
+		MessageType:    RouterConfigMessage,
+		Region:         region,
+		HardwareSpec:   "sx1301/1",
+		FrequencyRange: []uint32{freqMin, freqMax},
+		SX1301Conf:     []SX1301Conf{{}},
+	}
+
+	// data-rates
+	for i := range rc.DataRates {
+		dr, err := band.Band().GetDataRate(i)
+		if err != nil {
+			rc.DataRates[i] = [3]int{-1, 0, 0}
+			continue
+		}
+
+		dnOnly := 0
+		if j, err := band.Band().GetDataRateIndex(true, dr); err != nil || j != i {
+			dnOnly = 1
+		}
+
+		switch dr.Modulation {
+		case loraband.LoRaModulation:
+			rc.DataRates[i] = [3]int{dr.SpreadFactor, dr.Bandwidth, dnOnly}
+		case loraband.FSKModulation:
+			rc.DataRates[i] = [3]int{0, 0, dnOnly}
+		}
+	}
+
+	// radios
+	radios, err := getRadioFrequencies(conf.Channels)
+	if err != nil {
+		return rc, err
+	}
+
+	sx1301Conf := &rc.SX1301Conf[0]
+	radioConf := []*SX1301ConfRadio{&sx1301Conf.Radio0, &sx1301Conf.Radio1}
+	for i, freq := range radios {
+		radioConf[i].Enable = true
+		radioConf[i].Freq = freq
+	}
+
+	// channels
+	multiSF := []*SX1301ConfChannel{
+		&sx1301Conf.ChanMultiSF0,
+		&sx1301Conf.ChanMultiSF1,
+		&sx1301Conf.ChanMultiSF2,
+		&sx1301Conf.ChanMultiSF3,
+		&sx1301Conf.ChanMultiSF4,
+		&sx1301Conf.ChanMultiSF5,
+		&sx1301Conf.ChanMultiSF6,
+		&sx1301Conf.ChanMultiSF7,
+	}
+	var multiSFCount int
+
+	for _, c := range conf.Channels {
+		radio, ifFreq := getRadioAndIF(radios, c.Frequency)
+
+		if modConf := c.GetLoraModulationConfig(); modConf != nil {
+			if modConf.Bandwidth == 125 && len(modConf.SpreadingFactors) > 1 {
+				if multiSFCount >= len(multiSF) {
+					return rc, errors.New("too many multi-SF channels")
+				}
+
+				*multiSF[multiSFCount] = SX1301ConfChannel{
+					Enable: true,
+					Radio:  radio,
+					IF:     ifFreq,
+				}
+				multiSFCount++
+				continue
+			}
+
+			if sx1301Conf.ChanLoRaStd.Enable {
+				return rc, errors.New("too many single-SF LoRa channels")
+			}
+
+			sx1301Conf.ChanLoRaStd = SX1301ConfChannel{
+				Enable:    true,
+				Radio:     radio,
+				IF:        ifFreq,
+				Bandwidth: modConf.Bandwidth * 1000,
+			}
+			if len(modConf.SpreadingFactors) != 0 {
+				sx1301Conf.ChanLoRaStd.SpreadFactor = modConf.SpreadingFactors[0]
+			}
+		}
+
+		if modConf := c.GetFskModulationConfig(); modConf != nil {
+			if sx1301Conf.ChanFSK.Enable {
+				return rc, errors.New("too many FSK channels")
+			}
+
+			sx1301Conf.ChanFSK = SX1301ConfChannel{
+				Enable:   true,
+				Radio:    radio,
+				IF:       ifFreq,
+				DataRate: modConf.Bitrate,
+			}
+		}
+	}
+
+	return rc, nil
+}
+
+// getRadioFrequencies returns the center frequencies of the radios needed
+// to cover the given channels.
+func getRadioFrequencies(channels []*gw.ChannelConfiguration) ([]uint32, error) {
+	var freqs []int
+	for _, c := range channels {
+		freqs = append(freqs, int(c.Frequency))
+	}
+	sort.Ints(freqs)
+
+	var radios []uint32
+	var min, max int
+
+	for i, f := range freqs {
+		if i != 0 && f-min <= 2*maxIF {
+			max = f
+			continue
+		}
+
+		if i != 0 {
+			radios = append(radios, uint32((min+max)/2))
+		}
+
+		min = f
+		max = f
+	}
+
+	if len(freqs) != 0 {
+		radios = append(radios, uint32((min+max)/2))
+	}
+
+	if len(radios) > 2 {
+		return nil, errors.New("channels can not be covered by two radios")
+	}
+
+	return radios, nil
+}
+
+// getRadioAndIF returns the radio index and IF offset for the given frequency.
+func getRadioAndIF(radios []uint32, freq uint32) (int, int) {
+	for i, r := range radios {
+		ifFreq := int(freq) - int(r)
+		if ifFreq >= -maxIF && ifFreq <= maxIF {
+			return i, ifFreq
+		}
+	}
+
+	return 0, 0
+}
+
+// getContext returns the uplink context for the given xtime and rctx.
+func getContext(xtime, rctx uint64) []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[0:8], xtime)
+	binary.BigEndian.PutUint64(b[8:16], rctx)
+	return b
+}
+
+// getXTimeAndRCtx returns the xtime and rctx for the given context.
+func getXTimeAndRCtx(b []byte) (uint64, uint64, error) {
+	if len(b) != 16 {
+		return 0, 0, fmt.Errorf("context must be exactly 16 bytes, got: %d", len(b))
+	}
+	return binary.BigEndian.Uint64(b[0:8]), binary.BigEndian.Uint64(b[8:16]), nil
+}
+
+// getUplinkFrame returns the UplinkFrame for the given PHYPayload and radio
+// meta-data.
+func getUplinkFrame(gatewayID lorawan.EUI64, phy []byte, md RadioMetaData) (gw.UplinkFrame, error) {
+	uplinkID, err := uuid.NewV4()
+	if err != nil {
+		return gw.UplinkFrame{}, errors.Wrap(err, "new uuid error")
+	}
+
+	uf := gw.UplinkFrame{
+		PhyPayload: phy,
+		TxInfo: &gw.UplinkTXInfo{
+			Frequency: md.Frequency,
+		},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: gatewayID[:],
+			Rssi:      int32(md.UpInfo.RSSI),
+			LoraSnr:   float64(md.UpInfo.SNR),
+			Context:   getContext(md.UpInfo.XTime, md.UpInfo.RCtx),
+			UplinkId:  uplinkID[:],
+		},
+	}
+
+	if err := helpers.SetUplinkTXInfoDataRate(uf.TxInfo, md.DR, band.Band()); err != nil {
+		return uf, errors.Wrap(err, "set data-rate error")
+	}
+
+	if md.UpInfo.RxTime != 0 {
+		sec := int64(md.UpInfo.RxTime)
+		nsec := int64((md.UpInfo.RxTime - float64(sec)) * float64(time.Second))
+
+		uf.RxInfo.Time, err = ptypes.TimestampProto(time.Unix(sec, nsec))
+		if err != nil {
+			return uf, errors.Wrap(err, "timestamp proto error")
+		}
+	}
+
+	if md.UpInfo.GPSTime != 0 {
+		uf.RxInfo.TimeSinceGpsEpoch = ptypes.DurationProto(time.Duration(md.UpInfo.GPSTime) * time.Microsecond)
+	}
+
+	return uf, nil
+}
+
+// UplinkDataFrameToUplinkFrame converts the UplinkDataFrame into an
+// UplinkFrame.
+func UplinkDataFrameToUplinkFrame(gatewayID lorawan.EUI64, pl UplinkDataFrame) (gw.UplinkFrame, error) {
+	phy := []byte{pl.MHDR}
+
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, uint32(pl.DevAddr))
+	phy = append(phy, b...)
+	phy = append(phy, pl.FCtrl)
+
+	b = make([]byte, 2)
+	binary.LittleEndian.PutUint16(b, pl.FCnt)
+	phy = append(phy, b...)
+	phy = append(phy, pl.FOpts...)
+
+	if pl.FPort != -1 {
+		phy = append(phy, uint8(pl.FPort))
+	}
+	phy = append(phy, pl.FRMPayload...)
+
+	b = make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, uint32(pl.MIC))
+	phy = append(phy, b...)
+
+	return getUplinkFrame(gatewayID, phy, pl.RadioMetaData)
+}
+
+// JoinRequestToUplinkFrame converts the JoinRequest into an UplinkFrame.
+func JoinRequestToUplinkFrame(gatewayID lorawan.EUI64, pl JoinRequest) (gw.UplinkFrame, error) {
+	phy := []byte{pl.MHDR}
+
+	// the EUIs are encoded little-endian
+	for i := len(pl.JoinEUI) - 1; i >= 0; i-- {
+		phy = append(phy, pl.JoinEUI[i])
+	}
+	for i := len(pl.DevEUI) - 1; i >= 0; i-- {
+		phy = append(phy, pl.DevEUI[i])
+	}
+
+	b := make([]byte, 2)
+	binary.LittleEndian.PutUint16(b, pl.DevNonce)
+	phy = append(phy, b...)
+
+	b = make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, uint32(pl.MIC))
+	phy = append(phy, b...)
+
+	return getUplinkFrame(gatewayID, phy, pl.RadioMetaData)
+}
+
+// UplinkProprietaryFrameToUplinkFrame converts the UplinkProprietaryFrame
+// into an UplinkFrame.
+func UplinkProprietaryFrameToUplinkFrame(gatewayID lorawan.EUI64, pl UplinkProprietaryFrame) (gw.UplinkFrame, error) {
+	return getUplinkFrame(gatewayID, pl.FRMPayload, pl.RadioMetaData)
+}
+
+// DownlinkFrameToDownlinkMessage converts the DownlinkFrame into a
+// DownlinkMessage.
+func DownlinkFrameToDownlinkMessage(pl gw.DownlinkFrame) (DownlinkMessage, error) {
+	if pl.TxInfo == nil {
+		return DownlinkMessage{}, errors.New("tx_info must not be nil")
+	}
+
+	dr, err := helpers.GetDataRateIndex(false, pl.TxInfo, band.Band())
+	if err != nil {
+		return DownlinkMessage{}, errors.Wrap(err, "get data-rate index error")
+	}
+	freq := pl.TxInfo.Frequency
+
+	msg := DownlinkMessage{
+		MessageType: DownlinkMessageMessage,
+		DIID:        int64(pl.Token),
+		PDU:         HEXBytes(pl.PhyPayload),
+	}
+
+	if len(pl.TxInfo.Context) != 0 {
+		xtime, rctx, err := getXTimeAndRCtx(pl.TxInfo.Context)
+		if err != nil {
+			return msg, errors.Wrap(err, "get xtime and rctx error")
+		}
+		msg.XTime = &xtime
+		msg.RCtx = &rctx
+	}
+
+	switch pl.TxInfo.Timing {
+	case gw.DownlinkTiming_DELAY:
+		timingInfo := pl.TxInfo.GetDelayTimingInfo()
+		if timingInfo == nil {
+			return msg, errors.New("delay_timing_info must not be nil")
+		}
+		if msg.XTime == nil {
+			return msg, errors.New("context must be set for delay timing")
+		}
+
+		delay, err := ptypes.Duration(timingInfo.Delay)
+		if err != nil {
+			return msg, errors.Wrap(err, "get delay duration error")
+		}
+		rxDelay := int(delay / time.Second)
+
+		// The Basics Station calculates the RX1 timing using the RxDelay.
+		// As the delay is already set to the RX1 or RX2 delay, we can
+		// always use the RX1 fields.
+		msg.DeviceClass = DeviceClassA
+		msg.RxDelay = &rxDelay
+		msg.RX1DR = &dr
+		msg.RX1Freq = &freq
+	case gw.DownlinkTiming_GPS_EPOCH:
+		timingInfo := pl.TxInfo.GetGpsEpochTimingInfo()
+		if timingInfo == nil {
+			return msg, errors.New("gps_epoch_timing_info must not be nil")
+		}
+
+		gpsTime, err := ptypes.Duration(timingInfo.TimeSinceGpsEpoch)
+		if err != nil {
+			return msg, errors.Wrap(err, "get time since gps epoch error")
+		}
+		gpsTimeUS := int64(gpsTime / time.Microsecond)
+
+		msg.DeviceClass = DeviceClassB
+		msg.GPSTime = &gpsTimeUS
+		msg.RX2DR = &dr
+		msg.RX2Freq = &freq
+	case gw.DownlinkTiming_IMMEDIATELY:
+		msg.DeviceClass = DeviceClassC
+		msg.RX2DR = &dr
+		msg.RX2Freq = &freq
+	default:
+		return msg, fmt.Errorf("unexpected downlink timing: %s", pl.TxInfo.Timing)
+	}
+
+	return msg, nil
+}
+
+// DownlinkTransmittedToDownlinkTXAck converts the DownlinkTransmitted into a
+// DownlinkTXAck.
+func DownlinkTransmittedToDownlinkTXAck(gatewayID lorawan.EUI64, pl DownlinkTransmitted) gw.DownlinkTXAck {
+	return gw.DownlinkTXAck{
+		GatewayId: gatewayID[:],
+		Token:     uint32(pl.DIID),
+	}
+}
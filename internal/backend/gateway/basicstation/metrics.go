@@ -0,0 +1,35 @@
+package basicstation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_basic_station_event_count",
+		Help: "The number of received events by the Basics Station backend (per event type).",
+	}, []string{"event"})
+
+	cc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_basic_station_command_count",
+		Help: "The number of sent commands by the Basics Station backend (per command type).",
+	}, []string{"command"})
+
+	wsc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_basic_station_websocket_count",
+		Help: "The number of WebSocket connects and disconnects by the Basics Station backend.",
+	}, []string{"event"})
+)
+
+func basicStationEventCounter(e string) prometheus.Counter {
+	return ec.With(prometheus.Labels{"event": e})
+}
+
+func basicStationCommandCounter(c string) prometheus.Counter {
+	return cc.With(prometheus.Labels{"command": c})
+}
+
+func basicStationWebsocketCounter(e string) prometheus.Counter {
+	return wsc.With(prometheus.Labels{"event": e})
+}
@@ -0,0 +1,310 @@
+package basicstation
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// MessageType defines the message type.
+type MessageType string
+
+// Message types.
+const (
+	VersionMessage              MessageType = "version"
+	RouterConfigMessage         MessageType = "router_config"
+	UplinkDataFrameMessage      MessageType = "updf"
+	JoinRequestMessage          MessageType = "jreq"
+	ProprietaryDataFrameMessage MessageType = "propdf"
+	DownlinkMessageMessage      MessageType = "dnmsg"
+	DownlinkTransmittedMessage  MessageType = "dntxed"
+	TimeSyncMessage             MessageType = "timesync"
+)
+
+// Device classes as used by the dnmsg message.
+const (
+	DeviceClassA = 0
+	DeviceClassB = 1
+	DeviceClassC = 2
+)
+
+// EUI64 implements the Basics Station EUI64 type. When unmarshaling, it
+// accepts the EUI, ID6 and integer representations. It is always marshaled
+// using the EUI representation.
+type EUI64 lorawan.EUI64
+
+// MarshalText implements encoding.TextMarshaler.
+func (e EUI64) MarshalText() ([]byte, error) {
+	parts := make([]string, len(e))
+	for i := range e {
+		parts[i] = hex.EncodeToString(e[i : i+1])
+	}
+	return []byte(strings.Join(parts, "-")), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *EUI64) UnmarshalJSON(b []byte) error {
+	// integer representation
+	if len(b) != 0 && b[0] != '"' {
+		i, err := strconv.ParseUint(string(b), 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "parse uint64 error")
+		}
+		binary.BigEndian.PutUint64(e[:], i)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return e.UnmarshalText([]byte(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (e *EUI64) UnmarshalText(text []byte) error {
+	s := string(text)
+
+	// ID6 representation
+	if strings.Contains(s, ":") {
+		return e.unmarshalID6(s)
+	}
+
+	s = strings.Replace(s, "-", "", -1)
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return errors.Wrap(err, "decode hex error")
+	}
+	if len(b) != len(e) {
+		return fmt.Errorf("basicstation: exactly %d bytes are expected", len(e))
+	}
+	copy(e[:], b)
+	return nil
+}
+
+// unmarshalID6 decodes the ID6 representation (e.g. 1:2:3:4 or ::1).
+func (e *EUI64) unmarshalID6(s string) error {
+	var head, tail []string
+
+	parts := strings.SplitN(s, "::", 2)
+	if parts[0] != "" {
+		head = strings.Split(parts[0], ":")
+	}
+	if len(parts) == 2 && parts[1] != "" {
+		tail = strings.Split(parts[1], ":")
+	}
+
+	if len(parts) == 1 && len(head) != 4 {
+		return fmt.Errorf("basicstation: invalid id6 '%s'", s)
+	}
+	if len(head)+len(tail) > 4 {
+		return fmt.Errorf("basicstation: invalid id6 '%s'", s)
+	}
+
+	groups := make([]string, 4)
+	for i := range groups {
+		groups[i] = "0"
+	}
+	copy(groups, head)
+	copy(groups[4-len(tail):], tail)
+
+	for i, g := range groups {
+		v, err := strconv.ParseUint(g, 16, 16)
+		if err != nil {
+			return errors.Wrap(err, "parse id6 group error")
+		}
+		binary.BigEndian.PutUint16(e[i*2:], uint16(v))
+	}
+
+	return nil
+}
+
+// HEXBytes defines a type which represents bytes as HEX when marshaled to
+// text.
+type HEXBytes []byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (h HEXBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(h)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (h *HEXBytes) UnmarshalText(text []byte) error {
+	b, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*h = b
+	return nil
+}
+
+// BaseMessage contains the fields which are common to all messages.
+type BaseMessage struct {
+	MessageType MessageType `json:"msgtype"`
+}
+
+// RouterInfoRequest implements the router-info request.
+type RouterInfoRequest struct {
+	Router EUI64 `json:"router"`
+}
+
+// RouterInfoResponse implements the router-info response.
+type RouterInfoResponse struct {
+	Router EUI64  `json:"router"`
+	Muxs   EUI64  `json:"muxs"`
+	URI    string `json:"uri,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Version implements the version message.
+type Version struct {
+	MessageType MessageType `json:"msgtype"`
+	Station     string      `json:"station"`
+	Firmware    string      `json:"firmware"`
+	Package     string      `json:"package"`
+	Model       string      `json:"model"`
+	Protocol    int         `json:"protocol"`
+	Features    string      `json:"features"`
+}
+
+// RouterConfig implements the router-config message.
+type RouterConfig struct {
+	MessageType    MessageType  `json:"msgtype"`
+	NetID          []uint32     `json:"NetID,omitempty"`
+	Region         string       `json:"region"`
+	HardwareSpec   string       `json:"hwspec"`
+	FrequencyRange []uint32     `json:"freq_range"`
+	DataRates      [16][3]int   `json:"DRs"`
+	SX1301Conf     []SX1301Conf `json:"sx1301_conf"`
+	NoCCA          bool         `json:"nocca"`
+	NoDutyCycle    bool         `json:"nodc"`
+	NoDwellTime    bool         `json:"nodwell"`
+}
+
+// SX1301Conf implements the SX1301 concentrator configuration.
+type SX1301Conf struct {
+	Radio0       SX1301ConfRadio   `json:"radio_0"`
+	Radio1       SX1301ConfRadio   `json:"radio_1"`
+	ChanMultiSF0 SX1301ConfChannel `json:"chan_multiSF_0"`
+	ChanMultiSF1 SX1301ConfChannel `json:"chan_multiSF_1"`
+	ChanMultiSF2 SX1301ConfChannel `json:"chan_multiSF_2"`
+	ChanMultiSF3 SX1301ConfChannel `json:"chan_multiSF_3"`
+	ChanMultiSF4 SX1301ConfChannel `json:"chan_multiSF_4"`
+	ChanMultiSF5 SX1301ConfChannel `json:"chan_multiSF_5"`
+	ChanMultiSF6 SX1301ConfChannel `json:"chan_multiSF_6"`
+	ChanMultiSF7 SX1301ConfChannel `json:"chan_multiSF_7"`
+	ChanLoRaStd  SX1301ConfChannel `json:"chan_Lora_std"`
+	ChanFSK      SX1301ConfChannel `json:"chan_FSK"`
+}
+
+// SX1301ConfRadio implements a SX1301 radio configuration.
+type SX1301ConfRadio struct {
+	Enable bool   `json:"enable"`
+	Freq   uint32 `json:"freq"`
+}
+
+// SX1301ConfChannel implements a SX1301 (IF) channel configuration.
+type SX1301ConfChannel struct {
+	Enable       bool   `json:"enable"`
+	Radio        int    `json:"radio"`
+	IF           int    `json:"if"`
+	Bandwidth    uint32 `json:"bandwidth,omitempty"`
+	SpreadFactor uint32 `json:"spread_factor,omitempty"`
+	DataRate     uint32 `json:"datarate,omitempty"`
+}
+
+// UpInfo implements the radio meta-data of an uplink.
+type UpInfo struct {
+	RCtx    uint64  `json:"rctx"`
+	XTime   uint64  `json:"xtime"`
+	GPSTime int64   `json:"gpstime"`
+	RSSI    float32 `json:"rssi"`
+	SNR     float32 `json:"snr"`
+	RxTime  float64 `json:"rxtime"`
+}
+
+// RadioMetaData contains the radio meta-data of an uplink.
+type RadioMetaData struct {
+	DR        int    `json:"DR"`
+	Frequency uint32 `json:"Freq"`
+	UpInfo    UpInfo `json:"upinfo"`
+}
+
+// UplinkDataFrame implements the uplink data-frame message.
+type UplinkDataFrame struct {
+	RadioMetaData
+
+	MessageType MessageType `json:"msgtype"`
+	MHDR        uint8       `json:"MHdr"`
+	DevAddr     int32       `json:"DevAddr"`
+	FCtrl       uint8       `json:"FCtrl"`
+	FCnt        uint16      `json:"FCnt"`
+	FOpts       HEXBytes    `json:"FOpts"`
+	FPort       int         `json:"FPort"`
+	FRMPayload  HEXBytes    `json:"FRMPayload"`
+	MIC         int32       `json:"MIC"`
+}
+
+// JoinRequest implements the join-request message.
+type JoinRequest struct {
+	RadioMetaData
+
+	MessageType MessageType `json:"msgtype"`
+	MHDR        uint8       `json:"MHdr"`
+	JoinEUI     EUI64       `json:"JoinEui"`
+	DevEUI      EUI64       `json:"DevEui"`
+	DevNonce    uint16      `json:"DevNonce"`
+	MIC         int32       `json:"MIC"`
+}
+
+// UplinkProprietaryFrame implements the proprietary uplink frame message.
+type UplinkProprietaryFrame struct {
+	RadioMetaData
+
+	MessageType MessageType `json:"msgtype"`
+	FRMPayload  HEXBytes    `json:"FRMPayload"`
+}
+
+// DownlinkMessage implements the downlink message.
+type DownlinkMessage struct {
+	MessageType MessageType `json:"msgtype"`
+	DevEUI      EUI64       `json:"DevEui"`
+	DeviceClass uint8       `json:"dC"`
+	DIID        int64       `json:"diid"`
+	PDU         HEXBytes    `json:"pdu"`
+	Priority    int         `json:"priority"`
+	RxDelay     *int        `json:"RxDelay,omitempty"`
+	RX1DR       *int        `json:"RX1DR,omitempty"`
+	RX1Freq     *uint32     `json:"RX1Freq,omitempty"`
+	RX2DR       *int        `json:"RX2DR,omitempty"`
+	RX2Freq     *uint32     `json:"RX2Freq,omitempty"`
+	XTime       *uint64     `json:"xtime,omitempty"`
+	RCtx        *uint64     `json:"rctx,omitempty"`
+	GPSTime     *int64      `json:"gpstime,omitempty"`
+}
+
+// DownlinkTransmitted implements the downlink transmitted message.
+type DownlinkTransmitted struct {
+	MessageType MessageType `json:"msgtype"`
+	DevEUI      EUI64       `json:"DevEui"`
+	DIID        int64       `json:"diid"`
+	RCtx        uint64      `json:"rctx"`
+	XTime       uint64      `json:"xtime"`
+	TxTime      float64     `json:"txtime"`
+	GPSTime     int64       `json:"gpstime"`
+}
+
+// TimeSync implements the timesync request and response message.
+type TimeSync struct {
+	MessageType MessageType `json:"msgtype"`
+	TxTime      float64     `json:"txtime"`
+	GPSTime     int64       `json:"gpstime,omitempty"`
+}
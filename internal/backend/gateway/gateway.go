@@ -17,10 +17,13 @@ func SetBackend(b Gateway) {
 // Gateway is the interface of a gateway backend.
 // A gateway backend is responsible for the communication with the gateway.
 type Gateway interface {
-	SendTXPacket(gw.DownlinkFrame) error                   // send the given packet to the gateway
-	SendGatewayConfigPacket(gw.GatewayConfiguration) error // SendGatewayConfigPacket sends the given GatewayConfigPacket to the gateway.
-	RXPacketChan() chan gw.UplinkFrame                     // channel containing the received packets
-	StatsPacketChan() chan gw.GatewayStats                 // channel containing the received gateway stats
-	DownlinkTXAckChan() chan gw.DownlinkTXAck              // channel containing the downlink tx acknowledgements
-	Close() error                                          // close the gateway backend.
+	SendTXPacket(gw.DownlinkFrame) error                                // send the given packet to the gateway
+	SendGatewayConfigPacket(gw.GatewayConfiguration) error              // SendGatewayConfigPacket sends the given GatewayConfigPacket to the gateway.
+	SendGatewayCommandExecRequest(gw.GatewayCommandExecRequest) error   // send the given command execution request to the gateway
+	RXPacketChan() chan gw.UplinkFrame                                  // channel containing the received packets
+	StatsPacketChan() chan gw.GatewayStats                              // channel containing the received gateway stats
+	DownlinkTXAckChan() chan gw.DownlinkTXAck                           // channel containing the downlink tx acknowledgements
+	GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse // channel containing the command execution responses
+	ConnStateChan() chan gw.ConnState                                   // channel containing the gateway connection-states
+	Close() error                                                       // close the gateway backend.
 }
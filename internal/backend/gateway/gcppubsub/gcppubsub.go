@@ -35,10 +35,12 @@ type Backend struct {
 	uplinkTopic        *pubsub.Topic
 	uplinkSubscription *pubsub.Subscription
 
-	uplinkFrameChan   chan gw.UplinkFrame
-	gatewayStatsChan  chan gw.GatewayStats
-	downlinkTXAckChan chan gw.DownlinkTXAck
-	gatewayMarshaler  map[lorawan.EUI64]marshaler.Type
+	uplinkFrameChan         chan gw.UplinkFrame
+	gatewayStatsChan        chan gw.GatewayStats
+	downlinkTXAckChan       chan gw.DownlinkTXAck
+	commandExecResponseChan chan gw.GatewayCommandExecResponse
+	connStateChan           chan gw.ConnState
+	gatewayMarshaler        map[lorawan.EUI64]marshaler.Type
 }
 
 // NewBackend creates a new Backend.
@@ -46,11 +48,13 @@ func NewBackend(c config.Config) (gateway.Gateway, error) {
 	conf := c.NetworkServer.Gateway.Backend.GCPPubSub
 
 	b := Backend{
-		gatewayMarshaler:  make(map[lorawan.EUI64]marshaler.Type),
-		uplinkFrameChan:   make(chan gw.UplinkFrame),
-		gatewayStatsChan:  make(chan gw.GatewayStats),
-		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
-		ctx:               context.Background(),
+		gatewayMarshaler:        make(map[lorawan.EUI64]marshaler.Type),
+		uplinkFrameChan:         make(chan gw.UplinkFrame),
+		gatewayStatsChan:        make(chan gw.GatewayStats),
+		downlinkTXAckChan:       make(chan gw.DownlinkTXAck),
+		commandExecResponseChan: make(chan gw.GatewayCommandExecResponse),
+		connStateChan:           make(chan gw.ConnState),
+		ctx:                     context.Background(),
 	}
 	var err error
 	var o []option.ClientOption
@@ -155,6 +159,22 @@ func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
 	return b.publishCommand(log.Fields{}, gatewayID, "config", bb)
 }
 
+// SendGatewayCommandExecRequest sends the given command execution request
+// to the gateway.
+func (b *Backend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+	t := b.getGatewayMarshaler(gatewayID)
+
+	bb, err := marshaler.MarshalCommand(t, &pl)
+	if err != nil {
+		return errors.Wrap(err, "gateway/gcp_pub_sub: marshal gateway command execution request error")
+	}
+
+	return b.publishCommand(log.Fields{
+		"exec_id": helpers.GetExecID(&pl),
+	}, gatewayID, "exec", bb)
+}
+
 // RXPacketChan returns the channel to which uplink frames are published.
 func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
 	return b.uplinkFrameChan
@@ -170,6 +190,18 @@ func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
 	return b.downlinkTXAckChan
 }
 
+// GatewayCommandExecResponseChan returns the gateway command execution
+// response channel.
+func (b *Backend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.commandExecResponseChan
+}
+
+// ConnStateChan returns the gateway connection-state channel.
+// Note that this backend does not publish gateway connection-states.
+func (b *Backend) ConnStateChan() chan gw.ConnState {
+	return b.connStateChan
+}
+
 // Close closes the backend.
 func (b *Backend) Close() error {
 	log.Info("gateway/gcp_pub_sub: closing backend")
@@ -177,6 +209,8 @@ func (b *Backend) Close() error {
 	close(b.uplinkFrameChan)
 	close(b.gatewayStatsChan)
 	close(b.downlinkTXAckChan)
+	close(b.commandExecResponseChan)
+	close(b.connStateChan)
 	return b.client.Close()
 }
 
@@ -250,6 +284,8 @@ func (b *Backend) receiveFunc(ctx context.Context, msg *pubsub.Message) {
 		err = b.handleGatewayStats(gatewayID, msg.Data)
 	case "ack":
 		err = b.handleDownlinkTXAck(gatewayID, msg.Data)
+	case "exec":
+		err = b.handleGatewayCommandExecResponse(gatewayID, msg.Data)
 	default:
 		log.WithFields(log.Fields{
 			"gateway_id": gatewayID,
@@ -354,3 +390,30 @@ func (b *Backend) handleDownlinkTXAck(gatewayID lorawan.EUI64, data []byte) erro
 
 	return nil
 }
+
+func (b *Backend) handleGatewayCommandExecResponse(gatewayID lorawan.EUI64, data []byte) error {
+	var resp gw.GatewayCommandExecResponse
+	t, err := marshaler.UnmarshalGatewayCommandExecResponse(data, &resp)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	// make sure that the registered gateway is not using a different gateway_id
+	// than the ID used during the registration in Cloud IoT Core.
+	if !bytes.Equal(resp.GatewayId, gatewayID[:]) {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	execID := helpers.GetExecID(&resp)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"exec_id":    execID,
+	}).Info("gateway/gcp_pub_sub: exec event received")
+
+	b.commandExecResponseChan <- resp
+
+	return nil
+}
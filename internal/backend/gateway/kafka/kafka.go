@@ -0,0 +1,391 @@
+// Package kafka implements a Kafka gateway backend.
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/marshaler"
+	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/lorawan"
+)
+
+// commandHeader defines the message header containing the command type.
+const commandHeader = "command"
+
+// Backend implements a Kafka backend.
+type Backend struct {
+	sync.RWMutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	uplinkReader *kafka.Reader
+	statsReader  *kafka.Reader
+	ackReader    *kafka.Reader
+	writer       *kafka.Writer
+
+	uplinkFrameChan         chan gw.UplinkFrame
+	gatewayStatsChan        chan gw.GatewayStats
+	downlinkTXAckChan       chan gw.DownlinkTXAck
+	commandExecResponseChan chan gw.GatewayCommandExecResponse
+	connStateChan           chan gw.ConnState
+	gatewayMarshaler        map[lorawan.EUI64]marshaler.Type
+}
+
+// NewBackend creates a new Backend.
+func NewBackend(c config.Config) (gateway.Gateway, error) {
+	conf := c.NetworkServer.Gateway.Backend.Kafka
+
+	b := Backend{
+		uplinkFrameChan:         make(chan gw.UplinkFrame),
+		gatewayStatsChan:        make(chan gw.GatewayStats),
+		downlinkTXAckChan:       make(chan gw.DownlinkTXAck),
+		commandExecResponseChan: make(chan gw.GatewayCommandExecResponse),
+		connStateChan:           make(chan gw.ConnState),
+		gatewayMarshaler:        make(map[lorawan.EUI64]marshaler.Type),
+	}
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+
+	dialer := &kafka.Dialer{
+		Timeout:   10 * time.Second,
+		DualStack: true,
+	}
+	if conf.TLS {
+		dialer.TLS = &tls.Config{}
+	}
+	if conf.Username != "" || conf.Password != "" {
+		dialer.SASLMechanism = plain.Mechanism{
+			Username: conf.Username,
+			Password: conf.Password,
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"brokers":  conf.Brokers,
+		"group_id": conf.GroupID,
+	}).Info("gateway/kafka: setting up consumer group readers")
+
+	b.uplinkReader = newReader(dialer, conf.Brokers, conf.GroupID, conf.UplinkTopic)
+	b.statsReader = newReader(dialer, conf.Brokers, conf.GroupID, conf.StatsTopic)
+	b.ackReader = newReader(dialer, conf.Brokers, conf.GroupID, conf.AckTopic)
+
+	log.WithFields(log.Fields{
+		"brokers": conf.Brokers,
+		"topic":   conf.CommandTopic,
+	}).Info("gateway/kafka: setting up command writer")
+
+	b.writer = kafka.NewWriter(kafka.WriterConfig{
+		Brokers:  conf.Brokers,
+		Topic:    conf.CommandTopic,
+		Dialer:   dialer,
+		Balancer: &kafka.Hash{},
+
+		// Downlinks are time-critical, flush every message immediately.
+		BatchSize: 1,
+	})
+
+	for _, r := range []struct {
+		event   string
+		reader  *kafka.Reader
+		handler func(lorawan.EUI64, []byte) error
+	}{
+		{"up", b.uplinkReader, b.handleUplinkFrame},
+		{"stats", b.statsReader, b.handleGatewayStats},
+		{"ack", b.ackReader, b.handleDownlinkTXAck},
+	} {
+		b.wg.Add(1)
+		go func(event string, reader *kafka.Reader, handler func(lorawan.EUI64, []byte) error) {
+			defer b.wg.Done()
+			b.consume(event, reader, handler)
+		}(r.event, r.reader, r.handler)
+	}
+
+	return &b, nil
+}
+
+// SendTXPacket sends the given downlink frame to the gateway.
+func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if pl.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(pl.TxInfo)
+	downID := helpers.GetDownlinkID(&pl)
+
+	return b.publishCommand(log.Fields{
+		"downlink_id": downID,
+	}, gatewayID, "down", &pl)
+}
+
+// SendGatewayConfigPacket sends the given gateway configuration to the gateway.
+func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+
+	return b.publishCommand(log.Fields{}, gatewayID, "config", &pl)
+}
+
+// SendGatewayCommandExecRequest is not supported by the Kafka backend, as
+// there is no topic for consuming the command execution responses.
+func (b *Backend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	return errors.New("gateway/kafka: gateway command execution is not supported")
+}
+
+// RXPacketChan returns the channel to which uplink frames are published.
+func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+// StatsPacketChan returns the channel to which gateway stats are published.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+// DownlinkTXAckChan returns the downlink tx ack channel.
+func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+// GatewayCommandExecResponseChan returns the gateway command execution
+// response channel.
+func (b *Backend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.commandExecResponseChan
+}
+
+// ConnStateChan returns the gateway connection-state channel.
+// Note that this backend does not publish gateway connection-states.
+func (b *Backend) ConnStateChan() chan gw.ConnState {
+	return b.connStateChan
+}
+
+// Close closes the backend.
+// Note that this closes the backend one-way (gateway to backend).
+// This makes it possible to perform a graceful shutdown (e.g. when there are
+// still packets to send back to the gateway).
+func (b *Backend) Close() error {
+	log.Info("gateway/kafka: closing backend")
+	b.cancel()
+
+	log.Info("gateway/kafka: handling last messages")
+	b.wg.Wait()
+
+	for _, r := range []*kafka.Reader{b.uplinkReader, b.statsReader, b.ackReader} {
+		if err := r.Close(); err != nil {
+			return errors.Wrap(err, "gateway/kafka: close reader error")
+		}
+	}
+
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+	close(b.commandExecResponseChan)
+	close(b.connStateChan)
+	return nil
+}
+
+func newReader(dialer *kafka.Dialer, brokers []string, groupID, topic string) *kafka.Reader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokers,
+		GroupID: groupID,
+		Topic:   topic,
+		Dialer:  dialer,
+	})
+}
+
+// consume reads the messages from the given reader. The offset of a message
+// is committed after it has been handled. As the backend channels are
+// unbuffered, this means that the offset is committed once the event
+// has been handed over to the consumer of the channel.
+//
+// Note that this is before the event has been processed, e.g. the uplink
+// frames are de-duplicated and handled asynchronously by the uplink package.
+// Waiting for this would serialize the de-duplication delay for every
+// message of a partition. The trade-off is that events which are being
+// processed when the network-server stops or crashes are lost
+// (at-most-once delivery).
+func (b *Backend) consume(event string, reader *kafka.Reader, handler func(lorawan.EUI64, []byte) error) {
+	for {
+		msg, err := reader.FetchMessage(b.ctx)
+		if err != nil {
+			if b.ctx.Err() != nil {
+				return
+			}
+
+			log.WithError(err).WithField("topic", reader.Config().Topic).Error("gateway/kafka: fetch message error")
+			time.Sleep(time.Second)
+			continue
+		}
+
+		kafkaEventCounter(event).Inc()
+
+		var gatewayID lorawan.EUI64
+		if err := gatewayID.UnmarshalText(msg.Key); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"topic":      msg.Topic,
+				"key_base64": base64.StdEncoding.EncodeToString(msg.Key),
+			}).Error("gateway/kafka: unmarshal gateway id error")
+		} else if err := handler(gatewayID, msg.Value); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"gateway_id":  gatewayID,
+				"topic":       msg.Topic,
+				"data_base64": base64.StdEncoding.EncodeToString(msg.Value),
+			}).Error("gateway/kafka: handle received message error")
+		}
+
+		// Invalid messages are committed too, as they would otherwise be
+		// re-delivered over and over again.
+		if err := reader.CommitMessages(context.Background(), msg); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"topic":     msg.Topic,
+				"partition": msg.Partition,
+				"offset":    msg.Offset,
+			}).Error("gateway/kafka: commit message error")
+		}
+	}
+}
+
+func (b *Backend) handleUplinkFrame(gatewayID lorawan.EUI64, data []byte) error {
+	var uplinkFrame gw.UplinkFrame
+	t, err := marshaler.UnmarshalUplinkFrame(data, &uplinkFrame)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	if uplinkFrame.RxInfo == nil {
+		return errors.New("rx_info must not be nil")
+	}
+
+	if uplinkFrame.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	if helpers.GetGatewayID(uplinkFrame.RxInfo) != gatewayID {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	upID := helpers.GetUplinkID(uplinkFrame.RxInfo)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"uplink_id":  upID,
+	}).Info("gateway/kafka: uplink event received")
+
+	b.uplinkFrameChan <- uplinkFrame
+
+	return nil
+}
+
+func (b *Backend) handleGatewayStats(gatewayID lorawan.EUI64, data []byte) error {
+	var gatewayStats gw.GatewayStats
+	t, err := marshaler.UnmarshalGatewayStats(data, &gatewayStats)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	if helpers.GetGatewayID(&gatewayStats) != gatewayID {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	statsID := helpers.GetStatsID(&gatewayStats)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"stats_id":   statsID,
+	}).Info("gateway/kafka: stats event received")
+
+	b.gatewayStatsChan <- gatewayStats
+
+	return nil
+}
+
+func (b *Backend) handleDownlinkTXAck(gatewayID lorawan.EUI64, data []byte) error {
+	var ack gw.DownlinkTXAck
+	t, err := marshaler.UnmarshalDownlinkTXAck(data, &ack)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	if helpers.GetGatewayID(&ack) != gatewayID {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	downID := helpers.GetDownlinkID(&ack)
+
+	log.WithFields(log.Fields{
+		"gateway_id":  gatewayID,
+		"downlink_id": downID,
+	}).Info("gateway/kafka: ack event received")
+
+	b.downlinkTXAckChan <- ack
+
+	return nil
+}
+
+func (b *Backend) publishCommand(fields log.Fields, gatewayID lorawan.EUI64, command string, msg proto.Message) error {
+	t := b.getGatewayMarshaler(gatewayID)
+	bb, err := marshaler.MarshalCommand(t, msg)
+	if err != nil {
+		return errors.Wrap(err, "gateway/kafka: marshal gateway command error")
+	}
+
+	key, err := gatewayID.MarshalText()
+	if err != nil {
+		return errors.Wrap(err, "gateway/kafka: marshal gateway id error")
+	}
+
+	start := time.Now()
+
+	err = b.writer.WriteMessages(context.Background(), kafka.Message{
+		Key:   key,
+		Value: bb,
+		Headers: []kafka.Header{
+			{Key: commandHeader, Value: []byte(command)},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "gateway/kafka: publish gateway command error")
+	}
+
+	fields["duration"] = time.Now().Sub(start)
+	fields["gateway_id"] = gatewayID
+	fields["command"] = command
+
+	log.WithFields(fields).Info("gateway/kafka: gateway command published")
+
+	kafkaCommandCounter(command).Inc()
+
+	return nil
+}
+
+func (b *Backend) setGatewayMarshaler(gatewayID lorawan.EUI64, t marshaler.Type) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.gatewayMarshaler[gatewayID] = t
+}
+
+func (b *Backend) getGatewayMarshaler(gatewayID lorawan.EUI64) marshaler.Type {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.gatewayMarshaler[gatewayID]
+}
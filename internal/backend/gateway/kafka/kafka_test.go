@@ -0,0 +1,202 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway/marshaler"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+type BackendTestSuite struct {
+	suite.Suite
+
+	backend       gateway.Gateway
+	writers       map[string]*kafka.Writer
+	commandReader *kafka.Reader
+}
+
+func (ts *BackendTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+	kafkaConf := &conf.NetworkServer.Gateway.Backend.Kafka
+
+	// use unique topic names, so that messages of previous test-runs are
+	// not consumed
+	suffix := fmt.Sprintf("-%d", time.Now().UnixNano())
+	kafkaConf.UplinkTopic += suffix
+	kafkaConf.StatsTopic += suffix
+	kafkaConf.AckTopic += suffix
+	kafkaConf.CommandTopic += suffix
+
+	conn, err := kafka.Dial("tcp", kafkaConf.Brokers[0])
+	assert.NoError(err)
+	defer conn.Close()
+
+	ts.writers = make(map[string]*kafka.Writer)
+	for typ, topic := range map[string]string{
+		"up":    kafkaConf.UplinkTopic,
+		"stats": kafkaConf.StatsTopic,
+		"ack":   kafkaConf.AckTopic,
+	} {
+		assert.NoError(conn.CreateTopics(kafka.TopicConfig{
+			Topic:             topic,
+			NumPartitions:     1,
+			ReplicationFactor: 1,
+		}))
+
+		ts.writers[typ] = kafka.NewWriter(kafka.WriterConfig{
+			Brokers:   kafkaConf.Brokers,
+			Topic:     topic,
+			BatchSize: 1,
+		})
+	}
+
+	assert.NoError(conn.CreateTopics(kafka.TopicConfig{
+		Topic:             kafkaConf.CommandTopic,
+		NumPartitions:     1,
+		ReplicationFactor: 1,
+	}))
+
+	ts.commandReader = kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   kafkaConf.Brokers,
+		Topic:     kafkaConf.CommandTopic,
+		Partition: 0,
+	})
+
+	ts.backend, err = NewBackend(conf)
+	assert.NoError(err)
+
+	ts.backend.(*Backend).setGatewayMarshaler(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, marshaler.Protobuf)
+}
+
+func (ts *BackendTestSuite) TearDownSuite() {
+	assert := require.New(ts.T())
+
+	assert.NoError(ts.backend.Close())
+	assert.NoError(ts.commandReader.Close())
+	for _, w := range ts.writers {
+		assert.NoError(w.Close())
+	}
+}
+
+func (ts *BackendTestSuite) publishEvent(typ string, msg proto.Message) {
+	assert := require.New(ts.T())
+
+	b, err := proto.Marshal(msg)
+	assert.NoError(err)
+
+	assert.NoError(ts.writers[typ].WriteMessages(context.Background(), kafka.Message{
+		Key:   []byte("0102030405060708"),
+		Value: b,
+	}))
+}
+
+func (ts *BackendTestSuite) readCommand() kafka.Message {
+	assert := require.New(ts.T())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	msg, err := ts.commandReader.ReadMessage(ctx)
+	assert.NoError(err)
+	return msg
+}
+
+func (ts *BackendTestSuite) TestUplinkFrame() {
+	assert := require.New(ts.T())
+
+	uplinkFrame := gw.UplinkFrame{
+		PhyPayload: []byte{1, 2, 3, 4},
+		TxInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+	}
+	ts.publishEvent("up", &uplinkFrame)
+
+	receivedUplink := <-ts.backend.RXPacketChan()
+	assert.True(proto.Equal(&uplinkFrame, &receivedUplink))
+}
+
+func (ts *BackendTestSuite) TestGatewayStats() {
+	assert := require.New(ts.T())
+
+	gatewayStats := gw.GatewayStats{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	ts.publishEvent("stats", &gatewayStats)
+
+	receivedStats := <-ts.backend.StatsPacketChan()
+	assert.True(proto.Equal(&gatewayStats, &receivedStats))
+}
+
+func (ts *BackendTestSuite) TestDownlinkTXAck() {
+	assert := require.New(ts.T())
+
+	downlinkTXAck := gw.DownlinkTXAck{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Token:     1234,
+	}
+	ts.publishEvent("ack", &downlinkTXAck)
+
+	receivedAck := <-ts.backend.DownlinkTXAckChan()
+	assert.True(proto.Equal(&downlinkTXAck, &receivedAck))
+}
+
+func (ts *BackendTestSuite) TestCommands() {
+	ts.T().Run("SendTXPacket", func(t *testing.T) {
+		assert := require.New(t)
+
+		downlinkFrame := gw.DownlinkFrame{
+			PhyPayload: []byte{1, 2, 3, 4},
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			},
+		}
+		assert.NoError(ts.backend.SendTXPacket(downlinkFrame))
+
+		msg := ts.readCommand()
+		assert.Equal([]byte("0102030405060708"), msg.Key)
+		assert.Equal([]kafka.Header{{Key: "command", Value: []byte("down")}}, msg.Headers)
+
+		var downlinkReceived gw.DownlinkFrame
+		assert.NoError(proto.Unmarshal(msg.Value, &downlinkReceived))
+		assert.True(proto.Equal(&downlinkFrame, &downlinkReceived))
+	})
+
+	ts.T().Run("SendGatewayConfigPacket", func(t *testing.T) {
+		assert := require.New(t)
+
+		gatewayConfig := gw.GatewayConfiguration{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			Version:   "1.2.3",
+		}
+		assert.NoError(ts.backend.SendGatewayConfigPacket(gatewayConfig))
+
+		msg := ts.readCommand()
+		assert.Equal([]byte("0102030405060708"), msg.Key)
+		assert.Equal([]kafka.Header{{Key: "command", Value: []byte("config")}}, msg.Headers)
+
+		var configReceived gw.GatewayConfiguration
+		assert.NoError(proto.Unmarshal(msg.Value, &configReceived))
+		assert.True(proto.Equal(&gatewayConfig, &configReceived))
+	})
+}
+
+func TestBackend(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
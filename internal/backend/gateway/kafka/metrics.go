@@ -0,0 +1,26 @@
+package kafka
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_kafka_event_count",
+		Help: "The number of received events by the Kafka backend (per event type).",
+	}, []string{"event"})
+
+	cc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_kafka_command_count",
+		Help: "The number of published commands by the Kafka backend (per command).",
+	}, []string{"command"})
+)
+
+func kafkaEventCounter(e string) prometheus.Counter {
+	return ec.With(prometheus.Labels{"event": e})
+}
+
+func kafkaCommandCounter(c string) prometheus.Counter {
+	return cc.With(prometheus.Labels{"command": c})
+}
@@ -0,0 +1,34 @@
+package marshaler
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+)
+
+// UnmarshalConnState unmarshals a ConnState.
+func UnmarshalConnState(b []byte, connState *gw.ConnState) (Type, error) {
+	var t Type
+
+	if strings.Contains(string(b), `"gatewayID"`) {
+		t = JSON
+	} else {
+		t = Protobuf
+	}
+
+	switch t {
+	case Protobuf:
+		return t, proto.Unmarshal(b, connState)
+	case JSON:
+		m := jsonpb.Unmarshaler{
+			AllowUnknownFields: true,
+		}
+		return t, m.Unmarshal(bytes.NewReader(b), connState)
+	}
+
+	return t, nil
+}
@@ -30,6 +30,7 @@ import (
 const uplinkLockTTL = time.Millisecond * 500
 const statsLockTTL = time.Millisecond * 500
 const ackLockTTL = time.Millisecond * 500
+const execLockTTL = time.Millisecond * 500
 const (
 	marshalerV2JSON = iota
 	marshalerProtobuf
@@ -42,9 +43,11 @@ type Backend struct {
 
 	wg sync.WaitGroup
 
-	rxPacketChan      chan gw.UplinkFrame
-	statsPacketChan   chan gw.GatewayStats
-	downlinkTXAckChan chan gw.DownlinkTXAck
+	rxPacketChan            chan gw.UplinkFrame
+	statsPacketChan         chan gw.GatewayStats
+	downlinkTXAckChan       chan gw.DownlinkTXAck
+	commandExecResponseChan chan gw.GatewayCommandExecResponse
+	connStateChan           chan gw.ConnState
 
 	conn                 paho.Client
 	redisPool            *redis.Pool
@@ -61,13 +64,15 @@ func NewBackend(redisPool *redis.Pool, c config.Config) (gateway.Gateway, error)
 	var err error
 
 	b := Backend{
-		rxPacketChan:      make(chan gw.UplinkFrame),
-		statsPacketChan:   make(chan gw.GatewayStats),
-		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
-		gatewayMarshaler:  make(map[lorawan.EUI64]marshaler.Type),
-		redisPool:         redisPool,
-		eventTopic:        conf.EventTopic,
-		qos:               conf.QOS,
+		rxPacketChan:            make(chan gw.UplinkFrame),
+		statsPacketChan:         make(chan gw.GatewayStats),
+		downlinkTXAckChan:       make(chan gw.DownlinkTXAck),
+		commandExecResponseChan: make(chan gw.GatewayCommandExecResponse),
+		connStateChan:           make(chan gw.ConnState),
+		gatewayMarshaler:        make(map[lorawan.EUI64]marshaler.Type),
+		redisPool:               redisPool,
+		eventTopic:              conf.EventTopic,
+		qos:                     conf.QOS,
 	}
 
 	b.commandTopicTemplate, err = template.New("command").Parse(conf.CommandTopicTemplate)
@@ -127,6 +132,8 @@ func (b *Backend) Close() error {
 	close(b.rxPacketChan)
 	close(b.statsPacketChan)
 	close(b.downlinkTXAckChan)
+	close(b.commandExecResponseChan)
+	close(b.connStateChan)
 	return nil
 }
 
@@ -145,6 +152,17 @@ func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
 	return b.downlinkTXAckChan
 }
 
+// GatewayCommandExecResponseChan returns the gateway command execution
+// response channel.
+func (b *Backend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.commandExecResponseChan
+}
+
+// ConnStateChan returns the gateway connection-state channel.
+func (b *Backend) ConnStateChan() chan gw.ConnState {
+	return b.connStateChan
+}
+
 // SendTXPacket sends the given downlink-frame to the gateway.
 func (b *Backend) SendTXPacket(txPacket gw.DownlinkFrame) error {
 	if txPacket.TxInfo == nil {
@@ -166,6 +184,16 @@ func (b *Backend) SendGatewayConfigPacket(configPacket gw.GatewayConfiguration)
 	return b.publishCommand(log.Fields{}, gatewayID, "config", &configPacket)
 }
 
+// SendGatewayCommandExecRequest sends the given command execution request
+// to the gateway.
+func (b *Backend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+
+	return b.publishCommand(log.Fields{
+		"exec_id": helpers.GetExecID(&pl),
+	}, gatewayID, "exec", &pl)
+}
+
 func (b *Backend) publishCommand(fields log.Fields, gatewayID lorawan.EUI64, command string, msg proto.Message) error {
 	t := b.getGatewayMarshaler(gatewayID)
 	bb, err := marshaler.MarshalCommand(t, msg)
@@ -211,6 +239,12 @@ func (b *Backend) eventHandler(c paho.Client, msg paho.Message) {
 	} else if strings.HasSuffix(msg.Topic(), "stats") {
 		mqttEventCounter("stats").Inc()
 		b.statsPacketHandler(c, msg)
+	} else if strings.HasSuffix(msg.Topic(), "exec") {
+		mqttEventCounter("exec").Inc()
+		b.execPacketHandler(c, msg)
+	} else if strings.HasSuffix(msg.Topic(), "conn") {
+		mqttEventCounter("conn").Inc()
+		b.connStateHandler(c, msg)
 	}
 }
 
@@ -357,6 +391,76 @@ func (b *Backend) ackPacketHandler(c paho.Client, msg paho.Message) {
 	b.downlinkTXAckChan <- ack
 }
 
+func (b *Backend) execPacketHandler(c paho.Client, msg paho.Message) {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	var resp gw.GatewayCommandExecResponse
+	t, err := marshaler.UnmarshalGatewayCommandExecResponse(msg.Payload(), &resp)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
+		}).WithError(err).Error("gateway/mqtt: unmarshal gateway command execution response error")
+		return
+	}
+
+	gatewayID := helpers.GetGatewayID(&resp)
+	execID := helpers.GetExecID(&resp)
+	b.setGatewayMarshaler(gatewayID, t)
+
+	// Since with MQTT all subscribers will receive the exec messages sent
+	// by all the gateways, the first instance receiving the message must lock it,
+	// so that other instances can ignore the same message (from the same gw).
+	// As an unique id, the exec id is used.
+	key := fmt.Sprintf("lora:ns:exec:lock:%s", execID)
+	redisConn := b.redisPool.Get()
+	defer redisConn.Close()
+
+	_, err = redis.String(redisConn.Do("SET", key, "lock", "PX", int64(execLockTTL/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			// the payload is already being processed by an other instance
+			return
+		}
+		log.WithError(err).Error("gateway/mqtt: acquire exec lock error")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"exec_id":    execID,
+	}).Info("gateway/mqtt: gateway command execution response received")
+	b.commandExecResponseChan <- resp
+}
+
+// connStateHandler handles the connection-state messages of the gateway.
+// The ChirpStack Gateway Bridge publishes the ONLINE state on connect and
+// configures the OFFLINE state as MQTT last-will message.
+// Unlike the other events, no lock is acquired, as setting the gateway
+// state is idempotent.
+func (b *Backend) connStateHandler(c paho.Client, msg paho.Message) {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	var connState gw.ConnState
+	t, err := marshaler.UnmarshalConnState(msg.Payload(), &connState)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
+		}).WithError(err).Error("gateway/mqtt: unmarshal gateway connection-state error")
+		return
+	}
+
+	gatewayID := helpers.GetGatewayID(&connState)
+	b.setGatewayMarshaler(gatewayID, t)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"state":      connState.State,
+	}).Info("gateway/mqtt: gateway connection-state received")
+	b.connStateChan <- connState
+}
+
 func (b *Backend) onConnected(c paho.Client) {
 	log.Info("backend/gateway: connected to mqtt server")
 
@@ -120,6 +120,45 @@ func (ts *BackendTestSuite) TestDownlinkTXAck() {
 	}
 }
 
+func (ts *BackendTestSuite) TestGatewayCommandExecResponse() {
+	assert := require.New(ts.T())
+
+	resp := gw.GatewayCommandExecResponse{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		ExecId:    []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		Stdout:    []byte("hello"),
+	}
+	b, err := proto.Marshal(&resp)
+	assert.NoError(err)
+
+	token := ts.mqttClient.Publish("gateway/0102030405060708/event/exec", 0, false, b)
+	token.Wait()
+	assert.NoError(token.Error())
+
+	receivedResp := <-ts.backend.GatewayCommandExecResponseChan()
+	if !proto.Equal(&resp, &receivedResp) {
+		assert.Equal(resp, receivedResp)
+	}
+}
+
+func (ts *BackendTestSuite) TestConnState() {
+	assert := require.New(ts.T())
+
+	connState := gw.ConnState{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		State:     gw.ConnState_ONLINE,
+	}
+	b, err := proto.Marshal(&connState)
+	assert.NoError(err)
+
+	token := ts.mqttClient.Publish("gateway/0102030405060708/event/conn", 0, false, b)
+	token.Wait()
+	assert.NoError(token.Error())
+
+	receivedConnState := <-ts.backend.ConnStateChan()
+	assert.True(proto.Equal(&connState, &receivedConnState))
+}
+
 func (ts *BackendTestSuite) TestSendDownlinkFrame() {
 	assert := require.New(ts.T())
 
@@ -174,6 +213,31 @@ func (ts *BackendTestSuite) TestSendGatewayConfiguration() {
 	assert.Equal(gatewayConfig, configReceived)
 }
 
+func (ts *BackendTestSuite) TestSendGatewayCommandExecRequest() {
+	assert := require.New(ts.T())
+
+	execRequestChan := make(chan gw.GatewayCommandExecRequest)
+	token := ts.mqttClient.Subscribe("gateway/+/command/exec", 0, func(c paho.Client, msg paho.Message) {
+		var pl gw.GatewayCommandExecRequest
+		if err := proto.Unmarshal(msg.Payload(), &pl); err != nil {
+			panic(err)
+		}
+		execRequestChan <- pl
+	})
+	token.Wait()
+	assert.NoError(token.Error())
+
+	execRequest := gw.GatewayCommandExecRequest{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		ExecId:    []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		Command:   "reboot",
+	}
+	assert.NoError(ts.backend.SendGatewayCommandExecRequest(execRequest))
+
+	execRequestReceived := <-execRequestChan
+	assert.True(proto.Equal(&execRequest, &execRequestReceived))
+}
+
 func TestBackend(t *testing.T) {
 	suite.Run(t, new(BackendTestSuite))
 }
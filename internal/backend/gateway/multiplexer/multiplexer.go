@@ -0,0 +1,296 @@
+// Package multiplexer implements a gateway backend which combines multiple
+// gateway backends.
+package multiplexer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// gatewayBackendKeyTempl defines the key used for storing the name of the
+// backend on which the gateway was last seen.
+const gatewayBackendKeyTempl = "lora:ns:gw:%s:backend"
+
+// gatewayBackendTTL defines the TTL of the last-seen gateway backend.
+const gatewayBackendTTL = 24 * time.Hour
+
+// ErrUnknownGatewayBackend is returned when it is unknown to which backend
+// a command must be sent (the gateway has not been seen and is not pinned
+// to a backend).
+var ErrUnknownGatewayBackend = errors.New("unknown gateway backend")
+
+// Backend implements a multiplexer backend.
+type Backend struct {
+	wg sync.WaitGroup
+
+	redisPool *redis.Pool
+	db        sqlx.Queryer
+	backends  map[string]gateway.Gateway
+
+	uplinkFrameChan         chan gw.UplinkFrame
+	gatewayStatsChan        chan gw.GatewayStats
+	downlinkTXAckChan       chan gw.DownlinkTXAck
+	commandExecResponseChan chan gw.GatewayCommandExecResponse
+	connStateChan           chan gw.ConnState
+}
+
+// NewBackend creates a new Backend, combining the given backends. The map
+// key must contain the backend name (e.g. mqtt), this is the name which is
+// used when pinning a gateway to a backend.
+func NewBackend(redisPool *redis.Pool, db sqlx.Queryer, backends map[string]gateway.Gateway) (gateway.Gateway, error) {
+	if len(backends) == 0 {
+		return nil, errors.New("at least one backend must be given")
+	}
+
+	b := Backend{
+		redisPool:               redisPool,
+		db:                      db,
+		backends:                backends,
+		uplinkFrameChan:         make(chan gw.UplinkFrame),
+		gatewayStatsChan:        make(chan gw.GatewayStats),
+		downlinkTXAckChan:       make(chan gw.DownlinkTXAck),
+		commandExecResponseChan: make(chan gw.GatewayCommandExecResponse),
+		connStateChan:           make(chan gw.ConnState),
+	}
+
+	for name, backend := range backends {
+		log.WithField("backend", name).Info("gateway/multiplexer: adding gateway backend")
+
+		b.wg.Add(5)
+		go b.forwardUplinkFrames(name, backend)
+		go b.forwardGatewayStats(name, backend)
+		go b.forwardDownlinkTXAcks(name, backend)
+		go b.forwardGatewayCommandExecResponses(backend)
+		go b.forwardConnStates(name, backend)
+	}
+
+	return &b, nil
+}
+
+// SendTXPacket sends the given downlink frame to the gateway, using the
+// backend the gateway is pinned to or was last seen on.
+func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if pl.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(pl.TxInfo)
+	name, backend, err := b.getBackend(gatewayID)
+	if err != nil {
+		return errors.Wrap(err, "gateway/multiplexer: get backend error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id":  gatewayID,
+		"downlink_id": helpers.GetDownlinkID(&pl),
+		"backend":     name,
+	}).Debug("gateway/multiplexer: sending downlink frame")
+
+	return backend.SendTXPacket(pl)
+}
+
+// SendGatewayConfigPacket sends the given gateway configuration to the
+// gateway, using the backend the gateway is pinned to or was last seen on.
+func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+	name, backend, err := b.getBackend(gatewayID)
+	if err != nil {
+		return errors.Wrap(err, "gateway/multiplexer: get backend error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"backend":    name,
+	}).Debug("gateway/multiplexer: sending gateway configuration")
+
+	return backend.SendGatewayConfigPacket(pl)
+}
+
+// SendGatewayCommandExecRequest sends the given command execution request to
+// the gateway, using the backend the gateway is pinned to or was last seen
+// on.
+func (b *Backend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+	name, backend, err := b.getBackend(gatewayID)
+	if err != nil {
+		return errors.Wrap(err, "gateway/multiplexer: get backend error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"exec_id":    helpers.GetExecID(&pl),
+		"backend":    name,
+	}).Debug("gateway/multiplexer: sending gateway command execution request")
+
+	return backend.SendGatewayCommandExecRequest(pl)
+}
+
+// RXPacketChan returns the channel containing the uplink frames of all
+// backends.
+func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+// StatsPacketChan returns the channel containing the gateway stats of all
+// backends.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+// DownlinkTXAckChan returns the channel containing the downlink tx acks of
+// all backends.
+func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+// GatewayCommandExecResponseChan returns the channel containing the gateway
+// command execution responses of all backends.
+func (b *Backend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.commandExecResponseChan
+}
+
+// ConnStateChan returns the channel containing the gateway connection-states
+// of all backends.
+func (b *Backend) ConnStateChan() chan gw.ConnState {
+	return b.connStateChan
+}
+
+// Close closes all the backends.
+func (b *Backend) Close() error {
+	log.Info("gateway/multiplexer: closing backend")
+
+	for name, backend := range b.backends {
+		if err := backend.Close(); err != nil {
+			return errors.Wrapf(err, "gateway/multiplexer: close %s backend error", name)
+		}
+	}
+
+	log.Info("gateway/multiplexer: handling last messages")
+	b.wg.Wait()
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+	close(b.commandExecResponseChan)
+	close(b.connStateChan)
+	return nil
+}
+
+func (b *Backend) forwardUplinkFrames(name string, backend gateway.Gateway) {
+	defer b.wg.Done()
+
+	for uplinkFrame := range backend.RXPacketChan() {
+		b.setLastSeenBackend(helpers.GetGatewayID(uplinkFrame.RxInfo), name)
+		b.uplinkFrameChan <- uplinkFrame
+	}
+}
+
+func (b *Backend) forwardGatewayStats(name string, backend gateway.Gateway) {
+	defer b.wg.Done()
+
+	for gatewayStats := range backend.StatsPacketChan() {
+		b.setLastSeenBackend(helpers.GetGatewayID(&gatewayStats), name)
+		b.gatewayStatsChan <- gatewayStats
+	}
+}
+
+func (b *Backend) forwardDownlinkTXAcks(name string, backend gateway.Gateway) {
+	defer b.wg.Done()
+
+	for downlinkTXAck := range backend.DownlinkTXAckChan() {
+		b.downlinkTXAckChan <- downlinkTXAck
+	}
+}
+
+func (b *Backend) forwardGatewayCommandExecResponses(backend gateway.Gateway) {
+	defer b.wg.Done()
+
+	for resp := range backend.GatewayCommandExecResponseChan() {
+		b.commandExecResponseChan <- resp
+	}
+}
+
+func (b *Backend) forwardConnStates(name string, backend gateway.Gateway) {
+	defer b.wg.Done()
+
+	for connState := range backend.ConnStateChan() {
+		b.setLastSeenBackend(helpers.GetGatewayID(&connState), name)
+		b.connStateChan <- connState
+	}
+}
+
+// getBackend returns the backend for the given gateway ID. When the gateway
+// is pinned to a backend, this backend is returned. Else the backend on
+// which the gateway was last seen is returned.
+func (b *Backend) getBackend(gatewayID lorawan.EUI64) (string, gateway.Gateway, error) {
+	name, err := b.getPinnedBackend(gatewayID)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "get pinned backend error")
+	}
+
+	if name == "" {
+		name, err = b.getLastSeenBackend(gatewayID)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "get last-seen backend error")
+		}
+	}
+
+	backend, ok := b.backends[name]
+	if !ok {
+		return "", nil, fmt.Errorf("backend '%s' is not configured", name)
+	}
+
+	return name, backend, nil
+}
+
+func (b *Backend) getPinnedBackend(gatewayID lorawan.EUI64) (string, error) {
+	gw, err := storage.GetAndCacheGateway(context.Background(), b.db, b.redisPool, gatewayID)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return gw.Backend, nil
+}
+
+func (b *Backend) getLastSeenBackend(gatewayID lorawan.EUI64) (string, error) {
+	c := b.redisPool.Get()
+	defer c.Close()
+
+	name, err := redis.String(c.Do("GET", fmt.Sprintf(gatewayBackendKeyTempl, gatewayID)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return "", ErrUnknownGatewayBackend
+		}
+		return "", errors.Wrap(err, "get error")
+	}
+
+	return name, nil
+}
+
+func (b *Backend) setLastSeenBackend(gatewayID lorawan.EUI64, name string) {
+	c := b.redisPool.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(gatewayBackendKeyTempl, gatewayID), int64(gatewayBackendTTL/time.Millisecond), name)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"backend":    name,
+		}).Error("gateway/multiplexer: set last-seen backend error")
+	}
+}
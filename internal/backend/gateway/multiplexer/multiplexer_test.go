@@ -0,0 +1,145 @@
+package multiplexer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+type BackendTestSuite struct {
+	suite.Suite
+
+	backend  gateway.Gateway
+	backendA *test.GatewayBackend
+	backendB *test.GatewayBackend
+}
+
+func (ts *BackendTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustResetDB(storage.DB().DB)
+}
+
+func (ts *BackendTestSuite) SetupTest() {
+	assert := require.New(ts.T())
+
+	test.MustFlushRedis(storage.RedisPool())
+
+	ts.backendA = test.NewGatewayBackend()
+	ts.backendB = test.NewGatewayBackend()
+
+	var err error
+	ts.backend, err = NewBackend(storage.RedisPool(), storage.DB(), map[string]gateway.Gateway{
+		"a": ts.backendA,
+		"b": ts.backendB,
+	})
+	assert.NoError(err)
+}
+
+func (ts *BackendTestSuite) TearDownTest() {
+	assert := require.New(ts.T())
+	assert.NoError(ts.backend.Close())
+}
+
+func (ts *BackendTestSuite) TestForwardEvents() {
+	assert := require.New(ts.T())
+
+	ts.backendA.RXPacketChan() <- gw.UplinkFrame{
+		PhyPayload: []byte{1, 2, 3},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+	}
+	uplinkFrame := <-ts.backend.RXPacketChan()
+	assert.Equal([]byte{1, 2, 3}, uplinkFrame.PhyPayload)
+
+	ts.backendB.StatsPacketChan() <- gw.GatewayStats{
+		GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+	}
+	gatewayStats := <-ts.backend.StatsPacketChan()
+	assert.Equal([]byte{8, 7, 6, 5, 4, 3, 2, 1}, gatewayStats.GatewayId)
+
+	ts.backendA.DownlinkTXAckChan() <- gw.DownlinkTXAck{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Token:     123,
+	}
+	ack := <-ts.backend.DownlinkTXAckChan()
+	assert.Equal(uint32(123), ack.Token)
+}
+
+func (ts *BackendTestSuite) TestSendCommands() {
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	ts.T().Run("Unknown gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		err := ts.backend.SendTXPacket(gw.DownlinkFrame{
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: gatewayID[:],
+			},
+		})
+		assert.Equal(ErrUnknownGatewayBackend, errors.Cause(err))
+	})
+
+	ts.T().Run("Last seen", func(t *testing.T) {
+		assert := require.New(t)
+
+		ts.backendB.StatsPacketChan() <- gw.GatewayStats{
+			GatewayId: gatewayID[:],
+		}
+		<-ts.backend.StatsPacketChan()
+
+		assert.NoError(ts.backend.SendTXPacket(gw.DownlinkFrame{
+			PhyPayload: []byte{1, 2, 3},
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: gatewayID[:],
+			},
+		}))
+		downlinkFrame := <-ts.backendB.TXPacketChan
+		assert.Equal([]byte{1, 2, 3}, downlinkFrame.PhyPayload)
+
+		assert.NoError(ts.backend.SendGatewayConfigPacket(gw.GatewayConfiguration{
+			GatewayId: gatewayID[:],
+			Version:   "1.2.3",
+		}))
+		gatewayConfig := <-ts.backendB.GatewayConfigPacketChan
+		assert.Equal("1.2.3", gatewayConfig.Version)
+	})
+
+	ts.T().Run("Pinned", func(t *testing.T) {
+		assert := require.New(t)
+
+		rp := storage.RoutingProfile{}
+		assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+		assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &storage.Gateway{
+			GatewayID:        gatewayID,
+			RoutingProfileID: rp.ID,
+			Backend:          "a",
+		}))
+
+		assert.NoError(ts.backend.SendTXPacket(gw.DownlinkFrame{
+			PhyPayload: []byte{3, 2, 1},
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: gatewayID[:],
+			},
+		}))
+		downlinkFrame := <-ts.backendA.TXPacketChan
+		assert.Equal([]byte{3, 2, 1}, downlinkFrame.PhyPayload)
+	})
+}
+
+func TestBackend(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
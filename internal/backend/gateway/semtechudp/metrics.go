@@ -0,0 +1,26 @@
+package semtechudp
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ucr = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_semtech_udp_udp_read_count",
+		Help: "The number of UDP packets received by the Semtech UDP backend (per packet type).",
+	}, []string{"packet_type"})
+
+	ucw = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_semtech_udp_udp_write_count",
+		Help: "The number of UDP packets sent by the Semtech UDP backend (per packet type).",
+	}, []string{"packet_type"})
+)
+
+func udpReadCounter(pt string) prometheus.Counter {
+	return ucr.With(prometheus.Labels{"packet_type": pt})
+}
+
+func udpWriteCounter(pt string) prometheus.Counter {
+	return ucw.With(prometheus.Labels{"packet_type": pt})
+}
@@ -0,0 +1,679 @@
+package semtechudp
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/chirpstack-network-server/api/common"
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/lorawan"
+)
+
+// PacketType defines the packet type.
+type PacketType byte
+
+// Available packet types
+const (
+	PushData PacketType = iota
+	PushACK
+	PullData
+	PullResp
+	PullACK
+	TXACK
+)
+
+// Protocol versions.
+const (
+	ProtocolVersion1 uint8 = 0x01
+	ProtocolVersion2 uint8 = 0x02
+)
+
+// Errors
+var (
+	ErrInvalidProtocolVersion = errors.New("semtechudp: invalid protocol version")
+	ErrInvalidPacketType      = errors.New("semtechudp: invalid packet type")
+	ErrPacketTooShort         = errors.New("semtechudp: packet is too short")
+)
+
+func (p PacketType) String() string {
+	switch p {
+	case PushData:
+		return "PUSH_DATA"
+	case PushACK:
+		return "PUSH_ACK"
+	case PullData:
+		return "PULL_DATA"
+	case PullResp:
+		return "PULL_RESP"
+	case PullACK:
+		return "PULL_ACK"
+	case TXACK:
+		return "TX_ACK"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", p)
+	}
+}
+
+// GetPacketType returns the packet type for the given packet data.
+func GetPacketType(data []byte) (PacketType, error) {
+	if len(data) < 4 {
+		return PacketType(0), ErrPacketTooShort
+	}
+
+	if data[0] != ProtocolVersion1 && data[0] != ProtocolVersion2 {
+		return PacketType(0), ErrInvalidProtocolVersion
+	}
+
+	if data[3] > byte(TXACK) {
+		return PacketType(0), ErrInvalidPacketType
+	}
+
+	return PacketType(data[3]), nil
+}
+
+// PushDataPacket type is used by the gateway mainly to forward the RF packets
+// received, and associated metadata, to the server.
+type PushDataPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+	GatewayMAC      lorawan.EUI64
+	Payload         PushDataPayload
+}
+
+// MarshalBinary marshals the object in binary form.
+func (p PushDataPacket) MarshalBinary() ([]byte, error) {
+	pb, err := json.Marshal(&p.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 4, len(pb)+12)
+	out[0] = p.ProtocolVersion
+	binary.LittleEndian.PutUint16(out[1:3], p.RandomToken)
+	out[3] = byte(PushData)
+	out = append(out, p.GatewayMAC[:]...)
+	out = append(out, pb...)
+	return out, nil
+}
+
+// UnmarshalBinary decodes the object from binary form.
+func (p *PushDataPacket) UnmarshalBinary(data []byte) error {
+	if len(data) < 13 {
+		return ErrPacketTooShort
+	}
+	if data[3] != byte(PushData) {
+		return ErrInvalidPacketType
+	}
+	if data[0] != ProtocolVersion1 && data[0] != ProtocolVersion2 {
+		return ErrInvalidProtocolVersion
+	}
+
+	p.ProtocolVersion = data[0]
+	p.RandomToken = binary.LittleEndian.Uint16(data[1:3])
+	copy(p.GatewayMAC[:], data[4:12])
+
+	return json.Unmarshal(data[12:], &p.Payload)
+}
+
+// GetGatewayStats returns the GatewayStats object (if the packet contains
+// stats).
+func (p PushDataPacket) GetGatewayStats() (*gw.GatewayStats, error) {
+	if p.Payload.Stat == nil {
+		return nil, nil
+	}
+
+	statsID, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.Wrap(err, "new uuid error")
+	}
+
+	stats := gw.GatewayStats{
+		GatewayId:           p.GatewayMAC[:],
+		StatsId:             statsID[:],
+		RxPacketsReceived:   p.Payload.Stat.RXNb,
+		RxPacketsReceivedOk: p.Payload.Stat.RXOK,
+		TxPacketsReceived:   p.Payload.Stat.DWNb,
+		TxPacketsEmitted:    p.Payload.Stat.TXNb,
+	}
+
+	if p.Payload.Stat.Lati != 0 || p.Payload.Stat.Long != 0 || p.Payload.Stat.Alti != 0 {
+		stats.Location = &common.Location{
+			Latitude:  p.Payload.Stat.Lati,
+			Longitude: p.Payload.Stat.Long,
+			Altitude:  float64(p.Payload.Stat.Alti),
+			Source:    common.LocationSource_GPS,
+		}
+	}
+
+	ts := time.Time(p.Payload.Stat.Time)
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	stats.Time, err = ptypes.TimestampProto(ts)
+	if err != nil {
+		return nil, errors.Wrap(err, "timestamp proto error")
+	}
+
+	return &stats, nil
+}
+
+// GetUplinkFrames returns a slice of gw.UplinkFrame. When skipCRCCheck is
+// false, frames with an invalid or missing CRC are ignored.
+func (p PushDataPacket) GetUplinkFrames(skipCRCCheck bool) ([]gw.UplinkFrame, error) {
+	var frames []gw.UplinkFrame
+
+	for i := range p.Payload.RXPK {
+		if p.Payload.RXPK[i].Stat != 1 && !skipCRCCheck {
+			continue
+		}
+
+		frame, err := getUplinkFrame(p.GatewayMAC, p.Payload.RXPK[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "get uplink frame error")
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames, nil
+}
+
+func getUplinkFrame(gatewayID lorawan.EUI64, rxpk RXPK) (gw.UplinkFrame, error) {
+	uplinkID, err := uuid.NewV4()
+	if err != nil {
+		return gw.UplinkFrame{}, errors.Wrap(err, "new uuid error")
+	}
+
+	frame := gw.UplinkFrame{
+		PhyPayload: rxpk.Data,
+		TxInfo: &gw.UplinkTXInfo{
+			Frequency: uint32(math.Round(rxpk.Freq * 1000000)),
+		},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: gatewayID[:],
+			Rssi:      int32(rxpk.RSSI),
+			LoraSnr:   rxpk.LSNR,
+			Channel:   uint32(rxpk.Chan),
+			RfChain:   uint32(rxpk.RFCh),
+			Board:     uint32(rxpk.Brd),
+			Context:   make([]byte, 4),
+			UplinkId:  uplinkID[:],
+		},
+	}
+
+	binary.BigEndian.PutUint32(frame.RxInfo.Context, rxpk.Tmst)
+
+	switch rxpk.Modu {
+	case "LORA":
+		if rxpk.DatR.LoRa == nil {
+			return frame, errors.New("lora data-rate must not be nil")
+		}
+
+		frame.TxInfo.Modulation = common.Modulation_LORA
+		frame.TxInfo.ModulationInfo = &gw.UplinkTXInfo_LoraModulationInfo{
+			LoraModulationInfo: &gw.LoRaModulationInfo{
+				SpreadingFactor: uint32(rxpk.DatR.LoRa.SpreadFactor),
+				Bandwidth:       uint32(rxpk.DatR.LoRa.Bandwidth),
+				CodeRate:        rxpk.CodR,
+			},
+		}
+	case "FSK":
+		frame.TxInfo.Modulation = common.Modulation_FSK
+		frame.TxInfo.ModulationInfo = &gw.UplinkTXInfo_FskModulationInfo{
+			FskModulationInfo: &gw.FSKModulationInfo{
+				Bitrate: rxpk.DatR.FSK,
+			},
+		}
+	default:
+		return frame, fmt.Errorf("unknown modulation: %s", rxpk.Modu)
+	}
+
+	if rxpk.Time != nil {
+		ts := time.Time(*rxpk.Time)
+		if !ts.IsZero() {
+			frame.RxInfo.Time, err = ptypes.TimestampProto(ts)
+			if err != nil {
+				return frame, errors.Wrap(err, "timestamp proto error")
+			}
+		}
+	}
+
+	if rxpk.Tmms != nil {
+		frame.RxInfo.TimeSinceGpsEpoch = ptypes.DurationProto(time.Duration(*rxpk.Tmms) * time.Millisecond)
+	}
+
+	return frame, nil
+}
+
+// PushDataPayload represents the JSON payload of a PushDataPacket.
+type PushDataPayload struct {
+	RXPK []RXPK `json:"rxpk,omitempty"`
+	Stat *Stat  `json:"stat,omitempty"`
+}
+
+// PushACKPacket is used by the server to acknowledge immediately all the
+// PUSH_DATA packets received.
+type PushACKPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+}
+
+// MarshalBinary marshals the object in binary form.
+func (p PushACKPacket) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 4)
+	out[0] = p.ProtocolVersion
+	binary.LittleEndian.PutUint16(out[1:3], p.RandomToken)
+	out[3] = byte(PushACK)
+	return out, nil
+}
+
+// PullDataPacket is used by the gateway to poll data from the server.
+type PullDataPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+	GatewayMAC      lorawan.EUI64
+}
+
+// MarshalBinary marshals the object in binary form.
+func (p PullDataPacket) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 4, 12)
+	out[0] = p.ProtocolVersion
+	binary.LittleEndian.PutUint16(out[1:3], p.RandomToken)
+	out[3] = byte(PullData)
+	out = append(out, p.GatewayMAC[:]...)
+	return out, nil
+}
+
+// UnmarshalBinary decodes the object from binary form.
+func (p *PullDataPacket) UnmarshalBinary(data []byte) error {
+	if len(data) != 12 {
+		return errors.New("semtechudp: 12 bytes of data are expected")
+	}
+	if data[3] != byte(PullData) {
+		return ErrInvalidPacketType
+	}
+	if data[0] != ProtocolVersion1 && data[0] != ProtocolVersion2 {
+		return ErrInvalidProtocolVersion
+	}
+
+	p.ProtocolVersion = data[0]
+	p.RandomToken = binary.LittleEndian.Uint16(data[1:3])
+	copy(p.GatewayMAC[:], data[4:12])
+	return nil
+}
+
+// PullACKPacket is used by the server to confirm that the network route is
+// open and that the server can send PULL_RESP packets at any time.
+type PullACKPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+}
+
+// MarshalBinary marshals the object in binary form.
+func (p PullACKPacket) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 4)
+	out[0] = p.ProtocolVersion
+	binary.LittleEndian.PutUint16(out[1:3], p.RandomToken)
+	out[3] = byte(PullACK)
+	return out, nil
+}
+
+// PullRespPacket is used by the server to send RF packets and associated
+// metadata that will have to be emitted by the gateway.
+type PullRespPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+	Payload         PullRespPayload
+}
+
+// MarshalBinary marshals the object in binary form.
+func (p PullRespPacket) MarshalBinary() ([]byte, error) {
+	pb, err := json.Marshal(&p.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 4, 4+len(pb))
+	out[0] = p.ProtocolVersion
+
+	// the random token is only used by protocol version 2
+	if p.ProtocolVersion != ProtocolVersion1 {
+		binary.LittleEndian.PutUint16(out[1:3], p.RandomToken)
+	}
+	out[3] = byte(PullResp)
+	out = append(out, pb...)
+	return out, nil
+}
+
+// UnmarshalBinary decodes the object from binary form.
+func (p *PullRespPacket) UnmarshalBinary(data []byte) error {
+	if len(data) < 5 {
+		return ErrPacketTooShort
+	}
+	if data[3] != byte(PullResp) {
+		return ErrInvalidPacketType
+	}
+	if data[0] != ProtocolVersion1 && data[0] != ProtocolVersion2 {
+		return ErrInvalidProtocolVersion
+	}
+
+	p.ProtocolVersion = data[0]
+	p.RandomToken = binary.LittleEndian.Uint16(data[1:3])
+	return json.Unmarshal(data[4:], &p.Payload)
+}
+
+// GetPullRespPacket returns a PullRespPacket for the given gw.DownlinkFrame.
+func GetPullRespPacket(protoVersion uint8, randomToken uint16, frame gw.DownlinkFrame) (PullRespPacket, error) {
+	if frame.TxInfo == nil {
+		return PullRespPacket{}, errors.New("tx_info must not be nil")
+	}
+
+	packet := PullRespPacket{
+		ProtocolVersion: protoVersion,
+		RandomToken:     randomToken,
+		Payload: PullRespPayload{
+			TXPK: TXPK{
+				Freq: float64(frame.TxInfo.Frequency) / 1000000,
+				Powe: uint8(frame.TxInfo.Power),
+				Size: uint16(len(frame.PhyPayload)),
+				Data: frame.PhyPayload,
+				Brd:  uint32(frame.TxInfo.Board),
+				Ant:  uint32(frame.TxInfo.Antenna),
+			},
+		},
+	}
+
+	switch frame.TxInfo.Modulation {
+	case common.Modulation_LORA:
+		modInfo := frame.TxInfo.GetLoraModulationInfo()
+		if modInfo == nil {
+			return packet, errors.New("lora_modulation_info must not be nil")
+		}
+
+		packet.Payload.TXPK.Modu = "LORA"
+		packet.Payload.TXPK.DatR.LoRa = &DatRLoRa{
+			SpreadFactor: int(modInfo.SpreadingFactor),
+			Bandwidth:    int(modInfo.Bandwidth),
+		}
+		packet.Payload.TXPK.CodR = modInfo.CodeRate
+		packet.Payload.TXPK.IPol = modInfo.PolarizationInversion
+	case common.Modulation_FSK:
+		modInfo := frame.TxInfo.GetFskModulationInfo()
+		if modInfo == nil {
+			return packet, errors.New("fsk_modulation_info must not be nil")
+		}
+
+		packet.Payload.TXPK.Modu = "FSK"
+		packet.Payload.TXPK.DatR.FSK = modInfo.Bitrate
+		packet.Payload.TXPK.FDev = uint16(modInfo.Bitrate / 2)
+	default:
+		return packet, fmt.Errorf("unexpected modulation: %s", frame.TxInfo.Modulation)
+	}
+
+	switch frame.TxInfo.Timing {
+	case gw.DownlinkTiming_IMMEDIATELY:
+		packet.Payload.TXPK.Imme = true
+	case gw.DownlinkTiming_DELAY:
+		timingInfo := frame.TxInfo.GetDelayTimingInfo()
+		if timingInfo == nil {
+			return packet, errors.New("delay_timing_info must not be nil")
+		}
+
+		if len(frame.TxInfo.Context) != 4 {
+			return packet, fmt.Errorf("context must be exactly 4 bytes, got: %d", len(frame.TxInfo.Context))
+		}
+
+		delay, err := ptypes.Duration(timingInfo.Delay)
+		if err != nil {
+			return packet, errors.Wrap(err, "get delay duration error")
+		}
+
+		tmst := binary.BigEndian.Uint32(frame.TxInfo.Context) + uint32(delay/time.Microsecond)
+		packet.Payload.TXPK.Tmst = &tmst
+	case gw.DownlinkTiming_GPS_EPOCH:
+		timingInfo := frame.TxInfo.GetGpsEpochTimingInfo()
+		if timingInfo == nil {
+			return packet, errors.New("gps_epoch_timing_info must not be nil")
+		}
+
+		timeSinceGPSEpoch, err := ptypes.Duration(timingInfo.TimeSinceGpsEpoch)
+		if err != nil {
+			return packet, errors.Wrap(err, "get time since gps epoch error")
+		}
+
+		tmms := int64(timeSinceGPSEpoch / time.Millisecond)
+		packet.Payload.TXPK.Tmms = &tmms
+	default:
+		return packet, fmt.Errorf("unexpected downlink timing: %s", frame.TxInfo.Timing)
+	}
+
+	return packet, nil
+}
+
+// PullRespPayload represents the downstream JSON data structure.
+type PullRespPayload struct {
+	TXPK TXPK `json:"txpk"`
+}
+
+// TXACKPacket is used by the gateway to send a feedback to the server to
+// inform if a downlink request has been accepted or rejected by the gateway.
+type TXACKPacket struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+	GatewayMAC      lorawan.EUI64
+	Payload         *TXACKPayload
+}
+
+// MarshalBinary marshals the object into binary form.
+func (p TXACKPacket) MarshalBinary() ([]byte, error) {
+	var pb []byte
+	if p.Payload != nil {
+		var err error
+		pb, err = json.Marshal(p.Payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]byte, 4, len(pb)+12)
+	out[0] = p.ProtocolVersion
+	binary.LittleEndian.PutUint16(out[1:3], p.RandomToken)
+	out[3] = byte(TXACK)
+	out = append(out, p.GatewayMAC[:]...)
+	out = append(out, pb...)
+	return out, nil
+}
+
+// UnmarshalBinary decodes the object from binary form.
+func (p *TXACKPacket) UnmarshalBinary(data []byte) error {
+	if len(data) < 12 {
+		return ErrPacketTooShort
+	}
+	if data[3] != byte(TXACK) {
+		return ErrInvalidPacketType
+	}
+	if data[0] != ProtocolVersion2 {
+		return ErrInvalidProtocolVersion
+	}
+
+	p.ProtocolVersion = data[0]
+	p.RandomToken = binary.LittleEndian.Uint16(data[1:3])
+	copy(p.GatewayMAC[:], data[4:12])
+
+	// the JSON payload is optional, skip any null bytes
+	pb := strings.TrimRight(string(data[12:]), "\x00")
+	if len(pb) > 0 {
+		p.Payload = &TXACKPayload{}
+		return json.Unmarshal([]byte(pb), p.Payload)
+	}
+	return nil
+}
+
+// GetDownlinkTXAck returns the gw.DownlinkTXAck object.
+func (p TXACKPacket) GetDownlinkTXAck() gw.DownlinkTXAck {
+	ack := gw.DownlinkTXAck{
+		GatewayId: p.GatewayMAC[:],
+		Token:     uint32(p.RandomToken),
+	}
+
+	if p.Payload != nil && p.Payload.TXPKACK.Error != "NONE" {
+		ack.Error = p.Payload.TXPKACK.Error
+	}
+
+	return ack
+}
+
+// TXACKPayload contains the TXACKPacket payload.
+type TXACKPayload struct {
+	TXPKACK TXPKACK `json:"txpk_ack"`
+}
+
+// TXPKACK contains the status information of the associated PULL_RESP
+// packet.
+type TXPKACK struct {
+	Error string `json:"error"`
+}
+
+// CompactTime implements the JSON marshaling of the rxpk time (ISO 8601
+// 'compact' format).
+type CompactTime time.Time
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t CompactTime) MarshalJSON() ([]byte, error) {
+	return []byte(time.Time(t).UTC().Format(`"` + time.RFC3339Nano + `"`)), nil
+}
+
+// UnmarshalJSON decodes the json string into a CompactTime.
+func (t *CompactTime) UnmarshalJSON(data []byte) error {
+	t2, err := time.Parse(`"`+time.RFC3339Nano+`"`, string(data))
+	if err != nil {
+		return err
+	}
+	*t = CompactTime(t2)
+	return nil
+}
+
+// ExpandedTime implements the JSON marshaling of the stat time (ISO 8601
+// 'expanded' format).
+type ExpandedTime time.Time
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t ExpandedTime) MarshalJSON() ([]byte, error) {
+	return []byte(time.Time(t).UTC().Format(`"2006-01-02 15:04:05 MST"`)), nil
+}
+
+// UnmarshalJSON decodes the json string into an ExpandedTime.
+func (t *ExpandedTime) UnmarshalJSON(data []byte) error {
+	t2, err := time.Parse(`"2006-01-02 15:04:05 MST"`, string(data))
+	if err != nil {
+		return err
+	}
+	*t = ExpandedTime(t2)
+	return nil
+}
+
+// DatRLoRa contains the LoRa data-rate.
+type DatRLoRa struct {
+	SpreadFactor int
+	Bandwidth    int
+}
+
+// DatR implements the data-rate which can be either a string (LoRa
+// identifier) or an unsigned integer (FSK bitrate).
+type DatR struct {
+	LoRa *DatRLoRa
+	FSK  uint32
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d DatR) MarshalJSON() ([]byte, error) {
+	if d.LoRa != nil {
+		return []byte(fmt.Sprintf(`"SF%dBW%d"`, d.LoRa.SpreadFactor, d.LoRa.Bandwidth)), nil
+	}
+	return []byte(strconv.FormatUint(uint64(d.FSK), 10)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *DatR) UnmarshalJSON(data []byte) error {
+	i, err := strconv.ParseUint(string(data), 10, 32)
+	if err == nil {
+		d.FSK = uint32(i)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	var lora DatRLoRa
+	if _, err := fmt.Sscanf(s, "SF%dBW%d", &lora.SpreadFactor, &lora.Bandwidth); err != nil {
+		return errors.Wrap(err, "parse lora data-rate error")
+	}
+	d.LoRa = &lora
+
+	return nil
+}
+
+// RXPK contains a RF packet and associated metadata.
+type RXPK struct {
+	Time *CompactTime `json:"time,omitempty"` // UTC time of pkt RX, us precision, ISO 8601 'compact' format (e.g. 2013-03-31T16:21:17.528002Z)
+	Tmms *int64       `json:"tmms,omitempty"` // GPS time of pkt RX, number of milliseconds since 06.Jan.1980
+	Tmst uint32       `json:"tmst"`           // Internal timestamp of "RX finished" event (32b unsigned)
+	Freq float64      `json:"freq"`           // RX central frequency in MHz (unsigned float, Hz precision)
+	Brd  uint32       `json:"brd"`            // Concentrator board used for RX (unsigned integer)
+	Chan uint8        `json:"chan"`           // Concentrator "IF" channel used for RX (unsigned integer)
+	RFCh uint8        `json:"rfch"`           // Concentrator "RF chain" used for RX (unsigned integer)
+	Stat int8         `json:"stat"`           // CRC status: 1 = OK, -1 = fail, 0 = no CRC
+	Modu string       `json:"modu"`           // Modulation identifier "LORA" or "FSK"
+	DatR DatR         `json:"datr"`           // LoRa datarate identifier (eg. SF12BW500) || FSK datarate (unsigned, in bits per second)
+	CodR string       `json:"codr"`           // LoRa ECC coding rate identifier
+	RSSI int16        `json:"rssi"`           // RSSI in dBm (signed integer, 1 dB precision)
+	LSNR float64      `json:"lsnr"`           // Lora SNR ratio in dB (signed float, 0.1 dB precision)
+	Size uint16       `json:"size"`           // RF packet payload size in bytes (unsigned integer)
+	Data []byte       `json:"data"`           // Base64 encoded RF packet payload, padded
+}
+
+// Stat contains the status of the gateway.
+type Stat struct {
+	Time ExpandedTime `json:"time"` // UTC 'system' time of the gateway, ISO 8601 'expanded' format (e.g 2014-01-12 08:59:28 GMT)
+	Lati float64      `json:"lati"` // GPS latitude of the gateway in degree (float, N is +)
+	Long float64      `json:"long"` // GPS latitude of the gateway in degree (float, E is +)
+	Alti int32        `json:"alti"` // GPS altitude of the gateway in meter RX (integer)
+	RXNb uint32       `json:"rxnb"` // Number of radio packets received (unsigned integer)
+	RXOK uint32       `json:"rxok"` // Number of radio packets received with a valid PHY CRC
+	RXFW uint32       `json:"rxfw"` // Number of radio packets forwarded (unsigned integer)
+	ACKR float64      `json:"ackr"` // Percentage of upstream datagrams that were acknowledged
+	DWNb uint32       `json:"dwnb"` // Number of downlink datagrams received (unsigned integer)
+	TXNb uint32       `json:"txnb"` // Number of packets emitted (unsigned integer)
+}
+
+// TXPK contains a RF packet to be emitted and associated metadata.
+type TXPK struct {
+	Imme bool    `json:"imme"`           // Send packet immediately (will ignore tmst & time)
+	Tmst *uint32 `json:"tmst,omitempty"` // Send packet on a certain timestamp value (will ignore time)
+	Tmms *int64  `json:"tmms,omitempty"` // Send packet at a certain GPS time (GPS synchronization required)
+	Freq float64 `json:"freq"`           // TX central frequency in MHz (unsigned float, Hz precision)
+	RFCh uint8   `json:"rfch"`           // Concentrator "RF chain" used for TX (unsigned integer)
+	Powe uint8   `json:"powe"`           // TX output power in dBm (unsigned integer, dBm precision)
+	Modu string  `json:"modu"`           // Modulation identifier "LORA" or "FSK"
+	DatR DatR    `json:"datr"`           // LoRa datarate identifier (eg. SF12BW500) || FSK datarate (unsigned, in bits per second)
+	CodR string  `json:"codr,omitempty"` // LoRa ECC coding rate identifier
+	FDev uint16  `json:"fdev,omitempty"` // FSK frequency deviation (unsigned integer, in Hz)
+	IPol bool    `json:"ipol"`           // Lora modulation polarization inversion
+	Prea uint16  `json:"prea,omitempty"` // RF preamble size (unsigned integer)
+	Size uint16  `json:"size"`           // RF packet payload size in bytes (unsigned integer)
+	NCRC bool    `json:"ncrc,omitempty"` // If true, disable the CRC of the physical layer (optional)
+	Data []byte  `json:"data"`           // Base64 encoded RF packet payload, padding optional
+	Brd  uint32  `json:"brd"`            // Concentrator board used for TX (unsigned integer)
+	Ant  uint32  `json:"ant"`            // Antenna number on which signal has been received
+}
@@ -0,0 +1,365 @@
+package semtechudp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/api/common"
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/lorawan"
+)
+
+func TestGetPacketType(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Data          []byte
+		Expected      PacketType
+		ExpectedError error
+	}{
+		{"too short", []byte{2, 0, 0}, PacketType(0), ErrPacketTooShort},
+		{"invalid protocol version", []byte{3, 0, 0, 0}, PacketType(0), ErrInvalidProtocolVersion},
+		{"invalid packet type", []byte{2, 0, 0, 6}, PacketType(0), ErrInvalidPacketType},
+		{"push data", []byte{2, 0, 0, 0}, PushData, nil},
+		{"pull data", []byte{1, 0, 0, 2}, PullData, nil},
+		{"tx ack", []byte{2, 0, 0, 5}, TXACK, nil},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			pt, err := GetPacketType(tst.Data)
+			assert.Equal(tst.ExpectedError, err)
+			assert.Equal(tst.Expected, pt)
+		})
+	}
+}
+
+func TestPushDataPacket(t *testing.T) {
+	assert := require.New(t)
+
+	data := append([]byte{2, 0x34, 0x12, 0, 1, 2, 3, 4, 5, 6, 7, 8}, []byte(`{
+		"rxpk": [
+			{
+				"time": "2019-05-14T11:40:00.5Z",
+				"tmms": 1241885618500,
+				"tmst": 3512348611,
+				"chan": 2,
+				"rfch": 0,
+				"freq": 868.5,
+				"stat": 1,
+				"modu": "LORA",
+				"datr": "SF7BW125",
+				"codr": "4/5",
+				"rssi": -35,
+				"lsnr": 5.1,
+				"size": 4,
+				"data": "AQIDBA=="
+			},
+			{
+				"tmst": 3512348612,
+				"freq": 868.3,
+				"stat": -1,
+				"modu": "LORA",
+				"datr": "SF12BW125",
+				"codr": "4/5",
+				"size": 1,
+				"data": "AQ=="
+			},
+			{
+				"tmst": 3512348613,
+				"freq": 868.8,
+				"stat": 1,
+				"modu": "FSK",
+				"datr": 50000,
+				"size": 1,
+				"data": "Ag=="
+			}
+		],
+		"stat": {
+			"time": "2019-05-14 11:40:00 GMT",
+			"lati": 1.123,
+			"long": 2.123,
+			"alti": 10,
+			"rxnb": 3,
+			"rxok": 2,
+			"rxfw": 2,
+			"ackr": 100.0,
+			"dwnb": 1,
+			"txnb": 1
+		}
+	}`)...)
+
+	var p PushDataPacket
+	assert.NoError(p.UnmarshalBinary(data))
+	assert.Equal(ProtocolVersion2, p.ProtocolVersion)
+	assert.Equal(uint16(0x1234), p.RandomToken)
+	assert.Equal(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, p.GatewayMAC)
+
+	t.Run("GetGatewayStats", func(t *testing.T) {
+		assert := require.New(t)
+
+		stats, err := p.GetGatewayStats()
+		assert.NoError(err)
+		assert.Equal([]byte{1, 2, 3, 4, 5, 6, 7, 8}, stats.GatewayId)
+		assert.Len(stats.StatsId, 16)
+		assert.Equal(uint32(3), stats.RxPacketsReceived)
+		assert.Equal(uint32(2), stats.RxPacketsReceivedOk)
+		assert.Equal(uint32(1), stats.TxPacketsReceived)
+		assert.Equal(uint32(1), stats.TxPacketsEmitted)
+		assert.Equal(&common.Location{
+			Latitude:  1.123,
+			Longitude: 2.123,
+			Altitude:  10,
+			Source:    common.LocationSource_GPS,
+		}, stats.Location)
+
+		ts, err := ptypes.Timestamp(stats.Time)
+		assert.NoError(err)
+		assert.True(ts.Equal(time.Date(2019, 5, 14, 11, 40, 0, 0, time.UTC)))
+	})
+
+	t.Run("GetUplinkFrames", func(t *testing.T) {
+		assert := require.New(t)
+
+		frames, err := p.GetUplinkFrames(false)
+		assert.NoError(err)
+		assert.Len(frames, 2)
+
+		assert.Equal([]byte{1, 2, 3, 4}, frames[0].PhyPayload)
+		assert.Equal(uint32(868500000), frames[0].TxInfo.Frequency)
+		assert.Equal(common.Modulation_LORA, frames[0].TxInfo.Modulation)
+		assert.Equal(&gw.LoRaModulationInfo{
+			SpreadingFactor: 7,
+			Bandwidth:       125,
+			CodeRate:        "4/5",
+		}, frames[0].TxInfo.GetLoraModulationInfo())
+		assert.Equal([]byte{1, 2, 3, 4, 5, 6, 7, 8}, frames[0].RxInfo.GatewayId)
+		assert.Equal(int32(-35), frames[0].RxInfo.Rssi)
+		assert.Equal(5.1, frames[0].RxInfo.LoraSnr)
+		assert.Equal(uint32(2), frames[0].RxInfo.Channel)
+		assert.Equal([]byte{0xd1, 0x5a, 0x2f, 0xc3}, frames[0].RxInfo.Context)
+		assert.Len(frames[0].RxInfo.UplinkId, 16)
+
+		ts, err := ptypes.Timestamp(frames[0].RxInfo.Time)
+		assert.NoError(err)
+		assert.True(ts.Equal(time.Date(2019, 5, 14, 11, 40, 0, 500000000, time.UTC)))
+
+		gpsTime, err := ptypes.Duration(frames[0].RxInfo.TimeSinceGpsEpoch)
+		assert.NoError(err)
+		assert.Equal(1241885618500*time.Millisecond, gpsTime)
+
+		assert.Equal([]byte{2}, frames[1].PhyPayload)
+		assert.Equal(common.Modulation_FSK, frames[1].TxInfo.Modulation)
+		assert.Equal(uint32(50000), frames[1].TxInfo.GetFskModulationInfo().Bitrate)
+		assert.Nil(frames[1].RxInfo.Time)
+		assert.Nil(frames[1].RxInfo.TimeSinceGpsEpoch)
+
+		frames, err = p.GetUplinkFrames(true)
+		assert.NoError(err)
+		assert.Len(frames, 3)
+	})
+}
+
+func TestPullDataPacket(t *testing.T) {
+	assert := require.New(t)
+
+	p := PullDataPacket{
+		ProtocolVersion: ProtocolVersion2,
+		RandomToken:     0x1234,
+		GatewayMAC:      lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	b, err := p.MarshalBinary()
+	assert.NoError(err)
+	assert.Equal([]byte{2, 0x34, 0x12, 2, 1, 2, 3, 4, 5, 6, 7, 8}, b)
+
+	var pOut PullDataPacket
+	assert.NoError(pOut.UnmarshalBinary(b))
+	assert.Equal(p, pOut)
+}
+
+func TestGetPullRespPacket(t *testing.T) {
+	loraModInfo := gw.DownlinkTXInfo_LoraModulationInfo{
+		LoraModulationInfo: &gw.LoRaModulationInfo{
+			SpreadingFactor:       12,
+			Bandwidth:             125,
+			CodeRate:              "4/5",
+			PolarizationInversion: true,
+		},
+	}
+
+	tmst := uint32(0x01020304 + 1000000)
+	tmms := int64(5000)
+
+	tests := []struct {
+		Name          string
+		TXInfo        gw.DownlinkTXInfo
+		Expected      TXPK
+		ExpectedError string
+	}{
+		{
+			Name: "delay",
+			TXInfo: gw.DownlinkTXInfo{
+				Frequency:      868100000,
+				Power:          14,
+				Modulation:     common.Modulation_LORA,
+				ModulationInfo: &loraModInfo,
+				Context:        []byte{1, 2, 3, 4},
+				Timing:         gw.DownlinkTiming_DELAY,
+				TimingInfo: &gw.DownlinkTXInfo_DelayTimingInfo{
+					DelayTimingInfo: &gw.DelayTimingInfo{
+						Delay: ptypes.DurationProto(time.Second),
+					},
+				},
+			},
+			Expected: TXPK{
+				Tmst: &tmst,
+				Freq: 868.1,
+				Powe: 14,
+				Modu: "LORA",
+				DatR: DatR{LoRa: &DatRLoRa{SpreadFactor: 12, Bandwidth: 125}},
+				CodR: "4/5",
+				IPol: true,
+				Size: 3,
+				Data: []byte{1, 2, 3},
+			},
+		},
+		{
+			Name: "delay without context",
+			TXInfo: gw.DownlinkTXInfo{
+				Modulation:     common.Modulation_LORA,
+				ModulationInfo: &loraModInfo,
+				Timing:         gw.DownlinkTiming_DELAY,
+				TimingInfo: &gw.DownlinkTXInfo_DelayTimingInfo{
+					DelayTimingInfo: &gw.DelayTimingInfo{
+						Delay: ptypes.DurationProto(time.Second),
+					},
+				},
+			},
+			ExpectedError: "context must be exactly 4 bytes, got: 0",
+		},
+		{
+			Name: "gps epoch",
+			TXInfo: gw.DownlinkTXInfo{
+				Frequency:      869525000,
+				Power:          27,
+				Modulation:     common.Modulation_LORA,
+				ModulationInfo: &loraModInfo,
+				Timing:         gw.DownlinkTiming_GPS_EPOCH,
+				TimingInfo: &gw.DownlinkTXInfo_GpsEpochTimingInfo{
+					GpsEpochTimingInfo: &gw.GPSEpochTimingInfo{
+						TimeSinceGpsEpoch: ptypes.DurationProto(5 * time.Second),
+					},
+				},
+			},
+			Expected: TXPK{
+				Tmms: &tmms,
+				Freq: 869.525,
+				Powe: 27,
+				Modu: "LORA",
+				DatR: DatR{LoRa: &DatRLoRa{SpreadFactor: 12, Bandwidth: 125}},
+				CodR: "4/5",
+				IPol: true,
+				Size: 3,
+				Data: []byte{1, 2, 3},
+			},
+		},
+		{
+			Name: "immediately fsk",
+			TXInfo: gw.DownlinkTXInfo{
+				Frequency:  868800000,
+				Power:      14,
+				Modulation: common.Modulation_FSK,
+				ModulationInfo: &gw.DownlinkTXInfo_FskModulationInfo{
+					FskModulationInfo: &gw.FSKModulationInfo{
+						Bitrate: 50000,
+					},
+				},
+				Timing: gw.DownlinkTiming_IMMEDIATELY,
+			},
+			Expected: TXPK{
+				Imme: true,
+				Freq: 868.8,
+				Powe: 14,
+				Modu: "FSK",
+				DatR: DatR{FSK: 50000},
+				FDev: 25000,
+				Size: 3,
+				Data: []byte{1, 2, 3},
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			txInfo := tst.TXInfo
+			p, err := GetPullRespPacket(ProtocolVersion2, 1234, gw.DownlinkFrame{
+				PhyPayload: []byte{1, 2, 3},
+				TxInfo:     &txInfo,
+			})
+			if tst.ExpectedError != "" {
+				assert.EqualError(err, tst.ExpectedError)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(uint16(1234), p.RandomToken)
+			assert.Equal(tst.Expected, p.Payload.TXPK)
+
+			b, err := p.MarshalBinary()
+			assert.NoError(err)
+
+			var pOut PullRespPacket
+			assert.NoError(pOut.UnmarshalBinary(b))
+			assert.Equal(p, pOut)
+		})
+	}
+}
+
+func TestTXACKPacket(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Data     []byte
+		Expected gw.DownlinkTXAck
+	}{
+		{
+			Name: "without payload",
+			Data: []byte{2, 0x34, 0x12, 5, 1, 2, 3, 4, 5, 6, 7, 8},
+			Expected: gw.DownlinkTXAck{
+				GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Token:     0x1234,
+			},
+		},
+		{
+			Name: "no error",
+			Data: append([]byte{2, 0x34, 0x12, 5, 1, 2, 3, 4, 5, 6, 7, 8}, []byte(`{"txpk_ack":{"error":"NONE"}}`)...),
+			Expected: gw.DownlinkTXAck{
+				GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Token:     0x1234,
+			},
+		},
+		{
+			Name: "error",
+			Data: append([]byte{2, 0x34, 0x12, 5, 1, 2, 3, 4, 5, 6, 7, 8}, []byte(`{"txpk_ack":{"error":"TOO_LATE"}}`)...),
+			Expected: gw.DownlinkTXAck{
+				GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Token:     0x1234,
+				Error:     "TOO_LATE",
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var p TXACKPacket
+			assert.NoError(p.UnmarshalBinary(tst.Data))
+			assert.Equal(tst.Expected, p.GetDownlinkTXAck())
+		})
+	}
+}
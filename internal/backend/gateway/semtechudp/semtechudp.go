@@ -0,0 +1,498 @@
+// Package semtechudp implements a gateway backend for the Semtech UDP
+// packet-forwarder protocol.
+package semtechudp
+
+import (
+	"encoding/base64"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/lorawan"
+)
+
+// gatewayCleanupDuration defines the duration after which a gateway is
+// removed from the registry when no PULL_DATA has been received.
+const gatewayCleanupDuration = time.Minute
+
+// connStateBufferSize defines the buffer size of the connection-state
+// channel, so that the cleanup of gateways does not block on a slow
+// consumer.
+const connStateBufferSize = 100
+
+// ErrGatewayDoesNotExist is returned when the gateway is not known by the
+// backend (no PULL_DATA has been received yet or it has been removed).
+var ErrGatewayDoesNotExist = errors.New("gateway does not exist")
+
+type udpPacket struct {
+	addr *net.UDPAddr
+	data []byte
+}
+
+// gatewayConn holds the (downlink) connection details of a gateway.
+type gatewayConn struct {
+	addr            *net.UDPAddr
+	lastSeen        time.Time
+	protocolVersion uint8
+}
+
+// Backend implements a Semtech UDP packet-forwarder backend.
+type Backend struct {
+	sync.RWMutex
+
+	conn         *net.UDPConn
+	closed       bool
+	done         chan struct{}
+	skipCRCCheck bool
+	wg           sync.WaitGroup
+
+	gateways map[lorawan.EUI64]gatewayConn
+
+	uplinkFrameChan         chan gw.UplinkFrame
+	gatewayStatsChan        chan gw.GatewayStats
+	downlinkTXAckChan       chan gw.DownlinkTXAck
+	commandExecResponseChan chan gw.GatewayCommandExecResponse
+	connStateChan           chan gw.ConnState
+}
+
+// NewBackend creates a new Backend.
+func NewBackend(c config.Config) (gateway.Gateway, error) {
+	conf := c.NetworkServer.Gateway.Backend.SemtechUDP
+
+	b := Backend{
+		skipCRCCheck: conf.SkipCRCCheck,
+		gateways:     make(map[lorawan.EUI64]gatewayConn),
+		done:         make(chan struct{}),
+
+		uplinkFrameChan:         make(chan gw.UplinkFrame),
+		gatewayStatsChan:        make(chan gw.GatewayStats),
+		downlinkTXAckChan:       make(chan gw.DownlinkTXAck),
+		commandExecResponseChan: make(chan gw.GatewayCommandExecResponse),
+		connStateChan:           make(chan gw.ConnState, connStateBufferSize),
+	}
+
+	addr, err := net.ResolveUDPAddr("udp", conf.UDPBind)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve udp addr error")
+	}
+
+	log.WithField("addr", addr).Info("gateway/semtech_udp: starting gateway udp listener")
+	b.conn, err = net.ListenUDP("udp", addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "listen udp error")
+	}
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		if err := b.readPackets(); err != nil && !b.isClosed() {
+			log.WithError(err).Error("gateway/semtech_udp: read udp packets error")
+		}
+	}()
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.cleanupGateways()
+	}()
+
+	return &b, nil
+}
+
+// SendTXPacket sends the given downlink frame to the gateway.
+func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if pl.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(pl.TxInfo)
+	downID := helpers.GetDownlinkID(&pl)
+
+	gwConn, err := b.getGateway(gatewayID)
+	if err != nil {
+		return errors.Wrap(err, "get gateway error")
+	}
+
+	packet, err := GetPullRespPacket(gwConn.protocolVersion, uint16(pl.Token), pl)
+	if err != nil {
+		return errors.Wrap(err, "get PULL_RESP packet error")
+	}
+
+	bytes, err := packet.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal PULL_RESP packet error")
+	}
+
+	if err := b.sendPacket(gwConn.addr, bytes); err != nil {
+		return errors.Wrap(err, "send packet error")
+	}
+
+	udpWriteCounter(PullResp.String()).Inc()
+
+	log.WithFields(log.Fields{
+		"gateway_id":  gatewayID,
+		"downlink_id": downID,
+		"addr":        gwConn.addr,
+	}).Info("gateway/semtech_udp: downlink frame sent to gateway")
+
+	return nil
+}
+
+// SendGatewayConfigPacket is not supported by the Semtech UDP
+// packet-forwarder protocol. The channel-plan must be configured in the
+// packet-forwarder configuration.
+func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	log.WithFields(log.Fields{
+		"gateway_id": helpers.GetGatewayID(&pl),
+		"version":    pl.Version,
+	}).Debug("gateway/semtech_udp: gateway configuration is not supported by the semtech udp backend")
+	return nil
+}
+
+// SendGatewayCommandExecRequest is not supported by the Semtech UDP
+// packet-forwarder protocol.
+func (b *Backend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	return errors.New("gateway command execution is not supported by the semtech udp backend")
+}
+
+// RXPacketChan returns the channel containing the received uplink frames.
+func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+// StatsPacketChan returns the channel containing the gateway stats.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+// DownlinkTXAckChan returns the channel containing the downlink tx acks.
+func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+// GatewayCommandExecResponseChan returns the channel containing the gateway
+// command execution responses. Note that this channel will never receive
+// any responses.
+func (b *Backend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.commandExecResponseChan
+}
+
+// ConnStateChan returns the gateway connection-state channel. A gateway is
+// ONLINE when a PULL_DATA has been received and OFFLINE when no PULL_DATA
+// or PUSH_DATA has been received within the gatewayCleanupDuration.
+func (b *Backend) ConnStateChan() chan gw.ConnState {
+	return b.connStateChan
+}
+
+// Close closes the backend.
+func (b *Backend) Close() error {
+	log.Info("gateway/semtech_udp: closing gateway backend")
+
+	b.Lock()
+	b.closed = true
+	b.Unlock()
+
+	close(b.done)
+
+	if err := b.conn.Close(); err != nil {
+		return errors.Wrap(err, "close udp listener error")
+	}
+
+	log.Info("gateway/semtech_udp: handling last packets")
+	b.wg.Wait()
+
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+	close(b.commandExecResponseChan)
+	close(b.connStateChan)
+
+	return nil
+}
+
+func (b *Backend) isClosed() bool {
+	b.RLock()
+	defer b.RUnlock()
+	return b.closed
+}
+
+func (b *Backend) getGateway(gatewayID lorawan.EUI64) (gatewayConn, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	gwConn, ok := b.gateways[gatewayID]
+	if !ok {
+		return gwConn, ErrGatewayDoesNotExist
+	}
+	return gwConn, nil
+}
+
+// setLastSeen updates the last-seen timestamp of the given gateway. It
+// returns false when the gateway is not in the registry.
+func (b *Backend) setLastSeen(gatewayID lorawan.EUI64, lastSeen time.Time) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	gwConn, ok := b.gateways[gatewayID]
+	if !ok {
+		return false
+	}
+	gwConn.lastSeen = lastSeen
+	b.gateways[gatewayID] = gwConn
+	return true
+}
+
+// setGateway stores the given gateway connection. It returns true when the
+// gateway was added to the registry.
+func (b *Backend) setGateway(gatewayID lorawan.EUI64, gwConn gatewayConn) bool {
+	b.Lock()
+	defer b.Unlock()
+	_, ok := b.gateways[gatewayID]
+	b.gateways[gatewayID] = gwConn
+	return !ok
+}
+
+// cleanupGateways periodically removes the inactive gateways until the
+// backend is closed.
+func (b *Backend) cleanupGateways() {
+	ticker := time.NewTicker(gatewayCleanupDuration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-ticker.C:
+			b.removeInactiveGateways()
+		}
+	}
+}
+
+// removeInactiveGateways removes the gateways from which no PULL_DATA or
+// PUSH_DATA has been received within the gatewayCleanupDuration and publishes the OFFLINE
+// connection-state for these.
+func (b *Backend) removeInactiveGateways() {
+	var removed []lorawan.EUI64
+
+	b.Lock()
+	for gatewayID, gwConn := range b.gateways {
+		if time.Since(gwConn.lastSeen) > gatewayCleanupDuration {
+			delete(b.gateways, gatewayID)
+			removed = append(removed, gatewayID)
+			log.WithField("gateway_id", gatewayID).Info("gateway/semtech_udp: gateway removed from registry")
+		}
+	}
+	b.Unlock()
+
+	for _, gatewayID := range removed {
+		b.sendConnState(gatewayID, gw.ConnState_OFFLINE)
+	}
+}
+
+// sendConnState publishes the connection-state of the given gateway. The
+// connection-state is dropped when the channel buffer is full, as blocking
+// would also block the handling of packets and the cleanup of gateways.
+func (b *Backend) sendConnState(gatewayID lorawan.EUI64, state gw.ConnState_State) {
+	select {
+	case b.connStateChan <- gw.ConnState{
+		GatewayId: gatewayID[:],
+		State:     state,
+	}:
+	default:
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"state":      state,
+		}).Warning("gateway/semtech_udp: connection-state channel is full, dropping connection-state")
+	}
+}
+
+func (b *Backend) readPackets() error {
+	buf := make([]byte, 65507) // max udp data size
+	for {
+		i, addr, err := b.conn.ReadFromUDP(buf)
+		if err != nil {
+			if b.isClosed() {
+				return nil
+			}
+
+			log.WithError(err).Error("gateway/semtech_udp: read from udp error")
+			continue
+		}
+
+		data := make([]byte, i)
+		copy(data, buf[:i])
+		up := udpPacket{data: data, addr: addr}
+
+		// The packets are handled sequentially, so that the packets of a
+		// gateway (e.g. uplink frames and tx acks) are published in the
+		// order in which they were received.
+		if err := b.handlePacket(up); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"data_base64": base64.StdEncoding.EncodeToString(up.data),
+				"addr":        up.addr,
+			}).Error("gateway/semtech_udp: could not handle packet")
+		}
+	}
+}
+
+func (b *Backend) handlePacket(up udpPacket) error {
+	pt, err := GetPacketType(up.data)
+	if err != nil {
+		return errors.Wrap(err, "get packet-type error")
+	}
+
+	udpReadCounter(pt.String()).Inc()
+
+	log.WithFields(log.Fields{
+		"addr":             up.addr,
+		"type":             pt,
+		"protocol_version": up.data[0],
+	}).Debug("gateway/semtech_udp: received udp packet from gateway")
+
+	switch pt {
+	case PushData:
+		return b.handlePushData(up)
+	case PullData:
+		return b.handlePullData(up)
+	case TXACK:
+		return b.handleTXACK(up)
+	default:
+		return errors.Errorf("unexpected packet-type: %s", pt)
+	}
+}
+
+func (b *Backend) handlePullData(up udpPacket) error {
+	var p PullDataPacket
+	if err := p.UnmarshalBinary(up.data); err != nil {
+		return errors.Wrap(err, "unmarshal PULL_DATA packet error")
+	}
+
+	ack := PullACKPacket{
+		ProtocolVersion: p.ProtocolVersion,
+		RandomToken:     p.RandomToken,
+	}
+	bytes, err := ack.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal PULL_ACK packet error")
+	}
+
+	added := b.setGateway(p.GatewayMAC, gatewayConn{
+		addr:            up.addr,
+		lastSeen:        time.Now(),
+		protocolVersion: p.ProtocolVersion,
+	})
+
+	if added {
+		log.WithFields(log.Fields{
+			"gateway_id": p.GatewayMAC,
+			"addr":       up.addr,
+		}).Info("gateway/semtech_udp: gateway added to registry")
+	}
+
+	if err := b.sendPacket(up.addr, bytes); err != nil {
+		return errors.Wrap(err, "send PULL_ACK packet error")
+	}
+
+	udpWriteCounter(PullACK.String()).Inc()
+
+	if added {
+		b.sendConnState(p.GatewayMAC, gw.ConnState_ONLINE)
+	}
+
+	return nil
+}
+
+func (b *Backend) handlePushData(up udpPacket) error {
+	var p PushDataPacket
+	if err := p.UnmarshalBinary(up.data); err != nil {
+		return errors.Wrap(err, "unmarshal PUSH_DATA packet error")
+	}
+
+	// the gateway is still alive, note that the PULL_DATA address is kept
+	// as this is the address used for downlinks
+	b.setLastSeen(p.GatewayMAC, time.Now())
+
+	// ack the packet
+	ack := PushACKPacket{
+		ProtocolVersion: p.ProtocolVersion,
+		RandomToken:     p.RandomToken,
+	}
+	bytes, err := ack.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal PUSH_ACK packet error")
+	}
+
+	if err := b.sendPacket(up.addr, bytes); err != nil {
+		return errors.Wrap(err, "send PUSH_ACK packet error")
+	}
+
+	udpWriteCounter(PushACK.String()).Inc()
+
+	// gateway stats
+	stats, err := p.GetGatewayStats()
+	if err != nil {
+		return errors.Wrap(err, "get gateway stats error")
+	}
+	if stats != nil {
+		stats.Ip = up.addr.IP.String()
+
+		log.WithFields(log.Fields{
+			"gateway_id": p.GatewayMAC,
+			"stats_id":   helpers.GetStatsID(stats),
+		}).Info("gateway/semtech_udp: stats received from gateway")
+
+		b.gatewayStatsChan <- *stats
+	}
+
+	// uplink frames
+	uplinkFrames, err := p.GetUplinkFrames(b.skipCRCCheck)
+	if err != nil {
+		return errors.Wrap(err, "get uplink frames error")
+	}
+	for i := range uplinkFrames {
+		log.WithFields(log.Fields{
+			"gateway_id": p.GatewayMAC,
+			"uplink_id":  helpers.GetUplinkID(uplinkFrames[i].RxInfo),
+		}).Info("gateway/semtech_udp: uplink received from gateway")
+
+		b.uplinkFrameChan <- uplinkFrames[i]
+	}
+
+	return nil
+}
+
+func (b *Backend) handleTXACK(up udpPacket) error {
+	var p TXACKPacket
+	if err := p.UnmarshalBinary(up.data); err != nil {
+		return errors.Wrap(err, "unmarshal TX_ACK packet error")
+	}
+
+	// only accept acks from gateways to which downlinks could have been sent
+	if _, err := b.getGateway(p.GatewayMAC); err != nil {
+		return errors.Wrapf(err, "TX_ACK from gateway %s", p.GatewayMAC)
+	}
+
+	ack := p.GetDownlinkTXAck()
+
+	log.WithFields(log.Fields{
+		"gateway_id": p.GatewayMAC,
+		"token":      ack.Token,
+		"error":      ack.Error,
+	}).Info("gateway/semtech_udp: ack received from gateway")
+
+	b.downlinkTXAckChan <- ack
+
+	return nil
+}
+
+func (b *Backend) sendPacket(addr *net.UDPAddr, data []byte) error {
+	_, err := b.conn.WriteToUDP(data, addr)
+	return err
+}
@@ -0,0 +1,258 @@
+package semtechudp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/chirpstack-network-server/api/common"
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+type BackendTestSuite struct {
+	suite.Suite
+
+	backend    *Backend
+	gwConn     *net.UDPConn
+	gatewayMAC lorawan.EUI64
+}
+
+func (ts *BackendTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+	conf.NetworkServer.Gateway.Backend.SemtechUDP.UDPBind = "127.0.0.1:0"
+
+	backend, err := NewBackend(conf)
+	assert.NoError(err)
+	ts.backend = backend.(*Backend)
+
+	ts.gwConn, err = net.DialUDP("udp", nil, ts.backend.conn.LocalAddr().(*net.UDPAddr))
+	assert.NoError(err)
+
+	ts.gatewayMAC = lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+}
+
+func (ts *BackendTestSuite) TearDownSuite() {
+	assert := require.New(ts.T())
+	assert.NoError(ts.gwConn.Close())
+	assert.NoError(ts.backend.Close())
+}
+
+func (ts *BackendTestSuite) readPacket() []byte {
+	assert := require.New(ts.T())
+
+	buf := make([]byte, 65507)
+	assert.NoError(ts.gwConn.SetReadDeadline(time.Now().Add(time.Second)))
+	i, err := ts.gwConn.Read(buf)
+	assert.NoError(err)
+	return buf[:i]
+}
+
+func (ts *BackendTestSuite) TestPullData() {
+	assert := require.New(ts.T())
+
+	p := PullDataPacket{
+		ProtocolVersion: ProtocolVersion2,
+		RandomToken:     0x1234,
+		GatewayMAC:      ts.gatewayMAC,
+	}
+	b, err := p.MarshalBinary()
+	assert.NoError(err)
+
+	_, err = ts.gwConn.Write(b)
+	assert.NoError(err)
+
+	assert.Equal([]byte{2, 0x34, 0x12, byte(PullACK)}, ts.readPacket())
+
+	select {
+	case connState := <-ts.backend.ConnStateChan():
+		assert.Equal(gw.ConnState{
+			GatewayId: ts.gatewayMAC[:],
+			State:     gw.ConnState_ONLINE,
+		}, connState)
+	case <-time.After(time.Second):
+		ts.T().Fatal("no connection-state received")
+	}
+
+	gwConn, err := ts.backend.getGateway(ts.gatewayMAC)
+	assert.NoError(err)
+	assert.Equal(ProtocolVersion2, gwConn.protocolVersion)
+	assert.Equal(ts.gwConn.LocalAddr().String(), gwConn.addr.String())
+
+	ts.T().Run("SendTXPacket", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(ts.backend.SendTXPacket(gw.DownlinkFrame{
+			PhyPayload: []byte{1, 2, 3},
+			Token:      0x4321,
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId:  ts.gatewayMAC[:],
+				Frequency:  868100000,
+				Power:      14,
+				Modulation: common.Modulation_LORA,
+				ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+					LoraModulationInfo: &gw.LoRaModulationInfo{
+						SpreadingFactor: 7,
+						Bandwidth:       125,
+						CodeRate:        "4/5",
+					},
+				},
+				Timing: gw.DownlinkTiming_IMMEDIATELY,
+			},
+		}))
+
+		var pullResp PullRespPacket
+		assert.NoError(pullResp.UnmarshalBinary(ts.readPacket()))
+		assert.Equal(uint16(0x4321), pullResp.RandomToken)
+		assert.True(pullResp.Payload.TXPK.Imme)
+		assert.Equal([]byte{1, 2, 3}, pullResp.Payload.TXPK.Data)
+	})
+
+	ts.T().Run("PULL_DATA of known gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err = ts.gwConn.Write(b)
+		assert.NoError(err)
+		assert.Equal([]byte{2, 0x34, 0x12, byte(PullACK)}, ts.readPacket())
+
+		select {
+		case <-ts.backend.ConnStateChan():
+			t.Fatal("unexpected connection-state received")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	ts.T().Run("SendTXPacket unknown gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		err := ts.backend.SendTXPacket(gw.DownlinkFrame{
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+			},
+		})
+		assert.EqualError(err, "get gateway error: gateway does not exist")
+	})
+}
+
+func (ts *BackendTestSuite) TestRemoveInactiveGateways() {
+	assert := require.New(ts.T())
+
+	gatewayID := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+	ts.backend.setGateway(gatewayID, gatewayConn{
+		lastSeen: time.Now().Add(-2 * gatewayCleanupDuration),
+	})
+
+	go ts.backend.removeInactiveGateways()
+
+	select {
+	case connState := <-ts.backend.ConnStateChan():
+		assert.Equal(gw.ConnState{
+			GatewayId: gatewayID[:],
+			State:     gw.ConnState_OFFLINE,
+		}, connState)
+	case <-time.After(time.Second):
+		ts.T().Fatal("no connection-state received")
+	}
+
+	_, err := ts.backend.getGateway(gatewayID)
+	assert.Equal(ErrGatewayDoesNotExist, err)
+}
+
+func (ts *BackendTestSuite) TestPushData() {
+	assert := require.New(ts.T())
+
+	lastSeen := time.Now().Add(-gatewayCleanupDuration)
+	ts.backend.setGateway(ts.gatewayMAC, gatewayConn{
+		addr:     ts.gwConn.LocalAddr().(*net.UDPAddr),
+		lastSeen: lastSeen,
+	})
+
+	data := append([]byte{2, 0x34, 0x12, byte(PushData), 1, 2, 3, 4, 5, 6, 7, 8}, []byte(`{
+		"rxpk": [
+			{
+				"tmst": 1,
+				"freq": 868.1,
+				"stat": 1,
+				"modu": "LORA",
+				"datr": "SF7BW125",
+				"codr": "4/5",
+				"size": 4,
+				"data": "AQIDBA=="
+			}
+		],
+		"stat": {
+			"time": "2019-05-14 11:40:00 GMT",
+			"rxnb": 1,
+			"rxok": 1
+		}
+	}`)...)
+
+	_, err := ts.gwConn.Write(data)
+	assert.NoError(err)
+
+	assert.Equal([]byte{2, 0x34, 0x12, byte(PushACK)}, ts.readPacket())
+
+	stats := <-ts.backend.StatsPacketChan()
+	assert.Equal(ts.gatewayMAC[:], stats.GatewayId)
+	assert.Equal("127.0.0.1", stats.Ip)
+	assert.Equal(uint32(1), stats.RxPacketsReceived)
+
+	uf := <-ts.backend.RXPacketChan()
+	assert.Equal([]byte{1, 2, 3, 4}, uf.PhyPayload)
+	assert.Equal(ts.gatewayMAC[:], uf.RxInfo.GatewayId)
+
+	// the last-seen timestamp is updated
+	gwConn, err := ts.backend.getGateway(ts.gatewayMAC)
+	assert.NoError(err)
+	assert.True(gwConn.lastSeen.After(lastSeen))
+}
+
+func (ts *BackendTestSuite) TestTXACK() {
+	ts.backend.setGateway(ts.gatewayMAC, gatewayConn{
+		addr:     ts.gwConn.LocalAddr().(*net.UDPAddr),
+		lastSeen: time.Now(),
+	})
+
+	ts.T().Run("Known gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		data := append([]byte{2, 0x21, 0x43, byte(TXACK), 1, 2, 3, 4, 5, 6, 7, 8}, []byte(`{"txpk_ack":{"error":"TOO_EARLY"}}`)...)
+		_, err := ts.gwConn.Write(data)
+		assert.NoError(err)
+
+		select {
+		case ack := <-ts.backend.DownlinkTXAckChan():
+			assert.Equal(gw.DownlinkTXAck{
+				GatewayId: ts.gatewayMAC[:],
+				Token:     0x4321,
+				Error:     "TOO_EARLY",
+			}, ack)
+		case <-time.After(time.Second):
+			t.Fatal("no ack received")
+		}
+	})
+
+	ts.T().Run("Unknown gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		data := append([]byte{2, 0x21, 0x43, byte(TXACK), 8, 7, 6, 5, 4, 3, 2, 1}, []byte(`{"txpk_ack":{"error":"TOO_EARLY"}}`)...)
+		_, err := ts.gwConn.Write(data)
+		assert.NoError(err)
+
+		select {
+		case <-ts.backend.DownlinkTXAckChan():
+			t.Fatal("unexpected ack received")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
+
+func TestBackend(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
@@ -1,7 +1,13 @@
 package channels
 
 import (
+	"context"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
 	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 	"github.com/brocaar/lorawan"
 )
@@ -9,9 +15,18 @@ import (
 // HandleChannelReconfigure handles the reconfiguration of active channels
 // on the node. This is needed in case only a sub-set of channels is used
 // (e.g. for the US band) or when a reconfiguration of active channels
-// happens.
-func HandleChannelReconfigure(ds storage.DeviceSession) ([]storage.MACCommandBlock, error) {
-	payloads := band.Band().GetLinkADRReqPayloadsForEnabledUplinkChannelIndices(ds.EnabledUplinkChannels)
+// happens. When the service-profile defines a channel-mask, only the
+// channels allowed by this channel-mask are enabled.
+func HandleChannelReconfigure(ctx context.Context, sp storage.ServiceProfile, ds storage.DeviceSession) ([]storage.MACCommandBlock, error) {
+	var payloads []lorawan.LinkADRReqPayload
+	if enabledChannels := getEnabledUplinkChannels(ctx, sp, ds); enabledChannels != nil {
+		if !channelsEqual(ds.EnabledUplinkChannels, enabledChannels) {
+			payloads = GetLinkADRReqPayloads(ds.EnabledUplinkChannels, enabledChannels)
+		}
+	} else {
+		payloads = band.Band().GetLinkADRReqPayloadsForEnabledUplinkChannelIndices(ds.EnabledUplinkChannels)
+	}
+
 	if len(payloads) == 0 {
 		return nil, nil
 	}
@@ -32,3 +47,129 @@ func HandleChannelReconfigure(ds storage.DeviceSession) ([]storage.MACCommandBlo
 
 	return []storage.MACCommandBlock{block}, nil
 }
+
+// GetLinkADRReqPayloads returns the LinkADRReq payloads for configuring the
+// given enabled channels on the device. A payload is returned for each
+// block of 16 channels (ChMaskCntl) containing channels to enable or
+// disable. In case no channels need to be enabled or disabled, a single
+// payload containing the first block of enabled channels is returned.
+func GetLinkADRReqPayloads(deviceChannels, enabledChannels []int) []lorawan.LinkADRReqPayload {
+	enabled := make(map[int]bool)
+	for _, c := range enabledChannels {
+		enabled[c] = true
+	}
+
+	active := make(map[int]bool)
+	for _, c := range deviceChannels {
+		active[c] = true
+	}
+
+	// blocks containing channels to enable or disable
+	var blocks []int
+	blockSet := make(map[int]bool)
+	for _, c := range deviceChannels {
+		if !enabled[c] && !blockSet[c/16] {
+			blockSet[c/16] = true
+			blocks = append(blocks, c/16)
+		}
+	}
+	for _, c := range enabledChannels {
+		if !active[c] && !blockSet[c/16] {
+			blockSet[c/16] = true
+			blocks = append(blocks, c/16)
+		}
+	}
+	sort.Ints(blocks)
+
+	// nothing to enable or disable, send the first block of channels
+	if len(blocks) == 0 {
+		if len(enabledChannels) == 0 {
+			return []lorawan.LinkADRReqPayload{{}}
+		}
+		blocks = append(blocks, enabledChannels[0]/16)
+	}
+
+	var payloads []lorawan.LinkADRReqPayload
+	for _, b := range blocks {
+		pl := lorawan.LinkADRReqPayload{
+			Redundancy: lorawan.Redundancy{
+				ChMaskCntl: uint8(b),
+			},
+		}
+
+		for _, c := range enabledChannels {
+			if c/16 == b {
+				pl.ChMask[c%16] = true
+			}
+		}
+
+		payloads = append(payloads, pl)
+	}
+
+	return payloads
+}
+
+// getEnabledUplinkChannels returns the enabled uplink channels of the band
+// which are allowed by the service-profile channel-mask. Custom channels
+// which are not active on the device are not returned, as we have no
+// knowledge if the device has been provisioned with these frequencies.
+// It returns nil in case the service-profile does not define a channel-mask
+// or when none of the channels would remain enabled.
+func getEnabledUplinkChannels(ctx context.Context, sp storage.ServiceProfile, ds storage.DeviceSession) []int {
+	if len(sp.ChannelMask) == 0 {
+		return nil
+	}
+
+	active := make(map[int]bool)
+	for _, c := range ds.EnabledUplinkChannels {
+		active[c] = true
+	}
+
+	custom := make(map[int]bool)
+	for _, c := range band.Band().GetCustomUplinkChannelIndices() {
+		custom[c] = true
+	}
+
+	var out []int
+	for _, c := range band.Band().GetEnabledUplinkChannelIndices() {
+		if custom[c] && !active[c] {
+			continue
+		}
+
+		if sp.IsChannelEnabled(c) {
+			out = append(out, c)
+		}
+	}
+
+	if len(out) == 0 {
+		log.WithFields(log.Fields{
+			"dev_eui":            ds.DevEUI,
+			"service_profile_id": sp.ID,
+			"ctx_id":             ctx.Value(logging.ContextIDKey),
+		}).Warning("service-profile channel-mask does not contain any of the enabled channels, ignoring channel-mask")
+		return nil
+	}
+
+	sort.Ints(out)
+	return out
+}
+
+// channelsEqual returns true when both slices contain the same channels.
+func channelsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	set := make(map[int]bool)
+	for _, c := range a {
+		set[c] = true
+	}
+
+	for _, c := range b {
+		if !set[c] {
+			return false
+		}
+	}
+
+	return true
+}
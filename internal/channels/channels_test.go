@@ -1,6 +1,7 @@
 package channels
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -15,9 +16,10 @@ func TestHandleChannelReconfigure(t *testing.T) {
 
 	Convey("Given a set of tests", t, func() {
 		tests := []struct {
-			Name          string
-			DeviceSession storage.DeviceSession
-			Expected      []storage.MACCommandBlock
+			Name           string
+			ServiceProfile storage.ServiceProfile
+			DeviceSession  storage.DeviceSession
+			Expected       []storage.MACCommandBlock
 		}{
 			{
 				Name: "no channels to reconfigure",
@@ -54,14 +56,118 @@ func TestHandleChannelReconfigure(t *testing.T) {
 					},
 				},
 			},
+			{
+				Name: "channels disabled by service-profile channel-mask",
+				ServiceProfile: storage.ServiceProfile{
+					ChannelMask: []byte{0x05},
+				},
+				DeviceSession: storage.DeviceSession{
+					TXPowerIndex:          1,
+					NbTrans:               2,
+					EnabledUplinkChannels: []int{0, 1, 2},
+					DR:                    3,
+				},
+				Expected: []storage.MACCommandBlock{
+					{
+						CID: lorawan.LinkADRReq,
+						MACCommands: storage.MACCommands{
+							lorawan.MACCommand{
+								CID: lorawan.LinkADRReq,
+								Payload: &lorawan.LinkADRReqPayload{
+									DataRate: 3,
+									TXPower:  1,
+									ChMask:   lorawan.ChMask{true, false, true},
+									Redundancy: lorawan.Redundancy{
+										NbRep: 2,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "service-profile channel-mask already applied",
+				ServiceProfile: storage.ServiceProfile{
+					ChannelMask: []byte{0x05},
+				},
+				DeviceSession: storage.DeviceSession{
+					TXPowerIndex:          1,
+					NbTrans:               2,
+					EnabledUplinkChannels: []int{0, 2},
+				},
+			},
+			{
+				Name: "service-profile channel-mask without enabled channels is ignored",
+				ServiceProfile: storage.ServiceProfile{
+					ChannelMask: []byte{0x08},
+				},
+				DeviceSession: storage.DeviceSession{
+					TXPowerIndex:          1,
+					NbTrans:               2,
+					EnabledUplinkChannels: []int{0, 1, 2},
+				},
+			},
 		}
 
 		for i, test := range tests {
 			Convey(fmt.Sprintf("test: %s [%d]", test.Name, i), func() {
-				blocks, err := HandleChannelReconfigure(test.DeviceSession)
+				blocks, err := HandleChannelReconfigure(context.Background(), test.ServiceProfile, test.DeviceSession)
 				So(err, ShouldBeNil)
 				So(blocks, ShouldResemble, test.Expected)
 			})
 		}
 	})
 }
+
+func TestGetLinkADRReqPayloads(t *testing.T) {
+	Convey("Given a testtable for GetLinkADRReqPayloads", t, func() {
+		testTable := []struct {
+			Name             string
+			DeviceChannels   []int
+			EnabledChannels  []int
+			ExpectedPayloads []lorawan.LinkADRReqPayload
+		}{
+			{
+				Name:            "nothing to disable",
+				DeviceChannels:  []int{0, 1, 2},
+				EnabledChannels: []int{0, 1, 2},
+				ExpectedPayloads: []lorawan.LinkADRReqPayload{
+					{ChMask: lorawan.ChMask{true, true, true}},
+				},
+			},
+			{
+				Name:            "one channel to disable",
+				DeviceChannels:  []int{0, 1, 2},
+				EnabledChannels: []int{0, 2},
+				ExpectedPayloads: []lorawan.LinkADRReqPayload{
+					{ChMask: lorawan.ChMask{true, false, true}},
+				},
+			},
+			{
+				Name:            "channels to disable in two blocks",
+				DeviceChannels:  []int{0, 1, 16, 17},
+				EnabledChannels: []int{0, 17},
+				ExpectedPayloads: []lorawan.LinkADRReqPayload{
+					{ChMask: lorawan.ChMask{true}},
+					{ChMask: lorawan.ChMask{false, true}, Redundancy: lorawan.Redundancy{ChMaskCntl: 1}},
+				},
+			},
+			{
+				Name:            "channels to enable in two blocks",
+				DeviceChannels:  []int{0},
+				EnabledChannels: []int{0, 1, 16},
+				ExpectedPayloads: []lorawan.LinkADRReqPayload{
+					{ChMask: lorawan.ChMask{true, true}},
+					{ChMask: lorawan.ChMask{true}, Redundancy: lorawan.Redundancy{ChMaskCntl: 1}},
+				},
+			},
+		}
+
+		for i, tst := range testTable {
+			Convey(fmt.Sprintf("Testing '%s' [%d]", tst.Name, i), func() {
+				So(GetLinkADRReqPayloads(tst.DeviceChannels, tst.EnabledChannels), ShouldResemble, tst.ExpectedPayloads)
+			})
+		}
+	})
+}
@@ -32,11 +32,13 @@ type Config struct {
 	}
 
 	NetworkServer struct {
-		NetID                lorawan.NetID
-		NetIDString          string        `mapstructure:"net_id"`
-		DeduplicationDelay   time.Duration `mapstructure:"deduplication_delay"`
-		DeviceSessionTTL     time.Duration `mapstructure:"device_session_ttl"`
-		GetDownlinkDataDelay time.Duration `mapstructure:"get_downlink_data_delay"`
+		NetID                  lorawan.NetID
+		NetIDString            string        `mapstructure:"net_id"`
+		DeduplicationDelay     time.Duration `mapstructure:"deduplication_delay"`
+		DeviceSessionTTL       time.Duration `mapstructure:"device_session_ttl"`
+		DeviceStatusHistoryTTL time.Duration `mapstructure:"device_status_history_ttl"`
+		MulticastDeliveryTTL   time.Duration `mapstructure:"multicast_delivery_ttl"`
+		GetDownlinkDataDelay   time.Duration `mapstructure:"get_downlink_data_delay"`
 
 		Band struct {
 			Name                   band.Name
@@ -74,6 +76,26 @@ type Config struct {
 				MaxCountN int  `mapstructure:"max_count_n"`
 				MaxTimeN  int  `mapstructure:"max_time_n"`
 			} `mapstructure:"rejoin_request"`
+
+			DutyCycle struct {
+				Enabled bool          `mapstructure:"enabled"`
+				Window  time.Duration `mapstructure:"window"`
+			} `mapstructure:"duty_cycle"`
+
+			TXSchedule struct {
+				Enabled bool          `mapstructure:"enabled"`
+				Margin  time.Duration `mapstructure:"margin"`
+			} `mapstructure:"tx_schedule"`
+
+			ADRPlugins []struct {
+				ID      string        `mapstructure:"id"`
+				Name    string        `mapstructure:"name"`
+				Server  string        `mapstructure:"server"`
+				CACert  string        `mapstructure:"ca_cert"`
+				TLSCert string        `mapstructure:"tls_cert"`
+				TLSKey  string        `mapstructure:"tls_key"`
+				Timeout time.Duration `mapstructure:"timeout"`
+			} `mapstructure:"adr_plugins"`
 		} `mapstructure:"network_settings"`
 
 		Scheduler struct {
@@ -97,6 +119,14 @@ type Config struct {
 				Timezone string
 			}
 
+			CommandExecTimeout time.Duration `mapstructure:"command_exec_timeout"`
+
+			Monitor struct {
+				Interval             time.Duration `mapstructure:"interval"`
+				DefaultStatsInterval time.Duration `mapstructure:"default_stats_interval"`
+				MissedStatsThreshold int           `mapstructure:"missed_stats_threshold"`
+			} `mapstructure:"monitor"`
+
 			Backend struct {
 				Type string `mapstructure:"type"`
 
@@ -127,6 +157,48 @@ type Config struct {
 					EventsConnectionString   string `mapstructure:"events_connection_string"`
 					CommandsConnectionString string `mapstructure:"commands_connection_string"`
 				} `mapstructure:"azure_iot_hub"`
+
+				BasicStation struct {
+					Bind          string        `mapstructure:"bind"`
+					TLSCert       string        `mapstructure:"tls_cert"`
+					TLSKey        string        `mapstructure:"tls_key"`
+					CACert        string        `mapstructure:"ca_cert"`
+					StatsInterval time.Duration `mapstructure:"stats_interval"`
+					PingInterval  time.Duration `mapstructure:"ping_interval"`
+					ReadTimeout   time.Duration `mapstructure:"read_timeout"`
+					WriteTimeout  time.Duration `mapstructure:"write_timeout"`
+					Region        string        `mapstructure:"region"`
+					FrequencyMin  uint32        `mapstructure:"frequency_min"`
+					FrequencyMax  uint32        `mapstructure:"frequency_max"`
+				} `mapstructure:"basic_station"`
+
+				SemtechUDP struct {
+					UDPBind      string `mapstructure:"udp_bind"`
+					SkipCRCCheck bool   `mapstructure:"skip_crc_check"`
+				} `mapstructure:"semtech_udp"`
+
+				AMQP struct {
+					URL                       string `mapstructure:"url"`
+					EventQueueName            string `mapstructure:"event_queue_name"`
+					EventRoutingKey           string `mapstructure:"event_routing_key"`
+					CommandRoutingKeyTemplate string `mapstructure:"command_routing_key_template"`
+				} `mapstructure:"amqp"`
+
+				Kafka struct {
+					Brokers      []string `mapstructure:"brokers"`
+					TLS          bool     `mapstructure:"tls"`
+					Username     string   `mapstructure:"username"`
+					Password     string   `mapstructure:"password"`
+					GroupID      string   `mapstructure:"group_id"`
+					UplinkTopic  string   `mapstructure:"uplink_topic"`
+					StatsTopic   string   `mapstructure:"stats_topic"`
+					AckTopic     string   `mapstructure:"ack_topic"`
+					CommandTopic string   `mapstructure:"command_topic"`
+				} `mapstructure:"kafka"`
+
+				Multiplexer struct {
+					Backends []string `mapstructure:"backends"`
+				} `mapstructure:"multiplexer"`
 			}
 		}
 	} `mapstructure:"network_server"`
@@ -164,6 +236,35 @@ type Config struct {
 		} `mapstructure:"kek"`
 	} `mapstructure:"join_server"`
 
+	Roaming struct {
+		RequestTimeout time.Duration `mapstructure:"request_timeout"`
+
+		API struct {
+			Bind    string `mapstructure:"bind"`
+			CACert  string `mapstructure:"ca_cert"`
+			TLSCert string `mapstructure:"tls_cert"`
+			TLSKey  string `mapstructure:"tls_key"`
+		} `mapstructure:"api"`
+
+		Servers []struct {
+			NetID                  string        `mapstructure:"net_id"`
+			PassiveRoaming         bool          `mapstructure:"passive_roaming"`
+			PassiveRoamingLifetime time.Duration `mapstructure:"passive_roaming_lifetime"`
+			PassiveRoamingKEKLabel string        `mapstructure:"passive_roaming_kek_label"`
+			Server                 string        `mapstructure:"server"`
+			CACert                 string        `mapstructure:"ca_cert"`
+			TLSCert                string        `mapstructure:"tls_cert"`
+			TLSKey                 string        `mapstructure:"tls_key"`
+		} `mapstructure:"servers"`
+
+		KEK struct {
+			Set []struct {
+				Label string
+				KEK   string `mapstructure:"kek"`
+			}
+		} `mapstructure:"kek"`
+	} `mapstructure:"roaming"`
+
 	NetworkController struct {
 		Client nc.NetworkControllerServiceClient
 
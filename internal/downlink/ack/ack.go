@@ -1,30 +1,57 @@
 package ack
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
+	"time"
 
 	"github.com/brocaar/lorawan"
+	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/chirpstack-network-server/api/as"
 	"github.com/brocaar/chirpstack-network-server/api/gw"
 	"github.com/brocaar/chirpstack-network-server/api/nc"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/controller"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/dutycycle"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/txschedule"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
 	"github.com/brocaar/chirpstack-network-server/internal/logging"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 )
 
+// applicationClientTimeout defines the max. duration of the tx ack
+// notification to the application-server, as this blocks the handling of
+// the tx ack.
+const applicationClientTimeout = time.Second
+
 var (
 	errAbort = errors.New("abort")
 )
 
+// retryErrors contains the tx ack errors for which the downlink is retried
+// using the next-best gateway within reach of the device. These errors are
+// specific to the gateway which reported them, an other gateway might still
+// be able to transmit the downlink. Timing errors (e.g. TOO_LATE) are not
+// retried, as an other gateway would be too late as well.
+var retryErrors = map[string]struct{}{
+	"COLLISION_PACKET": {},
+	"TX_FREQ":          {},
+}
+
 var handleDownlinkTXAckTasks = []func(*ackContext) error{
 	getToken,
 	getDownlinkFrames,
 	sendDownlinkMetaDataToNetworkControllerOnNoError,
+	sendTxAckToApplicationServerOnNoError,
+	updateMulticastQueueItemDelivery,
 	abortOnNoError,
+	setAlternativeGatewayOnRetryError,
+	skipFramesExceedingDutyCycle,
+	sendTxAckToApplicationServerOnNoFramesLeft,
 	sendDownlinkFrame,
 	saveDownlinkFrames,
 }
@@ -148,6 +175,138 @@ func sendDownlinkMetaDataToNetworkControllerOnNoError(ctx *ackContext) error {
 	return nil
 }
 
+// sendTxAckToApplicationServerOnNoError notifies the application-server
+// when the downlink was transmitted. A notification error does not abort
+// the handling of the tx ack.
+func sendTxAckToApplicationServerOnNoError(ctx *ackContext) error {
+	if ctx.DownlinkTXAck.Error != "" {
+		return nil
+	}
+
+	if err := sendTxAckToApplicationServer(ctx); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("send tx ack to application-server error")
+	}
+
+	return nil
+}
+
+// sendTxAckToApplicationServerOnNoFramesLeft notifies the application-server
+// when the downlink failed and there is no other downlink frame left to try.
+// A notification error does not abort the handling of the tx ack.
+func sendTxAckToApplicationServerOnNoFramesLeft(ctx *ackContext) error {
+	if len(ctx.DownlinkFrames.DownlinkFrames) >= 2 {
+		return nil
+	}
+
+	if err := sendTxAckToApplicationServer(ctx); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("send tx ack to application-server error")
+	}
+
+	return nil
+}
+
+// sendTxAckToApplicationServer sends the tx ack to the application-server.
+// This is only done for downlinks containing an application payload, as
+// only these relate to the device-queue items of the application-server.
+func sendTxAckToApplicationServer(ctx *ackContext) error {
+	if len(ctx.DownlinkFrames.DevEui) == 0 || len(ctx.DownlinkFrames.DownlinkFrames) == 0 {
+		return nil
+	}
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(ctx.DownlinkFrames.DownlinkFrames[0].PhyPayload); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("unmarshal phypayload error")
+		return nil
+	}
+
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok || macPL.FPort == nil || *macPL.FPort == 0 {
+		return nil
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], ctx.DownlinkFrames.DevEui)
+
+	d, err := storage.GetDevice(ctx.ctx, storage.DB(), devEUI)
+	if err != nil {
+		return errors.Wrap(err, "get device error")
+	}
+
+	rp, err := storage.GetRoutingProfile(ctx.ctx, storage.DB(), d.RoutingProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get routing-profile error")
+	}
+
+	asClient, err := rp.GetApplicationServerClient()
+	if err != nil {
+		return errors.Wrap(err, "get application-server client error")
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx.ctx, applicationClientTimeout)
+	defer cancel()
+
+	_, err = asClient.HandleTxAck(ctxTimeout, &as.HandleTxAckRequest{
+		DevEui:    devEUI[:],
+		FCnt:      ctx.DownlinkFrames.FCnt,
+		GatewayId: ctx.DownlinkTXAck.GatewayId,
+		Error:     ctx.DownlinkTXAck.Error,
+	})
+	if err != nil {
+		return errors.Wrap(err, "application-server client error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": devEUI,
+		"f_cnt":   ctx.DownlinkFrames.FCnt,
+		"error":   ctx.DownlinkTXAck.Error,
+		"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+	}).Info("sent tx ack to application-server")
+
+	return nil
+}
+
+// updateMulticastQueueItemDelivery updates the delivery status of the
+// multicast queue-item for the gateway that sent the tx ack.
+func updateMulticastQueueItemDelivery(ctx *ackContext) error {
+	if len(ctx.DownlinkFrames.MulticastGroupId) == 0 || len(ctx.DownlinkFrames.DownlinkFrames) == 0 || ctx.DownlinkFrames.DownlinkFrames[0].TxInfo == nil {
+		return nil
+	}
+
+	var multicastGroupID uuid.UUID
+	copy(multicastGroupID[:], ctx.DownlinkFrames.MulticastGroupId)
+
+	status := storage.MulticastDeliverySuccess
+	if ctx.DownlinkTXAck.Error != "" {
+		status = storage.MulticastDeliveryFailed
+	}
+
+	// this creates or updates the delivery, in case the scheduler
+	// transaction which created the pending delivery has not yet been
+	// committed, the upsert waits for the commit
+	err := storage.SaveMulticastQueueItemDelivery(ctx.ctx, storage.DB(), &storage.MulticastQueueItemDelivery{
+		MulticastGroupID: multicastGroupID,
+		FCnt:             ctx.DownlinkFrames.FCnt,
+		GatewayID:        helpers.GetGatewayID(ctx.DownlinkFrames.DownlinkFrames[0].TxInfo),
+		Status:           status,
+		Error:            ctx.DownlinkTXAck.Error,
+	})
+	if err != nil {
+		// the multicast-group or gateway has been deleted in the meantime
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "update multicast queue-item delivery error")
+	}
+
+	return nil
+}
+
 func abortOnNoError(ctx *ackContext) error {
 	if ctx.DownlinkTXAck.Error == "" {
 		// no error, nothing to do
@@ -156,6 +315,158 @@ func abortOnNoError(ctx *ackContext) error {
 	return nil
 }
 
+// setAlternativeGatewayOnRetryError uses the next-best gateway (ordered by
+// the DeviceGatewayRXInfoSet) that is able to transmit within the duty-cycle
+// limitations and tx schedule for the retry. When there is a pending downlink
+// frame (e.g. RX2), the alternative gateway is set on this frame, so that the
+// retry does not consume the time of the pending frame. Otherwise a copy of
+// the failed downlink frame, using the alternative gateway, is inserted as the
+// next frame to send. In case there is no such gateway, the remaining
+// downlink frames are used as-is.
+func setAlternativeGatewayOnRetryError(ctx *ackContext) error {
+	if _, ok := retryErrors[ctx.DownlinkTXAck.Error]; !ok {
+		return nil
+	}
+
+	// multicast and proprietary downlinks are not bound to a device
+	if len(ctx.DownlinkFrames.DevEui) == 0 || len(ctx.DownlinkFrames.DownlinkFrames) == 0 {
+		return nil
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], ctx.DownlinkFrames.DevEui)
+
+	rxInfoSet, err := storage.GetDeviceGatewayRXInfoSet(ctx.ctx, storage.RedisPool(), devEUI)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get device gateway rx-info set error")
+	}
+
+	failed := ctx.DownlinkFrames.DownlinkFrames[0]
+	if failed.TxInfo == nil {
+		return nil
+	}
+
+	// the frame to retry using the alternative gateway
+	retry := failed
+	if len(ctx.DownlinkFrames.DownlinkFrames) > 1 {
+		retry = ctx.DownlinkFrames.DownlinkFrames[1]
+	}
+	if retry.TxInfo == nil {
+		return nil
+	}
+
+	txInfo, err := getAlternativeTXInfo(ctx, rxInfoSet, failed.TxInfo.GatewayId, *retry.TxInfo, len(retry.PhyPayload))
+	if err != nil || txInfo == nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":           devEUI,
+		"gateway_id":        helpers.GetGatewayID(txInfo),
+		"failed_gateway_id": helpers.GetGatewayID(failed.TxInfo),
+		"error":             ctx.DownlinkTXAck.Error,
+		"ctx_id":            ctx.ctx.Value(logging.ContextIDKey),
+	}).Info("retrying downlink using alternative gateway")
+
+	alternative := gw.DownlinkFrame{
+		Token:      retry.Token,
+		DownlinkId: retry.DownlinkId,
+		PhyPayload: retry.PhyPayload,
+		TxInfo:     txInfo,
+	}
+
+	// replace the pending frame or insert the alternative after the failed
+	// frame, so that it will be sent as next frame
+	if retry != failed {
+		ctx.DownlinkFrames.DownlinkFrames[1] = &alternative
+	} else {
+		ctx.DownlinkFrames.DownlinkFrames = []*gw.DownlinkFrame{failed, &alternative}
+	}
+
+	return nil
+}
+
+// getAlternativeTXInfo returns a copy of the given tx-info, using the first
+// gateway after the failed gateway that is able to transmit the downlink
+// within the duty-cycle limitations and tx schedule. It returns nil when
+// there is no such gateway.
+func getAlternativeTXInfo(ctx *ackContext, rxInfoSet storage.DeviceGatewayRXInfoSet, failedGatewayID []byte, txInfo gw.DownlinkTXInfo, size int) (*gw.DownlinkTXInfo, error) {
+	// only consider the gateways after the gateway that failed, as the
+	// gateways before it have already been tried (or were skipped because
+	// of duty-cycle limitations)
+	start := 0
+	for i, rxInfo := range rxInfoSet.Items {
+		if bytes.Equal(rxInfo.GatewayID[:], failedGatewayID) {
+			start = i + 1
+			break
+		}
+	}
+
+	for i := start; i < len(rxInfoSet.Items); i++ {
+		rxInfo := rxInfoSet.Items[i]
+		if bytes.Equal(rxInfo.GatewayID[:], failedGatewayID) {
+			continue
+		}
+
+		alternative := txInfo
+		alternative.GatewayId = rxInfo.GatewayID[:]
+		alternative.Board = rxInfo.Board
+		alternative.Antenna = rxInfo.Antenna
+		alternative.Context = rxInfo.Context
+
+		ok, err := dutycycle.CanTransmit(ctx.ctx, &alternative, size)
+		if err != nil {
+			return nil, errors.Wrap(err, "check duty-cycle error")
+		}
+
+		if !ok {
+			continue
+		}
+
+		ok, err = txschedule.CanSchedule(ctx.ctx, &alternative, size)
+		if err != nil {
+			return nil, errors.Wrap(err, "check tx schedule error")
+		}
+
+		if ok {
+			return &alternative, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// skipFramesExceedingDutyCycle removes the next frames that can not be sent
+// without exceeding the duty-cycle limit of their gateway.
+func skipFramesExceedingDutyCycle(ctx *ackContext) error {
+	for len(ctx.DownlinkFrames.DownlinkFrames) >= 2 {
+		df := ctx.DownlinkFrames.DownlinkFrames[1]
+		if df.TxInfo == nil {
+			return nil
+		}
+
+		ok, err := dutycycle.CanTransmit(ctx.ctx, df.TxInfo, len(df.PhyPayload))
+		if err != nil {
+			return errors.Wrap(err, "check duty-cycle error")
+		}
+		if ok {
+			return nil
+		}
+
+		log.WithFields(log.Fields{
+			"gateway_id": helpers.GetGatewayID(df.TxInfo),
+			"ctx_id":     ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("gateway exceeds duty-cycle limit, skipping downlink frame")
+
+		ctx.DownlinkFrames.DownlinkFrames = append(ctx.DownlinkFrames.DownlinkFrames[:1], ctx.DownlinkFrames.DownlinkFrames[2:]...)
+	}
+
+	return nil
+}
+
 func sendDownlinkFrame(ctx *ackContext) error {
 	if len(ctx.DownlinkFrames.DownlinkFrames) < 2 {
 		return nil
@@ -165,6 +476,21 @@ func sendDownlinkFrame(ctx *ackContext) error {
 	if err := gateway.Backend().SendTXPacket(*ctx.DownlinkFrames.DownlinkFrames[1]); err != nil {
 		return errors.Wrap(err, "send downlink-frame to gateway error")
 	}
+
+	// save the used airtime for duty-cycle accounting
+	if err := dutycycle.SaveAirtime(ctx.ctx, *ctx.DownlinkFrames.DownlinkFrames[1]); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("save airtime error")
+	}
+
+	// save the emission in the gateway tx schedule
+	if err := txschedule.SaveScheduleItem(ctx.ctx, *ctx.DownlinkFrames.DownlinkFrames[1]); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("save tx schedule item error")
+	}
+
 	return nil
 }
 
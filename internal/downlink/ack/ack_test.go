@@ -0,0 +1,65 @@
+package ack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/dutycycle"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestSkipFramesExceedingDutyCycle(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	conf.NetworkServer.NetworkSettings.DutyCycle.Enabled = true
+	conf.NetworkServer.NetworkSettings.DutyCycle.Window = time.Hour
+	assert.NoError(storage.Setup(conf))
+	assert.NoError(dutycycle.Setup(conf))
+	test.MustFlushRedis(storage.RedisPool())
+
+	getFrame := func(gatewayID lorawan.EUI64) *gw.DownlinkFrame {
+		txInfo := gw.DownlinkTXInfo{
+			GatewayId: gatewayID[:],
+			Frequency: 868100000,
+		}
+		assert.NoError(helpers.SetDownlinkTXInfoDataRate(&txInfo, 5, band.Band()))
+
+		return &gw.DownlinkFrame{
+			TxInfo:     &txInfo,
+			PhyPayload: []byte{1, 2, 3, 4},
+		}
+	}
+
+	gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+	gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+	// gw1 has used all airtime of the h1.4 sub-band (1% of an hour)
+	assert.NoError(storage.SaveGatewayAirtime(context.Background(), storage.RedisPool(), gw1, "h1.4", time.Now(), 36*time.Second, time.Hour))
+
+	failed := getFrame(gw1)
+	retry1 := getFrame(gw1)
+	retry2 := getFrame(gw2)
+
+	ctx := ackContext{
+		ctx: context.Background(),
+		DownlinkFrames: storage.DownlinkFrames{
+			DownlinkFrames: []*gw.DownlinkFrame{failed, retry1, retry2},
+		},
+	}
+
+	assert.NoError(skipFramesExceedingDutyCycle(&ctx))
+	assert.Equal([]*gw.DownlinkFrame{failed, retry2}, ctx.DownlinkFrames.DownlinkFrames)
+
+	ctx.DownlinkFrames.DownlinkFrames = []*gw.DownlinkFrame{failed, retry1}
+	assert.NoError(skipFramesExceedingDutyCycle(&ctx))
+	assert.Equal([]*gw.DownlinkFrame{failed}, ctx.DownlinkFrames.DownlinkFrames)
+}
@@ -0,0 +1,303 @@
+// Package beacon implements the Class-B beacon scheduler. For every beacon
+// period, it sends a beacon frame to each gateway for which beaconing has
+// been enabled within its gateway-profile.
+package beacon
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/api/common"
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/data/classb"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/dutycycle"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/txschedule"
+	"github.com/brocaar/chirpstack-network-server/internal/gps"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+const (
+	beaconPeriod  = 128 * time.Second
+	beaconLockKey = "lora:ns:beacon:lock:%d"
+
+	// infoDescGPSCoordinate is the GwSpecific info descriptor indicating
+	// that the info field contains the GPS coordinate of the gateway
+	// antenna.
+	infoDescGPSCoordinate = 0
+)
+
+// Parameters contains the beacon parameters of a band.
+type Parameters struct {
+	// DR contains the data-rate used for the beacon.
+	DR int
+
+	// RFU1Size contains the size (bytes) of the RFU field before the time
+	// field.
+	RFU1Size int
+
+	// RFU2Size contains the size (bytes) of the RFU field after the
+	// GwSpecific field.
+	RFU2Size int
+}
+
+// bandParameters contains the beacon parameters per band, as defined by the
+// LoRaWAN Regional Parameters.
+var bandParameters = map[loraband.Name]Parameters{
+	loraband.EU868: {DR: 3, RFU1Size: 2, RFU2Size: 0},
+	loraband.US915: {DR: 8, RFU1Size: 5, RFU2Size: 3},
+	loraband.CN779: {DR: 3, RFU1Size: 2, RFU2Size: 0},
+	loraband.EU433: {DR: 3, RFU1Size: 2, RFU2Size: 0},
+	loraband.AU915: {DR: 8, RFU1Size: 5, RFU2Size: 3},
+	loraband.CN470: {DR: 2, RFU1Size: 3, RFU2Size: 1},
+	loraband.AS923: {DR: 3, RFU1Size: 2, RFU2Size: 0},
+	loraband.KR920: {DR: 3, RFU1Size: 2, RFU2Size: 0},
+	loraband.IN865: {DR: 4, RFU1Size: 1, RFU2Size: 2},
+	loraband.RU864: {DR: 3, RFU1Size: 2, RFU2Size: 0},
+
+	// legacy band names
+	loraband.EU_863_870: {DR: 3, RFU1Size: 2, RFU2Size: 0},
+	loraband.US_902_928: {DR: 8, RFU1Size: 5, RFU2Size: 3},
+	loraband.CN_779_787: {DR: 3, RFU1Size: 2, RFU2Size: 0},
+	loraband.EU_433:     {DR: 3, RFU1Size: 2, RFU2Size: 0},
+	loraband.AU_915_928: {DR: 8, RFU1Size: 5, RFU2Size: 3},
+	loraband.CN_470_510: {DR: 2, RFU1Size: 3, RFU2Size: 1},
+	loraband.AS_923:     {DR: 3, RFU1Size: 2, RFU2Size: 0},
+	loraband.KR_920_923: {DR: 3, RFU1Size: 2, RFU2Size: 0},
+	loraband.IN_865_867: {DR: 4, RFU1Size: 1, RFU2Size: 2},
+	loraband.RU_864_870: {DR: 3, RFU1Size: 2, RFU2Size: 0},
+}
+
+var (
+	params          Parameters
+	downlinkTXPower int
+)
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	p, ok := bandParameters[conf.NetworkServer.Band.Name]
+	if !ok {
+		return fmt.Errorf("no beacon parameters for band %s", conf.NetworkServer.Band.Name)
+	}
+
+	params = p
+	downlinkTXPower = conf.NetworkServer.NetworkSettings.DownlinkTXPower
+
+	return nil
+}
+
+// BeaconLoop starts an infinite loop scheduling the beacons for the next
+// beacon period. The beacons are sent to the gateways
+// config.ClassBEnqueueMargin before the start of the beacon.
+func BeaconLoop() {
+	for {
+		beaconTime := classb.GetBeaconStartForTime(time.Now().Add(config.ClassBEnqueueMargin)) + beaconPeriod
+		time.Sleep(time.Until(time.Time(gps.NewFromTimeSinceGPSEpoch(beaconTime)).Add(-config.ClassBEnqueueMargin)))
+
+		ctx := context.Background()
+		ctxID, err := uuid.NewV4()
+		if err != nil {
+			log.WithError(err).Error("get new uuid error")
+		}
+		ctx = context.WithValue(ctx, logging.ContextIDKey, ctxID)
+
+		log.WithFields(log.Fields{
+			"beacon_time": beaconTime,
+			"ctx_id":      ctxID,
+		}).Debug("running class-b beacon scheduler")
+
+		if err := ScheduleBeacons(ctx, beaconTime); err != nil {
+			log.WithFields(log.Fields{
+				"ctx_id": ctxID,
+			}).WithError(err).Error("class-b beacon scheduler error")
+		}
+	}
+}
+
+// ScheduleBeacons sends the beacon for the given beacon time (duration since
+// GPS epoch) to all the gateways for which beaconing is enabled. In case the
+// beacon has already been scheduled by an other network-server instance,
+// this is a no-op.
+func ScheduleBeacons(ctx context.Context, beaconTime time.Duration) error {
+	// Only one network-server instance must schedule the beacons.
+	c := storage.RedisPool().Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(beaconLockKey, beaconTime/time.Second)
+	_, err := redis.String(c.Do("SET", key, "lock", "PX", int64(beaconPeriod/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil
+		}
+		return errors.Wrap(err, "acquire beacon lock error")
+	}
+
+	gateways, err := storage.GetBeaconGateways(ctx, storage.DB())
+	if err != nil {
+		return errors.Wrap(err, "get beacon gateways error")
+	}
+
+	for _, g := range gateways {
+		if err := sendBeacon(ctx, g, beaconTime); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"gateway_id": g.GatewayID,
+				"ctx_id":     ctx.Value(logging.ContextIDKey),
+			}).Error("send beacon error")
+		}
+	}
+
+	return nil
+}
+
+func sendBeacon(ctx context.Context, g storage.Gateway, beaconTime time.Duration) error {
+	frequency, err := band.Band().GetPingSlotFrequency(lorawan.DevAddr{}, beaconTime)
+	if err != nil {
+		return errors.Wrap(err, "get beacon frequency error")
+	}
+
+	var txPower int
+	if downlinkTXPower != -1 {
+		txPower = downlinkTXPower
+	} else {
+		txPower = band.Band().GetDownlinkTXPower(frequency)
+	}
+
+	downID, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "new uuid error")
+	}
+	token := binary.BigEndian.Uint16(downID[0:2])
+
+	txInfo := gw.DownlinkTXInfo{
+		GatewayId: g.GatewayID[:],
+		Frequency: uint32(frequency),
+		Power:     int32(txPower),
+
+		Timing: gw.DownlinkTiming_GPS_EPOCH,
+		TimingInfo: &gw.DownlinkTXInfo_GpsEpochTimingInfo{
+			GpsEpochTimingInfo: &gw.GPSEpochTimingInfo{
+				TimeSinceGpsEpoch: ptypes.DurationProto(beaconTime),
+			},
+		},
+	}
+
+	if err := helpers.SetDownlinkTXInfoDataRate(&txInfo, params.DR, band.Band()); err != nil {
+		return errors.Wrap(err, "set downlink tx-info data-rate error")
+	}
+
+	// beacons are sent without polarization inversion
+	if txInfo.Modulation == common.Modulation_LORA {
+		modInfo := txInfo.GetLoraModulationInfo()
+		if modInfo != nil {
+			modInfo.PolarizationInversion = false
+		}
+	}
+
+	df := gw.DownlinkFrame{
+		Token:      uint32(token),
+		DownlinkId: downID[:],
+		TxInfo:     &txInfo,
+		PhyPayload: GetBeaconPayload(params, beaconTime, g.Location),
+	}
+
+	if err := gateway.Backend().SendTXPacket(df); err != nil {
+		return errors.Wrap(err, "send downlink frame to gateway error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id":  g.GatewayID,
+		"beacon_time": beaconTime,
+		"frequency":   frequency,
+		"ctx_id":      ctx.Value(logging.ContextIDKey),
+	}).Info("class-b beacon sent to gateway")
+
+	if err := storage.SaveDownlinkFrames(ctx, storage.RedisPool(), storage.DownlinkFrames{
+		Token:          df.Token,
+		DownlinkFrames: []*gw.DownlinkFrame{&df},
+	}); err != nil {
+		return errors.Wrap(err, "save downlink-frames error")
+	}
+
+	// save the used airtime for duty-cycle accounting
+	if err := dutycycle.SaveAirtime(ctx, df); err != nil {
+		log.WithError(err).Error("save airtime error")
+	}
+
+	// save the emission in the gateway tx schedule
+	if err := txschedule.SaveScheduleItem(ctx, df); err != nil {
+		log.WithError(err).Error("save tx schedule item error")
+	}
+
+	return nil
+}
+
+// GetBeaconPayload returns the beacon payload for the given beacon time
+// (duration since GPS epoch) and gateway location. The layout is:
+//
+//	RFU | Time | CRC | GwSpecific | RFU | CRC
+//
+// Where GwSpecific contains the info descriptor and the GPS coordinate of
+// the gateway.
+func GetBeaconPayload(p Parameters, beaconTime time.Duration, location storage.GPSPoint) []byte {
+	// RFU | Time | CRC
+	part1 := make([]byte, p.RFU1Size+4, p.RFU1Size+6)
+	binary.LittleEndian.PutUint32(part1[p.RFU1Size:], uint32(beaconTime/time.Second))
+	part1 = appendCRC(part1)
+
+	// GwSpecific (InfoDesc | Lat | Lng) | RFU | CRC
+	part2 := make([]byte, 7+p.RFU2Size, 7+p.RFU2Size+2)
+	part2[0] = infoDescGPSCoordinate
+	putInt24(part2[1:4], int32(math.Round(location.Latitude/90*(1<<23))))
+	putInt24(part2[4:7], int32(math.Round(location.Longitude/180*(1<<23))))
+	part2 = appendCRC(part2)
+
+	return append(part1, part2...)
+}
+
+// putInt24 stores the given value as a signed 24 bit little-endian integer.
+// The maximum value (e.g. a latitude of 90 degrees) is clamped to fit.
+func putInt24(b []byte, v int32) {
+	if v > 1<<23-1 {
+		v = 1<<23 - 1
+	}
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+}
+
+// appendCRC appends the CRC-16 (CCITT) of the given bytes, little-endian
+// encoded.
+func appendCRC(b []byte) []byte {
+	crc := crc16(b)
+	return append(b, byte(crc), byte(crc>>8))
+}
+
+func crc16(b []byte) uint16 {
+	var crc uint16
+	for _, v := range b {
+		crc ^= uint16(v) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc = crc << 1
+			}
+		}
+	}
+	return crc
+}
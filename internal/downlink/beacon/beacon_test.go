@@ -0,0 +1,76 @@
+package beacon
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+)
+
+func TestCRC16(t *testing.T) {
+	assert := require.New(t)
+	assert.Equal(uint16(0x31c3), crc16([]byte("123456789")))
+}
+
+func TestGetBeaconPayload(t *testing.T) {
+	beaconTime := 1234567936 * time.Second
+
+	tests := []struct {
+		Name     string
+		Params   Parameters
+		Location storage.GPSPoint
+		Expected []byte
+	}{
+		{
+			Name:   "EU868",
+			Params: bandParameters["EU868"],
+			Location: storage.GPSPoint{
+				Latitude:  45,
+				Longitude: -90,
+			},
+			Expected: []byte{
+				0x00, 0x00, // RFU
+				0x00, 0x03, 0x96, 0x49, // Time
+				0x00, 0x00, // CRC (set below)
+				0x00,             // InfoDesc
+				0x00, 0x00, 0x40, // Lat
+				0x00, 0x00, 0xc0, // Lng
+				0x00, 0x00, // CRC (set below)
+			},
+		},
+		{
+			Name:   "US915",
+			Params: bandParameters["US915"],
+			Location: storage.GPSPoint{
+				Latitude:  -90,
+				Longitude: 180,
+			},
+			Expected: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, // RFU
+				0x00, 0x03, 0x96, 0x49, // Time
+				0x00, 0x00, // CRC (set below)
+				0x00,             // InfoDesc
+				0x00, 0x00, 0x80, // Lat
+				0xff, 0xff, 0x7f, // Lng
+				0x00, 0x00, 0x00, // RFU
+				0x00, 0x00, // CRC (set below)
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			crc1Pos := tst.Params.RFU1Size + 4
+			binary.LittleEndian.PutUint16(tst.Expected[crc1Pos:], crc16(tst.Expected[:crc1Pos]))
+			crc2Pos := len(tst.Expected) - 2
+			binary.LittleEndian.PutUint16(tst.Expected[crc2Pos:], crc16(tst.Expected[crc1Pos+2:crc2Pos]))
+
+			assert.Equal(tst.Expected, GetBeaconPayload(tst.Params, beaconTime, tst.Location))
+		})
+	}
+}
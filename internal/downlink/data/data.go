@@ -16,6 +16,8 @@ import (
 	"github.com/brocaar/chirpstack-network-server/internal/band"
 	"github.com/brocaar/chirpstack-network-server/internal/channels"
 	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/dutycycle"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/txschedule"
 	"github.com/brocaar/chirpstack-network-server/internal/framelog"
 	"github.com/brocaar/chirpstack-network-server/internal/helpers"
 	"github.com/brocaar/chirpstack-network-server/internal/logging"
@@ -23,6 +25,7 @@ import (
 	"github.com/brocaar/chirpstack-network-server/internal/models"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
 	loraband "github.com/brocaar/lorawan/band"
 )
 
@@ -86,6 +89,7 @@ var setMACCommandsSet = setMACCommands(
 	setPingSlotParameters,
 	setRXParameters,
 	setTXParameters,
+	requestDutyCycle,
 	getMACCommandsFromQueue,
 )
 
@@ -94,6 +98,7 @@ var responseTasks = []func(*dataContext) error{
 	getServiceProfile,
 	setDeviceGatewayRXInfo,
 	setDataTXInfo,
+	checkGatewayAvailability,
 	setToken,
 	getNextDeviceQueueItem,
 	setMACCommandsSet,
@@ -119,6 +124,7 @@ var scheduleNextQueueItemTasks = []func(*dataContext) error{
 	forClass(storage.DeviceModeA,
 		returnInvalidDeviceClassError,
 	),
+	checkGatewayAvailability,
 	setToken,
 	getNextDeviceQueueItem,
 	setMACCommandsSet,
@@ -208,6 +214,9 @@ type dataContext struct {
 	// value other than 0.
 	Data []byte
 
+	// FCnt contains the downlink frame-counter used for the PHYPayloads.
+	FCnt uint32
+
 	// RXPacket holds the received uplink packet (in case of Class-A downlink).
 	RXPacket *models.RXPacket
 
@@ -223,6 +232,11 @@ type dataContext struct {
 	// Only the first item will be emitted, the other(s) will be enqueued
 	// and emitted on a scheduling error.
 	DownlinkFrames []downlinkFrame
+
+	// RoamingNetID and RoamingULMetaData are set when responding to an
+	// uplink received from the fNS of a roaming partner (passive-roaming).
+	RoamingNetID      lorawan.NetID
+	RoamingULMetaData *backend.ULMetaData
 }
 
 type downlinkFrame struct {
@@ -405,6 +419,16 @@ func setTXParameters(ctx *dataContext) error {
 	return nil
 }
 
+func requestDutyCycle(ctx *dataContext) error {
+	maxDCycle := maccommand.GetMaxDCycle(ctx.DeviceProfile.MaxDutyCycle)
+	if ctx.DeviceSession.MaxDCycle != maxDCycle {
+		block := maccommand.RequestDutyCycle(maxDCycle)
+		ctx.MACCommands = append(ctx.MACCommands, block)
+	}
+
+	return nil
+}
+
 func setDataTXInfo(ctx *dataContext) error {
 	if rxWindow == 0 || rxWindow == 1 {
 		if err := setTXInfoForRX1(ctx); err != nil {
@@ -595,6 +619,78 @@ func setTXInfoForClassB(ctx *dataContext) error {
 	return nil
 }
 
+// checkGatewayAvailability validates that the downlink frames can be
+// transmitted without exceeding the duty-cycle limitations of the gateway
+// and without overlapping with an emission already scheduled for the gateway.
+// In case it can't, it tries to use one of the other gateways within reach
+// of the device. Downlink frames (RX windows) for which no gateway could be
+// found are removed. As the PHYPayload has not yet been set, the max.
+// PHYPayload size for the data-rate is used.
+func checkGatewayAvailability(ctx *dataContext) error {
+	var downlinkFrames []downlinkFrame
+
+	for _, df := range ctx.DownlinkFrames {
+		// MHDR (1) + FHDR (7) + FPort (1) + MIC (4)
+		size := df.RemainingPayloadSize + 13
+
+		ok, err := setAvailableGateway(ctx, df.DownlinkFrame.TxInfo, size)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			downlinkFrames = append(downlinkFrames, df)
+		}
+	}
+
+	if len(downlinkFrames) == 0 {
+		log.WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("no gateway available within duty-cycle limits and tx schedule, skipping downlink")
+		return ErrAbort
+	}
+
+	ctx.DownlinkFrames = downlinkFrames
+
+	return nil
+}
+
+// setAvailableGateway sets the first gateway (ordered by the
+// DeviceGatewayRXInfo) to the tx-info that is able to transmit within the
+// duty-cycle limitations and tx schedule. It returns false when no gateway
+// could be found.
+func setAvailableGateway(ctx *dataContext, txInfo *gw.DownlinkTXInfo, size int) (bool, error) {
+	for i := range ctx.DeviceGatewayRXInfo {
+		rxInfo := ctx.DeviceGatewayRXInfo[i]
+
+		txInfo.GatewayId = rxInfo.GatewayID[:]
+		txInfo.Board = rxInfo.Board
+		txInfo.Antenna = rxInfo.Antenna
+		txInfo.Context = rxInfo.Context
+
+		ok, err := dutycycle.CanTransmit(ctx.ctx, txInfo, size)
+		if err != nil {
+			return false, errors.Wrap(err, "check duty-cycle error")
+		}
+
+		if !ok {
+			continue
+		}
+
+		ok, err = txschedule.CanSchedule(ctx.ctx, txInfo, size)
+		if err != nil {
+			return false, errors.Wrap(err, "check tx schedule error")
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func getNextDeviceQueueItem(ctx *dataContext) error {
 	var fCnt uint32
 	if ctx.DeviceSession.GetMACVersion() == lorawan.LoRaWAN1_0 {
@@ -618,6 +714,24 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 		return errors.Wrap(err, "get next device-queue item for max payload error")
 	}
 
+	// With the Mark rate-policy, the queue-item is held back (it stays in
+	// the queue) until the downlink rate-limit allows it to be sent. With
+	// the Drop rate-policy, the rate-limit is enforced on enqueue.
+	if ctx.ServiceProfile.DLRatePolicy == storage.Mark {
+		ok, err := storage.TakeDownlinkRateLimitToken(ctx.ctx, storage.RedisPool(), ctx.ServiceProfile, ctx.DeviceSession.DevEUI)
+		if err != nil {
+			return errors.Wrap(err, "take downlink rate-limit token error")
+		}
+		if !ok {
+			log.WithFields(log.Fields{
+				"dev_eui": ctx.DeviceSession.DevEUI,
+				"f_cnt":   qi.FCnt,
+				"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+			}).Warning("downlink rate-limit exceeded, holding back device-queue item")
+			return nil
+		}
+	}
+
 	ctx.Confirmed = qi.Confirmed
 	ctx.Data = qi.FRMPayload
 	ctx.FPort = qi.FPort
@@ -659,6 +773,32 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 			}
 			ctx.DownlinkFrames[0].DownlinkFrame.TxInfo.Frequency = uint32(freq)
 		}
+
+		// As the ping-slot timing is only known at this point, the gateway
+		// availability must be re-validated. The queue-item is kept in the
+		// queue in case no gateway is available.
+		size := ctx.DownlinkFrames[0].RemainingPayloadSize + len(ctx.Data) + 13
+		ok, err := setAvailableGateway(ctx, ctx.DownlinkFrames[0].DownlinkFrame.TxInfo, size)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			log.WithFields(log.Fields{
+				"dev_eui": ctx.DeviceSession.DevEUI,
+				"f_cnt":   qi.FCnt,
+				"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+			}).Warning("no gateway available within duty-cycle limits and tx schedule for ping-slot, skipping downlink")
+
+			// the queue-item is kept in the queue, return the rate-limit
+			// token taken above
+			if ctx.ServiceProfile.DLRatePolicy == storage.Mark {
+				if err := storage.ReturnDownlinkRateLimitToken(ctx.ctx, storage.RedisPool(), ctx.ServiceProfile, ctx.DeviceSession.DevEUI); err != nil {
+					return errors.Wrap(err, "return downlink rate-limit token error")
+				}
+			}
+
+			return ErrAbort
+		}
 	}
 
 	if !qi.Confirmed {
@@ -817,7 +957,7 @@ func requestCustomChannelReconfiguration(ctx *dataContext) error {
 func requestChannelMaskReconfiguration(ctx *dataContext) error {
 	// handle channel configuration
 	// note that this must come before ADR!
-	blocks, err := channels.HandleChannelReconfigure(ctx.DeviceSession)
+	blocks, err := channels.HandleChannelReconfigure(ctx.ctx, ctx.ServiceProfile, ctx.DeviceSession)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"dev_eui": ctx.DeviceSession.DevEUI,
@@ -838,7 +978,7 @@ func requestADRChange(ctx *dataContext) error {
 		}
 	}
 
-	blocks, err := adr.HandleADR(ctx.ctx, ctx.ServiceProfile, ctx.DeviceSession, linkADRReq)
+	blocks, err := adr.HandleADR(ctx.ctx, ctx.DeviceProfile, ctx.ServiceProfile, ctx.DeviceSession, linkADRReq)
 	if err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"dev_eui": ctx.DeviceSession.DevEUI,
@@ -888,6 +1028,7 @@ func setPHYPayloads(ctx *dataContext) error {
 		fCnt = ctx.DeviceSession.AFCntDown
 		ctx.DeviceSession.AFCntDown++
 	}
+	ctx.FCnt = fCnt
 
 	for i := range ctx.DownlinkFrames {
 		// LoRaWAN MAC payload
@@ -1000,6 +1141,20 @@ func sendDownlinkFrame(ctx *dataContext) error {
 	// set last downlink tx timestamp
 	ctx.DeviceSession.LastDownlinkTX = time.Now()
 
+	// save the used airtime for duty-cycle accounting
+	if err := dutycycle.SaveAirtime(ctx.ctx, ctx.DownlinkFrames[0].DownlinkFrame); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("save airtime error")
+	}
+
+	// save the emission in the gateway tx schedule
+	if err := txschedule.SaveScheduleItem(ctx.ctx, ctx.DownlinkFrames[0].DownlinkFrame); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("save tx schedule item error")
+	}
+
 	// log for gateway (with encrypted mac-commands)
 	if err := framelog.LogDownlinkFrameForGateway(ctx.ctx, storage.RedisPool(), ctx.DownlinkFrames[0].DownlinkFrame); err != nil {
 		log.WithError(err).WithFields(log.Fields{
@@ -1126,6 +1281,7 @@ func checkLastDownlinkTimestamp(ctx *dataContext) error {
 func saveFrames(ctx *dataContext) error {
 	df := storage.DownlinkFrames{
 		DevEui: ctx.DeviceSession.DevEUI[:],
+		FCnt:   ctx.FCnt,
 	}
 
 	for i := range ctx.DownlinkFrames {
@@ -351,6 +351,88 @@ func (ts *SetMACCommandsSetTestSuite) TestSetMACCommandsSet() {
 				},
 			},
 		},
+		{
+			Name: "channel-reconfiguration and adr request change with service-profile channel-mask",
+			DataContext: dataContext{
+				ServiceProfile: storage.ServiceProfile{
+					DRMax:       5,
+					ChannelMask: []byte{0x03},
+				},
+				DeviceSession: storage.DeviceSession{
+					ADR:                   true,
+					DR:                    0,
+					EnabledUplinkChannels: []int{0, 1},
+					UplinkHistory: []storage.UplinkHistory{
+						{FCnt: 0, MaxSNR: 5, TXPowerIndex: 0, GatewayCount: 1},
+					},
+					RX2Frequency: 869525000,
+				},
+				DownlinkFrames: []downlinkFrame{
+					{
+						RemainingPayloadSize: 200,
+					},
+				},
+			},
+			ExpectedMACCommands: []storage.MACCommandBlock{
+				{
+					CID: lorawan.LinkADRReq,
+					MACCommands: storage.MACCommands{
+						{
+							CID: lorawan.LinkADRReq,
+							Payload: &lorawan.LinkADRReqPayload{
+								DataRate: 5,
+								TXPower:  3,
+								ChMask:   [16]bool{true, true},
+								Redundancy: lorawan.Redundancy{
+									NbRep: 1,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "channel-reconfiguration with service-profile channel-mask followed by adr request change",
+			DataContext: dataContext{
+				ServiceProfile: storage.ServiceProfile{
+					DRMax:       5,
+					ChannelMask: []byte{0x03},
+				},
+				DeviceSession: storage.DeviceSession{
+					ADR:                   true,
+					DR:                    0,
+					EnabledUplinkChannels: []int{0, 1, 2},
+					UplinkHistory: []storage.UplinkHistory{
+						{FCnt: 0, MaxSNR: 5, TXPowerIndex: 0, GatewayCount: 1},
+					},
+					RX2Frequency: 869525000,
+				},
+				DownlinkFrames: []downlinkFrame{
+					{
+						RemainingPayloadSize: 200,
+					},
+				},
+			},
+			ExpectedMACCommands: []storage.MACCommandBlock{
+				{
+					CID: lorawan.LinkADRReq,
+					MACCommands: storage.MACCommands{
+						{
+							CID: lorawan.LinkADRReq,
+							Payload: &lorawan.LinkADRReqPayload{
+								DataRate: 5,
+								TXPower:  3,
+								ChMask:   [16]bool{true, true},
+								Redundancy: lorawan.Redundancy{
+									NbRep: 1,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "request device-status",
 			DataContext: dataContext{
@@ -0,0 +1,164 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/chirpstack-network-server/internal/models"
+	"github.com/brocaar/chirpstack-network-server/internal/roaming"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// roamingResponseTasks contains the tasks for responding to an uplink
+// received from the fNS of a roaming partner (passive-roaming). As the
+// gateways are managed by the fNS, the gateway duty-cycle and tx schedule
+// checks are omitted and the downlink is sent to the fNS using a
+// XmitDataReq.
+var roamingResponseTasks = []func(*dataContext) error{
+	getDeviceProfile,
+	getServiceProfile,
+	setDeviceGatewayRXInfo,
+	setDataTXInfo,
+	setToken,
+	getNextDeviceQueueItem,
+	setMACCommandsSet,
+	stopOnNothingToSend,
+	setPHYPayloads,
+	sendDownlinkFrameToRoamingFNS,
+	saveDeviceSession,
+}
+
+// HandleRoamingResponse handles a downlink response for an uplink received
+// from the fNS with the given NetID. The ULMetaData must contain the
+// meta-data as received from the fNS, as the ULToken of each gateway is
+// used by the fNS to route the downlink.
+func HandleRoamingResponse(ctx context.Context, netID lorawan.NetID, ulMetaData backend.ULMetaData, rxPacket models.RXPacket, sp storage.ServiceProfile, ds storage.DeviceSession, adr, mustSend, ack bool, macCommands []storage.MACCommandBlock) error {
+	rctx := dataContext{
+		ctx:               ctx,
+		ServiceProfile:    sp,
+		DeviceSession:     ds,
+		ACK:               ack,
+		MustSend:          mustSend,
+		RXPacket:          &rxPacket,
+		MACCommands:       macCommands,
+		RoamingNetID:      netID,
+		RoamingULMetaData: &ulMetaData,
+	}
+
+	for _, t := range roamingResponseTasks {
+		if err := t(&rctx); err != nil {
+			if err == ErrAbort {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sendDownlinkFrameToRoamingFNS(ctx *dataContext) error {
+	if len(ctx.DownlinkFrames) == 0 || ctx.RoamingULMetaData == nil {
+		return nil
+	}
+
+	client, err := roaming.GetClientForNetID(ctx.RoamingNetID)
+	if err != nil {
+		return errors.Wrap(err, "get roaming client error")
+	}
+
+	dlMetaData, err := getRoamingDLMetaData(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get downlink meta-data error")
+	}
+
+	if _, err := client.XmitDataReq(ctx.ctx, backend.XmitDataReqPayload{
+		PHYPayload: backend.HEXBytes(ctx.DownlinkFrames[0].DownlinkFrame.PhyPayload),
+		DLMetaData: &dlMetaData,
+	}); err != nil {
+		return errors.Wrap(err, "xmit data request error")
+	}
+
+	// set last downlink tx timestamp
+	ctx.DeviceSession.LastDownlinkTX = time.Now()
+
+	log.WithFields(log.Fields{
+		"net_id":  ctx.RoamingNetID,
+		"dev_eui": ctx.DeviceSession.DevEUI,
+		"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+	}).Info("downlink/data: downlink sent to roaming fns")
+
+	return nil
+}
+
+// getRoamingDLMetaData returns the DLMetaData for the downlink frames. As the
+// XmitDataReq contains a single PHYPayload, the RX2 parameters are only
+// included when the RX2 frame contains the same PHYPayload as the first
+// frame (the PHYPayload can differ because of the max. payload-size).
+func getRoamingDLMetaData(ctx *dataContext) (backend.DLMetaData, error) {
+	classMode := "A"
+	rxDelay1 := int(ctx.DeviceSession.RXDelay)
+	if rxDelay1 == 0 {
+		rxDelay1 = int(band.Band().GetDefaults().ReceiveDelay1 / time.Second)
+	}
+
+	md := backend.DLMetaData{
+		DevEUI:    &ctx.DeviceSession.DevEUI,
+		FCntDown:  &ctx.FCnt,
+		Confirmed: ctx.Confirmed,
+		ClassMode: &classMode,
+		RXDelay1:  &rxDelay1,
+	}
+
+	if ctx.FPort > 0 {
+		md.FPort = &ctx.FPort
+	}
+
+	for i, df := range ctx.DownlinkFrames {
+		if !bytes.Equal(df.DownlinkFrame.PhyPayload, ctx.DownlinkFrames[0].DownlinkFrame.PhyPayload) {
+			continue
+		}
+
+		dr, err := helpers.GetDataRateIndex(false, df.DownlinkFrame.TxInfo, band.Band())
+		if err != nil {
+			return md, errors.Wrap(err, "get data-rate index error")
+		}
+		freq := float64(df.DownlinkFrame.TxInfo.Frequency) / 1000000
+
+		// setDataTXInfo adds the RX1 frame (when enabled) before the RX2 frame
+		if i == 0 && rxWindow != 2 {
+			md.DLFreq1 = &freq
+			md.DataRate1 = &dr
+		} else {
+			md.DLFreq2 = &freq
+			md.DataRate2 = &dr
+		}
+	}
+
+	for _, gwInfo := range ctx.RoamingULMetaData.GWInfo {
+		if !gwInfo.DLAllowed || len(gwInfo.ULToken) == 0 {
+			continue
+		}
+
+		md.GWInfo = append(md.GWInfo, backend.GWInfoElement{
+			ID:      gwInfo.ID,
+			ULToken: gwInfo.ULToken,
+		})
+	}
+
+	if len(md.GWInfo) == 0 {
+		return md, errors.New("no gateway available for downlink")
+	}
+
+	return md, nil
+}
@@ -6,10 +6,13 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/beacon"
 	"github.com/brocaar/chirpstack-network-server/internal/downlink/data"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/dutycycle"
 	"github.com/brocaar/chirpstack-network-server/internal/downlink/join"
 	"github.com/brocaar/chirpstack-network-server/internal/downlink/multicast"
 	"github.com/brocaar/chirpstack-network-server/internal/downlink/proprietary"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/txschedule"
 )
 
 var (
@@ -22,6 +25,14 @@ func Setup(conf config.Config) error {
 	nsConfig := conf.NetworkServer
 	schedulerInterval = nsConfig.Scheduler.SchedulerInterval
 
+	if err := dutycycle.Setup(conf); err != nil {
+		return errors.Wrap(err, "setup downlink/dutycycle error")
+	}
+
+	if err := txschedule.Setup(conf); err != nil {
+		return errors.Wrap(err, "setup downlink/txschedule error")
+	}
+
 	if err := data.Setup(conf); err != nil {
 		return errors.Wrap(err, "setup downlink/data error")
 	}
@@ -38,5 +49,9 @@ func Setup(conf config.Config) error {
 		return errors.Wrap(err, "setup downlink/proprietary error")
 	}
 
+	if err := beacon.Setup(conf); err != nil {
+		return errors.Wrap(err, "setup downlink/beacon error")
+	}
+
 	return nil
 }
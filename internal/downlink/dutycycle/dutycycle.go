@@ -0,0 +1,185 @@
+// Package dutycycle implements the airtime accounting of the gateways, so
+// that downlink transmissions do not exceed the regulatory duty-cycle limits.
+package dutycycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan/airtime"
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+// SubBand defines a frequency range with its duty-cycle limitation.
+type SubBand struct {
+	Name         string
+	MinFrequency int
+	MaxFrequency int
+	// DutyCycle in percent.
+	DutyCycle float64
+}
+
+// etsiSubBands contains the ETSI EN 300 220 sub-bands (as defined by the
+// ERC Recommendation 70-03, annex 1) in the 863 - 870MHz range.
+var etsiSubBands = []SubBand{
+	{Name: "h1.2", MinFrequency: 863000000, MaxFrequency: 865000000, DutyCycle: 0.1},
+	{Name: "h1.3", MinFrequency: 865000000, MaxFrequency: 868000000, DutyCycle: 1},
+	{Name: "h1.4", MinFrequency: 868000000, MaxFrequency: 868600000, DutyCycle: 1},
+	{Name: "h1.5", MinFrequency: 868700000, MaxFrequency: 869200000, DutyCycle: 0.1},
+	{Name: "h1.6", MinFrequency: 869400000, MaxFrequency: 869650000, DutyCycle: 10},
+	{Name: "h1.7", MinFrequency: 869700000, MaxFrequency: 870000000, DutyCycle: 1},
+}
+
+// LoRa and FSK frame parameters used for the time-on-air calculation.
+const (
+	loraPreambleNumber = 8
+	fskPreambleBytes   = 5
+	fskSyncWordBytes   = 3
+	fskLengthBytes     = 1
+	fskCRCBytes        = 2
+)
+
+var (
+	enabled  bool
+	window   time.Duration
+	subBands []SubBand
+)
+
+// Setup configures the duty-cycle package.
+func Setup(conf config.Config) error {
+	enabled = conf.NetworkServer.NetworkSettings.DutyCycle.Enabled
+	window = conf.NetworkServer.NetworkSettings.DutyCycle.Window
+	subBands = nil
+
+	if !enabled {
+		return nil
+	}
+
+	if window <= 0 {
+		return errors.New("duty-cycle window must be greater than 0")
+	}
+
+	switch conf.NetworkServer.Band.Name {
+	case loraband.EU_863_870, loraband.EU868:
+		subBands = etsiSubBands
+	default:
+		log.WithField("band", conf.NetworkServer.Band.Name).Warning("dutycycle: no duty-cycle sub-bands defined for band, airtime will not be limited")
+	}
+
+	return nil
+}
+
+// GetSubBand returns the sub-band for the given frequency. It returns false
+// when the frequency is not within a sub-band with a duty-cycle limitation.
+func GetSubBand(freq int) (SubBand, bool) {
+	for _, sb := range subBands {
+		if freq >= sb.MinFrequency && freq < sb.MaxFrequency {
+			return sb, true
+		}
+	}
+
+	return SubBand{}, false
+}
+
+// GetAirtime returns the time-on-air for transmitting a PHYPayload of the
+// given size, using the data-rate of the given tx-info.
+func GetAirtime(txInfo *gw.DownlinkTXInfo, size int) (time.Duration, error) {
+	drIndex, err := helpers.GetDataRateIndex(false, txInfo, band.Band())
+	if err != nil {
+		return 0, errors.Wrap(err, "get data-rate index error")
+	}
+
+	dr, err := band.Band().GetDataRate(drIndex)
+	if err != nil {
+		return 0, errors.Wrap(err, "get data-rate error")
+	}
+
+	switch dr.Modulation {
+	case loraband.LoRaModulation:
+		lowDataRateOptimization := dr.Bandwidth == 125 && dr.SpreadFactor >= 11
+		return airtime.CalculateLoRaAirtime(size, dr.SpreadFactor, dr.Bandwidth, loraPreambleNumber, airtime.CodingRate45, true, lowDataRateOptimization)
+	case loraband.FSKModulation:
+		if dr.BitRate == 0 {
+			return 0, errors.New("bit-rate must not be 0")
+		}
+		bits := (fskPreambleBytes + fskSyncWordBytes + fskLengthBytes + size + fskCRCBytes) * 8
+		return time.Duration(bits) * time.Second / time.Duration(dr.BitRate), nil
+	default:
+		return 0, fmt.Errorf("unknown modulation: %s", dr.Modulation)
+	}
+}
+
+// CanTransmit returns if the gateway of the given tx-info is able to
+// transmit a PHYPayload of the given size, without exceeding the duty-cycle
+// limitation of the sub-band over the configured window.
+func CanTransmit(ctx context.Context, txInfo *gw.DownlinkTXInfo, size int) (bool, error) {
+	if !enabled {
+		return true, nil
+	}
+
+	sb, ok := GetSubBand(int(txInfo.Frequency))
+	if !ok {
+		return true, nil
+	}
+
+	toa, err := GetAirtime(txInfo, size)
+	if err != nil {
+		return false, errors.Wrap(err, "get airtime error")
+	}
+
+	gatewayID := helpers.GetGatewayID(txInfo)
+	used, err := storage.GetGatewayAirtime(ctx, storage.RedisPool(), gatewayID, sb.Name, window)
+	if err != nil {
+		return false, errors.Wrap(err, "get gateway airtime error")
+	}
+
+	max := time.Duration(float64(window) * sb.DutyCycle / 100)
+	if used+toa > max {
+		log.WithFields(log.Fields{
+			"gateway_id":   gatewayID,
+			"sub_band":     sb.Name,
+			"frequency":    txInfo.Frequency,
+			"airtime":      toa,
+			"airtime_used": used,
+			"airtime_max":  max,
+			"ctx_id":       ctx.Value(logging.ContextIDKey),
+		}).Info("dutycycle: transmission would exceed duty-cycle limit")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// SaveAirtime stores the airtime used by transmitting the given downlink
+// frame, for the gateway and sub-band of its tx-info.
+func SaveAirtime(ctx context.Context, df gw.DownlinkFrame) error {
+	if !enabled || df.TxInfo == nil {
+		return nil
+	}
+
+	sb, ok := GetSubBand(int(df.TxInfo.Frequency))
+	if !ok {
+		return nil
+	}
+
+	toa, err := GetAirtime(df.TxInfo, len(df.PhyPayload))
+	if err != nil {
+		return errors.Wrap(err, "get airtime error")
+	}
+
+	if err := storage.SaveGatewayAirtime(ctx, storage.RedisPool(), helpers.GetGatewayID(df.TxInfo), sb.Name, time.Now(), toa, window); err != nil {
+		return errors.Wrap(err, "save gateway airtime error")
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package dutycycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+)
+
+func TestGetSubBand(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	conf.NetworkServer.NetworkSettings.DutyCycle.Enabled = true
+	conf.NetworkServer.NetworkSettings.DutyCycle.Window = time.Hour
+	assert.NoError(Setup(conf))
+
+	tests := []struct {
+		Frequency       int
+		ExpectedSubBand string
+		ExpectedOK      bool
+	}{
+		{868100000, "h1.4", true},
+		{867100000, "h1.3", true},
+		{869525000, "h1.6", true},
+		{868650000, "", false},
+	}
+
+	for _, tst := range tests {
+		sb, ok := GetSubBand(tst.Frequency)
+		assert.Equal(tst.ExpectedOK, ok, "frequency: %d", tst.Frequency)
+		assert.Equal(tst.ExpectedSubBand, sb.Name, "frequency: %d", tst.Frequency)
+	}
+}
+
+func TestGetAirtime(t *testing.T) {
+	test.GetConfig()
+
+	tests := []struct {
+		Name            string
+		DR              int
+		Size            int
+		ExpectedAirtime time.Duration
+	}{
+		{
+			Name:            "SF7 / 125kHz",
+			DR:              5,
+			Size:            13,
+			ExpectedAirtime: 46336 * time.Microsecond,
+		},
+		{
+			Name:            "SF12 / 125kHz",
+			DR:              0,
+			Size:            13,
+			ExpectedAirtime: 1155072 * time.Microsecond,
+		},
+		{
+			Name:            "FSK 50kbps",
+			DR:              7,
+			Size:            13,
+			ExpectedAirtime: 3840 * time.Microsecond,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var txInfo gw.DownlinkTXInfo
+			assert.NoError(helpers.SetDownlinkTXInfoDataRate(&txInfo, tst.DR, band.Band()))
+
+			toa, err := GetAirtime(&txInfo, tst.Size)
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedAirtime, toa)
+		})
+	}
+}
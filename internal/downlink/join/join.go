@@ -14,6 +14,8 @@ import (
 	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
 	"github.com/brocaar/chirpstack-network-server/internal/band"
 	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/dutycycle"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/txschedule"
 	"github.com/brocaar/chirpstack-network-server/internal/framelog"
 	"github.com/brocaar/chirpstack-network-server/internal/helpers"
 	"github.com/brocaar/chirpstack-network-server/internal/logging"
@@ -32,6 +34,7 @@ var tasks = []func(*joinContext) error{
 	setTXInfo,
 	setToken,
 	setDownlinkFrame,
+	checkGatewayAvailability,
 	sendJoinAcceptResponse,
 	saveFrames,
 }
@@ -237,6 +240,73 @@ func setDownlinkFrame(ctx *joinContext) error {
 	return nil
 }
 
+// checkGatewayAvailability validates that the downlink frames can be
+// transmitted without exceeding the duty-cycle limitations of the gateway
+// and without overlapping with an emission already scheduled for the gateway.
+// In case it can't, it tries to use one of the other gateways that received
+// the join-request. Downlink frames (RX windows) for which no gateway could
+// be found are removed.
+func checkGatewayAvailability(ctx *joinContext) error {
+	var downlinkFrames []gw.DownlinkFrame
+
+	for _, df := range ctx.DownlinkFrames {
+		ok, err := setAvailableGateway(ctx, df.TxInfo, len(df.PhyPayload))
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			downlinkFrames = append(downlinkFrames, df)
+		}
+	}
+
+	if len(downlinkFrames) == 0 {
+		log.WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("no gateway available within duty-cycle limits and tx schedule, skipping join-accept")
+	}
+
+	ctx.DownlinkFrames = downlinkFrames
+
+	return nil
+}
+
+// setAvailableGateway sets the first gateway (ordered by the
+// DeviceGatewayRXInfo) to the tx-info that is able to transmit within the
+// duty-cycle limitations and tx schedule. It returns false when no gateway
+// could be found.
+func setAvailableGateway(ctx *joinContext, txInfo *gw.DownlinkTXInfo, size int) (bool, error) {
+	for i := range ctx.DeviceGatewayRXInfo {
+		rxInfo := ctx.DeviceGatewayRXInfo[i]
+
+		txInfo.GatewayId = rxInfo.GatewayID[:]
+		txInfo.Board = rxInfo.Board
+		txInfo.Antenna = rxInfo.Antenna
+		txInfo.Context = rxInfo.Context
+
+		ok, err := dutycycle.CanTransmit(ctx.ctx, txInfo, size)
+		if err != nil {
+			return false, errors.Wrap(err, "check duty-cycle error")
+		}
+
+		if !ok {
+			continue
+		}
+
+		ok, err = txschedule.CanSchedule(ctx.ctx, txInfo, size)
+		if err != nil {
+			return false, errors.Wrap(err, "check tx schedule error")
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func sendJoinAcceptResponse(ctx *joinContext) error {
 	if len(ctx.DownlinkFrames) == 0 {
 		return nil
@@ -247,6 +317,16 @@ func sendJoinAcceptResponse(ctx *joinContext) error {
 		return errors.Wrap(err, "send downlink frame error")
 	}
 
+	// save the used airtime for duty-cycle accounting
+	if err := dutycycle.SaveAirtime(ctx.ctx, ctx.DownlinkFrames[0]); err != nil {
+		log.WithError(err).Error("save airtime error")
+	}
+
+	// save the emission in the gateway tx schedule
+	if err := txschedule.SaveScheduleItem(ctx.ctx, ctx.DownlinkFrames[0]); err != nil {
+		log.WithError(err).Error("save tx schedule item error")
+	}
+
 	// log frame
 	if err := framelog.LogDownlinkFrameForGateway(ctx.ctx, storage.RedisPool(), ctx.DownlinkFrames[0]); err != nil {
 		log.WithError(err).Error("log downlink frame for gateway error")
@@ -260,6 +340,10 @@ func sendJoinAcceptResponse(ctx *joinContext) error {
 }
 
 func saveFrames(ctx *joinContext) error {
+	if len(ctx.DownlinkFrames) == 0 {
+		return nil
+	}
+
 	df := storage.DownlinkFrames{
 		DevEui: ctx.DeviceSession.DevEUI[:],
 	}
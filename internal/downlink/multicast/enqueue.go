@@ -83,8 +83,12 @@ func EnqueueQueueItem(ctx context.Context, p *redis.Pool, db sqlx.Ext, qi storag
 			return errors.Wrap(err, "get maximum emit at time since gps epoch error")
 		}
 
-		if scheduleTS == 0 {
-			scheduleTS = gps.Time(time.Now().Add(classBEnqueueMargin)).TimeSinceGPSEpoch()
+		// the queue might contain items of which the ping-slot is already
+		// in the past (e.g. when the scheduler is lagging behind), never
+		// schedule before the enqueue margin
+		minScheduleTS := gps.Time(time.Now().Add(classBEnqueueMargin)).TimeSinceGPSEpoch()
+		if scheduleTS < minScheduleTS {
+			scheduleTS = minScheduleTS
 		}
 
 		for _, gatewayID := range gatewayIDs {
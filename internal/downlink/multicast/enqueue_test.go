@@ -4,6 +4,7 @@ import (
 	"context"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -11,6 +12,7 @@ import (
 	"github.com/brocaar/lorawan"
 
 	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/gps"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 	"github.com/brocaar/chirpstack-network-server/internal/test"
 )
@@ -179,6 +181,44 @@ func (ts *EnqueueQueueItemTestCase) TestClassB() {
 	assert.Equal(qi.FCnt+1, mg.FCnt)
 }
 
+func (ts *EnqueueQueueItemTestCase) TestClassBQueueItemInPast() {
+	assert := require.New(ts.T())
+
+	ts.MulticastGroup.PingSlotPeriod = 16
+	ts.MulticastGroup.GroupType = storage.MulticastGroupB
+	assert.NoError(storage.UpdateMulticastGroup(context.Background(), ts.tx, &ts.MulticastGroup))
+
+	// queue-item of which the ping-slot is in the past
+	emitAt := gps.Time(time.Now().Add(-time.Minute)).TimeSinceGPSEpoch()
+	assert.NoError(storage.CreateMulticastQueueItem(context.Background(), ts.tx, &storage.MulticastQueueItem{
+		ScheduleAt:              time.Now().Add(-time.Minute),
+		EmitAtTimeSinceGPSEpoch: &emitAt,
+		MulticastGroupID:        ts.MulticastGroup.ID,
+		GatewayID:               ts.Gateways[0].GatewayID,
+		FCnt:                    10,
+		FPort:                   2,
+		FRMPayload:              []byte{1, 2, 3, 4},
+	}))
+
+	qi := storage.MulticastQueueItem{
+		MulticastGroupID: ts.MulticastGroup.ID,
+		FCnt:             11,
+		FPort:            2,
+		FRMPayload:       []byte{1, 2, 3, 4},
+	}
+	assert.NoError(EnqueueQueueItem(context.Background(), storage.RedisPool(), ts.tx, qi))
+
+	items, err := storage.GetMulticastQueueItemsForMulticastGroup(context.Background(), ts.tx, ts.MulticastGroup.ID)
+	assert.NoError(err)
+	assert.Len(items, 3)
+
+	now := gps.Time(time.Now()).TimeSinceGPSEpoch()
+	for _, item := range items[1:] {
+		assert.NotNil(item.EmitAtTimeSinceGPSEpoch)
+		assert.True(*item.EmitAtTimeSinceGPSEpoch > now)
+	}
+}
+
 func TestEnqueueQueueItem(t *testing.T) {
 	suite.Run(t, new(EnqueueQueueItemTestCase))
 }
@@ -4,5 +4,6 @@ import "github.com/pkg/errors"
 
 // Errors
 var (
-	ErrInvalidFCnt = errors.New("invalid frame-counter value")
+	ErrInvalidFCnt  = errors.New("invalid frame-counter value")
+	ErrFragmentSize = errors.New("fragment exceeds max payload-size for data-rate")
 )
@@ -0,0 +1,288 @@
+package multicast
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/gomodule/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/applayer/fragmentation"
+)
+
+// FragmentationFPort defines the FPort used by the LoRaWAN Fragmented Data
+// Block Transport package.
+const FragmentationFPort = fragmentation.DefaultFPort
+
+// maxFragIndex defines the max. fragmentation session index.
+const maxFragIndex = 3
+
+// maxMcGroupID defines the max. multicast-group id on the device.
+const maxMcGroupID = 3
+
+// maxNbFrag defines the max. number of fragments (including the redundancy
+// fragments) within a fragmentation session, as N is a 14 bit field.
+const maxNbFrag = 1<<14 - 1
+
+// FragmentedDataBlock contains the result of fragmenting a data block.
+type FragmentedDataBlock struct {
+	// NbFrag contains the number of (uncoded) fragments.
+	NbFrag int
+
+	// Padding contains the number of padding bytes added to the last
+	// uncoded fragment.
+	Padding int
+
+	// DataFragments contains the (unencrypted) DataFragment commands,
+	// including the redundancy fragments.
+	DataFragments [][]byte
+}
+
+// GetDataFragments fragments the given data into fragments of fragSize bytes
+// and applies the forward error correction, adding redundancy fragments.
+// The last fragment is padded with zero bytes. It returns the DataFragment
+// commands for the given fragmentation session index.
+func GetDataFragments(data []byte, fragIndex uint8, fragSize, redundancy int) (FragmentedDataBlock, error) {
+	var out FragmentedDataBlock
+
+	if fragIndex > maxFragIndex {
+		return out, errors.Errorf("frag-index must be <= %d", maxFragIndex)
+	}
+	if fragSize <= 0 || fragSize > 255 {
+		return out, errors.New("fragment-size must be between 1 and 255")
+	}
+	if redundancy < 0 {
+		return out, errors.New("redundancy must be >= 0")
+	}
+	if len(data) == 0 {
+		return out, errors.New("data must not be empty")
+	}
+
+	if rem := len(data) % fragSize; rem != 0 {
+		out.Padding = fragSize - rem
+		data = append(append([]byte{}, data...), make([]byte, out.Padding)...)
+	}
+	out.NbFrag = len(data) / fragSize
+
+	if out.NbFrag+redundancy > maxNbFrag {
+		return out, errors.Errorf("number of fragments (including redundancy) must be <= %d", maxNbFrag)
+	}
+
+	fragments, err := fragmentation.Encode(data, fragSize, redundancy)
+	if err != nil {
+		return out, errors.Wrap(err, "encode fragments error")
+	}
+
+	for i, fragment := range fragments {
+		cmd := fragmentation.Command{
+			CID: fragmentation.DataFragment,
+			Payload: &fragmentation.DataFragmentPayload{
+				IndexAndN: fragmentation.DataFragmentPayloadIndexAndN{
+					FragIndex: fragIndex,
+					N:         uint16(i + 1),
+				},
+				Payload: fragment,
+			},
+		}
+
+		b, err := cmd.MarshalBinary()
+		if err != nil {
+			return out, errors.Wrap(err, "marshal data-fragment error")
+		}
+		out.DataFragments = append(out.DataFragments, b)
+	}
+
+	return out, nil
+}
+
+// GetFragSessionSetupReq returns the FragSessionSetupReq command for setting
+// up the fragmentation session on the device, for the given fragmented data
+// block. The fragmentation session is bound to the given multicast-group id
+// on the device.
+func GetFragSessionSetupReq(fragIndex, mcGroupID uint8, fragSize int, block FragmentedDataBlock, blockAckDelay uint8, descriptor [4]byte) (fragmentation.Command, error) {
+	if mcGroupID > maxMcGroupID {
+		return fragmentation.Command{}, errors.Errorf("mc-group-id must be <= %d", maxMcGroupID)
+	}
+
+	var mcGroupBitMask [4]bool
+	mcGroupBitMask[mcGroupID] = true
+
+	return fragmentation.Command{
+		CID: fragmentation.FragSessionSetupReq,
+		Payload: &fragmentation.FragSessionSetupReqPayload{
+			FragSession: fragmentation.FragSessionSetupReqPayloadFragSession{
+				FragIndex:      fragIndex,
+				McGroupBitMask: mcGroupBitMask,
+			},
+			NbFrag:   uint16(block.NbFrag),
+			FragSize: uint8(fragSize),
+			Control: fragmentation.FragSessionSetupReqPayloadControl{
+				BlockAckDelay: blockAckDelay,
+			},
+			Padding:    uint8(block.Padding),
+			Descriptor: descriptor,
+		},
+	}, nil
+}
+
+// ValidateDataFragmentSize validates that each of the given fragments fits
+// within the max. payload-size of the multicast-group data-rate.
+func ValidateDataFragmentSize(mg storage.MulticastGroup, fragments [][]byte) error {
+	maxSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex("", "", mg.DR)
+	if err != nil {
+		return errors.Wrap(err, "get max payload-size for data-rate index error")
+	}
+
+	for _, b := range fragments {
+		if len(b) > maxSize.N {
+			return ErrFragmentSize
+		}
+	}
+
+	return nil
+}
+
+// EnqueueDataFragments enqueues the given DataFragment commands, encrypted
+// by the application-server using the McAppSKey, as multicast queue-items
+// using consecutive frame-counters starting at fCnt. For each device of the
+// multicast-group, the fragmentation session status is reset to pending.
+func EnqueueDataFragments(ctx context.Context, p *redis.Pool, db sqlx.Ext, multicastGroupID uuid.UUID, fragIndex, nbFrag int, fCnt uint32, frmPayloads [][]byte) error {
+	// Get multicast-group and lock it.
+	mg, err := storage.GetMulticastGroup(ctx, db, multicastGroupID, true)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+
+	if err := ValidateDataFragmentSize(mg, frmPayloads); err != nil {
+		return err
+	}
+
+	for i, b := range frmPayloads {
+		qi := storage.MulticastQueueItem{
+			MulticastGroupID: mg.ID,
+			FCnt:             fCnt + uint32(i),
+			FPort:            FragmentationFPort,
+			FRMPayload:       b,
+		}
+
+		if err := EnqueueQueueItem(ctx, p, db, qi); err != nil {
+			return errors.Wrap(err, "enqueue multicast queue-item error")
+		}
+	}
+
+	devEUIs, err := storage.GetDevEUIsForMulticastGroup(ctx, db, mg.ID)
+	if err != nil {
+		return errors.Wrap(err, "get deveuis for multicast-group error")
+	}
+
+	for _, devEUI := range devEUIs {
+		if err := storage.SaveDeviceMulticastFragSession(ctx, db, &storage.DeviceMulticastFragSession{
+			DevEUI:           devEUI,
+			MulticastGroupID: mg.ID,
+			FragIndex:        fragIndex,
+			NbFrag:           nbFrag,
+			State:            storage.MulticastFragSessionPending,
+		}); err != nil {
+			return errors.Wrap(err, "save device multicast fragmentation session error")
+		}
+	}
+
+	return nil
+}
+
+// HandleFragmentationUplink handles the given (decrypted) Fragmented Data
+// Block Transport uplink payload of the given device and updates the
+// fragmentation session matching the FragIndex of each answer. Answers for
+// which there is no fragmentation session are ignored.
+func HandleFragmentationUplink(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64, b []byte) error {
+	var cmds fragmentation.Commands
+	if err := cmds.UnmarshalBinary(true, b); err != nil {
+		return errors.Wrap(err, "unmarshal commands error")
+	}
+
+	for _, cmd := range cmds {
+		var fragIndex uint8
+
+		switch pl := cmd.Payload.(type) {
+		case *fragmentation.FragSessionSetupAnsPayload:
+			fragIndex = pl.StatusBitMask.FragIndex
+		case *fragmentation.FragSessionStatusAnsPayload:
+			fragIndex = pl.ReceivedAndIndex.FragIndex
+		default:
+			log.WithFields(log.Fields{
+				"dev_eui": devEUI,
+				"cid":     cmd.CID,
+				"ctx_id":  ctx.Value(logging.ContextIDKey),
+			}).Info("multicast: ignoring fragmentation command")
+			continue
+		}
+
+		s, err := storage.GetDeviceMulticastFragSessionForFragIndex(ctx, db, devEUI, int(fragIndex))
+		if err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				log.WithFields(log.Fields{
+					"dev_eui":    devEUI,
+					"frag_index": fragIndex,
+					"ctx_id":     ctx.Value(logging.ContextIDKey),
+				}).Warning("multicast: no fragmentation session for frag index")
+				continue
+			}
+			return errors.Wrap(err, "get device multicast fragmentation session error")
+		}
+
+		// a failed setup is final
+		if s.State == storage.MulticastFragSessionFailed {
+			continue
+		}
+
+		switch pl := cmd.Payload.(type) {
+		case *fragmentation.FragSessionSetupAnsPayload:
+			// a late FragSessionSetupAns must not revert a reported status
+			if s.State != storage.MulticastFragSessionPending {
+				continue
+			}
+			s.State, s.Error = getFragSessionSetupAnsState(pl.StatusBitMask)
+		case *fragmentation.FragSessionStatusAnsPayload:
+			s.NbFragReceived = int(pl.ReceivedAndIndex.NbFragReceived)
+			s.MissingFrag = int(pl.MissingFrag)
+			s.State = storage.MulticastFragSessionCompleted
+			s.Error = ""
+
+			if pl.MissingFrag != 0 || pl.Status.NotEnoughMatrixMemory {
+				s.State = storage.MulticastFragSessionIncomplete
+			}
+			if pl.Status.NotEnoughMatrixMemory {
+				s.Error = storage.MulticastFragSessionErrorNotEnoughMatrixMemory
+			}
+		}
+
+		if err := storage.SaveDeviceMulticastFragSession(ctx, db, &s); err != nil {
+			return errors.Wrap(err, "save device multicast fragmentation session error")
+		}
+	}
+
+	return nil
+}
+
+// getFragSessionSetupAnsState returns the fragmentation session state and
+// error for the given FragSessionSetupAns status.
+func getFragSessionSetupAnsState(status fragmentation.FragSessionSetupAnsPayloadStatusBitMask) (storage.MulticastFragSessionState, string) {
+	switch {
+	case status.WrongDescriptor:
+		return storage.MulticastFragSessionFailed, storage.MulticastFragSessionErrorWrongDescriptor
+	case status.FragSessionIndexNotSupported:
+		return storage.MulticastFragSessionFailed, storage.MulticastFragSessionErrorFragSessionIndexNotSupported
+	case status.NotEnoughMemory:
+		return storage.MulticastFragSessionFailed, storage.MulticastFragSessionErrorNotEnoughMemory
+	case status.EncodingUnsupported:
+		return storage.MulticastFragSessionFailed, storage.MulticastFragSessionErrorEncodingUnsupported
+	default:
+		return storage.MulticastFragSessionCreated, ""
+	}
+}
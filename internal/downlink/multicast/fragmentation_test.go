@@ -0,0 +1,276 @@
+package multicast
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/applayer/fragmentation"
+)
+
+func TestGetDataFragments(t *testing.T) {
+	t.Run("Padding and redundancy", func(t *testing.T) {
+		assert := require.New(t)
+
+		block, err := GetDataFragments([]byte{1, 2, 3, 4, 5, 6, 7}, 1, 4, 2)
+		assert.NoError(err)
+		assert.Equal(2, block.NbFrag)
+		assert.Equal(1, block.Padding)
+		assert.Len(block.DataFragments, 4)
+
+		fragments, err := fragmentation.Encode([]byte{1, 2, 3, 4, 5, 6, 7, 0}, 4, 2)
+		assert.NoError(err)
+
+		for i, b := range block.DataFragments {
+			var cmd fragmentation.Command
+			assert.NoError(cmd.UnmarshalBinary(false, b))
+			assert.Equal(fragmentation.DataFragment, cmd.CID)
+
+			pl, ok := cmd.Payload.(*fragmentation.DataFragmentPayload)
+			assert.True(ok)
+			assert.EqualValues(1, pl.IndexAndN.FragIndex)
+			assert.EqualValues(i+1, pl.IndexAndN.N)
+			assert.Equal(fragments[i], pl.Payload)
+		}
+	})
+
+	t.Run("Invalid frag-index", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetDataFragments([]byte{1, 2, 3, 4}, 4, 4, 0)
+		assert.EqualError(err, "frag-index must be <= 3")
+	})
+
+	t.Run("Too many fragments", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetDataFragments(make([]byte, maxNbFrag), 0, 1, 1)
+		assert.EqualError(err, "number of fragments (including redundancy) must be <= 16383")
+	})
+}
+
+func TestGetFragSessionSetupReq(t *testing.T) {
+	assert := require.New(t)
+
+	block := FragmentedDataBlock{
+		NbFrag:  2,
+		Padding: 1,
+	}
+
+	cmd, err := GetFragSessionSetupReq(1, 2, 4, block, 3, [4]byte{1, 2, 3, 4})
+	assert.NoError(err)
+	b, err := cmd.MarshalBinary()
+	assert.NoError(err)
+	assert.Equal([]byte{
+		0x02,       // CID
+		0x14,       // FragSession
+		0x02, 0x00, // NbFrag
+		0x04,                   // FragSize
+		0x03,                   // Control
+		0x01,                   // Padding
+		0x01, 0x02, 0x03, 0x04, // Descriptor
+	}, b)
+
+	t.Run("Invalid mc-group-id", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetFragSessionSetupReq(1, 4, 4, block, 3, [4]byte{1, 2, 3, 4})
+		assert.EqualError(err, "mc-group-id must be <= 3")
+	})
+}
+
+func (ts *EnqueueQueueItemTestCase) TestEnqueueDataFragments() {
+	ts.T().Run("Enqueue", func(t *testing.T) {
+		assert := require.New(t)
+
+		frmPayloads := [][]byte{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+		assert.NoError(EnqueueDataFragments(context.Background(), storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 1, 2, ts.MulticastGroup.FCnt, frmPayloads))
+
+		mg, err := storage.GetMulticastGroup(context.Background(), ts.tx, ts.MulticastGroup.ID, false)
+		assert.NoError(err)
+		assert.Equal(ts.MulticastGroup.FCnt+3, mg.FCnt)
+
+		items, err := storage.GetMulticastQueueItemsForMulticastGroup(context.Background(), ts.tx, ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Len(items, 6) // 3 fragments x 2 gateways
+
+		for _, qi := range items {
+			assert.EqualValues(FragmentationFPort, qi.FPort)
+			assert.Equal(frmPayloads[qi.FCnt-ts.MulticastGroup.FCnt], qi.FRMPayload)
+		}
+
+		sessions, err := storage.GetDeviceMulticastFragSessionsForMulticastGroup(context.Background(), ts.tx, ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Len(sessions, len(ts.Devices))
+
+		for _, s := range sessions {
+			assert.Equal(1, s.FragIndex)
+			assert.Equal(2, s.NbFrag)
+			assert.Equal(storage.MulticastFragSessionPending, s.State)
+		}
+	})
+
+	ts.T().Run("Invalid frame-counter", func(t *testing.T) {
+		assert := require.New(t)
+
+		err := EnqueueDataFragments(context.Background(), storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 1, 1, 0, [][]byte{{1, 2, 3}})
+		assert.Equal(ErrInvalidFCnt, errors.Cause(err))
+	})
+
+	ts.T().Run("Fragment exceeds max payload-size", func(t *testing.T) {
+		assert := require.New(t)
+
+		block, err := GetDataFragments(make([]byte, 250), 0, 250, 0)
+		assert.NoError(err)
+
+		err = EnqueueDataFragments(context.Background(), storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 0, 1, ts.MulticastGroup.FCnt+10, block.DataFragments)
+		assert.Equal(ErrFragmentSize, err)
+	})
+}
+
+func (ts *EnqueueQueueItemTestCase) TestHandleFragmentationUplink() {
+	assert := require.New(ts.T())
+
+	for _, d := range ts.Devices {
+		assert.NoError(storage.SaveDeviceMulticastFragSession(context.Background(), ts.tx, &storage.DeviceMulticastFragSession{
+			DevEUI:           d.DevEUI,
+			MulticastGroupID: ts.MulticastGroup.ID,
+			FragIndex:        1,
+			NbFrag:           10,
+			State:            storage.MulticastFragSessionPending,
+		}))
+	}
+
+	tests := []struct {
+		Name                   string
+		DevEUI                 lorawan.EUI64
+		Commands               fragmentation.Commands
+		ExpectedState          storage.MulticastFragSessionState
+		ExpectedError          string
+		ExpectedNbFragReceived int
+		ExpectedMissingFrag    int
+	}{
+		{
+			Name:   "FragSessionSetupAns",
+			DevEUI: ts.Devices[0].DevEUI,
+			Commands: fragmentation.Commands{
+				{
+					CID: fragmentation.FragSessionSetupAns,
+					Payload: &fragmentation.FragSessionSetupAnsPayload{
+						StatusBitMask: fragmentation.FragSessionSetupAnsPayloadStatusBitMask{
+							FragIndex: 1,
+						},
+					},
+				},
+			},
+			ExpectedState: storage.MulticastFragSessionCreated,
+		},
+		{
+			Name:   "FragSessionStatusAns with missing fragments",
+			DevEUI: ts.Devices[0].DevEUI,
+			Commands: fragmentation.Commands{
+				{
+					CID: fragmentation.FragSessionStatusAns,
+					Payload: &fragmentation.FragSessionStatusAnsPayload{
+						ReceivedAndIndex: fragmentation.FragSessionStatusAnsPayloadReceivedAndIndex{
+							FragIndex:      1,
+							NbFragReceived: 8,
+						},
+						MissingFrag: 2,
+					},
+				},
+			},
+			ExpectedState:          storage.MulticastFragSessionIncomplete,
+			ExpectedNbFragReceived: 8,
+			ExpectedMissingFrag:    2,
+		},
+		{
+			Name:   "FragSessionStatusAns completed",
+			DevEUI: ts.Devices[0].DevEUI,
+			Commands: fragmentation.Commands{
+				{
+					CID: fragmentation.FragSessionStatusAns,
+					Payload: &fragmentation.FragSessionStatusAnsPayload{
+						ReceivedAndIndex: fragmentation.FragSessionStatusAnsPayloadReceivedAndIndex{
+							FragIndex:      1,
+							NbFragReceived: 10,
+						},
+					},
+				},
+			},
+			ExpectedState:          storage.MulticastFragSessionCompleted,
+			ExpectedNbFragReceived: 10,
+		},
+		{
+			Name:   "late FragSessionSetupAns is ignored",
+			DevEUI: ts.Devices[0].DevEUI,
+			Commands: fragmentation.Commands{
+				{
+					CID: fragmentation.FragSessionSetupAns,
+					Payload: &fragmentation.FragSessionSetupAnsPayload{
+						StatusBitMask: fragmentation.FragSessionSetupAnsPayloadStatusBitMask{
+							FragIndex: 1,
+						},
+					},
+				},
+			},
+			ExpectedState:          storage.MulticastFragSessionCompleted,
+			ExpectedNbFragReceived: 10,
+		},
+		{
+			Name:   "FragSessionSetupAns with error",
+			DevEUI: ts.Devices[1].DevEUI,
+			Commands: fragmentation.Commands{
+				{
+					CID: fragmentation.FragSessionSetupAns,
+					Payload: &fragmentation.FragSessionSetupAnsPayload{
+						StatusBitMask: fragmentation.FragSessionSetupAnsPayloadStatusBitMask{
+							FragIndex:       1,
+							NotEnoughMemory: true,
+						},
+					},
+				},
+			},
+			ExpectedState: storage.MulticastFragSessionFailed,
+			ExpectedError: storage.MulticastFragSessionErrorNotEnoughMemory,
+		},
+		{
+			Name:   "unknown frag index is ignored",
+			DevEUI: ts.Devices[1].DevEUI,
+			Commands: fragmentation.Commands{
+				{
+					CID: fragmentation.FragSessionStatusAns,
+					Payload: &fragmentation.FragSessionStatusAnsPayload{
+						ReceivedAndIndex: fragmentation.FragSessionStatusAnsPayloadReceivedAndIndex{
+							FragIndex:      2,
+							NbFragReceived: 10,
+						},
+					},
+				},
+			},
+			ExpectedState: storage.MulticastFragSessionFailed,
+			ExpectedError: storage.MulticastFragSessionErrorNotEnoughMemory,
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			b, err := tst.Commands.MarshalBinary()
+			assert.NoError(err)
+			assert.NoError(HandleFragmentationUplink(context.Background(), ts.tx, tst.DevEUI, b))
+
+			s, err := storage.GetDeviceMulticastFragSessionForFragIndex(context.Background(), ts.tx, tst.DevEUI, 1)
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedState, s.State)
+			assert.Equal(tst.ExpectedError, s.Error)
+			assert.Equal(tst.ExpectedNbFragReceived, s.NbFragReceived)
+			assert.Equal(tst.ExpectedMissingFrag, s.MissingFrag)
+		})
+	}
+}
@@ -16,7 +16,11 @@ import (
 	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
 	"github.com/brocaar/chirpstack-network-server/internal/band"
 	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/data/classb"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/dutycycle"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/txschedule"
 	"github.com/brocaar/chirpstack-network-server/internal/framelog"
+	"github.com/brocaar/chirpstack-network-server/internal/gps"
 	"github.com/brocaar/chirpstack-network-server/internal/helpers"
 	"github.com/brocaar/chirpstack-network-server/internal/logging"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
@@ -40,10 +44,13 @@ type multicastContext struct {
 var multicastTasks = []func(*multicastContext) error{
 	getMulticastGroup,
 	setToken,
-	removeQueueItem,
 	validatePayloadSize,
 	setTXInfo,
 	setPHYPayload,
+	checkDutyCycle,
+	checkTXSchedule,
+	removeQueueItem,
+	saveDeliveryPending,
 	sendDownlinkData,
 	saveDownlinkFrame,
 }
@@ -152,6 +159,15 @@ func validatePayloadSize(ctx *multicastContext) error {
 			"ctx_id":               ctx.ctx.Value(logging.ContextIDKey),
 		}).Error("payload exceeds max size for data-rate")
 
+		// the queue-item will never fit, remove it from the queue
+		if err := removeQueueItem(ctx); err != nil {
+			return err
+		}
+
+		if err := saveDelivery(ctx, storage.MulticastDeliveryFailed, storage.MulticastDeliveryErrorPayloadSize); err != nil {
+			return err
+		}
+
 		return errAbort
 	}
 
@@ -218,6 +234,114 @@ func setPHYPayload(ctx *multicastContext) error {
 	return nil
 }
 
+func checkDutyCycle(ctx *multicastContext) error {
+	phyB, err := ctx.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	ok, err := dutycycle.CanTransmit(ctx.ctx, &ctx.TXInfo, len(phyB))
+	if err != nil {
+		return errors.Wrap(err, "check duty-cycle error")
+	}
+
+	if !ok {
+		log.WithFields(log.Fields{
+			"multicast_group_id": ctx.MulticastGroup.ID,
+			"gateway_id":         ctx.MulticastQueueItem.GatewayID,
+			"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("gateway exceeds duty-cycle limit, rescheduling multicast transmission")
+		return rescheduleQueueItem(ctx, storage.MulticastDeliveryErrorDutyCycle)
+	}
+
+	return nil
+}
+
+// rescheduleQueueItem reschedules the queue-item, so that it is retried
+// instead of being lost. A Class-C queue-item is retried after the downlink
+// lock duration, a Class-B queue-item at the next ping-slot. The delivery
+// stays pending, with the reason as error.
+func rescheduleQueueItem(ctx *multicastContext, errStr string) error {
+	qi := ctx.MulticastQueueItem
+
+	if qi.EmitAtTimeSinceGPSEpoch == nil {
+		qi.ScheduleAt = time.Now().Add(downlinkLockDuration)
+	} else {
+		var pingSlotNb int
+		if ctx.MulticastGroup.PingSlotPeriod != 0 {
+			pingSlotNb = (1 << 12) / ctx.MulticastGroup.PingSlotPeriod
+		}
+
+		scheduleTS := *qi.EmitAtTimeSinceGPSEpoch
+		minScheduleTS := gps.Time(time.Now().Add(classBEnqueueMargin)).TimeSinceGPSEpoch()
+		if scheduleTS < minScheduleTS {
+			scheduleTS = minScheduleTS
+		}
+
+		scheduleTS, err := classb.GetNextPingSlotAfter(scheduleTS, ctx.MulticastGroup.MCAddr, pingSlotNb)
+		if err != nil {
+			return errors.Wrap(err, "get next ping-slot after error")
+		}
+
+		qi.EmitAtTimeSinceGPSEpoch = &scheduleTS
+		qi.ScheduleAt = time.Time(gps.NewFromTimeSinceGPSEpoch(scheduleTS)).Add(-2 * schedulerInterval)
+	}
+
+	if err := storage.UpdateMulticastQueueItemSchedule(ctx.ctx, ctx.DB, qi); err != nil {
+		return errors.Wrap(err, "update multicast queue-item schedule error")
+	}
+
+	if err := saveDelivery(ctx, storage.MulticastDeliveryPending, errStr); err != nil {
+		return err
+	}
+
+	return errAbort
+}
+
+func checkTXSchedule(ctx *multicastContext) error {
+	phyB, err := ctx.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	ok, err := txschedule.CanSchedule(ctx.ctx, &ctx.TXInfo, len(phyB))
+	if err != nil {
+		return errors.Wrap(err, "check tx schedule error")
+	}
+
+	if !ok {
+		log.WithFields(log.Fields{
+			"multicast_group_id": ctx.MulticastGroup.ID,
+			"gateway_id":         ctx.MulticastQueueItem.GatewayID,
+			"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("transmission overlaps with gateway tx schedule, rescheduling multicast transmission")
+		return rescheduleQueueItem(ctx, storage.MulticastDeliveryErrorTXSchedule)
+	}
+
+	return nil
+}
+
+func saveDeliveryPending(ctx *multicastContext) error {
+	return saveDelivery(ctx, storage.MulticastDeliveryPending, "")
+}
+
+// saveDelivery stores the delivery status of the queue-item for its gateway,
+// using the scheduler transaction. A tx ack received before the transaction
+// has been committed waits for the commit (see ack package).
+func saveDelivery(ctx *multicastContext, status storage.MulticastDeliveryStatus, errStr string) error {
+	if err := storage.SaveMulticastQueueItemDelivery(ctx.ctx, ctx.DB, &storage.MulticastQueueItemDelivery{
+		MulticastGroupID: ctx.MulticastQueueItem.MulticastGroupID,
+		FCnt:             ctx.MulticastQueueItem.FCnt,
+		GatewayID:        ctx.MulticastQueueItem.GatewayID,
+		Status:           status,
+		Error:            errStr,
+	}); err != nil {
+		return errors.Wrap(err, "save multicast queue-item delivery error")
+	}
+
+	return nil
+}
+
 func sendDownlinkData(ctx *multicastContext) error {
 	phyB, err := ctx.PHYPayload.MarshalBinary()
 	if err != nil {
@@ -242,6 +366,16 @@ func sendDownlinkData(ctx *multicastContext) error {
 		return errors.Wrap(err, "send downlink frame to gateway error")
 	}
 
+	// save the used airtime for duty-cycle accounting
+	if err := dutycycle.SaveAirtime(ctx.ctx, ctx.DownlinkFrame); err != nil {
+		log.WithError(err).Error("save airtime error")
+	}
+
+	// save the emission in the gateway tx schedule
+	if err := txschedule.SaveScheduleItem(ctx.ctx, ctx.DownlinkFrame); err != nil {
+		log.WithError(err).Error("save tx schedule item error")
+	}
+
 	if err := framelog.LogDownlinkFrameForGateway(ctx.ctx, storage.RedisPool(), ctx.DownlinkFrame); err != nil {
 		log.WithError(err).Error("log downlink frame for gateway error")
 	}
@@ -252,6 +386,7 @@ func sendDownlinkData(ctx *multicastContext) error {
 func saveDownlinkFrame(ctx *multicastContext) error {
 	df := storage.DownlinkFrames{
 		MulticastGroupId: ctx.MulticastGroup.ID[:],
+		FCnt:             ctx.MulticastQueueItem.FCnt,
 		Token:            uint32(ctx.Token),
 		DownlinkFrames:   []*gw.DownlinkFrame{&ctx.DownlinkFrame},
 	}
@@ -0,0 +1,217 @@
+package multicast
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/data/classb"
+	"github.com/brocaar/chirpstack-network-server/internal/gps"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/applayer/multicastsetup"
+)
+
+// SetupFPort defines the FPort used by the LoRaWAN Remote Multicast Setup
+// package.
+const SetupFPort = 200
+
+// maxPeriodicity defines the max. Class-B ping-slot periodicity that can be
+// set with the McClassBSessionReq command.
+const maxPeriodicity = 7
+
+// GetSessionTime returns the start of the multicast session (as duration
+// since GPS epoch) for the given multicast-group, not before the given time.
+// For Class-B, the session starts at a beacon, for Class-C the session time
+// is rounded up to the next second.
+func GetSessionTime(mg storage.MulticastGroup, after time.Time) time.Duration {
+	if mg.GroupType == storage.MulticastGroupB {
+		beacon := classb.GetBeaconStartForTime(after)
+		if beacon < gps.Time(after).TimeSinceGPSEpoch() {
+			beacon += 128 * time.Second
+		}
+		return beacon
+	}
+
+	sessionTime := gps.Time(after).TimeSinceGPSEpoch()
+	if rem := sessionTime % time.Second; rem != 0 {
+		sessionTime += time.Second - rem
+	}
+	return sessionTime
+}
+
+// GetMcGroupSetupReq returns the McGroupSetupReq command for setting up the
+// given multicast-group as mcGroupID on the device. The McKey must be
+// encrypted using the McKEKey of the device. When maxFCnt is 0, the session
+// is valid until the frame-counter rolls over.
+func GetMcGroupSetupReq(mg storage.MulticastGroup, mcGroupID uint8, mcKeyEncrypted lorawan.AES128Key, maxFCnt uint32) multicastsetup.Command {
+	if maxFCnt == 0 {
+		maxFCnt = math.MaxUint32
+	}
+
+	return multicastsetup.Command{
+		CID: multicastsetup.McGroupSetupReq,
+		Payload: &multicastsetup.McGroupSetupReqPayload{
+			McGroupIDHeader: multicastsetup.McGroupSetupReqPayloadMcGroupIDHeader{
+				McGroupID: mcGroupID,
+			},
+			McAddr:         mg.MCAddr,
+			McKeyEncrypted: mcKeyEncrypted,
+			MinMcFCnt:      mg.FCnt,
+			MaxMcFCnt:      maxFCnt,
+		},
+	}
+}
+
+// GetMcSessionReq returns the McClassBSessionReq or McClassCSessionReq
+// command (depending the multicast-group type) for starting the multicast
+// session at the given session time (duration since GPS epoch). The session
+// time-out is 2^timeOut seconds.
+func GetMcSessionReq(mg storage.MulticastGroup, mcGroupID uint8, sessionTime time.Duration, timeOut uint8) (multicastsetup.Command, error) {
+	if timeOut > 15 {
+		return multicastsetup.Command{}, errors.New("time-out must be <= 15")
+	}
+
+	switch mg.GroupType {
+	case storage.MulticastGroupB:
+		periodicity, err := getPeriodicity(mg.PingSlotPeriod)
+		if err != nil {
+			return multicastsetup.Command{}, err
+		}
+
+		return multicastsetup.Command{
+			CID: multicastsetup.McClassBSessionReq,
+			Payload: &multicastsetup.McClassBSessionReqPayload{
+				McGroupIDHeader: multicastsetup.McClassBSessionReqPayloadMcGroupIDHeader{
+					McGroupID: mcGroupID,
+				},
+				SessionTime: uint32(sessionTime / time.Second),
+				TimeOutPeriodicity: multicastsetup.McClassBSessionReqPayloadTimeOutPeriodicity{
+					Periodicity: periodicity,
+					TimeOut:     timeOut,
+				},
+				DLFrequency: uint32(mg.Frequency),
+				DR:          uint8(mg.DR),
+			},
+		}, nil
+	case storage.MulticastGroupC:
+		return multicastsetup.Command{
+			CID: multicastsetup.McClassCSessionReq,
+			Payload: &multicastsetup.McClassCSessionReqPayload{
+				McGroupIDHeader: multicastsetup.McClassCSessionReqPayloadMcGroupIDHeader{
+					McGroupID: mcGroupID,
+				},
+				SessionTime: uint32(sessionTime / time.Second),
+				SessionTimeOut: multicastsetup.McClassCSessionReqPayloadSessionTimeOut{
+					TimeOut: timeOut,
+				},
+				DLFrequency: uint32(mg.Frequency),
+				DR:          uint8(mg.DR),
+			},
+		}, nil
+	default:
+		return multicastsetup.Command{}, errors.Errorf("unexpected multicast-group type: %s", mg.GroupType)
+	}
+}
+
+// getPeriodicity returns the McClassBSessionReq periodicity for the given
+// ping-slot period (in number of slots). The ping-slot period of the
+// multicast-group must equal 2^periodicity seconds.
+func getPeriodicity(pingSlotPeriod int) (uint8, error) {
+	for p := uint8(0); p <= maxPeriodicity; p++ {
+		if pingSlotPeriod == (1<<12)/(1<<(7-p)) {
+			return p, nil
+		}
+	}
+
+	return 0, errors.Errorf("ping-slot period %d can not be expressed as periodicity", pingSlotPeriod)
+}
+
+// HandleSetupUplink handles the given (decrypted) Remote Multicast Setup
+// uplink payload of the given device and updates the multicast setup state
+// of the multicast-group matching the McGroupID of each answer. Answers for
+// which there is no multicast setup are ignored.
+func HandleSetupUplink(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64, b []byte) error {
+	var cmds multicastsetup.Commands
+	if err := cmds.UnmarshalBinary(true, b); err != nil {
+		return errors.Wrap(err, "unmarshal commands error")
+	}
+
+	for _, cmd := range cmds {
+		var mcGroupID uint8
+		var state storage.MulticastSetupState
+		var errStr string
+
+		switch pl := cmd.Payload.(type) {
+		case *multicastsetup.McGroupSetupAnsPayload:
+			mcGroupID = pl.McGroupIDHeader.McGroupID
+			state = storage.MulticastSetupGroupCreated
+			if pl.McGroupIDHeader.IDError {
+				state = storage.MulticastSetupFailed
+				errStr = storage.MulticastSetupErrorIDError
+			}
+		case *multicastsetup.McClassBSessionAnsPayload:
+			status := pl.StatusAndMcGroupID
+			mcGroupID = status.McGroupID
+			state, errStr = getSessionAnsState(status.McGroupUndefined, status.FreqError, status.DRError)
+		case *multicastsetup.McClassCSessionAnsPayload:
+			status := pl.StatusAndMcGroupID
+			mcGroupID = status.McGroupID
+			state, errStr = getSessionAnsState(status.McGroupUndefined, status.FreqError, status.DRError)
+		default:
+			log.WithFields(log.Fields{
+				"dev_eui": devEUI,
+				"cid":     cmd.CID,
+				"ctx_id":  ctx.Value(logging.ContextIDKey),
+			}).Info("multicast: ignoring remote multicast setup command")
+			continue
+		}
+
+		s, err := storage.GetDeviceMulticastGroupSetupForMcGroupID(ctx, db, devEUI, int(mcGroupID))
+		if err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				log.WithFields(log.Fields{
+					"dev_eui":     devEUI,
+					"mc_group_id": mcGroupID,
+					"ctx_id":      ctx.Value(logging.ContextIDKey),
+				}).Warning("multicast: no multicast setup for mc group id")
+				continue
+			}
+			return errors.Wrap(err, "get device multicast-group setup error")
+		}
+
+		// a failed setup is final and a late McGroupSetupAns must not
+		// revert a completed setup
+		if s.State == storage.MulticastSetupFailed || (s.State == storage.MulticastSetupCompleted && state == storage.MulticastSetupGroupCreated) {
+			continue
+		}
+
+		s.State = state
+		s.Error = errStr
+		if err := storage.SaveDeviceMulticastGroupSetup(ctx, db, &s); err != nil {
+			return errors.Wrap(err, "save device multicast-group setup error")
+		}
+	}
+
+	return nil
+}
+
+// getSessionAnsState returns the multicast setup state and error for the
+// given McClassBSessionAns or McClassCSessionAns status.
+func getSessionAnsState(mcGroupUndefined, freqError, drError bool) (storage.MulticastSetupState, string) {
+	switch {
+	case mcGroupUndefined:
+		return storage.MulticastSetupFailed, storage.MulticastSetupErrorMcGroupUndefined
+	case freqError:
+		return storage.MulticastSetupFailed, storage.MulticastSetupErrorFreqError
+	case drError:
+		return storage.MulticastSetupFailed, storage.MulticastSetupErrorDRError
+	default:
+		return storage.MulticastSetupCompleted, ""
+	}
+}
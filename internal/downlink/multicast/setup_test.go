@@ -0,0 +1,257 @@
+package multicast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/internal/gps"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/applayer/multicastsetup"
+)
+
+func TestGetSessionTime(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Type     storage.MulticastGroupType
+		After    time.Duration
+		Expected time.Duration
+	}{
+		{
+			Name:     "class-c, rounded to next second",
+			Type:     storage.MulticastGroupC,
+			After:    1000*time.Second + time.Millisecond,
+			Expected: 1001 * time.Second,
+		},
+		{
+			Name:     "class-c, exact second",
+			Type:     storage.MulticastGroupC,
+			After:    1000 * time.Second,
+			Expected: 1000 * time.Second,
+		},
+		{
+			Name:     "class-b, next beacon",
+			Type:     storage.MulticastGroupB,
+			After:    1000 * time.Second,
+			Expected: 1024 * time.Second,
+		},
+		{
+			Name:     "class-b, exact beacon",
+			Type:     storage.MulticastGroupB,
+			After:    1024 * time.Second,
+			Expected: 1024 * time.Second,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			after := time.Time(gps.NewFromTimeSinceGPSEpoch(tst.After))
+			assert.Equal(tst.Expected, GetSessionTime(storage.MulticastGroup{GroupType: tst.Type}, after))
+		})
+	}
+}
+
+func TestGetMcGroupSetupReq(t *testing.T) {
+	assert := require.New(t)
+
+	mg := storage.MulticastGroup{
+		MCAddr: lorawan.DevAddr{1, 2, 3, 4},
+		FCnt:   10,
+	}
+
+	b, err := GetMcGroupSetupReq(mg, 1, lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}, 0).MarshalBinary()
+	assert.NoError(err)
+	assert.Equal([]byte{
+		0x02,                   // CID
+		0x01,                   // McGroupIDHeader
+		0x04, 0x03, 0x02, 0x01, // McAddr
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, // McKeyEncrypted
+		0x0a, 0x00, 0x00, 0x00, // minMcFCnt
+		0xff, 0xff, 0xff, 0xff, // maxMcFCnt
+	}, b)
+}
+
+func TestGetMcSessionReq(t *testing.T) {
+	tests := []struct {
+		Name           string
+		MulticastGroup storage.MulticastGroup
+		Expected       []byte
+		ExpectedError  string
+	}{
+		{
+			Name: "class-c",
+			MulticastGroup: storage.MulticastGroup{
+				GroupType: storage.MulticastGroupC,
+				Frequency: 869525000,
+				DR:        3,
+			},
+			Expected: []byte{
+				0x04,                   // CID
+				0x01,                   // McGroupIDHeader
+				0x00, 0x04, 0x00, 0x00, // SessionTime
+				0x08,             // SessionTimeOut
+				0xd2, 0xad, 0x84, // DLFrequency
+				0x03, // DR
+			},
+		},
+		{
+			Name: "class-b",
+			MulticastGroup: storage.MulticastGroup{
+				GroupType:      storage.MulticastGroupB,
+				Frequency:      869525000,
+				DR:             3,
+				PingSlotPeriod: 128,
+			},
+			Expected: []byte{
+				0x05,                   // CID
+				0x01,                   // McGroupIDHeader
+				0x00, 0x04, 0x00, 0x00, // SessionTime
+				0x28,             // TimeOutPeriodicity
+				0xd2, 0xad, 0x84, // DLFrequency
+				0x03, // DR
+			},
+		},
+		{
+			Name: "class-b, invalid ping-slot period",
+			MulticastGroup: storage.MulticastGroup{
+				GroupType:      storage.MulticastGroupB,
+				Frequency:      869525000,
+				DR:             3,
+				PingSlotPeriod: 100,
+			},
+			ExpectedError: "ping-slot period 100 can not be expressed as periodicity",
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			cmd, err := GetMcSessionReq(tst.MulticastGroup, 1, 1024*time.Second, 8)
+			if tst.ExpectedError != "" {
+				assert.EqualError(err, tst.ExpectedError)
+				return
+			}
+			assert.NoError(err)
+
+			b, err := cmd.MarshalBinary()
+			assert.NoError(err)
+			assert.Equal(tst.Expected, b)
+		})
+	}
+}
+
+func (ts *EnqueueQueueItemTestCase) TestHandleSetupUplink() {
+	assert := require.New(ts.T())
+
+	for _, d := range ts.Devices {
+		assert.NoError(storage.SaveDeviceMulticastGroupSetup(context.Background(), ts.tx, &storage.DeviceMulticastGroupSetup{
+			DevEUI:           d.DevEUI,
+			MulticastGroupID: ts.MulticastGroup.ID,
+			McGroupID:        1,
+			State:            storage.MulticastSetupPending,
+		}))
+	}
+
+	timeToStart := uint32(10)
+
+	tests := []struct {
+		Name          string
+		DevEUI        lorawan.EUI64
+		Commands      multicastsetup.Commands
+		ExpectedState storage.MulticastSetupState
+		ExpectedError string
+	}{
+		{
+			Name:   "McGroupSetupAns",
+			DevEUI: ts.Devices[0].DevEUI,
+			Commands: multicastsetup.Commands{
+				{
+					CID: multicastsetup.McGroupSetupAns,
+					Payload: &multicastsetup.McGroupSetupAnsPayload{
+						McGroupIDHeader: multicastsetup.McGroupSetupAnsPayloadMcGroupIDHeader{
+							McGroupID: 1,
+						},
+					},
+				},
+			},
+			ExpectedState: storage.MulticastSetupGroupCreated,
+		},
+		{
+			Name:   "McClassCSessionAns",
+			DevEUI: ts.Devices[0].DevEUI,
+			Commands: multicastsetup.Commands{
+				{
+					CID: multicastsetup.McClassCSessionAns,
+					Payload: &multicastsetup.McClassCSessionAnsPayload{
+						StatusAndMcGroupID: multicastsetup.McClassCSessionAnsPayloadStatusAndMcGroupID{
+							McGroupID: 1,
+						},
+						TimeToStart: &timeToStart,
+					},
+				},
+			},
+			ExpectedState: storage.MulticastSetupCompleted,
+		},
+		{
+			Name:   "McGroupSetupAns and McClassCSessionAns with error",
+			DevEUI: ts.Devices[1].DevEUI,
+			Commands: multicastsetup.Commands{
+				{
+					CID: multicastsetup.McGroupSetupAns,
+					Payload: &multicastsetup.McGroupSetupAnsPayload{
+						McGroupIDHeader: multicastsetup.McGroupSetupAnsPayloadMcGroupIDHeader{
+							McGroupID: 1,
+						},
+					},
+				},
+				{
+					CID: multicastsetup.McClassCSessionAns,
+					Payload: &multicastsetup.McClassCSessionAnsPayload{
+						StatusAndMcGroupID: multicastsetup.McClassCSessionAnsPayloadStatusAndMcGroupID{
+							McGroupID: 1,
+							FreqError: true,
+						},
+					},
+				},
+			},
+			ExpectedState: storage.MulticastSetupFailed,
+			ExpectedError: storage.MulticastSetupErrorFreqError,
+		},
+		{
+			Name:   "unknown mc group id is ignored",
+			DevEUI: ts.Devices[1].DevEUI,
+			Commands: multicastsetup.Commands{
+				{
+					CID: multicastsetup.McGroupSetupAns,
+					Payload: &multicastsetup.McGroupSetupAnsPayload{
+						McGroupIDHeader: multicastsetup.McGroupSetupAnsPayloadMcGroupIDHeader{
+							McGroupID: 2,
+						},
+					},
+				},
+			},
+			ExpectedState: storage.MulticastSetupFailed,
+			ExpectedError: storage.MulticastSetupErrorFreqError,
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			b, err := tst.Commands.MarshalBinary()
+			assert.NoError(err)
+			assert.NoError(HandleSetupUplink(context.Background(), ts.tx, tst.DevEUI, b))
+
+			s, err := storage.GetDeviceMulticastGroupSetupForMcGroupID(context.Background(), ts.tx, tst.DevEUI, 1)
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedState, s.State)
+			assert.Equal(tst.ExpectedError, s.Error)
+		})
+	}
+}
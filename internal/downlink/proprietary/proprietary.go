@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/chirpstack-network-server/api/common"
@@ -13,6 +14,8 @@ import (
 	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
 	"github.com/brocaar/chirpstack-network-server/internal/band"
 	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/dutycycle"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/txschedule"
 	"github.com/brocaar/chirpstack-network-server/internal/helpers"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 )
@@ -132,6 +135,16 @@ func sendProprietaryDown(ctx *proprietaryContext) error {
 		if err := gateway.Backend().SendTXPacket(df); err != nil {
 			return errors.Wrap(err, "send downlink frame to gateway error")
 		}
+
+		// save the used airtime for duty-cycle accounting
+		if err := dutycycle.SaveAirtime(ctx.ctx, df); err != nil {
+			log.WithError(err).Error("save airtime error")
+		}
+
+		// save the emission in the gateway tx schedule
+		if err := txschedule.SaveScheduleItem(ctx.ctx, df); err != nil {
+			log.WithError(err).Error("save tx schedule item error")
+		}
 	}
 
 	return nil
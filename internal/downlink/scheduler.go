@@ -39,9 +39,16 @@ func DeviceQueueSchedulerLoop() {
 	}
 }
 
+// multicastDeliveryCleanupInterval defines the interval in which the expired
+// multicast queue-item deliveries are deleted.
+const multicastDeliveryCleanupInterval = time.Hour
+
 // MulticastQueueSchedulerLoop starts an infinit loop calling the multicast
-// scheduler loop.
+// scheduler loop. It also deletes the expired multicast queue-item
+// deliveries.
 func MulticastQueueSchedulerLoop() {
+	var cleanupAt time.Time
+
 	for {
 		ctx := context.Background()
 		ctxID, err := uuid.NewV4()
@@ -59,6 +66,16 @@ func MulticastQueueSchedulerLoop() {
 				"ctx_id": ctxID,
 			}).WithError(err).Error("multicast scheduler error")
 		}
+
+		if time.Now().After(cleanupAt) {
+			if err := storage.DeleteExpiredMulticastQueueItemDeliveries(ctx, storage.DB()); err != nil {
+				log.WithFields(log.Fields{
+					"ctx_id": ctxID,
+				}).WithError(err).Error("delete expired multicast queue-item deliveries error")
+			}
+			cleanupAt = time.Now().Add(multicastDeliveryCleanupInterval)
+		}
+
 		time.Sleep(schedulerInterval)
 	}
 }
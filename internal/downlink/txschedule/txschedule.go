@@ -0,0 +1,166 @@
+// Package txschedule implements the per-gateway downlink transmit schedule,
+// so that downlink transmissions scheduled for the same gateway do not
+// overlap in time.
+package txschedule
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/dutycycle"
+	"github.com/brocaar/chirpstack-network-server/internal/gps"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+)
+
+// scheduleTTL defines how long the tx schedule of a gateway is kept after
+// the last emission has been scheduled. This must be greater than the time
+// between sending a downlink to the gateway and its emission.
+const scheduleTTL = 10 * time.Minute
+
+var (
+	enabled bool
+	margin  time.Duration
+)
+
+// Setup configures the tx schedule package.
+func Setup(conf config.Config) error {
+	enabled = conf.NetworkServer.NetworkSettings.TXSchedule.Enabled
+	margin = conf.NetworkServer.NetworkSettings.TXSchedule.Margin
+
+	return nil
+}
+
+// GetScheduleItem returns the tx schedule item for transmitting a PHYPayload
+// of the given size, using the given tx-info. For the delay timing, the start
+// of the emission is estimated, assuming the uplink was received just now.
+func GetScheduleItem(txInfo *gw.DownlinkTXInfo, size int) (storage.GatewayTXScheduleItem, error) {
+	toa, err := dutycycle.GetAirtime(txInfo, size)
+	if err != nil {
+		return storage.GatewayTXScheduleItem{}, errors.Wrap(err, "get airtime error")
+	}
+
+	item := storage.GatewayTXScheduleItem{
+		Start:     time.Now(),
+		Airtime:   toa,
+		Frequency: txInfo.Frequency,
+	}
+
+	switch txInfo.Timing {
+	case gw.DownlinkTiming_IMMEDIATELY:
+		// the emission starts now
+	case gw.DownlinkTiming_DELAY:
+		timingInfo := txInfo.GetDelayTimingInfo()
+		if timingInfo == nil {
+			return item, errors.New("delay timing-info must not be nil")
+		}
+
+		delay, err := ptypes.Duration(timingInfo.Delay)
+		if err != nil {
+			return item, errors.Wrap(err, "get delay error")
+		}
+		item.Start = item.Start.Add(delay)
+
+		// in case of a concentrator counter context, the exact concentrator
+		// counter value of the emission is known
+		if len(txInfo.Context) == 4 {
+			ts := binary.BigEndian.Uint32(txInfo.Context) + uint32(delay/time.Microsecond)
+			item.Timestamp = &ts
+		}
+	case gw.DownlinkTiming_GPS_EPOCH:
+		timingInfo := txInfo.GetGpsEpochTimingInfo()
+		if timingInfo == nil {
+			return item, errors.New("gps epoch timing-info must not be nil")
+		}
+
+		timeSinceGPSEpoch, err := ptypes.Duration(timingInfo.TimeSinceGpsEpoch)
+		if err != nil {
+			return item, errors.Wrap(err, "get time since gps epoch error")
+		}
+		item.Start = time.Time(gps.NewFromTimeSinceGPSEpoch(timeSinceGPSEpoch))
+	default:
+		return item, fmt.Errorf("unknown timing: %s", txInfo.Timing)
+	}
+
+	return item, nil
+}
+
+// Overlaps returns if the two given tx schedule items overlap in time,
+// taking the given margin into account. When the concentrator counter
+// value is known for both items, it is used instead of the (estimated)
+// network-server time.
+func Overlaps(a, b storage.GatewayTXScheduleItem, margin time.Duration) bool {
+	if a.Timestamp != nil && b.Timestamp != nil {
+		// the int32 conversion handles the roll-over of the counter
+		diff := time.Duration(int32(*b.Timestamp-*a.Timestamp)) * time.Microsecond
+		return diff < a.Airtime+margin && -diff < b.Airtime+margin
+	}
+
+	return a.Start.Before(b.End().Add(margin)) && b.Start.Before(a.End().Add(margin))
+}
+
+// CanSchedule returns if the gateway of the given tx-info is able to
+// transmit a PHYPayload of the given size, without overlapping with an
+// emission already scheduled for the gateway.
+func CanSchedule(ctx context.Context, txInfo *gw.DownlinkTXInfo, size int) (bool, error) {
+	if !enabled {
+		return true, nil
+	}
+
+	item, err := GetScheduleItem(txInfo, size)
+	if err != nil {
+		return false, errors.Wrap(err, "get tx schedule item error")
+	}
+
+	gatewayID := helpers.GetGatewayID(txInfo)
+	items, err := storage.GetGatewayTXScheduleItems(ctx, storage.RedisPool(), gatewayID)
+	if err != nil {
+		return false, errors.Wrap(err, "get gateway tx schedule items error")
+	}
+
+	for _, scheduled := range items {
+		if Overlaps(item, scheduled, margin) {
+			log.WithFields(log.Fields{
+				"gateway_id":          gatewayID,
+				"frequency":           item.Frequency,
+				"start":               item.Start,
+				"airtime":             item.Airtime,
+				"scheduled_frequency": scheduled.Frequency,
+				"scheduled_start":     scheduled.Start,
+				"scheduled_airtime":   scheduled.Airtime,
+				"ctx_id":              ctx.Value(logging.ContextIDKey),
+			}).Info("txschedule: transmission would overlap with scheduled transmission")
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// SaveScheduleItem stores the emission of the given downlink frame in the
+// tx schedule of the gateway of its tx-info.
+func SaveScheduleItem(ctx context.Context, df gw.DownlinkFrame) error {
+	if !enabled || df.TxInfo == nil {
+		return nil
+	}
+
+	item, err := GetScheduleItem(df.TxInfo, len(df.PhyPayload))
+	if err != nil {
+		return errors.Wrap(err, "get tx schedule item error")
+	}
+
+	if err := storage.SaveGatewayTXScheduleItem(ctx, storage.RedisPool(), helpers.GetGatewayID(df.TxInfo), item, scheduleTTL); err != nil {
+		return errors.Wrap(err, "save gateway tx schedule item error")
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package txschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/gps"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+)
+
+func TestGetScheduleItem(t *testing.T) {
+	test.GetConfig()
+
+	gpsTime := gps.Time(time.Now().Add(5 * time.Second).Round(time.Millisecond)).TimeSinceGPSEpoch()
+	timestamp := uint32(2000000)
+
+	tests := []struct {
+		Name              string
+		TXInfo            gw.DownlinkTXInfo
+		ExpectedStart     time.Duration
+		ExpectedStartGPS  *time.Duration
+		ExpectedTimestamp *uint32
+	}{
+		{
+			Name: "immediately",
+			TXInfo: gw.DownlinkTXInfo{
+				Frequency: 868100000,
+				Timing:    gw.DownlinkTiming_IMMEDIATELY,
+			},
+		},
+		{
+			Name: "delay with concentrator counter context",
+			TXInfo: gw.DownlinkTXInfo{
+				Frequency: 868100000,
+				Timing:    gw.DownlinkTiming_DELAY,
+				TimingInfo: &gw.DownlinkTXInfo_DelayTimingInfo{
+					DelayTimingInfo: &gw.DelayTimingInfo{
+						Delay: ptypes.DurationProto(time.Second),
+					},
+				},
+				Context: []byte{0x00, 0x0f, 0x42, 0x40}, // 1000000
+			},
+			ExpectedStart:     time.Second,
+			ExpectedTimestamp: &timestamp,
+		},
+		{
+			Name: "gps epoch",
+			TXInfo: gw.DownlinkTXInfo{
+				Frequency: 868100000,
+				Timing:    gw.DownlinkTiming_GPS_EPOCH,
+				TimingInfo: &gw.DownlinkTXInfo_GpsEpochTimingInfo{
+					GpsEpochTimingInfo: &gw.GPSEpochTimingInfo{
+						TimeSinceGpsEpoch: ptypes.DurationProto(gpsTime),
+					},
+				},
+			},
+			ExpectedStartGPS: &gpsTime,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(helpers.SetDownlinkTXInfoDataRate(&tst.TXInfo, 5, band.Band()))
+
+			now := time.Now()
+			item, err := GetScheduleItem(&tst.TXInfo, 13)
+			assert.NoError(err)
+
+			assert.Equal(46336*time.Microsecond, item.Airtime)
+			assert.Equal(tst.TXInfo.Frequency, item.Frequency)
+			assert.Equal(tst.ExpectedTimestamp, item.Timestamp)
+
+			if tst.ExpectedStartGPS != nil {
+				assert.Equal(*tst.ExpectedStartGPS, gps.Time(item.Start).TimeSinceGPSEpoch())
+			} else {
+				assert.WithinDuration(now.Add(tst.ExpectedStart), item.Start, 100*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	now := time.Now()
+	ts := func(v uint32) *uint32 { return &v }
+
+	tests := []struct {
+		Name     string
+		A        storage.GatewayTXScheduleItem
+		B        storage.GatewayTXScheduleItem
+		Expected bool
+	}{
+		{
+			Name:     "overlapping",
+			A:        storage.GatewayTXScheduleItem{Start: now, Airtime: 100 * time.Millisecond},
+			B:        storage.GatewayTXScheduleItem{Start: now.Add(50 * time.Millisecond), Airtime: 100 * time.Millisecond},
+			Expected: true,
+		},
+		{
+			Name:     "within margin",
+			A:        storage.GatewayTXScheduleItem{Start: now, Airtime: 100 * time.Millisecond},
+			B:        storage.GatewayTXScheduleItem{Start: now.Add(105 * time.Millisecond), Airtime: 100 * time.Millisecond},
+			Expected: true,
+		},
+		{
+			Name:     "not overlapping",
+			A:        storage.GatewayTXScheduleItem{Start: now.Add(200 * time.Millisecond), Airtime: 100 * time.Millisecond},
+			B:        storage.GatewayTXScheduleItem{Start: now, Airtime: 100 * time.Millisecond},
+			Expected: false,
+		},
+		{
+			Name:     "timestamp overlapping, start not overlapping",
+			A:        storage.GatewayTXScheduleItem{Start: now, Airtime: 100 * time.Millisecond, Timestamp: ts(1000000)},
+			B:        storage.GatewayTXScheduleItem{Start: now.Add(time.Second), Airtime: 100 * time.Millisecond, Timestamp: ts(1050000)},
+			Expected: true,
+		},
+		{
+			Name:     "timestamp not overlapping, start overlapping",
+			A:        storage.GatewayTXScheduleItem{Start: now, Airtime: 100 * time.Millisecond, Timestamp: ts(1000000)},
+			B:        storage.GatewayTXScheduleItem{Start: now, Airtime: 100 * time.Millisecond, Timestamp: ts(2000000)},
+			Expected: false,
+		},
+		{
+			Name:     "timestamp overlapping with counter roll-over",
+			A:        storage.GatewayTXScheduleItem{Start: now, Airtime: 100 * time.Millisecond, Timestamp: ts(4294967000)},
+			B:        storage.GatewayTXScheduleItem{Start: now, Airtime: 100 * time.Millisecond, Timestamp: ts(1000)},
+			Expected: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Expected, Overlaps(tst.A, tst.B, 10*time.Millisecond))
+			assert.Equal(tst.Expected, Overlaps(tst.B, tst.A, 10*time.Millisecond))
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+)
+
+// commandExecResponsePubSubKeyTempl defines the pub-sub key to which the
+// gateway command execution response is published. As the response could be
+// received by a different network-server instance than the one which sent
+// the request, the response is published using Redis.
+const commandExecResponsePubSubKeyTempl = "lora:ns:gw:exec:%s:pubsub"
+
+// ErrCommandExecTimeout is returned when no response was received for the
+// gateway command execution request before the context deadline.
+var ErrCommandExecTimeout = errors.New("gateway command execution timeout")
+
+// ExecCommand sends the given command execution request to the gateway
+// and waits for the response with the matching ExecId. Use the given
+// context for setting the timeout.
+func ExecCommand(ctx context.Context, p *redis.Pool, req gw.GatewayCommandExecRequest) (gw.GatewayCommandExecResponse, error) {
+	var resp gw.GatewayCommandExecResponse
+	execID := helpers.GetExecID(&req)
+
+	c := p.Get()
+	defer c.Close()
+
+	// subscribe before sending the request, so that the response can't be
+	// missed
+	psc := redis.PubSubConn{Conn: c}
+	if err := psc.Subscribe(fmt.Sprintf(commandExecResponsePubSubKeyTempl, execID)); err != nil {
+		return resp, errors.Wrap(err, "subscribe error")
+	}
+	defer psc.Unsubscribe()
+
+	respChan := make(chan gw.GatewayCommandExecResponse, 1)
+	errChan := make(chan error, 1)
+
+	go func() {
+		for {
+			switch v := psc.Receive().(type) {
+			case redis.Message:
+				var resp gw.GatewayCommandExecResponse
+				if err := proto.Unmarshal(v.Data, &resp); err != nil {
+					errChan <- errors.Wrap(err, "unmarshal response error")
+				} else {
+					respChan <- resp
+				}
+				return
+			case redis.Subscription:
+				if v.Count == 0 {
+					return
+				}
+			case error:
+				errChan <- v
+				return
+			}
+		}
+	}()
+
+	if err := gateway.Backend().SendGatewayCommandExecRequest(req); err != nil {
+		return resp, errors.Wrap(err, "send gateway command execution request error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": helpers.GetGatewayID(&req),
+		"exec_id":    execID,
+		"command":    req.Command,
+	}).Info("gateway: command execution request sent")
+
+	select {
+	case resp = <-respChan:
+		return resp, nil
+	case err := <-errChan:
+		return resp, errors.Wrap(err, "receive response error")
+	case <-ctx.Done():
+		return resp, ErrCommandExecTimeout
+	}
+}
+
+// HandleGatewayCommandExecResponse handles the given gateway command
+// execution response, by publishing it to the waiting ExecCommand caller.
+func HandleGatewayCommandExecResponse(ctx context.Context, p *redis.Pool, resp gw.GatewayCommandExecResponse) error {
+	execID := helpers.GetExecID(&resp)
+
+	b, err := proto.Marshal(&resp)
+	if err != nil {
+		return errors.Wrap(err, "marshal response error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err = c.Do("PUBLISH", fmt.Sprintf(commandExecResponsePubSubKeyTempl, execID), b)
+	if err != nil {
+		return errors.Wrap(err, "publish response error")
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+// Package monitor implements the gateway connection-state monitor. It marks
+// gateways as offline when no stats have been received within the expected
+// stats interval and reports state changes to the application-server.
+package monitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/api/as"
+	"github.com/brocaar/chirpstack-network-server/api/common"
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+const monitorBatchSize = 100
+
+var (
+	monitorInterval      time.Duration
+	defaultStatsInterval time.Duration
+	missedStatsThreshold int
+)
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	monitorConf := conf.NetworkServer.Gateway.Monitor
+
+	monitorInterval = monitorConf.Interval
+	defaultStatsInterval = monitorConf.DefaultStatsInterval
+	missedStatsThreshold = monitorConf.MissedStatsThreshold
+
+	return nil
+}
+
+// MonitorLoop starts an infinite loop marking the gateways from which no
+// stats have been received within the expected interval as offline.
+func MonitorLoop() {
+	for {
+		ctx := context.Background()
+		ctxID, err := uuid.NewV4()
+		if err != nil {
+			log.WithError(err).Error("get new uuid error")
+		}
+		ctx = context.WithValue(ctx, logging.ContextIDKey, ctxID)
+
+		log.WithFields(log.Fields{
+			"ctx_id": ctxID,
+		}).Debug("running gateway monitor batch")
+
+		if err := MarkOfflineGateways(ctx, monitorBatchSize); err != nil {
+			log.WithFields(log.Fields{
+				"ctx_id": ctxID,
+			}).WithError(err).Error("gateway monitor error")
+		}
+		time.Sleep(monitorInterval)
+	}
+}
+
+// MarkOfflineGateways marks (a batch of) the gateways that missed the
+// configured number of stats intervals as offline.
+func MarkOfflineGateways(ctx context.Context, size int) error {
+	gws, err := storage.GetOfflineGateways(ctx, storage.DB(), defaultStatsInterval, missedStatsThreshold, size)
+	if err != nil {
+		return errors.Wrap(err, "get offline gateways error")
+	}
+
+	for _, gw := range gws {
+		if err := SetGatewayState(ctx, gw.GatewayID, storage.GatewayStateOffline); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"gateway_id": gw.GatewayID,
+				"ctx_id":     ctx.Value(logging.ContextIDKey),
+			}).Error("set gateway state error")
+		}
+	}
+
+	return nil
+}
+
+// HandleConnState handles the connection-state message sent by the gateway
+// (e.g. the MQTT last-will message of the ChirpStack Gateway Bridge).
+func HandleConnState(ctx context.Context, connState gw.ConnState) error {
+	gatewayID := helpers.GetGatewayID(&connState)
+
+	state := storage.GatewayStateOffline
+	if connState.State == gw.ConnState_ONLINE {
+		state = storage.GatewayStateOnline
+	}
+
+	return SetGatewayState(ctx, gatewayID, state)
+}
+
+// SetGatewayState sets the state of the given gateway. When this results in
+// a state change, the cached gateway is flushed and the state change is
+// reported to the application-server.
+func SetGatewayState(ctx context.Context, gatewayID lorawan.EUI64, state storage.GatewayState) error {
+	now := time.Now()
+	var changed bool
+
+	err := storage.Transaction(func(tx sqlx.Ext) error {
+		var err error
+		changed, err = storage.SetGatewayState(ctx, tx, gatewayID, state, now)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "set gateway state error")
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := storage.FlushGatewayCache(ctx, storage.RedisPool(), gatewayID); err != nil {
+		return errors.Wrap(err, "flush gateway cache error")
+	}
+
+	if err := reportGatewayStateChange(ctx, gatewayID, state, now); err != nil {
+		return errors.Wrap(err, "report gateway state change error")
+	}
+
+	return nil
+}
+
+func reportGatewayStateChange(ctx context.Context, gatewayID lorawan.EUI64, state storage.GatewayState, changedAt time.Time) error {
+	gateway, err := storage.GetGateway(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		return errors.Wrap(err, "get gateway error")
+	}
+
+	rp, err := storage.GetRoutingProfile(ctx, storage.DB(), gateway.RoutingProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get routing-profile error")
+	}
+
+	asClient, err := rp.GetApplicationServerClient()
+	if err != nil {
+		return errors.Wrap(err, "get application-server client error")
+	}
+
+	changedAtPB, err := ptypes.TimestampProto(changedAt)
+	if err != nil {
+		return errors.Wrap(err, "timestamp proto error")
+	}
+
+	_, err = asClient.HandleGatewayStateChange(ctx, &as.HandleGatewayStateChangeRequest{
+		GatewayId: gatewayID[:],
+		State:     GatewayStateToPB(state),
+		ChangedAt: changedAtPB,
+	})
+	if err != nil {
+		return errors.Wrap(err, "handle gateway state change error")
+	}
+
+	return nil
+}
+
+// GatewayStateToPB returns the protobuf representation of the given
+// gateway state.
+func GatewayStateToPB(state storage.GatewayState) common.GatewayState {
+	switch state {
+	case storage.GatewayStateOnline:
+		return common.GatewayState_ONLINE
+	case storage.GatewayStateOffline:
+		return common.GatewayState_OFFLINE
+	default:
+		return common.GatewayState_NEVER_SEEN
+	}
+}
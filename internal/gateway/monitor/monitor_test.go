@@ -0,0 +1,129 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/chirpstack-network-server/api/common"
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/backend/applicationserver"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+type MonitorTestSuite struct {
+	suite.Suite
+
+	asClient *test.ApplicationClient
+	gateway  storage.Gateway
+}
+
+func (ts *MonitorTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+
+	conf.NetworkServer.Gateway.Monitor.DefaultStatsInterval = time.Minute
+	conf.NetworkServer.Gateway.Monitor.MissedStatsThreshold = 3
+	assert.NoError(Setup(conf))
+}
+
+func (ts *MonitorTestSuite) SetupTest() {
+	assert := require.New(ts.T())
+	test.MustResetDB(storage.DB().DB)
+	test.MustFlushRedis(storage.RedisPool())
+
+	ts.asClient = test.NewApplicationClient()
+	applicationserver.SetPool(test.NewApplicationServerPool(ts.asClient))
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	lastSeenAt := time.Now().Add(-5 * time.Minute)
+	ts.gateway = storage.Gateway{
+		GatewayID:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		RoutingProfileID: rp.ID,
+		LastSeenAt:       &lastSeenAt,
+	}
+	assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &ts.gateway))
+}
+
+func (ts *MonitorTestSuite) TestSetGatewayState() {
+	assert := require.New(ts.T())
+
+	assert.NoError(SetGatewayState(context.Background(), ts.gateway.GatewayID, storage.GatewayStateOnline))
+
+	req := <-ts.asClient.HandleGatewayStateChangeChan
+	assert.Equal(ts.gateway.GatewayID[:], req.GatewayId)
+	assert.Equal(common.GatewayState_ONLINE, req.State)
+	assert.NotNil(req.ChangedAt)
+
+	// setting the same state again must not report a state change
+	assert.NoError(SetGatewayState(context.Background(), ts.gateway.GatewayID, storage.GatewayStateOnline))
+	assert.Len(ts.asClient.HandleGatewayStateChangeChan, 0)
+
+	gw, err := storage.GetGateway(context.Background(), storage.DB(), ts.gateway.GatewayID)
+	assert.NoError(err)
+	assert.Equal(storage.GatewayStateOnline, gw.State)
+}
+
+func (ts *MonitorTestSuite) TestMarkOfflineGateways() {
+	assert := require.New(ts.T())
+
+	assert.NoError(SetGatewayState(context.Background(), ts.gateway.GatewayID, storage.GatewayStateOnline))
+	<-ts.asClient.HandleGatewayStateChangeChan
+
+	assert.NoError(MarkOfflineGateways(context.Background(), 10))
+
+	req := <-ts.asClient.HandleGatewayStateChangeChan
+	assert.Equal(ts.gateway.GatewayID[:], req.GatewayId)
+	assert.Equal(common.GatewayState_OFFLINE, req.State)
+
+	changes, err := storage.GetGatewayStateChanges(context.Background(), storage.DB(), ts.gateway.GatewayID, 10)
+	assert.NoError(err)
+	assert.Len(changes, 2)
+	assert.Equal(storage.GatewayStateOffline, changes[0].State)
+	assert.Equal(storage.GatewayStateOnline, changes[1].State)
+}
+
+func (ts *MonitorTestSuite) TestHandleConnState() {
+	tests := []struct {
+		name          string
+		connState     gw.ConnState_State
+		expectedState common.GatewayState
+	}{
+		{
+			name:          "online",
+			connState:     gw.ConnState_ONLINE,
+			expectedState: common.GatewayState_ONLINE,
+		},
+		{
+			name:          "offline",
+			connState:     gw.ConnState_OFFLINE,
+			expectedState: common.GatewayState_OFFLINE,
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(HandleConnState(context.Background(), gw.ConnState{
+				GatewayId: ts.gateway.GatewayID[:],
+				State:     tst.connState,
+			}))
+
+			req := <-ts.asClient.HandleGatewayStateChangeChan
+			assert.Equal(tst.expectedState, req.State)
+		})
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	suite.Run(t, new(MonitorTestSuite))
+}
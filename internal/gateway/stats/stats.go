@@ -12,6 +12,7 @@ import (
 	"github.com/brocaar/chirpstack-network-server/api/gw"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/gateway"
 	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/gateway/monitor"
 	"github.com/brocaar/chirpstack-network-server/internal/helpers"
 	"github.com/brocaar/chirpstack-network-server/internal/logging"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
@@ -79,6 +80,10 @@ func updateGatewayState(ctx *statsContext) error {
 		return errors.Wrap(err, "flush gateway cache error")
 	}
 
+	if err := monitor.SetGatewayState(ctx.ctx, ctx.gateway.GatewayID, storage.GatewayStateOnline); err != nil {
+		return errors.Wrap(err, "set gateway state error")
+	}
+
 	return nil
 }
 
@@ -24,6 +24,11 @@ type DownlinkIDGetter interface {
 	GetDownlinkId() []byte
 }
 
+// ExecIDGetter provides an ExecId getter interface.
+type ExecIDGetter interface {
+	GetExecId() []byte
+}
+
 // DataRateGetter provides an interface for getting the data-rate.
 type DataRateGetter interface {
 	GetModulation() common.Modulation
@@ -131,6 +136,15 @@ func GetDownlinkID(v DownlinkIDGetter) uuid.UUID {
 	return downlinkID
 }
 
+// GetExecID returns the typed command execution ID.
+func GetExecID(v ExecIDGetter) uuid.UUID {
+	var execID uuid.UUID
+	if b := v.GetExecId(); b != nil {
+		copy(execID[:], b)
+	}
+	return execID
+}
+
 // GetDataRateIndex returns the data-rate index.
 func GetDataRateIndex(uplink bool, v DataRateGetter, b band.Band) (int, error) {
 	var dr band.DataRate
@@ -48,6 +48,18 @@ func handleDevStatusAns(ctx context.Context, ds *storage.DeviceSession, sp stora
 		"ctx_id":  ctx.Value(logging.ContextIDKey),
 	}).Info("dev_status_ans answer received")
 
+	if err := storage.SaveDeviceStatusHistoryItem(ctx, storage.RedisPool(), ds.DevEUI, storage.DeviceStatusHistoryItem{
+		Time:    time.Now(),
+		Type:    storage.DeviceStatusDevStatus,
+		Battery: int(pl.Battery),
+		Margin:  int(pl.Margin),
+	}); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ds.DevEUI,
+			"ctx_id":  ctx.Value(logging.ContextIDKey),
+		}).Error("save device-status history item error")
+	}
+
 	if !sp.ReportDevStatusBattery && !sp.ReportDevStatusMargin {
 		log.WithFields(log.Fields{
 			"dev_eui": ds.DevEUI,
@@ -135,6 +135,14 @@ func (ts *DevStatusTestSuite) TestDevStatusAns() {
 			assert.Len(resp, 0)
 
 			assert.Equal(tst.ExpectedSetDeviceStatusRequest, <-asClient.SetDeviceStatusChan)
+
+			items, err := storage.GetDeviceStatusHistory(context.Background(), storage.RedisPool(), tst.DeviceSession.DevEUI, time.Now().Add(-time.Minute), time.Now())
+			assert.NoError(err)
+			assert.NotEqual(0, len(items))
+			item := items[len(items)-1]
+			assert.Equal(storage.DeviceStatusDevStatus, item.Type)
+			assert.Equal(150, item.Battery)
+			assert.Equal(10, item.Margin)
 		})
 	}
 }
@@ -0,0 +1,67 @@
+package maccommand
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// RequestDutyCycle sets the max. aggregated transmit duty-cycle of the
+// device to 1 / 2^maxDCycle.
+func RequestDutyCycle(maxDCycle uint8) storage.MACCommandBlock {
+	return storage.MACCommandBlock{
+		CID: lorawan.DutyCycleReq,
+		MACCommands: []lorawan.MACCommand{
+			{
+				CID: lorawan.DutyCycleReq,
+				Payload: &lorawan.DutyCycleReqPayload{
+					MaxDCycle: maxDCycle,
+				},
+			},
+		},
+	}
+}
+
+// GetMaxDCycle returns the MaxDCycle value for the given max. duty-cycle
+// (in percent). It returns the smallest value for which the aggregated
+// duty-cycle (1 / 2^MaxDCycle) does not exceed the given percentage. A
+// percentage of 0 indicates no limitation.
+func GetMaxDCycle(maxDutyCycle int) uint8 {
+	if maxDutyCycle <= 0 || maxDutyCycle >= 100 {
+		return 0
+	}
+
+	var maxDCycle uint8
+	for maxDCycle < 15 && 100 > maxDutyCycle*(1<<maxDCycle) {
+		maxDCycle++
+	}
+
+	return maxDCycle
+}
+
+func handleDutyCycleAns(ctx context.Context, ds *storage.DeviceSession, block storage.MACCommandBlock, pendingBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
+	if pendingBlock == nil || len(pendingBlock.MACCommands) == 0 {
+		return nil, errors.New("expected pending mac-command")
+	}
+
+	req, ok := pendingBlock.MACCommands[0].Payload.(*lorawan.DutyCycleReqPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected *lorawan.DutyCycleReqPayload, got %T", pendingBlock.MACCommands[0].Payload)
+	}
+
+	ds.MaxDCycle = req.MaxDCycle
+
+	log.WithFields(log.Fields{
+		"dev_eui":     ds.DevEUI,
+		"max_d_cycle": ds.MaxDCycle,
+		"ctx_id":      ctx.Value(logging.ContextIDKey),
+	}).Info("duty_cycle request acknowledged")
+
+	return nil, nil
+}
@@ -0,0 +1,104 @@
+package maccommand
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+func TestDutyCycle(t *testing.T) {
+	t.Run("RequestDutyCycle", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.Equal(storage.MACCommandBlock{
+			CID: lorawan.DutyCycleReq,
+			MACCommands: []lorawan.MACCommand{
+				{
+					CID: lorawan.DutyCycleReq,
+					Payload: &lorawan.DutyCycleReqPayload{
+						MaxDCycle: 4,
+					},
+				},
+			},
+		}, RequestDutyCycle(4))
+	})
+
+	t.Run("GetMaxDCycle", func(t *testing.T) {
+		tests := []struct {
+			MaxDutyCycle      int
+			ExpectedMaxDCycle uint8
+		}{
+			{0, 0},
+			{100, 0},
+			{50, 1},
+			{10, 4},
+			{1, 7},
+		}
+
+		for _, tst := range tests {
+			assert := require.New(t)
+			assert.Equal(tst.ExpectedMaxDCycle, GetMaxDCycle(tst.MaxDutyCycle), "max duty-cycle: %d", tst.MaxDutyCycle)
+		}
+	})
+
+	t.Run("handleDutyCycleAns", func(t *testing.T) {
+		tests := []struct {
+			Name                  string
+			DeviceSession         storage.DeviceSession
+			PendingBlock          *storage.MACCommandBlock
+			ExpectedDeviceSession storage.DeviceSession
+			ExpectedError         error
+		}{
+			{
+				Name: "request acked",
+				DeviceSession: storage.DeviceSession{
+					MaxDCycle: 0,
+				},
+				PendingBlock: &storage.MACCommandBlock{
+					CID: lorawan.DutyCycleReq,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.DutyCycleReq,
+							Payload: &lorawan.DutyCycleReqPayload{
+								MaxDCycle: 4,
+							},
+						},
+					},
+				},
+				ExpectedDeviceSession: storage.DeviceSession{
+					MaxDCycle: 4,
+				},
+			},
+			{
+				Name: "pending missing",
+				DeviceSession: storage.DeviceSession{
+					MaxDCycle: 2,
+				},
+				ExpectedDeviceSession: storage.DeviceSession{
+					MaxDCycle: 2,
+				},
+				ExpectedError: errors.New("expected pending mac-command"),
+			},
+		}
+
+		for _, tst := range tests {
+			t.Run(tst.Name, func(t *testing.T) {
+				assert := require.New(t)
+
+				ret, err := handleDutyCycleAns(context.Background(), &tst.DeviceSession, storage.MACCommandBlock{}, tst.PendingBlock)
+				assert.Nil(ret)
+				assert.Equal(tst.ExpectedDeviceSession, tst.DeviceSession)
+				if err != nil {
+					assert.Equal(tst.ExpectedError.Error(), err.Error())
+				} else {
+					assert.Nil(tst.ExpectedError)
+				}
+			})
+		}
+	})
+}
@@ -3,9 +3,13 @@ package maccommand
 import (
 	"context"
 	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/chirpstack-network-server/api/common"
 	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
 	"github.com/brocaar/chirpstack-network-server/internal/models"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 	"github.com/brocaar/lorawan"
@@ -36,6 +40,18 @@ func handleLinkCheckReq(ctx context.Context, ds *storage.DeviceSession, rxPacket
 		margin = 0
 	}
 
+	if err := storage.SaveDeviceStatusHistoryItem(ctx, storage.RedisPool(), ds.DevEUI, storage.DeviceStatusHistoryItem{
+		Time:         time.Now(),
+		Type:         storage.DeviceStatusLinkCheck,
+		Margin:       int(margin),
+		GatewayCount: len(rxPacket.RXInfoSet),
+	}); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ds.DevEUI,
+			"ctx_id":  ctx.Value(logging.ContextIDKey),
+		}).Error("save device-status history item error")
+	}
+
 	block := storage.MACCommandBlock{
 		CID: lorawan.LinkCheckAns,
 		MACCommands: storage.MACCommands{
@@ -3,6 +3,7 @@ package maccommand
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -65,6 +66,13 @@ func (ts *LinkCheckTestSuite) TestLinkCheckReq() {
 			},
 		},
 	}, resp[0])
+
+	items, err := storage.GetDeviceStatusHistory(ctx, storage.RedisPool(), ds.DevEUI, time.Now().Add(-time.Minute), time.Now())
+	assert.NoError(err)
+	assert.Len(items, 1)
+	assert.Equal(storage.DeviceStatusLinkCheck, items[0].Type)
+	assert.Equal(20, items[0].Margin)
+	assert.Equal(1, items[0].GatewayCount)
 }
 
 func TestLinkCheck(t *testing.T) {
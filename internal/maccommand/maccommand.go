@@ -15,6 +15,8 @@ func Handle(ctx context.Context, ds *storage.DeviceSession, dp storage.DevicePro
 	switch block.CID {
 	case lorawan.LinkADRAns:
 		return handleLinkADRAns(ctx, ds, block, pending)
+	case lorawan.DutyCycleAns:
+		return handleDutyCycleAns(ctx, ds, block, pending)
 	case lorawan.LinkCheckReq:
 		return handleLinkCheckReq(ctx, ds, rxPacket)
 	case lorawan.DevStatusAns:
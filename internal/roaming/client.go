@@ -0,0 +1,167 @@
+package roaming
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// Client defines the roaming client interface, used to send requests to
+// the network-server of a roaming partner.
+type Client interface {
+	PRStartReq(ctx context.Context, pl backend.PRStartReqPayload) (backend.PRStartAnsPayload, error)
+	XmitDataReq(ctx context.Context, pl backend.XmitDataReqPayload) (backend.XmitDataAnsPayload, error)
+}
+
+type client struct {
+	netID      lorawan.NetID
+	server     string
+	httpClient *http.Client
+}
+
+// PRStartReq issues a passive-roaming start request.
+func (c *client) PRStartReq(ctx context.Context, pl backend.PRStartReqPayload) (backend.PRStartAnsPayload, error) {
+	var ans backend.PRStartAnsPayload
+
+	pl.BasePayload = c.getBasePayload(backend.PRStartReq)
+	if err := c.request(ctx, pl, &ans); err != nil {
+		return ans, err
+	}
+
+	if ans.Result.ResultCode != backend.Success {
+		return ans, fmt.Errorf("response error, code: %s, description: %s", ans.Result.ResultCode, ans.Result.Description)
+	}
+
+	return ans, nil
+}
+
+// XmitDataReq issues a transmit data request.
+func (c *client) XmitDataReq(ctx context.Context, pl backend.XmitDataReqPayload) (backend.XmitDataAnsPayload, error) {
+	var ans backend.XmitDataAnsPayload
+
+	pl.BasePayload = c.getBasePayload(backend.XmitDataReq)
+	if err := c.request(ctx, pl, &ans); err != nil {
+		return ans, err
+	}
+
+	if ans.Result.ResultCode != backend.Success {
+		return ans, fmt.Errorf("response error, code: %s, description: %s", ans.Result.ResultCode, ans.Result.Description)
+	}
+
+	return ans, nil
+}
+
+func (c *client) getBasePayload(mt backend.MessageType) backend.BasePayload {
+	return backend.BasePayload{
+		ProtocolVersion: backend.ProtocolVersion1_0,
+		SenderID:        netID.String(),
+		ReceiverID:      c.netID.String(),
+		TransactionID:   rand.Uint32(),
+		MessageType:     mt,
+	}
+}
+
+func (c *client) request(ctx context.Context, pl, ans interface{}) error {
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal request error")
+	}
+
+	req, err := http.NewRequest("POST", c.server, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "new request error")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "http post error")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("expected 2xx response, got: %d (%s)", resp.StatusCode, string(b))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(ans); err != nil {
+		return errors.Wrap(err, "unmarshal response error")
+	}
+
+	return nil
+}
+
+// NewClient creates a new roaming client for the given NetID. The timeout
+// defines the max. duration of a request (0 = no timeout).
+// If the caCert is set, it will configure the CA certificate to validate the
+// server certificate. When the tlsCert and tlsKey are set, then these will
+// be configured as client-certificates for authentication.
+func NewClient(netID lorawan.NetID, server, caCert, tlsCert, tlsKey string, timeout time.Duration) (Client, error) {
+	log.WithFields(log.Fields{
+		"net_id":   netID,
+		"server":   server,
+		"timeout":  timeout,
+		"ca_cert":  caCert,
+		"tls_cert": tlsCert,
+		"tls_key":  tlsKey,
+	}).Info("roaming: configuring roaming client")
+
+	if caCert == "" && tlsCert == "" && tlsKey == "" {
+		return &client{
+			netID:  netID,
+			server: server,
+			httpClient: &http.Client{
+				Timeout: timeout,
+			},
+		}, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if caCert != "" {
+		rawCACert, err := ioutil.ReadFile(caCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "load ca cert error")
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(rawCACert) {
+			return nil, errors.New("append ca cert to pool error")
+		}
+
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if tlsCert != "" || tlsKey != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "load x509 keypair error")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &client{
+		netID:  netID,
+		server: server,
+		httpClient: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		},
+	}, nil
+}
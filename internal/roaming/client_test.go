@@ -0,0 +1,66 @@
+package roaming
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestClient(t *testing.T) {
+	tests := []struct {
+		Name          string
+		StatusCode    int
+		Result        backend.Result
+		ExpectedError bool
+	}{
+		{
+			Name:       "success",
+			StatusCode: http.StatusOK,
+			Result:     backend.Result{ResultCode: backend.Success},
+		},
+		{
+			Name:          "result error",
+			StatusCode:    http.StatusOK,
+			Result:        backend.Result{ResultCode: backend.MICFailed},
+			ExpectedError: true,
+		},
+		{
+			Name:          "non 2xx status code",
+			StatusCode:    http.StatusInternalServerError,
+			Result:        backend.Result{ResultCode: backend.Success},
+			ExpectedError: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tst.StatusCode)
+				json.NewEncoder(w).Encode(backend.XmitDataAnsPayload{
+					Result: tst.Result,
+				})
+			}))
+			defer server.Close()
+
+			client, err := NewClient(lorawan.NetID{1, 2, 3}, server.URL, "", "", "", time.Second)
+			assert.NoError(err)
+
+			_, err = client.XmitDataReq(context.Background(), backend.XmitDataReqPayload{})
+			if tst.ExpectedError {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package roaming
+
+import (
+	"crypto/aes"
+	"fmt"
+
+	keywrap "github.com/NickBall/go-aes-key-wrap"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// WrapKeyEnvelope returns the KeyEnvelope for the given key, encrypted
+// using the KEK matching the given label. When the label is empty, the
+// key is returned unencrypted.
+func WrapKeyEnvelope(label string, key lorawan.AES128Key) (*backend.KeyEnvelope, error) {
+	if label == "" {
+		return &backend.KeyEnvelope{
+			AESKey: backend.HEXBytes(key[:]),
+		}, nil
+	}
+
+	kek, ok := keks[label]
+	if !ok {
+		return nil, fmt.Errorf("unknown kek label: %s", label)
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, errors.Wrap(err, "new cipher error")
+	}
+
+	b, err := keywrap.Wrap(block, key[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "wrap key error")
+	}
+
+	return &backend.KeyEnvelope{
+		KEKLabel: label,
+		AESKey:   backend.HEXBytes(b),
+	}, nil
+}
+
+// UnwrapKeyEnvelope returns the decrypted key from the given KeyEnvelope.
+func UnwrapKeyEnvelope(ke *backend.KeyEnvelope) (lorawan.AES128Key, error) {
+	var key lorawan.AES128Key
+
+	if ke.KEKLabel == "" {
+		copy(key[:], ke.AESKey[:])
+		return key, nil
+	}
+
+	kek, ok := keks[ke.KEKLabel]
+	if !ok {
+		return key, fmt.Errorf("unknown kek label: %s", ke.KEKLabel)
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return key, errors.Wrap(err, "new cipher error")
+	}
+
+	b, err := keywrap.Unwrap(block, ke.AESKey[:])
+	if err != nil {
+		return key, errors.Wrap(err, "unwrap key error")
+	}
+
+	copy(key[:], b)
+	return key, nil
+}
@@ -0,0 +1,146 @@
+package roaming
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/chirpstack-network-server/api/common"
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/models"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+// rfRegions maps the band names to the Backend Interfaces RF regions.
+var rfRegions = map[loraband.Name]backend.RFRegion{
+	loraband.AS_923:     backend.AS923,
+	loraband.AU_915_928: backend.Australia915,
+	loraband.CN_470_510: backend.China470,
+	loraband.CN_779_787: backend.China779,
+	loraband.EU_433:     backend.EU433,
+	loraband.EU_863_870: backend.EU868,
+	loraband.US_902_928: backend.US902,
+}
+
+var rfRegion backend.RFRegion
+
+// ulTokenTTL defines how long the gateway rx-info referenced by an ULToken
+// is stored. The downlink of the sNS must be received within this duration.
+const ulTokenTTL = time.Minute
+
+// RXPacketToULMetaData returns the ULMetaData for the given RXPacket. The
+// ULToken of each gateway refers to the stored gateway rx-info, so that the
+// downlink can be routed to the same gateway on a XmitDataReq.
+func RXPacketToULMetaData(ctx context.Context, rxPacket models.RXPacket) (backend.ULMetaData, error) {
+	dr, err := helpers.GetDataRateIndex(true, rxPacket.TXInfo, band.Band())
+	if err != nil {
+		return backend.ULMetaData{}, errors.Wrap(err, "get data-rate index error")
+	}
+	freq := float64(rxPacket.TXInfo.Frequency) / 1000000
+	gwCnt := len(rxPacket.RXInfoSet)
+
+	md := backend.ULMetaData{
+		DataRate: &dr,
+		ULFreq:   &freq,
+		RecvTime: backend.ISO8601Time(time.Now().UTC()),
+		RFRegion: rfRegion,
+		GWCnt:    &gwCnt,
+	}
+
+	if macPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload); ok {
+		devAddr := macPL.FHDR.DevAddr
+		md.DevAddr = &devAddr
+	}
+
+	for i := range rxPacket.RXInfoSet {
+		rxInfo := rxPacket.RXInfoSet[i]
+
+		ulToken, err := storage.SavePassiveRoamingULToken(ctx, storage.RedisPool(), rxInfo, ulTokenTTL)
+		if err != nil {
+			return backend.ULMetaData{}, errors.Wrap(err, "save ul token error")
+		}
+
+		rssi := int(rxInfo.Rssi)
+		snr := rxInfo.LoraSnr
+
+		gwInfo := backend.GWInfoElement{
+			ID:        backend.HEXBytes(rxInfo.GatewayId),
+			RFRegion:  rfRegion,
+			RSSI:      &rssi,
+			SNR:       &snr,
+			ULToken:   backend.HEXBytes(ulToken[:]),
+			DLAllowed: true,
+		}
+
+		if rxInfo.Location != nil {
+			lat := rxInfo.Location.Latitude
+			lon := rxInfo.Location.Longitude
+			gwInfo.Lat = &lat
+			gwInfo.Lon = &lon
+		}
+
+		md.GWInfo = append(md.GWInfo, gwInfo)
+	}
+
+	return md, nil
+}
+
+// ULMetaDataToRXPacket returns the RXPacket for the given PHYPayload and
+// ULMetaData, as received from the fNS.
+func ULMetaDataToRXPacket(phy lorawan.PHYPayload, md backend.ULMetaData) (models.RXPacket, error) {
+	if md.DataRate == nil {
+		return models.RXPacket{}, errors.New("DataRate must not be nil")
+	}
+	if md.ULFreq == nil {
+		return models.RXPacket{}, errors.New("ULFreq must not be nil")
+	}
+
+	rxPacket := models.RXPacket{
+		DR:         *md.DataRate,
+		PHYPayload: phy,
+		TXInfo: &gw.UplinkTXInfo{
+			Frequency: uint32(math.Round(*md.ULFreq * 1000000)),
+		},
+	}
+
+	if err := helpers.SetUplinkTXInfoDataRate(rxPacket.TXInfo, *md.DataRate, band.Band()); err != nil {
+		return models.RXPacket{}, errors.Wrap(err, "set uplink tx-info data-rate error")
+	}
+
+	recvTime, err := ptypes.TimestampProto(time.Time(md.RecvTime))
+	if err != nil {
+		return models.RXPacket{}, errors.Wrap(err, "recv time error")
+	}
+
+	for _, gwInfo := range md.GWInfo {
+		rxInfo := gw.UplinkRXInfo{
+			GatewayId: gwInfo.ID,
+			Time:      recvTime,
+		}
+
+		if gwInfo.RSSI != nil {
+			rxInfo.Rssi = int32(*gwInfo.RSSI)
+		}
+		if gwInfo.SNR != nil {
+			rxInfo.LoraSnr = *gwInfo.SNR
+		}
+		if gwInfo.Lat != nil && gwInfo.Lon != nil {
+			rxInfo.Location = &common.Location{
+				Latitude:  *gwInfo.Lat,
+				Longitude: *gwInfo.Lon,
+			}
+		}
+
+		rxPacket.RXInfoSet = append(rxPacket.RXInfoSet, &rxInfo)
+	}
+
+	return rxPacket, nil
+}
@@ -0,0 +1,99 @@
+package roaming
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/api/common"
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/band"
+	"github.com/brocaar/chirpstack-network-server/internal/helpers"
+	"github.com/brocaar/chirpstack-network-server/internal/models"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestULMetaData(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	assert.NoError(Setup(conf))
+	test.MustFlushRedis(storage.RedisPool())
+
+	rxInfo := gw.UplinkRXInfo{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Rssi:      -60,
+		LoraSnr:   5.5,
+		Context:   []byte{1, 2, 3, 4},
+		Location: &common.Location{
+			Latitude:  1.123,
+			Longitude: 2.123,
+		},
+	}
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+			},
+		},
+	}
+
+	rxPacket := models.RXPacket{
+		DR:         3,
+		PHYPayload: phy,
+		TXInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+		RXInfoSet: []*gw.UplinkRXInfo{&rxInfo},
+	}
+	assert.NoError(helpers.SetUplinkTXInfoDataRate(rxPacket.TXInfo, 3, band.Band()))
+
+	md, err := RXPacketToULMetaData(context.Background(), rxPacket)
+	assert.NoError(err)
+
+	assert.Equal(lorawan.DevAddr{1, 2, 3, 4}, *md.DevAddr)
+	assert.Equal(3, *md.DataRate)
+	assert.Equal(868.1, *md.ULFreq)
+	assert.Equal(backend.EU868, md.RFRegion)
+	assert.Equal(1, *md.GWCnt)
+	assert.Len(md.GWInfo, 1)
+	assert.Equal(backend.HEXBytes{1, 2, 3, 4, 5, 6, 7, 8}, md.GWInfo[0].ID)
+	assert.Equal(-60, *md.GWInfo[0].RSSI)
+	assert.Equal(5.5, *md.GWInfo[0].SNR)
+	assert.Equal(1.123, *md.GWInfo[0].Lat)
+	assert.Equal(2.123, *md.GWInfo[0].Lon)
+
+	// the ULToken refers to the stored rx-info
+	ulToken, err := uuid.FromBytes(md.GWInfo[0].ULToken)
+	assert.NoError(err)
+	ulTokenRXInfo, err := storage.GetPassiveRoamingULToken(context.Background(), storage.RedisPool(), ulToken)
+	assert.NoError(err)
+	assert.True(proto.Equal(&rxInfo, &ulTokenRXInfo))
+
+	t.Run("ULMetaDataToRXPacket", func(t *testing.T) {
+		assert := require.New(t)
+
+		rxPacketOut, err := ULMetaDataToRXPacket(phy, md)
+		assert.NoError(err)
+
+		assert.Equal(3, rxPacketOut.DR)
+		assert.True(proto.Equal(rxPacket.TXInfo, rxPacketOut.TXInfo))
+		assert.Len(rxPacketOut.RXInfoSet, 1)
+		assert.Equal(rxInfo.GatewayId, rxPacketOut.RXInfoSet[0].GatewayId)
+		assert.Equal(rxInfo.Rssi, rxPacketOut.RXInfoSet[0].Rssi)
+		assert.Equal(rxInfo.LoraSnr, rxPacketOut.RXInfoSet[0].LoraSnr)
+		assert.True(proto.Equal(rxInfo.Location, rxPacketOut.RXInfoSet[0].Location))
+	})
+}
@@ -0,0 +1,179 @@
+// Package roaming implements the LoRaWAN Backend Interfaces roaming
+// agreements, used by the forwarding (fNS) and serving (sNS) network-server.
+package roaming
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/internal/config"
+	"github.com/brocaar/lorawan"
+)
+
+// ErrNoAgreement is returned when there is no roaming agreement for the
+// requested NetID.
+var ErrNoAgreement = errors.New("no roaming agreement")
+
+type agreement struct {
+	netID                  lorawan.NetID
+	passiveRoaming         bool
+	passiveRoamingLifetime time.Duration
+	passiveRoamingKEKLabel string
+	client                 Client
+}
+
+var (
+	netID          lorawan.NetID
+	requestTimeout time.Duration
+	agreements     []agreement
+	keks           map[string][]byte
+)
+
+// Setup configures the roaming agreements.
+func Setup(conf config.Config) error {
+	netID = conf.NetworkServer.NetID
+	requestTimeout = conf.Roaming.RequestTimeout
+	rfRegion = rfRegions[conf.NetworkServer.Band.Name]
+	agreements = nil
+	keks = make(map[string][]byte)
+
+	for _, s := range conf.Roaming.Servers {
+		var a agreement
+		if err := a.netID.UnmarshalText([]byte(s.NetID)); err != nil {
+			return errors.Wrap(err, "roaming: decode net_id error")
+		}
+
+		if a.netID == netID {
+			return fmt.Errorf("roaming: net_id %s is equal to the network-server net_id", a.netID)
+		}
+
+		client, err := NewClient(a.netID, s.Server, s.CACert, s.TLSCert, s.TLSKey, requestTimeout)
+		if err != nil {
+			return errors.Wrapf(err, "roaming: create client for net_id %s error", a.netID)
+		}
+
+		a.passiveRoaming = s.PassiveRoaming
+		a.passiveRoamingLifetime = s.PassiveRoamingLifetime
+		a.passiveRoamingKEKLabel = s.PassiveRoamingKEKLabel
+		a.client = client
+
+		log.WithFields(log.Fields{
+			"net_id":                   a.netID,
+			"passive_roaming":          a.passiveRoaming,
+			"passive_roaming_lifetime": a.passiveRoamingLifetime,
+		}).Info("roaming: roaming agreement configured")
+
+		agreements = append(agreements, a)
+	}
+
+	for _, k := range conf.Roaming.KEK.Set {
+		kek, err := hex.DecodeString(k.KEK)
+		if err != nil {
+			return errors.Wrap(err, "roaming: decode kek error")
+		}
+
+		keks[k.Label] = kek
+	}
+
+	return nil
+}
+
+// NetID returns the NetID of the network-server.
+func NetID() lorawan.NetID {
+	return netID
+}
+
+// RequestTimeout returns the timeout of the requests to roaming partners.
+func RequestTimeout() time.Duration {
+	return requestTimeout
+}
+
+// IsRoamingDevAddr returns true when the given DevAddr does not belong to
+// the NetID of the network-server, but to the NetID of one of the roaming
+// agreements.
+func IsRoamingDevAddr(devAddr lorawan.DevAddr) bool {
+	if devAddr.IsNetID(netID) {
+		return false
+	}
+
+	return len(GetNetIDsForDevAddr(devAddr)) != 0
+}
+
+// GetNetIDsForDevAddr returns the NetIDs of the passive-roaming agreements
+// matching the given DevAddr. As the DevAddr only contains the NwkID part of
+// the NetID, this might return more than one NetID.
+func GetNetIDsForDevAddr(devAddr lorawan.DevAddr) []lorawan.NetID {
+	var out []lorawan.NetID
+
+	for _, a := range agreements {
+		if a.passiveRoaming && devAddr.IsNetID(a.netID) {
+			out = append(out, a.netID)
+		}
+	}
+
+	return out
+}
+
+// IsPassiveRoamingAllowed returns true when there is a passive-roaming
+// agreement for the given NetID.
+func IsPassiveRoamingAllowed(netID lorawan.NetID) bool {
+	a, err := getAgreement(netID)
+	if err != nil {
+		return false
+	}
+	return a.passiveRoaming
+}
+
+// GetClientForNetID returns the client for the given NetID.
+func GetClientForNetID(netID lorawan.NetID) (Client, error) {
+	a, err := getAgreement(netID)
+	if err != nil {
+		return nil, err
+	}
+	return a.client, nil
+}
+
+// SetClientForNetID sets the client for the given NetID. This is intended
+// for testing.
+func SetClientForNetID(netID lorawan.NetID, client Client) error {
+	for i := range agreements {
+		if agreements[i].netID == netID {
+			agreements[i].client = client
+			return nil
+		}
+	}
+	return ErrNoAgreement
+}
+
+// GetPassiveRoamingLifetime returns the passive-roaming lifetime for the
+// given NetID. A lifetime of 0 indicates a stateless passive-roaming session.
+func GetPassiveRoamingLifetime(netID lorawan.NetID) time.Duration {
+	a, err := getAgreement(netID)
+	if err != nil {
+		return 0
+	}
+	return a.passiveRoamingLifetime
+}
+
+// GetPassiveRoamingKEKLabel returns the KEK label which must be used to
+// encrypt the session-keys send to the given NetID.
+func GetPassiveRoamingKEKLabel(netID lorawan.NetID) string {
+	a, err := getAgreement(netID)
+	if err != nil {
+		return ""
+	}
+	return a.passiveRoamingKEKLabel
+}
+
+func getAgreement(netID lorawan.NetID) (agreement, error) {
+	for _, a := range agreements {
+		if a.netID == netID {
+			return a, nil
+		}
+	}
+	return agreement{}, ErrNoAgreement
+}
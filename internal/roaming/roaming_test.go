@@ -0,0 +1,124 @@
+package roaming
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestRoaming(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	conf.Roaming.Servers = []struct {
+		NetID                  string        `mapstructure:"net_id"`
+		PassiveRoaming         bool          `mapstructure:"passive_roaming"`
+		PassiveRoamingLifetime time.Duration `mapstructure:"passive_roaming_lifetime"`
+		PassiveRoamingKEKLabel string        `mapstructure:"passive_roaming_kek_label"`
+		Server                 string        `mapstructure:"server"`
+		CACert                 string        `mapstructure:"ca_cert"`
+		TLSCert                string        `mapstructure:"tls_cert"`
+		TLSKey                 string        `mapstructure:"tls_key"`
+	}{
+		{
+			NetID:                  "010203",
+			PassiveRoaming:         true,
+			PassiveRoamingLifetime: time.Hour,
+			PassiveRoamingKEKLabel: "010203",
+			Server:                 "http://localhost:1234",
+		},
+		{
+			NetID:  "040506",
+			Server: "http://localhost:1235",
+		},
+	}
+	conf.Roaming.KEK.Set = []struct {
+		Label string
+		KEK   string `mapstructure:"kek"`
+	}{
+		{
+			Label: "010203",
+			KEK:   "00112233445566778899aabbccddeeff",
+		},
+	}
+	assert.NoError(Setup(conf))
+
+	t.Run("IsRoamingDevAddr", func(t *testing.T) {
+		tests := []struct {
+			Name     string
+			NetID    lorawan.NetID
+			Expected bool
+		}{
+			{"own net id", conf.NetworkServer.NetID, false},
+			{"passive-roaming agreement", lorawan.NetID{1, 2, 3}, true},
+			{"agreement without passive-roaming", lorawan.NetID{4, 5, 6}, false},
+			{"no agreement", lorawan.NetID{7, 8, 9}, false},
+		}
+
+		for _, tst := range tests {
+			t.Run(tst.Name, func(t *testing.T) {
+				assert := require.New(t)
+
+				var devAddr lorawan.DevAddr
+				devAddr.SetAddrPrefix(tst.NetID)
+				assert.Equal(tst.Expected, IsRoamingDevAddr(devAddr))
+			})
+		}
+	})
+
+	t.Run("Agreement", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.True(IsPassiveRoamingAllowed(lorawan.NetID{1, 2, 3}))
+		assert.False(IsPassiveRoamingAllowed(lorawan.NetID{4, 5, 6}))
+		assert.Equal(time.Hour, GetPassiveRoamingLifetime(lorawan.NetID{1, 2, 3}))
+		assert.Equal("010203", GetPassiveRoamingKEKLabel(lorawan.NetID{1, 2, 3}))
+
+		_, err := GetClientForNetID(lorawan.NetID{1, 2, 3})
+		assert.NoError(err)
+
+		_, err = GetClientForNetID(lorawan.NetID{7, 8, 9})
+		assert.Equal(ErrNoAgreement, err)
+	})
+
+	t.Run("KeyEnvelope", func(t *testing.T) {
+		key := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+
+		t.Run("Without KEK", func(t *testing.T) {
+			assert := require.New(t)
+
+			ke, err := WrapKeyEnvelope("", key)
+			assert.NoError(err)
+			assert.Equal(&backend.KeyEnvelope{AESKey: backend.HEXBytes(key[:])}, ke)
+
+			keyOut, err := UnwrapKeyEnvelope(ke)
+			assert.NoError(err)
+			assert.Equal(key, keyOut)
+		})
+
+		t.Run("With KEK", func(t *testing.T) {
+			assert := require.New(t)
+
+			ke, err := WrapKeyEnvelope("010203", key)
+			assert.NoError(err)
+			assert.Equal("010203", ke.KEKLabel)
+			assert.NotEqual(backend.HEXBytes(key[:]), ke.AESKey)
+
+			keyOut, err := UnwrapKeyEnvelope(ke)
+			assert.NoError(err)
+			assert.Equal(key, keyOut)
+		})
+
+		t.Run("Unknown KEK", func(t *testing.T) {
+			assert := require.New(t)
+
+			_, err := WrapKeyEnvelope("040506", key)
+			assert.Error(err)
+		})
+	})
+}
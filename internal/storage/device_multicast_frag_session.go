@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/lorawan"
+)
+
+// MulticastFragSessionState defines the state of a fragmentation session
+// (Fragmented Data Block Transport) on a device.
+type MulticastFragSessionState string
+
+// Fragmentation session states.
+const (
+	// The fragments have been enqueued.
+	MulticastFragSessionPending MulticastFragSessionState = "PENDING"
+
+	// The device acknowledged the FragSessionSetupReq.
+	MulticastFragSessionCreated MulticastFragSessionState = "CREATED"
+
+	// The device rejected the FragSessionSetupReq.
+	MulticastFragSessionFailed MulticastFragSessionState = "FAILED"
+
+	// The device reported missing fragments.
+	MulticastFragSessionIncomplete MulticastFragSessionState = "INCOMPLETE"
+
+	// The device reported that the data block has been reconstructed.
+	MulticastFragSessionCompleted MulticastFragSessionState = "COMPLETED"
+)
+
+// Fragmentation session errors, as reported by the device.
+const (
+	MulticastFragSessionErrorWrongDescriptor              = "WRONG_DESCRIPTOR"
+	MulticastFragSessionErrorFragSessionIndexNotSupported = "FRAG_SESSION_INDEX_NOT_SUPPORTED"
+	MulticastFragSessionErrorNotEnoughMemory              = "NOT_ENOUGH_MEMORY"
+	MulticastFragSessionErrorEncodingUnsupported          = "ENCODING_UNSUPPORTED"
+	MulticastFragSessionErrorNotEnoughMatrixMemory        = "NOT_ENOUGH_MATRIX_MEMORY"
+)
+
+// DeviceMulticastFragSession defines the fragmentation session of a
+// multicast-group on a device.
+type DeviceMulticastFragSession struct {
+	DevEUI           lorawan.EUI64             `db:"dev_eui"`
+	MulticastGroupID uuid.UUID                 `db:"multicast_group_id"`
+	FragIndex        int                       `db:"frag_index"`
+	CreatedAt        time.Time                 `db:"created_at"`
+	UpdatedAt        time.Time                 `db:"updated_at"`
+	NbFrag           int                       `db:"nb_frag"`
+	State            MulticastFragSessionState `db:"state"`
+	Error            string                    `db:"error"`
+	NbFragReceived   int                       `db:"nb_frag_received"`
+	MissingFrag      int                       `db:"missing_frag"`
+}
+
+// SaveDeviceMulticastFragSession creates the given fragmentation session, or
+// updates it when it already exists for the device, multicast-group and
+// fragmentation session index.
+func SaveDeviceMulticastFragSession(ctx context.Context, db sqlx.Queryer, s *DeviceMulticastFragSession) error {
+	now := time.Now()
+	s.CreatedAt = now
+	s.UpdatedAt = now
+
+	err := sqlx.Get(db, s, `
+		insert into device_multicast_frag_session (
+			dev_eui,
+			multicast_group_id,
+			frag_index,
+			created_at,
+			updated_at,
+			nb_frag,
+			state,
+			error,
+			nb_frag_received,
+			missing_frag
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		on conflict (multicast_group_id, dev_eui, frag_index)
+			do update set
+				updated_at = excluded.updated_at,
+				nb_frag = excluded.nb_frag,
+				state = excluded.state,
+				error = excluded.error,
+				nb_frag_received = excluded.nb_frag_received,
+				missing_frag = excluded.missing_frag
+		returning
+			*`,
+		s.DevEUI[:],
+		s.MulticastGroupID,
+		s.FragIndex,
+		s.CreatedAt,
+		s.UpdatedAt,
+		s.NbFrag,
+		s.State,
+		s.Error,
+		s.NbFragReceived,
+		s.MissingFrag,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":            s.DevEUI,
+		"multicast_group_id": s.MulticastGroupID,
+		"frag_index":         s.FragIndex,
+		"state":              s.State,
+		"error":              s.Error,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info("device multicast fragmentation session saved")
+
+	return nil
+}
+
+// GetDeviceMulticastFragSessionForFragIndex returns the most recent
+// fragmentation session of the given device, for the given fragmentation
+// session index.
+func GetDeviceMulticastFragSessionForFragIndex(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64, fragIndex int) (DeviceMulticastFragSession, error) {
+	var s DeviceMulticastFragSession
+	err := sqlx.Get(db, &s, `
+		select
+			*
+		from
+			device_multicast_frag_session
+		where
+			dev_eui = $1
+			and frag_index = $2
+		order by
+			updated_at desc
+		limit 1`,
+		devEUI[:],
+		fragIndex,
+	)
+	if err != nil {
+		return s, handlePSQLError(err, "select error")
+	}
+
+	return s, nil
+}
+
+// GetDeviceMulticastFragSessionsForMulticastGroup returns the fragmentation
+// sessions of each device of the given multicast-group.
+func GetDeviceMulticastFragSessionsForMulticastGroup(ctx context.Context, db sqlx.Queryer, multicastGroupID uuid.UUID) ([]DeviceMulticastFragSession, error) {
+	var out []DeviceMulticastFragSession
+	err := sqlx.Select(db, &out, `
+		select
+			*
+		from
+			device_multicast_frag_session
+		where
+			multicast_group_id = $1
+		order by
+			dev_eui,
+			frag_index`,
+		multicastGroupID,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return out, nil
+}
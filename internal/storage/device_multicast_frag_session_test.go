@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestDeviceMulticastFragSession() {
+	assert := require.New(ts.T())
+
+	mg1 := ts.GetMulticastGroup()
+	assert.NoError(CreateMulticastGroup(context.Background(), ts.Tx(), &mg1))
+	mg2 := ts.GetMulticastGroup()
+	assert.NoError(CreateMulticastGroup(context.Background(), ts.Tx(), &mg2))
+
+	dp := DeviceProfile{}
+	assert.NoError(CreateDeviceProfile(context.Background(), ts.Tx(), &dp))
+
+	d := Device{
+		DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		ServiceProfileID: mg1.ServiceProfileID,
+		DeviceProfileID:  dp.ID,
+		RoutingProfileID: mg1.RoutingProfileID,
+	}
+	assert.NoError(CreateDevice(context.Background(), ts.Tx(), &d))
+	assert.NoError(AddDeviceToMulticastGroup(context.Background(), ts.Tx(), d.DevEUI, mg1.ID))
+	assert.NoError(AddDeviceToMulticastGroup(context.Background(), ts.Tx(), d.DevEUI, mg2.ID))
+
+	ts.T().Run("Device not in multicast-group", func(t *testing.T) {
+		assert := require.New(t)
+
+		s := DeviceMulticastFragSession{
+			DevEUI:           lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			MulticastGroupID: mg1.ID,
+			State:            MulticastFragSessionPending,
+		}
+		assert.Equal(ErrDoesNotExist, SaveDeviceMulticastFragSession(context.Background(), ts.Tx(), &s))
+	})
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		s1 := DeviceMulticastFragSession{
+			DevEUI:           d.DevEUI,
+			MulticastGroupID: mg1.ID,
+			FragIndex:        1,
+			NbFrag:           10,
+			State:            MulticastFragSessionPending,
+		}
+		assert.NoError(SaveDeviceMulticastFragSession(context.Background(), ts.Tx(), &s1))
+
+		t.Run("Get for frag index", func(t *testing.T) {
+			assert := require.New(t)
+
+			s, err := GetDeviceMulticastFragSessionForFragIndex(context.Background(), ts.Tx(), d.DevEUI, 1)
+			assert.NoError(err)
+			assert.Equal(mg1.ID, s.MulticastGroupID)
+			assert.Equal(10, s.NbFrag)
+			assert.Equal(MulticastFragSessionPending, s.State)
+
+			_, err = GetDeviceMulticastFragSessionForFragIndex(context.Background(), ts.Tx(), d.DevEUI, 2)
+			assert.Equal(ErrDoesNotExist, err)
+		})
+
+		t.Run("Save existing", func(t *testing.T) {
+			assert := require.New(t)
+
+			s1.State = MulticastFragSessionIncomplete
+			s1.NbFragReceived = 8
+			s1.MissingFrag = 2
+			assert.NoError(SaveDeviceMulticastFragSession(context.Background(), ts.Tx(), &s1))
+
+			items, err := GetDeviceMulticastFragSessionsForMulticastGroup(context.Background(), ts.Tx(), mg1.ID)
+			assert.NoError(err)
+			assert.Len(items, 1)
+			assert.Equal(MulticastFragSessionIncomplete, items[0].State)
+			assert.Equal(8, items[0].NbFragReceived)
+			assert.Equal(2, items[0].MissingFrag)
+		})
+
+		t.Run("Re-use frag index", func(t *testing.T) {
+			assert := require.New(t)
+
+			s2 := DeviceMulticastFragSession{
+				DevEUI:           d.DevEUI,
+				MulticastGroupID: mg2.ID,
+				FragIndex:        1,
+				NbFrag:           20,
+				State:            MulticastFragSessionPending,
+			}
+			assert.NoError(SaveDeviceMulticastFragSession(context.Background(), ts.Tx(), &s2))
+
+			s, err := GetDeviceMulticastFragSessionForFragIndex(context.Background(), ts.Tx(), d.DevEUI, 1)
+			assert.NoError(err)
+			assert.Equal(mg2.ID, s.MulticastGroupID)
+		})
+
+		t.Run("Removed from multicast-group", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(RemoveDeviceFromMulticastGroup(context.Background(), ts.Tx(), d.DevEUI, mg1.ID))
+
+			items, err := GetDeviceMulticastFragSessionsForMulticastGroup(context.Background(), ts.Tx(), mg1.ID)
+			assert.NoError(err)
+			assert.Len(items, 0)
+		})
+	})
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/lorawan"
+)
+
+// MulticastSetupState defines the state of the Remote Multicast Setup of a
+// multicast-group on a device.
+type MulticastSetupState string
+
+// Multicast setup states.
+const (
+	// The setup commands have been enqueued.
+	MulticastSetupPending MulticastSetupState = "PENDING"
+
+	// The device acknowledged the McGroupSetupReq.
+	MulticastSetupGroupCreated MulticastSetupState = "GROUP_CREATED"
+
+	// The device acknowledged the McClassBSessionReq or McClassCSessionReq.
+	MulticastSetupCompleted MulticastSetupState = "COMPLETED"
+
+	// The device rejected one of the setup commands.
+	MulticastSetupFailed MulticastSetupState = "FAILED"
+)
+
+// Multicast setup errors, as reported by the device.
+const (
+	MulticastSetupErrorIDError          = "ID_ERROR"
+	MulticastSetupErrorMcGroupUndefined = "MC_GROUP_UNDEFINED"
+	MulticastSetupErrorFreqError        = "FREQ_ERROR"
+	MulticastSetupErrorDRError          = "DR_ERROR"
+)
+
+// DeviceMulticastGroupSetup defines the Remote Multicast Setup of a
+// multicast-group on a device.
+type DeviceMulticastGroupSetup struct {
+	DevEUI           lorawan.EUI64       `db:"dev_eui"`
+	MulticastGroupID uuid.UUID           `db:"multicast_group_id"`
+	CreatedAt        time.Time           `db:"created_at"`
+	UpdatedAt        time.Time           `db:"updated_at"`
+	McGroupID        int                 `db:"mc_group_id"`
+	SessionTime      time.Duration       `db:"session_time"`
+	State            MulticastSetupState `db:"state"`
+	Error            string              `db:"error"`
+}
+
+// SaveDeviceMulticastGroupSetup creates the given multicast setup, or
+// updates it when it already exists for the device and multicast-group.
+func SaveDeviceMulticastGroupSetup(ctx context.Context, db sqlx.Queryer, s *DeviceMulticastGroupSetup) error {
+	now := time.Now()
+	s.CreatedAt = now
+	s.UpdatedAt = now
+
+	err := sqlx.Get(db, s, `
+		insert into device_multicast_group_setup (
+			dev_eui,
+			multicast_group_id,
+			created_at,
+			updated_at,
+			mc_group_id,
+			session_time,
+			state,
+			error
+		) values ($1, $2, $3, $4, $5, $6, $7, $8)
+		on conflict (multicast_group_id, dev_eui)
+			do update set
+				updated_at = excluded.updated_at,
+				mc_group_id = excluded.mc_group_id,
+				session_time = excluded.session_time,
+				state = excluded.state,
+				error = excluded.error
+		returning
+			*`,
+		s.DevEUI[:],
+		s.MulticastGroupID,
+		s.CreatedAt,
+		s.UpdatedAt,
+		s.McGroupID,
+		s.SessionTime,
+		s.State,
+		s.Error,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":            s.DevEUI,
+		"multicast_group_id": s.MulticastGroupID,
+		"mc_group_id":        s.McGroupID,
+		"state":              s.State,
+		"error":              s.Error,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info("device multicast-group setup saved")
+
+	return nil
+}
+
+// GetDeviceMulticastGroupSetupForMcGroupID returns the most recent multicast
+// setup of the given device, for the given multicast-group id on the device.
+func GetDeviceMulticastGroupSetupForMcGroupID(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64, mcGroupID int) (DeviceMulticastGroupSetup, error) {
+	var s DeviceMulticastGroupSetup
+	err := sqlx.Get(db, &s, `
+		select
+			*
+		from
+			device_multicast_group_setup
+		where
+			dev_eui = $1
+			and mc_group_id = $2
+		order by
+			updated_at desc
+		limit 1`,
+		devEUI[:],
+		mcGroupID,
+	)
+	if err != nil {
+		return s, handlePSQLError(err, "select error")
+	}
+
+	return s, nil
+}
+
+// GetDeviceMulticastGroupSetupsForMulticastGroup returns the multicast setup
+// of each device of the given multicast-group.
+func GetDeviceMulticastGroupSetupsForMulticastGroup(ctx context.Context, db sqlx.Queryer, multicastGroupID uuid.UUID) ([]DeviceMulticastGroupSetup, error) {
+	var out []DeviceMulticastGroupSetup
+	err := sqlx.Select(db, &out, `
+		select
+			*
+		from
+			device_multicast_group_setup
+		where
+			multicast_group_id = $1
+		order by
+			dev_eui`,
+		multicastGroupID,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return out, nil
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestDeviceMulticastGroupSetup() {
+	assert := require.New(ts.T())
+
+	mg1 := ts.GetMulticastGroup()
+	assert.NoError(CreateMulticastGroup(context.Background(), ts.Tx(), &mg1))
+	mg2 := ts.GetMulticastGroup()
+	assert.NoError(CreateMulticastGroup(context.Background(), ts.Tx(), &mg2))
+
+	dp := DeviceProfile{}
+	assert.NoError(CreateDeviceProfile(context.Background(), ts.Tx(), &dp))
+
+	d := Device{
+		DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		ServiceProfileID: mg1.ServiceProfileID,
+		DeviceProfileID:  dp.ID,
+		RoutingProfileID: mg1.RoutingProfileID,
+	}
+	assert.NoError(CreateDevice(context.Background(), ts.Tx(), &d))
+	assert.NoError(AddDeviceToMulticastGroup(context.Background(), ts.Tx(), d.DevEUI, mg1.ID))
+	assert.NoError(AddDeviceToMulticastGroup(context.Background(), ts.Tx(), d.DevEUI, mg2.ID))
+
+	ts.T().Run("Device not in multicast-group", func(t *testing.T) {
+		assert := require.New(t)
+
+		s := DeviceMulticastGroupSetup{
+			DevEUI:           lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			MulticastGroupID: mg1.ID,
+			State:            MulticastSetupPending,
+		}
+		assert.Equal(ErrDoesNotExist, SaveDeviceMulticastGroupSetup(context.Background(), ts.Tx(), &s))
+	})
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		s1 := DeviceMulticastGroupSetup{
+			DevEUI:           d.DevEUI,
+			MulticastGroupID: mg1.ID,
+			McGroupID:        1,
+			SessionTime:      100 * time.Second,
+			State:            MulticastSetupPending,
+		}
+		assert.NoError(SaveDeviceMulticastGroupSetup(context.Background(), ts.Tx(), &s1))
+
+		t.Run("Get for mc group id", func(t *testing.T) {
+			assert := require.New(t)
+
+			s, err := GetDeviceMulticastGroupSetupForMcGroupID(context.Background(), ts.Tx(), d.DevEUI, 1)
+			assert.NoError(err)
+			assert.Equal(mg1.ID, s.MulticastGroupID)
+			assert.Equal(100*time.Second, s.SessionTime)
+			assert.Equal(MulticastSetupPending, s.State)
+
+			_, err = GetDeviceMulticastGroupSetupForMcGroupID(context.Background(), ts.Tx(), d.DevEUI, 2)
+			assert.Equal(ErrDoesNotExist, err)
+		})
+
+		t.Run("Save existing", func(t *testing.T) {
+			assert := require.New(t)
+
+			s1.State = MulticastSetupFailed
+			s1.Error = MulticastSetupErrorIDError
+			assert.NoError(SaveDeviceMulticastGroupSetup(context.Background(), ts.Tx(), &s1))
+
+			items, err := GetDeviceMulticastGroupSetupsForMulticastGroup(context.Background(), ts.Tx(), mg1.ID)
+			assert.NoError(err)
+			assert.Len(items, 1)
+			assert.Equal(MulticastSetupFailed, items[0].State)
+			assert.Equal(MulticastSetupErrorIDError, items[0].Error)
+		})
+
+		t.Run("Re-use mc group id", func(t *testing.T) {
+			assert := require.New(t)
+
+			s2 := DeviceMulticastGroupSetup{
+				DevEUI:           d.DevEUI,
+				MulticastGroupID: mg2.ID,
+				McGroupID:        1,
+				SessionTime:      200 * time.Second,
+				State:            MulticastSetupPending,
+			}
+			assert.NoError(SaveDeviceMulticastGroupSetup(context.Background(), ts.Tx(), &s2))
+
+			s, err := GetDeviceMulticastGroupSetupForMcGroupID(context.Background(), ts.Tx(), d.DevEUI, 1)
+			assert.NoError(err)
+			assert.Equal(mg2.ID, s.MulticastGroupID)
+		})
+
+		t.Run("Removed from multicast-group", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(RemoveDeviceFromMulticastGroup(context.Background(), ts.Tx(), d.DevEUI, mg1.ID))
+
+			items, err := GetDeviceMulticastGroupSetupsForMulticastGroup(context.Background(), ts.Tx(), mg1.ID)
+			assert.NoError(err)
+			assert.Len(items, 0)
+		})
+	})
+}
@@ -47,6 +47,7 @@ type DeviceProfile struct {
 	Supports32bitFCnt   bool      `db:"supports_32bit_fcnt"`
 	GeolocBufferTTL     int       `db:"geoloc_buffer_ttl"`
 	GeolocMinBufferSize int       `db:"geoloc_min_buffer_size"`
+	ADRAlgorithmID      string    `db:"adr_algorithm_id"`
 }
 
 // CreateDeviceProfile creates the given device-profile.
@@ -90,8 +91,9 @@ func CreateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
             rf_region,
             supports_32bit_fcnt,
 			geoloc_buffer_ttl,
-			geoloc_min_buffer_size
-        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)`,
+			geoloc_min_buffer_size,
+			adr_algorithm_id
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)`,
 		dp.CreatedAt,
 		dp.UpdatedAt,
 		dp.ID,
@@ -116,6 +118,7 @@ func CreateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
 		dp.Supports32bitFCnt,
 		dp.GeolocBufferTTL,
 		dp.GeolocMinBufferSize,
+		dp.ADRAlgorithmID,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -250,7 +253,8 @@ func GetDeviceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Devic
             rf_region,
             supports_32bit_fcnt,
 			geoloc_buffer_ttl,
-			geoloc_min_buffer_size
+			geoloc_min_buffer_size,
+			adr_algorithm_id
         from device_profile
         where
             device_profile_id = $1
@@ -283,6 +287,7 @@ func GetDeviceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Devic
 		&dp.Supports32bitFCnt,
 		&dp.GeolocBufferTTL,
 		&dp.GeolocMinBufferSize,
+		&dp.ADRAlgorithmID,
 	)
 	if err != nil {
 		return dp, handlePSQLError(err, "select error")
@@ -323,7 +328,8 @@ func UpdateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
             rf_region = $20,
             supports_32bit_fcnt = $21,
 			geoloc_buffer_ttl = $22,
-			geoloc_min_buffer_size = $23
+			geoloc_min_buffer_size = $23,
+			adr_algorithm_id = $24
         where
             device_profile_id = $1`,
 		dp.ID,
@@ -349,6 +355,7 @@ func UpdateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
 		dp.Supports32bitFCnt,
 		dp.GeolocBufferTTL,
 		dp.GeolocMinBufferSize,
+		dp.ADRAlgorithmID,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
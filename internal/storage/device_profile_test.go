@@ -45,6 +45,7 @@ func TestDeviceProfile(t *testing.T) {
 				Supports32bitFCnt:   true,
 				GeolocBufferTTL:     10,
 				GeolocMinBufferSize: 3,
+				ADRAlgorithmID:      "default",
 			}
 
 			So(CreateDeviceProfile(context.Background(), DB(), &dp), ShouldBeNil)
@@ -108,6 +109,7 @@ func TestDeviceProfile(t *testing.T) {
 				dp.Supports32bitFCnt = false
 				dp.GeolocBufferTTL = 20
 				dp.GeolocMinBufferSize = 4
+				dp.ADRAlgorithmID = "conservative"
 
 				So(UpdateDeviceProfile(context.Background(), DB(), &dp), ShouldBeNil)
 				dp.UpdatedAt = dp.UpdatedAt.UTC().Truncate(time.Millisecond)
@@ -8,6 +8,7 @@ import (
 	"crypto/rand"
 	"encoding/gob"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -177,6 +178,10 @@ type DeviceSession struct {
 
 	// Max uplink EIRP limitation.
 	UplinkMaxEIRPIndex uint8
+
+	// Max aggregated duty-cycle (1 / 2^MaxDCycle) as acknowledged by the
+	// device.
+	MaxDCycle uint8
 }
 
 // AppendUplinkHistory appends an UplinkHistory item and makes sure the list
@@ -247,22 +252,95 @@ func (s *DeviceSession) ResetToBootParameters(dp DeviceProfile) {
 	s.TXPowerIndex = 0
 	s.MinSupportedTXPowerIndex = 0
 	s.MaxSupportedTXPowerIndex = 0
-	s.ExtraUplinkChannels = make(map[int]loraband.Channel)
 	s.RXDelay = uint8(dp.RXDelay1)
 	s.RX1DROffset = uint8(dp.RXDROffset1)
 	s.RX2DR = uint8(dp.RXDataRate2)
 	s.RX2Frequency = int(dp.RXFreq2)
-	s.EnabledUplinkChannels = band.Band().GetStandardUplinkChannelIndices() // TODO: replace by ServiceProfile.ChannelMask?
 	s.ChannelFrequencies = channelFrequencies
+	s.SetFactoryPresetChannels(dp.FactoryPresetFreqs)
 	s.PingSlotDR = dp.PingSlotDR
 	s.PingSlotFrequency = int(dp.PingSlotFreq)
 	s.NbTrans = 1
+	s.MaxDCycle = 0
 
 	if dp.PingSlotPeriod != 0 {
 		s.PingSlotNb = (1 << 12) / dp.PingSlotPeriod
 	}
 }
 
+// SetFactoryPresetChannels sets the enabled and extra uplink channels of
+// the device-session to the given factory preset frequencies, the index
+// of each frequency being the channel index on the device. In case no
+// frequencies are given, the standard uplink channels of the band are used.
+//
+// Frequencies matching a standard channel of the band enable this channel.
+// Other frequencies are added as extra uplink channel, using the data-rate
+// range of the first standard channel, in case the band has a custom channel
+// at the same index so that it can be reconfigured using the NewChannelReq
+// mac-command.
+func (s *DeviceSession) SetFactoryPresetChannels(freqs []int) {
+	s.EnabledUplinkChannels = band.Band().GetStandardUplinkChannelIndices()
+	s.ExtraUplinkChannels = make(map[int]loraband.Channel)
+
+	if len(freqs) == 0 {
+		return
+	}
+
+	customChannels := make(map[int]bool)
+	for _, i := range band.Band().GetCustomUplinkChannelIndices() {
+		customChannels[i] = true
+	}
+
+	defaultChannel, err := band.Band().GetUplinkChannel(0)
+	if err != nil {
+		log.WithError(err).Error("get uplink channel error")
+		return
+	}
+
+	enabled := make(map[int]bool)
+	s.EnabledUplinkChannels = nil
+
+	for i, f := range freqs {
+		// standard channel at the same index (dynamic channel-plans)
+		if c, err := band.Band().GetUplinkChannel(i); err == nil && !customChannels[i] && c.Frequency == f {
+			if !enabled[i] {
+				enabled[i] = true
+				s.EnabledUplinkChannels = append(s.EnabledUplinkChannels, i)
+			}
+			continue
+		}
+
+		// standard channel at another index (fixed channel-plans)
+		if j, err := band.Band().GetUplinkChannelIndex(f, true); err == nil {
+			if !enabled[j] {
+				enabled[j] = true
+				s.EnabledUplinkChannels = append(s.EnabledUplinkChannels, j)
+			}
+			continue
+		}
+
+		// extra channel
+		if customChannels[i] {
+			enabled[i] = true
+			s.EnabledUplinkChannels = append(s.EnabledUplinkChannels, i)
+			s.ExtraUplinkChannels[i] = loraband.Channel{
+				Frequency: f,
+				MinDR:     defaultChannel.MinDR,
+				MaxDR:     defaultChannel.MaxDR,
+			}
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"dev_eui":   s.DevEUI,
+			"frequency": f,
+			"channel":   i,
+		}).Warning("factory preset frequency does not match a channel of the band, ignoring frequency")
+	}
+
+	sort.Ints(s.EnabledUplinkChannels)
+}
+
 // GetRandomDevAddr returns a random DevAddr, prefixed with NwkID based on the
 // given NetID.
 func GetRandomDevAddr(netID lorawan.NetID) (lorawan.DevAddr, error) {
@@ -670,6 +748,7 @@ func deviceSessionToPB(d DeviceSession) DeviceSessionPB {
 		UplinkDwellTime_400Ms:   d.UplinkDwellTime400ms,
 		DownlinkDwellTime_400Ms: d.DownlinkDwellTime400ms,
 		UplinkMaxEirpIndex:      uint32(d.UplinkMaxEIRPIndex),
+		MaxDCycle:               uint32(d.MaxDCycle),
 	}
 
 	if d.AppSKeyEvelope != nil {
@@ -764,6 +843,7 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 		UplinkDwellTime400ms:   d.UplinkDwellTime_400Ms,
 		DownlinkDwellTime400ms: d.DownlinkDwellTime_400Ms,
 		UplinkMaxEIRPIndex:     uint8(d.UplinkMaxEirpIndex),
+		MaxDCycle:              uint8(d.MaxDCycle),
 	}
 
 	if d.LastDeviceStatusRequestTimeUnixNs > 0 {
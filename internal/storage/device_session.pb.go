@@ -250,7 +250,9 @@ type DeviceSessionPB struct {
 	// DownlinkDwellTime.
 	DownlinkDwellTime_400Ms bool `protobuf:"varint,48,opt,name=downlink_dwell_time_400ms,json=downlinkDwellTime400ms,proto3" json:"downlink_dwell_time_400ms,omitempty"`
 	// Uplink max. EIRP index.
-	UplinkMaxEirpIndex   uint32   `protobuf:"varint,49,opt,name=uplink_max_eirp_index,json=uplinkMaxEirpIndex,proto3" json:"uplink_max_eirp_index,omitempty"`
+	UplinkMaxEirpIndex uint32 `protobuf:"varint,49,opt,name=uplink_max_eirp_index,json=uplinkMaxEirpIndex,proto3" json:"uplink_max_eirp_index,omitempty"`
+	// Max. aggregated duty-cycle (1 / 2^max_d_cycle).
+	MaxDCycle            uint32   `protobuf:"varint,50,opt,name=max_d_cycle,json=maxDCycle,proto3" json:"max_d_cycle,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -596,6 +598,13 @@ func (m *DeviceSessionPB) GetUplinkMaxEirpIndex() uint32 {
 	return 0
 }
 
+func (m *DeviceSessionPB) GetMaxDCycle() uint32 {
+	if m != nil {
+		return m.MaxDCycle
+	}
+	return 0
+}
+
 type DeviceGatewayRXInfoSetPB struct {
 	// Device EUI.
 	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
@@ -751,88 +760,89 @@ func init() {
 func init() { proto.RegisterFile("device_session.proto", fileDescriptor_958563bbc6ebadf7) }
 
 var fileDescriptor_958563bbc6ebadf7 = []byte{
-	// 1326 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x56, 0xed, 0x56, 0x1b, 0x37,
-	0x13, 0x3e, 0xc6, 0xe1, 0x6b, 0xc0, 0x01, 0xc4, 0x97, 0xe0, 0x0d, 0x2f, 0x8e, 0x93, 0xf7, 0x8d,
-	0x9b, 0x26, 0x04, 0x68, 0xd2, 0x93, 0xe6, 0x47, 0x4f, 0x09, 0x26, 0x2d, 0x27, 0x0d, 0xe5, 0x2c,
-	0x24, 0xa7, 0xff, 0x74, 0xe4, 0x95, 0x4c, 0x54, 0xaf, 0xb5, 0x5b, 0xad, 0x8c, 0xd7, 0xb7, 0xd2,
-	0x9b, 0xe8, 0x95, 0xf5, 0x1e, 0x7a, 0x34, 0x92, 0x6d, 0x6c, 0xe0, 0x97, 0xad, 0xe7, 0x79, 0x66,
-	0xa4, 0x1d, 0xcd, 0x8c, 0x06, 0xd6, 0x84, 0xbc, 0x56, 0xb1, 0x64, 0xb9, 0xcc, 0x73, 0x95, 0xea,
-	0xbd, 0xcc, 0xa4, 0x36, 0x25, 0xb3, 0xb9, 0x4d, 0x0d, 0xbf, 0x92, 0xdb, 0x9b, 0x3c, 0x53, 0xaf,
-	0xe2, 0xb4, 0xd3, 0x49, 0x75, 0xf8, 0xf1, 0x8a, 0x9a, 0x80, 0x8d, 0x06, 0x5a, 0x5e, 0x78, 0xc3,
-	0xf3, 0xf7, 0xc7, 0x5f, 0xb9, 0xd6, 0x32, 0x21, 0x8f, 0x60, 0xbe, 0x65, 0xe4, 0x9f, 0x5d, 0xa9,
-	0xe3, 0x3e, 0x2d, 0x55, 0x4b, 0xf5, 0x4a, 0x34, 0x02, 0xc8, 0x3a, 0xcc, 0x74, 0x94, 0x66, 0xc2,
-	0xd0, 0x29, 0xa4, 0xa6, 0x3b, 0x4a, 0x37, 0x0c, 0xc2, 0xbc, 0x70, 0x70, 0x39, 0xc0, 0xbc, 0x68,
-	0x98, 0xda, 0x5f, 0x25, 0xd8, 0x9d, 0xd8, 0xe6, 0x73, 0x96, 0x28, 0xdd, 0x3e, 0x6a, 0x44, 0xbf,
-	0x28, 0x77, 0xc8, 0x3e, 0x59, 0x85, 0xe9, 0x16, 0x8b, 0xb5, 0x0d, 0x7b, 0x3d, 0x68, 0x1d, 0x6b,
-	0x4b, 0x36, 0x61, 0xd6, 0xf9, 0xcb, 0xb5, 0xdf, 0x67, 0x2a, 0x72, 0xee, 0x2f, 0xb4, 0x21, 0x4f,
-	0xe1, 0xa1, 0x2d, 0x58, 0x96, 0xf6, 0xa4, 0x61, 0x4a, 0x0b, 0x59, 0x84, 0x0d, 0x17, 0x6d, 0x71,
-	0xee, 0xc0, 0x53, 0x87, 0x91, 0x27, 0x50, 0xb9, 0xe2, 0x56, 0xf6, 0x78, 0x9f, 0xc5, 0x69, 0x57,
-	0x5b, 0xfa, 0xc0, 0x8b, 0x02, 0x78, 0xec, 0xb0, 0xda, 0x3f, 0xcb, 0xb0, 0x34, 0x71, 0x38, 0xf2,
-	0x1c, 0x56, 0x42, 0x40, 0x33, 0x93, 0xb6, 0x54, 0x22, 0x99, 0x12, 0x78, 0xb0, 0xf9, 0x68, 0xc9,
-	0x13, 0xe7, 0x1e, 0x3f, 0x15, 0xe4, 0x05, 0x90, 0x5c, 0x9a, 0x49, 0xf1, 0x14, 0x8a, 0x97, 0x03,
-	0x33, 0xa6, 0x36, 0x69, 0xd7, 0x2a, 0x7d, 0x75, 0x53, 0x5d, 0xf6, 0xea, 0xc0, 0x8c, 0xd4, 0x5b,
-	0x30, 0x27, 0xe4, 0x35, 0xe3, 0x42, 0x18, 0x3c, 0xfb, 0x62, 0x34, 0x2b, 0xe4, 0xf5, 0x91, 0x10,
-	0xc6, 0x85, 0xc6, 0x51, 0xb2, 0xab, 0xe8, 0x34, 0x32, 0x33, 0x42, 0x5e, 0x9f, 0x74, 0x95, 0xb3,
-	0xf9, 0x23, 0x55, 0x1a, 0x99, 0x19, 0x6f, 0xe3, 0xd6, 0x8e, 0x7a, 0x0a, 0x4b, 0x2d, 0xa6, 0x7b,
-	0x6d, 0x96, 0x33, 0xa5, 0x2d, 0x6b, 0xcb, 0x3e, 0x9d, 0x45, 0xc5, 0x42, 0xeb, 0xac, 0xd7, 0xbe,
-	0x38, 0xd5, 0xf6, 0xa3, 0xec, 0x3b, 0x55, 0x3e, 0xa1, 0x9a, 0xf3, 0xaa, 0xfc, 0x86, 0xea, 0x31,
-	0x54, 0xbc, 0x46, 0xea, 0x18, 0x35, 0xf3, 0xa8, 0x01, 0xdd, 0x6b, 0x5f, 0x9c, 0xe8, 0xd8, 0x49,
-	0x7e, 0x02, 0xc2, 0xb3, 0x8c, 0xe5, 0x8e, 0x66, 0x52, 0x5f, 0xcb, 0x24, 0xcd, 0x24, 0x7d, 0x59,
-	0x2d, 0xd5, 0x17, 0x0e, 0x57, 0xf7, 0x42, 0x1e, 0x7e, 0x94, 0xfd, 0x93, 0x40, 0x45, 0x4b, 0x3c,
-	0xcb, 0x2e, 0x6e, 0x00, 0x84, 0xc2, 0x1c, 0x26, 0x05, 0xeb, 0x66, 0x14, 0xf0, 0xee, 0x66, 0x5c,
-	0x5e, 0x7c, 0xce, 0xc8, 0x2e, 0x2c, 0x6a, 0xe6, 0x39, 0x91, 0xf6, 0x34, 0x5d, 0xf0, 0x19, 0xaa,
-	0x3f, 0x1c, 0x6b, 0xdb, 0x48, 0x7b, 0xda, 0x09, 0xf8, 0x4d, 0xc1, 0xa2, 0x17, 0xf0, 0xa1, 0xe0,
-	0x11, 0x40, 0x9c, 0xea, 0x96, 0xd7, 0xd0, 0x67, 0x48, 0xcf, 0x39, 0xc4, 0x29, 0xc8, 0x33, 0x58,
-	0xce, 0xdb, 0x2a, 0x0b, 0x1e, 0xe2, 0xaf, 0x32, 0x6e, 0xd3, 0x4a, 0xb5, 0x54, 0x9f, 0x8b, 0x2a,
-	0x0e, 0x77, 0x9a, 0x63, 0x07, 0xba, 0x70, 0x9b, 0x82, 0x09, 0x99, 0xf0, 0x3e, 0x7d, 0x88, 0x4e,
-	0x66, 0x4d, 0xd1, 0x70, 0x4b, 0x52, 0x83, 0x8a, 0x29, 0x0e, 0x98, 0x30, 0x2c, 0x6d, 0xb5, 0x72,
-	0x69, 0xe9, 0x12, 0xf2, 0x0b, 0xa6, 0x38, 0x68, 0x98, 0xdf, 0x10, 0x72, 0x15, 0x63, 0x8a, 0x43,
-	0x57, 0x31, 0xcb, 0xbe, 0x62, 0x4c, 0x71, 0xd8, 0x30, 0x2e, 0x73, 0x1d, 0x3c, 0xaa, 0xc0, 0x15,
-	0x9f, 0xb9, 0xa6, 0x38, 0xfc, 0x30, 0x2c, 0xc2, 0xdb, 0x45, 0x40, 0xee, 0x28, 0x82, 0x87, 0x30,
-	0x25, 0x0c, 0x5d, 0x45, 0x66, 0x4a, 0x18, 0xb2, 0x0c, 0x65, 0x2e, 0x0c, 0x5d, 0xc3, 0x8f, 0x71,
-	0x7f, 0xc9, 0x8f, 0xf0, 0x08, 0xab, 0xac, 0x9b, 0x65, 0xa9, 0xb1, 0x52, 0xb0, 0x09, 0xaf, 0xeb,
-	0x68, 0x4b, 0x5d, 0xe9, 0x0d, 0x24, 0x97, 0x37, 0x77, 0xd8, 0x82, 0x39, 0xdd, 0x64, 0xd6, 0x70,
-	0x9d, 0xd3, 0x4d, 0x1f, 0x02, 0xdd, 0xbc, 0x74, 0x4b, 0xf2, 0x3d, 0x6c, 0x4a, 0xcd, 0x9b, 0x89,
-	0x14, 0xac, 0x8b, 0x15, 0xcf, 0x62, 0xdf, 0x5f, 0x72, 0x4a, 0xab, 0xe5, 0x7a, 0x25, 0x5a, 0x0f,
-	0xb4, 0xef, 0x07, 0xa1, 0xf9, 0xe4, 0x44, 0xc2, 0xba, 0x2c, 0xac, 0xe1, 0xb7, 0xac, 0xb6, 0xaa,
-	0xe5, 0xfa, 0xc2, 0xe1, 0xc1, 0x5e, 0xe8, 0x6c, 0x7b, 0x13, 0x95, 0xbb, 0x77, 0xe2, 0xac, 0xc6,
-	0x9d, 0x9d, 0x68, 0x6b, 0xfa, 0xd1, 0xaa, 0xbc, 0xcd, 0x90, 0x57, 0xb0, 0x1a, 0x3c, 0x0f, 0x43,
-	0xad, 0x64, 0x4e, 0xb7, 0xf1, 0x68, 0x24, 0x50, 0x1f, 0x46, 0x0c, 0xf9, 0x02, 0x24, 0x9c, 0x88,
-	0x0b, 0xc3, 0xbe, 0xfa, 0xde, 0x45, 0xff, 0x83, 0x87, 0xaa, 0xdf, 0x77, 0xa8, 0xc9, 0x5e, 0x17,
-	0x2d, 0x7b, 0x1f, 0x47, 0xc2, 0x0c, 0xba, 0x5f, 0x04, 0xcf, 0x12, 0x9e, 0x5b, 0x36, 0x68, 0xe3,
-	0x96, 0xdb, 0x6e, 0xce, 0x70, 0xe3, 0xdc, 0x32, 0xab, 0x3a, 0x92, 0x75, 0xb5, 0x2a, 0x98, 0xce,
-	0xe9, 0x4e, 0xb5, 0x54, 0x2f, 0x47, 0x8f, 0x9d, 0x3c, 0xec, 0x83, 0xe2, 0xc8, 0x6b, 0x2f, 0x55,
-	0x47, 0x7e, 0xd6, 0xaa, 0x38, 0xcb, 0xc9, 0x29, 0xd4, 0xbc, 0xcf, 0xb4, 0xa7, 0xf1, 0xc8, 0xb6,
-	0x40, 0x4f, 0xb9, 0xe5, 0x9d, 0x6c, 0xe8, 0xae, 0x8a, 0xee, 0x76, 0xd0, 0x5d, 0x10, 0x5e, 0x16,
-	0x97, 0x03, 0x59, 0x70, 0xf5, 0x04, 0x2a, 0x4d, 0xc9, 0xe3, 0x54, 0xb3, 0x24, 0x8d, 0xdb, 0x52,
-	0xd0, 0xc7, 0x98, 0x3d, 0x8b, 0x1e, 0xfc, 0x15, 0x31, 0x52, 0x85, 0xc5, 0xcc, 0xf5, 0xb5, 0x3c,
-	0x49, 0x2d, 0xd3, 0x4d, 0x5a, 0xc3, 0x54, 0x00, 0x87, 0x5d, 0x24, 0xa9, 0x3d, 0x6b, 0x8e, 0x2b,
-	0x84, 0xa1, 0x4f, 0xc6, 0x15, 0x0d, 0x43, 0xf6, 0x60, 0x75, 0xa4, 0x18, 0x65, 0xff, 0x53, 0x14,
-	0xae, 0x0c, 0x84, 0xa3, 0x12, 0xd8, 0x85, 0x85, 0x0e, 0x8f, 0xd9, 0xb5, 0x34, 0x2e, 0xd4, 0xf4,
-	0x7f, 0xd8, 0x47, 0xa1, 0xc3, 0xe3, 0x2f, 0x1e, 0xc1, 0xdc, 0x56, 0xfa, 0xfe, 0xdc, 0xfe, 0x7f,
-	0xc8, 0x6d, 0xa5, 0xef, 0xce, 0xed, 0xd7, 0xb0, 0x61, 0x24, 0xf6, 0xd3, 0xc1, 0x65, 0x84, 0x84,
-	0xa5, 0x2f, 0x30, 0x04, 0x6b, 0x9e, 0x0d, 0xd1, 0x3f, 0xf1, 0x1c, 0x79, 0x07, 0xdb, 0x13, 0x56,
-	0xae, 0xc0, 0xf0, 0x0d, 0x62, 0x9a, 0xd6, 0x71, 0xcf, 0x8d, 0x31, 0xcb, 0x4f, 0xbc, 0xc0, 0xe7,
-	0xe8, 0x8c, 0xbc, 0x85, 0xad, 0x3b, 0x6c, 0x31, 0x05, 0x34, 0xfd, 0x06, 0x4d, 0xd7, 0x27, 0x4d,
-	0xdd, 0x7d, 0x9d, 0xb9, 0x7e, 0x10, 0x2c, 0xfd, 0x4e, 0xfb, 0xf4, 0x79, 0xe8, 0x1a, 0x88, 0xa2,
-	0xff, 0x7d, 0x72, 0x04, 0x3b, 0x99, 0xd4, 0xc2, 0x45, 0x39, 0xa8, 0xc7, 0x67, 0x07, 0xfa, 0x2d,
-	0x36, 0xf2, 0xed, 0x20, 0x8a, 0x50, 0x33, 0x96, 0xd1, 0xe4, 0x25, 0x10, 0x23, 0x5b, 0xd2, 0x48,
-	0x1d, 0x4b, 0xc6, 0x13, 0xab, 0x6c, 0x57, 0x48, 0xba, 0x57, 0x2d, 0xd5, 0x4b, 0xd1, 0xca, 0x90,
-	0x39, 0x0a, 0x04, 0x79, 0x03, 0x9b, 0xa1, 0x68, 0x44, 0x4f, 0x26, 0x89, 0xff, 0x96, 0xd7, 0xfb,
-	0xfb, 0x9d, 0x9c, 0xbe, 0xf2, 0x41, 0xf4, 0x74, 0xc3, 0xb1, 0xee, 0x53, 0x90, 0x23, 0x3f, 0xc0,
-	0xd6, 0x30, 0x75, 0x6f, 0x19, 0xee, 0xa3, 0xe1, 0xc6, 0x40, 0x30, 0x61, 0x7a, 0x00, 0xeb, 0x61,
-	0x47, 0x17, 0x3b, 0xa9, 0x4c, 0x16, 0xae, 0xfb, 0x00, 0x03, 0x12, 0x6a, 0xf8, 0x13, 0x2f, 0x4e,
-	0x94, 0xc9, 0xf0, 0xa2, 0xb7, 0xaf, 0x80, 0xde, 0xd7, 0x3b, 0x5c, 0xcb, 0x74, 0x2f, 0x9c, 0x9f,
-	0x4c, 0xdc, 0x5f, 0xf2, 0x06, 0xa6, 0xaf, 0x79, 0xd2, 0x95, 0xf8, 0xce, 0x2f, 0x1c, 0xee, 0xde,
-	0x57, 0xfa, 0xc1, 0x4f, 0xe4, 0xd5, 0xef, 0xa6, 0xde, 0x96, 0x6a, 0x7d, 0xa0, 0x5e, 0xf4, 0xb3,
-	0x9f, 0x42, 0xa2, 0xdf, 0x4f, 0x75, 0x2b, 0xbd, 0x90, 0xf6, 0xfc, 0xfd, 0xcd, 0x47, 0xbd, 0x34,
-	0xf6, 0xa8, 0xfb, 0x26, 0x3e, 0x35, 0x6c, 0xe2, 0xaf, 0x61, 0x5a, 0x59, 0xd9, 0xc9, 0x69, 0x19,
-	0x5b, 0xcf, 0x7f, 0x27, 0xf6, 0x1f, 0x73, 0x7d, 0xfe, 0x3e, 0xf2, 0xe2, 0xda, 0xdf, 0x25, 0x58,
-	0xbf, 0x53, 0x40, 0x76, 0x00, 0x06, 0x93, 0x52, 0x98, 0x74, 0x16, 0xa3, 0xf9, 0x80, 0x9c, 0x0a,
-	0x42, 0xe0, 0x81, 0xc9, 0x73, 0x85, 0x07, 0x98, 0x8e, 0xf0, 0xbf, 0xeb, 0xfa, 0x49, 0x6a, 0x38,
-	0x0e, 0x67, 0x65, 0xbc, 0xfa, 0x59, 0xb7, 0x76, 0xd3, 0xd9, 0x1a, 0x4c, 0x37, 0x53, 0x6e, 0x44,
-	0x98, 0xb7, 0xfc, 0x82, 0x50, 0x98, 0xe5, 0xda, 0x4a, 0xad, 0x39, 0x4e, 0x2c, 0x95, 0x68, 0xb0,
-	0x74, 0x4c, 0x9c, 0x6a, 0x2b, 0x0b, 0x3b, 0x98, 0x58, 0xc2, 0xb2, 0x39, 0x83, 0x63, 0xea, 0x77,
-	0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x4d, 0x3f, 0x46, 0xb2, 0xe0, 0x0a, 0x00, 0x00,
+	// 1340 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x56, 0x6f, 0x53, 0x1b, 0xb7,
+	0x13, 0x1e, 0xe3, 0xf0, 0x6f, 0xc1, 0x01, 0xc4, 0x3f, 0xc1, 0x2f, 0x04, 0xc7, 0xc9, 0xaf, 0x71,
+	0xd3, 0x84, 0x00, 0x4d, 0x3a, 0x69, 0x5e, 0x74, 0x4a, 0x30, 0x69, 0x99, 0x34, 0x94, 0x39, 0x48,
+	0xa6, 0xef, 0x34, 0xf2, 0x49, 0x26, 0xaa, 0xcf, 0xba, 0xab, 0x4e, 0xb6, 0xcf, 0x1f, 0xa2, 0x5f,
+	0xa0, 0x5f, 0xa2, 0x5f, 0xb1, 0xa3, 0x95, 0x8c, 0xb1, 0x03, 0xaf, 0x6c, 0x3d, 0xcf, 0xb3, 0x2b,
+	0xdd, 0x6a, 0x77, 0xb5, 0xb0, 0x26, 0x64, 0x4f, 0xc5, 0x92, 0xe5, 0x32, 0xcf, 0x55, 0xaa, 0xf7,
+	0x32, 0x93, 0xda, 0x94, 0xcc, 0xe6, 0x36, 0x35, 0xfc, 0x4a, 0x6e, 0x6f, 0xf2, 0x4c, 0xbd, 0x8c,
+	0xd3, 0x4e, 0x27, 0xd5, 0xe1, 0xc7, 0x2b, 0x6a, 0x02, 0x36, 0x1a, 0x68, 0x79, 0xe1, 0x0d, 0xcf,
+	0xdf, 0x1d, 0x7f, 0xe1, 0x5a, 0xcb, 0x84, 0x3c, 0x80, 0xf9, 0x96, 0x91, 0x7f, 0x75, 0xa5, 0x8e,
+	0x07, 0xb4, 0x54, 0x2d, 0xd5, 0x2b, 0xd1, 0x08, 0x20, 0xeb, 0x30, 0xd3, 0x51, 0x9a, 0x09, 0x43,
+	0xa7, 0x90, 0x9a, 0xee, 0x28, 0xdd, 0x30, 0x08, 0xf3, 0xc2, 0xc1, 0xe5, 0x00, 0xf3, 0xa2, 0x61,
+	0x6a, 0xff, 0x94, 0x60, 0x77, 0x62, 0x9b, 0x4f, 0x59, 0xa2, 0x74, 0xfb, 0xa8, 0x11, 0xfd, 0xaa,
+	0xdc, 0x21, 0x07, 0x64, 0x15, 0xa6, 0x5b, 0x2c, 0xd6, 0x36, 0xec, 0x75, 0xaf, 0x75, 0xac, 0x2d,
+	0xd9, 0x84, 0x59, 0xe7, 0x2f, 0xd7, 0x7e, 0x9f, 0xa9, 0xc8, 0xb9, 0xbf, 0xd0, 0x86, 0x3c, 0x81,
+	0xfb, 0xb6, 0x60, 0x59, 0xda, 0x97, 0x86, 0x29, 0x2d, 0x64, 0x11, 0x36, 0x5c, 0xb4, 0xc5, 0xb9,
+	0x03, 0x4f, 0x1d, 0x46, 0x1e, 0x43, 0xe5, 0x8a, 0x5b, 0xd9, 0xe7, 0x03, 0x16, 0xa7, 0x5d, 0x6d,
+	0xe9, 0x3d, 0x2f, 0x0a, 0xe0, 0xb1, 0xc3, 0x6a, 0x7f, 0xaf, 0xc0, 0xd2, 0xc4, 0xe1, 0xc8, 0x33,
+	0x58, 0x09, 0x01, 0xcd, 0x4c, 0xda, 0x52, 0x89, 0x64, 0x4a, 0xe0, 0xc1, 0xe6, 0xa3, 0x25, 0x4f,
+	0x9c, 0x7b, 0xfc, 0x54, 0x90, 0xe7, 0x40, 0x72, 0x69, 0x26, 0xc5, 0x53, 0x28, 0x5e, 0x0e, 0xcc,
+	0x98, 0xda, 0xa4, 0x5d, 0xab, 0xf4, 0xd5, 0x4d, 0x75, 0xd9, 0xab, 0x03, 0x33, 0x52, 0x6f, 0xc1,
+	0x9c, 0x90, 0x3d, 0xc6, 0x85, 0x30, 0x78, 0xf6, 0xc5, 0x68, 0x56, 0xc8, 0xde, 0x91, 0x10, 0xc6,
+	0x85, 0xc6, 0x51, 0xb2, 0xab, 0xe8, 0x34, 0x32, 0x33, 0x42, 0xf6, 0x4e, 0xba, 0xca, 0xd9, 0xfc,
+	0x99, 0x2a, 0x8d, 0xcc, 0x8c, 0xb7, 0x71, 0x6b, 0x47, 0x3d, 0x81, 0xa5, 0x16, 0xd3, 0xfd, 0x36,
+	0xcb, 0x99, 0xd2, 0x96, 0xb5, 0xe5, 0x80, 0xce, 0xa2, 0x62, 0xa1, 0x75, 0xd6, 0x6f, 0x5f, 0x9c,
+	0x6a, 0xfb, 0x41, 0x0e, 0x9c, 0x2a, 0x9f, 0x50, 0xcd, 0x79, 0x55, 0x7e, 0x43, 0xf5, 0x08, 0x2a,
+	0x5e, 0x23, 0x75, 0x8c, 0x9a, 0x79, 0xd4, 0x80, 0xee, 0xb7, 0x2f, 0x4e, 0x74, 0xec, 0x24, 0x3f,
+	0x03, 0xe1, 0x59, 0xc6, 0x72, 0x47, 0x33, 0xa9, 0x7b, 0x32, 0x49, 0x33, 0x49, 0x5f, 0x54, 0x4b,
+	0xf5, 0x85, 0xc3, 0xd5, 0xbd, 0x90, 0x87, 0x1f, 0xe4, 0xe0, 0x24, 0x50, 0xd1, 0x12, 0xcf, 0xb2,
+	0x8b, 0x1b, 0x00, 0xa1, 0x30, 0x87, 0x49, 0xc1, 0xba, 0x19, 0x05, 0xbc, 0xbb, 0x19, 0x97, 0x17,
+	0x9f, 0x32, 0xb2, 0x0b, 0x8b, 0x9a, 0x79, 0x4e, 0xa4, 0x7d, 0x4d, 0x17, 0x7c, 0x86, 0xea, 0xf7,
+	0xc7, 0xda, 0x36, 0xd2, 0xbe, 0x76, 0x02, 0x7e, 0x53, 0xb0, 0xe8, 0x05, 0xfc, 0x5a, 0xf0, 0x00,
+	0x20, 0x4e, 0x75, 0xcb, 0x6b, 0xe8, 0x53, 0xa4, 0xe7, 0x1c, 0xe2, 0x14, 0xe4, 0x29, 0x2c, 0xe7,
+	0x6d, 0x95, 0x05, 0x0f, 0xf1, 0x17, 0x19, 0xb7, 0x69, 0xa5, 0x5a, 0xaa, 0xcf, 0x45, 0x15, 0x87,
+	0x3b, 0xcd, 0xb1, 0x03, 0x5d, 0xb8, 0x4d, 0xc1, 0x84, 0x4c, 0xf8, 0x80, 0xde, 0x47, 0x27, 0xb3,
+	0xa6, 0x68, 0xb8, 0x25, 0xa9, 0x41, 0xc5, 0x14, 0x07, 0x4c, 0x18, 0x96, 0xb6, 0x5a, 0xb9, 0xb4,
+	0x74, 0x09, 0xf9, 0x05, 0x53, 0x1c, 0x34, 0xcc, 0xef, 0x08, 0xb9, 0x8a, 0x31, 0xc5, 0xa1, 0xab,
+	0x98, 0x65, 0x5f, 0x31, 0xa6, 0x38, 0x6c, 0x18, 0x97, 0xb9, 0x0e, 0x1e, 0x55, 0xe0, 0x8a, 0xcf,
+	0x5c, 0x53, 0x1c, 0xbe, 0x1f, 0x62, 0xb7, 0x14, 0x01, 0xb9, 0xa5, 0x08, 0xee, 0xc3, 0x94, 0x30,
+	0x74, 0x15, 0x99, 0x29, 0x61, 0xc8, 0x32, 0x94, 0xb9, 0x30, 0x74, 0x0d, 0x3f, 0xc6, 0xfd, 0x25,
+	0x3f, 0xc1, 0x03, 0xac, 0xb2, 0x6e, 0x96, 0xa5, 0xc6, 0x4a, 0xc1, 0x26, 0xbc, 0xae, 0xa3, 0x2d,
+	0x75, 0xa5, 0x37, 0x94, 0x5c, 0xde, 0xdc, 0x61, 0x0b, 0xe6, 0x74, 0x93, 0x59, 0xc3, 0x75, 0x4e,
+	0x37, 0x7d, 0x08, 0x74, 0xf3, 0xd2, 0x2d, 0xc9, 0x0f, 0xb0, 0x29, 0x35, 0x6f, 0x26, 0x52, 0xb0,
+	0x2e, 0x56, 0x3c, 0x8b, 0x7d, 0x7f, 0xc9, 0x29, 0xad, 0x96, 0xeb, 0x95, 0x68, 0x3d, 0xd0, 0xbe,
+	0x1f, 0x84, 0xe6, 0x93, 0x13, 0x09, 0xeb, 0xb2, 0xb0, 0x86, 0x7f, 0x65, 0xb5, 0x55, 0x2d, 0xd7,
+	0x17, 0x0e, 0x0f, 0xf6, 0x42, 0x67, 0xdb, 0x9b, 0xa8, 0xdc, 0xbd, 0x13, 0x67, 0x35, 0xee, 0xec,
+	0x44, 0x5b, 0x33, 0x88, 0x56, 0xe5, 0xd7, 0x0c, 0x79, 0x09, 0xab, 0xc1, 0xf3, 0x75, 0xa8, 0x95,
+	0xcc, 0xe9, 0x36, 0x1e, 0x8d, 0x04, 0xea, 0xfd, 0x88, 0x21, 0x9f, 0x81, 0x84, 0x13, 0x71, 0x61,
+	0xd8, 0x17, 0xdf, 0xbb, 0xe8, 0xff, 0xf0, 0x50, 0xf5, 0xbb, 0x0e, 0x35, 0xd9, 0xeb, 0xa2, 0x65,
+	0xef, 0xe3, 0x48, 0x98, 0x80, 0x90, 0x08, 0x9e, 0x26, 0x3c, 0xb7, 0x6c, 0xd8, 0xc6, 0x2d, 0xb7,
+	0xdd, 0x9c, 0xe1, 0xc6, 0xb9, 0x65, 0x56, 0x75, 0x24, 0xeb, 0x6a, 0x55, 0x30, 0x9d, 0xd3, 0x9d,
+	0x6a, 0xa9, 0x5e, 0x8e, 0x1e, 0x39, 0x79, 0xd8, 0x07, 0xc5, 0x91, 0xd7, 0x5e, 0xaa, 0x8e, 0xfc,
+	0xa4, 0x55, 0x71, 0x96, 0x93, 0x53, 0xa8, 0x79, 0x9f, 0x69, 0x5f, 0xe3, 0x91, 0x6d, 0x81, 0x9e,
+	0x72, 0xcb, 0x3b, 0xd9, 0xb5, 0xbb, 0x2a, 0xba, 0xdb, 0x41, 0x77, 0x41, 0x78, 0x59, 0x5c, 0x0e,
+	0x65, 0xc1, 0xd5, 0x63, 0xa8, 0x34, 0x25, 0x8f, 0x53, 0xcd, 0x92, 0x34, 0x6e, 0x4b, 0x41, 0x1f,
+	0x61, 0xf6, 0x2c, 0x7a, 0xf0, 0x37, 0xc4, 0x48, 0x15, 0x16, 0x33, 0xd7, 0xd7, 0xf2, 0x24, 0xb5,
+	0x4c, 0x37, 0x69, 0x0d, 0x53, 0x01, 0x1c, 0x76, 0x91, 0xa4, 0xf6, 0xac, 0x39, 0xae, 0x10, 0x86,
+	0x3e, 0x1e, 0x57, 0x34, 0x0c, 0xd9, 0x83, 0xd5, 0x91, 0x62, 0x94, 0xfd, 0x4f, 0x50, 0xb8, 0x32,
+	0x14, 0x8e, 0x4a, 0x60, 0x17, 0x16, 0x3a, 0x3c, 0x66, 0x3d, 0x69, 0x5c, 0xa8, 0xe9, 0xff, 0xb1,
+	0x8f, 0x42, 0x87, 0xc7, 0x9f, 0x3d, 0x82, 0xb9, 0xad, 0xf4, 0xdd, 0xb9, 0xfd, 0x4d, 0xc8, 0x6d,
+	0xa5, 0x6f, 0xcf, 0xed, 0x57, 0xb0, 0x61, 0x24, 0xf6, 0xd3, 0xe1, 0x65, 0x84, 0x84, 0xa5, 0xcf,
+	0x31, 0x04, 0x6b, 0x9e, 0x0d, 0xd1, 0x3f, 0xf1, 0x1c, 0x79, 0x0b, 0xdb, 0x13, 0x56, 0xae, 0xc0,
+	0xf0, 0x0d, 0x62, 0x9a, 0xd6, 0x71, 0xcf, 0x8d, 0x31, 0xcb, 0x8f, 0xbc, 0xc0, 0xe7, 0xe8, 0x8c,
+	0xbc, 0x81, 0xad, 0x5b, 0x6c, 0x31, 0x05, 0x34, 0xfd, 0x16, 0x4d, 0xd7, 0x27, 0x4d, 0xdd, 0x7d,
+	0x9d, 0xb9, 0x7e, 0x10, 0x2c, 0xfd, 0x4e, 0xfb, 0xf4, 0x59, 0xe8, 0x1a, 0x88, 0xa2, 0xff, 0x7d,
+	0x72, 0x04, 0x3b, 0x99, 0xd4, 0xc2, 0x45, 0x39, 0xa8, 0xc7, 0x67, 0x07, 0xfa, 0x1d, 0x36, 0xf2,
+	0xed, 0x20, 0x8a, 0x50, 0x33, 0x96, 0xd1, 0xe4, 0x05, 0x10, 0x23, 0x5b, 0xd2, 0x48, 0x1d, 0x4b,
+	0xc6, 0x13, 0xab, 0x6c, 0x57, 0x48, 0xba, 0x57, 0x2d, 0xd5, 0x4b, 0xd1, 0xca, 0x35, 0x73, 0x14,
+	0x08, 0xf2, 0x1a, 0x36, 0x43, 0xd1, 0x88, 0xbe, 0x4c, 0x12, 0xff, 0x2d, 0xaf, 0xf6, 0xf7, 0x3b,
+	0x39, 0x7d, 0xe9, 0x83, 0xe8, 0xe9, 0x86, 0x63, 0xdd, 0xa7, 0x20, 0x47, 0x7e, 0x84, 0xad, 0xeb,
+	0xd4, 0xfd, 0xca, 0x70, 0x1f, 0x0d, 0x37, 0x86, 0x82, 0x09, 0xd3, 0x03, 0x58, 0x0f, 0x3b, 0xba,
+	0xd8, 0x49, 0x65, 0xb2, 0x70, 0xdd, 0x07, 0x18, 0x90, 0x50, 0xc3, 0x1f, 0x79, 0x71, 0xa2, 0x4c,
+	0xe6, 0x2f, 0xfa, 0xa1, 0xcb, 0xa4, 0x82, 0x09, 0x16, 0x0f, 0xe2, 0x44, 0xd2, 0x43, 0xff, 0x5c,
+	0xb8, 0xf9, 0xe5, 0xd8, 0x01, 0xdb, 0x57, 0x40, 0xef, 0xea, 0x2d, 0xae, 0xa5, 0xba, 0x17, 0xd0,
+	0x4f, 0x2e, 0xee, 0x2f, 0x79, 0x0d, 0xd3, 0x3d, 0x9e, 0x74, 0x25, 0xce, 0x01, 0x0b, 0x87, 0xbb,
+	0x77, 0xb5, 0x86, 0xe0, 0x27, 0xf2, 0xea, 0xb7, 0x53, 0x6f, 0x4a, 0xb5, 0x01, 0x50, 0x2f, 0xfa,
+	0xc5, 0x4f, 0x29, 0xd1, 0x1f, 0xa7, 0xba, 0x95, 0x5e, 0x48, 0x7b, 0xfe, 0xee, 0xe6, 0xa3, 0x5f,
+	0x1a, 0x7b, 0xf4, 0x7d, 0x93, 0x9f, 0xba, 0x6e, 0xf2, 0xaf, 0x60, 0x5a, 0x59, 0xd9, 0xc9, 0x69,
+	0x19, 0x5b, 0xd3, 0xc3, 0x89, 0xfd, 0xc7, 0x5c, 0x9f, 0xbf, 0x8b, 0xbc, 0xb8, 0xf6, 0x6f, 0x09,
+	0xd6, 0x6f, 0x15, 0x90, 0x1d, 0x80, 0xe1, 0x24, 0x15, 0x26, 0xa1, 0xc5, 0x68, 0x3e, 0x20, 0xa7,
+	0x82, 0x10, 0xb8, 0x67, 0xf2, 0x5c, 0xe1, 0x01, 0xa6, 0x23, 0xfc, 0xef, 0x5e, 0x85, 0x24, 0x35,
+	0x1c, 0x87, 0xb7, 0x32, 0xa6, 0xc6, 0xac, 0x5b, 0xbb, 0xe9, 0x6d, 0x0d, 0xa6, 0x9b, 0x29, 0x37,
+	0x22, 0xcc, 0x63, 0x7e, 0x41, 0x28, 0xcc, 0x72, 0x6d, 0xa5, 0xd6, 0x1c, 0x27, 0x9a, 0x4a, 0x34,
+	0x5c, 0x3a, 0x26, 0x4e, 0xb5, 0x95, 0x85, 0x1d, 0x4e, 0x34, 0x61, 0xd9, 0x9c, 0xc1, 0x31, 0xf6,
+	0xfb, 0xff, 0x06, 0x00, 0x76, 0xfb, 0x16, 0x43, 0x00, 0x0b, 0x00, 0x00,
 }
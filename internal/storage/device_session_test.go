@@ -91,6 +91,71 @@ func TestUplinkHistory(t *testing.T) {
 	})
 }
 
+func TestSetFactoryPresetChannels(t *testing.T) {
+	_ = test.GetConfig()
+	// reset the band to its default configuration
+	defer test.GetConfig()
+
+	assert := require.New(t)
+	assert.NoError(band.Band().AddChannel(867100000, 0, 5))
+	assert.NoError(band.Band().AddChannel(867300000, 0, 5))
+
+	tests := []struct {
+		Name                          string
+		Frequencies                   []int
+		ExpectedEnabledUplinkChannels []int
+		ExpectedExtraUplinkChannels   map[int]loraband.Channel
+	}{
+		{
+			Name:                          "no factory preset frequencies",
+			ExpectedEnabledUplinkChannels: []int{0, 1, 2},
+			ExpectedExtraUplinkChannels:   map[int]loraband.Channel{},
+		},
+		{
+			Name:                          "standard channels only",
+			Frequencies:                   []int{868100000, 868300000, 868500000},
+			ExpectedEnabledUplinkChannels: []int{0, 1, 2},
+			ExpectedExtraUplinkChannels:   map[int]loraband.Channel{},
+		},
+		{
+			Name:                          "sub-set of standard channels",
+			Frequencies:                   []int{868300000, 868500000},
+			ExpectedEnabledUplinkChannels: []int{1, 2},
+			ExpectedExtraUplinkChannels:   map[int]loraband.Channel{},
+		},
+		{
+			Name:                          "standard and extra channels",
+			Frequencies:                   []int{868100000, 868300000, 868500000, 867100000, 867500000},
+			ExpectedEnabledUplinkChannels: []int{0, 1, 2, 3, 4},
+			ExpectedExtraUplinkChannels: map[int]loraband.Channel{
+				3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+				4: {Frequency: 867500000, MinDR: 0, MaxDR: 5},
+			},
+		},
+		{
+			Name:                          "extra channel without band channel is ignored",
+			Frequencies:                   []int{868100000, 868300000, 868500000, 867100000, 867300000, 867500000},
+			ExpectedEnabledUplinkChannels: []int{0, 1, 2, 3, 4},
+			ExpectedExtraUplinkChannels: map[int]loraband.Channel{
+				3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+				4: {Frequency: 867300000, MinDR: 0, MaxDR: 5},
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var ds DeviceSession
+			ds.SetFactoryPresetChannels(tst.Frequencies)
+
+			assert.Equal(tst.ExpectedEnabledUplinkChannels, ds.EnabledUplinkChannels)
+			assert.Equal(tst.ExpectedExtraUplinkChannels, ds.ExtraUplinkChannels)
+		})
+	}
+}
+
 func TestDeviceSession(t *testing.T) {
 	conf := test.GetConfig()
 	if err := Setup(conf); err != nil {
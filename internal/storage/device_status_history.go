@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	deviceStatusHistoryKeyTempl = "lora:ns:device:%s:status:history"
+)
+
+// DeviceStatusType defines the source of a device-status history item.
+type DeviceStatusType string
+
+// Device-status types.
+const (
+	DeviceStatusDevStatus DeviceStatusType = "DEV_STATUS"
+	DeviceStatusLinkCheck DeviceStatusType = "LINK_CHECK"
+)
+
+// DeviceStatusHistoryItem defines a device-status history item.
+type DeviceStatusHistoryItem struct {
+	// Time at which the status was received.
+	Time time.Time
+
+	// Type of the status (DevStatusAns or LinkCheckReq).
+	Type DeviceStatusType
+
+	// Battery as reported by the DevStatusAns (DEV_STATUS only).
+	Battery int
+
+	// Margin (dB). For DEV_STATUS, this is the demodulation margin as
+	// reported by the device. For LINK_CHECK, this is the link margin as
+	// sent to the device in the LinkCheckAns.
+	Margin int
+
+	// Number of gateways that received the LinkCheckReq (LINK_CHECK only).
+	GatewayCount int
+}
+
+// SaveDeviceStatusHistoryItem stores the given item in the device-status
+// history of the given device. Items older than the device-status history
+// TTL are removed. When the TTL is 0, this is a no-op.
+func SaveDeviceStatusHistoryItem(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, item DeviceStatusHistoryItem) error {
+	// nothing to do
+	if deviceStatusHistoryTTL == 0 {
+		return nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(deviceStatusHistoryKeyTempl, devEUI)
+	score := item.Time.UnixNano() / int64(time.Millisecond)
+	min := time.Now().Add(-deviceStatusHistoryTTL).UnixNano() / int64(time.Millisecond)
+	exp := int64(deviceStatusHistoryTTL) / int64(time.Millisecond)
+
+	member := fmt.Sprintf("%d:%s:%d:%d:%d", item.Time.UnixNano(), item.Type, item.Battery, item.Margin, item.GatewayCount)
+
+	c.Send("MULTI")
+	c.Send("ZADD", key, score, member)
+	c.Send("ZREMRANGEBYSCORE", key, "-inf", fmt.Sprintf("(%d", min))
+	c.Send("PEXPIRE", key, exp)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "redis exec error")
+	}
+
+	return nil
+}
+
+// GetDeviceStatusHistory returns the device-status history items of the
+// given device between start and end (inclusive), sorted by time.
+func GetDeviceStatusHistory(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, start, end time.Time) ([]DeviceStatusHistoryItem, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(deviceStatusHistoryKeyTempl, devEUI)
+	min := start.UnixNano() / int64(time.Millisecond)
+	max := end.UnixNano() / int64(time.Millisecond)
+
+	members, err := redis.Strings(c.Do("ZRANGEBYSCORE", key, min, max))
+	if err != nil {
+		return nil, errors.Wrap(err, "read device-status history error")
+	}
+
+	var out []DeviceStatusHistoryItem
+	for _, m := range members {
+		parts := strings.SplitN(m, ":", 5)
+		if len(parts) != 5 {
+			return nil, fmt.Errorf("invalid device-status history item: %s", m)
+		}
+
+		ts, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse time error")
+		}
+
+		var values [3]int
+		for i := range values {
+			values[i], err = strconv.Atoi(parts[i+2])
+			if err != nil {
+				return nil, errors.Wrap(err, "parse value error")
+			}
+		}
+
+		out = append(out, DeviceStatusHistoryItem{
+			Time:         time.Unix(0, ts),
+			Type:         DeviceStatusType(parts[1]),
+			Battery:      values[0],
+			Margin:       values[1],
+			GatewayCount: values[2],
+		})
+	}
+
+	return out, nil
+}
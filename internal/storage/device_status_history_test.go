@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestDeviceStatusHistory() {
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	now := time.Unix(0, time.Now().UnixNano())
+
+	ts.T().Run("save and get items", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		items := []DeviceStatusHistoryItem{
+			{
+				Time:    now.Add(-2 * time.Second),
+				Type:    DeviceStatusDevStatus,
+				Battery: 254,
+				Margin:  -5,
+			},
+			{
+				Time:         now.Add(-time.Second),
+				Type:         DeviceStatusLinkCheck,
+				Margin:       20,
+				GatewayCount: 3,
+			},
+		}
+
+		for _, item := range items {
+			assert.NoError(SaveDeviceStatusHistoryItem(context.Background(), RedisPool(), devEUI, item))
+		}
+
+		out, err := GetDeviceStatusHistory(context.Background(), RedisPool(), devEUI, now.Add(-time.Minute), now)
+		assert.NoError(err)
+		assert.Equal(items, out)
+
+		out, err = GetDeviceStatusHistory(context.Background(), RedisPool(), devEUI, now.Add(-1500*time.Millisecond), now)
+		assert.NoError(err)
+		assert.Equal(items[1:], out)
+	})
+
+	ts.T().Run("expired items are removed", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		assert.NoError(SaveDeviceStatusHistoryItem(context.Background(), RedisPool(), devEUI, DeviceStatusHistoryItem{
+			Time:    now.Add(-2 * deviceStatusHistoryTTL),
+			Type:    DeviceStatusDevStatus,
+			Battery: 100,
+		}))
+		assert.NoError(SaveDeviceStatusHistoryItem(context.Background(), RedisPool(), devEUI, DeviceStatusHistoryItem{
+			Time:    now,
+			Type:    DeviceStatusDevStatus,
+			Battery: 200,
+		}))
+
+		out, err := GetDeviceStatusHistory(context.Background(), RedisPool(), devEUI, now.Add(-3*deviceStatusHistoryTTL), now)
+		assert.NoError(err)
+		assert.Len(out, 1)
+		assert.Equal(200, out[0].Battery)
+	})
+}
@@ -58,6 +58,9 @@ type Gateway struct {
 	Location         GPSPoint       `db:"location"`
 	Altitude         float64        `db:"altitude"`
 	GatewayProfileID *uuid.UUID     `db:"gateway_profile_id"`
+	Backend          string         `db:"backend"`
+	State            GatewayState   `db:"state"`
+	StateChangedAt   *time.Time     `db:"state_changed_at"`
 	Boards           []GatewayBoard `db:"-"`
 }
 
@@ -83,8 +86,9 @@ func CreateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 			location,
 			altitude,
 			gateway_profile_id,
-			routing_profile_id
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			routing_profile_id,
+			backend
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
 		gw.GatewayID[:],
 		gw.CreatedAt,
 		gw.UpdatedAt,
@@ -94,6 +98,7 @@ func CreateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 		gw.Altitude,
 		gw.GatewayProfileID,
 		gw.RoutingProfileID,
+		gw.Backend,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -258,7 +263,8 @@ func UpdateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 			location = $5,
 			altitude = $6,
 			gateway_profile_id = $7,
-			routing_profile_id = $8
+			routing_profile_id = $8,
+			backend = $9
 		where gateway_id = $1`,
 		gw.GatewayID[:],
 		gw.UpdatedAt,
@@ -268,6 +274,7 @@ func UpdateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 		gw.Altitude,
 		gw.GatewayProfileID,
 		gw.RoutingProfileID,
+		gw.Backend,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -357,3 +364,29 @@ func GetGatewaysForIDs(ctx context.Context, db sqlx.Queryer, ids []lorawan.EUI64
 
 	return out, nil
 }
+
+// GetBeaconGateways returns the gateways for which Class-B beaconing has
+// been enabled in the gateway-profile. Gateways which are known to be
+// offline are excluded.
+func GetBeaconGateways(ctx context.Context, db sqlx.Queryer) ([]Gateway, error) {
+	var gws []Gateway
+	err := sqlx.Select(db, &gws, `
+		select
+			g.*
+		from
+			gateway g
+		inner join gateway_profile gp
+			on gp.gateway_profile_id = g.gateway_profile_id
+		where
+			gp.beacon_enabled = true
+			and g.state != $1
+		order by
+			g.gateway_id`,
+		GatewayStateOffline,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return gws, nil
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	gatewayAirtimeKeyTempl = "lora:ns:gw:%s:airtime:%s"
+)
+
+// SaveGatewayAirtime stores the airtime used by the given gateway within the
+// given sub-band. Each transmission is stored as a separate item so that
+// the used airtime can be calculated over a sliding window. Items older than
+// the given window are removed.
+func SaveGatewayAirtime(ctx context.Context, p *redis.Pool, gatewayID lorawan.EUI64, subBand string, ts time.Time, airtime, window time.Duration) error {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(gatewayAirtimeKeyTempl, gatewayID, subBand)
+	score := ts.UnixNano() / int64(time.Millisecond)
+	exp := int64(window) / int64(time.Millisecond)
+
+	// the timestamp is part of the member to make sure that multiple
+	// transmissions with the same airtime are stored as separate items
+	member := fmt.Sprintf("%d:%d", ts.UnixNano(), int64(airtime))
+
+	c.Send("MULTI")
+	c.Send("ZADD", key, score, member)
+	c.Send("ZREMRANGEBYSCORE", key, "-inf", score-exp)
+	c.Send("PEXPIRE", key, exp)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "redis exec error")
+	}
+
+	return nil
+}
+
+// GetGatewayAirtime returns the airtime used by the given gateway within
+// the given sub-band, over the given sliding window (ending now).
+func GetGatewayAirtime(ctx context.Context, p *redis.Pool, gatewayID lorawan.EUI64, subBand string, window time.Duration) (time.Duration, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(gatewayAirtimeKeyTempl, gatewayID, subBand)
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	start := now - int64(window)/int64(time.Millisecond)
+
+	members, err := redis.Strings(c.Do("ZRANGEBYSCORE", key, start, "+inf"))
+	if err != nil {
+		return 0, errors.Wrap(err, "read airtime error")
+	}
+
+	var out time.Duration
+	for _, m := range members {
+		parts := strings.SplitN(m, ":", 2)
+		if len(parts) != 2 {
+			return 0, fmt.Errorf("invalid airtime item: %s", m)
+		}
+
+		d, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return 0, errors.Wrap(err, "parse airtime error")
+		}
+
+		out += time.Duration(d)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestGatewayAirtime() {
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	ts.T().Run("airtime within window", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		now := time.Now()
+		assert.NoError(SaveGatewayAirtime(context.Background(), RedisPool(), gatewayID, "h1.4", now, 100*time.Millisecond, time.Hour))
+		assert.NoError(SaveGatewayAirtime(context.Background(), RedisPool(), gatewayID, "h1.4", now, 100*time.Millisecond, time.Hour))
+		assert.NoError(SaveGatewayAirtime(context.Background(), RedisPool(), gatewayID, "h1.6", now, 50*time.Millisecond, time.Hour))
+
+		airtime, err := GetGatewayAirtime(context.Background(), RedisPool(), gatewayID, "h1.4", time.Hour)
+		assert.NoError(err)
+		assert.Equal(200*time.Millisecond, airtime)
+
+		airtime, err = GetGatewayAirtime(context.Background(), RedisPool(), gatewayID, "h1.6", time.Hour)
+		assert.NoError(err)
+		assert.Equal(50*time.Millisecond, airtime)
+	})
+
+	ts.T().Run("airtime outside window", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		assert.NoError(SaveGatewayAirtime(context.Background(), RedisPool(), gatewayID, "h1.4", time.Now().Add(-2*time.Minute), 100*time.Millisecond, time.Hour))
+		assert.NoError(SaveGatewayAirtime(context.Background(), RedisPool(), gatewayID, "h1.4", time.Now(), 50*time.Millisecond, time.Hour))
+
+		airtime, err := GetGatewayAirtime(context.Background(), RedisPool(), gatewayID, "h1.4", time.Minute)
+		assert.NoError(err)
+		assert.Equal(50*time.Millisecond, airtime)
+	})
+}
@@ -33,6 +33,8 @@ type GatewayProfile struct {
 	CreatedAt     time.Time      `db:"created_at"`
 	UpdatedAt     time.Time      `db:"updated_at"`
 	Channels      []int64        `db:"channels"`
+	StatsInterval time.Duration  `db:"stats_interval"`
+	BeaconEnabled bool           `db:"beacon_enabled"`
 	ExtraChannels []ExtraChannel `db:"-"`
 }
 
@@ -62,12 +64,16 @@ func CreateGatewayProfile(ctx context.Context, db sqlx.Execer, c *GatewayProfile
 			gateway_profile_id,
 			created_at,
 			updated_at,
-			channels
-		) values ($1, $2, $3, $4)`,
+			channels,
+			stats_interval,
+			beacon_enabled
+		) values ($1, $2, $3, $4, $5, $6)`,
 		c.ID,
 		c.CreatedAt,
 		c.UpdatedAt,
 		pq.Array(c.Channels),
+		c.StatsInterval,
+		c.BeaconEnabled,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -112,7 +118,9 @@ func GetGatewayProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Gate
 			gateway_profile_id,
 			created_at,
 			updated_at,
-			channels
+			channels,
+			stats_interval,
+			beacon_enabled
 		from gateway_profile
 		where
 			gateway_profile_id = $1`,
@@ -122,6 +130,8 @@ func GetGatewayProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Gate
 		&c.CreatedAt,
 		&c.UpdatedAt,
 		pq.Array(&c.Channels),
+		&c.StatsInterval,
+		&c.BeaconEnabled,
 	)
 	if err != nil {
 		return c, handlePSQLError(err, "select error")
@@ -172,12 +182,16 @@ func UpdateGatewayProfile(ctx context.Context, db sqlx.Execer, c *GatewayProfile
 		update gateway_profile
 		set
 			updated_at = $2,
-			channels = $3
+			channels = $3,
+			stats_interval = $4,
+			beacon_enabled = $5
 		where
 			gateway_profile_id = $1`,
 		c.ID,
 		c.UpdatedAt,
 		pq.Array(c.Channels),
+		c.StatsInterval,
+		c.BeaconEnabled,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -21,7 +21,9 @@ func TestGatewayProfile(t *testing.T) {
 
 		Convey("When creating gateway profile", func() {
 			gc := GatewayProfile{
-				Channels: []int64{0, 1, 2},
+				Channels:      []int64{0, 1, 2},
+				StatsInterval: 30 * time.Second,
+				BeaconEnabled: true,
 				ExtraChannels: []ExtraChannel{
 					{
 						Modulation:       ModulationLoRa,
@@ -58,6 +60,8 @@ func TestGatewayProfile(t *testing.T) {
 
 			Convey("Then it can be updated", func() {
 				gc.Channels = []int64{0, 1}
+				gc.StatsInterval = time.Minute
+				gc.BeaconEnabled = false
 				gc.ExtraChannels = []ExtraChannel{
 					{
 						Modulation: ModulationLoRa,
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/lorawan"
+)
+
+// GatewayState defines the gateway connection-state.
+type GatewayState string
+
+// Gateway states.
+const (
+	GatewayStateNeverSeen GatewayState = ""
+	GatewayStateOnline    GatewayState = "ONLINE"
+	GatewayStateOffline   GatewayState = "OFFLINE"
+)
+
+// GatewayStateChange represents a gateway connection-state transition.
+type GatewayStateChange struct {
+	ID        int64         `db:"id"`
+	GatewayID lorawan.EUI64 `db:"gateway_id"`
+	CreatedAt time.Time     `db:"created_at"`
+	State     GatewayState  `db:"state"`
+}
+
+// SetGatewayState sets the state of the given gateway. In case the gateway
+// was already in the given state, this is a no-op and false is returned.
+// Otherwise the state change is logged and true is returned.
+// As this will execute multiple SQL statements, it is recommended to perform
+// this within a transaction.
+func SetGatewayState(ctx context.Context, db sqlx.Execer, gatewayID lorawan.EUI64, state GatewayState, changedAt time.Time) (bool, error) {
+	res, err := db.Exec(`
+		update gateway set
+			state = $2,
+			state_changed_at = $3
+		where
+			gateway_id = $1
+			and state != $2`,
+		gatewayID[:],
+		state,
+		changedAt,
+	)
+	if err != nil {
+		return false, handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return false, nil
+	}
+
+	_, err = db.Exec(`
+		insert into gateway_state_change (
+			gateway_id,
+			created_at,
+			state
+		) values ($1, $2, $3)`,
+		gatewayID[:],
+		changedAt,
+		state,
+	)
+	if err != nil {
+		return false, handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"state":      state,
+		"ctx_id":     ctx.Value(logging.ContextIDKey),
+	}).Info("gateway state changed")
+
+	return true, nil
+}
+
+// GetGatewayStateChanges returns the most recent state changes (newest first)
+// for the given gateway.
+func GetGatewayStateChanges(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64, limit int) ([]GatewayStateChange, error) {
+	var changes []GatewayStateChange
+	err := sqlx.Select(db, &changes, `
+		select
+			*
+		from
+			gateway_state_change
+		where
+			gateway_id = $1
+		order by
+			created_at desc,
+			id desc
+		limit $2`,
+		gatewayID[:],
+		limit,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return changes, nil
+}
+
+// GetOfflineGateways returns the gateways which are marked as online, but
+// from which no stats have been received within the given number of stats
+// intervals. The stats interval of the gateway-profile is used, or the
+// given default stats interval when not set.
+func GetOfflineGateways(ctx context.Context, db sqlx.Queryer, defaultStatsInterval time.Duration, missedStats, limit int) ([]Gateway, error) {
+	var gws []Gateway
+	err := sqlx.Select(db, &gws, `
+		select
+			g.*
+		from
+			gateway g
+		left join gateway_profile gp
+			on gp.gateway_profile_id = g.gateway_profile_id
+		where
+			g.state = $1
+			and g.last_seen_at < $2::timestamptz - interval '1 microsecond' * (coalesce(nullif(gp.stats_interval, 0), $3) / 1000 * $4)
+		order by
+			g.last_seen_at
+		limit $5`,
+		GatewayStateOnline,
+		time.Now(),
+		int64(defaultStatsInterval),
+		missedStats,
+		limit,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return gws, nil
+}
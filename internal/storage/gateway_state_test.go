@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestGatewayState() {
+	assert := require.New(ts.T())
+
+	rp := RoutingProfile{
+		ASID: "localhost:1234",
+	}
+	assert.NoError(CreateRoutingProfile(context.Background(), ts.Tx(), &rp))
+
+	gp := GatewayProfile{
+		StatsInterval: time.Minute,
+	}
+	assert.NoError(CreateGatewayProfile(context.Background(), ts.Tx(), &gp))
+
+	lastSeenAt := time.Now().Add(-5 * time.Minute)
+	gwDefault := Gateway{
+		GatewayID:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		RoutingProfileID: rp.ID,
+		LastSeenAt:       &lastSeenAt,
+	}
+	gwProfile := Gateway{
+		GatewayID:        lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+		RoutingProfileID: rp.ID,
+		GatewayProfileID: &gp.ID,
+		LastSeenAt:       &lastSeenAt,
+	}
+	assert.NoError(CreateGateway(context.Background(), ts.Tx(), &gwDefault))
+	assert.NoError(CreateGateway(context.Background(), ts.Tx(), &gwProfile))
+
+	ts.T().Run("Set state", func(t *testing.T) {
+		assert := require.New(t)
+		now := time.Now().Round(time.Millisecond).UTC()
+
+		changed, err := SetGatewayState(context.Background(), ts.Tx(), gwDefault.GatewayID, GatewayStateOnline, now)
+		assert.NoError(err)
+		assert.True(changed)
+
+		t.Run("Same state", func(t *testing.T) {
+			assert := require.New(t)
+
+			changed, err := SetGatewayState(context.Background(), ts.Tx(), gwDefault.GatewayID, GatewayStateOnline, now)
+			assert.NoError(err)
+			assert.False(changed)
+		})
+
+		t.Run("Get gateway", func(t *testing.T) {
+			assert := require.New(t)
+
+			gw, err := GetGateway(context.Background(), ts.Tx(), gwDefault.GatewayID)
+			assert.NoError(err)
+			assert.Equal(GatewayStateOnline, gw.State)
+			assert.True(gw.StateChangedAt.Equal(now))
+		})
+
+		t.Run("Get state changes", func(t *testing.T) {
+			assert := require.New(t)
+
+			changes, err := GetGatewayStateChanges(context.Background(), ts.Tx(), gwDefault.GatewayID, 10)
+			assert.NoError(err)
+			assert.Len(changes, 1)
+			assert.Equal(GatewayStateOnline, changes[0].State)
+			assert.True(changes[0].CreatedAt.Equal(now))
+		})
+	})
+
+	ts.T().Run("Get offline gateways", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := SetGatewayState(context.Background(), ts.Tx(), gwProfile.GatewayID, GatewayStateOnline, time.Now())
+		assert.NoError(err)
+
+		tests := []struct {
+			name                 string
+			defaultStatsInterval time.Duration
+			missedStats          int
+			expected             []lorawan.EUI64
+		}{
+			{
+				name:                 "both offline",
+				defaultStatsInterval: time.Minute,
+				missedStats:          2,
+				expected:             []lorawan.EUI64{gwDefault.GatewayID, gwProfile.GatewayID},
+			},
+			{
+				name:                 "only gateway with default stats interval offline",
+				defaultStatsInterval: time.Second,
+				missedStats:          10,
+				expected:             []lorawan.EUI64{gwDefault.GatewayID},
+			},
+			{
+				name:                 "none offline",
+				defaultStatsInterval: time.Minute,
+				missedStats:          10,
+			},
+		}
+
+		for _, tst := range tests {
+			t.Run(tst.name, func(t *testing.T) {
+				assert := require.New(t)
+
+				gws, err := GetOfflineGateways(context.Background(), ts.Tx(), tst.defaultStatsInterval, tst.missedStats, 10)
+				assert.NoError(err)
+
+				var ids []lorawan.EUI64
+				for _, gw := range gws {
+					ids = append(ids, gw.GatewayID)
+				}
+				assert.ElementsMatch(tst.expected, ids)
+			})
+		}
+	})
+}
@@ -87,6 +87,7 @@ func (ts *StorageTestSuite) TestGateway() {
 				Longitude: 3.123,
 			}
 			gw.Altitude = 100.5
+			gw.Backend = "mqtt"
 			gw.Boards = []GatewayBoard{
 				{
 					FineTimestampKey: &aesKey,
@@ -120,4 +121,49 @@ func (ts *StorageTestSuite) TestGateway() {
 			assert.Equal(ErrDoesNotExist, err)
 		})
 	})
+
+	ts.T().Run("Get beacon gateways", func(t *testing.T) {
+		assert := require.New(t)
+
+		gpBeacon := GatewayProfile{
+			BeaconEnabled: true,
+		}
+		assert.NoError(CreateGatewayProfile(context.Background(), ts.Tx(), &gpBeacon))
+
+		gpNoBeacon := GatewayProfile{}
+		assert.NoError(CreateGatewayProfile(context.Background(), ts.Tx(), &gpNoBeacon))
+
+		gws := []Gateway{
+			{
+				GatewayID:        lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+				RoutingProfileID: rp.ID,
+				GatewayProfileID: &gpBeacon.ID,
+			},
+			{
+				GatewayID:        lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+				RoutingProfileID: rp.ID,
+				GatewayProfileID: &gpNoBeacon.ID,
+			},
+			{
+				GatewayID:        lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3},
+				RoutingProfileID: rp.ID,
+			},
+			{
+				GatewayID:        lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4},
+				RoutingProfileID: rp.ID,
+				GatewayProfileID: &gpBeacon.ID,
+			},
+		}
+		for i := range gws {
+			assert.NoError(CreateGateway(context.Background(), ts.Tx(), &gws[i]))
+		}
+
+		_, err := SetGatewayState(context.Background(), ts.Tx(), gws[3].GatewayID, GatewayStateOffline, time.Now())
+		assert.NoError(err)
+
+		beaconGWs, err := GetBeaconGateways(context.Background(), ts.Tx())
+		assert.NoError(err)
+		assert.Len(beaconGWs, 1)
+		assert.Equal(gws[0].GatewayID, beaconGWs[0].GatewayID)
+	})
 }
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	gatewayTXScheduleKeyTempl = "lora:ns:gw:%s:txschedule"
+)
+
+// GatewayTXScheduleItem defines a downlink emission scheduled for a gateway.
+type GatewayTXScheduleItem struct {
+	// Start of the emission (network-server time). In case the emission is
+	// scheduled relative to the concentrator counter of the gateway, this is
+	// an estimation.
+	Start time.Time
+
+	// Airtime (time-on-air) of the emission.
+	Airtime time.Duration
+
+	// Frequency (Hz) of the emission.
+	Frequency uint32
+
+	// Timestamp contains the concentrator counter value (in microseconds) at
+	// the start of the emission. This is only set when it is known.
+	Timestamp *uint32
+}
+
+// End returns the end of the emission.
+func (i GatewayTXScheduleItem) End() time.Time {
+	return i.Start.Add(i.Airtime)
+}
+
+// SaveGatewayTXScheduleItem stores the given item in the tx schedule of the
+// given gateway. The items are sorted by the end of the emission, items
+// which already ended are removed.
+func SaveGatewayTXScheduleItem(ctx context.Context, p *redis.Pool, gatewayID lorawan.EUI64, item GatewayTXScheduleItem, ttl time.Duration) error {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(gatewayTXScheduleKeyTempl, gatewayID)
+	score := item.End().UnixNano() / int64(time.Millisecond)
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	exp := int64(ttl) / int64(time.Millisecond)
+
+	var ts string
+	if item.Timestamp != nil {
+		ts = strconv.FormatUint(uint64(*item.Timestamp), 10)
+	}
+	member := fmt.Sprintf("%d:%d:%d:%s", item.Start.UnixNano(), int64(item.Airtime), item.Frequency, ts)
+
+	c.Send("MULTI")
+	c.Send("ZADD", key, score, member)
+	c.Send("ZREMRANGEBYSCORE", key, "-inf", fmt.Sprintf("(%d", now))
+	c.Send("PEXPIRE", key, exp)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "redis exec error")
+	}
+
+	return nil
+}
+
+// GetGatewayTXScheduleItems returns the items in the tx schedule of the
+// given gateway which did not yet end.
+func GetGatewayTXScheduleItems(ctx context.Context, p *redis.Pool, gatewayID lorawan.EUI64) ([]GatewayTXScheduleItem, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(gatewayTXScheduleKeyTempl, gatewayID)
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+
+	members, err := redis.Strings(c.Do("ZRANGEBYSCORE", key, now, "+inf"))
+	if err != nil {
+		return nil, errors.Wrap(err, "read tx schedule error")
+	}
+
+	var out []GatewayTXScheduleItem
+	for _, m := range members {
+		parts := strings.SplitN(m, ":", 4)
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("invalid tx schedule item: %s", m)
+		}
+
+		start, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse start error")
+		}
+
+		airtime, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse airtime error")
+		}
+
+		freq, err := strconv.ParseUint(parts[2], 10, 32)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse frequency error")
+		}
+
+		item := GatewayTXScheduleItem{
+			Start:     time.Unix(0, start),
+			Airtime:   time.Duration(airtime),
+			Frequency: uint32(freq),
+		}
+
+		if parts[3] != "" {
+			ts, err := strconv.ParseUint(parts[3], 10, 32)
+			if err != nil {
+				return nil, errors.Wrap(err, "parse timestamp error")
+			}
+			tsUint32 := uint32(ts)
+			item.Timestamp = &tsUint32
+		}
+
+		out = append(out, item)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestGatewayTXSchedule() {
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	ts.T().Run("save and get items", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		timestamp := uint32(1234567)
+		items := []GatewayTXScheduleItem{
+			{
+				Start:     time.Unix(0, time.Now().Add(time.Second).UnixNano()),
+				Airtime:   100 * time.Millisecond,
+				Frequency: 868100000,
+				Timestamp: &timestamp,
+			},
+			{
+				Start:     time.Unix(0, time.Now().Add(2*time.Second).UnixNano()),
+				Airtime:   50 * time.Millisecond,
+				Frequency: 869525000,
+			},
+		}
+
+		for _, item := range items {
+			assert.NoError(SaveGatewayTXScheduleItem(context.Background(), RedisPool(), gatewayID, item, time.Minute))
+		}
+
+		out, err := GetGatewayTXScheduleItems(context.Background(), RedisPool(), gatewayID)
+		assert.NoError(err)
+		assert.Equal(items, out)
+	})
+
+	ts.T().Run("ended items are not returned", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		assert.NoError(SaveGatewayTXScheduleItem(context.Background(), RedisPool(), gatewayID, GatewayTXScheduleItem{
+			Start:     time.Now().Add(-time.Second),
+			Airtime:   100 * time.Millisecond,
+			Frequency: 868100000,
+		}, time.Minute))
+
+		out, err := GetGatewayTXScheduleItems(context.Background(), RedisPool(), gatewayID)
+		assert.NoError(err)
+		assert.Len(out, 0)
+	})
+}
@@ -263,6 +263,37 @@ func CreateMulticastQueueItem(ctx context.Context, db sqlx.Queryer, qi *Multicas
 	return nil
 }
 
+// UpdateMulticastQueueItemSchedule updates the schedule_at and
+// emit_at_time_since_gps_epoch of the given queue-item.
+func UpdateMulticastQueueItemSchedule(ctx context.Context, db sqlx.Execer, qi MulticastQueueItem) error {
+	res, err := db.Exec(`
+		update multicast_queue set
+			schedule_at = $2,
+			emit_at_time_since_gps_epoch = $3
+		where
+			id = $1
+	`, qi.ID, qi.ScheduleAt, qi.EmitAtTimeSinceGPSEpoch)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id":                           qi.ID,
+		"schedule_at":                  qi.ScheduleAt,
+		"emit_at_time_since_gps_epoch": qi.EmitAtTimeSinceGPSEpoch,
+		"ctx_id":                       ctx.Value(logging.ContextIDKey),
+	}).Info("multicast queue-item rescheduled")
+
+	return nil
+}
+
 // DeleteMulticastQueueItem deletes the queue-item given an id.
 func DeleteMulticastQueueItem(ctx context.Context, db sqlx.Execer, id int64) error {
 	res, err := db.Exec(`
@@ -163,6 +163,27 @@ func (ts *StorageTestSuite) TestMulticastQueue() {
 			assert.Equal(gps2, d)
 		})
 
+		t.Run("Update schedule", func(t *testing.T) {
+			assert := require.New(t)
+
+			qi := qi1
+			emitAt := gps2 + time.Minute
+			qi.EmitAtTimeSinceGPSEpoch = &emitAt
+			qi.ScheduleAt = qi.ScheduleAt.Add(time.Minute)
+			assert.NoError(UpdateMulticastQueueItemSchedule(context.Background(), ts.Tx(), qi))
+
+			items, err := GetMulticastQueueItemsForMulticastGroup(context.Background(), ts.Tx(), mg.ID)
+			assert.NoError(err)
+			assert.Len(items, 2)
+			assert.Equal(qi.ID, items[0].ID)
+			assert.Equal(emitAt, *items[0].EmitAtTimeSinceGPSEpoch)
+			assert.True(qi.ScheduleAt.Equal(items[0].ScheduleAt))
+
+			d, err := GetMaxEmitAtTimeSinceGPSEpochForMulticastGroup(context.Background(), ts.Tx(), mg.ID)
+			assert.NoError(err)
+			assert.Equal(emitAt, d)
+		})
+
 		t.Run("Delete", func(t *testing.T) {
 			assert := require.New(t)
 
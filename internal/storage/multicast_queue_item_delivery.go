@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/lorawan"
+)
+
+// MulticastDeliveryStatus defines the delivery status of a multicast
+// queue-item for a gateway.
+type MulticastDeliveryStatus string
+
+// Multicast delivery statuses.
+const (
+	MulticastDeliveryPending MulticastDeliveryStatus = "PENDING"
+	MulticastDeliverySuccess MulticastDeliveryStatus = "SUCCESS"
+	MulticastDeliveryFailed  MulticastDeliveryStatus = "FAILED"
+)
+
+// Multicast delivery errors, set by the network-server when the queue-item
+// was discarded (PAYLOAD_SIZE) or rescheduled (DUTY_CYCLE and TX_SCHEDULE).
+// Errors reported by the gateway (tx ack) are stored as-is.
+const (
+	MulticastDeliveryErrorPayloadSize = "PAYLOAD_SIZE"
+	MulticastDeliveryErrorDutyCycle   = "DUTY_CYCLE"
+	MulticastDeliveryErrorTXSchedule  = "TX_SCHEDULE"
+)
+
+// MulticastQueueItemDelivery defines the delivery of a multicast queue-item
+// (identified by its multicast-group id and frame-counter) by a gateway.
+type MulticastQueueItemDelivery struct {
+	ID               int64                   `db:"id"`
+	CreatedAt        time.Time               `db:"created_at"`
+	UpdatedAt        time.Time               `db:"updated_at"`
+	MulticastGroupID uuid.UUID               `db:"multicast_group_id"`
+	FCnt             uint32                  `db:"f_cnt"`
+	GatewayID        lorawan.EUI64           `db:"gateway_id"`
+	Status           MulticastDeliveryStatus `db:"status"`
+	Error            string                  `db:"error"`
+}
+
+// SaveMulticastQueueItemDelivery creates the given multicast queue-item
+// delivery, or updates its status and error when it already exists.
+func SaveMulticastQueueItemDelivery(ctx context.Context, db sqlx.Queryer, d *MulticastQueueItemDelivery) error {
+	now := time.Now()
+	d.CreatedAt = now
+	d.UpdatedAt = now
+
+	err := sqlx.Get(db, d, `
+		insert into multicast_queue_item_delivery (
+			created_at,
+			updated_at,
+			multicast_group_id,
+			f_cnt,
+			gateway_id,
+			status,
+			error
+		) values ($1, $2, $3, $4, $5, $6, $7)
+		on conflict (multicast_group_id, f_cnt, gateway_id)
+			do update set
+				updated_at = excluded.updated_at,
+				status = excluded.status,
+				error = excluded.error
+		returning
+			*`,
+		d.CreatedAt,
+		d.UpdatedAt,
+		d.MulticastGroupID,
+		d.FCnt,
+		d.GatewayID[:],
+		d.Status,
+		d.Error,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": d.MulticastGroupID,
+		"f_cnt":              d.FCnt,
+		"gateway_id":         d.GatewayID,
+		"status":             d.Status,
+		"error":              d.Error,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info("multicast queue-item delivery saved")
+
+	return nil
+}
+
+// DeleteExpiredMulticastQueueItemDeliveries deletes the multicast queue-item
+// deliveries which have not been updated within the multicast delivery TTL.
+// When the TTL is 0, this is a no-op.
+func DeleteExpiredMulticastQueueItemDeliveries(ctx context.Context, db sqlx.Execer) error {
+	// nothing to do
+	if multicastDeliveryTTL == 0 {
+		return nil
+	}
+
+	res, err := db.Exec(`
+		delete from
+			multicast_queue_item_delivery
+		where
+			updated_at < $1`,
+		time.Now().Add(-multicastDeliveryTTL),
+	)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+
+	if ra != 0 {
+		log.WithFields(log.Fields{
+			"count":  ra,
+			"ctx_id": ctx.Value(logging.ContextIDKey),
+		}).Info("expired multicast queue-item deliveries deleted")
+	}
+
+	return nil
+}
+
+// GetMulticastQueueItemDeliveries returns the deliveries of the multicast
+// queue-item given a multicast-group id and frame-counter.
+func GetMulticastQueueItemDeliveries(ctx context.Context, db sqlx.Queryer, multicastGroupID uuid.UUID, fCnt uint32) ([]MulticastQueueItemDelivery, error) {
+	var out []MulticastQueueItemDelivery
+	err := sqlx.Select(db, &out, `
+		select
+			*
+		from
+			multicast_queue_item_delivery
+		where
+			multicast_group_id = $1
+			and f_cnt = $2
+		order by
+			gateway_id`,
+		multicastGroupID,
+		fCnt,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return out, nil
+}
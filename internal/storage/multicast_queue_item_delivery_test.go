@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestMulticastQueueItemDelivery() {
+	assert := require.New(ts.T())
+
+	mg := ts.GetMulticastGroup()
+	assert.NoError(CreateMulticastGroup(context.Background(), ts.Tx(), &mg))
+
+	gateways := []Gateway{
+		{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, RoutingProfileID: mg.RoutingProfileID},
+		{GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, RoutingProfileID: mg.RoutingProfileID},
+	}
+	for i := range gateways {
+		assert.NoError(CreateGateway(context.Background(), ts.Tx(), &gateways[i]))
+	}
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		d1 := MulticastQueueItemDelivery{
+			MulticastGroupID: mg.ID,
+			FCnt:             10,
+			GatewayID:        gateways[0].GatewayID,
+			Status:           MulticastDeliveryPending,
+		}
+		d2 := MulticastQueueItemDelivery{
+			MulticastGroupID: mg.ID,
+			FCnt:             10,
+			GatewayID:        gateways[1].GatewayID,
+			Status:           MulticastDeliveryFailed,
+			Error:            MulticastDeliveryErrorDutyCycle,
+		}
+		assert.NoError(SaveMulticastQueueItemDelivery(context.Background(), ts.Tx(), &d1))
+		assert.NoError(SaveMulticastQueueItemDelivery(context.Background(), ts.Tx(), &d2))
+
+		t.Run("Get", func(t *testing.T) {
+			assert := require.New(t)
+
+			out, err := GetMulticastQueueItemDeliveries(context.Background(), ts.Tx(), mg.ID, 10)
+			assert.NoError(err)
+			assert.Len(out, 2)
+			assert.Equal(gateways[0].GatewayID, out[0].GatewayID)
+			assert.Equal(MulticastDeliveryPending, out[0].Status)
+			assert.Equal(gateways[1].GatewayID, out[1].GatewayID)
+			assert.Equal(MulticastDeliveryFailed, out[1].Status)
+			assert.Equal(MulticastDeliveryErrorDutyCycle, out[1].Error)
+
+			out, err = GetMulticastQueueItemDeliveries(context.Background(), ts.Tx(), mg.ID, 11)
+			assert.NoError(err)
+			assert.Len(out, 0)
+		})
+
+		t.Run("Save existing", func(t *testing.T) {
+			assert := require.New(t)
+
+			d := MulticastQueueItemDelivery{
+				MulticastGroupID: mg.ID,
+				FCnt:             10,
+				GatewayID:        gateways[1].GatewayID,
+				Status:           MulticastDeliveryPending,
+			}
+			assert.NoError(SaveMulticastQueueItemDelivery(context.Background(), ts.Tx(), &d))
+			assert.Equal(d2.ID, d.ID)
+
+			out, err := GetMulticastQueueItemDeliveries(context.Background(), ts.Tx(), mg.ID, 10)
+			assert.NoError(err)
+			assert.Len(out, 2)
+			assert.Equal(MulticastDeliveryPending, out[1].Status)
+			assert.Equal("", out[1].Error)
+		})
+
+		t.Run("Delete expired", func(t *testing.T) {
+			assert := require.New(t)
+
+			_, err := ts.Tx().Exec(`
+				update multicast_queue_item_delivery set
+					updated_at = $3
+				where
+					multicast_group_id = $1
+					and gateway_id = $2`,
+				mg.ID, gateways[0].GatewayID[:], time.Now().Add(-2*multicastDeliveryTTL),
+			)
+			assert.NoError(err)
+
+			assert.NoError(DeleteExpiredMulticastQueueItemDeliveries(context.Background(), ts.Tx()))
+
+			out, err := GetMulticastQueueItemDeliveries(context.Background(), ts.Tx(), mg.ID, 10)
+			assert.NoError(err)
+			assert.Len(out, 1)
+			assert.Equal(gateways[1].GatewayID, out[0].GatewayID)
+		})
+	})
+}
@@ -0,0 +1,246 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	passiveRoamingDevAddrKeyTempl = "lora:ns:pr:devaddr:%s" // contains a set of session IDs using this DevAddr
+	passiveRoamingSessionKeyTempl = "lora:ns:pr:sess:%s"    // contains the passive-roaming device-session
+	passiveRoamingULTokenKeyTempl = "lora:ns:pr:ultoken:%s" // contains the gateway rx-info referenced by the ULToken
+)
+
+// PassiveRoamingDeviceSession contains the passive-roaming device-session
+// as known by the forwarding network-server (fNS). It is created from the
+// PRStartAns response of the serving network-server (sNS) in case of a
+// stateful passive-roaming session and is used to forward the uplinks of
+// the roaming device using XmitDataReq.
+type PassiveRoamingDeviceSession struct {
+	SessionID   uuid.UUID
+	NetID       lorawan.NetID
+	DevAddr     lorawan.DevAddr
+	DevEUI      lorawan.EUI64
+	LoRaWAN11   bool
+	FNwkSIntKey lorawan.AES128Key
+	FCntUp      uint32
+	Lifetime    time.Time
+}
+
+// SavePassiveRoamingDeviceSession saves the given passive-roaming
+// device-session. The session expires at its lifetime.
+func SavePassiveRoamingDeviceSession(ctx context.Context, p *redis.Pool, ds *PassiveRoamingDeviceSession) error {
+	if ds.SessionID == uuid.Nil {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return errors.Wrap(err, "new uuid error")
+		}
+		ds.SessionID = id
+	}
+
+	exp := int64(time.Until(ds.Lifetime) / time.Millisecond)
+	if exp <= 0 {
+		return errors.New("passive-roaming device-session lifetime must be in the future")
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(ds); err != nil {
+		return errors.Wrap(err, "gob encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("PSETEX", fmt.Sprintf(passiveRoamingSessionKeyTempl, ds.SessionID), exp, buf.Bytes())
+	c.Send("SADD", fmt.Sprintf(passiveRoamingDevAddrKeyTempl, ds.DevAddr), ds.SessionID[:])
+	c.Send("PEXPIRE", fmt.Sprintf(passiveRoamingDevAddrKeyTempl, ds.DevAddr), exp)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "exec error")
+	}
+
+	log.WithFields(log.Fields{
+		"session_id": ds.SessionID,
+		"net_id":     ds.NetID,
+		"dev_addr":   ds.DevAddr,
+		"ctx_id":     ctx.Value(logging.ContextIDKey),
+	}).Info("passive-roaming device-session saved")
+
+	return nil
+}
+
+// GetPassiveRoamingDeviceSession returns the passive-roaming device-session
+// for the given session ID.
+func GetPassiveRoamingDeviceSession(ctx context.Context, p *redis.Pool, id uuid.UUID) (PassiveRoamingDeviceSession, error) {
+	var ds PassiveRoamingDeviceSession
+
+	c := p.Get()
+	defer c.Close()
+
+	val, err := redis.Bytes(c.Do("GET", fmt.Sprintf(passiveRoamingSessionKeyTempl, id)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return ds, ErrDoesNotExist
+		}
+		return ds, errors.Wrap(err, "get error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(val)).Decode(&ds); err != nil {
+		return ds, errors.Wrap(err, "gob decode error")
+	}
+
+	return ds, nil
+}
+
+// GetPassiveRoamingDeviceSessionsForDevAddr returns the passive-roaming
+// device-sessions using the given DevAddr.
+func GetPassiveRoamingDeviceSessionsForDevAddr(ctx context.Context, p *redis.Pool, devAddr lorawan.DevAddr) ([]PassiveRoamingDeviceSession, error) {
+	var items []PassiveRoamingDeviceSession
+
+	c := p.Get()
+	defer c.Close()
+
+	ids, err := redis.ByteSlices(c.Do("SMEMBERS", fmt.Sprintf(passiveRoamingDevAddrKeyTempl, devAddr)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return items, nil
+		}
+		return nil, errors.Wrap(err, "get members error")
+	}
+
+	for _, b := range ids {
+		var id uuid.UUID
+		copy(id[:], b)
+
+		ds, err := GetPassiveRoamingDeviceSession(ctx, p, id)
+		if err != nil {
+			// the session might have expired
+			if errors.Cause(err) != ErrDoesNotExist {
+				log.WithFields(log.Fields{
+					"dev_addr":   devAddr,
+					"session_id": id,
+					"ctx_id":     ctx.Value(logging.ContextIDKey),
+				}).WithError(err).Warning("get passive-roaming device-session error")
+			}
+			continue
+		}
+
+		items = append(items, ds)
+	}
+
+	return items, nil
+}
+
+// GetPassiveRoamingDeviceSessionsForPHYPayload returns the passive-roaming
+// device-sessions matching the given PHYPayload. The FCntUp of the returned
+// sessions is set to the full frame-counter of the PHYPayload. As the fNS
+// does only know the FNwkSIntKey, only the FNwkSIntKey part of the MIC is
+// validated in case of a LoRaWAN 1.1 device.
+func GetPassiveRoamingDeviceSessionsForPHYPayload(ctx context.Context, p *redis.Pool, phy lorawan.PHYPayload) ([]PassiveRoamingDeviceSession, error) {
+	var out []PassiveRoamingDeviceSession
+
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected *lorawan.MACPayload, got: %T", phy.MACPayload)
+	}
+	originalFCnt := macPL.FHDR.FCnt
+	defer func() {
+		macPL.FHDR.FCnt = originalFCnt
+	}()
+
+	sessions, err := GetPassiveRoamingDeviceSessionsForDevAddr(ctx, p, macPL.FHDR.DevAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sessions {
+		// get the full FCnt, using the last known FCntUp
+		gap := uint32(uint16(originalFCnt) - uint16(s.FCntUp%65536))
+		macPL.FHDR.FCnt = s.FCntUp + gap
+
+		micPHY := phy
+		if s.LoRaWAN11 {
+			if err := micPHY.SetUplinkDataMIC(lorawan.LoRaWAN1_1, 0, 0, 0, s.FNwkSIntKey, s.FNwkSIntKey); err != nil {
+				return nil, errors.Wrap(err, "set mic error")
+			}
+			if !bytes.Equal(micPHY.MIC[2:], phy.MIC[2:]) {
+				continue
+			}
+		} else {
+			micOK, err := micPHY.ValidateUplinkDataMIC(lorawan.LoRaWAN1_0, 0, 0, 0, s.FNwkSIntKey, s.FNwkSIntKey)
+			if err != nil {
+				return nil, errors.Wrap(err, "validate mic error")
+			}
+			if !micOK {
+				continue
+			}
+		}
+
+		s.FCntUp = macPL.FHDR.FCnt
+		out = append(out, s)
+	}
+
+	return out, nil
+}
+
+// SavePassiveRoamingULToken stores the given gateway rx-info and returns the
+// random ID which must be used as ULToken. This way the rx-info of the
+// gateway is not exposed to the roaming partner and the ULToken of a
+// downlink can not be forged. The rx-info expires after the given ttl.
+func SavePassiveRoamingULToken(ctx context.Context, p *redis.Pool, rxInfo *gw.UplinkRXInfo, ttl time.Duration) (uuid.UUID, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return id, errors.Wrap(err, "new uuid error")
+	}
+
+	b, err := proto.Marshal(rxInfo)
+	if err != nil {
+		return id, errors.Wrap(err, "protobuf marshal error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err = c.Do("PSETEX", fmt.Sprintf(passiveRoamingULTokenKeyTempl, id), int64(ttl/time.Millisecond), b)
+	if err != nil {
+		return id, errors.Wrap(err, "psetex error")
+	}
+
+	return id, nil
+}
+
+// GetPassiveRoamingULToken returns the gateway rx-info for the given ULToken
+// ID. It returns ErrDoesNotExist when the ULToken is unknown or has expired.
+func GetPassiveRoamingULToken(ctx context.Context, p *redis.Pool, id uuid.UUID) (gw.UplinkRXInfo, error) {
+	var rxInfo gw.UplinkRXInfo
+
+	c := p.Get()
+	defer c.Close()
+
+	val, err := redis.Bytes(c.Do("GET", fmt.Sprintf(passiveRoamingULTokenKeyTempl, id)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return rxInfo, ErrDoesNotExist
+		}
+		return rxInfo, errors.Wrap(err, "get error")
+	}
+
+	if err := proto.Unmarshal(val, &rxInfo); err != nil {
+		return rxInfo, errors.Wrap(err, "protobuf unmarshal error")
+	}
+
+	return rxInfo, nil
+}
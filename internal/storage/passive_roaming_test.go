@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/api/gw"
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestPassiveRoamingDeviceSession() {
+	ds := PassiveRoamingDeviceSession{
+		NetID:       lorawan.NetID{1, 2, 3},
+		DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+		DevEUI:      lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		FNwkSIntKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		FCntUp:      65535,
+		Lifetime:    time.Now().Add(time.Hour),
+	}
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+		assert.NoError(SavePassiveRoamingDeviceSession(context.Background(), ts.RedisPool(), &ds))
+
+		t.Run("Get", func(t *testing.T) {
+			assert := require.New(t)
+
+			dsGet, err := GetPassiveRoamingDeviceSession(context.Background(), ts.RedisPool(), ds.SessionID)
+			assert.NoError(err)
+			assert.Equal(ds.DevEUI, dsGet.DevEUI)
+			assert.Equal(ds.FNwkSIntKey, dsGet.FNwkSIntKey)
+			assert.True(ds.Lifetime.Equal(dsGet.Lifetime))
+		})
+
+		t.Run("Get for DevAddr", func(t *testing.T) {
+			assert := require.New(t)
+
+			sessions, err := GetPassiveRoamingDeviceSessionsForDevAddr(context.Background(), ts.RedisPool(), ds.DevAddr)
+			assert.NoError(err)
+			assert.Len(sessions, 1)
+			assert.Equal(ds.SessionID, sessions[0].SessionID)
+		})
+
+		tests := []struct {
+			Name           string
+			Key            lorawan.AES128Key
+			ExpectedFCntUp uint32
+			ExpectedCount  int
+		}{
+			{
+				Name:           "valid mic",
+				Key:            ds.FNwkSIntKey,
+				ExpectedFCntUp: 65536,
+				ExpectedCount:  1,
+			},
+			{
+				Name: "invalid mic",
+				Key:  lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1},
+			},
+		}
+
+		for _, tst := range tests {
+			t.Run("Get for PHYPayload "+tst.Name, func(t *testing.T) {
+				assert := require.New(t)
+
+				macPL := lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: ds.DevAddr,
+						FCnt:    65536,
+					},
+				}
+				phy := lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: lorawan.UnconfirmedDataUp,
+						Major: lorawan.LoRaWANR1,
+					},
+					MACPayload: &macPL,
+				}
+				assert.NoError(phy.SetUplinkDataMIC(lorawan.LoRaWAN1_0, 0, 0, 0, tst.Key, tst.Key))
+				macPL.FHDR.FCnt = 0
+
+				sessions, err := GetPassiveRoamingDeviceSessionsForPHYPayload(context.Background(), ts.RedisPool(), phy)
+				assert.NoError(err)
+				assert.Len(sessions, tst.ExpectedCount)
+				if tst.ExpectedCount != 0 {
+					assert.Equal(tst.ExpectedFCntUp, sessions[0].FCntUp)
+				}
+			})
+		}
+	})
+}
+
+func (ts *StorageTestSuite) TestPassiveRoamingULToken() {
+	assert := require.New(ts.T())
+
+	rxInfo := gw.UplinkRXInfo{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Context:   []byte{1, 2, 3, 4},
+	}
+
+	id, err := SavePassiveRoamingULToken(context.Background(), ts.RedisPool(), &rxInfo, time.Minute)
+	assert.NoError(err)
+
+	ts.T().Run("Get", func(t *testing.T) {
+		assert := require.New(t)
+
+		rxInfoGet, err := GetPassiveRoamingULToken(context.Background(), ts.RedisPool(), id)
+		assert.NoError(err)
+		assert.True(proto.Equal(&rxInfo, &rxInfoGet))
+	})
+
+	ts.T().Run("Get unknown", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetPassiveRoamingULToken(context.Background(), ts.RedisPool(), uuid.Must(uuid.NewV4()))
+		assert.Equal(ErrDoesNotExist, err)
+	})
+}
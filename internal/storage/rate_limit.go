@@ -0,0 +1,278 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	uplinkRateLimitKeyTempl   = "lora:ns:sp:%s:device:%s:ul:bucket"
+	downlinkRateLimitKeyTempl = "lora:ns:sp:%s:device:%s:dl:bucket"
+
+	spUplinkRateLimitKeyTempl   = "lora:ns:sp:%s:ul:bucket"
+	spDownlinkRateLimitKeyTempl = "lora:ns:sp:%s:dl:bucket"
+)
+
+// tokenBucketScript implements one or multiple token-buckets in Redis. Each
+// bucket (KEYS[n]) is refilled with ARGV[n*3-1] tokens per hour, up to a max
+// of ARGV[n*3] tokens and expires after ARGV[n*3+1] milliseconds. ARGV[1]
+// holds the current timestamp. A token is only taken when none of the buckets
+// is empty, so that a token is never taken from one bucket while the other
+// bucket refuses the packet. It returns 0 when a token was taken from all
+// buckets or the (1-based) index of the first empty bucket.
+var tokenBucketScript = redis.NewScript(-1, `
+local now = tonumber(ARGV[1])
+local tokens = {}
+local empty = 0
+
+for i, key in ipairs(KEYS) do
+	local rate = tonumber(ARGV[i * 3 - 1])
+	local size = tonumber(ARGV[i * 3])
+
+	local bucket = redis.call("HMGET", key, "tokens", "ts")
+	local t = tonumber(bucket[1])
+	local ts = tonumber(bucket[2])
+
+	if t == nil or ts == nil then
+		t = size
+		ts = now
+	end
+
+	tokens[i] = math.min(size, t + (math.max(0, now - ts) * rate / 3600000))
+
+	if tokens[i] < 1 and empty == 0 then
+		empty = i
+	end
+end
+
+for i, key in ipairs(KEYS) do
+	if empty == 0 then
+		tokens[i] = tokens[i] - 1
+	end
+
+	redis.call("HMSET", key, "tokens", tokens[i], "ts", now)
+	redis.call("PEXPIRE", key, tonumber(ARGV[i * 3 + 1]))
+end
+
+return empty
+`)
+
+// tokenBucketReturnScript returns a token to each of the given buckets
+// (KEYS[n]), up to a max of ARGV[n] tokens. As a missing bucket is handled as
+// a full bucket, missing buckets are not created.
+var tokenBucketReturnScript = redis.NewScript(-1, `
+for i, key in ipairs(KEYS) do
+	local size = tonumber(ARGV[i])
+	local tokens = tonumber(redis.call("HGET", key, "tokens"))
+
+	if tokens ~= nil then
+		redis.call("HSET", key, "tokens", math.min(size, tokens + 1))
+	end
+end
+
+return 0
+`)
+
+// rateLimitBucket defines a token-bucket. The rate is in tokens per hour.
+type rateLimitBucket struct {
+	key        string
+	rate       int
+	bucketSize int
+
+	// logFields and logMessage are logged when the bucket is empty.
+	logFields  log.Fields
+	logMessage string
+}
+
+// TakeUplinkRateLimitToken takes a token from the uplink token-bucket of the
+// given device and from the uplink token-bucket shared by all devices of the
+// service-profile. The device bucket is filled at the ULRate (packets / hour)
+// of the service-profile, up to ULBucketSize tokens. The service-profile
+// bucket is filled at the SPULRate, up to SPULBucketSize tokens. It returns
+// false when one of the buckets is empty, meaning that the rate-limit has
+// been exceeded. In this case no token is taken from the other bucket. A
+// bucket is not used when its rate is not defined.
+func TakeUplinkRateLimitToken(ctx context.Context, p *redis.Pool, sp ServiceProfile, devEUI lorawan.EUI64) (bool, error) {
+	taken, err := takeRateLimitToken(ctx, p, sp, devEUI, getUplinkRateLimitBuckets(sp, devEUI))
+	if err != nil {
+		return false, errors.Wrap(err, "take uplink rate-limit token error")
+	}
+
+	return taken, nil
+}
+
+// TakeDownlinkRateLimitToken takes a token from the downlink token-bucket of
+// the given device and from the downlink token-bucket shared by all devices
+// of the service-profile. The device bucket is filled at the DLRate
+// (packets / hour) of the service-profile, up to DLBucketSize tokens. The
+// service-profile bucket is filled at the SPDLRate, up to SPDLBucketSize
+// tokens. It returns false when one of the buckets is empty, meaning that the
+// rate-limit has been exceeded. In this case no token is taken from the other
+// bucket. A bucket is not used when its rate is not defined.
+func TakeDownlinkRateLimitToken(ctx context.Context, p *redis.Pool, sp ServiceProfile, devEUI lorawan.EUI64) (bool, error) {
+	taken, err := takeRateLimitToken(ctx, p, sp, devEUI, getDownlinkRateLimitBuckets(sp, devEUI))
+	if err != nil {
+		return false, errors.Wrap(err, "take downlink rate-limit token error")
+	}
+
+	return taken, nil
+}
+
+// ReturnDownlinkRateLimitToken returns the token taken by
+// TakeDownlinkRateLimitToken. This must be used when the downlink could not
+// be sent or enqueued after the token was taken.
+func ReturnDownlinkRateLimitToken(ctx context.Context, p *redis.Pool, sp ServiceProfile, devEUI lorawan.EUI64) error {
+	buckets := getDownlinkRateLimitBuckets(sp, devEUI)
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	keysAndArgs := []interface{}{len(buckets)}
+	for _, b := range buckets {
+		keysAndArgs = append(keysAndArgs, b.key)
+	}
+	for _, b := range buckets {
+		if b.bucketSize < 1 {
+			b.bucketSize = 1
+		}
+		keysAndArgs = append(keysAndArgs, b.bucketSize)
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := tokenBucketReturnScript.Do(c, keysAndArgs...); err != nil {
+		return errors.Wrap(err, "return downlink rate-limit token error")
+	}
+
+	return nil
+}
+
+// getUplinkRateLimitBuckets returns the uplink token-buckets of the given
+// device and service-profile.
+func getUplinkRateLimitBuckets(sp ServiceProfile, devEUI lorawan.EUI64) []rateLimitBucket {
+	var buckets []rateLimitBucket
+
+	if sp.ULRate != 0 {
+		buckets = append(buckets, rateLimitBucket{
+			key:        fmt.Sprintf(uplinkRateLimitKeyTempl, sp.ID, devEUI),
+			rate:       sp.ULRate,
+			bucketSize: sp.ULBucketSize,
+			logFields: log.Fields{
+				"ul_rate":        sp.ULRate,
+				"ul_bucket_size": sp.ULBucketSize,
+			},
+			logMessage: "uplink rate-limit exceeded",
+		})
+	}
+
+	if sp.SPULRate != 0 {
+		buckets = append(buckets, rateLimitBucket{
+			key:        fmt.Sprintf(spUplinkRateLimitKeyTempl, sp.ID),
+			rate:       sp.SPULRate,
+			bucketSize: sp.SPULBucketSize,
+			logFields: log.Fields{
+				"sp_ul_rate":        sp.SPULRate,
+				"sp_ul_bucket_size": sp.SPULBucketSize,
+			},
+			logMessage: "service-profile uplink rate-limit exceeded",
+		})
+	}
+
+	return buckets
+}
+
+// getDownlinkRateLimitBuckets returns the downlink token-buckets of the given
+// device and service-profile.
+func getDownlinkRateLimitBuckets(sp ServiceProfile, devEUI lorawan.EUI64) []rateLimitBucket {
+	var buckets []rateLimitBucket
+
+	if sp.DLRate != 0 {
+		buckets = append(buckets, rateLimitBucket{
+			key:        fmt.Sprintf(downlinkRateLimitKeyTempl, sp.ID, devEUI),
+			rate:       sp.DLRate,
+			bucketSize: sp.DLBucketSize,
+			logFields: log.Fields{
+				"dl_rate":        sp.DLRate,
+				"dl_bucket_size": sp.DLBucketSize,
+			},
+			logMessage: "downlink rate-limit exceeded",
+		})
+	}
+
+	if sp.SPDLRate != 0 {
+		buckets = append(buckets, rateLimitBucket{
+			key:        fmt.Sprintf(spDownlinkRateLimitKeyTempl, sp.ID),
+			rate:       sp.SPDLRate,
+			bucketSize: sp.SPDLBucketSize,
+			logFields: log.Fields{
+				"sp_dl_rate":        sp.SPDLRate,
+				"sp_dl_bucket_size": sp.SPDLBucketSize,
+			},
+			logMessage: "service-profile downlink rate-limit exceeded",
+		})
+	}
+
+	return buckets
+}
+
+// takeRateLimitToken takes a token from each of the given buckets. When one
+// of the buckets is empty, no token is taken and false is returned. A
+// bucket-size < 1 is handled as a bucket-size of 1.
+func takeRateLimitToken(ctx context.Context, p *redis.Pool, sp ServiceProfile, devEUI lorawan.EUI64, buckets []rateLimitBucket) (bool, error) {
+	if len(buckets) == 0 {
+		return true, nil
+	}
+
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	keysAndArgs := make([]interface{}, 0, len(buckets)*4+1)
+
+	for _, b := range buckets {
+		keysAndArgs = append(keysAndArgs, b.key)
+	}
+	keysAndArgs = append(keysAndArgs, now)
+
+	for i := range buckets {
+		if buckets[i].bucketSize < 1 {
+			buckets[i].bucketSize = 1
+		}
+
+		// The bucket can be removed once it would have been completely
+		// refilled, as a missing bucket is handled as a full bucket.
+		ttl := int64(time.Duration(buckets[i].bucketSize) * time.Hour / time.Duration(buckets[i].rate) / time.Millisecond)
+		if ttl < 1 {
+			ttl = 1
+		}
+
+		keysAndArgs = append(keysAndArgs, buckets[i].rate, buckets[i].bucketSize, ttl)
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	empty, err := redis.Int(tokenBucketScript.Do(c, append([]interface{}{len(buckets)}, keysAndArgs...)...))
+	if err != nil {
+		return false, err
+	}
+
+	if empty == 0 {
+		return true, nil
+	}
+
+	b := buckets[empty-1]
+	log.WithFields(b.logFields).WithFields(log.Fields{
+		"dev_eui":            devEUI,
+		"service_profile_id": sp.ID,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info(b.logMessage)
+
+	return false, nil
+}
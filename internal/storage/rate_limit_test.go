@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-network-server/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestUplinkRateLimit() {
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	spID, err := uuid.NewV4()
+	ts.Require().NoError(err)
+
+	tests := []struct {
+		Name           string
+		ServiceProfile ServiceProfile
+		Takes          int
+		ExpectedTaken  []bool
+	}{
+		{
+			Name: "no uplink rate configured",
+			ServiceProfile: ServiceProfile{
+				ID: spID,
+			},
+			Takes:         3,
+			ExpectedTaken: []bool{true, true, true},
+		},
+		{
+			Name: "bucket size of 2",
+			ServiceProfile: ServiceProfile{
+				ID:           spID,
+				ULRate:       1,
+				ULBucketSize: 2,
+			},
+			Takes:         3,
+			ExpectedTaken: []bool{true, true, false},
+		},
+		{
+			Name: "bucket size of 0 is handled as 1",
+			ServiceProfile: ServiceProfile{
+				ID:     spID,
+				ULRate: 1,
+			},
+			Takes:         2,
+			ExpectedTaken: []bool{true, false},
+		},
+		{
+			Name: "service-profile bucket size of 2",
+			ServiceProfile: ServiceProfile{
+				ID:             spID,
+				SPULRate:       1,
+				SPULBucketSize: 2,
+			},
+			Takes:         3,
+			ExpectedTaken: []bool{true, true, false},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			test.MustFlushRedis(RedisPool())
+			assert := require.New(t)
+
+			var taken []bool
+			for i := 0; i < tst.Takes; i++ {
+				ok, err := TakeUplinkRateLimitToken(context.Background(), RedisPool(), tst.ServiceProfile, devEUI)
+				assert.NoError(err)
+				taken = append(taken, ok)
+			}
+
+			assert.Equal(tst.ExpectedTaken, taken)
+		})
+	}
+}
+
+func (ts *StorageTestSuite) TestServiceProfileUplinkRateLimit() {
+	spID, err := uuid.NewV4()
+	ts.Require().NoError(err)
+
+	ts.T().Run("bucket is shared by the devices of the service-profile", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		sp := ServiceProfile{
+			ID:             spID,
+			ULRate:         1,
+			ULBucketSize:   2,
+			SPULRate:       1,
+			SPULBucketSize: 3,
+		}
+
+		var taken []bool
+		for _, devEUI := range []lorawan.EUI64{{1}, {2}, {3}, {4}} {
+			ok, err := TakeUplinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI)
+			assert.NoError(err)
+			taken = append(taken, ok)
+		}
+
+		assert.Equal([]bool{true, true, true, false}, taken)
+	})
+
+	ts.T().Run("no device token is taken when the service-profile bucket is empty", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		sp := ServiceProfile{
+			ID:             spID,
+			ULRate:         1,
+			ULBucketSize:   2,
+			SPULRate:       1,
+			SPULBucketSize: 1,
+		}
+
+		var taken []bool
+		for i := 0; i < 2; i++ {
+			ok, err := TakeUplinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI)
+			assert.NoError(err)
+			taken = append(taken, ok)
+		}
+		assert.Equal([]bool{true, false}, taken)
+
+		// the device bucket must still contain one token
+		sp.SPULRate = 0
+		ok, err := TakeUplinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI)
+		assert.NoError(err)
+		assert.True(ok)
+	})
+}
+
+func (ts *StorageTestSuite) TestDownlinkRateLimit() {
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	spID, err := uuid.NewV4()
+	ts.Require().NoError(err)
+
+	ts.T().Run("uplink and downlink buckets are independent", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		sp := ServiceProfile{
+			ID:           spID,
+			ULRate:       1,
+			ULBucketSize: 1,
+			DLRate:       1,
+			DLBucketSize: 1,
+		}
+
+		ok, err := TakeUplinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI)
+		assert.NoError(err)
+		assert.True(ok)
+
+		ok, err = TakeDownlinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI)
+		assert.NoError(err)
+		assert.True(ok)
+
+		ok, err = TakeDownlinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI)
+		assert.NoError(err)
+		assert.False(ok)
+	})
+
+	ts.T().Run("service-profile bucket is shared by the devices", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		sp := ServiceProfile{
+			ID:             spID,
+			SPDLRate:       1,
+			SPDLBucketSize: 2,
+		}
+
+		var taken []bool
+		for _, devEUI := range []lorawan.EUI64{{1}, {2}, {3}} {
+			ok, err := TakeDownlinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI)
+			assert.NoError(err)
+			taken = append(taken, ok)
+		}
+
+		assert.Equal([]bool{true, true, false}, taken)
+	})
+
+	ts.T().Run("returned token can be taken again", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		sp := ServiceProfile{
+			ID:             spID,
+			DLRate:         1,
+			DLBucketSize:   1,
+			SPDLRate:       1,
+			SPDLBucketSize: 1,
+		}
+
+		ok, err := TakeDownlinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI)
+		assert.NoError(err)
+		assert.True(ok)
+
+		assert.NoError(ReturnDownlinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI))
+
+		ok, err = TakeDownlinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI)
+		assert.NoError(err)
+		assert.True(ok)
+
+		ok, err = TakeDownlinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI)
+		assert.NoError(err)
+		assert.False(ok)
+	})
+
+	ts.T().Run("no downlink rate configured", func(t *testing.T) {
+		test.MustFlushRedis(RedisPool())
+		assert := require.New(t)
+
+		sp := ServiceProfile{
+			ID: spID,
+		}
+
+		for i := 0; i < 3; i++ {
+			ok, err := TakeDownlinkRateLimitToken(context.Background(), RedisPool(), sp, devEUI)
+			assert.NoError(err)
+			assert.True(ok)
+		}
+	})
+}
@@ -53,6 +53,27 @@ type ServiceProfile struct {
 	NwkGeoLoc              bool       `db:"nwk_geo_loc"`
 	TargetPER              int        `db:"target_per"` // Example: 10 indicates 10%
 	MinGWDiversity         int        `db:"min_gw_diversity"`
+	MinGWDiversityPolicy   RatePolicy `db:"min_gw_diversity_policy"`
+	SPULRate               int        `db:"sp_ul_rate"`
+	SPULBucketSize         int        `db:"sp_ul_bucket_size"`
+	SPDLRate               int        `db:"sp_dl_rate"`
+	SPDLBucketSize         int        `db:"sp_dl_bucket_size"`
+}
+
+// IsChannelEnabled returns true when the given uplink channel index is
+// enabled by the channel-mask of the service-profile. The channel-mask is
+// a bitmask, the LSB of the first byte representing channel 0. When no
+// channel-mask is set, all channels are enabled.
+func (sp ServiceProfile) IsChannelEnabled(c int) bool {
+	if len(sp.ChannelMask) == 0 {
+		return true
+	}
+
+	if c < 0 || c/8 >= len(sp.ChannelMask) {
+		return false
+	}
+
+	return sp.ChannelMask[c/8]&(1<<uint(c%8)) != 0
 }
 
 // CreateServiceProfile creates the given service-profile.
@@ -94,8 +115,13 @@ func CreateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 			ra_allowed,
 			nwk_geo_loc,
 			target_per,
-			min_gw_diversity
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`,
+			min_gw_diversity,
+			min_gw_diversity_policy,
+			sp_ul_rate,
+			sp_ul_bucket_size,
+			sp_dl_rate,
+			sp_dl_bucket_size
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27)`,
 		sp.CreatedAt,
 		sp.UpdatedAt,
 		sp.ID,
@@ -118,6 +144,11 @@ func CreateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 		sp.NwkGeoLoc,
 		sp.TargetPER,
 		sp.MinGWDiversity,
+		sp.MinGWDiversityPolicy,
+		sp.SPULRate,
+		sp.SPULBucketSize,
+		sp.SPDLRate,
+		sp.SPDLBucketSize,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -263,7 +294,12 @@ func UpdateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 			ra_allowed = $18,
 			nwk_geo_loc = $19,
 			target_per = $20,
-			min_gw_diversity = $21
+			min_gw_diversity = $21,
+			min_gw_diversity_policy = $22,
+			sp_ul_rate = $23,
+			sp_ul_bucket_size = $24,
+			sp_dl_rate = $25,
+			sp_dl_bucket_size = $26
 		where
 			service_profile_id = $1`,
 		sp.ID,
@@ -287,6 +323,11 @@ func UpdateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 		sp.NwkGeoLoc,
 		sp.TargetPER,
 		sp.MinGWDiversity,
+		sp.MinGWDiversityPolicy,
+		sp.SPULRate,
+		sp.SPULBucketSize,
+		sp.SPDLRate,
+		sp.SPDLBucketSize,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
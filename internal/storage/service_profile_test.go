@@ -43,6 +43,11 @@ func TestServiceProfile(t *testing.T) {
 				NwkGeoLoc:              true,
 				TargetPER:              1,
 				MinGWDiversity:         8,
+				MinGWDiversityPolicy:   Mark,
+				SPULRate:               9,
+				SPULBucketSize:         10,
+				SPDLRate:               11,
+				SPDLBucketSize:         12,
 			}
 
 			So(CreateServiceProfile(context.Background(), DB(), &sp), ShouldBeNil)
@@ -78,6 +83,7 @@ func TestServiceProfile(t *testing.T) {
 				sp.NwkGeoLoc = false
 				sp.TargetPER = 2
 				sp.MinGWDiversity = 9
+				sp.MinGWDiversityPolicy = Drop
 
 				So(UpdateServiceProfile(context.Background(), DB(), &sp), ShouldBeNil)
 				sp.UpdatedAt = sp.UpdatedAt.UTC().Truncate(time.Millisecond)
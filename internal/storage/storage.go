@@ -17,6 +17,12 @@ import (
 // deviceSessionTTL holds the device-session TTL.
 var deviceSessionTTL time.Duration
 
+// deviceStatusHistoryTTL holds the device-status history TTL.
+var deviceStatusHistoryTTL time.Duration
+
+// multicastDeliveryTTL holds the multicast queue-item delivery TTL.
+var multicastDeliveryTTL time.Duration
+
 // schedulerInterval holds the interval in which the Class-B and -C
 // scheduler runs.
 var schedulerInterval time.Duration
@@ -26,6 +32,8 @@ func Setup(c config.Config) error {
 	log.Info("storage: setting up storage module")
 
 	deviceSessionTTL = c.NetworkServer.DeviceSessionTTL
+	deviceStatusHistoryTTL = c.NetworkServer.DeviceStatusHistoryTTL
+	multicastDeliveryTTL = c.NetworkServer.MulticastDeliveryTTL
 	schedulerInterval = c.NetworkServer.Scheduler.SchedulerInterval
 
 	log.Info("storage: setting up Redis connection pool")
@@ -152,6 +152,25 @@ func AssertMulticastQueueItems(items []storage.MulticastQueueItem) Assertion {
 	}
 }
 
+// AssertMulticastQueueItemDelivery asserts the delivery of the multicast
+// queue-item by the given gateway.
+func AssertMulticastQueueItemDelivery(fCnt uint32, gatewayID lorawan.EUI64, status storage.MulticastDeliveryStatus, errStr string) Assertion {
+	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
+		deliveries, err := storage.GetMulticastQueueItemDeliveries(context.Background(), storage.DB(), ts.MulticastGroup.ID, fCnt)
+		assert.NoError(err)
+
+		for _, d := range deliveries {
+			if d.GatewayID == gatewayID {
+				assert.Equal(status, d.Status)
+				assert.Equal(errStr, d.Error)
+				return
+			}
+		}
+
+		assert.Fail("multicast queue-item delivery does not exist")
+	}
+}
+
 // AssertDeviceQueueItems asserts the device-queue items.
 func AssertDeviceQueueItems(items []storage.DeviceQueueItem) Assertion {
 	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
@@ -252,6 +271,18 @@ func AssertASHandleUplinkDataRequest(req as.HandleUplinkDataRequest) Assertion {
 	}
 }
 
+// AssertNoASHandleUplinkDataRequest asserts that there is no uplink request.
+func AssertNoASHandleUplinkDataRequest() Assertion {
+	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
+		time.Sleep(100 * time.Millisecond)
+		select {
+		case <-ts.ASClient.HandleDataUpChan:
+			assert.Fail("unexpected uplink request")
+		default:
+		}
+	}
+}
+
 // AssertASHandleDownlinkACKRequest asserts the given ack request.
 func AssertASHandleDownlinkACKRequest(req as.HandleDownlinkACKRequest) Assertion {
 	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
@@ -262,6 +293,16 @@ func AssertASHandleDownlinkACKRequest(req as.HandleDownlinkACKRequest) Assertion
 	}
 }
 
+// AssertASHandleTxAckRequest asserts the given tx ack request.
+func AssertASHandleTxAckRequest(req as.HandleTxAckRequest) Assertion {
+	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
+		r := <-ts.ASClient.HandleTxAckChan
+		if !proto.Equal(&r, &req) {
+			assert.Equal(req, r)
+		}
+	}
+}
+
 // AssertASHandleErrorRequest asserts the given error request.
 func AssertASHandleErrorRequest(req as.HandleErrorRequest) Assertion {
 	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
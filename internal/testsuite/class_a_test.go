@@ -3597,6 +3597,203 @@ func (ts *ClassATestSuite) TestLW11ReceiveWindowSelection() {
 	}
 }
 
+func (ts *ClassATestSuite) TestLW10UplinkRateLimit() {
+	ts.CreateDeviceSession(storage.DeviceSession{
+		MACVersion:            "1.0.2",
+		JoinEUI:               lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+		DevAddr:               lorawan.DevAddr{1, 2, 3, 4},
+		FNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		NwkSEncKey:            [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		FCntUp:                8,
+		NFCntDown:             5,
+		EnabledUplinkChannels: []int{0, 1, 2},
+		RX2Frequency:          869525000,
+	})
+
+	sp := *ts.ServiceProfile
+	defer func() {
+		ts.Require().NoError(storage.UpdateServiceProfile(context.Background(), storage.DB(), &sp))
+	}()
+
+	var fPortOne uint8 = 1
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: ts.DeviceSession.DevAddr,
+				FCnt:    10,
+			},
+			FPort:      &fPortOne,
+			FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}}},
+		},
+		MIC: lorawan.MIC{104, 147, 35, 121},
+	}
+
+	// setRatePolicy sets the uplink rate-policy and empties the bucket of the
+	// device.
+	setRatePolicy := func(policy storage.RatePolicy) func(*ClassATest) error {
+		return func(tst *ClassATest) error {
+			sp := *ts.ServiceProfile
+			sp.ULRate = 1
+			sp.ULBucketSize = 1
+			sp.ULRatePolicy = policy
+			if err := storage.UpdateServiceProfile(context.Background(), storage.DB(), &sp); err != nil {
+				return err
+			}
+
+			_, err := storage.TakeUplinkRateLimitToken(context.Background(), storage.RedisPool(), sp, tst.DeviceSession.DevEUI)
+			return err
+		}
+	}
+
+	tests := []ClassATest{
+		{
+			Name:          "rate-limit exceeded, frame is dropped",
+			BeforeFunc:    setRatePolicy(storage.Drop),
+			DeviceSession: *ts.DeviceSession,
+			TXInfo:        ts.TXInfo,
+			RXInfo:        ts.RXInfo,
+			PHYPayload:    phy,
+			Assert: []Assertion{
+				AssertFCntUp(11),
+				AssertNFCntDown(5),
+				AssertNoASHandleUplinkDataRequest(),
+			},
+		},
+		{
+			Name:          "rate-limit exceeded, frame is marked",
+			BeforeFunc:    setRatePolicy(storage.Mark),
+			DeviceSession: *ts.DeviceSession,
+			TXInfo:        ts.TXInfo,
+			RXInfo:        ts.RXInfo,
+			PHYPayload:    phy,
+			Assert: []Assertion{
+				AssertFCntUp(11),
+				AssertNFCntDown(5),
+				AssertASHandleUplinkDataRequest(as.HandleUplinkDataRequest{
+					DevEui:              ts.Device.DevEUI[:],
+					JoinEui:             ts.DeviceSession.JoinEUI[:],
+					FCnt:                10,
+					FPort:               1,
+					Dr:                  0,
+					TxInfo:              &ts.TXInfo,
+					RxInfo:              []*gw.UplinkRXInfo{&ts.RXInfo},
+					Data:                []byte{1, 2, 3, 4},
+					UlRateLimitExceeded: true,
+				}),
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			ts.AssertClassATest(t, tst)
+		})
+	}
+}
+
+func (ts *ClassATestSuite) TestLW10MinGWDiversity() {
+	ts.CreateDeviceSession(storage.DeviceSession{
+		MACVersion:            "1.0.2",
+		JoinEUI:               lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+		DevAddr:               lorawan.DevAddr{1, 2, 3, 4},
+		FNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SNwkSIntKey:           [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		NwkSEncKey:            [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		FCntUp:                8,
+		NFCntDown:             5,
+		EnabledUplinkChannels: []int{0, 1, 2},
+		RX2Frequency:          869525000,
+	})
+
+	sp := *ts.ServiceProfile
+	defer func() {
+		ts.Require().NoError(storage.UpdateServiceProfile(context.Background(), storage.DB(), &sp))
+	}()
+
+	var fPortOne uint8 = 1
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: ts.DeviceSession.DevAddr,
+				FCnt:    10,
+			},
+			FPort:      &fPortOne,
+			FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}}},
+		},
+		MIC: lorawan.MIC{104, 147, 35, 121},
+	}
+
+	// setMinGWDiversity sets the min. gateway diversity of the
+	// service-profile.
+	setMinGWDiversity := func(minGWDiversity int, policy storage.RatePolicy) func(*ClassATest) error {
+		return func(tst *ClassATest) error {
+			sp := *ts.ServiceProfile
+			sp.MinGWDiversity = minGWDiversity
+			sp.MinGWDiversityPolicy = policy
+			return storage.UpdateServiceProfile(context.Background(), storage.DB(), &sp)
+		}
+	}
+
+	tests := []ClassATest{
+		{
+			Name:          "min. gateway diversity not met, frame is dropped",
+			BeforeFunc:    setMinGWDiversity(2, storage.Drop),
+			DeviceSession: *ts.DeviceSession,
+			TXInfo:        ts.TXInfo,
+			RXInfo:        ts.RXInfo,
+			PHYPayload:    phy,
+			Assert: []Assertion{
+				AssertFCntUp(11),
+				AssertNFCntDown(5),
+				AssertASHandleErrorRequest(as.HandleErrorRequest{
+					DevEui: ts.Device.DevEUI[:],
+					Type:   as.ErrorType_DATA_UP_MIN_GW_DIVERSITY,
+					FCnt:   10,
+					Error:  "received by 1 gateway(s), min. gateway diversity is 2",
+				}),
+				AssertNoASHandleUplinkDataRequest(),
+			},
+		},
+		{
+			Name:          "min. gateway diversity met",
+			BeforeFunc:    setMinGWDiversity(1, storage.Drop),
+			DeviceSession: *ts.DeviceSession,
+			TXInfo:        ts.TXInfo,
+			RXInfo:        ts.RXInfo,
+			PHYPayload:    phy,
+			Assert: []Assertion{
+				AssertFCntUp(11),
+				AssertNFCntDown(5),
+				AssertASHandleUplinkDataRequest(as.HandleUplinkDataRequest{
+					DevEui:  ts.Device.DevEUI[:],
+					JoinEui: ts.DeviceSession.JoinEUI[:],
+					FCnt:    10,
+					FPort:   1,
+					Dr:      0,
+					TxInfo:  &ts.TXInfo,
+					RxInfo:  []*gw.UplinkRXInfo{&ts.RXInfo},
+					Data:    []byte{1, 2, 3, 4},
+				}),
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			ts.AssertClassATest(t, tst)
+		})
+	}
+}
+
 func TestClassA(t *testing.T) {
 	suite.Run(t, new(ClassATestSuite))
 }
@@ -1,6 +1,8 @@
 package testsuite
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/gofrs/uuid"
@@ -8,6 +10,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/brocaar/lorawan"
+	"github.com/brocaar/chirpstack-network-server/api/as"
 	"github.com/brocaar/chirpstack-network-server/api/gw"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 )
@@ -16,6 +19,24 @@ type DownlinkTXAckTestSuite struct {
 	IntegrationTestSuite
 }
 
+func (ts *DownlinkTXAckTestSuite) SetupTest() {
+	ts.IntegrationTestSuite.SetupTest()
+
+	ts.CreateDevice(storage.Device{
+		DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+	})
+
+	ts.CreateGateway(storage.Gateway{
+		GatewayID: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+	})
+
+	ts.CreateMulticastGroup(storage.MulticastGroup{
+		GroupType: storage.MulticastGroupC,
+		MCAddr:    lorawan.DevAddr{1, 2, 3, 4},
+		FCnt:      10,
+	})
+}
+
 func (ts *DownlinkTXAckTestSuite) TestDownlinkTXAck() {
 	assert := require.New(ts.T())
 
@@ -69,6 +90,69 @@ func (ts *DownlinkTXAckTestSuite) TestDownlinkTXAck() {
 				AssertNoDownlinkFrame,
 			},
 		},
+		{
+			Name:   "positive ack, application payload",
+			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			DownlinkTXAck: gw.DownlinkTXAck{
+				Token:     12345,
+				GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+			},
+			DownlinkFrames: storage.DownlinkFrames{
+				Token:  12345,
+				DevEui: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+				FCnt:   7,
+				DownlinkFrames: []*gw.DownlinkFrame{
+					{
+						Token: 12345,
+						TxInfo: &gw.DownlinkTXInfo{
+							GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+						},
+						PhyPayload: phyB,
+					},
+				},
+			},
+			Assert: []Assertion{
+				AssertNoDownlinkFrame,
+				AssertASHandleTxAckRequest(as.HandleTxAckRequest{
+					DevEui:    []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+					FCnt:      7,
+					GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+				}),
+			},
+		},
+		{
+			Name:   "positive ack, application-server error does not abort",
+			BeforeFunc: func(tst *DownlinkTXAckTest) error {
+				ts.ASClient.HandleTxAckErr = errors.New("boom")
+				return nil
+			},
+			DownlinkTXAck: gw.DownlinkTXAck{
+				Token:     12345,
+				GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+			},
+			DownlinkFrames: storage.DownlinkFrames{
+				Token:  12345,
+				DevEui: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+				FCnt:   7,
+				DownlinkFrames: []*gw.DownlinkFrame{
+					{
+						Token: 12345,
+						TxInfo: &gw.DownlinkTXInfo{
+							GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+						},
+						PhyPayload: phyB,
+					},
+				},
+			},
+			Assert: []Assertion{
+				AssertNoDownlinkFrame,
+				AssertASHandleTxAckRequest(as.HandleTxAckRequest{
+					DevEui:    []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+					FCnt:      7,
+					GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+				}),
+			},
+		},
 		{
 			Name:   "negative ack",
 			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
@@ -108,6 +192,172 @@ func (ts *DownlinkTXAckTestSuite) TestDownlinkTXAck() {
 				}, phy),
 			},
 		},
+		{
+			Name:   "negative ack, pending frame uses alternative gateway",
+			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			BeforeFunc: func(tst *DownlinkTXAckTest) error {
+				return storage.SaveDeviceGatewayRXInfoSet(context.Background(), storage.RedisPool(), storage.DeviceGatewayRXInfoSet{
+					DevEUI: tst.DevEUI,
+					Items: []storage.DeviceGatewayRXInfo{
+						{
+							GatewayID: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+							Context:   []byte{1, 2, 3, 4},
+						},
+						{
+							GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+							Antenna:   1,
+							Context:   []byte{4, 3, 2, 1},
+						},
+					},
+				})
+			},
+			DownlinkTXAck: gw.DownlinkTXAck{
+				Token:     12345,
+				GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+				Error:     "COLLISION_PACKET",
+			},
+			DownlinkFrames: storage.DownlinkFrames{
+				Token:  12345,
+				DevEui: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+				DownlinkFrames: []*gw.DownlinkFrame{
+					// the one that was previously "sent"
+					{
+						Token: 12345,
+						TxInfo: &gw.DownlinkTXInfo{
+							GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+							Frequency: 868100000,
+							Context:   []byte{1, 2, 3, 4},
+						},
+						PhyPayload: phyB,
+					},
+					// the next one in the queue
+					{
+						Token: 12345,
+						TxInfo: &gw.DownlinkTXInfo{
+							GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+							Frequency: 869525000,
+							Context:   []byte{1, 2, 3, 4},
+						},
+						PhyPayload: phyB,
+					},
+				},
+			},
+			Assert: []Assertion{
+				AssertDownlinkFrame(gw.DownlinkTXInfo{
+					GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1},
+					Antenna:   1,
+					Frequency: 869525000,
+					Context:   []byte{4, 3, 2, 1},
+				}, phy),
+			},
+		},
+		{
+			Name:   "negative ack, too late is not retried using alternative gateway",
+			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			BeforeFunc: func(tst *DownlinkTXAckTest) error {
+				return storage.SaveDeviceGatewayRXInfoSet(context.Background(), storage.RedisPool(), storage.DeviceGatewayRXInfoSet{
+					DevEUI: tst.DevEUI,
+					Items: []storage.DeviceGatewayRXInfo{
+						{
+							GatewayID: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+							Context:   []byte{1, 2, 3, 4},
+						},
+						{
+							GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+							Antenna:   1,
+							Context:   []byte{4, 3, 2, 1},
+						},
+					},
+				})
+			},
+			DownlinkTXAck: gw.DownlinkTXAck{
+				Token:     12345,
+				GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+				Error:     "TOO_LATE",
+			},
+			DownlinkFrames: storage.DownlinkFrames{
+				Token:  12345,
+				DevEui: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+				DownlinkFrames: []*gw.DownlinkFrame{
+					// the one that was previously "sent"
+					{
+						Token: 12345,
+						TxInfo: &gw.DownlinkTXInfo{
+							GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+							Frequency: 868100000,
+							Context:   []byte{1, 2, 3, 4},
+						},
+						PhyPayload: phyB,
+					},
+					// the next one in the queue
+					{
+						Token: 12345,
+						TxInfo: &gw.DownlinkTXInfo{
+							GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+							Frequency: 869525000,
+							Context:   []byte{1, 2, 3, 4},
+						},
+						PhyPayload: phyB,
+					},
+				},
+			},
+			Assert: []Assertion{
+				AssertDownlinkFrame(gw.DownlinkTXInfo{
+					GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+					Frequency: 869525000,
+					Context:   []byte{1, 2, 3, 4},
+				}, phy),
+			},
+		},
+		{
+			Name:   "negative ack, retry using alternative gateway",
+			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			BeforeFunc: func(tst *DownlinkTXAckTest) error {
+				return storage.SaveDeviceGatewayRXInfoSet(context.Background(), storage.RedisPool(), storage.DeviceGatewayRXInfoSet{
+					DevEUI: tst.DevEUI,
+					Items: []storage.DeviceGatewayRXInfo{
+						{
+							GatewayID: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+							Context:   []byte{1, 2, 3, 4},
+						},
+						{
+							GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+							Antenna:   1,
+							Context:   []byte{4, 3, 2, 1},
+						},
+					},
+				})
+			},
+			DownlinkTXAck: gw.DownlinkTXAck{
+				Token:     12345,
+				GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+				Error:     "TX_FREQ",
+			},
+			DownlinkFrames: storage.DownlinkFrames{
+				Token:  12345,
+				DevEui: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+				DownlinkFrames: []*gw.DownlinkFrame{
+					// the one that was previously "sent"
+					{
+						Token: 12345,
+						TxInfo: &gw.DownlinkTXInfo{
+							GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+							Frequency: 868100000,
+							Context:   []byte{1, 2, 3, 4},
+						},
+						PhyPayload: phyB,
+					},
+				},
+			},
+			Assert: []Assertion{
+				AssertDownlinkFrame(gw.DownlinkTXInfo{
+					GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1},
+					Antenna:   1,
+					Frequency: 868100000,
+					Context:   []byte{4, 3, 2, 1},
+				}, phy),
+			},
+		},
 		{
 			Name:   "negative ack, no saved downlink-frame",
 			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
@@ -119,6 +369,7 @@ func (ts *DownlinkTXAckTestSuite) TestDownlinkTXAck() {
 			DownlinkFrames: storage.DownlinkFrames{
 				Token:  54321,
 				DevEui: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+				FCnt:   7,
 				DownlinkFrames: []*gw.DownlinkFrame{
 					{
 						Token: 54321,
@@ -131,6 +382,65 @@ func (ts *DownlinkTXAckTestSuite) TestDownlinkTXAck() {
 			},
 			Assert: []Assertion{
 				AssertNoDownlinkFrame,
+				AssertASHandleTxAckRequest(as.HandleTxAckRequest{
+					DevEui:    []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+					FCnt:      7,
+					GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+					Error:     "BOOM",
+				}),
+			},
+		},
+		{
+			Name:       "positive ack, multicast",
+			BeforeFunc: ts.saveMulticastQueueItemDelivery,
+			DownlinkTXAck: gw.DownlinkTXAck{
+				Token:     12345,
+				GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+			},
+			DownlinkFrames: storage.DownlinkFrames{
+				Token:            12345,
+				MulticastGroupId: ts.MulticastGroup.ID[:],
+				FCnt:             10,
+				DownlinkFrames: []*gw.DownlinkFrame{
+					{
+						Token: 12345,
+						TxInfo: &gw.DownlinkTXInfo{
+							GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+						},
+						PhyPayload: phyB,
+					},
+				},
+			},
+			Assert: []Assertion{
+				AssertNoDownlinkFrame,
+				AssertMulticastQueueItemDelivery(10, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, storage.MulticastDeliverySuccess, ""),
+			},
+		},
+		{
+			Name:       "negative ack, multicast",
+			BeforeFunc: ts.saveMulticastQueueItemDelivery,
+			DownlinkTXAck: gw.DownlinkTXAck{
+				Token:     12345,
+				GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+				Error:     "TOO_LATE",
+			},
+			DownlinkFrames: storage.DownlinkFrames{
+				Token:            12345,
+				MulticastGroupId: ts.MulticastGroup.ID[:],
+				FCnt:             10,
+				DownlinkFrames: []*gw.DownlinkFrame{
+					{
+						Token: 12345,
+						TxInfo: &gw.DownlinkTXInfo{
+							GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+						},
+						PhyPayload: phyB,
+					},
+				},
+			},
+			Assert: []Assertion{
+				AssertNoDownlinkFrame,
+				AssertMulticastQueueItemDelivery(10, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, storage.MulticastDeliveryFailed, "TOO_LATE"),
 			},
 		},
 	}
@@ -142,6 +452,15 @@ func (ts *DownlinkTXAckTestSuite) TestDownlinkTXAck() {
 	}
 }
 
+func (ts *DownlinkTXAckTestSuite) saveMulticastQueueItemDelivery(tst *DownlinkTXAckTest) error {
+	return storage.SaveMulticastQueueItemDelivery(context.Background(), storage.DB(), &storage.MulticastQueueItemDelivery{
+		MulticastGroupID: ts.MulticastGroup.ID,
+		FCnt:             10,
+		GatewayID:        lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+		Status:           storage.MulticastDeliveryPending,
+	})
+}
+
 func TestDownlinkTXAck(t *testing.T) {
 	suite.Run(t, new(DownlinkTXAckTestSuite))
 }
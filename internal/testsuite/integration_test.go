@@ -127,6 +127,7 @@ type ClassATest struct {
 // DownlinkTXAckTest is the structure for a downlink tx ack test.
 type DownlinkTXAckTest struct {
 	Name           string
+	BeforeFunc     func(*DownlinkTXAckTest) error
 	DevEUI         lorawan.EUI64
 	DownlinkTXAck  gw.DownlinkTXAck
 	DownlinkFrames storage.DownlinkFrames
@@ -661,6 +662,11 @@ func (ts *IntegrationTestSuite) AssertUplinkProprietaryTest(t *testing.T, tst Up
 func (ts *IntegrationTestSuite) AssertDownlinkTXAckTest(t *testing.T, tst DownlinkTXAckTest) {
 	assert := require.New(t)
 	test.MustFlushRedis(storage.RedisPool())
+	ts.FlushClients()
+
+	if tst.BeforeFunc != nil {
+		assert.NoError(tst.BeforeFunc(&tst))
+	}
 
 	assert.NoError(storage.SaveDownlinkFrames(context.Background(), storage.RedisPool(), tst.DownlinkFrames))
 
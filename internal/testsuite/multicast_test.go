@@ -13,8 +13,10 @@ import (
 	"github.com/brocaar/chirpstack-network-server/api/common"
 	"github.com/brocaar/chirpstack-network-server/api/gw"
 	"github.com/brocaar/chirpstack-network-server/api/nc"
+	"github.com/brocaar/chirpstack-network-server/internal/downlink/txschedule"
 	"github.com/brocaar/chirpstack-network-server/internal/gps"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/chirpstack-network-server/internal/test"
 )
 
 type MulticastTestSuite struct {
@@ -87,6 +89,7 @@ func (ts *MulticastTestSuite) TestMulticast() {
 			},
 			Assert: []Assertion{
 				AssertMulticastQueueItems([]storage.MulticastQueueItem{}),
+				AssertMulticastQueueItemDelivery(10, ts.Gateway.GatewayID, storage.MulticastDeliveryPending, ""),
 				AssertDownlinkFrame(gw.DownlinkTXInfo{
 					GatewayId:  ts.Gateway.GatewayID[:],
 					Frequency:  uint32(ts.MulticastGroup.Frequency),
@@ -237,6 +240,7 @@ func (ts *MulticastTestSuite) TestMulticast() {
 			Assert: []Assertion{
 				AssertNoDownlinkFrame,
 				AssertMulticastQueueItems([]storage.MulticastQueueItem{}),
+				AssertMulticastQueueItemDelivery(10, ts.Gateway.GatewayID, storage.MulticastDeliveryFailed, storage.MulticastDeliveryErrorPayloadSize),
 			},
 		},
 	}
@@ -248,6 +252,52 @@ func (ts *MulticastTestSuite) TestMulticast() {
 	}
 }
 
+func (ts *MulticastTestSuite) TestMulticastReschedule() {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+	conf.NetworkServer.NetworkSettings.TXSchedule.Enabled = true
+	assert.NoError(txschedule.Setup(conf))
+	defer txschedule.Setup(test.GetConfig())
+
+	emitAt := time.Now().Add(3 * time.Second).Round(time.Second)
+	emitAtGPS := gps.Time(emitAt).TimeSinceGPSEpoch()
+
+	ts.AssertMulticastTest(ts.T(), MulticastTest{
+		Name:           "item rescheduled because of tx schedule",
+		MulticastGroup: *ts.MulticastGroup,
+		BeforeFunc: func(tst *MulticastTest) error {
+			return storage.SaveGatewayTXScheduleItem(context.Background(), storage.RedisPool(), ts.Gateway.GatewayID, storage.GatewayTXScheduleItem{
+				Start:     emitAt,
+				Airtime:   time.Second,
+				Frequency: uint32(ts.MulticastGroup.Frequency),
+			}, time.Minute)
+		},
+		MulticastQueueItems: []storage.MulticastQueueItem{
+			{
+				ScheduleAt:              time.Now(),
+				EmitAtTimeSinceGPSEpoch: &emitAtGPS,
+				MulticastGroupID:        ts.MulticastGroup.ID,
+				GatewayID:               ts.Gateway.GatewayID,
+				FCnt:                    10,
+				FPort:                   2,
+				FRMPayload:              []byte{1, 2, 3, 4},
+			},
+		},
+		Assert: []Assertion{
+			AssertNoDownlinkFrame,
+			AssertMulticastQueueItemDelivery(10, ts.Gateway.GatewayID, storage.MulticastDeliveryPending, storage.MulticastDeliveryErrorTXSchedule),
+			func(assert *require.Assertions, ts *IntegrationTestSuite) {
+				items, err := storage.GetMulticastQueueItemsForMulticastGroup(context.Background(), storage.DB(), ts.MulticastGroup.ID)
+				assert.NoError(err)
+				assert.Len(items, 1)
+				assert.True(*items[0].EmitAtTimeSinceGPSEpoch > emitAtGPS)
+				assert.True(items[0].ScheduleAt.After(time.Now()))
+			},
+		},
+	})
+}
+
 func TestMulticast(t *testing.T) {
 	suite.Run(t, new(MulticastTestSuite))
 }
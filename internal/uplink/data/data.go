@@ -25,8 +25,10 @@ import (
 	"github.com/brocaar/chirpstack-network-server/internal/logging"
 	"github.com/brocaar/chirpstack-network-server/internal/maccommand"
 	"github.com/brocaar/chirpstack-network-server/internal/models"
+	"github.com/brocaar/chirpstack-network-server/internal/roaming"
 	"github.com/brocaar/chirpstack-network-server/internal/storage"
 	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
 )
 
 const applicationClientTimeout = time.Second
@@ -39,7 +41,9 @@ var tasks = []func(*dataContext) error{
 	logUplinkFrame,
 	getDeviceProfile,
 	getServiceProfile,
+	checkUplinkRateLimit,
 	getApplicationServerClientForDataUp,
+	checkMinGWDiversity,
 	resolveDeviceLocation,
 	setADR,
 	setUplinkDataRate,
@@ -80,10 +84,30 @@ type dataContext struct {
 	ApplicationServerClient as.ApplicationServerServiceClient
 	MACCommandResponses     []storage.MACCommandBlock
 	MustSendDownlink        bool
+
+	// ULRateLimitExceeded is set when the uplink rate-limit of the
+	// service-profile has been exceeded and the frame must be marked.
+	ULRateLimitExceeded bool
+
+	// MinGWDiversityNotMet is set when the frame was received by less
+	// gateways than the min. gateway diversity of the service-profile and
+	// the frame must be marked.
+	MinGWDiversityNotMet bool
+
+	// RoamingNetID and RoamingULMetaData are set when the uplink was
+	// received from the fNS of a roaming partner (passive-roaming).
+	RoamingNetID      lorawan.NetID
+	RoamingULMetaData backend.ULMetaData
 }
 
 // Handle handles an uplink data frame
 func Handle(ctx context.Context, rxPacket models.RXPacket) error {
+	// forward the frame to the roaming partner in case the DevAddr belongs
+	// to the NetID of a roaming agreement
+	if macPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload); ok && roaming.IsRoamingDevAddr(macPL.FHDR.DevAddr) {
+		return handleRoamingFNS(ctx, rxPacket)
+	}
+
 	dctx := dataContext{
 		ctx:      ctx,
 		RXPacket: rxPacket,
@@ -91,6 +115,9 @@ func Handle(ctx context.Context, rxPacket models.RXPacket) error {
 
 	for _, t := range tasks {
 		if err := t(&dctx); err != nil {
+			if err == ErrAbort {
+				return nil
+			}
 			return err
 		}
 	}
@@ -176,6 +203,87 @@ func getServiceProfile(ctx *dataContext) error {
 	return nil
 }
 
+func checkUplinkRateLimit(ctx *dataContext) error {
+	ok, err := storage.TakeUplinkRateLimitToken(ctx.ctx, storage.RedisPool(), ctx.ServiceProfile, ctx.DeviceSession.DevEUI)
+	if err != nil {
+		return errors.Wrap(err, "take uplink rate-limit token error")
+	}
+	if ok {
+		return nil
+	}
+
+	switch ctx.ServiceProfile.ULRatePolicy {
+	case storage.Mark:
+		ctx.ULRateLimitExceeded = true
+		return nil
+	default:
+		log.WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"f_cnt":   ctx.MACPayload.FHDR.FCnt,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("uplink rate-limit exceeded, dropping frame")
+
+		if err := saveUplinkFCnt(ctx); err != nil {
+			return err
+		}
+
+		return ErrAbort
+	}
+}
+
+func checkMinGWDiversity(ctx *dataContext) error {
+	gwCount := getGatewayCount(ctx.RXPacket.RXInfoSet)
+	if ctx.ServiceProfile.MinGWDiversity == 0 || gwCount >= ctx.ServiceProfile.MinGWDiversity {
+		return nil
+	}
+
+	switch ctx.ServiceProfile.MinGWDiversityPolicy {
+	case storage.Mark:
+		ctx.MinGWDiversityNotMet = true
+		return nil
+	default:
+		log.WithFields(log.Fields{
+			"dev_eui":          ctx.DeviceSession.DevEUI,
+			"f_cnt":            ctx.MACPayload.FHDR.FCnt,
+			"gateway_count":    gwCount,
+			"min_gw_diversity": ctx.ServiceProfile.MinGWDiversity,
+			"ctx_id":           ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("min. gateway diversity not met, dropping frame")
+
+		go func(ctx context.Context, asClient as.ApplicationServerServiceClient, req as.HandleErrorRequest) {
+			ctxTimeout, cancel := context.WithTimeout(ctx, applicationClientTimeout)
+			defer cancel()
+
+			if _, err := asClient.HandleError(ctxTimeout, &req); err != nil {
+				log.WithFields(log.Fields{
+					"ctx_id": ctx.Value(logging.ContextIDKey),
+				}).WithError(err).Error("publish error to application-server error")
+			}
+		}(ctx.ctx, ctx.ApplicationServerClient, as.HandleErrorRequest{
+			DevEui: ctx.DeviceSession.DevEUI[:],
+			Type:   as.ErrorType_DATA_UP_MIN_GW_DIVERSITY,
+			FCnt:   ctx.MACPayload.FHDR.FCnt,
+			Error:  fmt.Sprintf("received by %d gateway(s), min. gateway diversity is %d", gwCount, ctx.ServiceProfile.MinGWDiversity),
+		})
+
+		if err := saveUplinkFCnt(ctx); err != nil {
+			return err
+		}
+
+		return ErrAbort
+	}
+}
+
+// getGatewayCount returns the number of distinct gateways in the given
+// rx-info set.
+func getGatewayCount(rxInfoSet []*gw.UplinkRXInfo) int {
+	gateways := make(map[lorawan.EUI64]struct{})
+	for _, rxInfo := range rxInfoSet {
+		gateways[helpers.GetGatewayID(rxInfo)] = struct{}{}
+	}
+	return len(gateways)
+}
+
 func setADR(ctx *dataContext) error {
 	ctx.DeviceSession.ADR = ctx.MACPayload.FHDR.FCtrl.ADR
 	return nil
@@ -589,6 +697,9 @@ func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
 		FCnt:    ctx.MACPayload.FHDR.FCnt,
 		Adr:     ctx.MACPayload.FHDR.FCtrl.ADR,
 		TxInfo:  ctx.RXPacket.TXInfo,
+
+		UlRateLimitExceeded:  ctx.ULRateLimitExceeded,
+		MinGwDiversityNotMet: ctx.MinGWDiversityNotMet,
 	}
 
 	dr, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Band())
@@ -651,6 +762,16 @@ func saveDeviceSession(ctx *dataContext) error {
 	return storage.SaveDeviceSession(ctx.ctx, storage.RedisPool(), ctx.DeviceSession)
 }
 
+// saveUplinkFCnt stores the frame-counter of a dropped uplink, so that the
+// dropped frame can not be replayed.
+func saveUplinkFCnt(ctx *dataContext) error {
+	if err := syncUplinkFCnt(ctx); err != nil {
+		return err
+	}
+
+	return saveDeviceSession(ctx)
+}
+
 func handleUplinkACK(ctx *dataContext) error {
 	if !ctx.MACPayload.FHDR.FCtrl.ACK {
 		return nil
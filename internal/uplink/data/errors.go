@@ -0,0 +1,10 @@
+package data
+
+import "github.com/pkg/errors"
+
+// data errors
+var (
+	ErrAbort             = errors.New("nothing to do")
+	ErrRoamingNotAllowed = errors.New("roaming is not allowed for this device")
+	ErrDropped           = errors.New("uplink frame has been dropped")
+)
@@ -0,0 +1,278 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	datadown "github.com/brocaar/chirpstack-network-server/internal/downlink/data"
+	"github.com/brocaar/chirpstack-network-server/internal/logging"
+	"github.com/brocaar/chirpstack-network-server/internal/models"
+	"github.com/brocaar/chirpstack-network-server/internal/roaming"
+	"github.com/brocaar/chirpstack-network-server/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// roamingSNSTasks contains the tasks for handling uplink data frames
+// received from a fNS (passive-roaming). As the gateways are managed by the
+// fNS, the gateway rx-info is not stored and the downlink is sent to the
+// fNS using a XmitDataReq.
+var roamingSNSTasks = []func(*dataContext) error{
+	setContextFromDataPHYPayload,
+	getDeviceSessionForPHYPayload,
+	decryptFOptsMACCommands,
+	decryptFRMPayloadMACCommands,
+	logUplinkFrame,
+	getDeviceProfile,
+	getServiceProfile,
+	checkPassiveRoaming,
+	checkUplinkRateLimit,
+	getApplicationServerClientForDataUp,
+	checkMinGWDiversity,
+	resolveDeviceLocation,
+	setADR,
+	setUplinkDataRate,
+	setBeaconLocked,
+	sendUplinkMetaDataToNetworkController,
+	handleFOptsMACCommands,
+	handleFRMPayloadMACCommands,
+	appendMetaDataToUplinkHistory,
+	sendFRMPayloadToApplicationServer,
+	syncUplinkFCnt,
+	saveDeviceSession,
+	handleUplinkACK,
+	handleRoamingDownlink,
+}
+
+// HandleRoamingSNS handles an uplink data frame received from the fNS of a
+// roaming partner with the given NetID. The ULMetaData must contain the
+// meta-data as received from the fNS. On success, it returns the updated
+// device-session. It returns ErrDropped when the uplink was dropped
+// because of the service-profile rate-limit or min. gateway diversity.
+func HandleRoamingSNS(ctx context.Context, netID lorawan.NetID, rxPacket models.RXPacket, ulMetaData backend.ULMetaData) (storage.DeviceSession, error) {
+	dctx := dataContext{
+		ctx:               ctx,
+		RXPacket:          rxPacket,
+		RoamingNetID:      netID,
+		RoamingULMetaData: ulMetaData,
+	}
+
+	for _, t := range roamingSNSTasks {
+		if err := t(&dctx); err != nil {
+			if err == ErrAbort {
+				return dctx.DeviceSession, ErrDropped
+			}
+			return dctx.DeviceSession, err
+		}
+	}
+
+	return dctx.DeviceSession, nil
+}
+
+func checkPassiveRoaming(ctx *dataContext) error {
+	if !ctx.ServiceProfile.PRAllowed {
+		return ErrRoamingNotAllowed
+	}
+	return nil
+}
+
+// handleRoamingDownlink handles the downlink response to the fNS. As the
+// fNS is waiting for the answer of the (uplink) request, the downlink is
+// handled asynchronously, using a context which is not bound to the request.
+func handleRoamingDownlink(ctx *dataContext) error {
+	downCtx := context.WithValue(context.Background(), logging.ContextIDKey, ctx.ctx.Value(logging.ContextIDKey))
+
+	go func(ctx context.Context, dctx dataContext) {
+		time.Sleep(getDownlinkDataDelay)
+
+		if err := datadown.HandleRoamingResponse(
+			ctx,
+			dctx.RoamingNetID,
+			dctx.RoamingULMetaData,
+			dctx.RXPacket,
+			dctx.ServiceProfile,
+			dctx.DeviceSession,
+			dctx.MACPayload.FHDR.FCtrl.ADR,
+			dctx.MACPayload.FHDR.FCtrl.ADRACKReq || dctx.MustSendDownlink,
+			dctx.RXPacket.PHYPayload.MHDR.MType == lorawan.ConfirmedDataUp,
+			dctx.MACCommandResponses,
+		); err != nil {
+			log.WithFields(log.Fields{
+				"net_id":  dctx.RoamingNetID,
+				"dev_eui": dctx.DeviceSession.DevEUI,
+				"ctx_id":  ctx.Value(logging.ContextIDKey),
+			}).WithError(err).Error("uplink/data: run passive-roaming response flow error")
+		}
+	}(downCtx, *ctx)
+
+	return nil
+}
+
+// handleRoamingFNS forwards an uplink data frame of a roaming device to the
+// sNS of the roaming partner(s). When a passive-roaming device-session
+// exists, it is forwarded using a XmitDataReq, else a PRStartReq is issued.
+func handleRoamingFNS(ctx context.Context, rxPacket models.RXPacket) error {
+	ulMetaData, err := roaming.RXPacketToULMetaData(ctx, rxPacket)
+	if err != nil {
+		return errors.Wrap(err, "get uplink meta-data error")
+	}
+
+	phyB, err := rxPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	sessions, err := storage.GetPassiveRoamingDeviceSessionsForPHYPayload(ctx, storage.RedisPool(), rxPacket.PHYPayload)
+	if err != nil {
+		return errors.Wrap(err, "get passive-roaming device-sessions error")
+	}
+
+	// The requests to the roaming partners are sent concurrently and the
+	// whole fan-out is bounded by the request timeout, as the sNS must be
+	// able to respond with a downlink within the RX window of the device.
+	if timeout := roaming.RequestTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var wg sync.WaitGroup
+
+	if len(sessions) != 0 {
+		for i := range sessions {
+			wg.Add(1)
+			go func(ds *storage.PassiveRoamingDeviceSession) {
+				defer wg.Done()
+
+				if err := xmitDataReqForPassiveRoamingSession(ctx, ds, phyB, ulMetaData); err != nil {
+					log.WithFields(log.Fields{
+						"net_id":     ds.NetID,
+						"session_id": ds.SessionID,
+						"ctx_id":     ctx.Value(logging.ContextIDKey),
+					}).WithError(err).Error("uplink/data: passive-roaming xmit data request error")
+				}
+			}(&sessions[i])
+		}
+
+		wg.Wait()
+		return nil
+	}
+
+	macPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return errors.New("expected *lorawan.MACPayload")
+	}
+
+	for _, netID := range roaming.GetNetIDsForDevAddr(macPL.FHDR.DevAddr) {
+		wg.Add(1)
+		go func(netID lorawan.NetID) {
+			defer wg.Done()
+
+			if err := prStartReq(ctx, netID, phyB, ulMetaData); err != nil {
+				log.WithFields(log.Fields{
+					"net_id":   netID,
+					"dev_addr": macPL.FHDR.DevAddr,
+					"ctx_id":   ctx.Value(logging.ContextIDKey),
+				}).WithError(err).Error("uplink/data: passive-roaming start request error")
+			}
+		}(netID)
+	}
+
+	wg.Wait()
+	return nil
+}
+
+func xmitDataReqForPassiveRoamingSession(ctx context.Context, ds *storage.PassiveRoamingDeviceSession, phyB []byte, ulMetaData backend.ULMetaData) error {
+	client, err := roaming.GetClientForNetID(ds.NetID)
+	if err != nil {
+		return errors.Wrap(err, "get roaming client error")
+	}
+
+	if _, err := client.XmitDataReq(ctx, backend.XmitDataReqPayload{
+		PHYPayload: backend.HEXBytes(phyB),
+		ULMetaData: &ulMetaData,
+	}); err != nil {
+		return errors.Wrap(err, "xmit data request error")
+	}
+
+	if err := storage.SavePassiveRoamingDeviceSession(ctx, storage.RedisPool(), ds); err != nil {
+		return errors.Wrap(err, "save passive-roaming device-session error")
+	}
+
+	log.WithFields(log.Fields{
+		"net_id":     ds.NetID,
+		"dev_eui":    ds.DevEUI,
+		"session_id": ds.SessionID,
+		"ctx_id":     ctx.Value(logging.ContextIDKey),
+	}).Info("uplink/data: uplink forwarded to roaming sns")
+
+	return nil
+}
+
+func prStartReq(ctx context.Context, netID lorawan.NetID, phyB []byte, ulMetaData backend.ULMetaData) error {
+	client, err := roaming.GetClientForNetID(netID)
+	if err != nil {
+		return errors.Wrap(err, "get roaming client error")
+	}
+
+	ans, err := client.PRStartReq(ctx, backend.PRStartReqPayload{
+		PHYPayload: backend.HEXBytes(phyB),
+		ULMetaData: ulMetaData,
+	})
+	if err != nil {
+		return errors.Wrap(err, "passive-roaming start request error")
+	}
+
+	log.WithFields(log.Fields{
+		"net_id":   netID,
+		"dev_addr": ulMetaData.DevAddr,
+		"ctx_id":   ctx.Value(logging.ContextIDKey),
+	}).Info("uplink/data: passive-roaming started")
+
+	// A lifetime of 0 (or absent) indicates a stateless passive-roaming
+	// session, in which case every uplink results in a PRStartReq.
+	if ans.Lifetime == nil || *ans.Lifetime == 0 {
+		return nil
+	}
+
+	ds := storage.PassiveRoamingDeviceSession{
+		NetID:    netID,
+		Lifetime: time.Now().Add(time.Duration(*ans.Lifetime) * time.Second),
+	}
+
+	if ulMetaData.DevAddr != nil {
+		ds.DevAddr = *ulMetaData.DevAddr
+	}
+
+	if ans.DevEUI != nil {
+		ds.DevEUI = *ans.DevEUI
+	}
+
+	if ans.FCntUp != nil {
+		ds.FCntUp = *ans.FCntUp
+	}
+
+	switch {
+	case ans.FNwkSIntKey != nil:
+		ds.LoRaWAN11 = true
+		ds.FNwkSIntKey, err = roaming.UnwrapKeyEnvelope(ans.FNwkSIntKey)
+	case ans.NwkSKey != nil:
+		ds.FNwkSIntKey, err = roaming.UnwrapKeyEnvelope(ans.NwkSKey)
+	default:
+		// without session-key, the fNS is unable to validate the MIC
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "unwrap key-envelope error")
+	}
+
+	if err := storage.SavePassiveRoamingDeviceSession(ctx, storage.RedisPool(), &ds); err != nil {
+		return errors.Wrap(err, "save passive-roaming device-session error")
+	}
+
+	return nil
+}
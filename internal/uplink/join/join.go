@@ -13,7 +13,6 @@ import (
 
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/backend"
-	loraband "github.com/brocaar/lorawan/band"
 	"github.com/brocaar/chirpstack-network-server/api/nc"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/controller"
 	"github.com/brocaar/chirpstack-network-server/internal/backend/joinserver"
@@ -288,22 +287,20 @@ func createDeviceSession(ctx *joinContext) error {
 		ServiceProfileID: ctx.Device.ServiceProfileID,
 		RoutingProfileID: ctx.Device.RoutingProfileID,
 
-		MACVersion:            ctx.DeviceProfile.MACVersion,
-		DevAddr:               ctx.DevAddr,
-		JoinEUI:               ctx.JoinRequestPayload.JoinEUI,
-		DevEUI:                ctx.JoinRequestPayload.DevEUI,
-		RXWindow:              storage.RX1,
-		RXDelay:               uint8(rx1Delay),
-		RX1DROffset:           uint8(rx1DROffset),
-		RX2DR:                 uint8(rx2DR),
-		RX2Frequency:          band.Band().GetDefaults().RX2Frequency,
-		EnabledUplinkChannels: band.Band().GetStandardUplinkChannelIndices(),
-		ExtraUplinkChannels:   make(map[int]loraband.Channel),
-		SkipFCntValidation:    ctx.Device.SkipFCntCheck,
-		PingSlotDR:            ctx.DeviceProfile.PingSlotDR,
-		PingSlotFrequency:     int(ctx.DeviceProfile.PingSlotFreq),
-		NbTrans:               1,
-		ReferenceAltitude:     ctx.Device.ReferenceAltitude,
+		MACVersion:         ctx.DeviceProfile.MACVersion,
+		DevAddr:            ctx.DevAddr,
+		JoinEUI:            ctx.JoinRequestPayload.JoinEUI,
+		DevEUI:             ctx.JoinRequestPayload.DevEUI,
+		RXWindow:           storage.RX1,
+		RXDelay:            uint8(rx1Delay),
+		RX1DROffset:        uint8(rx1DROffset),
+		RX2DR:              uint8(rx2DR),
+		RX2Frequency:       band.Band().GetDefaults().RX2Frequency,
+		SkipFCntValidation: ctx.Device.SkipFCntCheck,
+		PingSlotDR:         ctx.DeviceProfile.PingSlotDR,
+		PingSlotFrequency:  int(ctx.DeviceProfile.PingSlotFreq),
+		NbTrans:            1,
+		ReferenceAltitude:  ctx.Device.ReferenceAltitude,
 	}
 
 	if ctx.JoinAnsPayload.AppSKey != nil {
@@ -351,6 +348,10 @@ func createDeviceSession(ctx *joinContext) error {
 		ds.NwkSEncKey = key
 	}
 
+	// in case the device-profile defines factory preset frequencies, use
+	// these as the initial channel-plan of the device
+	ds.SetFactoryPresetChannels(ctx.DeviceProfile.FactoryPresetFreqs)
+
 	if cfList := band.Band().GetCFList(ctx.DeviceProfile.MACVersion); cfList != nil && cfList.CFListType == lorawan.CFListChannel {
 		channelPL, ok := cfList.Payload.(*lorawan.CFListChannelPayload)
 		if !ok {
@@ -371,8 +372,11 @@ func createDeviceSession(ctx *joinContext) error {
 				continue
 			}
 
-			// add extra channel to enabled channels
-			ds.EnabledUplinkChannels = append(ds.EnabledUplinkChannels, i)
+			// add extra channel to enabled channels (in case not already
+			// enabled by the factory preset frequencies)
+			if _, ok := ds.ExtraUplinkChannels[i]; !ok {
+				ds.EnabledUplinkChannels = append(ds.EnabledUplinkChannels, i)
+			}
 
 			// add extra channel to extra uplink channels, so that we can
 			// keep track on frequency and data-rate changes
@@ -17,6 +17,7 @@ import (
 	"github.com/brocaar/chirpstack-network-server/internal/downlink/ack"
 	"github.com/brocaar/chirpstack-network-server/internal/framelog"
 	"github.com/brocaar/chirpstack-network-server/internal/gateway"
+	"github.com/brocaar/chirpstack-network-server/internal/gateway/monitor"
 	"github.com/brocaar/chirpstack-network-server/internal/helpers"
 	"github.com/brocaar/chirpstack-network-server/internal/logging"
 	"github.com/brocaar/chirpstack-network-server/internal/models"
@@ -74,6 +75,18 @@ func (s *Server) Start() error {
 		defer s.wg.Done()
 		HandleDownlinkTXAcks(&s.wg)
 	}()
+
+	go func() {
+		s.wg.Add(1)
+		defer s.wg.Done()
+		HandleGatewayCommandExecResponses(&s.wg)
+	}()
+
+	go func() {
+		s.wg.Add(1)
+		defer s.wg.Done()
+		HandleConnStates(&s.wg)
+	}()
 	return nil
 }
 
@@ -152,6 +165,54 @@ func HandleDownlinkTXAcks(wg *sync.WaitGroup) {
 	}
 }
 
+// HandleGatewayCommandExecResponses consumes received gateway command
+// execution responses from the gateway.
+func HandleGatewayCommandExecResponses(wg *sync.WaitGroup) {
+	for resp := range gwbackend.Backend().GatewayCommandExecResponseChan() {
+		go func(resp gw.GatewayCommandExecResponse) {
+			wg.Add(1)
+			defer wg.Done()
+
+			execID := helpers.GetExecID(&resp)
+
+			ctx := context.Background()
+			ctx = context.WithValue(ctx, logging.ContextIDKey, execID)
+
+			if err := gateway.HandleGatewayCommandExecResponse(ctx, storage.RedisPool(), resp); err != nil {
+				log.WithFields(log.Fields{
+					"gateway_id": hex.EncodeToString(resp.GatewayId),
+					"exec_id":    execID,
+				}).WithError(err).Error("uplink: handle gateway command execution response error")
+			}
+		}(resp)
+	}
+}
+
+// HandleConnStates consumes received gateway connection-states.
+func HandleConnStates(wg *sync.WaitGroup) {
+	for connState := range gwbackend.Backend().ConnStateChan() {
+		go func(connState gw.ConnState) {
+			wg.Add(1)
+			defer wg.Done()
+
+			ctxID, err := uuid.NewV4()
+			if err != nil {
+				log.WithError(err).Error("uplink: get new uuid error")
+			}
+
+			ctx := context.Background()
+			ctx = context.WithValue(ctx, logging.ContextIDKey, ctxID)
+
+			if err := monitor.HandleConnState(ctx, connState); err != nil {
+				log.WithFields(log.Fields{
+					"gateway_id": hex.EncodeToString(connState.GatewayId),
+					"ctx_id":     ctxID,
+				}).WithError(err).Error("uplink: handle gateway connection-state error")
+			}
+		}(connState)
+	}
+}
+
 func collectUplinkFrames(ctx context.Context, uplinkFrame gw.UplinkFrame) error {
 	return collectAndCallOnce(storage.RedisPool(), uplinkFrame, func(rxPacket models.RXPacket) error {
 		var uplinkIDs []uuid.UUID
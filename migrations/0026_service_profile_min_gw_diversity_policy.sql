@@ -0,0 +1,10 @@
+-- +migrate Up
+alter table service_profile
+    add column min_gw_diversity_policy char(4) not null default 'Mark';
+
+alter table service_profile
+    alter column min_gw_diversity_policy drop default;
+
+-- +migrate Down
+alter table service_profile
+    drop column min_gw_diversity_policy;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table gateway
+    add column backend varchar(20) not null default '';
+
+-- +migrate Down
+alter table gateway
+    drop column backend;
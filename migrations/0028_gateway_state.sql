@@ -0,0 +1,34 @@
+-- +migrate Up
+alter table gateway_profile
+    add column stats_interval bigint not null default 0;
+
+alter table gateway
+    add column state varchar(10) not null default '',
+    add column state_changed_at timestamp with time zone null;
+
+create index idx_gateway_state on gateway(state);
+
+create table gateway_state_change (
+    id bigserial primary key,
+    gateway_id bytea not null references gateway on delete cascade,
+    created_at timestamp with time zone not null,
+    state varchar(10) not null
+);
+
+create index idx_gateway_state_change_gateway_id on gateway_state_change(gateway_id);
+create index idx_gateway_state_change_created_at on gateway_state_change(created_at);
+
+-- +migrate Down
+drop index idx_gateway_state_change_created_at;
+drop index idx_gateway_state_change_gateway_id;
+
+drop table gateway_state_change;
+
+drop index idx_gateway_state;
+
+alter table gateway
+    drop column state_changed_at,
+    drop column state;
+
+alter table gateway_profile
+    drop column stats_interval;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table gateway_profile
+    add column beacon_enabled boolean not null default false;
+
+-- +migrate Down
+alter table gateway_profile
+    drop column beacon_enabled;
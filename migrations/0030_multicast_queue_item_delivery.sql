@@ -0,0 +1,18 @@
+-- +migrate Up
+create table multicast_queue_item_delivery (
+    id bigserial primary key,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    multicast_group_id uuid not null references multicast_group on delete cascade,
+    f_cnt bigint not null,
+    gateway_id bytea not null references gateway on delete cascade,
+    status varchar(10) not null,
+    error varchar(50) not null default ''
+);
+
+create unique index idx_multicast_queue_item_delivery_mg_f_cnt_gw on multicast_queue_item_delivery(multicast_group_id, f_cnt, gateway_id);
+
+-- +migrate Down
+drop index idx_multicast_queue_item_delivery_mg_f_cnt_gw;
+
+drop table multicast_queue_item_delivery;
@@ -0,0 +1,21 @@
+-- +migrate Up
+create table device_multicast_group_setup (
+    dev_eui bytea not null,
+    multicast_group_id uuid not null,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    mc_group_id smallint not null,
+    session_time bigint not null,
+    state varchar(20) not null,
+    error varchar(50) not null default '',
+
+    primary key(multicast_group_id, dev_eui),
+    foreign key(multicast_group_id, dev_eui) references device_multicast_group on delete cascade
+);
+
+create index idx_device_multicast_group_setup_dev_eui_mc_group_id on device_multicast_group_setup(dev_eui, mc_group_id);
+
+-- +migrate Down
+drop index idx_device_multicast_group_setup_dev_eui_mc_group_id;
+
+drop table device_multicast_group_setup;
@@ -0,0 +1,23 @@
+-- +migrate Up
+create table device_multicast_frag_session (
+    dev_eui bytea not null,
+    multicast_group_id uuid not null,
+    frag_index smallint not null,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    nb_frag integer not null,
+    state varchar(20) not null,
+    error varchar(50) not null default '',
+    nb_frag_received integer not null default 0,
+    missing_frag smallint not null default 0,
+
+    primary key(multicast_group_id, dev_eui, frag_index),
+    foreign key(multicast_group_id, dev_eui) references device_multicast_group on delete cascade
+);
+
+create index idx_device_multicast_frag_session_dev_eui_frag_index on device_multicast_frag_session(dev_eui, frag_index);
+
+-- +migrate Down
+drop index idx_device_multicast_frag_session_dev_eui_frag_index;
+
+drop table device_multicast_frag_session;
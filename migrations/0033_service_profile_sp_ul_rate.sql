@@ -0,0 +1,13 @@
+-- +migrate Up
+alter table service_profile
+    add column sp_ul_rate bigint not null default 0,
+    add column sp_ul_bucket_size bigint not null default 0;
+
+alter table service_profile
+    alter column sp_ul_rate drop default,
+    alter column sp_ul_bucket_size drop default;
+
+-- +migrate Down
+alter table service_profile
+    drop column sp_ul_bucket_size,
+    drop column sp_ul_rate;
@@ -0,0 +1,5 @@
+-- +migrate Up
+create index idx_multicast_queue_item_delivery_updated_at on multicast_queue_item_delivery(updated_at);
+
+-- +migrate Down
+drop index idx_multicast_queue_item_delivery_updated_at;